@@ -5,8 +5,14 @@ import (
 	"fmt"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"homework-1/config"
+	"homework-1/internal/alerts"
+	"homework-1/internal/approvals"
+	localBlobStore "homework-1/internal/blobstore/local"
 	"homework-1/internal/commander"
+	"homework-1/internal/gallery"
 	"homework-1/internal/handlers"
+	"homework-1/internal/models/relations"
+	"homework-1/internal/policy"
 	postgresRepository "homework-1/internal/repository/postgres"
 	"log"
 	"os"
@@ -46,12 +52,56 @@ func main() {
 	poolConfig.MinConns = config.DBMinConns
 	poolConfig.MaxConns = config.DBMaxConns
 
-	cmd, err := commander.Init(tgApiKey, postgresRepository.NewRepository(pool))
+	repository := postgresRepository.NewRepository(pool)
+
+	cmd, err := commander.Init(tgApiKey, repository)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	handlers.AddHandlers(cmd)
+	blobStore, err := localBlobStore.New(config.BlobStoreRoot)
+	if err != nil {
+		log.Fatal("can't open blob store", err)
+	}
+
+	relationPolicy, err := relations.ParseDeletePolicy(config.RelationDeletePolicy)
+	if err != nil {
+		log.Fatal("invalid relation delete policy", err)
+	}
+
+	userRoles, err := policy.LoadUserRoles(config.BotUsersFile, config.BotDefaultRole)
+	if err != nil {
+		log.Fatal("can't load bot users", err)
+	}
+
+	handlers.AddHandlers(cmd, handlers.Deps{
+		ChangeRepository: repository,
+		ApprovalService: &approvals.Service{
+			ProductRepository:     repository,
+			PriceChangeRepository: repository,
+			Threshold:             config.PriceChangeApprovalThreshold,
+		},
+		StockRepository:       repository,
+		StocktakeRepository:   repository,
+		ReportRepository:      repository,
+		TranslationRepository: repository,
+		ImageService: &gallery.Service{
+			ProductRepository:  repository,
+			ImageRepository:    repository,
+			BlobStore:          blobStore,
+			RelationRepository: repository,
+			RelationPolicy:     relationPolicy,
+		},
+		UserRoles:       userRoles,
+		GrantRepository: repository,
+	})
+
+	lowStockAlertConsumer := &alerts.LowStockAlertConsumer{
+		Repository: repository,
+		Notifier:   cmd,
+		Topic:      config.LowStockAlertTopic,
+	}
+	go lowStockAlertConsumer.StartConsuming(ctx)
 
 	if err = cmd.Run(); err != nil {
 		log.Fatal(err)
@@ -3,22 +3,32 @@ package main
 import (
 	"context"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 	"homework-1/config"
+	"homework-1/internal/auth"
+	"homework-1/internal/certs"
+	"homework-1/internal/tenants"
 	pb "homework-1/pkg/api/v1"
 	"log"
+	"os"
 )
 
 // Test GRPC client
 func main() {
-	conn, err := grpc.Dial(config.ProxyApiServiceAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	transport, err := certs.NewTransport(config.TLSEnabled, certs.Files{CA: config.TLSCAFile, Cert: config.TLSCertFile, Key: config.TLSKeyFile}, config.TLSReloadInterval)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	conn, err := grpc.Dial(config.ProxyApiServiceAddress, transport.DialOption(config.ProxyApiServiceAddress))
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	client := pb.NewApiServiceClient(conn)
 
-	ctx := context.Background()
+	ctx := tenants.AppendToOutgoingContext(context.Background(), tenants.Default)
+	ctx = metadata.AppendToOutgoingContext(ctx, auth.APIKeyMetadataKey, os.Getenv("API_KEY"))
 	productId := uint64(1)
 	pageNum := uint64(0)
 	pageSize := uint64(4)
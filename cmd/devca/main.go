@@ -0,0 +1,32 @@
+package main
+
+import (
+	"flag"
+	"homework-1/config"
+	"homework-1/internal/auth"
+	"homework-1/internal/certs"
+	"log"
+	"path/filepath"
+	"strings"
+)
+
+// Generates a dev CA and the certificate the services use for mutual TLS, and the key
+// forwarded identities are signed with. Running it again keeps the CA and the key and
+// renews the certificate, the services reload it.
+func main() {
+	dir := flag.String("dir", filepath.Dir(config.TLSCertFile), "directory to write the certificates to")
+	forwardingKey := flag.String("forwarding-key", config.ForwardingKeyFile, "file to write the forwarding key to")
+	hosts := flag.String("hosts", "localhost,127.0.0.1,::1", "comma separated host names and IPs of the certificate")
+	flag.Parse()
+
+	files, err := certs.GenerateDevCA(*dir, strings.Split(*hosts, ","))
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("CA: %s, certificate: %s, key: %s", files.CA, files.Cert, files.Key)
+
+	if err = auth.GenerateForwardingKey(*forwardingKey); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("forwarding key: %s", *forwardingKey)
+}
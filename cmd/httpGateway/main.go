@@ -2,17 +2,36 @@ package main
 
 import (
 	"context"
+	"github.com/go-redis/redis/v9"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"homework-1/config"
+	"homework-1/internal/auth"
+	"homework-1/internal/certs"
+	"homework-1/internal/money"
+	"homework-1/internal/ratelimit"
+	"homework-1/internal/ratelimit/setup"
+	"homework-1/internal/tenants"
 	gw "homework-1/pkg/api/v1"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"net/textproto"
+	"strconv"
 )
 
+// forwardedHeaders maps the headers that carry the tenant and the credentials of a request
+// to gRPC metadata, the default matcher would drop them. Authorization is passed by the gateway itself.
+var forwardedHeaders = map[string]string{
+	"X-Tenant-Id": tenants.MetadataKey,
+	"X-Api-Key":   auth.APIKeyMetadataKey,
+}
+
 func main() {
 	if err := run(); err != nil {
 		log.Fatal(err)
@@ -24,7 +43,11 @@ func run() error {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	mux := runtime.NewServeMux()
+	mux := runtime.NewServeMux(
+		runtime.WithMetadata(currencyMetadata),
+		runtime.WithIncomingHeaderMatcher(headerMatcher),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher),
+	)
 
 	// init swagger
 	err := mux.HandlePath("GET", "/swagger.json", swaggerHandler)
@@ -32,14 +55,98 @@ func run() error {
 		return errors.Wrap(err, "Can't init swagger handler")
 	}
 
+	transport, err := certs.NewTransport(config.TLSEnabled, certs.Files{CA: config.TLSCAFile, Cert: config.TLSCertFile, Key: config.TLSKeyFile}, config.TLSReloadInterval)
+	if err != nil {
+		return errors.Wrap(err, "Can't load TLS certificates")
+	}
+
 	// init grpc gateway
-	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	opts := []grpc.DialOption{transport.DialOption(config.ProxyApiServiceAddress)}
 	err = gw.RegisterApiServiceHandlerFromEndpoint(ctx, mux, config.ProxyApiServiceAddress, opts)
 	if err != nil {
 		return errors.Wrap(err, "Can't init grpc gateway")
 	}
 
-	return http.ListenAndServe(config.HTTPGatewayServiceAddress, mux)
+	// images are served as raw content, which the generated gateway handlers can't stream
+	conn, err := grpc.DialContext(ctx, config.ProxyApiServiceAddress, opts...)
+	if err != nil {
+		return errors.Wrap(err, "Can't dial api service")
+	}
+	defer conn.Close()
+
+	err = mux.HandlePath("GET", "/api/v1/users/{product_id}/images/{id}", imageHandler(gw.NewApiServiceClient(conn)))
+	if err != nil {
+		return errors.Wrap(err, "Can't init image handler")
+	}
+
+	// the only endpoint served without credentials
+	err = mux.HandlePath("GET", "/healthz", healthHandler(healthpb.NewHealthClient(conn)))
+	if err != nil {
+		return errors.Wrap(err, "Can't init health handler")
+	}
+
+	redisClient := redis.NewClient(config.GetRedisOpts())
+	defer redisClient.Close()
+
+	limiter, err := setup.FromConfig(redisClient)
+	if err != nil {
+		return errors.Wrap(err, "Can't init rate limiting")
+	}
+
+	return http.ListenAndServe(config.HTTPGatewayServiceAddress, ratelimit.Middleware(limiter, mux, "/healthz"))
+}
+
+// currencyMetadata passes the currency query parameter to the api service, which converts prices into it.
+func currencyMetadata(_ context.Context, r *http.Request) metadata.MD {
+	if currency := r.URL.Query().Get("currency"); currency != "" {
+		return metadata.Pairs(money.MetadataKey, currency)
+	}
+	return nil
+}
+
+func headerMatcher(key string) (string, bool) {
+	if metadataKey, ok := forwardedHeaders[textproto.CanonicalMIMEHeaderKey(key)]; ok {
+		return metadataKey, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// outgoingHeaderMatcher passes the retry-after of a rate limited call on as the standard header.
+func outgoingHeaderMatcher(key string) (string, bool) {
+	if key == ratelimit.RetryAfterMetadataKey {
+		return "Retry-After", true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
+// forwardedMetadata is headerMatcher for the handlers that call the api service directly.
+func forwardedMetadata(r *http.Request) metadata.MD {
+	md := metadata.MD{}
+	for header, key := range forwardedHeaders {
+		if value := r.Header.Get(header); value != "" {
+			md.Set(key, value)
+		}
+	}
+	if value := r.Header.Get("Authorization"); value != "" {
+		md.Set(auth.AuthorizationMetadataKey, value)
+	}
+	return md
+}
+
+// healthHandler reports the health of the api service behind the gateway.
+func healthHandler(client healthpb.HealthClient) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		response, err := client.Check(r.Context(), &healthpb.HealthCheckRequest{})
+		if err != nil {
+			writeStatusError(w, err)
+			return
+		}
+		if response.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			http.Error(w, response.GetStatus().String(), http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}
 }
 
 func swaggerHandler(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
@@ -56,3 +163,56 @@ func swaggerHandler(w http.ResponseWriter, _ *http.Request, _ map[string]string)
 	}
 	return
 }
+
+func imageHandler(client gw.ApiServiceClient) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		productId, err := strconv.ParseUint(params["product_id"], 10, 64)
+		if err != nil {
+			http.Error(w, "invalid product id", http.StatusBadRequest)
+			return
+		}
+		id, err := strconv.ParseUint(params["id"], 10, 64)
+		if err != nil {
+			http.Error(w, "invalid image id", http.StatusBadRequest)
+			return
+		}
+
+		ctx := metadata.NewOutgoingContext(r.Context(), forwardedMetadata(r))
+		download, err := client.DownloadProductImage(ctx, &gw.DownloadProductImageRequest{ProductId: productId, Id: id})
+		if err != nil {
+			writeStatusError(w, err)
+			return
+		}
+		// the first message carries the image, the chunks follow
+		first, err := download.Recv()
+		if err != nil {
+			writeStatusError(w, err)
+			return
+		}
+		image := first.GetImage()
+		w.Header().Set("Content-Type", image.GetContentType())
+		w.Header().Set("Content-Length", strconv.FormatUint(image.GetSize(), 10))
+		w.Header().Set("ETag", strconv.Quote(image.GetChecksum()))
+
+		for {
+			item, err := download.Recv()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				// the headers are already sent, the client sees a short body
+				log.WithError(err).Error("imageHandler: receive")
+				return
+			}
+			if _, err = w.Write(item.GetChunk()); err != nil {
+				log.WithError(err).Error("imageHandler: write")
+				return
+			}
+		}
+	}
+}
+
+func writeStatusError(w http.ResponseWriter, err error) {
+	s := status.Convert(err)
+	http.Error(w, s.Message(), runtime.HTTPStatusFromCode(s.Code()))
+}
@@ -3,17 +3,31 @@ package main
 import (
 	"context"
 	"fmt"
+	"github.com/Shopify/sarama"
+	"github.com/go-redis/redis/v9"
 	"github.com/jackc/pgx/v4/pgxpool"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/Shopify/sarama/otelsarama"
 	"google.golang.org/grpc"
 	"homework-1/config"
+	"homework-1/internal/alerts"
 	"homework-1/internal/api/kafkaStorage"
 	"homework-1/internal/api/kafkaStorage/consumers"
+	"homework-1/internal/approvals"
+	"homework-1/internal/auth"
+	"homework-1/internal/blobstore"
+	localBlobStore "homework-1/internal/blobstore/local"
 	redisCache "homework-1/internal/cache/redis"
+	"homework-1/internal/certs"
+	"homework-1/internal/gallery"
+	"homework-1/internal/health"
+	"homework-1/internal/interceptors"
 	"homework-1/internal/metrics"
+	"homework-1/internal/models/relations"
 	"homework-1/internal/opentelemetry"
-	"homework-1/internal/repository"
+	"homework-1/internal/policy"
 	postgresRepository "homework-1/internal/repository/postgres"
+	"homework-1/internal/tenants"
 	pbStorage "homework-1/pkg/api/storage/v2"
 	"net"
 	"net/http"
@@ -57,14 +71,44 @@ func main() {
 	poolConfig.MinConns = config.DBMinConns
 	poolConfig.MaxConns = config.DBMaxConns
 
-	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(opentelemetry.UnaryServerInterceptor()),
-		grpc.StreamInterceptor(opentelemetry.StreamServerInterceptor()),
-	)
+	forwardingKey, err := auth.LoadForwardingKey(config.ForwardingKeyFile)
+	if err != nil {
+		log.WithError(err).Fatal("failed to load forwarding key")
+	}
+	forwarded := auth.Forwarded{Key: forwardingKey}
+
+	transport, err := certs.NewTransport(config.TLSEnabled, certs.Files{CA: config.TLSCAFile, Cert: config.TLSCertFile, Key: config.TLSKeyFile}, config.TLSReloadInterval)
+	if err != nil {
+		log.WithError(err).Fatal("failed to load TLS certificates")
+	}
 
 	appMetrics := metrics.NewMetrics()
+	appMetrics.Consumers = metrics.NewConsumers()
 	appMetrics.Publish()
 
+	callDeps := interceptors.Deps{
+		Metrics:  appMetrics,
+		Redactor: interceptors.NewRedactor(config.GetLogRedactedMetadata(), config.GetLogRedactedFields()),
+	}
+	grpcServer := grpc.NewServer(
+		transport.ServerOption(),
+		grpc.ChainUnaryInterceptor(
+			opentelemetry.UnaryServerInterceptor(),
+			interceptors.UnaryServerInterceptor(callDeps),
+			auth.UnaryServerInterceptor(forwarded),
+			policy.UnaryServerInterceptor(),
+			tenants.UnaryServerInterceptor(),
+		),
+		grpc.ChainStreamInterceptor(
+			opentelemetry.StreamServerInterceptor(),
+			interceptors.StreamServerInterceptor(callDeps),
+			auth.StreamServerInterceptor(forwarded),
+			policy.StreamServerInterceptor(),
+			tenants.StreamServerInterceptor(),
+		),
+	)
+	health.Register(grpcServer)
+
 	go func() {
 		log.Infof("starting metrics http server on %s", config.StorageStatAddress)
 		if err = http.ListenAndServe(config.StorageStatAddress, nil); err != nil {
@@ -72,13 +116,48 @@ func main() {
 		}
 	}()
 
-	cache := redisCache.New(config.GetRedisOpts(), appMetrics)
+	redisClient := redis.NewClient(config.GetRedisOpts())
+	defer redisClient.Close()
+	cache := tenants.NewCache(redisCache.New(redisClient, appMetrics))
+
+	blobStore, err := localBlobStore.New(config.BlobStoreRoot)
+	if err != nil {
+		log.WithError(err).Fatal("failed to open blob store")
+	}
 
-	runStorageKafkaConsumers(postgresRepository.NewRepository(pool), appMetrics, cache)
+	relationPolicy, err := relations.ParseDeletePolicy(config.RelationDeletePolicy)
+	if err != nil {
+		log.WithError(err).Fatal("invalid relation delete policy")
+	}
+
+	runStorageKafkaConsumers(postgresRepository.NewRepository(pool), blobStore, relationPolicy, appMetrics, cache)
+
+	offsets, err := sarama.NewClient(config.GetKafkaBrokers(), sarama.NewConfig())
+	if err != nil {
+		log.WithError(err).Fatal("kafka: NewClient")
+	}
+	defer offsets.Close()
+	go appMetrics.Consumers.WatchHighWaterMarks(ctx, offsets, config.ConsumerLagRefreshInterval)
+
+	cfg := sarama.NewConfig()
+	cfg.Producer.Return.Successes = true
+	syncProducer, err := sarama.NewSyncProducer(config.GetKafkaBrokers(), cfg)
+	if err != nil {
+		log.WithError(err).Fatal("kafka: NewSyncProducer")
+	}
+	syncProducer = otelsarama.WrapSyncProducer(cfg, syncProducer)
+
+	lowStockChecker := &alerts.LowStockChecker{
+		Repository: postgresRepository.NewRepository(pool),
+		Producer:   syncProducer,
+		Topic:      config.LowStockAlertTopic,
+		Interval:   config.LowStockCheckInterval,
+	}
+	// alerts go to the chats of the bot, which manages a single shop
+	go lowStockChecker.Run(tenants.NewContext(ctx, config.BotTenant))
 
 	deps := kafkaStorage.Deps{
 		ProductRepository: postgresRepository.NewRepository(pool),
-		Metrics:           appMetrics,
 		Cache:             cache,
 	}
 
@@ -104,7 +183,7 @@ func SetUpLogger() {
 	}
 }
 
-func runStorageKafkaConsumers(productRepository repository.Product, appMetrics *metrics.Metrics, cache *redisCache.Cache) {
+func runStorageKafkaConsumers(productRepository *postgresRepository.Repository, blobStore blobstore.BlobStore, relationPolicy relations.DeletePolicy, appMetrics *metrics.Metrics, cache *tenants.Cache) {
 	productCreateConsumer := &consumers.ProductCreateConsumer{
 		ProductRepository: productRepository,
 		Metrics:           appMetrics,
@@ -114,15 +193,26 @@ func runStorageKafkaConsumers(productRepository repository.Product, appMetrics *
 
 	productUpdateConsumer := &consumers.ProductUpdateConsumer{
 		ProductRepository: productRepository,
-		Metrics:           appMetrics,
-		Cache:             cache,
+		ApprovalService: &approvals.Service{
+			ProductRepository:     productRepository,
+			PriceChangeRepository: productRepository,
+			Threshold:             config.PriceChangeApprovalThreshold,
+		},
+		Metrics: appMetrics,
+		Cache:   cache,
 	}
 	go productUpdateConsumer.StartConsuming(context.Background())
 
 	productDeleteConsumer := &consumers.ProductDeleteConsumer{
-		ProductRepository: productRepository,
-		Metrics:           appMetrics,
-		Cache:             cache,
+		ImageService: &gallery.Service{
+			ProductRepository:  productRepository,
+			ImageRepository:    productRepository,
+			BlobStore:          blobStore,
+			RelationRepository: productRepository,
+			RelationPolicy:     relationPolicy,
+		},
+		Metrics: appMetrics,
+		Cache:   cache,
 	}
 	go productDeleteConsumer.StartConsuming(context.Background())
 }
@@ -1,13 +1,21 @@
 package main
 
 import (
+	"github.com/go-redis/redis/v9"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 	"homework-1/config"
 	"homework-1/internal/api/proxyApi"
+	"homework-1/internal/auth"
+	"homework-1/internal/certs"
+	"homework-1/internal/health"
+	"homework-1/internal/interceptors"
 	"homework-1/internal/metrics"
 	"homework-1/internal/opentelemetry"
+	"homework-1/internal/policy"
+	"homework-1/internal/ratelimit"
+	"homework-1/internal/ratelimit/setup"
+	"homework-1/internal/tenants"
 	pbStorage "homework-1/pkg/api/storage/v1"
 	pbApi "homework-1/pkg/api/v1"
 	"net"
@@ -23,16 +31,71 @@ func main() {
 		log.WithError(err).Fatal("failed to create tracer")
 	}
 
+	authenticator, err := auth.Load(config.APIKeysFile, config.JWTKeySetFile, config.JWTIssuer, config.JWTAudience)
+	if err != nil {
+		log.WithError(err).Fatal("failed to load credentials")
+	}
+	forwardingKey, err := auth.LoadForwardingKey(config.ForwardingKeyFile)
+	if err != nil {
+		log.WithError(err).Fatal("failed to load forwarding key")
+	}
+
+	redisClient := redis.NewClient(config.GetRedisOpts())
+	defer redisClient.Close()
+
+	limiter, err := setup.FromConfig(redisClient)
+	if err != nil {
+		log.WithError(err).Fatal("failed to set up rate limiting")
+	}
+
+	transport, err := certs.NewTransport(config.TLSEnabled, certs.Files{CA: config.TLSCAFile, Cert: config.TLSCertFile, Key: config.TLSKeyFile}, config.TLSReloadInterval)
+	if err != nil {
+		log.WithError(err).Fatal("failed to load TLS certificates")
+	}
+
+	appMetrics := metrics.NewMetrics()
+	appMetrics.Publish()
+
+	callDeps := interceptors.Deps{
+		Metrics:  appMetrics,
+		Redactor: interceptors.NewRedactor(config.GetLogRedactedMetadata(), config.GetLogRedactedFields()),
+	}
 	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(opentelemetry.UnaryServerInterceptor()),
-		grpc.StreamInterceptor(opentelemetry.StreamServerInterceptor()),
+		transport.ServerOption(),
+		grpc.ChainUnaryInterceptor(
+			opentelemetry.UnaryServerInterceptor(),
+			interceptors.UnaryServerInterceptor(callDeps),
+			auth.UnaryServerInterceptor(authenticator),
+			ratelimit.UnaryServerInterceptor(limiter),
+			policy.UnaryServerInterceptor(),
+			tenants.UnaryServerInterceptor(),
+		),
+		grpc.ChainStreamInterceptor(
+			opentelemetry.StreamServerInterceptor(),
+			interceptors.StreamServerInterceptor(callDeps),
+			auth.StreamServerInterceptor(authenticator),
+			ratelimit.StreamServerInterceptor(limiter),
+			policy.StreamServerInterceptor(),
+			tenants.StreamServerInterceptor(),
+		),
 	)
+	health.Register(grpcServer)
 
 	conn, err := grpc.Dial(
 		config.StorageServiceAddress,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithUnaryInterceptor(opentelemetry.UnaryClientInterceptor()),
-		grpc.WithStreamInterceptor(opentelemetry.StreamClientInterceptor()),
+		transport.DialOption(config.StorageServiceAddress),
+		grpc.WithChainUnaryInterceptor(
+			opentelemetry.UnaryClientInterceptor(),
+			interceptors.UnaryClientInterceptor(appMetrics),
+			auth.UnaryClientInterceptor(forwardingKey),
+			tenants.UnaryClientInterceptor(),
+		),
+		grpc.WithChainStreamInterceptor(
+			opentelemetry.StreamClientInterceptor(),
+			interceptors.StreamClientInterceptor(appMetrics),
+			auth.StreamClientInterceptor(forwardingKey),
+			tenants.StreamClientInterceptor(),
+		),
 	)
 	if err != nil {
 		log.WithError(err).Fatal("failed to connect to storage service")
@@ -40,9 +103,6 @@ func main() {
 
 	client := pbStorage.NewStorageServiceClient(conn)
 
-	appMetrics := metrics.NewMetrics()
-	appMetrics.Publish()
-
 	go func() {
 		log.Infof("starting metrics http server on %s", config.ProxyApiStatAddress)
 		if err = http.ListenAndServe(config.ProxyApiStatAddress, nil); err != nil {
@@ -52,7 +112,6 @@ func main() {
 
 	deps := proxyApi.Deps{
 		StorageClient: client,
-		Metrics:       appMetrics,
 	}
 	pbApi.RegisterApiServiceServer(grpcServer, proxyApi.New(deps))
 
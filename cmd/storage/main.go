@@ -3,14 +3,29 @@ package main
 import (
 	"context"
 	"fmt"
+	"github.com/Shopify/sarama"
 	"github.com/jackc/pgx/v4/pgxpool"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/Shopify/sarama/otelsarama"
 	"google.golang.org/grpc"
 	"homework-1/config"
+	"homework-1/internal/alerts"
 	"homework-1/internal/api/storage"
+	"homework-1/internal/approvals"
+	"homework-1/internal/auth"
+	localBlobStore "homework-1/internal/blobstore/local"
+	"homework-1/internal/certs"
+	"homework-1/internal/gallery"
+	"homework-1/internal/health"
+	"homework-1/internal/interceptors"
 	"homework-1/internal/metrics"
+	"homework-1/internal/models/attributes"
+	"homework-1/internal/models/relations"
 	"homework-1/internal/opentelemetry"
+	"homework-1/internal/ordering"
+	"homework-1/internal/policy"
 	postgresRepository "homework-1/internal/repository/postgres"
+	"homework-1/internal/tenants"
 	pbStorage "homework-1/pkg/api/storage/v1"
 	"net"
 	"net/http"
@@ -54,14 +69,43 @@ func main() {
 	poolConfig.MinConns = config.DBMinConns
 	poolConfig.MaxConns = config.DBMaxConns
 
-	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(opentelemetry.UnaryServerInterceptor()),
-		grpc.StreamInterceptor(opentelemetry.StreamServerInterceptor()),
-	)
+	forwardingKey, err := auth.LoadForwardingKey(config.ForwardingKeyFile)
+	if err != nil {
+		log.WithError(err).Fatal("failed to load forwarding key")
+	}
+	forwarded := auth.Forwarded{Key: forwardingKey}
+
+	transport, err := certs.NewTransport(config.TLSEnabled, certs.Files{CA: config.TLSCAFile, Cert: config.TLSCertFile, Key: config.TLSKeyFile}, config.TLSReloadInterval)
+	if err != nil {
+		log.WithError(err).Fatal("failed to load TLS certificates")
+	}
 
 	appMetrics := metrics.NewMetrics()
 	appMetrics.Publish()
 
+	callDeps := interceptors.Deps{
+		Metrics:  appMetrics,
+		Redactor: interceptors.NewRedactor(config.GetLogRedactedMetadata(), config.GetLogRedactedFields()),
+	}
+	grpcServer := grpc.NewServer(
+		transport.ServerOption(),
+		grpc.ChainUnaryInterceptor(
+			opentelemetry.UnaryServerInterceptor(),
+			interceptors.UnaryServerInterceptor(callDeps),
+			auth.UnaryServerInterceptor(forwarded),
+			policy.UnaryServerInterceptor(),
+			tenants.UnaryServerInterceptor(),
+		),
+		grpc.ChainStreamInterceptor(
+			opentelemetry.StreamServerInterceptor(),
+			interceptors.StreamServerInterceptor(callDeps),
+			auth.StreamServerInterceptor(forwarded),
+			policy.StreamServerInterceptor(),
+			tenants.StreamServerInterceptor(),
+		),
+	)
+	health.Register(grpcServer)
+
 	go func() {
 		log.Infof("starting metrics http server on %s", config.StorageStatAddress)
 		if err = http.ListenAndServe(config.StorageStatAddress, nil); err != nil {
@@ -69,9 +113,72 @@ func main() {
 		}
 	}()
 
+	repository := postgresRepository.NewRepository(pool)
+
+	cfg := sarama.NewConfig()
+	cfg.Producer.Return.Successes = true
+	syncProducer, err := sarama.NewSyncProducer(config.GetKafkaBrokers(), cfg)
+	if err != nil {
+		log.WithError(err).Fatal("kafka: NewSyncProducer")
+	}
+	syncProducer = otelsarama.WrapSyncProducer(cfg, syncProducer)
+
+	lowStockChecker := &alerts.LowStockChecker{
+		Repository: repository,
+		Producer:   syncProducer,
+		Topic:      config.LowStockAlertTopic,
+		Interval:   config.LowStockCheckInterval,
+	}
+	// alerts go to the chats of the bot, which manages a single shop
+	go lowStockChecker.Run(tenants.NewContext(ctx, config.BotTenant))
+
+	orderService := &ordering.Service{
+		Repository:     repository,
+		Publisher:      &ordering.KafkaPublisher{Producer: syncProducer},
+		PlacedTopic:    config.OrderPlacedTopic,
+		CancelledTopic: config.OrderCancelledTopic,
+	}
+
+	blobStore, err := localBlobStore.New(config.BlobStoreRoot)
+	if err != nil {
+		log.WithError(err).Fatal("failed to open blob store")
+	}
+
+	relationPolicy, err := relations.ParseDeletePolicy(config.RelationDeletePolicy)
+	if err != nil {
+		log.WithError(err).Fatal("invalid relation delete policy")
+	}
+
+	imageService := &gallery.Service{
+		ProductRepository:  repository,
+		ImageRepository:    repository,
+		BlobStore:          blobStore,
+		RelationRepository: repository,
+		RelationPolicy:     relationPolicy,
+	}
+
+	approvalService := &approvals.Service{
+		ProductRepository:     repository,
+		PriceChangeRepository: repository,
+		Threshold:             config.PriceChangeApprovalThreshold,
+	}
+
 	deps := storage.Deps{
-		ProductRepository: postgresRepository.NewRepository(pool),
-		Metrics:           appMetrics,
+		ProductRepository:      repository,
+		PriceChangeRepository:  repository,
+		ApprovalService:        approvalService,
+		StockRepository:        repository,
+		PurchaseRepository:     repository,
+		OrderService:           orderService,
+		LotRepository:          repository,
+		StocktakeRepository:    repository,
+		ReportRepository:       repository,
+		TranslationRepository:  repository,
+		ImageRepository:        repository,
+		ImageService:           imageService,
+		RelationRepository:     repository,
+		ExchangeRateRepository: repository,
+		AttributeRegistry:      attributes.DefaultRegistry(),
 	}
 
 	pbStorage.RegisterStorageServiceServer(grpcServer, storage.New(deps))
@@ -28,6 +28,80 @@ const (
 	TracerUrl = "http://localhost:14268/api/traces"
 )
 
+// TLS secures every gRPC hop with mutual TLS, each service presents TLSCertFile as a server
+// and as a client and trusts the certificates signed by TLSCAFile. `go run ./cmd/devca` writes
+// a dev CA and a certificate for localhost there. Changed files are picked up within
+// TLSReloadInterval, without a restart.
+const (
+	TLSEnabled        = true
+	TLSCAFile         = "certs/ca.crt"
+	TLSCertFile       = "certs/service.crt"
+	TLSKeyFile        = "certs/service.key"
+	TLSReloadInterval = 10 * time.Second
+)
+
+// APIKeysFile and JWTKeySetFile hold the credentials the api services accept, a missing
+// file disables its kind of credentials. Tokens must be issued by JWTIssuer for JWTAudience.
+// The .example.json files next to this one show the formats, the example api key is "dev-key".
+const (
+	APIKeysFile   = "config/api_keys.json"
+	JWTKeySetFile = "config/jwt_keys.json"
+	JWTIssuer     = "homework-1"
+	JWTAudience   = "api"
+)
+
+// ForwardingKeyFile holds the key the api services sign the identity they forward to the storage
+// services with, every one of them must read the same key. `go run ./cmd/devca` writes a dev key.
+const ForwardingKeyFile = "config/forwarding.key"
+
+// PriceChangeApprovalThreshold is the price change in percent above which
+// a product update waits for approval by another user. Zero disables approvals.
+const PriceChangeApprovalThreshold = 50
+
+// BlobStoreRoot is the directory of the local blob store that keeps product images,
+// every service that deletes products must use the same one to remove their images.
+const BlobStoreRoot = "data/blobs"
+
+// RelationDeletePolicy is what deleting a product that takes part in relations does:
+// "cascade" deletes its relations too, "restrict" refuses until they are deleted.
+const RelationDeletePolicy = "cascade"
+
+// RateLimitRate and RateLimitBurst are the token bucket of every client of the api services
+// and the gateway, in requests per second, RateLimitQuotasFile sets other limits for single
+// subjects, {"<subject>": {"rate": 50, "burst": 100}}. RateLimitStore is "memory" for buckets
+// per instance or "redis" to share them between the instances of a service.
+const (
+	RateLimitRate       = 20
+	RateLimitBurst      = 40
+	RateLimitStore      = "memory"
+	RateLimitQuotasFile = "config/rate_limits.json"
+)
+
+// BotTenant is the shop the telegram bot manages, its commands carry no tenant id.
+const BotTenant = "default"
+
+// BotUsersFile assigns roles to Telegram user ids, {"123456": "admin"}, on top of the roles
+// admins grant with /grant. Users without either get BotDefaultRole, the empty default keeps
+// the bot to an allowlist and politely refuses everyone else.
+const (
+	BotUsersFile   = "config/bot_users.json"
+	BotDefaultRole = ""
+)
+
+// ConsumerLagRefreshInterval is how often the kafka storage asks the brokers for the high water
+// marks of the topics it consumes, the consumer lag it reports is at most that old.
+const ConsumerLagRefreshInterval = 15 * time.Second
+
+const (
+	LowStockAlertTopic    = "lowStockAlert"
+	LowStockCheckInterval = time.Minute
+)
+
+const (
+	OrderPlacedTopic    = "orderPlaced"
+	OrderCancelledTopic = "orderCancelled"
+)
+
 const (
 	RedisAddr = "localhost:6379"
 	RedisDB   = 0
@@ -41,3 +115,20 @@ func GetKafkaBrokers() []string {
 func GetRedisOpts() *redis.Options {
 	return &redis.Options{Addr: RedisAddr, DB: RedisDB, Password: RedisPass}
 }
+
+// GetLogRedactedMetadata lists the metadata keys whose values never reach the logs, the gateway
+// forwards the headers of HTTP clients with the grpcgateway- prefix.
+func GetLogRedactedMetadata() []string {
+	return []string{
+		"authorization", "grpcgateway-authorization",
+		"cookie", "grpcgateway-cookie",
+		"x-api-key",
+		"x-auth-signature",
+	}
+}
+
+// GetLogRedactedFields lists the request fields hidden in debug logs, supplier contacts are
+// personal data and image chunks are too big to log.
+func GetLogRedactedFields() []string {
+	return []string{"contact", "chunk"}
+}
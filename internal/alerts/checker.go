@@ -0,0 +1,100 @@
+package alerts
+
+import (
+	"context"
+	"github.com/Shopify/sarama"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+	"homework-1/internal/models/stock"
+	"homework-1/internal/repository"
+	pb "homework-1/pkg/api/storage/v1"
+	"time"
+)
+
+const checkPageSize = 100
+
+// LowStockChecker periodically looks for products below their reorder threshold and
+// publishes one alert per product each time it drops below the threshold.
+type LowStockChecker struct {
+	Repository repository.Stock
+	Producer   sarama.SyncProducer
+	Topic      string
+	Interval   time.Duration
+
+	alerted map[uint64]struct{}
+}
+
+func (c *LowStockChecker) Run(ctx context.Context) {
+	log.Info("starting lowStockChecker")
+
+	ticker := time.NewTicker(c.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.Check(ctx); err != nil {
+			log.WithError(err).Error("LowStockChecker: check")
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Info("lowStockChecker done")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check publishes alerts for products that became low on stock since the previous check.
+// Products that failed to publish are retried on the next check.
+func (c *LowStockChecker) Check(ctx context.Context) error {
+	lowStock, err := c.getLowStockProducts(ctx)
+	if err != nil {
+		return err
+	}
+
+	alerted := make(map[uint64]struct{}, len(lowStock))
+	for _, item := range lowStock {
+		if _, ok := c.alerted[item.ProductId]; !ok {
+			if err = c.publish(item); err != nil {
+				log.WithError(err).Errorf("LowStockChecker: publish alert for product %d", item.ProductId)
+				continue
+			}
+		}
+		alerted[item.ProductId] = struct{}{}
+	}
+
+	c.alerted = alerted
+	return nil
+}
+
+func (c *LowStockChecker) getLowStockProducts(ctx context.Context) ([]*stock.LowStock, error) {
+	var lowStock []*stock.LowStock
+	for page := uint64(1); ; page++ {
+		items, err := c.Repository.GetLowStockProducts(ctx, page, checkPageSize)
+		if err != nil {
+			return nil, err
+		}
+		lowStock = append(lowStock, items...)
+		if len(items) < checkPageSize {
+			return lowStock, nil
+		}
+	}
+}
+
+func (c *LowStockChecker) publish(item *stock.LowStock) error {
+	data, err := proto.Marshal(&pb.LowStockAlert{
+		ProductId: item.ProductId,
+		Name:      item.Name,
+		Quantity:  item.Quantity,
+		Threshold: item.Threshold,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, _, err = c.Producer.SendMessage(&sarama.ProducerMessage{
+		Topic: c.Topic,
+		Value: sarama.ByteEncoder(data),
+	})
+	return err
+}
@@ -0,0 +1,97 @@
+package alerts
+
+import (
+	"context"
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"homework-1/internal/models/stock"
+	mock_repository "homework-1/internal/repository/mock"
+	pb "homework-1/pkg/api/storage/v1"
+	"testing"
+)
+
+func TestLowStockCheckerCheck(t *testing.T) {
+	lowProduct := &stock.LowStock{ProductId: uint64(1), Name: "product1", Quantity: uint64(1), Threshold: uint64(5)}
+
+	t.Run("alert is published once per drop", func(t *testing.T) {
+		// arrange
+		stockRepo := mock_repository.NewMockStock(gomock.NewController(t))
+		producer := mocks.NewSyncProducer(t, nil)
+		defer producer.Close()
+		checker := LowStockChecker{Repository: stockRepo, Producer: producer, Topic: "lowStockAlert"}
+
+		gomock.InOrder(
+			stockRepo.EXPECT().GetLowStockProducts(gomock.Any(), uint64(1), uint64(checkPageSize)).
+				Return([]*stock.LowStock{lowProduct}, nil),
+			stockRepo.EXPECT().GetLowStockProducts(gomock.Any(), uint64(1), uint64(checkPageSize)).
+				Return([]*stock.LowStock{lowProduct}, nil),
+			stockRepo.EXPECT().GetLowStockProducts(gomock.Any(), uint64(1), uint64(checkPageSize)).
+				Return(nil, nil),
+			stockRepo.EXPECT().GetLowStockProducts(gomock.Any(), uint64(1), uint64(checkPageSize)).
+				Return([]*stock.LowStock{lowProduct}, nil),
+		)
+
+		var alerts []*pb.LowStockAlert
+		collect := func(val []byte) error {
+			alert := pb.LowStockAlert{}
+			if err := proto.Unmarshal(val, &alert); err != nil {
+				return err
+			}
+			alerts = append(alerts, &alert)
+			return nil
+		}
+		producer.ExpectSendMessageWithCheckerFunctionAndSucceed(collect)
+		producer.ExpectSendMessageWithCheckerFunctionAndSucceed(collect)
+
+		// act
+		for i := 0; i < 4; i++ {
+			require.NoError(t, checker.Check(context.Background()))
+		}
+
+		// assert
+		require.Len(t, alerts, 2)
+		assert.Equal(t, alerts[0].GetProductId(), uint64(1))
+		assert.Equal(t, alerts[0].GetThreshold(), uint64(5))
+	})
+
+	t.Run("failed alert is retried on next check", func(t *testing.T) {
+		// arrange
+		stockRepo := mock_repository.NewMockStock(gomock.NewController(t))
+		producer := mocks.NewSyncProducer(t, nil)
+		defer producer.Close()
+		checker := LowStockChecker{Repository: stockRepo, Producer: producer, Topic: "lowStockAlert"}
+
+		stockRepo.EXPECT().GetLowStockProducts(gomock.Any(), uint64(1), uint64(checkPageSize)).
+			Return([]*stock.LowStock{lowProduct}, nil).Times(2)
+
+		producer.ExpectSendMessageAndFail(sarama.ErrOutOfBrokers)
+		producer.ExpectSendMessageAndSucceed()
+
+		// act
+		require.NoError(t, checker.Check(context.Background()))
+		require.NoError(t, checker.Check(context.Background()))
+
+		// assert
+		assert.Contains(t, checker.alerted, uint64(1))
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		// arrange
+		stockRepo := mock_repository.NewMockStock(gomock.NewController(t))
+		checker := LowStockChecker{Repository: stockRepo, Topic: "lowStockAlert"}
+
+		stockRepo.EXPECT().GetLowStockProducts(gomock.Any(), uint64(1), uint64(checkPageSize)).
+			Return(nil, errors.New("some error"))
+
+		// act
+		err := checker.Check(context.Background())
+
+		// assert
+		assert.EqualError(t, err, "some error")
+	})
+}
@@ -0,0 +1,100 @@
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"github.com/Shopify/sarama"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/Shopify/sarama/otelsarama"
+	"google.golang.org/protobuf/proto"
+	"homework-1/config"
+	"homework-1/internal/repository"
+	pb "homework-1/pkg/api/storage/v1"
+	"time"
+)
+
+// Notifier delivers a text message to a chat.
+type Notifier interface {
+	Notify(chatId int64, text string) error
+}
+
+// LowStockAlertConsumer forwards low stock alerts to every subscribed chat.
+type LowStockAlertConsumer struct {
+	Repository repository.Stock
+	Notifier   Notifier
+	Topic      string
+}
+
+func (c *LowStockAlertConsumer) Setup(_ sarama.ConsumerGroupSession) error {
+	log.Info("starting lowStockAlertConsumer")
+	return nil
+}
+
+func (c *LowStockAlertConsumer) Cleanup(_ sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (c *LowStockAlertConsumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for {
+		select {
+		case <-session.Context().Done():
+			log.Info("Consume session done")
+			return nil
+		case msg, ok := <-claim.Messages():
+			if !ok {
+				log.Info("Data channel closed")
+				return nil
+			}
+			session.MarkMessage(msg, "")
+
+			alert := pb.LowStockAlert{}
+			if err := proto.Unmarshal(msg.Value, &alert); err != nil {
+				log.WithError(err).Error("Failed to unmarshal message")
+				continue
+			}
+
+			c.notifySubscribers(session.Context(), &alert)
+		}
+	}
+}
+
+func (c *LowStockAlertConsumer) StartConsuming(ctx context.Context) {
+	saramaConfig := sarama.NewConfig()
+	saramaConfig.Consumer.Offsets.Initial = sarama.OffsetNewest
+	client, err := sarama.NewConsumerGroup(config.GetKafkaBrokers(), "lowStockAlertConsumer", saramaConfig)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to create kafka consumer group: lowStockAlertConsumer")
+		return
+	}
+
+	handler := otelsarama.WrapConsumerGroupHandler(c)
+
+	for {
+		if err := client.Consume(ctx, []string{c.Topic}, handler); err != nil {
+			log.WithError(err).Errorf("on consume %s", c.Topic)
+			time.Sleep(time.Second * 3)
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+func (c *LowStockAlertConsumer) notifySubscribers(ctx context.Context, alert *pb.LowStockAlert) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*2)
+	defer cancel()
+
+	chatIds, err := c.Repository.GetAlertSubscriptions(ctx)
+	if err != nil {
+		log.WithError(err).Error("StockRepository: GetAlertSubscriptions: internal error")
+		return
+	}
+
+	text := fmt.Sprintf("Low stock: product %d %s has %d left, reorder threshold is %d",
+		alert.GetProductId(), alert.GetName(), alert.GetQuantity(), alert.GetThreshold())
+	for _, chatId := range chatIds {
+		if err = c.Notifier.Notify(chatId, text); err != nil {
+			log.WithError(err).Errorf("LowStockAlertConsumer: notify chat %d", chatId)
+		}
+	}
+}
@@ -9,12 +9,13 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/github.com/Shopify/sarama/otelsarama"
 	"go.opentelemetry.io/otel/propagation"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+	"homework-1/internal/auth"
 	"homework-1/internal/cache"
-	"homework-1/internal/metrics"
+	"homework-1/internal/interceptors"
 	"homework-1/internal/models/products"
+	"homework-1/internal/tenants"
 	pbStorage "homework-1/pkg/api/storage/v2"
 	pbApi "homework-1/pkg/api/v2"
 	"io"
@@ -42,30 +43,20 @@ type implementation struct {
 type Deps struct {
 	StorageClient StorageServiceClient
 	Producer      sarama.SyncProducer
-	Metrics       *metrics.Metrics
 	Cache         cache.KVCache
 }
 
 func (i *implementation) ProductList(ctx context.Context, in *pbApi.ProductListRequest) (*pbApi.ProductListResponse, error) {
-	i.deps.Metrics.IncomingRequestCounter.Inc()
-
-	md, _ := metadata.FromIncomingContext(ctx)
-	log.Infof("ProductList request metadata: %v", md)
-	log.Debugf("ProductList request data: %v", in)
-
 	ctx, cancel := context.WithTimeout(ctx, maxTimeout)
 	defer cancel()
 
 	pageNum := in.GetPage()
 	pageSize := in.GetSize()
 
-	i.deps.Metrics.OutgoingRequestCounter.Inc()
 	request := pbStorage.ProductListRequest{Page: &pageNum, Size: &pageSize}
 	productStream, err := i.deps.StorageClient.ProductList(ctx, &request)
 	if err != nil {
-		i.deps.Metrics.FailedRequestCounter.Inc()
-		log.WithError(err).Error("StorageClient: ProductList: internal error")
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, err
 	}
 
 	var result []*pbApi.ProductListResponse_Product
@@ -75,9 +66,7 @@ func (i *implementation) ProductList(ctx context.Context, in *pbApi.ProductListR
 			break
 		}
 		if err != nil {
-			i.deps.Metrics.FailedRequestCounter.Inc()
-			log.WithError(err).Error("StorageClient: ProductList: receive internal error")
-			return nil, status.Error(codes.Internal, "internal error")
+			return nil, err
 		}
 		result = append(result, &pbApi.ProductListResponse_Product{
 			Id:       product.GetId(),
@@ -87,19 +76,12 @@ func (i *implementation) ProductList(ctx context.Context, in *pbApi.ProductListR
 		})
 	}
 
-	i.deps.Metrics.SuccessfulRequestCounter.Inc()
 	return &pbApi.ProductListResponse{
 		Products: result,
 	}, nil
 }
 
 func (i *implementation) AsyncProductList(ctx context.Context, in *pbApi.AsyncProductListRequest) (*pbApi.AsyncProductListResponse, error) {
-	i.deps.Metrics.IncomingRequestCounter.Inc()
-
-	md, _ := metadata.FromIncomingContext(ctx)
-	log.Infof("ProductList request metadata: %v", md)
-	log.Debugf("ProductList request data: %v", in)
-
 	var result []*pbApi.AsyncProductListResponse_Product
 
 	ctx, cancel := context.WithTimeout(ctx, maxTimeout)
@@ -124,7 +106,6 @@ func (i *implementation) AsyncProductList(ctx context.Context, in *pbApi.AsyncPr
 				})
 			}
 
-			i.deps.Metrics.SuccessfulRequestCounter.Inc()
 			return &pbApi.AsyncProductListResponse{
 				Ready:    true,
 				Products: result,
@@ -133,16 +114,12 @@ func (i *implementation) AsyncProductList(ctx context.Context, in *pbApi.AsyncPr
 	}
 
 	log.Debugf("AsyncProductList: send request for products")
-	i.deps.Metrics.OutgoingRequestCounter.Inc()
 	request := pbStorage.AsyncProductListRequest{Page: &pageNum, Size: &pageSize}
 	_, err = i.deps.StorageClient.AsyncProductList(ctx, &request)
 	if err != nil {
-		i.deps.Metrics.FailedRequestCounter.Inc()
-		log.WithError(err).Error("StorageClient: AsyncProductList: internal error")
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, err
 	}
 
-	i.deps.Metrics.SuccessfulRequestCounter.Inc()
 	return &pbApi.AsyncProductListResponse{
 		Ready:    false,
 		Products: result,
@@ -150,28 +127,17 @@ func (i *implementation) AsyncProductList(ctx context.Context, in *pbApi.AsyncPr
 }
 
 func (i *implementation) ProductGet(ctx context.Context, in *pbApi.ProductGetRequest) (*pbApi.ProductGetResponse, error) {
-	i.deps.Metrics.IncomingRequestCounter.Inc()
-
-	md, _ := metadata.FromIncomingContext(ctx)
-	log.Infof("ProductGet request metadata: %v", md)
-	log.Debugf("ProductGet request data: %v", in)
-
 	ctx, cancel := context.WithTimeout(ctx, maxTimeout)
 	defer cancel()
 
-	i.deps.Metrics.OutgoingRequestCounter.Inc()
 	product, err := i.deps.StorageClient.ProductGet(ctx, &pbStorage.ProductGetRequest{Id: in.GetId()})
 	if err != nil {
 		if status.Code(err) == codes.NotFound {
-			i.deps.Metrics.UnsuccessfulRequestCounter.Inc()
 			return nil, status.Error(codes.NotFound, "product not found")
 		}
-		i.deps.Metrics.FailedRequestCounter.Inc()
-		log.WithError(err).Error("StorageClient: ProductGet: internal error")
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, err
 	}
 
-	i.deps.Metrics.SuccessfulRequestCounter.Inc()
 	return &pbApi.ProductGetResponse{
 		Id:       product.GetId(),
 		Name:     product.GetName(),
@@ -181,12 +147,6 @@ func (i *implementation) ProductGet(ctx context.Context, in *pbApi.ProductGetReq
 }
 
 func (i *implementation) ProductCreate(ctx context.Context, in *pbApi.ProductCreateRequest) (*pbApi.ProductCreateResponse, error) {
-	i.deps.Metrics.IncomingRequestCounter.Inc()
-
-	md, _ := metadata.FromIncomingContext(ctx)
-	log.Infof("ProductCreate request metadata: %v", md)
-	log.Debugf("ProductCreate request data: %v", in)
-
 	ctx, cancel := context.WithTimeout(ctx, maxTimeout)
 	defer cancel()
 
@@ -195,7 +155,6 @@ func (i *implementation) ProductCreate(ctx context.Context, in *pbApi.ProductCre
 		for _, err := range errs {
 			errStrings = append(errStrings, err.Error())
 		}
-		i.deps.Metrics.UnsuccessfulRequestCounter.Inc()
 		return nil, status.Error(codes.InvalidArgument, strings.Join(errStrings, "; "))
 	}
 
@@ -205,16 +164,18 @@ func (i *implementation) ProductCreate(ctx context.Context, in *pbApi.ProductCre
 		Quantity: in.GetQuantity(),
 	})
 	if err != nil {
-		i.deps.Metrics.FailedRequestCounter.Inc()
-		log.WithError(err).Error("ProductCreate: proto.Marshal: internal error")
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, err
 	}
 
-	i.deps.Metrics.OutgoingRequestCounter.Inc()
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, interceptors.Invalid(err)
+	}
 
 	msg := sarama.ProducerMessage{
-		Topic: "productCreate",
-		Value: sarama.ByteEncoder(requestData),
+		Topic:   "productCreate",
+		Value:   sarama.ByteEncoder(requestData),
+		Headers: []sarama.RecordHeader{tenants.MessageHeader(tenant)},
 	}
 
 	propagator := propagation.TraceContext{}
@@ -222,22 +183,13 @@ func (i *implementation) ProductCreate(ctx context.Context, in *pbApi.ProductCre
 
 	_, _, err = i.deps.Producer.SendMessage(&msg)
 	if err != nil {
-		i.deps.Metrics.FailedRequestCounter.Inc()
-		log.WithError(err).Error("ProductCreate: Producer: SendMessage: internal error")
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, err
 	}
 
-	i.deps.Metrics.SuccessfulRequestCounter.Inc()
 	return &pbApi.ProductCreateResponse{}, nil
 }
 
 func (i *implementation) ProductUpdate(ctx context.Context, in *pbApi.ProductUpdateRequest) (*pbApi.ProductUpdateResponse, error) {
-	i.deps.Metrics.IncomingRequestCounter.Inc()
-
-	md, _ := metadata.FromIncomingContext(ctx)
-	log.Infof("ProductUpdate request metadata: %v", md)
-	log.Debugf("ProductUpdate request data: %v", in)
-
 	ctx, cancel := context.WithTimeout(ctx, maxTimeout)
 	defer cancel()
 
@@ -246,75 +198,75 @@ func (i *implementation) ProductUpdate(ctx context.Context, in *pbApi.ProductUpd
 		for _, err := range errs {
 			errStrings = append(errStrings, err.Error())
 		}
-		i.deps.Metrics.UnsuccessfulRequestCounter.Inc()
 		return nil, status.Error(codes.InvalidArgument, strings.Join(errStrings, "; "))
 	}
 
+	// the authenticated caller requests the price change when the update needs approval
+	var actor string
+	if identity, ok := auth.FromContext(ctx); ok {
+		actor = identity.Subject
+	}
+
 	requestData, err := proto.Marshal(&pbStorage.ProductUpdateRequest{
 		Id:       in.GetId(),
 		Name:     in.GetName(),
 		Price:    in.GetPrice(),
 		Quantity: in.GetQuantity(),
+		Actor:    actor,
 	})
 	if err != nil {
-		i.deps.Metrics.FailedRequestCounter.Inc()
-		log.WithError(err).Error("ProductUpdate: proto.Marshal: internal error")
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, err
+	}
+
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, interceptors.Invalid(err)
 	}
 
 	msg := sarama.ProducerMessage{
-		Topic: "productUpdate",
-		Value: sarama.ByteEncoder(requestData),
+		Topic:   "productUpdate",
+		Value:   sarama.ByteEncoder(requestData),
+		Headers: []sarama.RecordHeader{tenants.MessageHeader(tenant)},
 	}
 
 	propagator := propagation.TraceContext{}
 	propagator.Inject(ctx, otelsarama.NewProducerMessageCarrier(&msg))
 
-	i.deps.Metrics.OutgoingRequestCounter.Inc()
 	_, _, err = i.deps.Producer.SendMessage(&msg)
 	if err != nil {
-		i.deps.Metrics.FailedRequestCounter.Inc()
-		log.WithError(err).Error("productUpdate: Producer: SendMessage: internal error")
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, err
 	}
 
-	i.deps.Metrics.SuccessfulRequestCounter.Inc()
 	return &pbApi.ProductUpdateResponse{}, nil
 }
 
 func (i *implementation) ProductDelete(ctx context.Context, in *pbApi.ProductDeleteRequest) (*pbApi.ProductDeleteResponse, error) {
-	i.deps.Metrics.IncomingRequestCounter.Inc()
-
-	md, _ := metadata.FromIncomingContext(ctx)
-	log.Infof("ProductDelete request metadata: %v", md)
-	log.Debugf("ProductDelete request data: %v", in)
-
 	ctx, cancel := context.WithTimeout(ctx, maxTimeout)
 	defer cancel()
 
 	requestData, err := proto.Marshal(&pbStorage.ProductDeleteRequest{Id: in.GetId()})
 	if err != nil {
-		i.deps.Metrics.FailedRequestCounter.Inc()
-		log.WithError(err).Error("ProductDelete: proto.Marshal: internal error")
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, err
+	}
+
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, interceptors.Invalid(err)
 	}
 
 	msg := sarama.ProducerMessage{
-		Topic: "productDelete",
-		Value: sarama.ByteEncoder(requestData),
+		Topic:   "productDelete",
+		Value:   sarama.ByteEncoder(requestData),
+		Headers: []sarama.RecordHeader{tenants.MessageHeader(tenant)},
 	}
 
 	propagator := propagation.TraceContext{}
 	propagator.Inject(ctx, otelsarama.NewProducerMessageCarrier(&msg))
 
-	i.deps.Metrics.OutgoingRequestCounter.Inc()
 	_, _, err = i.deps.Producer.SendMessage(&msg)
 	if err != nil {
-		i.deps.Metrics.FailedRequestCounter.Inc()
-		log.WithError(err).Error("ProductDelete: Producer: SendMessage: internal error")
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, err
 	}
 
-	i.deps.Metrics.SuccessfulRequestCounter.Inc()
 	return &pbApi.ProductDeleteResponse{}, nil
 }
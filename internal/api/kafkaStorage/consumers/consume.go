@@ -0,0 +1,64 @@
+package consumers
+
+import (
+	"github.com/Shopify/sarama"
+	log "github.com/sirupsen/logrus"
+	"homework-1/internal/metrics"
+	"time"
+)
+
+// consumeClaim hands the messages of the claim to handle one by one until the session ends,
+// recording the lag, handling time and rate of the group when the metrics follow consumers.
+// A message is marked once it is handled, so a crash hands it to the group again. Messages
+// that fail are logged and marked too, they would fail again.
+func consumeClaim(group string, m *metrics.Metrics, session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim, handle func(msg *sarama.ConsumerMessage) error) error {
+	if m.Consumers != nil {
+		m.Consumers.Claimed(group, claim.Topic(), claim.Partition(), claim.InitialOffset(), claim.HighWaterMarkOffset())
+	}
+
+	for {
+		select {
+		case <-session.Context().Done():
+			log.Info("Consume session done")
+			return nil
+		case msg, ok := <-claim.Messages():
+			if !ok {
+				log.Info("Data channel closed")
+				return nil
+			}
+			m.IncomingRequestCounter.Inc()
+
+			start := time.Now()
+			err := handle(msg)
+			if err != nil {
+				m.FailedRequestCounter.Inc()
+				log.WithError(err).WithFields(log.Fields{
+					"group":     group,
+					"topic":     msg.Topic,
+					"partition": msg.Partition,
+					"offset":    msg.Offset,
+				}).Error("Failed to handle message")
+			} else {
+				m.SuccessfulRequestCounter.Inc()
+			}
+			session.MarkMessage(msg, "")
+
+			if m.Consumers != nil {
+				m.Consumers.Processed(group, msg.Topic, msg.Partition, msg.Offset, claim.HighWaterMarkOffset(), time.Since(start), err)
+			}
+		}
+	}
+}
+
+// releaseClaims forgets the partitions of a session that ends, the next session of the
+// group may hand them to another instance.
+func releaseClaims(group string, m *metrics.Metrics, session sarama.ConsumerGroupSession) {
+	if m.Consumers == nil {
+		return
+	}
+	for topic, partitions := range session.Claims() {
+		for _, partition := range partitions {
+			m.Consumers.Released(group, topic, partition)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package consumers
+
+import (
+	"context"
+	"errors"
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"homework-1/internal/metrics"
+	"testing"
+)
+
+func TestConsumeClaim(t *testing.T) {
+	t.Run("marks messages once they are handled", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		session := &fakeSession{ctx: context.Background()}
+		claim := newFakeClaim("productCreate", 5, 8,
+			&sarama.ConsumerMessage{Topic: "productCreate", Offset: 5},
+			&sarama.ConsumerMessage{Topic: "productCreate", Offset: 6},
+		)
+
+		var markedWhileHandling [][]int64
+		handle := func(msg *sarama.ConsumerMessage) error {
+			markedWhileHandling = append(markedWhileHandling, append([]int64(nil), session.marked...))
+			return nil
+		}
+
+		// act
+		err := consumeClaim(productCreateGroup, f.metrics, session, claim, handle)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, [][]int64{nil, {5}}, markedWhileHandling)
+		assert.Equal(t, []int64{5, 6}, session.marked)
+		assert.Equal(t, uint64(2), f.metrics.IncomingRequestCounter.Value())
+		assert.Equal(t, uint64(2), f.metrics.SuccessfulRequestCounter.Value())
+	})
+
+	t.Run("lag is counted from the marked offset", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		session := &fakeSession{ctx: context.Background()}
+		claim := newFakeClaim("productCreate", 5, 8, &sarama.ConsumerMessage{Topic: "productCreate", Offset: 5})
+
+		var lagWhileHandling int64
+		handle := func(msg *sarama.ConsumerMessage) error {
+			lagWhileHandling = f.metrics.Consumers.Status()[0].Lag
+			return nil
+		}
+
+		// act
+		err := consumeClaim(productCreateGroup, f.metrics, session, claim, handle)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), lagWhileHandling, "the message being handled is not consumed yet")
+		status := f.metrics.Consumers.Status()
+		assert.Equal(t, int64(6), status[0].Offset)
+		assert.Equal(t, int64(2), status[0].Lag)
+	})
+
+	t.Run("failed messages are marked and counted", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		session := &fakeSession{ctx: context.Background()}
+		claim := newFakeClaim("productCreate", 5, 6, &sarama.ConsumerMessage{Topic: "productCreate", Offset: 5})
+
+		// act
+		err := consumeClaim(productCreateGroup, f.metrics, session, claim, func(*sarama.ConsumerMessage) error {
+			return errors.New("boom")
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, []int64{5}, session.marked)
+		assert.Equal(t, uint64(1), f.metrics.FailedRequestCounter.Value())
+		status := f.metrics.Consumers.Status()
+		assert.Equal(t, uint64(1), status[0].Failures)
+		assert.Equal(t, int64(0), status[0].Lag)
+	})
+
+	t.Run("stops when the session ends", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		session := &fakeSession{ctx: ctx}
+		claim := &fakeClaim{topic: "productCreate", initialOffset: sarama.OffsetNewest, messages: make(chan *sarama.ConsumerMessage)}
+
+		// act
+		err := consumeClaim(productCreateGroup, f.metrics, session, claim, func(*sarama.ConsumerMessage) error {
+			t.Fatal("no message is handled")
+			return nil
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, int64(-1), f.metrics.Consumers.Status()[0].Lag)
+	})
+
+	t.Run("without consumer metrics", func(t *testing.T) {
+		// arrange
+		m := metrics.NewMetrics()
+		session := &fakeSession{ctx: context.Background()}
+		claim := newFakeClaim("productCreate", 5, 6, &sarama.ConsumerMessage{Topic: "productCreate", Offset: 5})
+
+		// act
+		err := consumeClaim(productCreateGroup, m, session, claim, func(*sarama.ConsumerMessage) error { return nil })
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, []int64{5}, session.marked)
+	})
+}
+
+func TestCleanup(t *testing.T) {
+	t.Run("forgets the partitions of the session", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		f.metrics.Consumers.Claimed(productCreateGroup, "productCreate", 0, 5, 8)
+		f.metrics.Consumers.Claimed(productCreateGroup, "productCreate", 1, 2, 3)
+		f.metrics.Consumers.Claimed(productDeleteGroup, "productDelete", 0, 1, 1)
+		consumer := &ProductCreateConsumer{Metrics: f.metrics}
+		session := &fakeSession{ctx: context.Background(), claims: map[string][]int32{"productCreate": {0}}}
+
+		// act
+		err := consumer.Cleanup(session)
+
+		// assert
+		require.NoError(t, err)
+		status := f.metrics.Consumers.Status()
+		require.Len(t, status, 2)
+		assert.Equal(t, productCreateGroup, status[0].Group)
+		assert.Equal(t, int32(1), status[0].Partition)
+		assert.Equal(t, productDeleteGroup, status[1].Group, "other groups keep their partitions")
+	})
+
+	t.Run("without consumer metrics", func(t *testing.T) {
+		// arrange
+		consumer := &ProductCreateConsumer{Metrics: metrics.NewMetrics()}
+		session := &fakeSession{ctx: context.Background(), claims: map[string][]int32{"productCreate": {0}}}
+
+		// act
+		err := consumer.Cleanup(session)
+
+		// assert
+		assert.NoError(t, err)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/Shopify/sarama"
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/Shopify/sarama/otelsarama"
 	"google.golang.org/protobuf/proto"
@@ -13,10 +14,14 @@ import (
 	"homework-1/internal/metrics"
 	"homework-1/internal/models/products"
 	"homework-1/internal/repository"
+	"homework-1/internal/tenants"
 	pb "homework-1/pkg/api/storage/v1"
 	"time"
 )
 
+// productCreateGroup is the consumer group of the productCreate topic.
+const productCreateGroup = "productCreateConsuming"
+
 type ProductCreateConsumer struct {
 	ProductRepository repository.Product
 	Metrics           *metrics.Metrics
@@ -28,68 +33,67 @@ func (c *ProductCreateConsumer) Setup(_ sarama.ConsumerGroupSession) error {
 	return nil
 }
 
-func (c *ProductCreateConsumer) Cleanup(_ sarama.ConsumerGroupSession) error {
+func (c *ProductCreateConsumer) Cleanup(session sarama.ConsumerGroupSession) error {
+	releaseClaims(productCreateGroup, c.Metrics, session)
 	return nil
 }
 
 func (c *ProductCreateConsumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
-	for {
-		select {
-		case <-session.Context().Done():
-			log.Info("Consume session done")
-			return nil
-		case msg, ok := <-claim.Messages():
-			if !ok {
-				log.Info("Data channel closed")
-				return nil
-			}
-			c.Metrics.IncomingRequestCounter.Inc()
-			session.MarkMessage(msg, "")
-
-			in := pb.ProductCreateRequest{}
-			if err := proto.Unmarshal(msg.Value, &in); err != nil {
-				c.Metrics.FailedRequestCounter.Inc()
-				log.WithError(err).Error("Failed to unmarshal message")
-				continue
-			}
-
-			ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
-			defer cancel()
-
-			p := products.Product{
-				Name:     in.GetName(),
-				Price:    in.GetPrice(),
-				Quantity: in.GetQuantity(),
-			}
-
-			product, err := c.ProductRepository.CreateProduct(ctx, p)
-			if err != nil {
-				c.Metrics.FailedRequestCounter.Inc()
-				log.WithError(err).Error("ProductRepository: ProductCreate: internal error")
-			} else {
-				c.Metrics.SuccessfulRequestCounter.Inc()
-				log.Infof("Product created: %v", product)
-			}
-
-			if cacheData, err := json.Marshal(*product); err != nil {
-				log.WithError(err).Error("ProductCreateConsumer: ConsumeClaim: marshal product to cache")
-			} else {
-				key := fmt.Sprintf("product:%d", product.GetId())
-				err = c.Cache.Set(ctx, key, string(cacheData), time.Minute*10)
-				if err != nil {
-					log.WithError(err).Error("ProductCreateConsumer: ConsumeClaim: set product to cache")
-				}
-			}
+	return consumeClaim(productCreateGroup, c.Metrics, session, claim, c.handle)
+}
+
+func (c *ProductCreateConsumer) handle(msg *sarama.ConsumerMessage) error {
+	in := pb.ProductCreateRequest{}
+	if err := proto.Unmarshal(msg.Value, &in); err != nil {
+		return errors.Wrap(err, "unmarshal message")
+	}
+
+	tenant, err := tenants.FromMessage(msg)
+	if err != nil {
+		return errors.Wrap(err, "message without a valid tenant is rejected")
+	}
+
+	ctx, cancel := context.WithTimeout(tenants.NewContext(context.Background(), tenant), time.Second*2)
+	defer cancel()
+
+	status := products.Status(in.GetStatus())
+	if status != "" {
+		if err = products.ValidateStatus(status); err != nil {
+			return err
 		}
 	}
+
+	p := products.Product{
+		Name:     in.GetName(),
+		Price:    in.GetPrice(),
+		Quantity: in.GetQuantity(),
+		Status:   status,
+	}
+
+	product, err := c.ProductRepository.CreateProduct(ctx, p)
+	if err != nil {
+		return errors.Wrap(err, "ProductRepository: CreateProduct")
+	}
+	log.Infof("Product created: %v", product)
+
+	if cacheData, err := json.Marshal(*product); err != nil {
+		log.WithError(err).Error("ProductCreateConsumer: handle: marshal product to cache")
+	} else {
+		key := fmt.Sprintf("product:%d", product.GetId())
+		err = c.Cache.Set(ctx, key, string(cacheData), time.Minute*10)
+		if err != nil {
+			log.WithError(err).Error("ProductCreateConsumer: handle: set product to cache")
+		}
+	}
+	return nil
 }
 
 func (c *ProductCreateConsumer) StartConsuming(ctx context.Context) {
 	saramaConfig := sarama.NewConfig()
 	saramaConfig.Consumer.Offsets.Initial = sarama.OffsetOldest
-	client, err := sarama.NewConsumerGroup(config.GetKafkaBrokers(), "productCreateConsuming", saramaConfig)
+	client, err := sarama.NewConsumerGroup(config.GetKafkaBrokers(), productCreateGroup, saramaConfig)
 	if err != nil {
-		log.WithError(err).Fatal("Failed to create kafka consumer group: productCreateConsuming")
+		log.WithError(err).Fatal("Failed to create kafka consumer group: " + productCreateGroup)
 	}
 
 	handler := otelsarama.WrapConsumerGroupHandler(c)
@@ -0,0 +1,82 @@
+package consumers
+
+import (
+	"context"
+	"github.com/Shopify/sarama"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"homework-1/internal/models/products"
+	"homework-1/internal/tenants"
+	pb "homework-1/pkg/api/storage/v1"
+	"testing"
+)
+
+func TestProductCreateConsumer(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		c := &ProductCreateConsumer{ProductRepository: f.productRepo, Metrics: f.metrics, Cache: tenants.NewCache(f.cache)}
+		msg := message(t, "productCreate", 0, &pb.ProductCreateRequest{Name: "pillow", Price: 100, Quantity: 3, Status: "draft"})
+
+		f.productRepo.EXPECT().
+			CreateProduct(gomock.Any(), products.Product{Name: "pillow", Price: 100, Quantity: 3, Status: products.StatusDraft}).
+			DoAndReturn(func(ctx context.Context, p products.Product) (*products.Product, error) {
+				tenant, err := tenants.FromContext(ctx)
+				require.NoError(t, err)
+				assert.Equal(t, testTenant, tenant)
+				p.Id = 1
+				return &p, nil
+			})
+
+		// act
+		err := c.handle(msg)
+
+		// assert
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"id":1,"name":"pillow","price":100,"quantity":3,"status":"draft","unit":""}`,
+			f.cache[tenants.CacheKey(testTenant, "product:1")])
+	})
+
+	t.Run("message without a tenant", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		c := &ProductCreateConsumer{ProductRepository: f.productRepo, Metrics: f.metrics, Cache: tenants.NewCache(f.cache)}
+		msg := message(t, "productCreate", 0, &pb.ProductCreateRequest{Name: "pillow", Price: 100})
+		msg.Headers = nil
+
+		// act
+		err := c.handle(msg)
+
+		// assert
+		assert.ErrorIs(t, err, tenants.ErrNoTenant)
+		assert.Empty(t, f.cache)
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		c := &ProductCreateConsumer{ProductRepository: f.productRepo, Metrics: f.metrics, Cache: tenants.NewCache(f.cache)}
+		msg := message(t, "productCreate", 0, &pb.ProductCreateRequest{Name: "pillow", Price: 100, Status: "sold"})
+
+		// act
+		err := c.handle(msg)
+
+		// assert
+		assert.Error(t, err)
+		assert.Empty(t, f.cache)
+	})
+
+	t.Run("not a product create request", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		c := &ProductCreateConsumer{ProductRepository: f.productRepo, Metrics: f.metrics, Cache: tenants.NewCache(f.cache)}
+		msg := &sarama.ConsumerMessage{Topic: "productCreate", Value: []byte("pillow")}
+
+		// act
+		err := c.handle(msg)
+
+		// assert
+		assert.ErrorContains(t, err, "unmarshal message")
+	})
+}
@@ -4,21 +4,27 @@ import (
 	"context"
 	"fmt"
 	"github.com/Shopify/sarama"
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/Shopify/sarama/otelsarama"
 	"google.golang.org/protobuf/proto"
 	"homework-1/config"
 	"homework-1/internal/cache"
+	"homework-1/internal/gallery"
 	"homework-1/internal/metrics"
-	"homework-1/internal/repository"
+	"homework-1/internal/tenants"
 	pb "homework-1/pkg/api/storage/v1"
 	"time"
 )
 
+// productDeleteGroup is the consumer group of the productDelete topic.
+const productDeleteGroup = "productDeleteConsuming"
+
 type ProductDeleteConsumer struct {
-	ProductRepository repository.Product
-	Metrics           *metrics.Metrics
-	Cache             cache.KVCache
+	// ImageService deletes the product together with its images
+	ImageService *gallery.Service
+	Metrics      *metrics.Metrics
+	Cache        cache.KVCache
 }
 
 func (c *ProductDeleteConsumer) Setup(_ sarama.ConsumerGroupSession) error {
@@ -26,56 +32,46 @@ func (c *ProductDeleteConsumer) Setup(_ sarama.ConsumerGroupSession) error {
 	return nil
 }
 
-func (c *ProductDeleteConsumer) Cleanup(_ sarama.ConsumerGroupSession) error {
+func (c *ProductDeleteConsumer) Cleanup(session sarama.ConsumerGroupSession) error {
+	releaseClaims(productDeleteGroup, c.Metrics, session)
 	return nil
 }
 
 func (c *ProductDeleteConsumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
-	for {
-		select {
-		case <-session.Context().Done():
-			log.Info("Consume session done")
-			return nil
-		case msg, ok := <-claim.Messages():
-			if !ok {
-				log.Info("Data channel closed")
-				return nil
-			}
-			c.Metrics.IncomingRequestCounter.Inc()
-			session.MarkMessage(msg, "")
+	return consumeClaim(productDeleteGroup, c.Metrics, session, claim, c.handle)
+}
+
+func (c *ProductDeleteConsumer) handle(msg *sarama.ConsumerMessage) error {
+	in := pb.ProductDeleteRequest{}
+	if err := proto.Unmarshal(msg.Value, &in); err != nil {
+		return errors.Wrap(err, "unmarshal message")
+	}
 
-			in := pb.ProductDeleteRequest{}
-			if err := proto.Unmarshal(msg.Value, &in); err != nil {
-				c.Metrics.FailedRequestCounter.Inc()
-				log.WithError(err).Error("Failed to unmarshal message")
-				continue
-			}
+	tenant, err := tenants.FromMessage(msg)
+	if err != nil {
+		return errors.Wrap(err, "message without a valid tenant is rejected")
+	}
 
-			ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
-			defer cancel()
+	ctx, cancel := context.WithTimeout(tenants.NewContext(context.Background(), tenant), time.Second*2)
+	defer cancel()
 
-			err := c.ProductRepository.DeleteProduct(ctx, in.GetId())
-			if err != nil {
-				c.Metrics.FailedRequestCounter.Inc()
-				log.WithError(err).Error("ProductRepository: DeleteProduct: internal error")
-			} else {
-				c.Metrics.SuccessfulRequestCounter.Inc()
-				log.Infof("Product deleted: %d", in.GetId())
-			}
+	if err = c.ImageService.DeleteProduct(ctx, in.GetId()); err != nil {
+		return errors.Wrap(err, "ImageService: DeleteProduct")
+	}
+	log.Infof("Product deleted: %d", in.GetId())
 
-			if err = c.Cache.Del(ctx, fmt.Sprintf("product:%d", in.GetId())); err != nil {
-				log.WithError(err).Error("ProductDeleteConsumer: ConsumeClaim: del product from cache")
-			}
-		}
+	if err = c.Cache.Del(ctx, fmt.Sprintf("product:%d", in.GetId())); err != nil {
+		log.WithError(err).Error("ProductDeleteConsumer: handle: del product from cache")
 	}
+	return nil
 }
 
 func (c *ProductDeleteConsumer) StartConsuming(ctx context.Context) {
 	saramaConfig := sarama.NewConfig()
 	saramaConfig.Consumer.Offsets.Initial = sarama.OffsetOldest
-	client, err := sarama.NewConsumerGroup(config.GetKafkaBrokers(), "productDeleteConsuming", saramaConfig)
+	client, err := sarama.NewConsumerGroup(config.GetKafkaBrokers(), productDeleteGroup, saramaConfig)
 	if err != nil {
-		log.WithError(err).Fatal("Failed to create kafka consumer group: productDeleteConsuming")
+		log.WithError(err).Fatal("Failed to create kafka consumer group: " + productDeleteGroup)
 		return
 	}
 
@@ -5,21 +5,29 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/Shopify/sarama"
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/Shopify/sarama/otelsarama"
 	"google.golang.org/protobuf/proto"
 	"homework-1/config"
+	"homework-1/internal/approvals"
 	"homework-1/internal/cache"
 	"homework-1/internal/metrics"
 	"homework-1/internal/repository"
+	"homework-1/internal/tenants"
 	pb "homework-1/pkg/api/storage/v1"
 	"time"
 )
 
+// productUpdateGroup is the consumer group of the productUpdate topic.
+const productUpdateGroup = "productUpdateConsumer"
+
 type ProductUpdateConsumer struct {
 	ProductRepository repository.Product
-	Metrics           *metrics.Metrics
-	Cache             cache.KVCache
+	// ApprovalService updates products and holds large price changes back for approval
+	ApprovalService *approvals.Service
+	Metrics         *metrics.Metrics
+	Cache           cache.KVCache
 }
 
 func (c *ProductUpdateConsumer) Setup(_ sarama.ConsumerGroupSession) error {
@@ -27,73 +35,67 @@ func (c *ProductUpdateConsumer) Setup(_ sarama.ConsumerGroupSession) error {
 	return nil
 }
 
-func (c *ProductUpdateConsumer) Cleanup(_ sarama.ConsumerGroupSession) error {
+func (c *ProductUpdateConsumer) Cleanup(session sarama.ConsumerGroupSession) error {
+	releaseClaims(productUpdateGroup, c.Metrics, session)
 	return nil
 }
 
 func (c *ProductUpdateConsumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
-	for {
-		select {
-		case <-session.Context().Done():
-			log.Info("Consume session done")
-			return nil
-		case msg, ok := <-claim.Messages():
-			if !ok {
-				log.Info("Data channel closed")
-				return nil
-			}
-			c.Metrics.IncomingRequestCounter.Inc()
-			session.MarkMessage(msg, "")
-
-			in := pb.ProductUpdateRequest{}
-			if err := proto.Unmarshal(msg.Value, &in); err != nil {
-				c.Metrics.FailedRequestCounter.Inc()
-				log.WithError(err).Error("Failed to unmarshal message")
-				continue
-			}
-
-			ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
-			defer cancel()
-
-			product, err := c.ProductRepository.GetProductById(ctx, in.GetId())
-			if err != nil {
-				c.Metrics.FailedRequestCounter.Inc()
-				log.WithError(err).Error("ProductRepository: GetProductById: internal error")
-				continue
-			}
-
-			product.Name = in.GetName()
-			product.Price = in.GetPrice()
-			product.Quantity = in.GetQuantity()
-
-			product, err = c.ProductRepository.UpdateProduct(ctx, *product)
-			if err != nil {
-				c.Metrics.FailedRequestCounter.Inc()
-				log.WithError(err).Error("ProductRepository: ProductUpdate: internal error")
-			} else {
-				c.Metrics.SuccessfulRequestCounter.Inc()
-				log.Infof("Product updated: %v", product)
-			}
-
-			if cacheData, err := json.Marshal(*product); err != nil {
-				log.WithError(err).Error("ProductUpdateConsumer: ConsumeClaim: marshal product to cache")
-			} else {
-				key := fmt.Sprintf("product:%d", product.GetId())
-				err = c.Cache.Set(ctx, key, string(cacheData), time.Minute*10)
-				if err != nil {
-					log.WithError(err).Error("ProductUpdateConsumer: ConsumeClaim: set product to cache")
-				}
-			}
+	return consumeClaim(productUpdateGroup, c.Metrics, session, claim, c.handle)
+}
+
+func (c *ProductUpdateConsumer) handle(msg *sarama.ConsumerMessage) error {
+	in := pb.ProductUpdateRequest{}
+	if err := proto.Unmarshal(msg.Value, &in); err != nil {
+		return errors.Wrap(err, "unmarshal message")
+	}
+
+	tenant, err := tenants.FromMessage(msg)
+	if err != nil {
+		return errors.Wrap(err, "message without a valid tenant is rejected")
+	}
+
+	ctx, cancel := context.WithTimeout(tenants.NewContext(context.Background(), tenant), time.Second*2)
+	defer cancel()
+
+	product, err := c.ProductRepository.GetProductById(ctx, in.GetId())
+	if err != nil {
+		return errors.Wrap(err, "ProductRepository: GetProductById")
+	}
+
+	updated := *product.Copy()
+	updated.Name = in.GetName()
+	updated.Price = in.GetPrice()
+	updated.Quantity = in.GetQuantity()
+
+	// the kafka proxy puts the authenticated caller into the message
+	product, change, err := c.ApprovalService.UpdateProduct(ctx, product, updated, approvals.Requested(in.GetActor()))
+	if err != nil {
+		return errors.Wrap(err, "ApprovalService: UpdateProduct")
+	}
+	log.Infof("Product updated: %v", product)
+	if change != nil {
+		log.Infof("Price change waits for approval: %v", change)
+	}
+
+	if cacheData, err := json.Marshal(*product); err != nil {
+		log.WithError(err).Error("ProductUpdateConsumer: handle: marshal product to cache")
+	} else {
+		key := fmt.Sprintf("product:%d", product.GetId())
+		err = c.Cache.Set(ctx, key, string(cacheData), time.Minute*10)
+		if err != nil {
+			log.WithError(err).Error("ProductUpdateConsumer: handle: set product to cache")
 		}
 	}
+	return nil
 }
 
 func (c *ProductUpdateConsumer) StartConsuming(ctx context.Context) {
 	saramaConfig := sarama.NewConfig()
 	saramaConfig.Consumer.Offsets.Initial = sarama.OffsetOldest
-	client, err := sarama.NewConsumerGroup(config.GetKafkaBrokers(), "productUpdateConsumer", saramaConfig)
+	client, err := sarama.NewConsumerGroup(config.GetKafkaBrokers(), productUpdateGroup, saramaConfig)
 	if err != nil {
-		log.WithError(err).Fatal("Failed to create kafka consumer group: productUpdateConsumer")
+		log.WithError(err).Fatal("Failed to create kafka consumer group: " + productUpdateGroup)
 		return
 	}
 
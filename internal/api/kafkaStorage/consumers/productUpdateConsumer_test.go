@@ -0,0 +1,112 @@
+package consumers
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"homework-1/internal/approvals"
+	"homework-1/internal/models/changes"
+	"homework-1/internal/models/products"
+	"homework-1/internal/repository"
+	"homework-1/internal/tenants"
+	pb "homework-1/pkg/api/storage/v1"
+	"testing"
+)
+
+func TestProductUpdateConsumer(t *testing.T) {
+	t.Run("small price change is applied", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		c := f.updateConsumer(20)
+		msg := message(t, "productUpdate", 0, &pb.ProductUpdateRequest{Id: 1, Name: "soft pillow", Price: 110, Quantity: 4})
+
+		f.productRepo.EXPECT().GetProductById(gomock.Any(), uint64(1)).
+			Return(&products.Product{Id: 1, Name: "pillow", Price: 100, Quantity: 3}, nil)
+		f.productRepo.EXPECT().UpdateProduct(gomock.Any(), products.Product{Id: 1, Name: "soft pillow", Price: 110, Quantity: 4}).
+			DoAndReturn(func(_ context.Context, p products.Product) (*products.Product, error) {
+				return &p, nil
+			})
+
+		// act
+		err := c.handle(msg)
+
+		// assert
+		require.NoError(t, err)
+		assert.Contains(t, f.cache[tenants.CacheKey(testTenant, "product:1")], `"price":110`)
+	})
+
+	t.Run("large price change waits for approval", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		c := f.updateConsumer(20)
+		msg := message(t, "productUpdate", 0, &pb.ProductUpdateRequest{Id: 1, Name: "soft pillow", Price: 200, Quantity: 4, Actor: "alice"})
+
+		f.productRepo.EXPECT().GetProductById(gomock.Any(), uint64(1)).
+			Return(&products.Product{Id: 1, Name: "pillow", Price: 100, Quantity: 3}, nil)
+		f.productRepo.EXPECT().UpdateProduct(gomock.Any(), products.Product{Id: 1, Name: "soft pillow", Price: 100, Quantity: 4}).
+			DoAndReturn(func(_ context.Context, p products.Product) (*products.Product, error) {
+				return &p, nil
+			})
+		f.priceChangeRepo.EXPECT().CreatePriceChange(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, change changes.PriceChange) (*changes.PriceChange, error) {
+				assert.Equal(t, uint64(1), change.ProductId)
+				assert.Equal(t, uint64(100), change.OldPrice)
+				assert.Equal(t, uint64(200), change.Price)
+				assert.Equal(t, changes.StatusPending, change.Status)
+				assert.Equal(t, "alice", change.RequestedBy)
+				change.Id = 5
+				return &change, nil
+			})
+
+		// act
+		err := c.handle(msg)
+
+		// assert
+		require.NoError(t, err)
+		assert.Contains(t, f.cache[tenants.CacheKey(testTenant, "product:1")], `"price":100`)
+	})
+
+	t.Run("product does not exist", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		c := f.updateConsumer(20)
+		msg := message(t, "productUpdate", 0, &pb.ProductUpdateRequest{Id: 1, Name: "soft pillow", Price: 110})
+
+		f.productRepo.EXPECT().GetProductById(gomock.Any(), uint64(1)).Return(nil, repository.ProductNotExists)
+
+		// act
+		err := c.handle(msg)
+
+		// assert
+		assert.ErrorIs(t, err, repository.ProductNotExists)
+		assert.Empty(t, f.cache)
+	})
+
+	t.Run("message without a tenant", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		c := f.updateConsumer(20)
+		msg := message(t, "productUpdate", 0, &pb.ProductUpdateRequest{Id: 1, Name: "soft pillow", Price: 110})
+		msg.Headers = nil
+
+		// act
+		err := c.handle(msg)
+
+		// assert
+		assert.ErrorIs(t, err, tenants.ErrNoTenant)
+	})
+}
+
+func (f consumerFixture) updateConsumer(threshold uint64) *ProductUpdateConsumer {
+	return &ProductUpdateConsumer{
+		ProductRepository: f.productRepo,
+		ApprovalService: &approvals.Service{
+			ProductRepository:     f.productRepo,
+			PriceChangeRepository: f.priceChangeRepo,
+			Threshold:             threshold,
+		},
+		Metrics: f.metrics,
+		Cache:   tenants.NewCache(f.cache),
+	}
+}
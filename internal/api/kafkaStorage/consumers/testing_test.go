@@ -0,0 +1,109 @@
+package consumers
+
+import (
+	"context"
+	"github.com/Shopify/sarama"
+	"github.com/golang/mock/gomock"
+	"google.golang.org/protobuf/proto"
+	"homework-1/internal/metrics"
+	mock_repository "homework-1/internal/repository/mock"
+	"homework-1/internal/tenants"
+	"testing"
+	"time"
+)
+
+const testTenant = tenants.Tenant("shop")
+
+type consumerFixture struct {
+	productRepo     *mock_repository.MockProduct
+	priceChangeRepo *mock_repository.MockPriceChange
+	cache           mapCache
+	metrics         *metrics.Metrics
+}
+
+func SetUp(t *testing.T) consumerFixture {
+	ctrl := gomock.NewController(t)
+	m := metrics.NewMetrics()
+	m.Consumers = metrics.NewConsumers()
+	return consumerFixture{
+		productRepo:     mock_repository.NewMockProduct(ctrl),
+		priceChangeRepo: mock_repository.NewMockPriceChange(ctrl),
+		cache:           mapCache{},
+		metrics:         m,
+	}
+}
+
+// message is a message of the test tenant with in as the value.
+func message(t *testing.T, topic string, offset int64, in proto.Message) *sarama.ConsumerMessage {
+	value, err := proto.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := tenants.MessageHeader(testTenant)
+	return &sarama.ConsumerMessage{
+		Topic:   topic,
+		Offset:  offset,
+		Value:   value,
+		Headers: []*sarama.RecordHeader{&header},
+	}
+}
+
+type mapCache map[string]string
+
+func (c mapCache) Get(_ context.Context, key string) (string, error) {
+	return c[key], nil
+}
+
+func (c mapCache) Set(_ context.Context, key string, value string, _ time.Duration) error {
+	c[key] = value
+	return nil
+}
+
+func (c mapCache) Del(_ context.Context, key string) error {
+	delete(c, key)
+	return nil
+}
+
+// fakeSession records the offsets marked in a consumer group session.
+type fakeSession struct {
+	sarama.ConsumerGroupSession
+	ctx    context.Context
+	claims map[string][]int32
+	marked []int64
+}
+
+func (s *fakeSession) Claims() map[string][]int32 {
+	return s.claims
+}
+
+func (s *fakeSession) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeSession) MarkMessage(msg *sarama.ConsumerMessage, _ string) {
+	s.marked = append(s.marked, msg.Offset)
+}
+
+// fakeClaim hands out the messages of partition 0 of topic, the channel is closed after them.
+type fakeClaim struct {
+	sarama.ConsumerGroupClaim
+	topic         string
+	initialOffset int64
+	highWaterMark int64
+	messages      chan *sarama.ConsumerMessage
+}
+
+func newFakeClaim(topic string, initialOffset, highWaterMark int64, messages ...*sarama.ConsumerMessage) *fakeClaim {
+	ch := make(chan *sarama.ConsumerMessage, len(messages))
+	for _, msg := range messages {
+		ch <- msg
+	}
+	close(ch)
+	return &fakeClaim{topic: topic, initialOffset: initialOffset, highWaterMark: highWaterMark, messages: ch}
+}
+
+func (c *fakeClaim) Topic() string                            { return c.topic }
+func (c *fakeClaim) Partition() int32                         { return 0 }
+func (c *fakeClaim) InitialOffset() int64                     { return c.initialOffset }
+func (c *fakeClaim) HighWaterMarkOffset() int64               { return c.highWaterMark }
+func (c *fakeClaim) Messages() <-chan *sarama.ConsumerMessage { return c.messages }
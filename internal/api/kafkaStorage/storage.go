@@ -7,12 +7,11 @@ import (
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"homework-1/internal/cache"
-	"homework-1/internal/metrics"
 	"homework-1/internal/models/products"
 	"homework-1/internal/repository"
+	"homework-1/internal/tenants"
 	pb "homework-1/pkg/api/storage/v2"
 	"time"
 )
@@ -32,25 +31,16 @@ type implementation struct {
 
 type Deps struct {
 	ProductRepository repository.Product
-	Metrics           *metrics.Metrics
 	Cache             cache.KVCache
 }
 
 func (i *implementation) ProductList(in *pb.ProductListRequest, srv pb.StorageService_ProductListServer) error {
-	i.deps.Metrics.IncomingRequestCounter.Inc()
-
-	md, _ := metadata.FromIncomingContext(srv.Context())
-	log.Infof("ProductList request metadata: %v", md)
-	log.Debugf("ProductList request data: %v", in)
-
-	ctx, cancel := context.WithTimeout(context.Background(), maxTimeout)
+	ctx, cancel := context.WithTimeout(tenants.Detach(srv.Context()), maxTimeout)
 	defer cancel()
 
 	allProducts, err := i.deps.ProductRepository.GetAllProducts(ctx, in.GetPage(), in.GetSize())
 	if err != nil {
-		i.deps.Metrics.FailedRequestCounter.Inc()
-		log.WithError(err).Error("ProductRepository: GetAllProducts: internal error")
-		return status.Error(codes.Internal, "internal error")
+		return err
 	}
 
 	for _, product := range allProducts {
@@ -65,54 +55,34 @@ func (i *implementation) ProductList(in *pb.ProductListRequest, srv pb.StorageSe
 		}
 	}
 
-	i.deps.Metrics.SuccessfulRequestCounter.Inc()
 	return nil
 }
 
 func (i *implementation) AsyncProductList(ctx context.Context, in *pb.AsyncProductListRequest) (*pb.AsyncProductListResponse, error) {
-	i.deps.Metrics.IncomingRequestCounter.Inc()
-
-	md, _ := metadata.FromIncomingContext(ctx)
-	log.Infof("AsyncProductList request metadata: %v", md)
-	log.Debugf("AsyncProductList request data: %v", in)
-
-	ctx, cancel := context.WithTimeout(context.Background(), maxTimeout)
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
 	defer cancel()
 
 	allProducts, err := i.deps.ProductRepository.GetAllProducts(ctx, in.GetPage(), in.GetSize())
 	if err != nil {
-		i.deps.Metrics.FailedRequestCounter.Inc()
-		log.WithError(err).Error("ProductRepository: GetAllProducts: internal error")
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, err
 	}
 
 	cacheData, err := json.Marshal(allProducts)
 	if err != nil {
-		log.WithError(err).Error("AsyncProductList: marshal products to cache")
-		i.deps.Metrics.FailedRequestCounter.Inc()
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, errors.Wrap(err, "AsyncProductList: marshal products to cache")
 	} else {
 		cacheKey := fmt.Sprintf("products:page:%d:size:%d", in.GetPage(), in.GetSize())
 		err = i.deps.Cache.Set(ctx, cacheKey, string(cacheData), time.Minute*1)
 		if err != nil {
-			log.WithError(err).Error("AsyncProductList: set products to cache")
-			i.deps.Metrics.FailedRequestCounter.Inc()
-			return nil, status.Error(codes.Internal, "internal error")
+			return nil, errors.Wrap(err, "AsyncProductList: set products to cache")
 		}
 	}
 
-	i.deps.Metrics.SuccessfulRequestCounter.Inc()
 	return &pb.AsyncProductListResponse{}, nil
 }
 
 func (i *implementation) ProductGet(ctx context.Context, in *pb.ProductGetRequest) (*pb.ProductGetResponse, error) {
-	i.deps.Metrics.IncomingRequestCounter.Inc()
-
-	md, _ := metadata.FromIncomingContext(ctx)
-	log.Infof("ProductGet request metadata: %v", md)
-	log.Debugf("ProductGet request data: %v", in)
-
-	ctx, cancel := context.WithTimeout(context.Background(), maxTimeout)
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
 	defer cancel()
 
 	val, err := i.deps.Cache.Get(ctx, fmt.Sprintf("product:%d", in.GetId()))
@@ -121,7 +91,6 @@ func (i *implementation) ProductGet(ctx context.Context, in *pb.ProductGetReques
 		if err = json.Unmarshal([]byte(val), &product); err != nil {
 			log.WithError(err).Error("ProductGet: unmarshal product from cache")
 		} else {
-			i.deps.Metrics.SuccessfulRequestCounter.Inc()
 			return &pb.ProductGetResponse{
 				Id:       product.GetId(),
 				Name:     product.GetName(),
@@ -133,13 +102,7 @@ func (i *implementation) ProductGet(ctx context.Context, in *pb.ProductGetReques
 
 	p, err := i.deps.ProductRepository.GetProductById(ctx, in.GetId())
 	if err != nil {
-		if errors.Is(err, repository.ProductNotExists) {
-			i.deps.Metrics.UnsuccessfulRequestCounter.Inc()
-			return nil, status.Error(codes.NotFound, err.Error())
-		}
-		i.deps.Metrics.FailedRequestCounter.Inc()
-		log.WithError(err).Error("ProductRepository: GetProductById: internal error")
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, err
 	}
 
 	cacheData, err := json.Marshal(*p)
@@ -152,7 +115,6 @@ func (i *implementation) ProductGet(ctx context.Context, in *pb.ProductGetReques
 		}
 	}
 
-	i.deps.Metrics.SuccessfulRequestCounter.Inc()
 	return &pb.ProductGetResponse{
 		Id:       p.GetId(),
 		Name:     p.GetName(),
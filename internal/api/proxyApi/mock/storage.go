@@ -36,6 +36,226 @@ func (m *MockStorageServiceClient) EXPECT() *MockStorageServiceClientMockRecorde
 	return m.recorder
 }
 
+// ApproveChange mocks base method.
+func (m *MockStorageServiceClient) ApproveChange(ctx context.Context, in *storage.ApproveChangeRequest, opts ...grpc.CallOption) (*storage.ApproveChangeResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ApproveChange", varargs...)
+	ret0, _ := ret[0].(*storage.ApproveChangeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveChange indicates an expected call of ApproveChange.
+func (mr *MockStorageServiceClientMockRecorder) ApproveChange(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveChange", reflect.TypeOf((*MockStorageServiceClient)(nil).ApproveChange), varargs...)
+}
+
+// DownloadProductImage mocks base method.
+func (m *MockStorageServiceClient) DownloadProductImage(ctx context.Context, in *storage.DownloadProductImageRequest, opts ...grpc.CallOption) (storage.StorageService_DownloadProductImageClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DownloadProductImage", varargs...)
+	ret0, _ := ret[0].(storage.StorageService_DownloadProductImageClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadProductImage indicates an expected call of DownloadProductImage.
+func (mr *MockStorageServiceClientMockRecorder) DownloadProductImage(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadProductImage", reflect.TypeOf((*MockStorageServiceClient)(nil).DownloadProductImage), varargs...)
+}
+
+// ExchangeRateList mocks base method.
+func (m *MockStorageServiceClient) ExchangeRateList(ctx context.Context, in *storage.ExchangeRateListRequest, opts ...grpc.CallOption) (storage.StorageService_ExchangeRateListClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ExchangeRateList", varargs...)
+	ret0, _ := ret[0].(storage.StorageService_ExchangeRateListClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExchangeRateList indicates an expected call of ExchangeRateList.
+func (mr *MockStorageServiceClientMockRecorder) ExchangeRateList(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExchangeRateList", reflect.TypeOf((*MockStorageServiceClient)(nil).ExchangeRateList), varargs...)
+}
+
+// ExchangeRateSet mocks base method.
+func (m *MockStorageServiceClient) ExchangeRateSet(ctx context.Context, in *storage.ExchangeRateSetRequest, opts ...grpc.CallOption) (*storage.ExchangeRateSetResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ExchangeRateSet", varargs...)
+	ret0, _ := ret[0].(*storage.ExchangeRateSetResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExchangeRateSet indicates an expected call of ExchangeRateSet.
+func (mr *MockStorageServiceClientMockRecorder) ExchangeRateSet(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExchangeRateSet", reflect.TypeOf((*MockStorageServiceClient)(nil).ExchangeRateSet), varargs...)
+}
+
+// GetRelatedProducts mocks base method.
+func (m *MockStorageServiceClient) GetRelatedProducts(ctx context.Context, in *storage.GetRelatedProductsRequest, opts ...grpc.CallOption) (storage.StorageService_GetRelatedProductsClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetRelatedProducts", varargs...)
+	ret0, _ := ret[0].(storage.StorageService_GetRelatedProductsClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRelatedProducts indicates an expected call of GetRelatedProducts.
+func (mr *MockStorageServiceClientMockRecorder) GetRelatedProducts(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRelatedProducts", reflect.TypeOf((*MockStorageServiceClient)(nil).GetRelatedProducts), varargs...)
+}
+
+// ListExpiringLots mocks base method.
+func (m *MockStorageServiceClient) ListExpiringLots(ctx context.Context, in *storage.ListExpiringLotsRequest, opts ...grpc.CallOption) (storage.StorageService_ListExpiringLotsClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListExpiringLots", varargs...)
+	ret0, _ := ret[0].(storage.StorageService_ListExpiringLotsClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiringLots indicates an expected call of ListExpiringLots.
+func (mr *MockStorageServiceClientMockRecorder) ListExpiringLots(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiringLots", reflect.TypeOf((*MockStorageServiceClient)(nil).ListExpiringLots), varargs...)
+}
+
+// ListLowStock mocks base method.
+func (m *MockStorageServiceClient) ListLowStock(ctx context.Context, in *storage.ListLowStockRequest, opts ...grpc.CallOption) (storage.StorageService_ListLowStockClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListLowStock", varargs...)
+	ret0, _ := ret[0].(storage.StorageService_ListLowStockClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLowStock indicates an expected call of ListLowStock.
+func (mr *MockStorageServiceClientMockRecorder) ListLowStock(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLowStock", reflect.TypeOf((*MockStorageServiceClient)(nil).ListLowStock), varargs...)
+}
+
+// ListProductImages mocks base method.
+func (m *MockStorageServiceClient) ListProductImages(ctx context.Context, in *storage.ListProductImagesRequest, opts ...grpc.CallOption) (storage.StorageService_ListProductImagesClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListProductImages", varargs...)
+	ret0, _ := ret[0].(storage.StorageService_ListProductImagesClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductImages indicates an expected call of ListProductImages.
+func (mr *MockStorageServiceClientMockRecorder) ListProductImages(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductImages", reflect.TypeOf((*MockStorageServiceClient)(nil).ListProductImages), varargs...)
+}
+
+// LotAdd mocks base method.
+func (m *MockStorageServiceClient) LotAdd(ctx context.Context, in *storage.LotAddRequest, opts ...grpc.CallOption) (*storage.LotAddResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "LotAdd", varargs...)
+	ret0, _ := ret[0].(*storage.LotAddResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LotAdd indicates an expected call of LotAdd.
+func (mr *MockStorageServiceClientMockRecorder) LotAdd(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LotAdd", reflect.TypeOf((*MockStorageServiceClient)(nil).LotAdd), varargs...)
+}
+
+// LotList mocks base method.
+func (m *MockStorageServiceClient) LotList(ctx context.Context, in *storage.LotListRequest, opts ...grpc.CallOption) (storage.StorageService_LotListClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "LotList", varargs...)
+	ret0, _ := ret[0].(storage.StorageService_LotListClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LotList indicates an expected call of LotList.
+func (mr *MockStorageServiceClientMockRecorder) LotList(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LotList", reflect.TypeOf((*MockStorageServiceClient)(nil).LotList), varargs...)
+}
+
+// PlaceOrder mocks base method.
+func (m *MockStorageServiceClient) PlaceOrder(ctx context.Context, in *storage.PlaceOrderRequest, opts ...grpc.CallOption) (*storage.PlaceOrderResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PlaceOrder", varargs...)
+	ret0, _ := ret[0].(*storage.PlaceOrderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlaceOrder indicates an expected call of PlaceOrder.
+func (mr *MockStorageServiceClientMockRecorder) PlaceOrder(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlaceOrder", reflect.TypeOf((*MockStorageServiceClient)(nil).PlaceOrder), varargs...)
+}
+
 // ProductCreate mocks base method.
 func (m *MockStorageServiceClient) ProductCreate(ctx context.Context, in *storage.ProductCreateRequest, opts ...grpc.CallOption) (*storage.ProductCreateResponse, error) {
 	m.ctrl.T.Helper()
@@ -96,6 +316,26 @@ func (mr *MockStorageServiceClientMockRecorder) ProductGet(ctx, in interface{},
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProductGet", reflect.TypeOf((*MockStorageServiceClient)(nil).ProductGet), varargs...)
 }
 
+// ProductGetByBarcode mocks base method.
+func (m *MockStorageServiceClient) ProductGetByBarcode(ctx context.Context, in *storage.ProductGetByBarcodeRequest, opts ...grpc.CallOption) (*storage.ProductGetByBarcodeResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ProductGetByBarcode", varargs...)
+	ret0, _ := ret[0].(*storage.ProductGetByBarcodeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProductGetByBarcode indicates an expected call of ProductGetByBarcode.
+func (mr *MockStorageServiceClientMockRecorder) ProductGetByBarcode(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProductGetByBarcode", reflect.TypeOf((*MockStorageServiceClient)(nil).ProductGetByBarcode), varargs...)
+}
+
 // ProductList mocks base method.
 func (m *MockStorageServiceClient) ProductList(ctx context.Context, in *storage.ProductListRequest, opts ...grpc.CallOption) (storage.StorageService_ProductListClient, error) {
 	m.ctrl.T.Helper()
@@ -116,6 +356,46 @@ func (mr *MockStorageServiceClientMockRecorder) ProductList(ctx, in interface{},
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProductList", reflect.TypeOf((*MockStorageServiceClient)(nil).ProductList), varargs...)
 }
 
+// ProductTransition mocks base method.
+func (m *MockStorageServiceClient) ProductTransition(ctx context.Context, in *storage.ProductTransitionRequest, opts ...grpc.CallOption) (*storage.ProductTransitionResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ProductTransition", varargs...)
+	ret0, _ := ret[0].(*storage.ProductTransitionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProductTransition indicates an expected call of ProductTransition.
+func (mr *MockStorageServiceClientMockRecorder) ProductTransition(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProductTransition", reflect.TypeOf((*MockStorageServiceClient)(nil).ProductTransition), varargs...)
+}
+
+// ProductTranslate mocks base method.
+func (m *MockStorageServiceClient) ProductTranslate(ctx context.Context, in *storage.ProductTranslateRequest, opts ...grpc.CallOption) (*storage.ProductTranslateResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ProductTranslate", varargs...)
+	ret0, _ := ret[0].(*storage.ProductTranslateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProductTranslate indicates an expected call of ProductTranslate.
+func (mr *MockStorageServiceClientMockRecorder) ProductTranslate(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProductTranslate", reflect.TypeOf((*MockStorageServiceClient)(nil).ProductTranslate), varargs...)
+}
+
 // ProductUpdate mocks base method.
 func (m *MockStorageServiceClient) ProductUpdate(ctx context.Context, in *storage.ProductUpdateRequest, opts ...grpc.CallOption) (*storage.ProductUpdateResponse, error) {
 	m.ctrl.T.Helper()
@@ -135,3 +415,383 @@ func (mr *MockStorageServiceClientMockRecorder) ProductUpdate(ctx, in interface{
 	varargs := append([]interface{}{ctx, in}, opts...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProductUpdate", reflect.TypeOf((*MockStorageServiceClient)(nil).ProductUpdate), varargs...)
 }
+
+// PurchaseOrderCreate mocks base method.
+func (m *MockStorageServiceClient) PurchaseOrderCreate(ctx context.Context, in *storage.PurchaseOrderCreateRequest, opts ...grpc.CallOption) (*storage.PurchaseOrderCreateResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PurchaseOrderCreate", varargs...)
+	ret0, _ := ret[0].(*storage.PurchaseOrderCreateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurchaseOrderCreate indicates an expected call of PurchaseOrderCreate.
+func (mr *MockStorageServiceClientMockRecorder) PurchaseOrderCreate(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurchaseOrderCreate", reflect.TypeOf((*MockStorageServiceClient)(nil).PurchaseOrderCreate), varargs...)
+}
+
+// PurchaseOrderGet mocks base method.
+func (m *MockStorageServiceClient) PurchaseOrderGet(ctx context.Context, in *storage.PurchaseOrderGetRequest, opts ...grpc.CallOption) (*storage.PurchaseOrderGetResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PurchaseOrderGet", varargs...)
+	ret0, _ := ret[0].(*storage.PurchaseOrderGetResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurchaseOrderGet indicates an expected call of PurchaseOrderGet.
+func (mr *MockStorageServiceClientMockRecorder) PurchaseOrderGet(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurchaseOrderGet", reflect.TypeOf((*MockStorageServiceClient)(nil).PurchaseOrderGet), varargs...)
+}
+
+// PurchaseOrderList mocks base method.
+func (m *MockStorageServiceClient) PurchaseOrderList(ctx context.Context, in *storage.PurchaseOrderListRequest, opts ...grpc.CallOption) (storage.StorageService_PurchaseOrderListClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PurchaseOrderList", varargs...)
+	ret0, _ := ret[0].(storage.StorageService_PurchaseOrderListClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurchaseOrderList indicates an expected call of PurchaseOrderList.
+func (mr *MockStorageServiceClientMockRecorder) PurchaseOrderList(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurchaseOrderList", reflect.TypeOf((*MockStorageServiceClient)(nil).PurchaseOrderList), varargs...)
+}
+
+// PurchaseOrderReceive mocks base method.
+func (m *MockStorageServiceClient) PurchaseOrderReceive(ctx context.Context, in *storage.PurchaseOrderReceiveRequest, opts ...grpc.CallOption) (*storage.PurchaseOrderReceiveResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PurchaseOrderReceive", varargs...)
+	ret0, _ := ret[0].(*storage.PurchaseOrderReceiveResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurchaseOrderReceive indicates an expected call of PurchaseOrderReceive.
+func (mr *MockStorageServiceClientMockRecorder) PurchaseOrderReceive(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurchaseOrderReceive", reflect.TypeOf((*MockStorageServiceClient)(nil).PurchaseOrderReceive), varargs...)
+}
+
+// RejectChange mocks base method.
+func (m *MockStorageServiceClient) RejectChange(ctx context.Context, in *storage.RejectChangeRequest, opts ...grpc.CallOption) (*storage.RejectChangeResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RejectChange", varargs...)
+	ret0, _ := ret[0].(*storage.RejectChangeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectChange indicates an expected call of RejectChange.
+func (mr *MockStorageServiceClientMockRecorder) RejectChange(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectChange", reflect.TypeOf((*MockStorageServiceClient)(nil).RejectChange), varargs...)
+}
+
+// RelationCreate mocks base method.
+func (m *MockStorageServiceClient) RelationCreate(ctx context.Context, in *storage.RelationCreateRequest, opts ...grpc.CallOption) (*storage.RelationCreateResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RelationCreate", varargs...)
+	ret0, _ := ret[0].(*storage.RelationCreateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RelationCreate indicates an expected call of RelationCreate.
+func (mr *MockStorageServiceClientMockRecorder) RelationCreate(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelationCreate", reflect.TypeOf((*MockStorageServiceClient)(nil).RelationCreate), varargs...)
+}
+
+// RelationDelete mocks base method.
+func (m *MockStorageServiceClient) RelationDelete(ctx context.Context, in *storage.RelationDeleteRequest, opts ...grpc.CallOption) (*storage.RelationDeleteResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RelationDelete", varargs...)
+	ret0, _ := ret[0].(*storage.RelationDeleteResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RelationDelete indicates an expected call of RelationDelete.
+func (mr *MockStorageServiceClientMockRecorder) RelationDelete(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelationDelete", reflect.TypeOf((*MockStorageServiceClient)(nil).RelationDelete), varargs...)
+}
+
+// RelationList mocks base method.
+func (m *MockStorageServiceClient) RelationList(ctx context.Context, in *storage.RelationListRequest, opts ...grpc.CallOption) (storage.StorageService_RelationListClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RelationList", varargs...)
+	ret0, _ := ret[0].(storage.StorageService_RelationListClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RelationList indicates an expected call of RelationList.
+func (mr *MockStorageServiceClientMockRecorder) RelationList(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelationList", reflect.TypeOf((*MockStorageServiceClient)(nil).RelationList), varargs...)
+}
+
+// RelationUpdate mocks base method.
+func (m *MockStorageServiceClient) RelationUpdate(ctx context.Context, in *storage.RelationUpdateRequest, opts ...grpc.CallOption) (*storage.RelationUpdateResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RelationUpdate", varargs...)
+	ret0, _ := ret[0].(*storage.RelationUpdateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RelationUpdate indicates an expected call of RelationUpdate.
+func (mr *MockStorageServiceClientMockRecorder) RelationUpdate(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelationUpdate", reflect.TypeOf((*MockStorageServiceClient)(nil).RelationUpdate), varargs...)
+}
+
+// SetReorderThreshold mocks base method.
+func (m *MockStorageServiceClient) SetReorderThreshold(ctx context.Context, in *storage.SetReorderThresholdRequest, opts ...grpc.CallOption) (*storage.SetReorderThresholdResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetReorderThreshold", varargs...)
+	ret0, _ := ret[0].(*storage.SetReorderThresholdResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetReorderThreshold indicates an expected call of SetReorderThreshold.
+func (mr *MockStorageServiceClientMockRecorder) SetReorderThreshold(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReorderThreshold", reflect.TypeOf((*MockStorageServiceClient)(nil).SetReorderThreshold), varargs...)
+}
+
+// StockValuation mocks base method.
+func (m *MockStorageServiceClient) StockValuation(ctx context.Context, in *storage.StockValuationRequest, opts ...grpc.CallOption) (*storage.StockValuationResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StockValuation", varargs...)
+	ret0, _ := ret[0].(*storage.StockValuationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StockValuation indicates an expected call of StockValuation.
+func (mr *MockStorageServiceClientMockRecorder) StockValuation(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StockValuation", reflect.TypeOf((*MockStorageServiceClient)(nil).StockValuation), varargs...)
+}
+
+// StocktakeCommit mocks base method.
+func (m *MockStorageServiceClient) StocktakeCommit(ctx context.Context, in *storage.StocktakeCommitRequest, opts ...grpc.CallOption) (*storage.StocktakeCommitResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StocktakeCommit", varargs...)
+	ret0, _ := ret[0].(*storage.StocktakeCommitResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StocktakeCommit indicates an expected call of StocktakeCommit.
+func (mr *MockStorageServiceClientMockRecorder) StocktakeCommit(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StocktakeCommit", reflect.TypeOf((*MockStorageServiceClient)(nil).StocktakeCommit), varargs...)
+}
+
+// StocktakeCount mocks base method.
+func (m *MockStorageServiceClient) StocktakeCount(ctx context.Context, in *storage.StocktakeCountRequest, opts ...grpc.CallOption) (*storage.StocktakeCountResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StocktakeCount", varargs...)
+	ret0, _ := ret[0].(*storage.StocktakeCountResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StocktakeCount indicates an expected call of StocktakeCount.
+func (mr *MockStorageServiceClientMockRecorder) StocktakeCount(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StocktakeCount", reflect.TypeOf((*MockStorageServiceClient)(nil).StocktakeCount), varargs...)
+}
+
+// StocktakeGet mocks base method.
+func (m *MockStorageServiceClient) StocktakeGet(ctx context.Context, in *storage.StocktakeGetRequest, opts ...grpc.CallOption) (*storage.StocktakeGetResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StocktakeGet", varargs...)
+	ret0, _ := ret[0].(*storage.StocktakeGetResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StocktakeGet indicates an expected call of StocktakeGet.
+func (mr *MockStorageServiceClientMockRecorder) StocktakeGet(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StocktakeGet", reflect.TypeOf((*MockStorageServiceClient)(nil).StocktakeGet), varargs...)
+}
+
+// StocktakeOpen mocks base method.
+func (m *MockStorageServiceClient) StocktakeOpen(ctx context.Context, in *storage.StocktakeOpenRequest, opts ...grpc.CallOption) (*storage.StocktakeOpenResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StocktakeOpen", varargs...)
+	ret0, _ := ret[0].(*storage.StocktakeOpenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StocktakeOpen indicates an expected call of StocktakeOpen.
+func (mr *MockStorageServiceClientMockRecorder) StocktakeOpen(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StocktakeOpen", reflect.TypeOf((*MockStorageServiceClient)(nil).StocktakeOpen), varargs...)
+}
+
+// SupplierCreate mocks base method.
+func (m *MockStorageServiceClient) SupplierCreate(ctx context.Context, in *storage.SupplierCreateRequest, opts ...grpc.CallOption) (*storage.SupplierCreateResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SupplierCreate", varargs...)
+	ret0, _ := ret[0].(*storage.SupplierCreateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SupplierCreate indicates an expected call of SupplierCreate.
+func (mr *MockStorageServiceClientMockRecorder) SupplierCreate(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SupplierCreate", reflect.TypeOf((*MockStorageServiceClient)(nil).SupplierCreate), varargs...)
+}
+
+// SupplierList mocks base method.
+func (m *MockStorageServiceClient) SupplierList(ctx context.Context, in *storage.SupplierListRequest, opts ...grpc.CallOption) (storage.StorageService_SupplierListClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SupplierList", varargs...)
+	ret0, _ := ret[0].(storage.StorageService_SupplierListClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SupplierList indicates an expected call of SupplierList.
+func (mr *MockStorageServiceClientMockRecorder) SupplierList(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SupplierList", reflect.TypeOf((*MockStorageServiceClient)(nil).SupplierList), varargs...)
+}
+
+// TopProductsByValue mocks base method.
+func (m *MockStorageServiceClient) TopProductsByValue(ctx context.Context, in *storage.TopProductsByValueRequest, opts ...grpc.CallOption) (storage.StorageService_TopProductsByValueClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "TopProductsByValue", varargs...)
+	ret0, _ := ret[0].(storage.StorageService_TopProductsByValueClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopProductsByValue indicates an expected call of TopProductsByValue.
+func (mr *MockStorageServiceClientMockRecorder) TopProductsByValue(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopProductsByValue", reflect.TypeOf((*MockStorageServiceClient)(nil).TopProductsByValue), varargs...)
+}
+
+// UploadProductImage mocks base method.
+func (m *MockStorageServiceClient) UploadProductImage(ctx context.Context, opts ...grpc.CallOption) (storage.StorageService_UploadProductImageClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UploadProductImage", varargs...)
+	ret0, _ := ret[0].(storage.StorageService_UploadProductImageClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadProductImage indicates an expected call of UploadProductImage.
+func (mr *MockStorageServiceClientMockRecorder) UploadProductImage(ctx interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadProductImage", reflect.TypeOf((*MockStorageServiceClient)(nil).UploadProductImage), varargs...)
+}
@@ -4,12 +4,19 @@ package proxyApi
 
 import (
 	"context"
-	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
-	"homework-1/internal/metrics"
+	"homework-1/internal/interceptors"
+	"homework-1/internal/locales"
+	"homework-1/internal/models/images"
+	"homework-1/internal/models/lots"
+	"homework-1/internal/models/orders"
 	"homework-1/internal/models/products"
+	"homework-1/internal/models/purchases"
+	"homework-1/internal/models/relations"
+	"homework-1/internal/models/units"
+	"homework-1/internal/money"
+	"homework-1/internal/tenants"
 	pbStorage "homework-1/pkg/api/storage/v1"
 	pbApi "homework-1/pkg/api/v1"
 	"io"
@@ -19,6 +26,9 @@ import (
 
 const maxTimeout = time.Millisecond * 30
 
+// maxTransferTimeout bounds image uploads and downloads, which take longer than other requests
+const maxTransferTimeout = time.Second * 30
+
 type StorageServiceClient interface {
 	pbStorage.StorageServiceClient
 }
@@ -36,29 +46,31 @@ type implementation struct {
 
 type Deps struct {
 	StorageClient StorageServiceClient
-	Metrics       *metrics.Metrics
 }
 
 func (i *implementation) ProductList(ctx context.Context, in *pbApi.ProductListRequest) (*pbApi.ProductListResponse, error) {
-	i.deps.Metrics.IncomingRequestCounter.Inc()
-
-	md, _ := metadata.FromIncomingContext(ctx)
-	log.Infof("ProductList request metadata: %v", md)
-	log.Debugf("ProductList request data: %v", in)
-
-	ctx, cancel := context.WithTimeout(context.Background(), maxTimeout)
+	locale := locales.FromIncomingContext(ctx)
+	currency, err := money.FromIncomingContext(ctx)
+	if err != nil {
+		return nil, interceptors.Invalid(err)
+	}
+	outgoing := money.AppendToOutgoingContext(locales.AppendToOutgoingContext(tenants.Detach(ctx), locale), currency)
+	ctx, cancel := context.WithTimeout(outgoing, maxTimeout)
 	defer cancel()
 
 	pageNum := in.GetPage()
 	pageSize := in.GetSize()
 
-	i.deps.Metrics.OutgoingRequestCounter.Inc()
-	request := pbStorage.ProductListRequest{Page: &pageNum, Size: &pageSize}
+	request := pbStorage.ProductListRequest{
+		Page:       &pageNum,
+		Size:       &pageSize,
+		Status:     in.Status,
+		Category:   in.Category,
+		Attributes: in.GetAttributes(),
+	}
 	productStream, err := i.deps.StorageClient.ProductList(ctx, &request)
 	if err != nil {
-		i.deps.Metrics.FailedRequestCounter.Inc()
-		log.WithError(err).Error("StorageClient: ProductList: internal error")
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, err
 	}
 
 	var result []*pbApi.ProductListResponse_Product
@@ -68,166 +80,1278 @@ func (i *implementation) ProductList(ctx context.Context, in *pbApi.ProductListR
 			break
 		}
 		if err != nil {
-			i.deps.Metrics.FailedRequestCounter.Inc()
-			log.WithError(err).Error("StorageClient: ProductList: receive internal error")
-			return nil, status.Error(codes.Internal, "internal error")
+			return nil, err
 		}
 		result = append(result, &pbApi.ProductListResponse_Product{
-			Id:       product.GetId(),
-			Name:     product.GetName(),
-			Price:    product.GetPrice(),
-			Quantity: product.GetQuantity(),
+			Id:          product.GetId(),
+			Name:        product.GetName(),
+			Price:       product.GetPrice(),
+			Quantity:    product.GetQuantity(),
+			Status:      product.GetStatus(),
+			Unit:        product.GetUnit(),
+			Amount:      product.GetAmount(),
+			Barcode:     product.GetBarcode(),
+			Category:    product.GetCategory(),
+			Attributes:  product.GetAttributes(),
+			Description: product.GetDescription(),
+			Locale:      product.GetLocale(),
+			Currency:    product.GetCurrency(),
 		})
 	}
 
-	i.deps.Metrics.SuccessfulRequestCounter.Inc()
 	return &pbApi.ProductListResponse{
 		Products: result,
 	}, nil
 }
 
 func (i *implementation) ProductGet(ctx context.Context, in *pbApi.ProductGetRequest) (*pbApi.ProductGetResponse, error) {
-	i.deps.Metrics.IncomingRequestCounter.Inc()
-
-	md, _ := metadata.FromIncomingContext(ctx)
-	log.Infof("ProductGet request metadata: %v", md)
-	log.Debugf("ProductGet request data: %v", in)
-
-	ctx, cancel := context.WithTimeout(context.Background(), maxTimeout)
+	locale := locales.FromIncomingContext(ctx)
+	currency, err := money.FromIncomingContext(ctx)
+	if err != nil {
+		return nil, interceptors.Invalid(err)
+	}
+	outgoing := money.AppendToOutgoingContext(locales.AppendToOutgoingContext(tenants.Detach(ctx), locale), currency)
+	ctx, cancel := context.WithTimeout(outgoing, maxTimeout)
 	defer cancel()
 
-	i.deps.Metrics.OutgoingRequestCounter.Inc()
 	product, err := i.deps.StorageClient.ProductGet(ctx, &pbStorage.ProductGetRequest{Id: in.GetId()})
 	if err != nil {
 		if status.Code(err) == codes.NotFound {
-			i.deps.Metrics.UnsuccessfulRequestCounter.Inc()
 			return nil, status.Error(codes.NotFound, "product not found")
 		}
-		i.deps.Metrics.FailedRequestCounter.Inc()
-		log.WithError(err).Error("StorageClient: ProductGet: internal error")
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, err
 	}
 
-	i.deps.Metrics.SuccessfulRequestCounter.Inc()
 	return &pbApi.ProductGetResponse{
-		Id:       product.GetId(),
-		Name:     product.GetName(),
-		Price:    product.GetPrice(),
-		Quantity: product.GetQuantity(),
+		Id:          product.GetId(),
+		Name:        product.GetName(),
+		Price:       product.GetPrice(),
+		Quantity:    product.GetQuantity(),
+		Status:      product.GetStatus(),
+		Unit:        product.GetUnit(),
+		Amount:      product.GetAmount(),
+		Barcode:     product.GetBarcode(),
+		Category:    product.GetCategory(),
+		Attributes:  product.GetAttributes(),
+		Description: product.GetDescription(),
+		Locale:      product.GetLocale(),
+		Currency:    product.GetCurrency(),
 	}, nil
 }
 
-func (i *implementation) ProductCreate(ctx context.Context, in *pbApi.ProductCreateRequest) (*pbApi.ProductCreateResponse, error) {
-	i.deps.Metrics.IncomingRequestCounter.Inc()
+func (i *implementation) ProductGetByBarcode(ctx context.Context, in *pbApi.ProductGetByBarcodeRequest) (*pbApi.ProductGetByBarcodeResponse, error) {
+	locale := locales.FromIncomingContext(ctx)
+	currency, err := money.FromIncomingContext(ctx)
+	if err != nil {
+		return nil, interceptors.Invalid(err)
+	}
+	outgoing := money.AppendToOutgoingContext(locales.AppendToOutgoingContext(tenants.Detach(ctx), locale), currency)
+	ctx, cancel := context.WithTimeout(outgoing, maxTimeout)
+	defer cancel()
+
+	if err := products.ValidateBarcode(in.GetBarcode()); err != nil {
+		return nil, interceptors.Invalid(err)
+	}
+
+	product, err := i.deps.StorageClient.ProductGetByBarcode(ctx, &pbStorage.ProductGetByBarcodeRequest{Barcode: in.GetBarcode()})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Error(codes.NotFound, "product not found")
+		}
+		return nil, err
+	}
+
+	return &pbApi.ProductGetByBarcodeResponse{
+		Id:          product.GetId(),
+		Name:        product.GetName(),
+		Price:       product.GetPrice(),
+		Quantity:    product.GetQuantity(),
+		Status:      product.GetStatus(),
+		Unit:        product.GetUnit(),
+		Amount:      product.GetAmount(),
+		Barcode:     product.GetBarcode(),
+		Category:    product.GetCategory(),
+		Attributes:  product.GetAttributes(),
+		Description: product.GetDescription(),
+		Locale:      product.GetLocale(),
+		Currency:    product.GetCurrency(),
+	}, nil
+}
+
+func (i *implementation) ProductTranslate(ctx context.Context, in *pbApi.ProductTranslateRequest) (*pbApi.ProductTranslateResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	locale, err := locales.Parse(in.GetLocale())
+	if err != nil {
+		return nil, interceptors.Invalid(err)
+	}
+
+	translation := products.Translation{
+		ProductId:   in.GetId(),
+		Locale:      locale,
+		Name:        in.GetName(),
+		Description: in.GetDescription(),
+	}
+	if err = products.ValidateTranslation(translation); err != nil {
+		return nil, interceptors.Invalid(err)
+	}
+
+	request := pbStorage.ProductTranslateRequest{
+		Id:          in.GetId(),
+		Locale:      locale.String(),
+		Name:        in.GetName(),
+		Description: in.GetDescription(),
+	}
+
+	saved, err := i.deps.StorageClient.ProductTranslate(ctx, &request)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Error(codes.NotFound, "product not found")
+		}
+		return nil, err
+	}
 
-	md, _ := metadata.FromIncomingContext(ctx)
-	log.Infof("ProductCreate request metadata: %v", md)
-	log.Debugf("ProductCreate request data: %v", in)
+	return &pbApi.ProductTranslateResponse{
+		Id:          saved.GetId(),
+		Locale:      saved.GetLocale(),
+		Name:        saved.GetName(),
+		Description: saved.GetDescription(),
+	}, nil
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), maxTimeout)
+func (i *implementation) ProductCreate(ctx context.Context, in *pbApi.ProductCreateRequest) (*pbApi.ProductCreateResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
 	defer cancel()
 
-	if errs := products.ValidateProductFields(in.GetName(), in.GetPrice(), in.GetQuantity()); len(errs) > 0 {
+	if errs := validateProductFields(in.GetName(), in.GetPrice(), in.GetQuantity(), in.Amount); len(errs) > 0 {
 		errStrings := make([]string, 0, len(errs))
 		for _, err := range errs {
 			errStrings = append(errStrings, err.Error())
 		}
-		i.deps.Metrics.UnsuccessfulRequestCounter.Inc()
 		return nil, status.Error(codes.InvalidArgument, strings.Join(errStrings, "; "))
 	}
 
+	if in.Unit != nil {
+		if _, err := units.Parse(in.GetUnit()); err != nil {
+			return nil, interceptors.Invalid(err)
+		}
+	}
+
+	if in.Barcode != nil {
+		if _, err := products.ParseBarcode(in.GetBarcode()); err != nil {
+			return nil, interceptors.Invalid(err)
+		}
+	}
+
+	if in.GetStatus() != "" {
+		if err := products.ValidateStatus(products.Status(in.GetStatus())); err != nil {
+			return nil, interceptors.Invalid(err)
+		}
+	}
+
 	request := pbStorage.ProductCreateRequest{
-		Name:     in.GetName(),
-		Price:    in.GetPrice(),
-		Quantity: in.GetQuantity(),
+		Name:       in.GetName(),
+		Price:      in.GetPrice(),
+		Quantity:   in.GetQuantity(),
+		Status:     in.GetStatus(),
+		Unit:       in.Unit,
+		Amount:     in.Amount,
+		Barcode:    in.Barcode,
+		Category:   in.Category,
+		Attributes: in.GetAttributes(),
 	}
 
-	i.deps.Metrics.OutgoingRequestCounter.Inc()
 	product, err := i.deps.StorageClient.ProductCreate(ctx, &request)
 	if err != nil {
-		i.deps.Metrics.FailedRequestCounter.Inc()
-		log.WithError(err).Error("StorageClient: ProductCreate: internal error")
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, err
 	}
 
-	i.deps.Metrics.SuccessfulRequestCounter.Inc()
 	return &pbApi.ProductCreateResponse{
-		Id:       product.GetId(),
-		Name:     product.GetName(),
-		Price:    product.GetPrice(),
-		Quantity: product.GetQuantity(),
+		Id:         product.GetId(),
+		Name:       product.GetName(),
+		Price:      product.GetPrice(),
+		Quantity:   product.GetQuantity(),
+		Status:     product.GetStatus(),
+		Unit:       product.GetUnit(),
+		Amount:     product.GetAmount(),
+		Barcode:    product.GetBarcode(),
+		Category:   product.GetCategory(),
+		Attributes: product.GetAttributes(),
 	}, nil
 }
 
 func (i *implementation) ProductUpdate(ctx context.Context, in *pbApi.ProductUpdateRequest) (*pbApi.ProductUpdateResponse, error) {
-	i.deps.Metrics.IncomingRequestCounter.Inc()
-
-	md, _ := metadata.FromIncomingContext(ctx)
-	log.Infof("ProductUpdate request metadata: %v", md)
-	log.Debugf("ProductUpdate request data: %v", in)
-
-	ctx, cancel := context.WithTimeout(context.Background(), maxTimeout)
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
 	defer cancel()
 
-	if errs := products.ValidateProductFields(in.GetName(), in.GetPrice(), in.GetQuantity()); len(errs) > 0 {
+	if errs := validateProductFields(in.GetName(), in.GetPrice(), in.GetQuantity(), in.Amount); len(errs) > 0 {
 		errStrings := make([]string, 0, len(errs))
 		for _, err := range errs {
 			errStrings = append(errStrings, err.Error())
 		}
-		i.deps.Metrics.UnsuccessfulRequestCounter.Inc()
 		return nil, status.Error(codes.InvalidArgument, strings.Join(errStrings, "; "))
 	}
 
+	if in.Unit != nil {
+		if _, err := units.Parse(in.GetUnit()); err != nil {
+			return nil, interceptors.Invalid(err)
+		}
+	}
+
+	if in.Barcode != nil {
+		if _, err := products.ParseBarcode(in.GetBarcode()); err != nil {
+			return nil, interceptors.Invalid(err)
+		}
+	}
+
 	request := pbStorage.ProductUpdateRequest{
-		Id:       in.GetId(),
-		Name:     in.GetName(),
-		Price:    in.GetPrice(),
-		Quantity: in.GetQuantity(),
+		Id:         in.GetId(),
+		Name:       in.GetName(),
+		Price:      in.GetPrice(),
+		Quantity:   in.GetQuantity(),
+		Unit:       in.Unit,
+		Amount:     in.Amount,
+		Barcode:    in.Barcode,
+		Category:   in.Category,
+		Attributes: in.GetAttributes(),
 	}
 
-	i.deps.Metrics.OutgoingRequestCounter.Inc()
 	product, err := i.deps.StorageClient.ProductUpdate(ctx, &request)
 	if err != nil {
 		if status.Code(err) == codes.NotFound {
-			i.deps.Metrics.UnsuccessfulRequestCounter.Inc()
 			return nil, status.Error(codes.NotFound, "product not found")
 		}
-		i.deps.Metrics.FailedRequestCounter.Inc()
-		log.WithError(err).Error("StorageClient: ProductUpdate: internal error")
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, err
 	}
 
-	i.deps.Metrics.SuccessfulRequestCounter.Inc()
 	return &pbApi.ProductUpdateResponse{
+		Id:         product.GetId(),
+		Name:       product.GetName(),
+		Price:      product.GetPrice(),
+		Quantity:   product.GetQuantity(),
+		Status:     product.GetStatus(),
+		Unit:       product.GetUnit(),
+		Amount:     product.GetAmount(),
+		Barcode:    product.GetBarcode(),
+		Category:   product.GetCategory(),
+		Attributes: product.GetAttributes(),
+		ChangeId:   product.GetChangeId(),
+	}, nil
+}
+
+func (i *implementation) ProductDelete(ctx context.Context, in *pbApi.ProductDeleteRequest) (*pbApi.ProductDeleteResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	_, err := i.deps.StorageClient.ProductDelete(ctx, &pbStorage.ProductDeleteRequest{Id: in.GetId()})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Error(codes.NotFound, "product not found")
+		}
+		return nil, err
+	}
+
+	return &pbApi.ProductDeleteResponse{}, nil
+}
+
+func (i *implementation) ProductTransition(ctx context.Context, in *pbApi.ProductTransitionRequest) (*pbApi.ProductTransitionResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	errs := products.ValidateStatusTransitionFields(products.Status(in.GetStatus()), in.GetReason())
+	if len(errs) > 0 {
+		errStrings := make([]string, 0, len(errs))
+		for _, err := range errs {
+			errStrings = append(errStrings, err.Error())
+		}
+		return nil, status.Error(codes.InvalidArgument, strings.Join(errStrings, "; "))
+	}
+
+	request := pbStorage.ProductTransitionRequest{
+		Id:     in.GetId(),
+		Status: in.GetStatus(),
+		Reason: in.GetReason(),
+	}
+
+	product, err := i.deps.StorageClient.ProductTransition(ctx, &request)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Error(codes.NotFound, "product not found")
+		}
+		return nil, err
+	}
+
+	return &pbApi.ProductTransitionResponse{
 		Id:       product.GetId(),
 		Name:     product.GetName(),
 		Price:    product.GetPrice(),
 		Quantity: product.GetQuantity(),
+		Status:   product.GetStatus(),
 	}, nil
 }
 
-func (i *implementation) ProductDelete(ctx context.Context, in *pbApi.ProductDeleteRequest) (*pbApi.ProductDeleteResponse, error) {
-	i.deps.Metrics.IncomingRequestCounter.Inc()
+func (i *implementation) ApproveChange(ctx context.Context, in *pbApi.ApproveChangeRequest) (*pbApi.ApproveChangeResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	// storage resolves the change as the forwarded caller, the approver of the request is ignored
+	request := pbStorage.ApproveChangeRequest{Id: in.GetId()}
+
+	change, err := i.deps.StorageClient.ApproveChange(ctx, &request)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Error(codes.NotFound, "change not found")
+		}
+		return nil, err
+	}
+
+	return &pbApi.ApproveChangeResponse{
+		Id:          change.GetId(),
+		ProductId:   change.GetProductId(),
+		Name:        change.GetName(),
+		OldPrice:    change.GetOldPrice(),
+		Price:       change.GetPrice(),
+		Quantity:    change.GetQuantity(),
+		Status:      change.GetStatus(),
+		RequestedBy: change.GetRequestedBy(),
+		ResolvedBy:  change.GetResolvedBy(),
+	}, nil
+}
+
+func (i *implementation) RejectChange(ctx context.Context, in *pbApi.RejectChangeRequest) (*pbApi.RejectChangeResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	// storage resolves the change as the forwarded caller, the approver of the request is ignored
+	request := pbStorage.RejectChangeRequest{Id: in.GetId()}
+
+	change, err := i.deps.StorageClient.RejectChange(ctx, &request)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Error(codes.NotFound, "change not found")
+		}
+		return nil, err
+	}
 
-	md, _ := metadata.FromIncomingContext(ctx)
-	log.Infof("ProductDelete request metadata: %v", md)
-	log.Debugf("ProductDelete request data: %v", in)
+	return &pbApi.RejectChangeResponse{
+		Id:          change.GetId(),
+		ProductId:   change.GetProductId(),
+		Name:        change.GetName(),
+		OldPrice:    change.GetOldPrice(),
+		Price:       change.GetPrice(),
+		Quantity:    change.GetQuantity(),
+		Status:      change.GetStatus(),
+		RequestedBy: change.GetRequestedBy(),
+		ResolvedBy:  change.GetResolvedBy(),
+	}, nil
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), maxTimeout)
+func (i *implementation) ListLowStock(ctx context.Context, in *pbApi.ListLowStockRequest) (*pbApi.ListLowStockResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
 	defer cancel()
 
-	i.deps.Metrics.OutgoingRequestCounter.Inc()
-	_, err := i.deps.StorageClient.ProductDelete(ctx, &pbStorage.ProductDeleteRequest{Id: in.GetId()})
+	pageNum := in.GetPage()
+	pageSize := in.GetSize()
+
+	request := pbStorage.ListLowStockRequest{Page: &pageNum, Size: &pageSize}
+	lowStockStream, err := i.deps.StorageClient.ListLowStock(ctx, &request)
 	if err != nil {
+		return nil, err
+	}
+
+	var result []*pbApi.ListLowStockResponse_Product
+	for {
+		item, err := lowStockStream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, &pbApi.ListLowStockResponse_Product{
+			ProductId: item.GetProductId(),
+			Name:      item.GetName(),
+			Quantity:  item.GetQuantity(),
+			Threshold: item.GetThreshold(),
+		})
+	}
+
+	return &pbApi.ListLowStockResponse{
+		Products: result,
+	}, nil
+}
+
+func (i *implementation) SetReorderThreshold(ctx context.Context, in *pbApi.SetReorderThresholdRequest) (*pbApi.SetReorderThresholdResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	request := pbStorage.SetReorderThresholdRequest{
+		Id:        in.GetId(),
+		Threshold: in.GetThreshold(),
+	}
+
+	if _, err := i.deps.StorageClient.SetReorderThreshold(ctx, &request); err != nil {
 		if status.Code(err) == codes.NotFound {
-			i.deps.Metrics.UnsuccessfulRequestCounter.Inc()
 			return nil, status.Error(codes.NotFound, "product not found")
 		}
-		i.deps.Metrics.FailedRequestCounter.Inc()
-		log.WithError(err).Error("StorageClient: ProductDelete: internal error")
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, err
 	}
 
-	i.deps.Metrics.SuccessfulRequestCounter.Inc()
-	return &pbApi.ProductDeleteResponse{}, nil
+	return &pbApi.SetReorderThresholdResponse{}, nil
+}
+
+func (i *implementation) SupplierCreate(ctx context.Context, in *pbApi.SupplierCreateRequest) (*pbApi.SupplierCreateResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	if err := purchases.ValidateSupplierName(in.GetName()); err != nil {
+		return nil, interceptors.Invalid(err)
+	}
+
+	request := pbStorage.SupplierCreateRequest{
+		Name:    in.GetName(),
+		Contact: in.GetContact(),
+	}
+
+	supplier, err := i.deps.StorageClient.SupplierCreate(ctx, &request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbApi.SupplierCreateResponse{
+		Id:      supplier.GetId(),
+		Name:    supplier.GetName(),
+		Contact: supplier.GetContact(),
+	}, nil
+}
+
+func (i *implementation) SupplierList(ctx context.Context, in *pbApi.SupplierListRequest) (*pbApi.SupplierListResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	pageNum := in.GetPage()
+	pageSize := in.GetSize()
+
+	request := pbStorage.SupplierListRequest{Page: &pageNum, Size: &pageSize}
+	supplierStream, err := i.deps.StorageClient.SupplierList(ctx, &request)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*pbApi.SupplierListResponse_Supplier
+	for {
+		supplier, err := supplierStream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, &pbApi.SupplierListResponse_Supplier{
+			Id:      supplier.GetId(),
+			Name:    supplier.GetName(),
+			Contact: supplier.GetContact(),
+		})
+	}
+
+	return &pbApi.SupplierListResponse{
+		Suppliers: result,
+	}, nil
+}
+
+func (i *implementation) PurchaseOrderCreate(ctx context.Context, in *pbApi.PurchaseOrderCreateRequest) (*pbApi.PurchaseOrderCreateResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	lines := make([]*purchases.Line, 0, len(in.GetLines()))
+	requestLines := make([]*pbStorage.PurchaseOrderCreateRequest_Line, 0, len(in.GetLines()))
+	for _, line := range in.GetLines() {
+		lines = append(lines, &purchases.Line{ProductId: line.GetProductId(), Quantity: line.GetQuantity()})
+		requestLines = append(requestLines, &pbStorage.PurchaseOrderCreateRequest_Line{
+			ProductId: line.GetProductId(),
+			Quantity:  line.GetQuantity(),
+		})
+	}
+	if err := purchases.ValidateLines(lines); err != nil {
+		return nil, interceptors.Invalid(err)
+	}
+
+	request := pbStorage.PurchaseOrderCreateRequest{
+		SupplierId: in.GetSupplierId(),
+		Lines:      requestLines,
+	}
+
+	order, err := i.deps.StorageClient.PurchaseOrderCreate(ctx, &request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbApi.PurchaseOrderCreateResponse{
+		Id:         order.GetId(),
+		SupplierId: order.GetSupplierId(),
+		Status:     order.GetStatus(),
+		Lines:      purchaseOrderLinesFromStorage(order.GetLines()),
+	}, nil
+}
+
+func (i *implementation) PurchaseOrderGet(ctx context.Context, in *pbApi.PurchaseOrderGetRequest) (*pbApi.PurchaseOrderGetResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	order, err := i.deps.StorageClient.PurchaseOrderGet(ctx, &pbStorage.PurchaseOrderGetRequest{Id: in.GetId()})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Error(codes.NotFound, "purchase order not found")
+		}
+		return nil, err
+	}
+
+	return &pbApi.PurchaseOrderGetResponse{
+		Id:         order.GetId(),
+		SupplierId: order.GetSupplierId(),
+		Status:     order.GetStatus(),
+		Lines:      purchaseOrderLinesFromStorage(order.GetLines()),
+	}, nil
+}
+
+func (i *implementation) PurchaseOrderList(ctx context.Context, in *pbApi.PurchaseOrderListRequest) (*pbApi.PurchaseOrderListResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	pageNum := in.GetPage()
+	pageSize := in.GetSize()
+
+	request := pbStorage.PurchaseOrderListRequest{Page: &pageNum, Size: &pageSize}
+	orderStream, err := i.deps.StorageClient.PurchaseOrderList(ctx, &request)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*pbApi.PurchaseOrderListResponse_PurchaseOrder
+	for {
+		order, err := orderStream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, &pbApi.PurchaseOrderListResponse_PurchaseOrder{
+			Id:         order.GetId(),
+			SupplierId: order.GetSupplierId(),
+			Status:     order.GetStatus(),
+			Lines:      purchaseOrderLinesFromStorage(order.GetLines()),
+		})
+	}
+
+	return &pbApi.PurchaseOrderListResponse{
+		PurchaseOrders: result,
+	}, nil
+}
+
+func (i *implementation) PurchaseOrderReceive(ctx context.Context, in *pbApi.PurchaseOrderReceiveRequest) (*pbApi.PurchaseOrderReceiveResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	requestLines := make([]*pbStorage.PurchaseOrderReceiveRequest_Line, 0, len(in.GetLines()))
+	for _, line := range in.GetLines() {
+		requestLines = append(requestLines, &pbStorage.PurchaseOrderReceiveRequest_Line{
+			ProductId: line.GetProductId(),
+			Quantity:  line.GetQuantity(),
+		})
+	}
+
+	request := pbStorage.PurchaseOrderReceiveRequest{
+		Id:    in.GetId(),
+		Lines: requestLines,
+	}
+
+	order, err := i.deps.StorageClient.PurchaseOrderReceive(ctx, &request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbApi.PurchaseOrderReceiveResponse{
+		Id:         order.GetId(),
+		SupplierId: order.GetSupplierId(),
+		Status:     order.GetStatus(),
+		Lines:      purchaseOrderLinesFromStorage(order.GetLines()),
+	}, nil
+}
+
+func (i *implementation) PlaceOrder(ctx context.Context, in *pbApi.PlaceOrderRequest) (*pbApi.PlaceOrderResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	lines := make([]*orders.Line, 0, len(in.GetLines()))
+	requestLines := make([]*pbStorage.OrderLine, 0, len(in.GetLines()))
+	for _, line := range in.GetLines() {
+		lines = append(lines, &orders.Line{ProductId: line.GetProductId(), Quantity: line.GetQuantity()})
+		requestLines = append(requestLines, &pbStorage.OrderLine{
+			ProductId: line.GetProductId(),
+			Quantity:  line.GetQuantity(),
+		})
+	}
+	if _, err := orders.NewOrder(in.GetOrderId(), lines); err != nil {
+		return nil, interceptors.Invalid(err)
+	}
+
+	request := pbStorage.PlaceOrderRequest{
+		OrderId: in.GetOrderId(),
+		Lines:   requestLines,
+	}
+
+	order, err := i.deps.StorageClient.PlaceOrder(ctx, &request)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*pbApi.OrderLine, 0, len(order.GetLines()))
+	for _, line := range order.GetLines() {
+		result = append(result, &pbApi.OrderLine{
+			ProductId: line.GetProductId(),
+			Quantity:  line.GetQuantity(),
+		})
+	}
+
+	return &pbApi.PlaceOrderResponse{
+		OrderId: order.GetOrderId(),
+		Status:  order.GetStatus(),
+		Lines:   result,
+	}, nil
+}
+
+func (i *implementation) LotAdd(ctx context.Context, in *pbApi.LotAddRequest) (*pbApi.LotAddResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	expiresAt, err := lots.ParseExpiresAt(in.GetExpiresAt())
+	if err != nil {
+		return nil, interceptors.Invalid(err)
+	}
+	if _, err = lots.NewLot(in.GetProductId(), in.GetNumber(), in.GetQuantity(), expiresAt); err != nil {
+		return nil, interceptors.Invalid(err)
+	}
+
+	request := pbStorage.LotAddRequest{
+		ProductId: in.GetProductId(),
+		Number:    in.GetNumber(),
+		Quantity:  in.GetQuantity(),
+		ExpiresAt: in.GetExpiresAt(),
+	}
+
+	response, err := i.deps.StorageClient.LotAdd(ctx, &request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbApi.LotAddResponse{
+		Lot: lotFromStorage(response.GetLot()),
+	}, nil
+}
+
+func (i *implementation) LotList(ctx context.Context, in *pbApi.LotListRequest) (*pbApi.LotListResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	lotStream, err := i.deps.StorageClient.LotList(ctx, &pbStorage.LotListRequest{ProductId: in.GetProductId()})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*pbApi.Lot
+	for {
+		item, err := lotStream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, lotFromStorage(item.GetLot()))
+	}
+
+	return &pbApi.LotListResponse{
+		Lots: result,
+	}, nil
+}
+
+func (i *implementation) ListExpiringLots(ctx context.Context, in *pbApi.ListExpiringLotsRequest) (*pbApi.ListExpiringLotsResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	pageNum := in.GetPage()
+	pageSize := in.GetSize()
+
+	request := pbStorage.ListExpiringLotsRequest{Days: in.GetDays(), Page: &pageNum, Size: &pageSize}
+	lotStream, err := i.deps.StorageClient.ListExpiringLots(ctx, &request)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*pbApi.Lot
+	for {
+		item, err := lotStream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, lotFromStorage(item.GetLot()))
+	}
+
+	return &pbApi.ListExpiringLotsResponse{
+		Lots: result,
+	}, nil
+}
+
+func (i *implementation) StocktakeOpen(ctx context.Context, in *pbApi.StocktakeOpenRequest) (*pbApi.StocktakeOpenResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	response, err := i.deps.StorageClient.StocktakeOpen(ctx, &pbStorage.StocktakeOpenRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbApi.StocktakeOpenResponse{
+		Stocktake: stocktakeFromStorage(response.GetStocktake()),
+	}, nil
+}
+
+func (i *implementation) StocktakeCount(ctx context.Context, in *pbApi.StocktakeCountRequest) (*pbApi.StocktakeCountResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	request := pbStorage.StocktakeCountRequest{
+		Id:        in.GetId(),
+		ProductId: in.GetProductId(),
+		Counted:   in.GetCounted(),
+	}
+
+	response, err := i.deps.StorageClient.StocktakeCount(ctx, &request)
+	if err != nil {
+		return nil, i.stocktakeError("StocktakeCount", err)
+	}
+
+	return &pbApi.StocktakeCountResponse{
+		Id:        response.GetId(),
+		ProductId: response.GetProductId(),
+		Counted:   response.GetCounted(),
+	}, nil
+}
+
+func (i *implementation) StocktakeGet(ctx context.Context, in *pbApi.StocktakeGetRequest) (*pbApi.StocktakeGetResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	response, err := i.deps.StorageClient.StocktakeGet(ctx, &pbStorage.StocktakeGetRequest{Id: in.GetId()})
+	if err != nil {
+		return nil, i.stocktakeError("StocktakeGet", err)
+	}
+
+	variances := make([]*pbApi.StocktakeVariance, 0, len(response.GetVariances()))
+	for _, variance := range response.GetVariances() {
+		variances = append(variances, &pbApi.StocktakeVariance{
+			ProductId:  variance.GetProductId(),
+			Name:       variance.GetName(),
+			Expected:   variance.GetExpected(),
+			Counted:    variance.GetCounted(),
+			Difference: variance.GetDifference(),
+		})
+	}
+
+	return &pbApi.StocktakeGetResponse{
+		Stocktake: stocktakeFromStorage(response.GetStocktake()),
+		Variances: variances,
+	}, nil
+}
+
+func (i *implementation) StocktakeCommit(ctx context.Context, in *pbApi.StocktakeCommitRequest) (*pbApi.StocktakeCommitResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	response, err := i.deps.StorageClient.StocktakeCommit(ctx, &pbStorage.StocktakeCommitRequest{Id: in.GetId()})
+	if err != nil {
+		return nil, i.stocktakeError("StocktakeCommit", err)
+	}
+
+	adjustments := make([]*pbApi.StockAdjustment, 0, len(response.GetAdjustments()))
+	for _, adjustment := range response.GetAdjustments() {
+		adjustments = append(adjustments, &pbApi.StockAdjustment{
+			Id:        adjustment.GetId(),
+			ProductId: adjustment.GetProductId(),
+			Before:    adjustment.GetBefore(),
+			After:     adjustment.GetAfter(),
+		})
+	}
+
+	return &pbApi.StocktakeCommitResponse{
+		Stocktake:   stocktakeFromStorage(response.GetStocktake()),
+		Adjustments: adjustments,
+	}, nil
+}
+
+func (i *implementation) StockValuation(ctx context.Context, in *pbApi.StockValuationRequest) (*pbApi.StockValuationResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	valuation, err := i.deps.StorageClient.StockValuation(ctx, &pbStorage.StockValuationRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	byStatus := make([]*pbApi.StatusValue, 0, len(valuation.GetByStatus()))
+	for _, value := range valuation.GetByStatus() {
+		byStatus = append(byStatus, &pbApi.StatusValue{
+			Status:       value.GetStatus(),
+			ProductCount: value.GetProductCount(),
+			Quantity:     value.GetQuantity(),
+			Value:        value.GetValue(),
+		})
+	}
+
+	byCategory := make([]*pbApi.CategoryValue, 0, len(valuation.GetByCategory()))
+	for _, value := range valuation.GetByCategory() {
+		byCategory = append(byCategory, &pbApi.CategoryValue{
+			Category:     value.GetCategory(),
+			ProductCount: value.GetProductCount(),
+			Quantity:     value.GetQuantity(),
+			Value:        value.GetValue(),
+		})
+	}
+
+	return &pbApi.StockValuationResponse{
+		TotalValue:     valuation.GetTotalValue(),
+		TotalQuantity:  valuation.GetTotalQuantity(),
+		ProductCount:   valuation.GetProductCount(),
+		ZeroStockCount: valuation.GetZeroStockCount(),
+		ByStatus:       byStatus,
+		ByCategory:     byCategory,
+	}, nil
+}
+
+func (i *implementation) TopProductsByValue(ctx context.Context, in *pbApi.TopProductsByValueRequest) (*pbApi.TopProductsByValueResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	limit := in.GetLimit()
+
+	valueStream, err := i.deps.StorageClient.TopProductsByValue(ctx, &pbStorage.TopProductsByValueRequest{Limit: &limit})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*pbApi.ProductValue
+	for {
+		item, err := valueStream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, &pbApi.ProductValue{
+			ProductId: item.GetProduct().GetProductId(),
+			Name:      item.GetProduct().GetName(),
+			Price:     item.GetProduct().GetPrice(),
+			Quantity:  item.GetProduct().GetQuantity(),
+			Value:     item.GetProduct().GetValue(),
+			Unit:      item.GetProduct().GetUnit(),
+		})
+	}
+
+	return &pbApi.TopProductsByValueResponse{
+		Products: result,
+	}, nil
+}
+
+func (i *implementation) UploadProductImage(srv pbApi.ApiService_UploadProductImageServer) error {
+	ctx, cancel := context.WithTimeout(tenants.Detach(srv.Context()), maxTransferTimeout)
+	defer cancel()
+
+	first, err := srv.Recv()
+	if err != nil && err != io.EOF {
+		return err
+	}
+	info := first.GetInfo()
+	if info == nil {
+		return status.Error(codes.InvalidArgument, "the first message must carry the image info")
+	}
+
+	if err = images.ValidateContentType(info.GetContentType()); err != nil {
+		return interceptors.Invalid(err)
+	}
+
+	upload, err := i.deps.StorageClient.UploadProductImage(ctx)
+	if err != nil {
+		return err
+	}
+
+	request := &pbStorage.UploadProductImageRequest{Data: &pbStorage.UploadProductImageRequest_Info_{
+		Info: &pbStorage.UploadProductImageRequest_Info{
+			ProductId:   info.GetProductId(),
+			ContentType: info.GetContentType(),
+		},
+	}}
+	for {
+		if err = upload.Send(request); err != nil {
+			// the storage ended the upload, its status comes from CloseAndRecv
+			break
+		}
+
+		in, err := srv.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if in.GetInfo() != nil {
+			return status.Error(codes.InvalidArgument, "image info must be sent only in the first message")
+		}
+		request = &pbStorage.UploadProductImageRequest{Data: &pbStorage.UploadProductImageRequest_Chunk{Chunk: in.GetChunk()}}
+	}
+
+	response, err := upload.CloseAndRecv()
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return status.Error(codes.NotFound, "product not found")
+		}
+		return err
+	}
+
+	return srv.SendAndClose(&pbApi.UploadProductImageResponse{Image: imageFromStorage(response.GetImage())})
+}
+
+func (i *implementation) DownloadProductImage(in *pbApi.DownloadProductImageRequest, srv pbApi.ApiService_DownloadProductImageServer) error {
+	ctx, cancel := context.WithTimeout(tenants.Detach(srv.Context()), maxTransferTimeout)
+	defer cancel()
+
+	download, err := i.deps.StorageClient.DownloadProductImage(ctx, &pbStorage.DownloadProductImageRequest{
+		ProductId: in.GetProductId(),
+		Id:        in.GetId(),
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		item, err := download.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return status.Error(codes.NotFound, "image not found")
+			}
+			return err
+		}
+
+		response := &pbApi.DownloadProductImageResponse{Data: &pbApi.DownloadProductImageResponse_Chunk{Chunk: item.GetChunk()}}
+		if image := item.GetImage(); image != nil {
+			response.Data = &pbApi.DownloadProductImageResponse_Image{Image: imageFromStorage(image)}
+		}
+		if err = srv.Send(response); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (i *implementation) ListProductImages(ctx context.Context, in *pbApi.ListProductImagesRequest) (*pbApi.ListProductImagesResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	imageStream, err := i.deps.StorageClient.ListProductImages(ctx, &pbStorage.ListProductImagesRequest{ProductId: in.GetProductId()})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*pbApi.ProductImage
+	for {
+		item, err := imageStream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, imageFromStorage(item.GetImage()))
+	}
+
+	return &pbApi.ListProductImagesResponse{
+		Images: result,
+	}, nil
+}
+
+func (i *implementation) RelationCreate(ctx context.Context, in *pbApi.RelationCreateRequest) (*pbApi.RelationCreateResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	if _, err := relations.NewRelation(in.GetProductId(), in.GetRelatedId(), relations.Type(in.GetType())); err != nil {
+		return nil, interceptors.Invalid(err)
+	}
+
+	request := pbStorage.RelationCreateRequest{
+		ProductId: in.GetProductId(),
+		RelatedId: in.GetRelatedId(),
+		Type:      in.GetType(),
+	}
+
+	response, err := i.deps.StorageClient.RelationCreate(ctx, &request)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Error(codes.NotFound, "product not found")
+		}
+		return nil, err
+	}
+
+	return &pbApi.RelationCreateResponse{
+		Relation: relationFromStorage(response.GetRelation()),
+	}, nil
+}
+
+func (i *implementation) RelationList(ctx context.Context, in *pbApi.RelationListRequest) (*pbApi.RelationListResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	relationStream, err := i.deps.StorageClient.RelationList(ctx, &pbStorage.RelationListRequest{ProductId: in.GetProductId()})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*pbApi.ProductRelation
+	for {
+		item, err := relationStream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, relationFromStorage(item.GetRelation()))
+	}
+
+	return &pbApi.RelationListResponse{
+		Relations: result,
+	}, nil
+}
+
+func (i *implementation) RelationUpdate(ctx context.Context, in *pbApi.RelationUpdateRequest) (*pbApi.RelationUpdateResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	if _, err := relations.NewRelation(in.GetProductId(), in.GetRelatedId(), relations.Type(in.GetType())); err != nil {
+		return nil, interceptors.Invalid(err)
+	}
+
+	request := pbStorage.RelationUpdateRequest{
+		ProductId: in.GetProductId(),
+		RelatedId: in.GetRelatedId(),
+		Type:      in.GetType(),
+	}
+
+	response, err := i.deps.StorageClient.RelationUpdate(ctx, &request)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Error(codes.NotFound, "relation not found")
+		}
+		return nil, err
+	}
+
+	return &pbApi.RelationUpdateResponse{
+		Relation: relationFromStorage(response.GetRelation()),
+	}, nil
+}
+
+func (i *implementation) RelationDelete(ctx context.Context, in *pbApi.RelationDeleteRequest) (*pbApi.RelationDeleteResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	request := pbStorage.RelationDeleteRequest{
+		ProductId: in.GetProductId(),
+		RelatedId: in.GetRelatedId(),
+	}
+
+	if _, err := i.deps.StorageClient.RelationDelete(ctx, &request); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Error(codes.NotFound, "relation not found")
+		}
+		return nil, err
+	}
+
+	return &pbApi.RelationDeleteResponse{}, nil
+}
+
+func (i *implementation) GetRelatedProducts(ctx context.Context, in *pbApi.GetRelatedProductsRequest) (*pbApi.GetRelatedProductsResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	if in.Type != nil {
+		if err := relations.ValidateType(relations.Type(in.GetType())); err != nil {
+			return nil, interceptors.Invalid(err)
+		}
+	}
+
+	productStream, err := i.deps.StorageClient.GetRelatedProducts(ctx, &pbStorage.GetRelatedProductsRequest{
+		ProductId: in.GetProductId(),
+		Type:      in.Type,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*pbApi.RelatedProduct
+	for {
+		item, err := productStream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		product := item.GetProduct()
+		result = append(result, &pbApi.RelatedProduct{
+			Id:           product.GetId(),
+			Name:         product.GetName(),
+			Price:        product.GetPrice(),
+			Quantity:     product.GetQuantity(),
+			Status:       product.GetStatus(),
+			Unit:         product.GetUnit(),
+			Amount:       product.GetAmount(),
+			RelationType: product.GetRelationType(),
+		})
+	}
+
+	return &pbApi.GetRelatedProductsResponse{
+		Products: result,
+	}, nil
+}
+
+func (i *implementation) ExchangeRateSet(ctx context.Context, in *pbApi.ExchangeRateSetRequest) (*pbApi.ExchangeRateSetResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	currency, err := money.ParseCurrency(in.GetCurrency())
+	if err != nil {
+		return nil, interceptors.Invalid(err)
+	}
+	if _, err = money.NewRate(currency, in.GetRate()); err != nil {
+		return nil, interceptors.Invalid(err)
+	}
+
+	request := pbStorage.ExchangeRateSetRequest{
+		Currency: currency.String(),
+		Rate:     in.GetRate(),
+	}
+
+	response, err := i.deps.StorageClient.ExchangeRateSet(ctx, &request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbApi.ExchangeRateSetResponse{
+		Rate: exchangeRateFromStorage(response.GetRate()),
+	}, nil
+}
+
+func (i *implementation) ExchangeRateList(ctx context.Context, in *pbApi.ExchangeRateListRequest) (*pbApi.ExchangeRateListResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	rateStream, err := i.deps.StorageClient.ExchangeRateList(ctx, &pbStorage.ExchangeRateListRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*pbApi.ExchangeRate
+	for {
+		item, err := rateStream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, exchangeRateFromStorage(item.GetRate()))
+	}
+
+	return &pbApi.ExchangeRateListResponse{
+		Rates: result,
+	}, nil
+}
+
+func (i *implementation) stocktakeError(method string, err error) error {
+	return err
+}
+
+func stocktakeFromStorage(session *pbStorage.Stocktake) *pbApi.Stocktake {
+	return &pbApi.Stocktake{
+		Id:          session.GetId(),
+		Status:      session.GetStatus(),
+		OpenedBy:    session.GetOpenedBy(),
+		CommittedBy: session.GetCommittedBy(),
+	}
+}
+
+// validateProductFields validates the decimal amount instead of the quantity when it is set,
+// its precision depends on the unit and is checked by the storage.
+func validateProductFields(name string, price, quantity uint64, amount *string) []error {
+	if amount != nil {
+		return products.ValidateProductAmountFields(name, price, *amount)
+	}
+	return products.ValidateProductFields(name, price, quantity)
+}
+
+func lotFromStorage(lot *pbStorage.Lot) *pbApi.Lot {
+	return &pbApi.Lot{
+		Id:        lot.GetId(),
+		ProductId: lot.GetProductId(),
+		Number:    lot.GetNumber(),
+		Quantity:  lot.GetQuantity(),
+		ExpiresAt: lot.GetExpiresAt(),
+	}
+}
+
+func imageFromStorage(image *pbStorage.ProductImage) *pbApi.ProductImage {
+	return &pbApi.ProductImage{
+		Id:          image.GetId(),
+		ProductId:   image.GetProductId(),
+		ContentType: image.GetContentType(),
+		Size:        image.GetSize(),
+		Checksum:    image.GetChecksum(),
+		CreatedAt:   image.GetCreatedAt(),
+	}
+}
+
+func relationFromStorage(relation *pbStorage.ProductRelation) *pbApi.ProductRelation {
+	return &pbApi.ProductRelation{
+		ProductId: relation.GetProductId(),
+		RelatedId: relation.GetRelatedId(),
+		Type:      relation.GetType(),
+	}
+}
+
+func exchangeRateFromStorage(rate *pbStorage.ExchangeRate) *pbApi.ExchangeRate {
+	return &pbApi.ExchangeRate{
+		Currency:  rate.GetCurrency(),
+		Rate:      rate.GetRate(),
+		UpdatedAt: rate.GetUpdatedAt(),
+	}
+}
+
+func purchaseOrderLinesFromStorage(lines []*pbStorage.PurchaseOrderLine) []*pbApi.PurchaseOrderLine {
+	result := make([]*pbApi.PurchaseOrderLine, 0, len(lines))
+	for _, line := range lines {
+		result = append(result, &pbApi.PurchaseOrderLine{
+			ProductId: line.GetProductId(),
+			Quantity:  line.GetQuantity(),
+			Received:  line.GetReceived(),
+		})
+	}
+	return result
 }
@@ -7,7 +7,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"homework-1/internal/interceptors"
 	pbStorage "homework-1/pkg/api/storage/v1"
 	pbApi "homework-1/pkg/api/v1"
 	"testing"
@@ -77,7 +79,54 @@ func TestProductList(t *testing.T) {
 		_, err := f.service.ProductList(context.Background(), &pbApi.ProductListRequest{Page: &pageNum, Size: &pageSize})
 
 		// assert
-		assert.EqualError(t, err, "rpc error: code = Internal desc = internal error")
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = Internal desc = internal error")
+	})
+}
+
+func TestProductListByAttributes(t *testing.T) {
+	t.Run("filters are forwarded", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		stream := makeProductListResponseStreamMock()
+		defer stream.Close()
+
+		stream.Send(&pbStorage.ProductListResponse{
+			Id:         uint64(1),
+			Name:       "kettle",
+			Category:   "electronics",
+			Attributes: map[string]string{"voltage": "220"},
+		})
+
+		pageNum := uint64(0)
+		pageSize := uint64(0)
+		category := "electronics"
+		f.storageClient.EXPECT().ProductList(gomock.Any(), &pbStorage.ProductListRequest{
+			Page:       &pageNum,
+			Size:       &pageSize,
+			Category:   &category,
+			Attributes: map[string]string{"voltage": "220"},
+		}).Return(stream, nil)
+
+		// act
+		res, err := f.service.ProductList(context.Background(), &pbApi.ProductListRequest{
+			Category:   &category,
+			Attributes: map[string]string{"voltage": "220"},
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pbApi.ProductListResponse{
+			Products: []*pbApi.ProductListResponse_Product{
+				{
+					Id:         uint64(1),
+					Name:       "kettle",
+					Category:   "electronics",
+					Attributes: map[string]string{"voltage": "220"},
+				},
+			},
+		})
 	})
 }
 
@@ -120,7 +169,7 @@ func TestProductGet(t *testing.T) {
 		_, err := f.service.ProductGet(context.Background(), &pbApi.ProductGetRequest{Id: uint64(1)})
 
 		// assert
-		assert.EqualError(t, err, "rpc error: code = NotFound desc = product not found")
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = NotFound desc = product not found")
 	})
 
 	t.Run("internal error", func(t *testing.T) {
@@ -135,7 +184,248 @@ func TestProductGet(t *testing.T) {
 		_, err := f.service.ProductGet(context.Background(), &pbApi.ProductGetRequest{Id: uint64(1)})
 
 		// assert
-		assert.EqualError(t, err, "rpc error: code = Internal desc = internal error")
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = Internal desc = internal error")
+	})
+}
+
+func TestProductGetByBarcode(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.storageClient.EXPECT().ProductGetByBarcode(gomock.Any(), &pbStorage.ProductGetByBarcodeRequest{Barcode: "4006381333931"}).
+			Return(&pbStorage.ProductGetByBarcodeResponse{
+				Id:       uint64(1),
+				Name:     "product1",
+				Price:    uint64(1),
+				Quantity: uint64(1),
+				Barcode:  "04006381333931",
+			}, nil)
+
+		// act
+		res, err := f.service.ProductGetByBarcode(context.Background(), &pbApi.ProductGetByBarcodeRequest{Barcode: "4006381333931"})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pbApi.ProductGetByBarcodeResponse{
+			Id:       uint64(1),
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(1),
+			Barcode:  "04006381333931",
+		})
+	})
+
+	t.Run("invalid barcode", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		// act
+		_, err := f.service.ProductGetByBarcode(context.Background(), &pbApi.ProductGetByBarcodeRequest{Barcode: "12345"})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), `rpc error: code = InvalidArgument desc = "12345": invalid barcode: expected 8, 12, 13 or 14 digits`)
+	})
+
+	t.Run("not found error", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.storageClient.EXPECT().ProductGetByBarcode(gomock.Any(), &pbStorage.ProductGetByBarcodeRequest{Barcode: "4006381333931"}).
+			Return(nil, status.Error(codes.NotFound, "not found"))
+
+		// act
+		_, err := f.service.ProductGetByBarcode(context.Background(), &pbApi.ProductGetByBarcodeRequest{Barcode: "4006381333931"})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = NotFound desc = product not found")
+	})
+}
+
+func TestProductGetLocalized(t *testing.T) {
+	t.Run("forwards locale", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.storageClient.EXPECT().ProductGet(gomock.Any(), &pbStorage.ProductGetRequest{Id: uint64(1)}).
+			DoAndReturn(func(ctx context.Context, _ *pbStorage.ProductGetRequest, _ ...interface{}) (*pbStorage.ProductGetResponse, error) {
+				md, _ := metadata.FromOutgoingContext(ctx)
+				assert.Equal(t, []string{"ru"}, md.Get("accept-language"))
+				return &pbStorage.ProductGetResponse{
+					Id:          uint64(1),
+					Name:        "товар1",
+					Price:       uint64(1),
+					Quantity:    uint64(1),
+					Description: "описание",
+					Locale:      "ru",
+				}, nil
+			})
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("grpcgateway-accept-language", "ru-RU,ru;q=0.9,en;q=0.8"))
+
+		// act
+		res, err := f.service.ProductGet(ctx, &pbApi.ProductGetRequest{Id: uint64(1)})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pbApi.ProductGetResponse{
+			Id:          uint64(1),
+			Name:        "товар1",
+			Price:       uint64(1),
+			Quantity:    uint64(1),
+			Description: "описание",
+			Locale:      "ru",
+		})
+	})
+}
+
+func TestProductGetInCurrency(t *testing.T) {
+	t.Run("forwards currency", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.storageClient.EXPECT().ProductGet(gomock.Any(), &pbStorage.ProductGetRequest{Id: uint64(1)}).
+			DoAndReturn(func(ctx context.Context, _ *pbStorage.ProductGetRequest, _ ...interface{}) (*pbStorage.ProductGetResponse, error) {
+				md, _ := metadata.FromOutgoingContext(ctx)
+				assert.Equal(t, []string{"USD"}, md.Get("x-currency"))
+				return &pbStorage.ProductGetResponse{
+					Id:       uint64(1),
+					Name:     "product1",
+					Price:    uint64(21),
+					Quantity: uint64(1),
+					Locale:   "en",
+					Currency: "USD",
+				}, nil
+			})
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-currency", "usd"))
+
+		// act
+		res, err := f.service.ProductGet(ctx, &pbApi.ProductGetRequest{Id: uint64(1)})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res.GetPrice(), uint64(21))
+		assert.Equal(t, res.GetCurrency(), "USD")
+	})
+
+	t.Run("no exchange rate", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.storageClient.EXPECT().ProductGet(gomock.Any(), &pbStorage.ProductGetRequest{Id: uint64(1)}).
+			Return(nil, status.Error(codes.FailedPrecondition, "USD: exchange rate does not exist"))
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-currency", "USD"))
+
+		// act
+		_, err := f.service.ProductGet(ctx, &pbApi.ProductGetRequest{Id: uint64(1)})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = FailedPrecondition desc = USD: exchange rate does not exist")
+	})
+
+	t.Run("invalid currency", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-currency", "US"))
+
+		// act
+		_, err := f.service.ProductGet(ctx, &pbApi.ProductGetRequest{Id: uint64(1)})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = US: invalid currency, expected three-letter ISO 4217 code")
+	})
+}
+
+func TestProductTranslate(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.storageClient.EXPECT().ProductTranslate(gomock.Any(), &pbStorage.ProductTranslateRequest{
+			Id:     uint64(1),
+			Locale: "ru",
+			Name:   "товар1",
+		}).Return(&pbStorage.ProductTranslateResponse{
+			Id:     uint64(1),
+			Locale: "ru",
+			Name:   "товар1",
+		}, nil)
+
+		// act
+		res, err := f.service.ProductTranslate(context.Background(), &pbApi.ProductTranslateRequest{
+			Id:     uint64(1),
+			Locale: "ru-RU",
+			Name:   "товар1",
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pbApi.ProductTranslateResponse{
+			Id:     uint64(1),
+			Locale: "ru",
+			Name:   "товар1",
+		})
+	})
+
+	t.Run("unsupported locale", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		// act
+		_, err := f.service.ProductTranslate(context.Background(), &pbApi.ProductTranslateRequest{
+			Id:     uint64(1),
+			Locale: "de",
+			Name:   "Produkt1",
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = de: unsupported locale")
+	})
+
+	t.Run("empty translation", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		// act
+		_, err := f.service.ProductTranslate(context.Background(), &pbApi.ProductTranslateRequest{
+			Id:     uint64(1),
+			Locale: "ru",
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = translation must have a name or a description")
+	})
+
+	t.Run("not found error", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.storageClient.EXPECT().ProductTranslate(gomock.Any(), gomock.Any()).
+			Return(nil, status.Error(codes.NotFound, "not found"))
+
+		// act
+		_, err := f.service.ProductTranslate(context.Background(), &pbApi.ProductTranslateRequest{
+			Id:     uint64(1),
+			Locale: "ru",
+			Name:   "товар1",
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = NotFound desc = product not found")
 	})
 }
 
@@ -192,7 +482,7 @@ func TestProductCreate(t *testing.T) {
 		})
 
 		// assert
-		assert.EqualError(t, err, "rpc error: code = Internal desc = internal error")
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = Internal desc = internal error")
 	})
 
 	t.Run("fail with wrong name", func(t *testing.T) {
@@ -208,7 +498,7 @@ func TestProductCreate(t *testing.T) {
 		})
 
 		// assert
-		assert.EqualError(t, err, "rpc error: code = InvalidArgument desc = name length must be greater than 0")
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = name length must be greater than 0")
 	})
 
 	t.Run("fail with wrong price", func(t *testing.T) {
@@ -224,7 +514,7 @@ func TestProductCreate(t *testing.T) {
 		})
 
 		// assert
-		assert.EqualError(t, err, "rpc error: code = InvalidArgument desc = price must be greater than 0")
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = price must be greater than 0")
 	})
 
 	t.Run("fail with wrong quantity", func(t *testing.T) {
@@ -240,7 +530,7 @@ func TestProductCreate(t *testing.T) {
 		})
 
 		// assert
-		assert.EqualError(t, err, "rpc error: code = InvalidArgument desc = quantity must be greater than 0")
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = quantity must be greater than 0")
 	})
 
 	t.Run("fail with wrong args", func(t *testing.T) {
@@ -318,7 +608,7 @@ func TestProductUpdate(t *testing.T) {
 		})
 
 		// assert
-		assert.EqualError(t, err, "rpc error: code = NotFound desc = product not found")
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = NotFound desc = product not found")
 	})
 
 	t.Run("storageClient internal error", func(t *testing.T) {
@@ -342,7 +632,7 @@ func TestProductUpdate(t *testing.T) {
 		})
 
 		// assert
-		assert.EqualError(t, err, "rpc error: code = Internal desc = internal error")
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = Internal desc = internal error")
 	})
 
 	t.Run("fail with wrong name", func(t *testing.T) {
@@ -359,7 +649,7 @@ func TestProductUpdate(t *testing.T) {
 		})
 
 		// assert
-		assert.EqualError(t, err, "rpc error: code = InvalidArgument desc = name length must be greater than 0")
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = name length must be greater than 0")
 	})
 
 	t.Run("fail with wrong price", func(t *testing.T) {
@@ -376,7 +666,7 @@ func TestProductUpdate(t *testing.T) {
 		})
 
 		// assert
-		assert.EqualError(t, err, "rpc error: code = InvalidArgument desc = price must be greater than 0")
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = price must be greater than 0")
 	})
 
 	t.Run("fail with wrong quantity", func(t *testing.T) {
@@ -393,7 +683,7 @@ func TestProductUpdate(t *testing.T) {
 		})
 
 		// assert
-		assert.EqualError(t, err, "rpc error: code = InvalidArgument desc = quantity must be greater than 0")
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = quantity must be greater than 0")
 	})
 
 	t.Run("fail with wrong args", func(t *testing.T) {
@@ -450,7 +740,7 @@ func TestProductDelete(t *testing.T) {
 		})
 
 		// assert
-		assert.EqualError(t, err, "rpc error: code = NotFound desc = product not found")
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = NotFound desc = product not found")
 	})
 
 	t.Run("storageClient internal error", func(t *testing.T) {
@@ -468,6 +758,833 @@ func TestProductDelete(t *testing.T) {
 		})
 
 		// assert
-		assert.EqualError(t, err, "rpc error: code = Internal desc = internal error")
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = Internal desc = internal error")
+	})
+
+	t.Run("product has relations", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.storageClient.EXPECT().ProductDelete(gomock.Any(), &pbStorage.ProductDeleteRequest{
+			Id: uint64(1),
+		}).Return(nil, status.Error(codes.FailedPrecondition, "1: product has relations, delete them first"))
+
+		// act
+		_, err := f.service.ProductDelete(context.Background(), &pbApi.ProductDeleteRequest{
+			Id: uint64(1),
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = FailedPrecondition desc = 1: product has relations, delete them first")
+	})
+}
+
+func TestProductTransition(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.storageClient.EXPECT().ProductTransition(gomock.Any(), &pbStorage.ProductTransitionRequest{
+			Id:     uint64(1),
+			Status: "discontinued",
+			Reason: "reason",
+		}).Return(&pbStorage.ProductTransitionResponse{
+			Id:       uint64(1),
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(1),
+			Status:   "discontinued",
+		}, nil)
+
+		// act
+		res, err := f.service.ProductTransition(context.Background(), &pbApi.ProductTransitionRequest{
+			Id:     uint64(1),
+			Status: "discontinued",
+			Reason: "reason",
+			Actor:  "admin",
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pbApi.ProductTransitionResponse{
+			Id:       uint64(1),
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(1),
+			Status:   "discontinued",
+		})
+	})
+
+	t.Run("fail with validation errors", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		// act
+		_, err := f.service.ProductTransition(context.Background(), &pbApi.ProductTransitionRequest{
+			Id:     uint64(1),
+			Status: "unknown",
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = unknown: unknown status; transition reason must not be empty")
+	})
+
+	t.Run("invalid transition", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.storageClient.EXPECT().ProductTransition(gomock.Any(), &pbStorage.ProductTransitionRequest{
+			Id:     uint64(1),
+			Status: "draft",
+			Reason: "reason",
+		}).Return(nil, status.Error(codes.FailedPrecondition, "archived -> draft: invalid status transition"))
+
+		// act
+		_, err := f.service.ProductTransition(context.Background(), &pbApi.ProductTransitionRequest{
+			Id:     uint64(1),
+			Status: "draft",
+			Reason: "reason",
+			Actor:  "admin",
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = FailedPrecondition desc = archived -> draft: invalid status transition")
+	})
+}
+
+func TestApproveChange(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.storageClient.EXPECT().ApproveChange(gomock.Any(), &pbStorage.ApproveChangeRequest{Id: uint64(1)}).Return(&pbStorage.ApproveChangeResponse{
+			Id:          uint64(1),
+			ProductId:   uint64(1),
+			Name:        "product1",
+			OldPrice:    uint64(100),
+			Price:       uint64(1),
+			Quantity:    uint64(1),
+			Status:      "approved",
+			RequestedBy: "alice",
+			ResolvedBy:  "bob",
+		}, nil)
+
+		// act
+		res, err := f.service.ApproveChange(context.Background(), &pbApi.ApproveChangeRequest{
+			Id:       uint64(1),
+			Approver: "bob",
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pbApi.ApproveChangeResponse{
+			Id:          uint64(1),
+			ProductId:   uint64(1),
+			Name:        "product1",
+			OldPrice:    uint64(100),
+			Price:       uint64(1),
+			Quantity:    uint64(1),
+			Status:      "approved",
+			RequestedBy: "alice",
+			ResolvedBy:  "bob",
+		})
+	})
+
+	t.Run("requester approves own change", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.storageClient.EXPECT().ApproveChange(gomock.Any(), &pbStorage.ApproveChangeRequest{Id: uint64(1)}).
+			Return(nil, status.Error(codes.PermissionDenied, "change must be resolved by another user"))
+
+		// act
+		_, err := f.service.ApproveChange(context.Background(), &pbApi.ApproveChangeRequest{
+			Id:       uint64(1),
+			Approver: "alice",
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = PermissionDenied desc = change must be resolved by another user")
+	})
+}
+
+func TestRejectChange(t *testing.T) {
+	t.Run("change not found", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.storageClient.EXPECT().RejectChange(gomock.Any(), &pbStorage.RejectChangeRequest{Id: uint64(1)}).
+			Return(nil, status.Error(codes.NotFound, "price change does not exist"))
+
+		// act
+		_, err := f.service.RejectChange(context.Background(), &pbApi.RejectChangeRequest{
+			Id:       uint64(1),
+			Approver: "bob",
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = NotFound desc = change not found")
+	})
+}
+
+func TestSetReorderThreshold(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.storageClient.EXPECT().SetReorderThreshold(gomock.Any(), &pbStorage.SetReorderThresholdRequest{
+			Id:        uint64(1),
+			Threshold: uint64(5),
+		}).Return(&pbStorage.SetReorderThresholdResponse{}, nil)
+
+		// act
+		res, err := f.service.SetReorderThreshold(context.Background(), &pbApi.SetReorderThresholdRequest{
+			Id:        uint64(1),
+			Threshold: uint64(5),
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pbApi.SetReorderThresholdResponse{})
+	})
+
+	t.Run("product not found", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.storageClient.EXPECT().SetReorderThreshold(gomock.Any(), &pbStorage.SetReorderThresholdRequest{
+			Id:        uint64(1),
+			Threshold: uint64(5),
+		}).Return(nil, status.Error(codes.NotFound, "product does not exist"))
+
+		// act
+		_, err := f.service.SetReorderThreshold(context.Background(), &pbApi.SetReorderThresholdRequest{
+			Id:        uint64(1),
+			Threshold: uint64(5),
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = NotFound desc = product not found")
+	})
+}
+
+func TestSupplierCreate(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.storageClient.EXPECT().SupplierCreate(gomock.Any(), &pbStorage.SupplierCreateRequest{
+			Name:    "supplier1",
+			Contact: "supplier1@example.com",
+		}).Return(&pbStorage.SupplierCreateResponse{
+			Id:      uint64(1),
+			Name:    "supplier1",
+			Contact: "supplier1@example.com",
+		}, nil)
+
+		// act
+		res, err := f.service.SupplierCreate(context.Background(), &pbApi.SupplierCreateRequest{
+			Name:    "supplier1",
+			Contact: "supplier1@example.com",
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pbApi.SupplierCreateResponse{
+			Id:      uint64(1),
+			Name:    "supplier1",
+			Contact: "supplier1@example.com",
+		})
+	})
+
+	t.Run("empty name", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		// act
+		_, err := f.service.SupplierCreate(context.Background(), &pbApi.SupplierCreateRequest{})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = supplier name length must be greater than 0")
+	})
+}
+
+func TestPurchaseOrderReceive(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.storageClient.EXPECT().PurchaseOrderReceive(gomock.Any(), &pbStorage.PurchaseOrderReceiveRequest{
+			Id:    uint64(1),
+			Lines: []*pbStorage.PurchaseOrderReceiveRequest_Line{{ProductId: uint64(2), Quantity: uint64(5)}},
+		}).Return(&pbStorage.PurchaseOrderReceiveResponse{
+			Id:         uint64(1),
+			SupplierId: uint64(1),
+			Status:     "received",
+			Lines:      []*pbStorage.PurchaseOrderLine{{ProductId: uint64(2), Quantity: uint64(5), Received: uint64(5)}},
+		}, nil)
+
+		// act
+		res, err := f.service.PurchaseOrderReceive(context.Background(), &pbApi.PurchaseOrderReceiveRequest{
+			Id:    uint64(1),
+			Lines: []*pbApi.PurchaseOrderReceiveRequest_Line{{ProductId: uint64(2), Quantity: uint64(5)}},
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pbApi.PurchaseOrderReceiveResponse{
+			Id:         uint64(1),
+			SupplierId: uint64(1),
+			Status:     "received",
+			Lines:      []*pbApi.PurchaseOrderLine{{ProductId: uint64(2), Quantity: uint64(5), Received: uint64(5)}},
+		})
+	})
+
+	t.Run("order closed", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.storageClient.EXPECT().PurchaseOrderReceive(gomock.Any(), gomock.Any()).
+			Return(nil, status.Error(codes.FailedPrecondition, "1: purchase order is not open"))
+
+		// act
+		_, err := f.service.PurchaseOrderReceive(context.Background(), &pbApi.PurchaseOrderReceiveRequest{Id: uint64(1)})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = FailedPrecondition desc = 1: purchase order is not open")
+	})
+}
+
+func TestPlaceOrder(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.storageClient.EXPECT().PlaceOrder(gomock.Any(), &pbStorage.PlaceOrderRequest{
+			OrderId: "order1",
+			Lines:   []*pbStorage.OrderLine{{ProductId: uint64(1), Quantity: uint64(2)}},
+		}).Return(&pbStorage.PlaceOrderResponse{
+			OrderId: "order1",
+			Status:  "placed",
+			Lines:   []*pbStorage.OrderLine{{ProductId: uint64(1), Quantity: uint64(2)}},
+		}, nil)
+
+		// act
+		res, err := f.service.PlaceOrder(context.Background(), &pbApi.PlaceOrderRequest{
+			OrderId: "order1",
+			Lines:   []*pbApi.OrderLine{{ProductId: uint64(1), Quantity: uint64(2)}},
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pbApi.PlaceOrderResponse{
+			OrderId: "order1",
+			Status:  "placed",
+			Lines:   []*pbApi.OrderLine{{ProductId: uint64(1), Quantity: uint64(2)}},
+		})
+	})
+
+	t.Run("empty order", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		// act
+		_, err := f.service.PlaceOrder(context.Background(), &pbApi.PlaceOrderRequest{OrderId: "order1"})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = order must have at least one line")
+	})
+
+	t.Run("not enough quantity", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.storageClient.EXPECT().PlaceOrder(gomock.Any(), gomock.Any()).
+			Return(nil, status.Error(codes.FailedPrecondition, "1: not enough quantity"))
+
+		// act
+		_, err := f.service.PlaceOrder(context.Background(), &pbApi.PlaceOrderRequest{
+			OrderId: "order1",
+			Lines:   []*pbApi.OrderLine{{ProductId: uint64(1), Quantity: uint64(2)}},
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = FailedPrecondition desc = 1: not enough quantity")
+	})
+}
+
+func TestLotAdd(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.storageClient.EXPECT().LotAdd(gomock.Any(), &pbStorage.LotAddRequest{
+			ProductId: uint64(1),
+			Number:    "A",
+			Quantity:  uint64(5),
+			ExpiresAt: "2022-10-01",
+		}).Return(&pbStorage.LotAddResponse{
+			Lot: &pbStorage.Lot{Id: uint64(1), ProductId: uint64(1), Number: "A", Quantity: uint64(5), ExpiresAt: "2022-10-01"},
+		}, nil)
+
+		// act
+		res, err := f.service.LotAdd(context.Background(), &pbApi.LotAddRequest{
+			ProductId: uint64(1),
+			Number:    "A",
+			Quantity:  uint64(5),
+			ExpiresAt: "2022-10-01",
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pbApi.LotAddResponse{
+			Lot: &pbApi.Lot{Id: uint64(1), ProductId: uint64(1), Number: "A", Quantity: uint64(5), ExpiresAt: "2022-10-01"},
+		})
+	})
+
+	t.Run("empty lot number", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		// act
+		_, err := f.service.LotAdd(context.Background(), &pbApi.LotAddRequest{
+			ProductId: uint64(1),
+			Quantity:  uint64(5),
+			ExpiresAt: "2022-10-01",
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = lot number length must be greater than 0")
+	})
+}
+
+func TestStocktakeCommit(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.storageClient.EXPECT().StocktakeCommit(gomock.Any(), &pbStorage.StocktakeCommitRequest{Id: uint64(1)}).Return(&pbStorage.StocktakeCommitResponse{
+			Stocktake:   &pbStorage.Stocktake{Id: uint64(1), Status: "committed", OpenedBy: "user1", CommittedBy: "user2"},
+			Adjustments: []*pbStorage.StockAdjustment{{Id: uint64(1), ProductId: uint64(2), Before: uint64(5), After: uint64(3)}},
+		}, nil)
+
+		// act
+		res, err := f.service.StocktakeCommit(context.Background(), &pbApi.StocktakeCommitRequest{Id: uint64(1), CommittedBy: "user2"})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pbApi.StocktakeCommitResponse{
+			Stocktake:   &pbApi.Stocktake{Id: uint64(1), Status: "committed", OpenedBy: "user1", CommittedBy: "user2"},
+			Adjustments: []*pbApi.StockAdjustment{{Id: uint64(1), ProductId: uint64(2), Before: uint64(5), After: uint64(3)}},
+		})
+	})
+
+	t.Run("session already committed", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.storageClient.EXPECT().StocktakeCommit(gomock.Any(), gomock.Any()).
+			Return(nil, status.Error(codes.FailedPrecondition, "1: stocktake session is not open"))
+
+		// act
+		_, err := f.service.StocktakeCommit(context.Background(), &pbApi.StocktakeCommitRequest{Id: uint64(1), CommittedBy: "user2"})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = FailedPrecondition desc = 1: stocktake session is not open")
+	})
+}
+
+func TestStockValuation(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.storageClient.EXPECT().StockValuation(gomock.Any(), &pbStorage.StockValuationRequest{}).Return(&pbStorage.StockValuationResponse{
+			TotalValue:     uint64(50),
+			TotalQuantity:  uint64(12),
+			ProductCount:   uint64(3),
+			ZeroStockCount: uint64(1),
+			ByStatus:       []*pbStorage.StatusValue{{Status: "active", ProductCount: uint64(2), Quantity: uint64(12), Value: uint64(50)}},
+			ByCategory:     []*pbStorage.CategoryValue{{Category: "food", ProductCount: uint64(2), Quantity: uint64(12), Value: uint64(50)}},
+		}, nil)
+
+		// act
+		res, err := f.service.StockValuation(context.Background(), &pbApi.StockValuationRequest{})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pbApi.StockValuationResponse{
+			TotalValue:     uint64(50),
+			TotalQuantity:  uint64(12),
+			ProductCount:   uint64(3),
+			ZeroStockCount: uint64(1),
+			ByStatus:       []*pbApi.StatusValue{{Status: "active", ProductCount: uint64(2), Quantity: uint64(12), Value: uint64(50)}},
+			ByCategory:     []*pbApi.CategoryValue{{Category: "food", ProductCount: uint64(2), Quantity: uint64(12), Value: uint64(50)}},
+		})
+	})
+}
+
+func TestProductCreateWithAmount(t *testing.T) {
+	t.Run("zero amount", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		amount := "0.000"
+
+		// act
+		_, err := f.service.ProductCreate(context.Background(), &pbApi.ProductCreateRequest{
+			Name:   "flour",
+			Price:  uint64(1),
+			Amount: &amount,
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = quantity must be greater than 0")
+	})
+
+	t.Run("malformed amount", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		amount := "1,5"
+
+		// act
+		_, err := f.service.ProductCreate(context.Background(), &pbApi.ProductCreateRequest{
+			Name:   "flour",
+			Price:  uint64(1),
+			Amount: &amount,
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), `rpc error: code = InvalidArgument desc = "1,5": invalid decimal quantity`)
+	})
+}
+
+func TestUploadProductImage(t *testing.T) {
+	info := &pbApi.UploadProductImageRequest{Data: &pbApi.UploadProductImageRequest_Info_{
+		Info: &pbApi.UploadProductImageRequest_Info{ProductId: uint64(1), ContentType: "image/png"},
+	}}
+	chunk := &pbApi.UploadProductImageRequest{Data: &pbApi.UploadProductImageRequest_Chunk{Chunk: []byte("content")}}
+
+	t.Run("success", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		upload := &UploadProductImageClientStreamMock{response: &pbStorage.UploadProductImageResponse{
+			Image: &pbStorage.ProductImage{Id: uint64(1), ProductId: uint64(1), ContentType: "image/png", Size: uint64(7)},
+		}}
+		f.storageClient.EXPECT().UploadProductImage(gomock.Any()).Return(upload, nil)
+
+		stream := &UploadProductImageServerStreamMock{requests: []*pbApi.UploadProductImageRequest{info, chunk}}
+
+		// act
+		err := f.service.UploadProductImage(stream)
+
+		// assert
+		require.NoError(t, err)
+		require.Len(t, upload.sent, 2)
+		assert.Equal(t, upload.sent[0].GetInfo().GetContentType(), "image/png")
+		assert.Equal(t, upload.sent[1].GetChunk(), []byte("content"))
+		assert.Equal(t, stream.response.GetImage(), &pbApi.ProductImage{
+			Id:          uint64(1),
+			ProductId:   uint64(1),
+			ContentType: "image/png",
+			Size:        uint64(7),
+		})
+	})
+
+	t.Run("unsupported content type", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		stream := &UploadProductImageServerStreamMock{requests: []*pbApi.UploadProductImageRequest{{
+			Data: &pbApi.UploadProductImageRequest_Info_{
+				Info: &pbApi.UploadProductImageRequest_Info{ProductId: uint64(1), ContentType: "text/plain"},
+			},
+		}}}
+
+		// act
+		err := f.service.UploadProductImage(stream)
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), `rpc error: code = InvalidArgument desc = "text/plain": unsupported image content type, expected image/jpeg, image/png, image/gif or image/webp`)
+	})
+
+	t.Run("storage validation error", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		upload := &UploadProductImageClientStreamMock{err: status.Error(codes.InvalidArgument, "image must not be empty")}
+		f.storageClient.EXPECT().UploadProductImage(gomock.Any()).Return(upload, nil)
+
+		// act
+		err := f.service.UploadProductImage(&UploadProductImageServerStreamMock{requests: []*pbApi.UploadProductImageRequest{info}})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = image must not be empty")
+	})
+}
+
+func TestDownloadProductImage(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.storageClient.EXPECT().DownloadProductImage(gomock.Any(), &pbStorage.DownloadProductImageRequest{ProductId: uint64(1), Id: uint64(2)}).
+			Return(&DownloadProductImageClientStreamMock{responses: []*pbStorage.DownloadProductImageResponse{
+				{Data: &pbStorage.DownloadProductImageResponse_Image{Image: &pbStorage.ProductImage{Id: uint64(2), ContentType: "image/png"}}},
+				{Data: &pbStorage.DownloadProductImageResponse_Chunk{Chunk: []byte("content")}},
+			}}, nil)
+
+		stream := &DownloadProductImageServerStreamMock{}
+
+		// act
+		err := f.service.DownloadProductImage(&pbApi.DownloadProductImageRequest{ProductId: uint64(1), Id: uint64(2)}, stream)
+
+		// assert
+		require.NoError(t, err)
+		require.Len(t, stream.responses, 2)
+		assert.Equal(t, stream.responses[0].GetImage().GetContentType(), "image/png")
+		assert.Equal(t, stream.responses[1].GetChunk(), []byte("content"))
+	})
+
+	t.Run("not found error", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.storageClient.EXPECT().DownloadProductImage(gomock.Any(), gomock.Any()).
+			Return(&DownloadProductImageClientStreamMock{err: status.Error(codes.NotFound, "2: image does not exist")}, nil)
+
+		// act
+		err := f.service.DownloadProductImage(&pbApi.DownloadProductImageRequest{ProductId: uint64(1), Id: uint64(2)}, &DownloadProductImageServerStreamMock{})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = NotFound desc = image not found")
+	})
+}
+
+func TestRelationCreate(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.storageClient.EXPECT().RelationCreate(gomock.Any(), &pbStorage.RelationCreateRequest{
+			ProductId: uint64(1),
+			RelatedId: uint64(2),
+			Type:      "accessory",
+		}).Return(&pbStorage.RelationCreateResponse{
+			Relation: &pbStorage.ProductRelation{ProductId: uint64(1), RelatedId: uint64(2), Type: "accessory"},
+		}, nil)
+
+		// act
+		res, err := f.service.RelationCreate(context.Background(), &pbApi.RelationCreateRequest{
+			ProductId: uint64(1),
+			RelatedId: uint64(2),
+			Type:      "accessory",
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pbApi.RelationCreateResponse{
+			Relation: &pbApi.ProductRelation{ProductId: uint64(1), RelatedId: uint64(2), Type: "accessory"},
+		})
+	})
+
+	t.Run("self relation", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		// act
+		_, err := f.service.RelationCreate(context.Background(), &pbApi.RelationCreateRequest{
+			ProductId: uint64(1),
+			RelatedId: uint64(1),
+			Type:      "related",
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = product can't be related to itself")
+	})
+
+	t.Run("relation already exists", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.storageClient.EXPECT().RelationCreate(gomock.Any(), gomock.Any()).
+			Return(nil, status.Error(codes.AlreadyExists, "1 -> 2: relation already exists"))
+
+		// act
+		_, err := f.service.RelationCreate(context.Background(), &pbApi.RelationCreateRequest{
+			ProductId: uint64(1),
+			RelatedId: uint64(2),
+			Type:      "related",
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = AlreadyExists desc = 1 -> 2: relation already exists")
+	})
+}
+
+func TestRelationList(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.storageClient.EXPECT().RelationList(gomock.Any(), &pbStorage.RelationListRequest{ProductId: uint64(1)}).
+			Return(&RelationListClientStreamMock{responses: []*pbStorage.RelationListResponse{
+				{Relation: &pbStorage.ProductRelation{ProductId: uint64(1), RelatedId: uint64(2), Type: "accessory"}},
+				{Relation: &pbStorage.ProductRelation{ProductId: uint64(1), RelatedId: uint64(3), Type: "replacement"}},
+			}}, nil)
+
+		// act
+		res, err := f.service.RelationList(context.Background(), &pbApi.RelationListRequest{ProductId: uint64(1)})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pbApi.RelationListResponse{Relations: []*pbApi.ProductRelation{
+			{ProductId: uint64(1), RelatedId: uint64(2), Type: "accessory"},
+			{ProductId: uint64(1), RelatedId: uint64(3), Type: "replacement"},
+		}})
+	})
+}
+
+func TestRelationDelete(t *testing.T) {
+	t.Run("relation does not exist", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.storageClient.EXPECT().RelationDelete(gomock.Any(), &pbStorage.RelationDeleteRequest{ProductId: uint64(1), RelatedId: uint64(2)}).
+			Return(nil, status.Error(codes.NotFound, "1 -> 2: relation does not exist"))
+
+		// act
+		_, err := f.service.RelationDelete(context.Background(), &pbApi.RelationDeleteRequest{ProductId: uint64(1), RelatedId: uint64(2)})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = NotFound desc = relation not found")
+	})
+}
+
+func TestGetRelatedProducts(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		relationType := "accessory"
+		f.storageClient.EXPECT().GetRelatedProducts(gomock.Any(), &pbStorage.GetRelatedProductsRequest{ProductId: uint64(1), Type: &relationType}).
+			Return(&GetRelatedProductsClientStreamMock{responses: []*pbStorage.GetRelatedProductsResponse{
+				{Product: &pbStorage.RelatedProduct{Id: uint64(2), Name: "cable", Price: uint64(5), Quantity: uint64(3), Status: "active", Unit: "pcs", Amount: "3", RelationType: "accessory"}},
+			}}, nil)
+
+		// act
+		res, err := f.service.GetRelatedProducts(context.Background(), &pbApi.GetRelatedProductsRequest{ProductId: uint64(1), Type: &relationType})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pbApi.GetRelatedProductsResponse{Products: []*pbApi.RelatedProduct{
+			{Id: uint64(2), Name: "cable", Price: uint64(5), Quantity: uint64(3), Status: "active", Unit: "pcs", Amount: "3", RelationType: "accessory"},
+		}})
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		relationType := "sibling"
+
+		// act
+		_, err := f.service.GetRelatedProducts(context.Background(), &pbApi.GetRelatedProductsRequest{ProductId: uint64(1), Type: &relationType})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = sibling: unknown relation type, expected related, accessory or replacement")
+	})
+}
+
+func TestExchangeRateSet(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.storageClient.EXPECT().ExchangeRateSet(gomock.Any(), &pbStorage.ExchangeRateSetRequest{Currency: "USD", Rate: "0.013725"}).
+			Return(&pbStorage.ExchangeRateSetResponse{
+				Rate: &pbStorage.ExchangeRate{Currency: "USD", Rate: "0.013725", UpdatedAt: "2022-10-03T00:00:00Z"},
+			}, nil)
+
+		// act
+		res, err := f.service.ExchangeRateSet(context.Background(), &pbApi.ExchangeRateSetRequest{Currency: "usd", Rate: "0.013725"})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pbApi.ExchangeRateSetResponse{
+			Rate: &pbApi.ExchangeRate{Currency: "USD", Rate: "0.013725", UpdatedAt: "2022-10-03T00:00:00Z"},
+		})
+	})
+
+	t.Run("too precise rate", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		// act
+		_, err := f.service.ExchangeRateSet(context.Background(), &pbApi.ExchangeRateSetRequest{Currency: "USD", Rate: "0.0137251"})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = \"0.0137251\": exchange rate allows 6 decimal places")
+	})
+}
+
+func TestExchangeRateList(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.storageClient.EXPECT().ExchangeRateList(gomock.Any(), &pbStorage.ExchangeRateListRequest{}).
+			Return(&ExchangeRateListClientStreamMock{responses: []*pbStorage.ExchangeRateListResponse{
+				{Rate: &pbStorage.ExchangeRate{Currency: "EUR", Rate: "0.01401", UpdatedAt: "2022-10-03T00:00:00Z"}},
+			}}, nil)
+
+		// act
+		res, err := f.service.ExchangeRateList(context.Background(), &pbApi.ExchangeRateListRequest{})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pbApi.ExchangeRateListResponse{Rates: []*pbApi.ExchangeRate{
+			{Currency: "EUR", Rate: "0.01401", UpdatedAt: "2022-10-03T00:00:00Z"},
+		}})
 	})
 }
@@ -1,10 +1,12 @@
 package proxyApi
 
 import (
+	"context"
 	"github.com/golang/mock/gomock"
 	"google.golang.org/grpc"
 	mock_storage "homework-1/internal/api/proxyApi/mock"
 	pbStorage "homework-1/pkg/api/storage/v1"
+	pbApi "homework-1/pkg/api/v1"
 	"io"
 	"testing"
 )
@@ -51,3 +53,117 @@ func (m *ProductListResponseStreamMock) Recv() (*pbStorage.ProductListResponse,
 	}
 	return <-m.queue, nil
 }
+
+type UploadProductImageServerStreamMock struct {
+	grpc.ServerStream
+	requests []*pbApi.UploadProductImageRequest
+	response *pbApi.UploadProductImageResponse
+}
+
+func (m *UploadProductImageServerStreamMock) Context() context.Context {
+	return context.Background()
+}
+
+func (m *UploadProductImageServerStreamMock) Recv() (*pbApi.UploadProductImageRequest, error) {
+	if len(m.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := m.requests[0]
+	m.requests = m.requests[1:]
+	return req, nil
+}
+
+func (m *UploadProductImageServerStreamMock) SendAndClose(resp *pbApi.UploadProductImageResponse) error {
+	m.response = resp
+	return nil
+}
+
+type UploadProductImageClientStreamMock struct {
+	grpc.ClientStream
+	sent     []*pbStorage.UploadProductImageRequest
+	response *pbStorage.UploadProductImageResponse
+	err      error
+}
+
+func (m *UploadProductImageClientStreamMock) Send(req *pbStorage.UploadProductImageRequest) error {
+	m.sent = append(m.sent, req)
+	return nil
+}
+
+func (m *UploadProductImageClientStreamMock) CloseAndRecv() (*pbStorage.UploadProductImageResponse, error) {
+	return m.response, m.err
+}
+
+type DownloadProductImageClientStreamMock struct {
+	grpc.ClientStream
+	responses []*pbStorage.DownloadProductImageResponse
+	err       error
+}
+
+func (m *DownloadProductImageClientStreamMock) Recv() (*pbStorage.DownloadProductImageResponse, error) {
+	if len(m.responses) == 0 {
+		if m.err != nil {
+			return nil, m.err
+		}
+		return nil, io.EOF
+	}
+	resp := m.responses[0]
+	m.responses = m.responses[1:]
+	return resp, nil
+}
+
+type DownloadProductImageServerStreamMock struct {
+	grpc.ServerStream
+	responses []*pbApi.DownloadProductImageResponse
+}
+
+func (m *DownloadProductImageServerStreamMock) Context() context.Context {
+	return context.Background()
+}
+
+func (m *DownloadProductImageServerStreamMock) Send(resp *pbApi.DownloadProductImageResponse) error {
+	m.responses = append(m.responses, resp)
+	return nil
+}
+
+type RelationListClientStreamMock struct {
+	grpc.ClientStream
+	responses []*pbStorage.RelationListResponse
+}
+
+func (m *RelationListClientStreamMock) Recv() (*pbStorage.RelationListResponse, error) {
+	if len(m.responses) == 0 {
+		return nil, io.EOF
+	}
+	resp := m.responses[0]
+	m.responses = m.responses[1:]
+	return resp, nil
+}
+
+type GetRelatedProductsClientStreamMock struct {
+	grpc.ClientStream
+	responses []*pbStorage.GetRelatedProductsResponse
+}
+
+func (m *GetRelatedProductsClientStreamMock) Recv() (*pbStorage.GetRelatedProductsResponse, error) {
+	if len(m.responses) == 0 {
+		return nil, io.EOF
+	}
+	resp := m.responses[0]
+	m.responses = m.responses[1:]
+	return resp, nil
+}
+
+type ExchangeRateListClientStreamMock struct {
+	grpc.ClientStream
+	responses []*pbStorage.ExchangeRateListResponse
+}
+
+func (m *ExchangeRateListClientStreamMock) Recv() (*pbStorage.ExchangeRateListResponse, error) {
+	if len(m.responses) == 0 {
+		return nil, io.EOF
+	}
+	resp := m.responses[0]
+	m.responses = m.responses[1:]
+	return resp, nil
+}
@@ -5,17 +5,41 @@ import (
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
-	"homework-1/internal/metrics"
+	"homework-1/internal/approvals"
+	"homework-1/internal/auth"
+	"homework-1/internal/gallery"
+	"homework-1/internal/interceptors"
+	"homework-1/internal/locales"
+	"homework-1/internal/models/attributes"
+	"homework-1/internal/models/images"
+	"homework-1/internal/models/lots"
+	"homework-1/internal/models/orders"
 	"homework-1/internal/models/products"
+	"homework-1/internal/models/purchases"
+	"homework-1/internal/models/relations"
+	"homework-1/internal/models/reports"
+	"homework-1/internal/models/stocktakes"
+	"homework-1/internal/models/units"
+	"homework-1/internal/money"
+	"homework-1/internal/ordering"
 	"homework-1/internal/repository"
+	"homework-1/internal/tenants"
 	pb "homework-1/pkg/api/storage/v1"
+	"io"
 	"time"
 )
 
 const maxTimeout = time.Millisecond * 27
 
+const (
+	// maxTransferTimeout bounds image uploads and downloads, which take longer than other requests
+	maxTransferTimeout = time.Second * 30
+	imageChunkSize     = 64 << 10
+)
+
+var errImageInfoRepeated = errors.New("image info must be sent only in the first message")
+
 func New(deps Deps) *implementation {
 	return &implementation{
 		deps: deps,
@@ -28,174 +52,1245 @@ type implementation struct {
 }
 
 type Deps struct {
-	ProductRepository repository.Product
-	Metrics           *metrics.Metrics
+	ProductRepository     repository.Product
+	PriceChangeRepository repository.PriceChange
+	// ApprovalService updates products and holds large price changes back for approval
+	ApprovalService       *approvals.Service
+	StockRepository       repository.Stock
+	PurchaseRepository    repository.Purchase
+	OrderService          *ordering.Service
+	LotRepository         repository.Lot
+	StocktakeRepository   repository.Stocktake
+	ReportRepository      repository.Report
+	TranslationRepository repository.Translation
+	ImageRepository       repository.Image
+	ImageService          *gallery.Service
+	RelationRepository    repository.Relation
+	// ExchangeRateRepository converts prices into the currency requested by the client
+	ExchangeRateRepository repository.ExchangeRate
+	// AttributeRegistry validates custom product attributes against their category schema
+	AttributeRegistry *attributes.Registry
 }
 
 func (i *implementation) ProductList(in *pb.ProductListRequest, srv pb.StorageService_ProductListServer) error {
-	i.deps.Metrics.IncomingRequestCounter.Inc()
-
-	md, _ := metadata.FromIncomingContext(srv.Context())
-	log.Infof("ProductList request metadata: %v", md)
-	log.Debugf("ProductList request data: %v", in)
+	locale := locales.FromIncomingContext(srv.Context())
+	currency, err := money.FromIncomingContext(srv.Context())
+	if err != nil {
+		return interceptors.Invalid(err)
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), maxTimeout)
+	ctx, cancel := context.WithTimeout(tenants.Detach(srv.Context()), maxTimeout)
 	defer cancel()
 
-	allProducts, err := i.deps.ProductRepository.GetAllProducts(ctx, in.GetPage(), in.GetSize())
+	rate, err := i.exchangeRate(ctx, currency)
+	if err != nil {
+		return err
+	}
+
+	var allProducts []*products.Product
+	productStatus := products.Status(in.GetStatus())
+	if in.Status != nil {
+		if err = products.ValidateStatus(productStatus); err != nil {
+			return interceptors.Invalid(err)
+		}
+	}
+	if in.Category != nil || len(in.GetAttributes()) > 0 {
+		filter := products.Filter{Status: productStatus, Category: in.GetCategory()}
+		if filter.Attributes, err = i.deps.AttributeRegistry.ParseFilter(in.GetCategory(), in.GetAttributes()); err != nil {
+			return interceptors.Invalid(err)
+		}
+		allProducts, err = i.deps.ProductRepository.FindProducts(ctx, filter, in.GetPage(), in.GetSize())
+	} else if in.Status != nil {
+		allProducts, err = i.deps.ProductRepository.GetProductsByStatus(ctx, productStatus, in.GetPage(), in.GetSize())
+	} else {
+		allProducts, err = i.deps.ProductRepository.GetAllProducts(ctx, in.GetPage(), in.GetSize())
+	}
+	if err != nil {
+		return err
+	}
+
+	translations, err := i.translations(ctx, allProducts, locale)
 	if err != nil {
-		i.deps.Metrics.FailedRequestCounter.Inc()
-		log.WithError(err).Error("ProductRepository: GetAllProducts: internal error")
-		return status.Error(codes.Internal, "internal error")
+		return err
 	}
 
 	for _, product := range allProducts {
+		product, description := product.Localize(translations[product.GetId()])
+		price, err := rate.Convert(product.GetPrice())
+		if err != nil {
+			return err
+		}
 		productResponse := pb.ProductListResponse{
-			Id:       product.GetId(),
-			Name:     product.GetName(),
-			Price:    product.GetPrice(),
-			Quantity: product.GetQuantity(),
+			Id:          product.GetId(),
+			Name:        product.GetName(),
+			Price:       price,
+			Quantity:    product.GetQuantity(),
+			Status:      product.GetStatus().String(),
+			Unit:        product.GetUnit().String(),
+			Amount:      product.Amount(),
+			Barcode:     product.GetBarcode(),
+			Category:    product.GetCategory(),
+			Attributes:  product.Attributes.Strings(),
+			Description: description,
+			Locale:      locale.String(),
+			Currency:    currency.String(),
 		}
 		if err = srv.Send(&productResponse); err != nil {
 			log.WithError(err).Error("ProductList send")
 		}
 	}
 
-	i.deps.Metrics.SuccessfulRequestCounter.Inc()
 	return nil
 }
 
 func (i *implementation) ProductGet(ctx context.Context, in *pb.ProductGetRequest) (*pb.ProductGetResponse, error) {
-	i.deps.Metrics.IncomingRequestCounter.Inc()
-
-	md, _ := metadata.FromIncomingContext(ctx)
-	log.Infof("ProductGet request metadata: %v", md)
-	log.Debugf("ProductGet request data: %v", in)
+	locale := locales.FromIncomingContext(ctx)
+	currency, err := money.FromIncomingContext(ctx)
+	if err != nil {
+		return nil, interceptors.Invalid(err)
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), maxTimeout)
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
 	defer cancel()
 
+	rate, err := i.exchangeRate(ctx, currency)
+	if err != nil {
+		return nil, err
+	}
+
 	p, err := i.deps.ProductRepository.GetProductById(ctx, in.GetId())
 	if err != nil {
-		if errors.Is(err, repository.ProductNotExists) {
-			i.deps.Metrics.UnsuccessfulRequestCounter.Inc()
-			return nil, status.Error(codes.NotFound, err.Error())
-		}
-		i.deps.Metrics.FailedRequestCounter.Inc()
-		log.WithError(err).Error("ProductRepository: GetProductById: internal error")
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, err
+	}
+
+	translations, err := i.translations(ctx, []*products.Product{p}, locale)
+	if err != nil {
+		return nil, err
+	}
+	p, description := p.Localize(translations[p.GetId()])
+
+	price, err := rate.Convert(p.GetPrice())
+	if err != nil {
+		return nil, err
 	}
 
-	i.deps.Metrics.SuccessfulRequestCounter.Inc()
 	return &pb.ProductGetResponse{
-		Id:       p.GetId(),
-		Name:     p.GetName(),
-		Price:    p.GetPrice(),
-		Quantity: p.GetQuantity(),
+		Id:          p.GetId(),
+		Name:        p.GetName(),
+		Price:       price,
+		Quantity:    p.GetQuantity(),
+		Status:      p.GetStatus().String(),
+		Unit:        p.GetUnit().String(),
+		Amount:      p.Amount(),
+		Barcode:     p.GetBarcode(),
+		Category:    p.GetCategory(),
+		Attributes:  p.Attributes.Strings(),
+		Description: description,
+		Locale:      locale.String(),
+		Currency:    currency.String(),
 	}, nil
 }
 
-func (i *implementation) ProductCreate(ctx context.Context, in *pb.ProductCreateRequest) (*pb.ProductCreateResponse, error) {
-	i.deps.Metrics.IncomingRequestCounter.Inc()
+func (i *implementation) ProductGetByBarcode(ctx context.Context, in *pb.ProductGetByBarcodeRequest) (*pb.ProductGetByBarcodeResponse, error) {
+	locale := locales.FromIncomingContext(ctx)
+	currency, err := money.FromIncomingContext(ctx)
+	if err != nil {
+		return nil, interceptors.Invalid(err)
+	}
+
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	rate, err := i.exchangeRate(ctx, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	barcode, err := products.NormalizeBarcode(in.GetBarcode())
+	if err != nil {
+		return nil, interceptors.Invalid(err)
+	}
+
+	p, err := i.deps.ProductRepository.GetProductByBarcode(ctx, barcode)
+	if err != nil {
+		return nil, err
+	}
 
-	md, _ := metadata.FromIncomingContext(ctx)
-	log.Infof("ProductCreate request metadata: %v", md)
-	log.Debugf("ProductCreate request data: %v", in)
+	translations, err := i.translations(ctx, []*products.Product{p}, locale)
+	if err != nil {
+		return nil, err
+	}
+	p, description := p.Localize(translations[p.GetId()])
 
-	ctx, cancel := context.WithTimeout(context.Background(), maxTimeout)
+	price, err := rate.Convert(p.GetPrice())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ProductGetByBarcodeResponse{
+		Id:          p.GetId(),
+		Name:        p.GetName(),
+		Price:       price,
+		Quantity:    p.GetQuantity(),
+		Status:      p.GetStatus().String(),
+		Unit:        p.GetUnit().String(),
+		Amount:      p.Amount(),
+		Barcode:     p.GetBarcode(),
+		Category:    p.GetCategory(),
+		Attributes:  p.Attributes.Strings(),
+		Description: description,
+		Locale:      locale.String(),
+		Currency:    currency.String(),
+	}, nil
+}
+
+func (i *implementation) ProductTranslate(ctx context.Context, in *pb.ProductTranslateRequest) (*pb.ProductTranslateResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
 	defer cancel()
 
+	locale, err := locales.Parse(in.GetLocale())
+	if err != nil {
+		return nil, interceptors.Invalid(err)
+	}
+
+	translation := products.Translation{
+		ProductId:   in.GetId(),
+		Locale:      locale,
+		Name:        in.GetName(),
+		Description: in.GetDescription(),
+	}
+	if err = products.ValidateTranslation(translation); err != nil {
+		return nil, interceptors.Invalid(err)
+	}
+
+	saved, err := i.deps.TranslationRepository.SetTranslation(ctx, translation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ProductTranslateResponse{
+		Id:          saved.ProductId,
+		Locale:      saved.Locale.String(),
+		Name:        saved.Name,
+		Description: saved.Description,
+	}, nil
+}
+
+// exchangeRate returns the rate that converts prices into the currency, prices in the base currency are not converted.
+func (i *implementation) exchangeRate(ctx context.Context, currency money.Currency) (*money.Rate, error) {
+	if currency == money.Base {
+		rate := money.BaseRate
+		return &rate, nil
+	}
+	return i.deps.ExchangeRateRepository.GetExchangeRate(ctx, currency)
+}
+
+// translations picks the translation of every product in the locale, see products.PickTranslations.
+func (i *implementation) translations(ctx context.Context, list []*products.Product, locale locales.Locale) (map[uint64]*products.Translation, error) {
+	if len(list) == 0 {
+		return nil, nil
+	}
+	ids := make([]uint64, 0, len(list))
+	for _, product := range list {
+		ids = append(ids, product.GetId())
+	}
+
+	translations, err := i.deps.TranslationRepository.GetTranslations(ctx, ids, locale)
+	if err != nil {
+		return nil, err
+	}
+	return products.PickTranslations(translations, locale), nil
+}
+
+func (i *implementation) ProductCreate(ctx context.Context, in *pb.ProductCreateRequest) (*pb.ProductCreateResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	unit, err := units.Parse(in.GetUnit())
+	if err != nil {
+		return nil, interceptors.Invalid(err)
+	}
+
+	quantity := in.GetQuantity()
+	if in.Amount != nil {
+		if quantity, err = units.ParseQuantity(in.GetAmount(), unit); err != nil {
+			return nil, interceptors.Invalid(err)
+		}
+	}
+
+	status := products.Status(in.GetStatus())
+	if status != "" {
+		if err = products.ValidateStatus(status); err != nil {
+			return nil, interceptors.Invalid(err)
+		}
+	}
+
 	p := products.Product{
 		Name:     in.GetName(),
 		Price:    in.GetPrice(),
-		Quantity: in.GetQuantity(),
+		Quantity: quantity,
+		Status:   status,
+		Unit:     unit,
+		Category: in.GetCategory(),
+	}
+	if err = p.SetBarcode(in.GetBarcode()); err != nil {
+		return nil, interceptors.Invalid(err)
+	}
+	if p.Attributes, err = i.deps.AttributeRegistry.Parse(p.Category, in.GetAttributes()); err != nil {
+		return nil, interceptors.Invalid(err)
 	}
 
 	product, err := i.deps.ProductRepository.CreateProduct(ctx, p)
 	if err != nil {
-		if errors.Is(err, repository.ProductAlreadyExists) {
-			i.deps.Metrics.UnsuccessfulRequestCounter.Inc()
-			return nil, status.Error(codes.AlreadyExists, err.Error())
-		}
-		i.deps.Metrics.FailedRequestCounter.Inc()
-		log.WithError(err).Error("ProductRepository: ProductCreate: internal error")
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, err
 	}
 
-	i.deps.Metrics.SuccessfulRequestCounter.Inc()
 	return &pb.ProductCreateResponse{
-		Id:       product.GetId(),
-		Name:     product.GetName(),
-		Price:    product.GetPrice(),
-		Quantity: product.GetQuantity(),
+		Id:         product.GetId(),
+		Name:       product.GetName(),
+		Price:      product.GetPrice(),
+		Quantity:   product.GetQuantity(),
+		Status:     product.GetStatus().String(),
+		Unit:       product.GetUnit().String(),
+		Amount:     product.Amount(),
+		Barcode:    product.GetBarcode(),
+		Category:   product.GetCategory(),
+		Attributes: product.Attributes.Strings(),
 	}, nil
 }
 
 func (i *implementation) ProductUpdate(ctx context.Context, in *pb.ProductUpdateRequest) (*pb.ProductUpdateResponse, error) {
-	i.deps.Metrics.IncomingRequestCounter.Inc()
-
-	md, _ := metadata.FromIncomingContext(ctx)
-	log.Infof("ProductUpdate request metadata: %v", md)
-	log.Debugf("ProductUpdate request data: %v", in)
-
-	ctx, cancel := context.WithTimeout(context.Background(), maxTimeout)
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
 	defer cancel()
 
 	product, err := i.deps.ProductRepository.GetProductById(ctx, in.GetId())
 	if err != nil {
-		if errors.Is(err, repository.ProductNotExists) {
-			i.deps.Metrics.UnsuccessfulRequestCounter.Inc()
-			return nil, status.Error(codes.NotFound, err.Error())
+		return nil, err
+	}
+
+	quantity := in.GetQuantity()
+	if in.Amount != nil {
+		unit := product.GetUnit()
+		if in.Unit != nil {
+			if unit, err = units.Parse(in.GetUnit()); err != nil {
+				return nil, interceptors.Invalid(err)
+			}
+		}
+		if quantity, err = product.QuantityOf(in.GetAmount(), unit); err != nil {
+			return nil, interceptors.Invalid(err)
 		}
-		i.deps.Metrics.FailedRequestCounter.Inc()
-		log.WithError(err).Error("ProductRepository: GetProductById: internal error")
-		return nil, status.Error(codes.Internal, "internal error")
 	}
 
-	product.Name = in.GetName()
-	product.Price = in.GetPrice()
-	product.Quantity = in.GetQuantity()
+	barcode := product.GetBarcode()
+	if in.Barcode != nil {
+		if barcode, err = products.ParseBarcode(in.GetBarcode()); err != nil {
+			return nil, interceptors.Invalid(err)
+		}
+	}
 
-	if product, err = i.deps.ProductRepository.UpdateProduct(ctx, *product); err != nil {
-		if errors.Is(err, repository.ProductNotExists) {
-			i.deps.Metrics.UnsuccessfulRequestCounter.Inc()
-			return nil, status.Error(codes.NotFound, err.Error())
+	category, attrs := product.GetCategory(), product.Attributes
+	if in.Category != nil {
+		if category = in.GetCategory(); category == "" {
+			attrs = nil
 		}
-		i.deps.Metrics.FailedRequestCounter.Inc()
-		log.WithError(err).Error("ProductRepository: ProductUpdate: internal error")
-		return nil, status.Error(codes.Internal, "internal error")
+	}
+	if len(in.GetAttributes()) > 0 {
+		attrs, err = i.deps.AttributeRegistry.Parse(category, in.GetAttributes())
+	} else if in.Category != nil {
+		err = i.deps.AttributeRegistry.Validate(category, attrs)
+	}
+	if err != nil {
+		return nil, interceptors.Invalid(err)
+	}
+
+	updated := *product.Copy()
+	updated.Name = in.GetName()
+	updated.Price = in.GetPrice()
+	updated.Quantity = quantity
+	updated.Barcode = barcode
+	updated.Category = category
+	updated.Attributes = attrs
+
+	product, change, err := i.deps.ApprovalService.UpdateProduct(ctx, product, updated, func() (string, error) {
+		return caller(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var changeId uint64
+	if change != nil {
+		changeId = change.Id
 	}
 
-	i.deps.Metrics.SuccessfulRequestCounter.Inc()
 	return &pb.ProductUpdateResponse{
+		Id:         product.GetId(),
+		Name:       product.GetName(),
+		Price:      product.GetPrice(),
+		Quantity:   product.GetQuantity(),
+		Status:     product.GetStatus().String(),
+		Unit:       product.GetUnit().String(),
+		Amount:     product.Amount(),
+		Barcode:    product.GetBarcode(),
+		Category:   product.GetCategory(),
+		Attributes: product.Attributes.Strings(),
+		ChangeId:   changeId,
+	}, nil
+}
+
+func (i *implementation) ProductDelete(ctx context.Context, in *pb.ProductDeleteRequest) (*pb.ProductDeleteResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	if err := i.deps.ImageService.DeleteProduct(ctx, in.GetId()); err != nil {
+		return nil, err
+	}
+
+	return &pb.ProductDeleteResponse{}, nil
+}
+
+func (i *implementation) ProductTransition(ctx context.Context, in *pb.ProductTransitionRequest) (*pb.ProductTransitionResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	actor, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := i.deps.ProductRepository.TransitionProductStatus(
+		ctx, in.GetId(), products.Status(in.GetStatus()), in.GetReason(), actor,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ProductTransitionResponse{
 		Id:       product.GetId(),
 		Name:     product.GetName(),
 		Price:    product.GetPrice(),
 		Quantity: product.GetQuantity(),
+		Status:   product.GetStatus().String(),
 	}, nil
 }
 
-func (i *implementation) ProductDelete(ctx context.Context, in *pb.ProductDeleteRequest) (*pb.ProductDeleteResponse, error) {
-	i.deps.Metrics.IncomingRequestCounter.Inc()
+func (i *implementation) ApproveChange(ctx context.Context, in *pb.ApproveChangeRequest) (*pb.ApproveChangeResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	approver, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	change, _, err := i.deps.PriceChangeRepository.ApprovePriceChange(ctx, in.GetId(), approver)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ApproveChangeResponse{
+		Id:          change.Id,
+		ProductId:   change.ProductId,
+		Name:        change.Name,
+		OldPrice:    change.OldPrice,
+		Price:       change.Price,
+		Quantity:    change.Quantity,
+		Status:      string(change.Status),
+		RequestedBy: change.RequestedBy,
+		ResolvedBy:  change.ResolvedBy,
+	}, nil
+}
+
+func (i *implementation) RejectChange(ctx context.Context, in *pb.RejectChangeRequest) (*pb.RejectChangeResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
 
-	md, _ := metadata.FromIncomingContext(ctx)
-	log.Infof("ProductDelete request metadata: %v", md)
-	log.Debugf("ProductDelete request data: %v", in)
+	approver, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	change, err := i.deps.PriceChangeRepository.RejectPriceChange(ctx, in.GetId(), approver)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RejectChangeResponse{
+		Id:          change.Id,
+		ProductId:   change.ProductId,
+		Name:        change.Name,
+		OldPrice:    change.OldPrice,
+		Price:       change.Price,
+		Quantity:    change.Quantity,
+		Status:      string(change.Status),
+		RequestedBy: change.RequestedBy,
+		ResolvedBy:  change.ResolvedBy,
+	}, nil
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), maxTimeout)
+func (i *implementation) ListLowStock(in *pb.ListLowStockRequest, srv pb.StorageService_ListLowStockServer) error {
+	ctx, cancel := context.WithTimeout(tenants.Detach(srv.Context()), maxTimeout)
 	defer cancel()
 
-	if err := i.deps.ProductRepository.DeleteProduct(ctx, in.GetId()); err != nil {
-		i.deps.Metrics.FailedRequestCounter.Inc()
-		if errors.Is(err, repository.ProductNotExists) {
-			i.deps.Metrics.UnsuccessfulRequestCounter.Inc()
-			return nil, status.Error(codes.NotFound, err.Error())
+	lowStock, err := i.deps.StockRepository.GetLowStockProducts(ctx, in.GetPage(), in.GetSize())
+	if err != nil {
+		return err
+	}
+
+	for _, item := range lowStock {
+		response := pb.ListLowStockResponse{
+			ProductId: item.ProductId,
+			Name:      item.Name,
+			Quantity:  item.Quantity,
+			Threshold: item.Threshold,
+		}
+		if err = srv.Send(&response); err != nil {
+			log.WithError(err).Error("ListLowStock send")
 		}
-		i.deps.Metrics.FailedRequestCounter.Inc()
-		log.WithError(err).Error("ProductRepository: ProductDelete: internal error")
-		return nil, status.Error(codes.Internal, "internal error")
 	}
 
-	i.deps.Metrics.SuccessfulRequestCounter.Inc()
-	return &pb.ProductDeleteResponse{}, nil
+	return nil
+}
+
+func (i *implementation) SetReorderThreshold(ctx context.Context, in *pb.SetReorderThresholdRequest) (*pb.SetReorderThresholdResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	if err := i.deps.StockRepository.SetReorderThreshold(ctx, in.GetId(), in.GetThreshold()); err != nil {
+		return nil, err
+	}
+
+	return &pb.SetReorderThresholdResponse{}, nil
+}
+
+func (i *implementation) SupplierCreate(ctx context.Context, in *pb.SupplierCreateRequest) (*pb.SupplierCreateResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	supplier, err := i.deps.PurchaseRepository.CreateSupplier(ctx, purchases.Supplier{
+		Name:    in.GetName(),
+		Contact: in.GetContact(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.SupplierCreateResponse{
+		Id:      supplier.Id,
+		Name:    supplier.Name,
+		Contact: supplier.Contact,
+	}, nil
+}
+
+func (i *implementation) SupplierList(in *pb.SupplierListRequest, srv pb.StorageService_SupplierListServer) error {
+	ctx, cancel := context.WithTimeout(tenants.Detach(srv.Context()), maxTimeout)
+	defer cancel()
+
+	suppliers, err := i.deps.PurchaseRepository.GetAllSuppliers(ctx, in.GetPage(), in.GetSize())
+	if err != nil {
+		return err
+	}
+
+	for _, supplier := range suppliers {
+		response := pb.SupplierListResponse{
+			Id:      supplier.Id,
+			Name:    supplier.Name,
+			Contact: supplier.Contact,
+		}
+		if err = srv.Send(&response); err != nil {
+			log.WithError(err).Error("SupplierList send")
+		}
+	}
+
+	return nil
+}
+
+func (i *implementation) PurchaseOrderCreate(ctx context.Context, in *pb.PurchaseOrderCreateRequest) (*pb.PurchaseOrderCreateResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	lines := make([]*purchases.Line, 0, len(in.GetLines()))
+	for _, line := range in.GetLines() {
+		lines = append(lines, &purchases.Line{
+			ProductId: line.GetProductId(),
+			Quantity:  line.GetQuantity(),
+		})
+	}
+
+	order, err := purchases.NewPurchaseOrder(in.GetSupplierId(), lines)
+	if err != nil {
+		return nil, interceptors.Invalid(err)
+	}
+
+	if order, err = i.deps.PurchaseRepository.CreatePurchaseOrder(ctx, *order); err != nil {
+		return nil, err
+	}
+
+	return &pb.PurchaseOrderCreateResponse{
+		Id:         order.Id,
+		SupplierId: order.SupplierId,
+		Status:     string(order.Status),
+		Lines:      purchaseOrderLinesToPb(order.Lines),
+	}, nil
+}
+
+func (i *implementation) PurchaseOrderGet(ctx context.Context, in *pb.PurchaseOrderGetRequest) (*pb.PurchaseOrderGetResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	order, err := i.deps.PurchaseRepository.GetPurchaseOrderById(ctx, in.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.PurchaseOrderGetResponse{
+		Id:         order.Id,
+		SupplierId: order.SupplierId,
+		Status:     string(order.Status),
+		Lines:      purchaseOrderLinesToPb(order.Lines),
+	}, nil
+}
+
+func (i *implementation) PurchaseOrderList(in *pb.PurchaseOrderListRequest, srv pb.StorageService_PurchaseOrderListServer) error {
+	ctx, cancel := context.WithTimeout(tenants.Detach(srv.Context()), maxTimeout)
+	defer cancel()
+
+	orders, err := i.deps.PurchaseRepository.GetOpenPurchaseOrders(ctx, in.GetPage(), in.GetSize())
+	if err != nil {
+		return err
+	}
+
+	for _, order := range orders {
+		response := pb.PurchaseOrderListResponse{
+			Id:         order.Id,
+			SupplierId: order.SupplierId,
+			Status:     string(order.Status),
+			Lines:      purchaseOrderLinesToPb(order.Lines),
+		}
+		if err = srv.Send(&response); err != nil {
+			log.WithError(err).Error("PurchaseOrderList send")
+		}
+	}
+
+	return nil
+}
+
+func (i *implementation) PurchaseOrderReceive(ctx context.Context, in *pb.PurchaseOrderReceiveRequest) (*pb.PurchaseOrderReceiveResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	receipts := make(map[uint64]uint64, len(in.GetLines()))
+	for _, line := range in.GetLines() {
+		if _, ok := receipts[line.GetProductId()]; ok {
+			return nil, status.Errorf(codes.InvalidArgument, "product %d: %s", line.GetProductId(), purchases.ErrDuplicateLine)
+		}
+		receipts[line.GetProductId()] = line.GetQuantity()
+	}
+
+	order, err := i.deps.PurchaseRepository.ReceivePurchaseOrder(ctx, in.GetId(), receipts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.PurchaseOrderReceiveResponse{
+		Id:         order.Id,
+		SupplierId: order.SupplierId,
+		Status:     string(order.Status),
+		Lines:      purchaseOrderLinesToPb(order.Lines),
+	}, nil
+}
+
+func (i *implementation) PlaceOrder(ctx context.Context, in *pb.PlaceOrderRequest) (*pb.PlaceOrderResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	lines := make([]*orders.Line, 0, len(in.GetLines()))
+	for _, line := range in.GetLines() {
+		lines = append(lines, &orders.Line{
+			ProductId: line.GetProductId(),
+			Quantity:  line.GetQuantity(),
+		})
+	}
+
+	order, err := orders.NewOrder(in.GetOrderId(), lines)
+	if err != nil {
+		return nil, interceptors.Invalid(err)
+	}
+
+	if err = i.deps.OrderService.PlaceOrder(ctx, order); err != nil {
+		return nil, err
+	}
+
+	return &pb.PlaceOrderResponse{
+		OrderId: order.Id,
+		Status:  string(order.Status),
+		Lines:   in.GetLines(),
+	}, nil
+}
+
+func (i *implementation) LotAdd(ctx context.Context, in *pb.LotAddRequest) (*pb.LotAddResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	expiresAt, err := lots.ParseExpiresAt(in.GetExpiresAt())
+	if err != nil {
+		return nil, interceptors.Invalid(err)
+	}
+
+	lot, err := lots.NewLot(in.GetProductId(), in.GetNumber(), in.GetQuantity(), expiresAt)
+	if err != nil {
+		return nil, interceptors.Invalid(err)
+	}
+
+	if lot, err = i.deps.LotRepository.AddLot(ctx, *lot); err != nil {
+		return nil, err
+	}
+
+	return &pb.LotAddResponse{
+		Lot: lotToPb(lot),
+	}, nil
+}
+
+func (i *implementation) LotList(in *pb.LotListRequest, srv pb.StorageService_LotListServer) error {
+	ctx, cancel := context.WithTimeout(tenants.Detach(srv.Context()), maxTimeout)
+	defer cancel()
+
+	productLots, err := i.deps.LotRepository.GetProductLots(ctx, in.GetProductId())
+	if err != nil {
+		return err
+	}
+
+	for _, lot := range productLots {
+		if err = srv.Send(&pb.LotListResponse{Lot: lotToPb(lot)}); err != nil {
+			log.WithError(err).Error("LotList send")
+		}
+	}
+
+	return nil
+}
+
+func (i *implementation) ListExpiringLots(in *pb.ListExpiringLotsRequest, srv pb.StorageService_ListExpiringLotsServer) error {
+	ctx, cancel := context.WithTimeout(tenants.Detach(srv.Context()), maxTimeout)
+	defer cancel()
+
+	before := time.Now().AddDate(0, 0, int(in.GetDays()))
+	expiring, err := i.deps.LotRepository.GetExpiringLots(ctx, before, in.GetPage(), in.GetSize())
+	if err != nil {
+		return err
+	}
+
+	for _, lot := range expiring {
+		if err = srv.Send(&pb.ListExpiringLotsResponse{Lot: lotToPb(lot)}); err != nil {
+			log.WithError(err).Error("ListExpiringLots send")
+		}
+	}
+
+	return nil
+}
+
+func (i *implementation) StocktakeOpen(ctx context.Context, in *pb.StocktakeOpenRequest) (*pb.StocktakeOpenResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	openedBy, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := stocktakes.NewSession(openedBy)
+	if err != nil {
+		return nil, interceptors.Invalid(err)
+	}
+
+	if session, err = i.deps.StocktakeRepository.OpenStocktake(ctx, *session); err != nil {
+		return nil, err
+	}
+
+	return &pb.StocktakeOpenResponse{
+		Stocktake: stocktakeToPb(session),
+	}, nil
+}
+
+func (i *implementation) StocktakeCount(ctx context.Context, in *pb.StocktakeCountRequest) (*pb.StocktakeCountResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	countedBy, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := stocktakes.NewCount(in.GetId(), in.GetProductId(), in.GetCounted(), countedBy)
+	if err != nil {
+		return nil, interceptors.Invalid(err)
+	}
+
+	if count, err = i.deps.StocktakeRepository.SubmitCount(ctx, *count); err != nil {
+		return nil, err
+	}
+
+	return &pb.StocktakeCountResponse{
+		Id:        count.SessionId,
+		ProductId: count.ProductId,
+		Counted:   count.Counted,
+	}, nil
+}
+
+func (i *implementation) StocktakeGet(ctx context.Context, in *pb.StocktakeGetRequest) (*pb.StocktakeGetResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	session, err := i.deps.StocktakeRepository.GetStocktakeById(ctx, in.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	variances, err := i.deps.StocktakeRepository.GetStocktakeVariances(ctx, in.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*pb.StocktakeVariance, 0, len(variances))
+	for _, variance := range variances {
+		result = append(result, &pb.StocktakeVariance{
+			ProductId:  variance.ProductId,
+			Name:       variance.Name,
+			Expected:   variance.Expected,
+			Counted:    variance.Counted,
+			Difference: variance.Difference(),
+		})
+	}
+
+	return &pb.StocktakeGetResponse{
+		Stocktake: stocktakeToPb(session),
+		Variances: result,
+	}, nil
+}
+
+func (i *implementation) StocktakeCommit(ctx context.Context, in *pb.StocktakeCommitRequest) (*pb.StocktakeCommitResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	committedBy, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	session, adjustments, err := i.deps.StocktakeRepository.CommitStocktake(ctx, in.GetId(), committedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*pb.StockAdjustment, 0, len(adjustments))
+	for _, adjustment := range adjustments {
+		result = append(result, &pb.StockAdjustment{
+			Id:        adjustment.Id,
+			ProductId: adjustment.ProductId,
+			Before:    adjustment.Before,
+			After:     adjustment.After,
+		})
+	}
+
+	return &pb.StocktakeCommitResponse{
+		Stocktake:   stocktakeToPb(session),
+		Adjustments: result,
+	}, nil
+}
+
+func (i *implementation) StockValuation(ctx context.Context, in *pb.StockValuationRequest) (*pb.StockValuationResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	valuation, err := i.deps.ReportRepository.GetStockValuation(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byStatus, err := i.deps.ReportRepository.GetValueByStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byCategory, err := i.deps.ReportRepository.GetValueByCategory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.StockValuationResponse{
+		TotalValue:     valuation.TotalValue,
+		TotalQuantity:  valuation.TotalQuantity,
+		ProductCount:   valuation.ProductCount,
+		ZeroStockCount: valuation.ZeroStockCount,
+		ByStatus:       statusValuesToPb(byStatus),
+		ByCategory:     categoryValuesToPb(byCategory),
+	}, nil
+}
+
+func (i *implementation) TopProductsByValue(in *pb.TopProductsByValueRequest, srv pb.StorageService_TopProductsByValueServer) error {
+	ctx, cancel := context.WithTimeout(tenants.Detach(srv.Context()), maxTimeout)
+	defer cancel()
+
+	values, err := i.deps.ReportRepository.GetTopProductsByValue(ctx, in.GetLimit())
+	if err != nil {
+		return err
+	}
+
+	for _, value := range values {
+		response := pb.TopProductsByValueResponse{
+			Product: &pb.ProductValue{
+				ProductId: value.ProductId,
+				Name:      value.Name,
+				Price:     value.Price,
+				Quantity:  value.Quantity,
+				Value:     value.Value,
+				Unit:      string(value.Unit),
+			},
+		}
+		if err = srv.Send(&response); err != nil {
+			log.WithError(err).Error("TopProductsByValue send")
+		}
+	}
+
+	return nil
+}
+
+func (i *implementation) UploadProductImage(srv pb.StorageService_UploadProductImageServer) error {
+	ctx, cancel := context.WithTimeout(tenants.Detach(srv.Context()), maxTransferTimeout)
+	defer cancel()
+
+	first, err := srv.Recv()
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	info := first.GetInfo()
+	if info == nil {
+		return status.Error(codes.InvalidArgument, "the first message must carry the image info")
+	}
+
+	image, err := images.NewImage(info.GetProductId(), info.GetContentType())
+	if err != nil {
+		return interceptors.Invalid(err)
+	}
+
+	if _, err = i.deps.ProductRepository.GetProductById(ctx, info.GetProductId()); err != nil {
+		return err
+	}
+
+	saved, err := i.deps.ImageService.Upload(ctx, *image, &imageChunkReader{srv: srv})
+	if err != nil {
+		return err
+	}
+
+	return srv.SendAndClose(&pb.UploadProductImageResponse{Image: imageToPb(saved)})
+}
+
+func (i *implementation) DownloadProductImage(in *pb.DownloadProductImageRequest, srv pb.StorageService_DownloadProductImageServer) error {
+	ctx, cancel := context.WithTimeout(tenants.Detach(srv.Context()), maxTransferTimeout)
+	defer cancel()
+
+	image, content, err := i.deps.ImageService.Open(ctx, in.GetProductId(), in.GetId())
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	if err = srv.Send(&pb.DownloadProductImageResponse{Data: &pb.DownloadProductImageResponse_Image{Image: imageToPb(image)}}); err != nil {
+		return err
+	}
+
+	chunk := make([]byte, imageChunkSize)
+	for {
+		n, err := content.Read(chunk)
+		if n > 0 {
+			if err := srv.Send(&pb.DownloadProductImageResponse{Data: &pb.DownloadProductImageResponse_Chunk{Chunk: chunk[:n]}}); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (i *implementation) ListProductImages(in *pb.ListProductImagesRequest, srv pb.StorageService_ListProductImagesServer) error {
+	ctx, cancel := context.WithTimeout(tenants.Detach(srv.Context()), maxTimeout)
+	defer cancel()
+
+	productImages, err := i.deps.ImageRepository.GetProductImages(ctx, in.GetProductId())
+	if err != nil {
+		return err
+	}
+
+	for _, image := range productImages {
+		if err = srv.Send(&pb.ListProductImagesResponse{Image: imageToPb(image)}); err != nil {
+			log.WithError(err).Error("ListProductImages send")
+		}
+	}
+
+	return nil
+}
+
+func (i *implementation) RelationCreate(ctx context.Context, in *pb.RelationCreateRequest) (*pb.RelationCreateResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	relation, err := relations.NewRelation(in.GetProductId(), in.GetRelatedId(), relations.Type(in.GetType()))
+	if err != nil {
+		return nil, interceptors.Invalid(err)
+	}
+
+	if relation, err = i.deps.RelationRepository.CreateRelation(ctx, *relation); err != nil {
+		return nil, err
+	}
+
+	return &pb.RelationCreateResponse{
+		Relation: relationToPb(relation),
+	}, nil
+}
+
+func (i *implementation) RelationList(in *pb.RelationListRequest, srv pb.StorageService_RelationListServer) error {
+	ctx, cancel := context.WithTimeout(tenants.Detach(srv.Context()), maxTimeout)
+	defer cancel()
+
+	productRelations, err := i.deps.RelationRepository.GetProductRelations(ctx, in.GetProductId())
+	if err != nil {
+		return err
+	}
+
+	for _, relation := range productRelations {
+		if err = srv.Send(&pb.RelationListResponse{Relation: relationToPb(relation)}); err != nil {
+			log.WithError(err).Error("RelationList send")
+		}
+	}
+
+	return nil
+}
+
+func (i *implementation) RelationUpdate(ctx context.Context, in *pb.RelationUpdateRequest) (*pb.RelationUpdateResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	relation, err := relations.NewRelation(in.GetProductId(), in.GetRelatedId(), relations.Type(in.GetType()))
+	if err != nil {
+		return nil, interceptors.Invalid(err)
+	}
+
+	if relation, err = i.deps.RelationRepository.UpdateRelation(ctx, *relation); err != nil {
+		return nil, err
+	}
+
+	return &pb.RelationUpdateResponse{
+		Relation: relationToPb(relation),
+	}, nil
+}
+
+func (i *implementation) RelationDelete(ctx context.Context, in *pb.RelationDeleteRequest) (*pb.RelationDeleteResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	if err := i.deps.RelationRepository.DeleteRelation(ctx, in.GetProductId(), in.GetRelatedId()); err != nil {
+		return nil, err
+	}
+
+	return &pb.RelationDeleteResponse{}, nil
+}
+
+func (i *implementation) GetRelatedProducts(in *pb.GetRelatedProductsRequest, srv pb.StorageService_GetRelatedProductsServer) error {
+	ctx, cancel := context.WithTimeout(tenants.Detach(srv.Context()), maxTimeout)
+	defer cancel()
+
+	var relationType relations.Type
+	if in.Type != nil {
+		relationType = relations.Type(in.GetType())
+		if err := relations.ValidateType(relationType); err != nil {
+			return interceptors.Invalid(err)
+		}
+	}
+
+	related, err := i.deps.RelationRepository.GetRelatedProducts(ctx, in.GetProductId(), relationType)
+	if err != nil {
+		return err
+	}
+
+	for _, product := range related {
+		response := pb.GetRelatedProductsResponse{
+			Product: &pb.RelatedProduct{
+				Id:           product.GetId(),
+				Name:         product.GetName(),
+				Price:        product.GetPrice(),
+				Quantity:     product.GetQuantity(),
+				Status:       product.GetStatus().String(),
+				Unit:         product.GetUnit().String(),
+				Amount:       product.Amount(),
+				RelationType: product.Type.String(),
+			},
+		}
+		if err = srv.Send(&response); err != nil {
+			log.WithError(err).Error("GetRelatedProducts send")
+		}
+	}
+
+	return nil
+}
+
+func (i *implementation) ExchangeRateSet(ctx context.Context, in *pb.ExchangeRateSetRequest) (*pb.ExchangeRateSetResponse, error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), maxTimeout)
+	defer cancel()
+
+	currency, err := money.ParseCurrency(in.GetCurrency())
+	if err != nil {
+		return nil, interceptors.Invalid(err)
+	}
+	rate, err := money.NewRate(currency, in.GetRate())
+	if err != nil {
+		return nil, interceptors.Invalid(err)
+	}
+
+	if rate, err = i.deps.ExchangeRateRepository.SetExchangeRate(ctx, *rate); err != nil {
+		return nil, err
+	}
+
+	return &pb.ExchangeRateSetResponse{
+		Rate: exchangeRateToPb(rate),
+	}, nil
+}
+
+func (i *implementation) ExchangeRateList(in *pb.ExchangeRateListRequest, srv pb.StorageService_ExchangeRateListServer) error {
+	ctx, cancel := context.WithTimeout(tenants.Detach(srv.Context()), maxTimeout)
+	defer cancel()
+
+	rates, err := i.deps.ExchangeRateRepository.GetExchangeRates(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, rate := range rates {
+		if err = srv.Send(&pb.ExchangeRateListResponse{Rate: exchangeRateToPb(rate)}); err != nil {
+			log.WithError(err).Error("ExchangeRateList send")
+		}
+	}
+
+	return nil
+}
+
+// imageChunkReader reads the image content from the chunks of an upload stream until the client closes it.
+type imageChunkReader struct {
+	srv     pb.StorageService_UploadProductImageServer
+	pending []byte
+}
+
+func (r *imageChunkReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		in, err := r.srv.Recv()
+		if err != nil {
+			return 0, err
+		}
+		if in.GetInfo() != nil {
+			return 0, interceptors.Invalid(errImageInfoRepeated)
+		}
+		r.pending = in.GetChunk()
+	}
+
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+// caller is the authenticated subject the audit trail records: price changes, status transitions
+// and stocktakes. Names given in the request body are ignored so that nobody acts under another name.
+func caller(ctx context.Context) (string, error) {
+	identity, ok := auth.FromContext(ctx)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, auth.ErrNoCredentials.Error())
+	}
+	return identity.Subject, nil
+}
+
+func imageToPb(image *images.Image) *pb.ProductImage {
+	return &pb.ProductImage{
+		Id:          image.Id,
+		ProductId:   image.ProductId,
+		ContentType: image.ContentType,
+		Size:        image.Size,
+		Checksum:    image.Checksum,
+		CreatedAt:   image.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func relationToPb(relation *relations.Relation) *pb.ProductRelation {
+	return &pb.ProductRelation{
+		ProductId: relation.ProductId,
+		RelatedId: relation.RelatedId,
+		Type:      relation.Type.String(),
+	}
+}
+
+func exchangeRateToPb(rate *money.Rate) *pb.ExchangeRate {
+	return &pb.ExchangeRate{
+		Currency:  rate.Currency.String(),
+		Rate:      money.FormatRate(rate.Value),
+		UpdatedAt: rate.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func statusValuesToPb(values []*reports.StatusValue) []*pb.StatusValue {
+	result := make([]*pb.StatusValue, 0, len(values))
+	for _, value := range values {
+		result = append(result, &pb.StatusValue{
+			Status:       string(value.Status),
+			ProductCount: value.ProductCount,
+			Quantity:     value.Quantity,
+			Value:        value.Value,
+		})
+	}
+	return result
+}
+
+func categoryValuesToPb(values []*reports.CategoryValue) []*pb.CategoryValue {
+	result := make([]*pb.CategoryValue, 0, len(values))
+	for _, value := range values {
+		result = append(result, &pb.CategoryValue{
+			Category:     value.Category,
+			ProductCount: value.ProductCount,
+			Quantity:     value.Quantity,
+			Value:        value.Value,
+		})
+	}
+	return result
+}
+
+func stocktakeToPb(session *stocktakes.Session) *pb.Stocktake {
+	return &pb.Stocktake{
+		Id:          session.Id,
+		Status:      string(session.Status),
+		OpenedBy:    session.OpenedBy,
+		CommittedBy: session.CommittedBy,
+	}
+}
+
+func lotToPb(lot *lots.Lot) *pb.Lot {
+	return &pb.Lot{
+		Id:        lot.Id,
+		ProductId: lot.ProductId,
+		Number:    lot.Number,
+		Quantity:  lot.Quantity,
+		ExpiresAt: lot.ExpiresAt.Format(lots.DateLayout),
+	}
+}
+
+func purchaseOrderLinesToPb(lines []*purchases.Line) []*pb.PurchaseOrderLine {
+	result := make([]*pb.PurchaseOrderLine, 0, len(lines))
+	for _, line := range lines {
+		result = append(result, &pb.PurchaseOrderLine{
+			ProductId: line.ProductId,
+			Quantity:  line.Quantity,
+			Received:  line.Received,
+		})
+	}
+	return result
 }
@@ -2,14 +2,35 @@ package storage
 
 import (
 	"context"
+	"fmt"
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"homework-1/internal/interceptors"
+	"homework-1/internal/locales"
+	"homework-1/internal/models/attributes"
+	"homework-1/internal/models/changes"
+	"homework-1/internal/models/images"
+	"homework-1/internal/models/lots"
+	"homework-1/internal/models/orders"
 	"homework-1/internal/models/products"
+	"homework-1/internal/models/purchases"
+	"homework-1/internal/models/relations"
+	"homework-1/internal/models/reports"
+	"homework-1/internal/models/stock"
+	"homework-1/internal/models/stocktakes"
+	"homework-1/internal/models/units"
+	"homework-1/internal/money"
 	"homework-1/internal/repository"
 	pb "homework-1/pkg/api/storage/v1"
+	"io"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestProductList(t *testing.T) {
@@ -34,6 +55,8 @@ func TestProductList(t *testing.T) {
 			},
 		}, nil)
 
+		f.translationRepo.EXPECT().GetTranslations(gomock.Any(), []uint64{1, 2}, locales.Default).Return(nil, nil)
+
 		// act
 		err := f.service.ProductList(&pb.ProductListRequest{}, stream)
 
@@ -47,12 +70,20 @@ func TestProductList(t *testing.T) {
 				Name:     "product1",
 				Price:    uint64(1),
 				Quantity: uint64(1),
+				Unit:     "piece",
+				Amount:   "1",
+				Locale:   "en",
+				Currency: "RUB",
 			},
 			{
 				Id:       uint64(2),
 				Name:     "product2",
 				Price:    uint64(2),
 				Quantity: uint64(2),
+				Unit:     "piece",
+				Amount:   "2",
+				Locale:   "en",
+				Currency: "RUB",
 			},
 		})
 	})
@@ -70,7 +101,75 @@ func TestProductList(t *testing.T) {
 		err := f.service.ProductList(&pb.ProductListRequest{}, stream)
 
 		// assert
-		assert.EqualError(t, err, "rpc error: code = Internal desc = internal error")
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = Internal desc = internal error")
+	})
+}
+
+func TestProductListByAttributes(t *testing.T) {
+	t.Run("filter values are typed by the category schema", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		stream := makeProductListResponseStreamMock()
+
+		f.productRepo.EXPECT().FindProducts(gomock.Any(), products.Filter{
+			Category:   "electronics",
+			Attributes: attributes.Attributes{"voltage": float64(220), "wireless": true},
+		}, uint64(0), uint64(0)).Return([]*products.Product{
+			{
+				Id:         uint64(1),
+				Name:       "kettle",
+				Price:      uint64(1),
+				Quantity:   uint64(1),
+				Category:   "electronics",
+				Attributes: attributes.Attributes{"voltage": float64(220), "wireless": true},
+			},
+		}, nil)
+
+		category := "electronics"
+
+		f.translationRepo.EXPECT().GetTranslations(gomock.Any(), []uint64{1}, locales.Default).Return(nil, nil)
+
+		// act
+		err := f.service.ProductList(&pb.ProductListRequest{
+			Category:   &category,
+			Attributes: map[string]string{"voltage": "220.0", "wireless": "true"},
+		}, stream)
+
+		res := stream.GetAll()
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, []*pb.ProductListResponse{
+			{
+				Id:         uint64(1),
+				Name:       "kettle",
+				Price:      uint64(1),
+				Quantity:   uint64(1),
+				Unit:       "piece",
+				Amount:     "1",
+				Category:   "electronics",
+				Attributes: map[string]string{"voltage": "220", "wireless": "true"},
+				Locale:     "en",
+				Currency:   "RUB",
+			},
+		})
+	})
+
+	t.Run("attribute filter without category", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		stream := makeProductListResponseStreamMock()
+		defer stream.Close()
+
+		// act
+		err := f.service.ProductList(&pb.ProductListRequest{
+			Attributes: map[string]string{"voltage": "220"},
+		}, stream)
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = attributes require a category")
 	})
 }
 
@@ -86,6 +185,8 @@ func TestProductGet(t *testing.T) {
 			Quantity: uint64(1),
 		}, nil)
 
+		f.translationRepo.EXPECT().GetTranslations(gomock.Any(), []uint64{1}, locales.Default).Return(nil, nil)
+
 		// act
 		res, err := f.service.ProductGet(context.Background(), &pb.ProductGetRequest{Id: uint64(1)})
 
@@ -96,6 +197,10 @@ func TestProductGet(t *testing.T) {
 			Name:     "product1",
 			Price:    uint64(1),
 			Quantity: uint64(1),
+			Unit:     "piece",
+			Amount:   "1",
+			Locale:   "en",
+			Currency: "RUB",
 		})
 	})
 
@@ -109,7 +214,7 @@ func TestProductGet(t *testing.T) {
 		_, err := f.service.ProductGet(context.Background(), &pb.ProductGetRequest{Id: uint64(1)})
 
 		// assert
-		assert.EqualError(t, err, "rpc error: code = NotFound desc = product does not exist")
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = NotFound desc = product does not exist")
 	})
 
 	t.Run("fail with internal error", func(t *testing.T) {
@@ -122,269 +227,2245 @@ func TestProductGet(t *testing.T) {
 		_, err := f.service.ProductGet(context.Background(), &pb.ProductGetRequest{Id: uint64(1)})
 
 		// assert
-		assert.EqualError(t, err, "rpc error: code = Internal desc = internal error")
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = Internal desc = internal error")
 	})
 }
 
-func TestProductCreate(t *testing.T) {
-	t.Run("success creating product", func(t *testing.T) {
+func TestProductGetByBarcode(t *testing.T) {
+	t.Run("upc-a finds product stored as gtin-14", func(t *testing.T) {
 		// arrange
 		f := SetUp(t)
 
-		f.productRepo.EXPECT().CreateProduct(gomock.Any(), products.Product{
-			Name:     "product1",
-			Price:    uint64(1),
-			Quantity: uint64(1),
-		}).Return(&products.Product{
+		f.productRepo.EXPECT().GetProductByBarcode(gomock.Any(), "00036000291452").Return(&products.Product{
 			Id:       uint64(1),
 			Name:     "product1",
 			Price:    uint64(1),
 			Quantity: uint64(1),
+			Barcode:  "00036000291452",
 		}, nil)
 
+		f.translationRepo.EXPECT().GetTranslations(gomock.Any(), []uint64{1}, locales.Default).Return(nil, nil)
+
 		// act
-		res, err := f.service.ProductCreate(context.Background(), &pb.ProductCreateRequest{
-			Name:     "product1",
-			Price:    uint64(1),
-			Quantity: uint64(1),
-		})
+		res, err := f.service.ProductGetByBarcode(context.Background(), &pb.ProductGetByBarcodeRequest{Barcode: "036000291452"})
 
 		// assert
 		require.NoError(t, err)
-		assert.Equal(t, res, &pb.ProductCreateResponse{
+		assert.Equal(t, res, &pb.ProductGetByBarcodeResponse{
 			Id:       uint64(1),
 			Name:     "product1",
 			Price:    uint64(1),
 			Quantity: uint64(1),
+			Unit:     "piece",
+			Amount:   "1",
+			Barcode:  "00036000291452",
+			Locale:   "en",
+			Currency: "RUB",
 		})
 	})
 
-	t.Run("fail with not found error", func(t *testing.T) {
+	t.Run("fail with invalid check digit", func(t *testing.T) {
 		// arrange
 		f := SetUp(t)
 
-		f.productRepo.EXPECT().CreateProduct(gomock.Any(), products.Product{
-			Name:     "product1",
-			Price:    uint64(1),
-			Quantity: uint64(1),
-		}).Return(nil, repository.ProductAlreadyExists)
-
 		// act
-		_, err := f.service.ProductCreate(context.Background(), &pb.ProductCreateRequest{
-			Name:     "product1",
-			Price:    uint64(1),
-			Quantity: uint64(1),
-		})
+		_, err := f.service.ProductGetByBarcode(context.Background(), &pb.ProductGetByBarcodeRequest{Barcode: "4006381333932"})
 
 		// assert
-		assert.EqualError(t, err, "rpc error: code = AlreadyExists desc = product already exists")
+		assert.EqualError(t, interceptors.Status(err), `rpc error: code = InvalidArgument desc = "4006381333932": barcode check digit mismatch`)
 	})
 
-	t.Run("fail with internal error", func(t *testing.T) {
+	t.Run("fail with not found error", func(t *testing.T) {
 		// arrange
 		f := SetUp(t)
 
-		f.productRepo.EXPECT().CreateProduct(gomock.Any(), products.Product{
-			Name:     "product1",
-			Price:    uint64(1),
-			Quantity: uint64(1),
-		}).Return(nil, errors.New("internal error"))
+		f.productRepo.EXPECT().GetProductByBarcode(gomock.Any(), "04006381333931").
+			Return(nil, errors.Wrap(repository.ProductNotExists, "04006381333931"))
 
 		// act
-		_, err := f.service.ProductCreate(context.Background(), &pb.ProductCreateRequest{
-			Name:     "product1",
-			Price:    uint64(1),
-			Quantity: uint64(1),
-		})
+		_, err := f.service.ProductGetByBarcode(context.Background(), &pb.ProductGetByBarcodeRequest{Barcode: "4006381333931"})
 
 		// assert
-		assert.EqualError(t, err, "rpc error: code = Internal desc = internal error")
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = NotFound desc = 04006381333931: product does not exist")
 	})
 }
 
-func TestProductUpdate(t *testing.T) {
-	t.Run("success updating product", func(t *testing.T) {
+func TestProductGetInCurrency(t *testing.T) {
+	t.Run("price converted into requested currency", func(t *testing.T) {
 		// arrange
 		f := SetUp(t)
 
+		f.rateRepo.EXPECT().GetExchangeRate(gomock.Any(), money.Currency("USD")).
+			Return(&money.Rate{Currency: "USD", Value: uint64(13725)}, nil)
 		f.productRepo.EXPECT().GetProductById(gomock.Any(), uint64(1)).Return(&products.Product{
 			Id:       uint64(1),
-			Name:     "product1",
-			Price:    uint64(1),
+			Name:     "pillow",
+			Price:    uint64(1500),
 			Quantity: uint64(1),
 		}, nil)
+		f.translationRepo.EXPECT().GetTranslations(gomock.Any(), []uint64{1}, locales.Default).Return(nil, nil)
 
-		f.productRepo.EXPECT().UpdateProduct(gomock.Any(), products.Product{
-			Id:       uint64(1),
-			Name:     "product2",
-			Price:    uint64(2),
-			Quantity: uint64(2),
-		}).Return(&products.Product{
-			Id:       uint64(1),
-			Name:     "product2",
-			Price:    uint64(2),
-			Quantity: uint64(2),
-		}, nil)
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(money.MetadataKey, "usd"))
 
 		// act
-		res, err := f.service.ProductUpdate(context.Background(), &pb.ProductUpdateRequest{
-			Id:       uint64(1),
-			Name:     "product2",
-			Price:    uint64(2),
-			Quantity: uint64(2),
-		})
+		res, err := f.service.ProductGet(ctx, &pb.ProductGetRequest{Id: uint64(1)})
 
 		// assert
 		require.NoError(t, err)
-		assert.Equal(t, res, &pb.ProductUpdateResponse{
-			Id:       uint64(1),
-			Name:     "product2",
-			Price:    uint64(2),
-			Quantity: uint64(2),
-		})
+		assert.Equal(t, res.GetPrice(), uint64(21))
+		assert.Equal(t, res.GetCurrency(), "USD")
 	})
 
-	t.Run("product not found in GetProductById", func(t *testing.T) {
+	t.Run("no exchange rate", func(t *testing.T) {
 		// arrange
 		f := SetUp(t)
 
-		f.productRepo.EXPECT().GetProductById(gomock.Any(), uint64(1)).Return(nil, repository.ProductNotExists)
+		f.rateRepo.EXPECT().GetExchangeRate(gomock.Any(), money.Currency("USD")).
+			Return(nil, errors.Wrap(repository.ExchangeRateNotExists, "USD"))
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(money.MetadataKey, "USD"))
 
 		// act
-		_, err := f.service.ProductUpdate(context.Background(), &pb.ProductUpdateRequest{
-			Id:       uint64(1),
-			Name:     "product2",
-			Price:    uint64(2),
-			Quantity: uint64(2),
-		})
+		_, err := f.service.ProductGet(ctx, &pb.ProductGetRequest{Id: uint64(1)})
 
 		// assert
-		assert.EqualError(t, err, "rpc error: code = NotFound desc = product does not exist")
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = FailedPrecondition desc = USD: exchange rate does not exist")
 	})
 
-	t.Run("internal error in GetProductById", func(t *testing.T) {
+	t.Run("invalid currency", func(t *testing.T) {
 		// arrange
 		f := SetUp(t)
 
-		f.productRepo.EXPECT().GetProductById(gomock.Any(), uint64(1)).Return(nil, errors.New("internal error"))
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(money.MetadataKey, "dollar"))
 
 		// act
-		_, err := f.service.ProductUpdate(context.Background(), &pb.ProductUpdateRequest{
-			Id:       uint64(1),
-			Name:     "product2",
-			Price:    uint64(2),
-			Quantity: uint64(2),
-		})
+		_, err := f.service.ProductGet(ctx, &pb.ProductGetRequest{Id: uint64(1)})
 
 		// assert
-		assert.EqualError(t, err, "rpc error: code = Internal desc = internal error")
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = DOLLAR: invalid currency, expected three-letter ISO 4217 code")
 	})
+}
 
-	t.Run("product not found in UpdateProduct", func(t *testing.T) {
+func TestProductGetLocalized(t *testing.T) {
+	t.Run("name and description in requested locale", func(t *testing.T) {
 		// arrange
 		f := SetUp(t)
 
 		f.productRepo.EXPECT().GetProductById(gomock.Any(), uint64(1)).Return(&products.Product{
 			Id:       uint64(1),
-			Name:     "product1",
+			Name:     "pillow",
 			Price:    uint64(1),
 			Quantity: uint64(1),
 		}, nil)
+		f.translationRepo.EXPECT().GetTranslations(gomock.Any(), []uint64{1}, locales.Russian).Return([]*products.Translation{
+			{ProductId: uint64(1), Locale: locales.English, Name: "pillow", Description: "soft"},
+			{ProductId: uint64(1), Locale: locales.Russian, Name: "подушка", Description: "мягкая"},
+		}, nil)
 
-		f.productRepo.EXPECT().UpdateProduct(gomock.Any(), products.Product{
-			Id:       uint64(1),
-			Name:     "product2",
-			Price:    uint64(2),
-			Quantity: uint64(2),
-		}).Return(nil, repository.ProductNotExists)
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(locales.MetadataKey, "ru-RU,ru;q=0.9"))
 
 		// act
-		_, err := f.service.ProductUpdate(context.Background(), &pb.ProductUpdateRequest{
-			Id:       uint64(1),
-			Name:     "product2",
-			Price:    uint64(2),
-			Quantity: uint64(2),
-		})
+		res, err := f.service.ProductGet(ctx, &pb.ProductGetRequest{Id: uint64(1)})
 
 		// assert
-		assert.EqualError(t, err, "rpc error: code = NotFound desc = product does not exist")
+		require.NoError(t, err)
+		assert.Equal(t, res.GetName(), "подушка")
+		assert.Equal(t, res.GetDescription(), "мягкая")
+		assert.Equal(t, res.GetLocale(), "ru")
 	})
 
-	t.Run("internal error in UpdateProduct", func(t *testing.T) {
+	t.Run("fallback to default locale translation", func(t *testing.T) {
 		// arrange
 		f := SetUp(t)
 
 		f.productRepo.EXPECT().GetProductById(gomock.Any(), uint64(1)).Return(&products.Product{
 			Id:       uint64(1),
-			Name:     "product1",
+			Name:     "pillow",
 			Price:    uint64(1),
 			Quantity: uint64(1),
 		}, nil)
+		f.translationRepo.EXPECT().GetTranslations(gomock.Any(), []uint64{1}, locales.Russian).Return([]*products.Translation{
+			{ProductId: uint64(1), Locale: locales.English, Description: "soft"},
+		}, nil)
 
-		f.productRepo.EXPECT().UpdateProduct(gomock.Any(), products.Product{
-			Id:       uint64(1),
-			Name:     "product2",
-			Price:    uint64(2),
-			Quantity: uint64(2),
-		}).Return(nil, errors.New("internal error"))
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(locales.MetadataKey, "ru"))
 
 		// act
-		_, err := f.service.ProductUpdate(context.Background(), &pb.ProductUpdateRequest{
-			Id:       uint64(1),
-			Name:     "product2",
-			Price:    uint64(2),
-			Quantity: uint64(2),
+		res, err := f.service.ProductGet(ctx, &pb.ProductGetRequest{Id: uint64(1)})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res.GetName(), "pillow")
+		assert.Equal(t, res.GetDescription(), "soft")
+	})
+}
+
+func TestProductTranslate(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.translationRepo.EXPECT().SetTranslation(gomock.Any(), products.Translation{
+			ProductId: uint64(1),
+			Locale:    locales.Russian,
+			Name:      "подушка",
+		}).DoAndReturn(func(_ context.Context, translation products.Translation) (*products.Translation, error) {
+			return &translation, nil
+		})
+
+		// act
+		res, err := f.service.ProductTranslate(context.Background(), &pb.ProductTranslateRequest{
+			Id:     uint64(1),
+			Locale: "ru-RU",
+			Name:   "подушка",
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pb.ProductTranslateResponse{Id: uint64(1), Locale: "ru", Name: "подушка"})
+	})
+
+	t.Run("unsupported locale", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		// act
+		_, err := f.service.ProductTranslate(context.Background(), &pb.ProductTranslateRequest{
+			Id:     uint64(1),
+			Locale: "de",
+			Name:   "Kissen",
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = de: unsupported locale")
+	})
+
+	t.Run("product does not exist", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.translationRepo.EXPECT().SetTranslation(gomock.Any(), gomock.Any()).
+			Return(nil, errors.Wrap(repository.ProductNotExists, "1"))
+
+		// act
+		_, err := f.service.ProductTranslate(context.Background(), &pb.ProductTranslateRequest{
+			Id:     uint64(1),
+			Locale: "ru",
+			Name:   "подушка",
 		})
 
 		// assert
-		assert.EqualError(t, err, "rpc error: code = Internal desc = internal error")
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = NotFound desc = 1: product does not exist")
 	})
 }
 
-func TestProductDelete(t *testing.T) {
-	t.Run("success deleting product", func(t *testing.T) {
+func TestProductCreate(t *testing.T) {
+	t.Run("success creating product", func(t *testing.T) {
 		// arrange
 		f := SetUp(t)
 
-		f.productRepo.EXPECT().DeleteProduct(gomock.Any(), uint64(1)).Return(nil)
+		f.productRepo.EXPECT().CreateProduct(gomock.Any(), products.Product{
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(1),
+			Unit:     units.Piece,
+		}).Return(&products.Product{
+			Id:       uint64(1),
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(1),
+		}, nil)
 
 		// act
-		res, err := f.service.ProductDelete(context.Background(), &pb.ProductDeleteRequest{
-			Id: uint64(1),
+		res, err := f.service.ProductCreate(context.Background(), &pb.ProductCreateRequest{
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(1),
 		})
 
 		// assert
 		require.NoError(t, err)
-		assert.Equal(t, res, &pb.ProductDeleteResponse{})
+		assert.Equal(t, res, &pb.ProductCreateResponse{
+			Id:       uint64(1),
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(1),
+			Unit:     "piece",
+			Amount:   "1",
+		})
 	})
 
-	t.Run("product not found", func(t *testing.T) {
+	t.Run("fail with not found error", func(t *testing.T) {
 		// arrange
 		f := SetUp(t)
 
-		f.productRepo.EXPECT().DeleteProduct(gomock.Any(), uint64(1)).Return(repository.ProductNotExists)
+		f.productRepo.EXPECT().CreateProduct(gomock.Any(), products.Product{
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(1),
+			Unit:     units.Piece,
+		}).Return(nil, repository.ProductAlreadyExists)
 
 		// act
-		_, err := f.service.ProductDelete(context.Background(), &pb.ProductDeleteRequest{
-			Id: uint64(1),
+		_, err := f.service.ProductCreate(context.Background(), &pb.ProductCreateRequest{
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(1),
 		})
 
 		// assert
-		assert.EqualError(t, err, "rpc error: code = NotFound desc = product does not exist")
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = AlreadyExists desc = product already exists")
 	})
 
-	t.Run("internal error", func(t *testing.T) {
+	t.Run("fail with internal error", func(t *testing.T) {
 		// arrange
 		f := SetUp(t)
 
-		f.productRepo.EXPECT().DeleteProduct(gomock.Any(), uint64(1)).Return(errors.New("internal error"))
+		f.productRepo.EXPECT().CreateProduct(gomock.Any(), products.Product{
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(1),
+			Unit:     units.Piece,
+		}).Return(nil, errors.New("internal error"))
 
 		// act
-		_, err := f.service.ProductDelete(context.Background(), &pb.ProductDeleteRequest{
-			Id: uint64(1),
+		_, err := f.service.ProductCreate(context.Background(), &pb.ProductCreateRequest{
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(1),
 		})
 
 		// assert
-		assert.EqualError(t, err, "rpc error: code = Internal desc = internal error")
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = Internal desc = internal error")
+	})
+}
+
+func TestProductCreateWithUnit(t *testing.T) {
+	t.Run("decimal amount in kg", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.productRepo.EXPECT().CreateProduct(gomock.Any(), products.Product{
+			Name:     "flour",
+			Price:    uint64(1),
+			Quantity: uint64(1250),
+			Unit:     units.Kilogram,
+		}).Return(&products.Product{
+			Id:       uint64(1),
+			Name:     "flour",
+			Price:    uint64(1),
+			Quantity: uint64(1250),
+			Status:   products.StatusActive,
+			Unit:     units.Kilogram,
+		}, nil)
+
+		unit, amount := "kg", "1.25"
+
+		// act
+		res, err := f.service.ProductCreate(context.Background(), &pb.ProductCreateRequest{
+			Name:   "flour",
+			Price:  uint64(1),
+			Unit:   &unit,
+			Amount: &amount,
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res.GetQuantity(), uint64(1250))
+		assert.Equal(t, res.GetAmount(), "1.25")
+		assert.Equal(t, res.GetUnit(), "kg")
+	})
+
+	t.Run("amount exceeds unit precision", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		unit, amount := "kg", "1.2505"
+
+		// act
+		_, err := f.service.ProductCreate(context.Background(), &pb.ProductCreateRequest{
+			Name:   "flour",
+			Price:  uint64(1),
+			Unit:   &unit,
+			Amount: &amount,
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), `rpc error: code = InvalidArgument desc = "1.2505": quantity precision exceeded: kg allows 3 decimal places`)
+	})
+
+	t.Run("unknown unit", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		unit := "lb"
+
+		// act
+		_, err := f.service.ProductCreate(context.Background(), &pb.ProductCreateRequest{
+			Name:     "flour",
+			Price:    uint64(1),
+			Quantity: uint64(1),
+			Unit:     &unit,
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = lb: unknown unit")
+	})
+
+	t.Run("unknown status", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		// act
+		_, err := f.service.ProductCreate(context.Background(), &pb.ProductCreateRequest{
+			Name:     "flour",
+			Price:    uint64(1),
+			Quantity: uint64(1),
+			Status:   "sold",
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = sold: unknown status")
+	})
+}
+
+func TestProductUpdateWithUnit(t *testing.T) {
+	t.Run("amount converted into product unit", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.productRepo.EXPECT().GetProductById(gomock.Any(), uint64(1)).Return(&products.Product{
+			Id:       uint64(1),
+			Name:     "flour",
+			Price:    uint64(1),
+			Quantity: uint64(1250),
+			Unit:     units.Kilogram,
+		}, nil)
+		f.productRepo.EXPECT().UpdateProduct(gomock.Any(), products.Product{
+			Id:       uint64(1),
+			Name:     "flour",
+			Price:    uint64(1),
+			Quantity: uint64(500),
+			Unit:     units.Kilogram,
+		}).DoAndReturn(func(_ context.Context, product products.Product) (*products.Product, error) {
+			return &product, nil
+		})
+
+		unit, amount := "g", "500"
+
+		// act
+		res, err := f.service.ProductUpdate(context.Background(), &pb.ProductUpdateRequest{
+			Id:     uint64(1),
+			Name:   "flour",
+			Price:  uint64(1),
+			Unit:   &unit,
+			Amount: &amount,
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res.GetAmount(), "0.5")
+		assert.Equal(t, res.GetUnit(), "kg")
+	})
+
+	t.Run("incompatible unit", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.productRepo.EXPECT().GetProductById(gomock.Any(), uint64(1)).Return(&products.Product{
+			Id:       uint64(1),
+			Name:     "flour",
+			Price:    uint64(1),
+			Quantity: uint64(1250),
+			Unit:     units.Kilogram,
+		}, nil)
+
+		unit, amount := "l", "1"
+
+		// act
+		_, err := f.service.ProductUpdate(context.Background(), &pb.ProductUpdateRequest{
+			Id:     uint64(1),
+			Name:   "flour",
+			Price:  uint64(1),
+			Unit:   &unit,
+			Amount: &amount,
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = l -> kg: incompatible units")
+	})
+}
+
+func TestProductBarcode(t *testing.T) {
+	t.Run("create with barcode taken by another product", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.productRepo.EXPECT().CreateProduct(gomock.Any(), products.Product{
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(1),
+			Unit:     units.Piece,
+			Barcode:  "04006381333931",
+		}).Return(nil, errors.Wrap(repository.BarcodeAlreadyExists, "04006381333931"))
+
+		barcode := "4006381333931"
+
+		// act
+		_, err := f.service.ProductCreate(context.Background(), &pb.ProductCreateRequest{
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(1),
+			Barcode:  &barcode,
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = AlreadyExists desc = 04006381333931: barcode already belongs to another product")
+	})
+
+	t.Run("create with malformed barcode", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		barcode := "40063813"
+
+		// act
+		_, err := f.service.ProductCreate(context.Background(), &pb.ProductCreateRequest{
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(1),
+			Barcode:  &barcode,
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), `rpc error: code = InvalidArgument desc = "40063813": barcode check digit mismatch`)
+	})
+
+	t.Run("update removes barcode", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.productRepo.EXPECT().GetProductById(gomock.Any(), uint64(1)).Return(&products.Product{
+			Id:       uint64(1),
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(1),
+			Barcode:  "04006381333931",
+		}, nil)
+		f.productRepo.EXPECT().UpdateProduct(gomock.Any(), products.Product{
+			Id:       uint64(1),
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(1),
+		}).DoAndReturn(func(_ context.Context, product products.Product) (*products.Product, error) {
+			return &product, nil
+		})
+
+		barcode := ""
+
+		// act
+		res, err := f.service.ProductUpdate(context.Background(), &pb.ProductUpdateRequest{
+			Id:       uint64(1),
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(1),
+			Barcode:  &barcode,
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Empty(t, res.GetBarcode())
+	})
+}
+
+func TestProductAttributes(t *testing.T) {
+	t.Run("create with typed attributes", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.productRepo.EXPECT().CreateProduct(gomock.Any(), products.Product{
+			Name:       "shirt",
+			Price:      uint64(1),
+			Quantity:   uint64(1),
+			Unit:       units.Piece,
+			Category:   "clothing",
+			Attributes: attributes.Attributes{"fabric": "cotton", "size": "M"},
+		}).DoAndReturn(func(_ context.Context, product products.Product) (*products.Product, error) {
+			product.Id = uint64(1)
+			return &product, nil
+		})
+
+		category := "clothing"
+
+		// act
+		res, err := f.service.ProductCreate(context.Background(), &pb.ProductCreateRequest{
+			Name:       "shirt",
+			Price:      uint64(1),
+			Quantity:   uint64(1),
+			Category:   &category,
+			Attributes: map[string]string{"fabric": "cotton", "size": "M"},
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res.GetCategory(), "clothing")
+		assert.Equal(t, res.GetAttributes(), map[string]string{"fabric": "cotton", "size": "M"})
+	})
+
+	t.Run("create without required attribute", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		category := "electronics"
+
+		// act
+		_, err := f.service.ProductCreate(context.Background(), &pb.ProductCreateRequest{
+			Name:       "kettle",
+			Price:      uint64(1),
+			Quantity:   uint64(1),
+			Category:   &category,
+			Attributes: map[string]string{"wattage": "2000"},
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = voltage: required attribute is missing")
+	})
+
+	t.Run("create with wrongly typed attribute", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		category := "electronics"
+
+		// act
+		_, err := f.service.ProductCreate(context.Background(), &pb.ProductCreateRequest{
+			Name:       "kettle",
+			Price:      uint64(1),
+			Quantity:   uint64(1),
+			Category:   &category,
+			Attributes: map[string]string{"voltage": "high"},
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), `rpc error: code = InvalidArgument desc = voltage="high": invalid attribute type: expected number`)
+	})
+
+	t.Run("update to another category revalidates stored attributes", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.productRepo.EXPECT().GetProductById(gomock.Any(), uint64(1)).Return(&products.Product{
+			Id:         uint64(1),
+			Name:       "shirt",
+			Price:      uint64(1),
+			Quantity:   uint64(1),
+			Category:   "clothing",
+			Attributes: attributes.Attributes{"fabric": "cotton"},
+		}, nil)
+
+		category := "electronics"
+
+		// act
+		_, err := f.service.ProductUpdate(context.Background(), &pb.ProductUpdateRequest{
+			Id:       uint64(1),
+			Name:     "shirt",
+			Price:    uint64(1),
+			Quantity: uint64(1),
+			Category: &category,
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = voltage: required attribute is missing")
+	})
+
+	t.Run("update removing category clears attributes", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.productRepo.EXPECT().GetProductById(gomock.Any(), uint64(1)).Return(&products.Product{
+			Id:         uint64(1),
+			Name:       "shirt",
+			Price:      uint64(1),
+			Quantity:   uint64(1),
+			Category:   "clothing",
+			Attributes: attributes.Attributes{"fabric": "cotton"},
+		}, nil)
+		f.productRepo.EXPECT().UpdateProduct(gomock.Any(), products.Product{
+			Id:       uint64(1),
+			Name:     "shirt",
+			Price:    uint64(1),
+			Quantity: uint64(1),
+		}).DoAndReturn(func(_ context.Context, product products.Product) (*products.Product, error) {
+			return &product, nil
+		})
+
+		category := ""
+
+		// act
+		res, err := f.service.ProductUpdate(context.Background(), &pb.ProductUpdateRequest{
+			Id:       uint64(1),
+			Name:     "shirt",
+			Price:    uint64(1),
+			Quantity: uint64(1),
+			Category: &category,
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Empty(t, res.GetAttributes())
+	})
+}
+
+func TestProductUpdate(t *testing.T) {
+	t.Run("success updating product", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.productRepo.EXPECT().GetProductById(gomock.Any(), uint64(1)).Return(&products.Product{
+			Id:       uint64(1),
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(1),
+		}, nil)
+
+		f.productRepo.EXPECT().UpdateProduct(gomock.Any(), products.Product{
+			Id:       uint64(1),
+			Name:     "product2",
+			Price:    uint64(2),
+			Quantity: uint64(2),
+		}).Return(&products.Product{
+			Id:       uint64(1),
+			Name:     "product2",
+			Price:    uint64(2),
+			Quantity: uint64(2),
+		}, nil)
+
+		// act
+		res, err := f.service.ProductUpdate(context.Background(), &pb.ProductUpdateRequest{
+			Id:       uint64(1),
+			Name:     "product2",
+			Price:    uint64(2),
+			Quantity: uint64(2),
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pb.ProductUpdateResponse{
+			Id:       uint64(1),
+			Name:     "product2",
+			Price:    uint64(2),
+			Quantity: uint64(2),
+			Unit:     "piece",
+			Amount:   "2",
+		})
+	})
+
+	t.Run("large price change waits for approval", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		f.service.deps.ApprovalService.Threshold = 50
+
+		f.productRepo.EXPECT().GetProductById(gomock.Any(), uint64(1)).Return(&products.Product{
+			Id:       uint64(1),
+			Name:     "product1",
+			Price:    uint64(100),
+			Quantity: uint64(1),
+			Status:   products.StatusActive,
+		}, nil)
+
+		f.productRepo.EXPECT().UpdateProduct(gomock.Any(), products.Product{
+			Id:       uint64(1),
+			Name:     "product2",
+			Price:    uint64(100),
+			Quantity: uint64(3),
+			Status:   products.StatusActive,
+		}).Return(&products.Product{
+			Id:       uint64(1),
+			Name:     "product2",
+			Price:    uint64(100),
+			Quantity: uint64(3),
+			Status:   products.StatusActive,
+		}, nil)
+
+		f.priceChangeRepo.EXPECT().CreatePriceChange(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, change changes.PriceChange) (*changes.PriceChange, error) {
+				assert.Equal(t, change.OldPrice, uint64(100))
+				assert.Equal(t, change.Price, uint64(1))
+				assert.Equal(t, change.RequestedBy, "alice")
+				assert.Equal(t, change.Status, changes.StatusPending)
+				change.Id = uint64(7)
+				return &change, nil
+			})
+
+		// act
+		res, err := f.service.ProductUpdate(asCaller("alice"), &pb.ProductUpdateRequest{
+			Id:       uint64(1),
+			Name:     "product2",
+			Price:    uint64(1),
+			Quantity: uint64(3),
+			Actor:    "bob",
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pb.ProductUpdateResponse{
+			Id:       uint64(1),
+			Name:     "product2",
+			Price:    uint64(100),
+			Quantity: uint64(3),
+			Unit:     "piece",
+			Amount:   "3",
+			Status:   "active",
+			ChangeId: uint64(7),
+		})
+	})
+
+	t.Run("large price change of an unauthenticated caller", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		f.service.deps.ApprovalService.Threshold = 50
+
+		f.productRepo.EXPECT().GetProductById(gomock.Any(), uint64(1)).Return(&products.Product{
+			Id:       uint64(1),
+			Name:     "product1",
+			Price:    uint64(100),
+			Quantity: uint64(1),
+			Status:   products.StatusActive,
+		}, nil)
+
+		// act
+		_, err := f.service.ProductUpdate(context.Background(), &pb.ProductUpdateRequest{
+			Id:       uint64(1),
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(1),
+			Actor:    "alice",
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = Unauthenticated desc = credentials are required")
+	})
+
+	t.Run("product not found in GetProductById", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.productRepo.EXPECT().GetProductById(gomock.Any(), uint64(1)).Return(nil, repository.ProductNotExists)
+
+		// act
+		_, err := f.service.ProductUpdate(context.Background(), &pb.ProductUpdateRequest{
+			Id:       uint64(1),
+			Name:     "product2",
+			Price:    uint64(2),
+			Quantity: uint64(2),
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = NotFound desc = product does not exist")
+	})
+
+	t.Run("internal error in GetProductById", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.productRepo.EXPECT().GetProductById(gomock.Any(), uint64(1)).Return(nil, errors.New("internal error"))
+
+		// act
+		_, err := f.service.ProductUpdate(context.Background(), &pb.ProductUpdateRequest{
+			Id:       uint64(1),
+			Name:     "product2",
+			Price:    uint64(2),
+			Quantity: uint64(2),
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = Internal desc = internal error")
+	})
+
+	t.Run("product not found in UpdateProduct", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.productRepo.EXPECT().GetProductById(gomock.Any(), uint64(1)).Return(&products.Product{
+			Id:       uint64(1),
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(1),
+		}, nil)
+
+		f.productRepo.EXPECT().UpdateProduct(gomock.Any(), products.Product{
+			Id:       uint64(1),
+			Name:     "product2",
+			Price:    uint64(2),
+			Quantity: uint64(2),
+		}).Return(nil, repository.ProductNotExists)
+
+		// act
+		_, err := f.service.ProductUpdate(context.Background(), &pb.ProductUpdateRequest{
+			Id:       uint64(1),
+			Name:     "product2",
+			Price:    uint64(2),
+			Quantity: uint64(2),
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = NotFound desc = product does not exist")
+	})
+
+	t.Run("internal error in UpdateProduct", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.productRepo.EXPECT().GetProductById(gomock.Any(), uint64(1)).Return(&products.Product{
+			Id:       uint64(1),
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(1),
+		}, nil)
+
+		f.productRepo.EXPECT().UpdateProduct(gomock.Any(), products.Product{
+			Id:       uint64(1),
+			Name:     "product2",
+			Price:    uint64(2),
+			Quantity: uint64(2),
+		}).Return(nil, errors.New("internal error"))
+
+		// act
+		_, err := f.service.ProductUpdate(context.Background(), &pb.ProductUpdateRequest{
+			Id:       uint64(1),
+			Name:     "product2",
+			Price:    uint64(2),
+			Quantity: uint64(2),
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = Internal desc = internal error")
+	})
+}
+
+func TestProductDelete(t *testing.T) {
+	t.Run("success deleting product", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.imageRepo.EXPECT().GetProductImages(gomock.Any(), uint64(1)).Return(nil, nil)
+		f.productRepo.EXPECT().DeleteProduct(gomock.Any(), uint64(1)).Return(nil)
+
+		// act
+		res, err := f.service.ProductDelete(context.Background(), &pb.ProductDeleteRequest{
+			Id: uint64(1),
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pb.ProductDeleteResponse{})
+	})
+
+	t.Run("product not found", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.imageRepo.EXPECT().GetProductImages(gomock.Any(), uint64(1)).Return(nil, nil)
+		f.productRepo.EXPECT().DeleteProduct(gomock.Any(), uint64(1)).Return(repository.ProductNotExists)
+
+		// act
+		_, err := f.service.ProductDelete(context.Background(), &pb.ProductDeleteRequest{
+			Id: uint64(1),
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = NotFound desc = product does not exist")
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.imageRepo.EXPECT().GetProductImages(gomock.Any(), uint64(1)).Return(nil, nil)
+		f.productRepo.EXPECT().DeleteProduct(gomock.Any(), uint64(1)).Return(errors.New("internal error"))
+
+		// act
+		_, err := f.service.ProductDelete(context.Background(), &pb.ProductDeleteRequest{
+			Id: uint64(1),
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = Internal desc = internal error")
+	})
+}
+
+func TestProductDeleteWithImages(t *testing.T) {
+	t.Run("removes image blobs", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.imageRepo.EXPECT().GetProductImages(gomock.Any(), uint64(1)).
+			Return([]*images.Image{{Id: uint64(1), ProductId: uint64(1), Key: "products/1/a"}}, nil)
+		f.productRepo.EXPECT().DeleteProduct(gomock.Any(), uint64(1)).Return(nil)
+		f.blobStore.EXPECT().Delete(gomock.Any(), "products/1/a").Return(nil)
+
+		// act
+		_, err := f.service.ProductDelete(context.Background(), &pb.ProductDeleteRequest{Id: uint64(1)})
+
+		// assert
+		require.NoError(t, err)
+	})
+
+	t.Run("keeps blobs when product is not deleted", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.imageRepo.EXPECT().GetProductImages(gomock.Any(), uint64(1)).
+			Return([]*images.Image{{Id: uint64(1), ProductId: uint64(1), Key: "products/1/a"}}, nil)
+		f.productRepo.EXPECT().DeleteProduct(gomock.Any(), uint64(1)).Return(repository.ProductNotExists)
+
+		// act
+		_, err := f.service.ProductDelete(context.Background(), &pb.ProductDeleteRequest{Id: uint64(1)})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = NotFound desc = product does not exist")
+	})
+}
+
+func TestProductDeleteWithRelations(t *testing.T) {
+	t.Run("restrict policy refuses related product", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		f.service.deps.ImageService.RelationPolicy = relations.DeleteRestrict
+
+		f.relationRepo.EXPECT().CountProductRelations(gomock.Any(), uint64(1)).Return(uint64(2), nil)
+
+		// act
+		_, err := f.service.ProductDelete(context.Background(), &pb.ProductDeleteRequest{Id: uint64(1)})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = FailedPrecondition desc = 1: product has relations, delete them first")
+	})
+
+	t.Run("restrict policy deletes unrelated product", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		f.service.deps.ImageService.RelationPolicy = relations.DeleteRestrict
+
+		f.relationRepo.EXPECT().CountProductRelations(gomock.Any(), uint64(1)).Return(uint64(0), nil)
+		f.imageRepo.EXPECT().GetProductImages(gomock.Any(), uint64(1)).Return(nil, nil)
+		f.productRepo.EXPECT().DeleteProduct(gomock.Any(), uint64(1)).Return(nil)
+
+		// act
+		_, err := f.service.ProductDelete(context.Background(), &pb.ProductDeleteRequest{Id: uint64(1)})
+
+		// assert
+		require.NoError(t, err)
+	})
+}
+
+func TestProductTransition(t *testing.T) {
+	t.Run("success transition", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.productRepo.EXPECT().TransitionProductStatus(gomock.Any(), uint64(1), products.StatusArchived, "reason", "admin").
+			Return(&products.Product{
+				Id:       uint64(1),
+				Name:     "product1",
+				Price:    uint64(1),
+				Quantity: uint64(1),
+				Status:   products.StatusArchived,
+			}, nil)
+
+		// act
+		res, err := f.service.ProductTransition(asCaller("admin"), &pb.ProductTransitionRequest{
+			Id:     uint64(1),
+			Status: "archived",
+			Reason: "reason",
+			Actor:  "mallory",
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pb.ProductTransitionResponse{
+			Id:       uint64(1),
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(1),
+			Status:   "archived",
+		})
+	})
+
+	t.Run("invalid transition", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.productRepo.EXPECT().TransitionProductStatus(gomock.Any(), uint64(1), products.StatusDraft, "reason", "admin").
+			Return(nil, products.ValidateStatusTransition(products.StatusArchived, products.StatusDraft))
+
+		// act
+		_, err := f.service.ProductTransition(asCaller("admin"), &pb.ProductTransitionRequest{
+			Id:     uint64(1),
+			Status: "draft",
+			Reason: "reason",
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = FailedPrecondition desc = archived -> draft: invalid status transition")
+	})
+
+	t.Run("product not found", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.productRepo.EXPECT().TransitionProductStatus(gomock.Any(), uint64(1), products.StatusActive, "reason", "admin").
+			Return(nil, repository.ProductNotExists)
+
+		// act
+		_, err := f.service.ProductTransition(asCaller("admin"), &pb.ProductTransitionRequest{
+			Id:     uint64(1),
+			Status: "active",
+			Reason: "reason",
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = NotFound desc = product does not exist")
+	})
+
+	t.Run("unauthenticated caller", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		// act
+		_, err := f.service.ProductTransition(context.Background(), &pb.ProductTransitionRequest{
+			Id:     uint64(1),
+			Status: "active",
+			Reason: "reason",
+			Actor:  "admin",
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = Unauthenticated desc = credentials are required")
+	})
+}
+
+func TestApproveChange(t *testing.T) {
+	t.Run("success approving change", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.priceChangeRepo.EXPECT().ApprovePriceChange(gomock.Any(), uint64(1), "bob").
+			Return(&changes.PriceChange{
+				Id:          uint64(1),
+				ProductId:   uint64(1),
+				Name:        "product1",
+				OldPrice:    uint64(100),
+				Price:       uint64(1),
+				Quantity:    uint64(1),
+				Status:      changes.StatusApproved,
+				RequestedBy: "alice",
+				ResolvedBy:  "bob",
+			}, &products.Product{}, nil)
+
+		// act
+		res, err := f.service.ApproveChange(asCaller("bob"), &pb.ApproveChangeRequest{Id: uint64(1)})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pb.ApproveChangeResponse{
+			Id:          uint64(1),
+			ProductId:   uint64(1),
+			Name:        "product1",
+			OldPrice:    uint64(100),
+			Price:       uint64(1),
+			Quantity:    uint64(1),
+			Status:      "approved",
+			RequestedBy: "alice",
+			ResolvedBy:  "bob",
+		})
+	})
+
+	t.Run("requester approves own change", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.priceChangeRepo.EXPECT().ApprovePriceChange(gomock.Any(), uint64(1), "alice").
+			Return(nil, nil, changes.ErrSelfApproval)
+
+		// act
+		_, err := f.service.ApproveChange(asCaller("alice"), &pb.ApproveChangeRequest{
+			Id:       uint64(1),
+			Approver: "bob",
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = PermissionDenied desc = change must be resolved by another user")
+	})
+
+	t.Run("change is not pending", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.priceChangeRepo.EXPECT().ApprovePriceChange(gomock.Any(), uint64(1), "bob").
+			Return(nil, nil, errors.Wrap(changes.ErrNotPending, "1"))
+
+		// act
+		_, err := f.service.ApproveChange(asCaller("bob"), &pb.ApproveChangeRequest{Id: uint64(1)})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = FailedPrecondition desc = 1: change is not pending")
+	})
+
+	t.Run("change not found", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.priceChangeRepo.EXPECT().ApprovePriceChange(gomock.Any(), uint64(1), "bob").
+			Return(nil, nil, repository.PriceChangeNotExists)
+
+		// act
+		_, err := f.service.ApproveChange(asCaller("bob"), &pb.ApproveChangeRequest{Id: uint64(1)})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = NotFound desc = price change does not exist")
+	})
+
+	t.Run("unauthenticated caller", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		// act
+		_, err := f.service.ApproveChange(context.Background(), &pb.ApproveChangeRequest{
+			Id:       uint64(1),
+			Approver: "bob",
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = Unauthenticated desc = credentials are required")
+	})
+}
+
+func TestRejectChange(t *testing.T) {
+	t.Run("success rejecting change", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.priceChangeRepo.EXPECT().RejectPriceChange(gomock.Any(), uint64(1), "bob").
+			Return(&changes.PriceChange{
+				Id:          uint64(1),
+				ProductId:   uint64(1),
+				Status:      changes.StatusRejected,
+				RequestedBy: "alice",
+				ResolvedBy:  "bob",
+			}, nil)
+
+		// act
+		res, err := f.service.RejectChange(asCaller("bob"), &pb.RejectChangeRequest{Id: uint64(1)})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pb.RejectChangeResponse{
+			Id:          uint64(1),
+			ProductId:   uint64(1),
+			Status:      "rejected",
+			RequestedBy: "alice",
+			ResolvedBy:  "bob",
+		})
+	})
+}
+
+func TestListLowStock(t *testing.T) {
+	t.Run("success listing low stock", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		stream := makeListLowStockResponseStreamMock()
+
+		f.stockRepo.EXPECT().GetLowStockProducts(gomock.Any(), uint64(0), uint64(0)).Return([]*stock.LowStock{
+			{ProductId: uint64(1), Name: "product1", Quantity: uint64(1), Threshold: uint64(5)},
+		}, nil)
+
+		// act
+		err := f.service.ListLowStock(&pb.ListLowStockRequest{}, stream)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, stream.GetAll(), []*pb.ListLowStockResponse{
+			{ProductId: uint64(1), Name: "product1", Quantity: uint64(1), Threshold: uint64(5)},
+		})
+	})
+
+	t.Run("fail with internal error", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		stream := makeListLowStockResponseStreamMock()
+
+		f.stockRepo.EXPECT().GetLowStockProducts(gomock.Any(), uint64(0), uint64(0)).Return(nil, errors.New("some error"))
+
+		// act
+		err := f.service.ListLowStock(&pb.ListLowStockRequest{}, stream)
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = Internal desc = internal error")
+	})
+}
+
+func TestSetReorderThreshold(t *testing.T) {
+	t.Run("success setting threshold", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.stockRepo.EXPECT().SetReorderThreshold(gomock.Any(), uint64(1), uint64(5)).Return(nil)
+
+		// act
+		res, err := f.service.SetReorderThreshold(context.Background(), &pb.SetReorderThresholdRequest{
+			Id:        uint64(1),
+			Threshold: uint64(5),
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pb.SetReorderThresholdResponse{})
+	})
+
+	t.Run("product not found", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.stockRepo.EXPECT().SetReorderThreshold(gomock.Any(), uint64(1), uint64(5)).Return(repository.ProductNotExists)
+
+		// act
+		_, err := f.service.SetReorderThreshold(context.Background(), &pb.SetReorderThresholdRequest{
+			Id:        uint64(1),
+			Threshold: uint64(5),
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = NotFound desc = product does not exist")
+	})
+}
+
+func TestPurchaseOrderCreate(t *testing.T) {
+	t.Run("success creating purchase order", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.purchaseRepo.EXPECT().CreatePurchaseOrder(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, order purchases.PurchaseOrder) (*purchases.PurchaseOrder, error) {
+				order.Id = uint64(1)
+				order.Lines[0].Id = uint64(1)
+				order.Lines[0].OrderId = uint64(1)
+				return &order, nil
+			})
+
+		// act
+		res, err := f.service.PurchaseOrderCreate(context.Background(), &pb.PurchaseOrderCreateRequest{
+			SupplierId: uint64(1),
+			Lines:      []*pb.PurchaseOrderCreateRequest_Line{{ProductId: uint64(2), Quantity: uint64(5)}},
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pb.PurchaseOrderCreateResponse{
+			Id:         uint64(1),
+			SupplierId: uint64(1),
+			Status:     string(purchases.StatusOpen),
+			Lines:      []*pb.PurchaseOrderLine{{ProductId: uint64(2), Quantity: uint64(5)}},
+		})
+	})
+
+	t.Run("empty order", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		// act
+		_, err := f.service.PurchaseOrderCreate(context.Background(), &pb.PurchaseOrderCreateRequest{SupplierId: uint64(1)})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = "+purchases.ErrEmptyOrder.Error())
+	})
+
+	t.Run("supplier not found", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.purchaseRepo.EXPECT().CreatePurchaseOrder(gomock.Any(), gomock.Any()).
+			Return(nil, errors.Wrap(repository.SupplierNotExists, "1"))
+
+		// act
+		_, err := f.service.PurchaseOrderCreate(context.Background(), &pb.PurchaseOrderCreateRequest{
+			SupplierId: uint64(1),
+			Lines:      []*pb.PurchaseOrderCreateRequest_Line{{ProductId: uint64(2), Quantity: uint64(5)}},
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = NotFound desc = 1: supplier does not exist")
+	})
+}
+
+func TestPurchaseOrderReceive(t *testing.T) {
+	t.Run("success receiving purchase order", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.purchaseRepo.EXPECT().ReceivePurchaseOrder(gomock.Any(), uint64(1), map[uint64]uint64{2: 5}).
+			Return(&purchases.PurchaseOrder{
+				Id:         uint64(1),
+				SupplierId: uint64(1),
+				Status:     purchases.StatusReceived,
+				Lines:      []*purchases.Line{{Id: uint64(1), OrderId: uint64(1), ProductId: uint64(2), Quantity: uint64(5), Received: uint64(5)}},
+			}, nil)
+
+		// act
+		res, err := f.service.PurchaseOrderReceive(context.Background(), &pb.PurchaseOrderReceiveRequest{
+			Id:    uint64(1),
+			Lines: []*pb.PurchaseOrderReceiveRequest_Line{{ProductId: uint64(2), Quantity: uint64(5)}},
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pb.PurchaseOrderReceiveResponse{
+			Id:         uint64(1),
+			SupplierId: uint64(1),
+			Status:     string(purchases.StatusReceived),
+			Lines:      []*pb.PurchaseOrderLine{{ProductId: uint64(2), Quantity: uint64(5), Received: uint64(5)}},
+		})
+	})
+
+	t.Run("duplicate lines", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		// act
+		_, err := f.service.PurchaseOrderReceive(context.Background(), &pb.PurchaseOrderReceiveRequest{
+			Id: uint64(1),
+			Lines: []*pb.PurchaseOrderReceiveRequest_Line{
+				{ProductId: uint64(2), Quantity: uint64(1)},
+				{ProductId: uint64(2), Quantity: uint64(1)},
+			},
+		})
+
+		// assert
+		assert.Equal(t, status.Code(interceptors.Status(err)), codes.InvalidArgument)
+	})
+
+	t.Run("over receipt", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.purchaseRepo.EXPECT().ReceivePurchaseOrder(gomock.Any(), uint64(1), map[uint64]uint64{2: 6}).
+			Return(nil, fmt.Errorf("product 2: %w", purchases.ErrOverReceipt))
+
+		// act
+		_, err := f.service.PurchaseOrderReceive(context.Background(), &pb.PurchaseOrderReceiveRequest{
+			Id:    uint64(1),
+			Lines: []*pb.PurchaseOrderReceiveRequest_Line{{ProductId: uint64(2), Quantity: uint64(6)}},
+		})
+
+		// assert
+		assert.Equal(t, status.Code(interceptors.Status(err)), codes.FailedPrecondition)
+	})
+
+	t.Run("purchase order not found", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.purchaseRepo.EXPECT().ReceivePurchaseOrder(gomock.Any(), uint64(1), map[uint64]uint64{}).
+			Return(nil, errors.Wrap(repository.PurchaseOrderNotExists, "1"))
+
+		// act
+		_, err := f.service.PurchaseOrderReceive(context.Background(), &pb.PurchaseOrderReceiveRequest{Id: uint64(1)})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = NotFound desc = 1: purchase order does not exist")
+	})
+}
+
+func TestPlaceOrder(t *testing.T) {
+	t.Run("success placing order", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.productRepo.EXPECT().ReserveProduct(gomock.Any(), uint64(1), uint64(2)).
+			Return(&products.Product{Id: uint64(1), Quantity: uint64(3)}, nil, nil)
+
+		// act
+		res, err := f.service.PlaceOrder(context.Background(), &pb.PlaceOrderRequest{
+			OrderId: "order1",
+			Lines:   []*pb.OrderLine{{ProductId: uint64(1), Quantity: uint64(2)}},
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pb.PlaceOrderResponse{
+			OrderId: "order1",
+			Status:  string(orders.StatusPlaced),
+			Lines:   []*pb.OrderLine{{ProductId: uint64(1), Quantity: uint64(2)}},
+		})
+		assert.Len(t, f.bus.Messages("orderPlaced"), 1)
+	})
+
+	t.Run("not enough quantity", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		gomock.InOrder(
+			f.productRepo.EXPECT().ReserveProduct(gomock.Any(), uint64(1), uint64(2)).
+				Return(&products.Product{Id: uint64(1), Quantity: uint64(3)}, []lots.Picked{{LotId: uint64(4), Quantity: uint64(2)}}, nil),
+			f.productRepo.EXPECT().ReserveProduct(gomock.Any(), uint64(2), uint64(2)).
+				Return(nil, nil, fmt.Errorf("2: %w", products.ErrNotEnoughQuantity)),
+			f.productRepo.EXPECT().ReleaseProduct(gomock.Any(), uint64(1), uint64(2), []lots.Picked{{LotId: uint64(4), Quantity: uint64(2)}}).
+				Return(&products.Product{Id: uint64(1), Quantity: uint64(5)}, nil),
+		)
+
+		// act
+		_, err := f.service.PlaceOrder(context.Background(), &pb.PlaceOrderRequest{
+			OrderId: "order1",
+			Lines: []*pb.OrderLine{
+				{ProductId: uint64(1), Quantity: uint64(2)},
+				{ProductId: uint64(2), Quantity: uint64(2)},
+			},
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = FailedPrecondition desc = 2: not enough quantity")
+		assert.Len(t, f.bus.Messages("orderCancelled"), 1)
+	})
+
+	t.Run("empty order id", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		// act
+		_, err := f.service.PlaceOrder(context.Background(), &pb.PlaceOrderRequest{
+			Lines: []*pb.OrderLine{{ProductId: uint64(1), Quantity: uint64(2)}},
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = order id length must be greater than 0")
+	})
+}
+
+func TestLotAdd(t *testing.T) {
+	expiresAt := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success adding lot", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.lotRepo.EXPECT().AddLot(gomock.Any(), lots.Lot{
+			ProductId: uint64(1),
+			Number:    "A",
+			Quantity:  uint64(5),
+			ExpiresAt: expiresAt,
+		}).Return(&lots.Lot{
+			Id:        uint64(1),
+			ProductId: uint64(1),
+			Number:    "A",
+			Quantity:  uint64(5),
+			ExpiresAt: expiresAt,
+		}, nil)
+
+		// act
+		res, err := f.service.LotAdd(context.Background(), &pb.LotAddRequest{
+			ProductId: uint64(1),
+			Number:    "A",
+			Quantity:  uint64(5),
+			ExpiresAt: "2022-10-01",
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pb.LotAddResponse{
+			Lot: &pb.Lot{Id: uint64(1), ProductId: uint64(1), Number: "A", Quantity: uint64(5), ExpiresAt: "2022-10-01"},
+		})
+	})
+
+	t.Run("invalid expiry date", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		// act
+		_, err := f.service.LotAdd(context.Background(), &pb.LotAddRequest{
+			ProductId: uint64(1),
+			Number:    "A",
+			Quantity:  uint64(5),
+			ExpiresAt: "01.10.2022",
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = lot expiry date must be in 2006-01-02 format")
+	})
+
+	t.Run("lot already exists", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.lotRepo.EXPECT().AddLot(gomock.Any(), gomock.Any()).Return(nil, errors.Wrap(repository.LotAlreadyExists, "A"))
+
+		// act
+		_, err := f.service.LotAdd(context.Background(), &pb.LotAddRequest{
+			ProductId: uint64(1),
+			Number:    "A",
+			Quantity:  uint64(5),
+			ExpiresAt: "2022-10-01",
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = AlreadyExists desc = A: lot already exists")
+	})
+}
+
+func TestListExpiringLots(t *testing.T) {
+	t.Run("success listing expiring lots", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		stream := makeListExpiringLotsResponseStreamMock()
+
+		f.lotRepo.EXPECT().GetExpiringLots(gomock.Any(), gomock.Any(), uint64(0), uint64(0)).Return([]*lots.Lot{
+			{Id: uint64(1), ProductId: uint64(1), Number: "A", Quantity: uint64(5), ExpiresAt: time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)},
+		}, nil)
+
+		// act
+		err := f.service.ListExpiringLots(&pb.ListExpiringLotsRequest{Days: uint64(7)}, stream)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, stream.GetAll(), []*pb.ListExpiringLotsResponse{
+			{Lot: &pb.Lot{Id: uint64(1), ProductId: uint64(1), Number: "A", Quantity: uint64(5), ExpiresAt: "2022-10-01"}},
+		})
+	})
+}
+
+func TestStocktakeCount(t *testing.T) {
+	t.Run("success submitting count", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.stocktakeRepo.EXPECT().SubmitCount(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, count stocktakes.Count) (*stocktakes.Count, error) {
+				assert.Equal(t, "user1", count.CountedBy)
+				return &count, nil
+			})
+
+		// act
+		res, err := f.service.StocktakeCount(asCaller("user1"), &pb.StocktakeCountRequest{
+			Id:        uint64(1),
+			ProductId: uint64(2),
+			Counted:   uint64(3),
+			CountedBy: "mallory",
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pb.StocktakeCountResponse{Id: uint64(1), ProductId: uint64(2), Counted: uint64(3)})
+	})
+
+	t.Run("session is committed", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.stocktakeRepo.EXPECT().SubmitCount(gomock.Any(), gomock.Any()).Return(nil, errors.Wrap(stocktakes.ErrSessionClosed, "1"))
+
+		// act
+		_, err := f.service.StocktakeCount(asCaller("user1"), &pb.StocktakeCountRequest{
+			Id:        uint64(1),
+			ProductId: uint64(2),
+			Counted:   uint64(3),
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = FailedPrecondition desc = 1: stocktake session is not open")
+	})
+
+	t.Run("unauthenticated caller", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		// act
+		_, err := f.service.StocktakeCount(context.Background(), &pb.StocktakeCountRequest{Id: uint64(1), ProductId: uint64(2), CountedBy: "user1"})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = Unauthenticated desc = credentials are required")
+	})
+}
+
+func TestStocktakeGet(t *testing.T) {
+	t.Run("success getting stocktake", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.stocktakeRepo.EXPECT().GetStocktakeById(gomock.Any(), uint64(1)).Return(&stocktakes.Session{
+			Id:       uint64(1),
+			Status:   stocktakes.StatusOpen,
+			OpenedBy: "user1",
+		}, nil)
+		f.stocktakeRepo.EXPECT().GetStocktakeVariances(gomock.Any(), uint64(1)).Return([]*stocktakes.Variance{
+			{ProductId: uint64(2), Name: "product2", Expected: uint64(5), Counted: uint64(3)},
+		}, nil)
+
+		// act
+		res, err := f.service.StocktakeGet(context.Background(), &pb.StocktakeGetRequest{Id: uint64(1)})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pb.StocktakeGetResponse{
+			Stocktake: &pb.Stocktake{Id: uint64(1), Status: "open", OpenedBy: "user1"},
+			Variances: []*pb.StocktakeVariance{
+				{ProductId: uint64(2), Name: "product2", Expected: uint64(5), Counted: uint64(3), Difference: int64(-2)},
+			},
+		})
+	})
+
+	t.Run("stocktake does not exists", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.stocktakeRepo.EXPECT().GetStocktakeById(gomock.Any(), uint64(1)).Return(nil, errors.Wrap(repository.StocktakeNotExists, "1"))
+
+		// act
+		_, err := f.service.StocktakeGet(context.Background(), &pb.StocktakeGetRequest{Id: uint64(1)})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = NotFound desc = 1: stocktake session does not exist")
+	})
+}
+
+func TestStocktakeCommit(t *testing.T) {
+	t.Run("success committing stocktake", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.stocktakeRepo.EXPECT().CommitStocktake(gomock.Any(), uint64(1), "user2").Return(
+			&stocktakes.Session{Id: uint64(1), Status: stocktakes.StatusCommitted, OpenedBy: "user1", CommittedBy: "user2"},
+			[]*stocktakes.Adjustment{{Id: uint64(1), SessionId: uint64(1), ProductId: uint64(2), Before: uint64(5), After: uint64(3)}},
+			nil)
+
+		// act
+		res, err := f.service.StocktakeCommit(asCaller("user2"), &pb.StocktakeCommitRequest{Id: uint64(1), CommittedBy: "mallory"})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pb.StocktakeCommitResponse{
+			Stocktake:   &pb.Stocktake{Id: uint64(1), Status: "committed", OpenedBy: "user1", CommittedBy: "user2"},
+			Adjustments: []*pb.StockAdjustment{{Id: uint64(1), ProductId: uint64(2), Before: uint64(5), After: uint64(3)}},
+		})
+	})
+
+	t.Run("unauthenticated caller", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		// act
+		_, err := f.service.StocktakeCommit(context.Background(), &pb.StocktakeCommitRequest{Id: uint64(1), CommittedBy: "user2"})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = Unauthenticated desc = credentials are required")
+	})
+}
+
+func TestStocktakeOpen(t *testing.T) {
+	t.Run("success opening stocktake", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.stocktakeRepo.EXPECT().OpenStocktake(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, session stocktakes.Session) (*stocktakes.Session, error) {
+				session.Id = uint64(1)
+				return &session, nil
+			})
+
+		// act
+		res, err := f.service.StocktakeOpen(asCaller("user1"), &pb.StocktakeOpenRequest{OpenedBy: "mallory"})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, "user1", res.GetStocktake().GetOpenedBy())
+	})
+}
+
+func TestStockValuation(t *testing.T) {
+	t.Run("success getting valuation", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.reportRepo.EXPECT().GetStockValuation(gomock.Any()).Return(&reports.Valuation{
+			TotalValue:     uint64(50),
+			TotalQuantity:  uint64(12),
+			ProductCount:   uint64(3),
+			ZeroStockCount: uint64(1),
+		}, nil)
+		f.reportRepo.EXPECT().GetValueByStatus(gomock.Any()).Return([]*reports.StatusValue{
+			{Status: products.StatusActive, ProductCount: uint64(2), Quantity: uint64(12), Value: uint64(50)},
+		}, nil)
+		f.reportRepo.EXPECT().GetValueByCategory(gomock.Any()).Return([]*reports.CategoryValue{
+			{ProductCount: uint64(1)},
+			{Category: "food", ProductCount: uint64(2), Quantity: uint64(12), Value: uint64(50)},
+		}, nil)
+
+		// act
+		res, err := f.service.StockValuation(context.Background(), &pb.StockValuationRequest{})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pb.StockValuationResponse{
+			TotalValue:     uint64(50),
+			TotalQuantity:  uint64(12),
+			ProductCount:   uint64(3),
+			ZeroStockCount: uint64(1),
+			ByStatus:       []*pb.StatusValue{{Status: "active", ProductCount: uint64(2), Quantity: uint64(12), Value: uint64(50)}},
+			ByCategory: []*pb.CategoryValue{
+				{ProductCount: uint64(1)},
+				{Category: "food", ProductCount: uint64(2), Quantity: uint64(12), Value: uint64(50)},
+			},
+		})
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.reportRepo.EXPECT().GetStockValuation(gomock.Any()).Return(nil, errors.New("some error"))
+
+		// act
+		_, err := f.service.StockValuation(context.Background(), &pb.StockValuationRequest{})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = Internal desc = internal error")
+	})
+}
+
+func TestUploadProductImage(t *testing.T) {
+	info := &pb.UploadProductImageRequest{Data: &pb.UploadProductImageRequest_Info_{
+		Info: &pb.UploadProductImageRequest_Info{ProductId: uint64(1), ContentType: "image/png"},
+	}}
+	chunk := func(data string) *pb.UploadProductImageRequest {
+		return &pb.UploadProductImageRequest{Data: &pb.UploadProductImageRequest_Chunk{Chunk: []byte(data)}}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		createdAt := time.Date(2022, 9, 28, 0, 0, 0, 0, time.UTC)
+		f.productRepo.EXPECT().GetProductById(gomock.Any(), uint64(1)).Return(&products.Product{Id: uint64(1)}, nil)
+		f.blobStore.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, content io.Reader) (int64, error) {
+				data, err := io.ReadAll(content)
+				return int64(len(data)), err
+			})
+		f.imageRepo.EXPECT().AddImage(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, image images.Image) (*images.Image, error) {
+				image.Id = uint64(1)
+				image.CreatedAt = createdAt
+				return &image, nil
+			})
+
+		stream := makeUploadProductImageStreamMock(info, chunk("con"), chunk("tent"))
+
+		// act
+		err := f.service.UploadProductImage(stream)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, stream.response.GetImage(), &pb.ProductImage{
+			Id:          uint64(1),
+			ProductId:   uint64(1),
+			ContentType: "image/png",
+			Size:        uint64(7),
+			Checksum:    "ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73",
+			CreatedAt:   "2022-09-28T00:00:00Z",
+		})
+	})
+
+	t.Run("missing info", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		// act
+		err := f.service.UploadProductImage(makeUploadProductImageStreamMock(chunk("content")))
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = the first message must carry the image info")
+	})
+
+	t.Run("unsupported content type", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		stream := makeUploadProductImageStreamMock(&pb.UploadProductImageRequest{Data: &pb.UploadProductImageRequest_Info_{
+			Info: &pb.UploadProductImageRequest_Info{ProductId: uint64(1), ContentType: "text/plain"},
+		}})
+
+		// act
+		err := f.service.UploadProductImage(stream)
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), `rpc error: code = InvalidArgument desc = "text/plain": unsupported image content type, expected image/jpeg, image/png, image/gif or image/webp`)
+	})
+
+	t.Run("product not found", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.productRepo.EXPECT().GetProductById(gomock.Any(), uint64(1)).Return(nil, repository.ProductNotExists)
+
+		// act
+		err := f.service.UploadProductImage(makeUploadProductImageStreamMock(info, chunk("content")))
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = NotFound desc = product does not exist")
+	})
+
+	t.Run("empty image", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.productRepo.EXPECT().GetProductById(gomock.Any(), uint64(1)).Return(&products.Product{Id: uint64(1)}, nil)
+		f.blobStore.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(0), nil)
+		f.blobStore.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+
+		// act
+		err := f.service.UploadProductImage(makeUploadProductImageStreamMock(info))
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = image must not be empty")
+	})
+}
+
+func TestDownloadProductImage(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		createdAt := time.Date(2022, 9, 28, 0, 0, 0, 0, time.UTC)
+		f.imageRepo.EXPECT().GetImageById(gomock.Any(), uint64(2)).Return(&images.Image{
+			Id:          uint64(2),
+			ProductId:   uint64(1),
+			Key:         "products/1/a",
+			ContentType: "image/png",
+			Size:        uint64(7),
+			CreatedAt:   createdAt,
+		}, nil)
+		f.blobStore.EXPECT().Get(gomock.Any(), "products/1/a").Return(io.NopCloser(strings.NewReader("content")), nil)
+
+		stream := &DownloadProductImageStreamMock{}
+
+		// act
+		err := f.service.DownloadProductImage(&pb.DownloadProductImageRequest{ProductId: uint64(1), Id: uint64(2)}, stream)
+
+		// assert
+		require.NoError(t, err)
+		require.Len(t, stream.responses, 2)
+		assert.Equal(t, stream.responses[0].GetImage().GetContentType(), "image/png")
+		assert.Equal(t, stream.responses[1].GetChunk(), []byte("content"))
+	})
+
+	t.Run("image of another product", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.imageRepo.EXPECT().GetImageById(gomock.Any(), uint64(2)).
+			Return(&images.Image{Id: uint64(2), ProductId: uint64(3), Key: "products/3/a"}, nil)
+
+		// act
+		err := f.service.DownloadProductImage(&pb.DownloadProductImageRequest{ProductId: uint64(1), Id: uint64(2)}, &DownloadProductImageStreamMock{})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = NotFound desc = 2: image does not exist")
+	})
+}
+
+func TestRelationCreate(t *testing.T) {
+	t.Run("success creating relation", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		relation := relations.Relation{ProductId: uint64(1), RelatedId: uint64(2), Type: relations.TypeAccessory}
+		f.relationRepo.EXPECT().CreateRelation(gomock.Any(), relation).Return(&relation, nil)
+
+		// act
+		res, err := f.service.RelationCreate(context.Background(), &pb.RelationCreateRequest{
+			ProductId: uint64(1),
+			RelatedId: uint64(2),
+			Type:      "accessory",
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pb.RelationCreateResponse{
+			Relation: &pb.ProductRelation{ProductId: uint64(1), RelatedId: uint64(2), Type: "accessory"},
+		})
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		// act
+		_, err := f.service.RelationCreate(context.Background(), &pb.RelationCreateRequest{
+			ProductId: uint64(1),
+			RelatedId: uint64(2),
+			Type:      "sibling",
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = sibling: unknown relation type, expected related, accessory or replacement")
+	})
+
+	t.Run("self relation", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		// act
+		_, err := f.service.RelationCreate(context.Background(), &pb.RelationCreateRequest{
+			ProductId: uint64(1),
+			RelatedId: uint64(1),
+			Type:      "related",
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = product can't be related to itself")
+	})
+
+	t.Run("product not found", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.relationRepo.EXPECT().CreateRelation(gomock.Any(), gomock.Any()).Return(nil, errors.Wrap(repository.ProductNotExists, "2"))
+
+		// act
+		_, err := f.service.RelationCreate(context.Background(), &pb.RelationCreateRequest{
+			ProductId: uint64(1),
+			RelatedId: uint64(2),
+			Type:      "related",
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = NotFound desc = 2: product does not exist")
+	})
+
+	t.Run("relation already exists", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.relationRepo.EXPECT().CreateRelation(gomock.Any(), gomock.Any()).Return(nil, errors.Wrap(repository.RelationAlreadyExists, "1 -> 2"))
+
+		// act
+		_, err := f.service.RelationCreate(context.Background(), &pb.RelationCreateRequest{
+			ProductId: uint64(1),
+			RelatedId: uint64(2),
+			Type:      "related",
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = AlreadyExists desc = 1 -> 2: relation already exists")
+	})
+}
+
+func TestRelationList(t *testing.T) {
+	t.Run("success listing relations", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.relationRepo.EXPECT().GetProductRelations(gomock.Any(), uint64(1)).Return([]*relations.Relation{
+			{ProductId: uint64(1), RelatedId: uint64(2), Type: relations.TypeAccessory},
+			{ProductId: uint64(1), RelatedId: uint64(3), Type: relations.TypeReplacement},
+		}, nil)
+
+		stream := &RelationListStreamMock{}
+
+		// act
+		err := f.service.RelationList(&pb.RelationListRequest{ProductId: uint64(1)}, stream)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, stream.responses, []*pb.RelationListResponse{
+			{Relation: &pb.ProductRelation{ProductId: uint64(1), RelatedId: uint64(2), Type: "accessory"}},
+			{Relation: &pb.ProductRelation{ProductId: uint64(1), RelatedId: uint64(3), Type: "replacement"}},
+		})
+	})
+}
+
+func TestRelationUpdate(t *testing.T) {
+	t.Run("success updating relation", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		relation := relations.Relation{ProductId: uint64(1), RelatedId: uint64(2), Type: relations.TypeReplacement}
+		f.relationRepo.EXPECT().UpdateRelation(gomock.Any(), relation).Return(&relation, nil)
+
+		// act
+		res, err := f.service.RelationUpdate(context.Background(), &pb.RelationUpdateRequest{
+			ProductId: uint64(1),
+			RelatedId: uint64(2),
+			Type:      "replacement",
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pb.RelationUpdateResponse{
+			Relation: &pb.ProductRelation{ProductId: uint64(1), RelatedId: uint64(2), Type: "replacement"},
+		})
+	})
+
+	t.Run("relation not found", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.relationRepo.EXPECT().UpdateRelation(gomock.Any(), gomock.Any()).Return(nil, errors.Wrap(repository.RelationNotExists, "1 -> 2"))
+
+		// act
+		_, err := f.service.RelationUpdate(context.Background(), &pb.RelationUpdateRequest{
+			ProductId: uint64(1),
+			RelatedId: uint64(2),
+			Type:      "replacement",
+		})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = NotFound desc = 1 -> 2: relation does not exist")
+	})
+}
+
+func TestRelationDelete(t *testing.T) {
+	t.Run("success deleting relation", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.relationRepo.EXPECT().DeleteRelation(gomock.Any(), uint64(1), uint64(2)).Return(nil)
+
+		// act
+		res, err := f.service.RelationDelete(context.Background(), &pb.RelationDeleteRequest{ProductId: uint64(1), RelatedId: uint64(2)})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pb.RelationDeleteResponse{})
+	})
+
+	t.Run("relation not found", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.relationRepo.EXPECT().DeleteRelation(gomock.Any(), uint64(1), uint64(2)).Return(errors.Wrap(repository.RelationNotExists, "1 -> 2"))
+
+		// act
+		_, err := f.service.RelationDelete(context.Background(), &pb.RelationDeleteRequest{ProductId: uint64(1), RelatedId: uint64(2)})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = NotFound desc = 1 -> 2: relation does not exist")
+	})
+}
+
+func TestGetRelatedProducts(t *testing.T) {
+	t.Run("success filtering by type", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.relationRepo.EXPECT().GetRelatedProducts(gomock.Any(), uint64(1), relations.TypeAccessory).Return([]*relations.RelatedProduct{
+			{
+				Product: products.Product{Id: uint64(2), Name: "cable", Price: uint64(5), Quantity: uint64(3), Status: products.StatusActive},
+				Type:    relations.TypeAccessory,
+			},
+		}, nil)
+
+		stream := &GetRelatedProductsStreamMock{}
+		relationType := "accessory"
+
+		// act
+		err := f.service.GetRelatedProducts(&pb.GetRelatedProductsRequest{ProductId: uint64(1), Type: &relationType}, stream)
+
+		// assert
+		require.NoError(t, err)
+		require.Len(t, stream.responses, 1)
+		assert.Equal(t, stream.responses[0].GetProduct().GetId(), uint64(2))
+		assert.Equal(t, stream.responses[0].GetProduct().GetName(), "cable")
+		assert.Equal(t, stream.responses[0].GetProduct().GetRelationType(), "accessory")
+	})
+
+	t.Run("all types without filter", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.relationRepo.EXPECT().GetRelatedProducts(gomock.Any(), uint64(1), relations.Type("")).Return(nil, nil)
+
+		// act
+		err := f.service.GetRelatedProducts(&pb.GetRelatedProductsRequest{ProductId: uint64(1)}, &GetRelatedProductsStreamMock{})
+
+		// assert
+		require.NoError(t, err)
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		relationType := "sibling"
+
+		// act
+		err := f.service.GetRelatedProducts(&pb.GetRelatedProductsRequest{ProductId: uint64(1), Type: &relationType}, &GetRelatedProductsStreamMock{})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = sibling: unknown relation type, expected related, accessory or replacement")
+	})
+}
+
+func TestExchangeRateSet(t *testing.T) {
+	t.Run("success setting rate", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		updatedAt := time.Date(2022, 10, 3, 0, 0, 0, 0, time.UTC)
+		f.rateRepo.EXPECT().SetExchangeRate(gomock.Any(), money.Rate{Currency: "USD", Value: uint64(13725)}).
+			Return(&money.Rate{Currency: "USD", Value: uint64(13725), UpdatedAt: updatedAt}, nil)
+
+		// act
+		res, err := f.service.ExchangeRateSet(context.Background(), &pb.ExchangeRateSetRequest{Currency: "usd", Rate: "0.013725"})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &pb.ExchangeRateSetResponse{
+			Rate: &pb.ExchangeRate{Currency: "USD", Rate: "0.013725", UpdatedAt: "2022-10-03T00:00:00Z"},
+		})
+	})
+
+	t.Run("base currency", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		// act
+		_, err := f.service.ExchangeRateSet(context.Background(), &pb.ExchangeRateSetRequest{Currency: "RUB", Rate: "2"})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = exchange rate of the base currency is always 1")
+	})
+
+	t.Run("invalid rate", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		// act
+		_, err := f.service.ExchangeRateSet(context.Background(), &pb.ExchangeRateSetRequest{Currency: "USD", Rate: "-1"})
+
+		// assert
+		assert.EqualError(t, interceptors.Status(err), "rpc error: code = InvalidArgument desc = \"-1\": invalid exchange rate, expected a positive decimal number")
+	})
+}
+
+func TestExchangeRateList(t *testing.T) {
+	t.Run("success listing rates", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		updatedAt := time.Date(2022, 10, 3, 0, 0, 0, 0, time.UTC)
+		f.rateRepo.EXPECT().GetExchangeRates(gomock.Any()).Return([]*money.Rate{
+			{Currency: "EUR", Value: uint64(14010), UpdatedAt: updatedAt},
+			{Currency: "USD", Value: uint64(13725), UpdatedAt: updatedAt},
+		}, nil)
+
+		stream := &ExchangeRateListStreamMock{}
+
+		// act
+		err := f.service.ExchangeRateList(&pb.ExchangeRateListRequest{}, stream)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, stream.responses, []*pb.ExchangeRateListResponse{
+			{Rate: &pb.ExchangeRate{Currency: "EUR", Rate: "0.01401", UpdatedAt: "2022-10-03T00:00:00Z"}},
+			{Rate: &pb.ExchangeRate{Currency: "USD", Rate: "0.013725", UpdatedAt: "2022-10-03T00:00:00Z"}},
+		})
 	})
 }
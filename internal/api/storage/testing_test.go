@@ -4,24 +4,93 @@ import (
 	"context"
 	"github.com/golang/mock/gomock"
 	"google.golang.org/grpc"
+	"homework-1/internal/approvals"
+	"homework-1/internal/auth"
+	mock_blobstore "homework-1/internal/blobstore/mock"
+	"homework-1/internal/gallery"
+	"homework-1/internal/models/attributes"
+	"homework-1/internal/models/relations"
+	"homework-1/internal/ordering"
 	mock_repository "homework-1/internal/repository/mock"
 	pb "homework-1/pkg/api/storage/v1"
+	"io"
 	"testing"
 )
 
 type storageFixture struct {
-	Ctx         context.Context
-	service     *implementation
-	productRepo *mock_repository.MockProduct
+	Ctx             context.Context
+	service         *implementation
+	productRepo     *mock_repository.MockProduct
+	priceChangeRepo *mock_repository.MockPriceChange
+	stockRepo       *mock_repository.MockStock
+	purchaseRepo    *mock_repository.MockPurchase
+	bus             *ordering.MemoryBus
+	lotRepo         *mock_repository.MockLot
+	stocktakeRepo   *mock_repository.MockStocktake
+	reportRepo      *mock_repository.MockReport
+	translationRepo *mock_repository.MockTranslation
+	imageRepo       *mock_repository.MockImage
+	relationRepo    *mock_repository.MockRelation
+	rateRepo        *mock_repository.MockExchangeRate
+	blobStore       *mock_blobstore.MockBlobStore
 }
 
 func SetUp(t *testing.T) *storageFixture {
 	f := storageFixture{Ctx: context.Background()}
-	f.productRepo = mock_repository.NewMockProduct(gomock.NewController(t))
-	f.service = New(Deps{ProductRepository: f.productRepo})
+	ctrl := gomock.NewController(t)
+	f.productRepo = mock_repository.NewMockProduct(ctrl)
+	f.priceChangeRepo = mock_repository.NewMockPriceChange(ctrl)
+	f.stockRepo = mock_repository.NewMockStock(ctrl)
+	f.purchaseRepo = mock_repository.NewMockPurchase(ctrl)
+	f.bus = ordering.NewMemoryBus()
+	f.lotRepo = mock_repository.NewMockLot(ctrl)
+	f.stocktakeRepo = mock_repository.NewMockStocktake(ctrl)
+	f.reportRepo = mock_repository.NewMockReport(ctrl)
+	f.translationRepo = mock_repository.NewMockTranslation(ctrl)
+	f.imageRepo = mock_repository.NewMockImage(ctrl)
+	f.relationRepo = mock_repository.NewMockRelation(ctrl)
+	f.rateRepo = mock_repository.NewMockExchangeRate(ctrl)
+	f.blobStore = mock_blobstore.NewMockBlobStore(ctrl)
+	orderService := &ordering.Service{
+		Repository:     f.productRepo,
+		Publisher:      f.bus,
+		PlacedTopic:    "orderPlaced",
+		CancelledTopic: "orderCancelled",
+	}
+	f.service = New(Deps{
+		ProductRepository:     f.productRepo,
+		PriceChangeRepository: f.priceChangeRepo,
+		ApprovalService: &approvals.Service{
+			ProductRepository:     f.productRepo,
+			PriceChangeRepository: f.priceChangeRepo,
+		},
+		StockRepository:       f.stockRepo,
+		PurchaseRepository:    f.purchaseRepo,
+		OrderService:          orderService,
+		LotRepository:         f.lotRepo,
+		StocktakeRepository:   f.stocktakeRepo,
+		ReportRepository:      f.reportRepo,
+		TranslationRepository: f.translationRepo,
+		ImageRepository:       f.imageRepo,
+		ImageService: &gallery.Service{
+			ProductRepository:  f.productRepo,
+			ImageRepository:    f.imageRepo,
+			BlobStore:          f.blobStore,
+			RelationRepository: f.relationRepo,
+			RelationPolicy:     relations.DeleteCascade,
+		},
+		RelationRepository:     f.relationRepo,
+		ExchangeRateRepository: f.rateRepo,
+		AttributeRegistry:      attributes.DefaultRegistry(),
+	})
 	return &f
 }
 
+// asCaller is the context of a request authenticated as subject.
+func asCaller(subject string) context.Context {
+	return auth.NewContext(context.Background(), &auth.Identity{Subject: subject})
+}
+
 func makeProductListResponseStreamMock() *ProductListResponseStreamMock {
 	return &ProductListResponseStreamMock{
 		queue: make(chan *pb.ProductListResponse, 10),
@@ -33,6 +102,10 @@ type ProductListResponseStreamMock struct {
 	queue chan *pb.ProductListResponse
 }
 
+func (m *ProductListResponseStreamMock) Context() context.Context {
+	return context.Background()
+}
+
 func (m *ProductListResponseStreamMock) Close() {
 	close(m.queue)
 }
@@ -51,3 +124,147 @@ func (m *ProductListResponseStreamMock) GetAll() []*pb.ProductListResponse {
 	}
 	return resp
 }
+
+func makeListLowStockResponseStreamMock() *ListLowStockResponseStreamMock {
+	return &ListLowStockResponseStreamMock{
+		queue: make(chan *pb.ListLowStockResponse, 10),
+	}
+}
+
+type ListLowStockResponseStreamMock struct {
+	grpc.ServerStream
+	queue chan *pb.ListLowStockResponse
+}
+
+func (m *ListLowStockResponseStreamMock) Context() context.Context {
+	return context.Background()
+}
+
+func (m *ListLowStockResponseStreamMock) Send(resp *pb.ListLowStockResponse) error {
+	m.queue <- resp
+	return nil
+}
+
+func (m *ListLowStockResponseStreamMock) GetAll() []*pb.ListLowStockResponse {
+	close(m.queue)
+
+	var resp []*pb.ListLowStockResponse
+	for item := range m.queue {
+		resp = append(resp, item)
+	}
+	return resp
+}
+
+func makeListExpiringLotsResponseStreamMock() *ListExpiringLotsResponseStreamMock {
+	return &ListExpiringLotsResponseStreamMock{
+		queue: make(chan *pb.ListExpiringLotsResponse, 10),
+	}
+}
+
+type ListExpiringLotsResponseStreamMock struct {
+	grpc.ServerStream
+	queue chan *pb.ListExpiringLotsResponse
+}
+
+func (m *ListExpiringLotsResponseStreamMock) Context() context.Context {
+	return context.Background()
+}
+
+func (m *ListExpiringLotsResponseStreamMock) Send(resp *pb.ListExpiringLotsResponse) error {
+	m.queue <- resp
+	return nil
+}
+
+func (m *ListExpiringLotsResponseStreamMock) GetAll() []*pb.ListExpiringLotsResponse {
+	close(m.queue)
+
+	var resp []*pb.ListExpiringLotsResponse
+	for item := range m.queue {
+		resp = append(resp, item)
+	}
+	return resp
+}
+
+func makeUploadProductImageStreamMock(requests ...*pb.UploadProductImageRequest) *UploadProductImageStreamMock {
+	return &UploadProductImageStreamMock{requests: requests}
+}
+
+type UploadProductImageStreamMock struct {
+	grpc.ServerStream
+	requests []*pb.UploadProductImageRequest
+	response *pb.UploadProductImageResponse
+}
+
+func (m *UploadProductImageStreamMock) Context() context.Context {
+	return context.Background()
+}
+
+func (m *UploadProductImageStreamMock) Recv() (*pb.UploadProductImageRequest, error) {
+	if len(m.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := m.requests[0]
+	m.requests = m.requests[1:]
+	return req, nil
+}
+
+func (m *UploadProductImageStreamMock) SendAndClose(resp *pb.UploadProductImageResponse) error {
+	m.response = resp
+	return nil
+}
+
+type DownloadProductImageStreamMock struct {
+	grpc.ServerStream
+	responses []*pb.DownloadProductImageResponse
+}
+
+func (m *DownloadProductImageStreamMock) Context() context.Context {
+	return context.Background()
+}
+
+func (m *DownloadProductImageStreamMock) Send(resp *pb.DownloadProductImageResponse) error {
+	m.responses = append(m.responses, resp)
+	return nil
+}
+
+type RelationListStreamMock struct {
+	grpc.ServerStream
+	responses []*pb.RelationListResponse
+}
+
+func (m *RelationListStreamMock) Context() context.Context {
+	return context.Background()
+}
+
+func (m *RelationListStreamMock) Send(resp *pb.RelationListResponse) error {
+	m.responses = append(m.responses, resp)
+	return nil
+}
+
+type GetRelatedProductsStreamMock struct {
+	grpc.ServerStream
+	responses []*pb.GetRelatedProductsResponse
+}
+
+func (m *GetRelatedProductsStreamMock) Context() context.Context {
+	return context.Background()
+}
+
+func (m *GetRelatedProductsStreamMock) Send(resp *pb.GetRelatedProductsResponse) error {
+	m.responses = append(m.responses, resp)
+	return nil
+}
+
+type ExchangeRateListStreamMock struct {
+	grpc.ServerStream
+	responses []*pb.ExchangeRateListResponse
+}
+
+func (m *ExchangeRateListStreamMock) Context() context.Context {
+	return context.Background()
+}
+
+func (m *ExchangeRateListStreamMock) Send(resp *pb.ExchangeRateListResponse) error {
+	m.responses = append(m.responses, resp)
+	return nil
+}
@@ -0,0 +1,55 @@
+package approvals
+
+import (
+	"context"
+	"homework-1/internal/models/changes"
+	"homework-1/internal/models/products"
+	"homework-1/internal/repository"
+)
+
+// Service updates products and holds price changes above the threshold back until another
+// user approves them. Only the price waits for approval, the rest of the update is applied right away.
+type Service struct {
+	ProductRepository     repository.Product
+	PriceChangeRepository repository.PriceChange
+	// Threshold is the price change in percent that requires approval, zero disables approvals
+	Threshold uint64
+}
+
+// Requester tells who requests an update, it is asked only when the update waits for approval.
+type Requester func() (string, error)
+
+// Requested is the Requester of a known user.
+func Requested(by string) Requester {
+	return func() (string, error) {
+		return by, nil
+	}
+}
+
+// UpdateProduct stores updated in place of product, the stored version of the same product.
+// When the price change needs approval the product keeps its price and the pending change is
+// returned, otherwise the change is nil.
+func (s *Service) UpdateProduct(ctx context.Context, product *products.Product, updated products.Product, requester Requester) (*products.Product, *changes.PriceChange, error) {
+	if !changes.RequiresApproval(product.GetPrice(), updated.GetPrice(), s.Threshold) {
+		stored, err := s.ProductRepository.UpdateProduct(ctx, updated)
+		return stored, nil, err
+	}
+
+	requestedBy, err := requester()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	price := updated.GetPrice()
+	updated.Price = product.GetPrice()
+	stored, err := s.ProductRepository.UpdateProduct(ctx, updated)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	change := changes.NewPriceChange(stored, stored.GetName(), price, stored.GetQuantity(), requestedBy)
+	if change, err = s.PriceChangeRepository.CreatePriceChange(ctx, *change); err != nil {
+		return nil, nil, err
+	}
+	return stored, change, nil
+}
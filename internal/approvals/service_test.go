@@ -0,0 +1,124 @@
+package approvals
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"homework-1/internal/models/changes"
+	"homework-1/internal/models/products"
+	localRepository "homework-1/internal/repository/local"
+	"homework-1/internal/tenants"
+	"testing"
+)
+
+type serviceFixture struct {
+	service *Service
+	repo    *localRepository.Repository
+	product *products.Product
+	ctx     context.Context
+}
+
+func SetUp(t *testing.T) *serviceFixture {
+	repo := localRepository.NewRepository(localRepository.NewWarehouse())
+
+	f := serviceFixture{repo: repo, ctx: tenants.NewContext(context.Background(), "shop")}
+	f.service = &Service{
+		ProductRepository:     repo,
+		PriceChangeRepository: repo,
+		Threshold:             20,
+	}
+
+	product, err := repo.CreateProduct(f.ctx, products.Product{
+		Name:     "pillow",
+		Price:    uint64(100),
+		Quantity: uint64(3),
+		Status:   products.StatusActive,
+	})
+	require.NoError(t, err)
+	f.product = product
+	return &f
+}
+
+// update is the stored product with a new name, price and quantity.
+func (f *serviceFixture) update(price uint64) products.Product {
+	updated := *f.product.Copy()
+	updated.Name = "soft pillow"
+	updated.Price = price
+	updated.Quantity = uint64(4)
+	return updated
+}
+
+func TestUpdateProduct(t *testing.T) {
+	t.Run("price within the threshold is applied", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		requester := func() (string, error) {
+			t.Fatal("no approval is requested")
+			return "", nil
+		}
+
+		// act
+		product, change, err := f.service.UpdateProduct(f.ctx, f.product, f.update(110), requester)
+
+		// assert
+		require.NoError(t, err)
+		assert.Nil(t, change)
+		assert.Equal(t, uint64(110), product.GetPrice())
+		assert.Equal(t, "soft pillow", product.GetName())
+		pending, err := f.repo.GetPendingPriceChanges(f.ctx, 0, 0)
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+	})
+
+	t.Run("price above the threshold waits for approval", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		// act
+		product, change, err := f.service.UpdateProduct(f.ctx, f.product, f.update(200), Requested("alice"))
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, uint64(100), product.GetPrice())
+		assert.Equal(t, "soft pillow", product.GetName())
+		assert.Equal(t, uint64(4), product.GetQuantity())
+		require.NotNil(t, change)
+		assert.Equal(t, product.GetId(), change.ProductId)
+		assert.Equal(t, uint64(100), change.OldPrice)
+		assert.Equal(t, uint64(200), change.Price)
+		assert.Equal(t, changes.StatusPending, change.Status)
+		assert.Equal(t, "alice", change.RequestedBy)
+	})
+
+	t.Run("unknown requester", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		unauthenticated := errors.New("credentials are required")
+
+		// act
+		_, _, err := f.service.UpdateProduct(f.ctx, f.product, f.update(200), func() (string, error) {
+			return "", unauthenticated
+		})
+
+		// assert
+		assert.ErrorIs(t, err, unauthenticated)
+		stored, err := f.repo.GetProductById(f.ctx, f.product.GetId())
+		require.NoError(t, err)
+		assert.Equal(t, "pillow", stored.GetName())
+	})
+
+	t.Run("zero threshold disables approvals", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		f.service.Threshold = 0
+
+		// act
+		product, change, err := f.service.UpdateProduct(f.ctx, f.product, f.update(1000), Requested("alice"))
+
+		// assert
+		require.NoError(t, err)
+		assert.Nil(t, change)
+		assert.Equal(t, uint64(1000), product.GetPrice())
+	})
+}
@@ -0,0 +1,117 @@
+// Package auth authenticates calls to the public api services. A request carries either
+// a static API key or an HMAC-signed JWT, both are checked against local files.
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/metadata"
+	"homework-1/internal/tenants"
+	"os"
+	"strings"
+)
+
+const (
+	// AuthorizationMetadataKey carries a JWT as "Bearer <token>", the gateway passes the Authorization header in it.
+	AuthorizationMetadataKey = "authorization"
+	// APIKeyMetadataKey carries a static API key, the gateway fills it from the X-Api-Key header.
+	APIKeyMetadataKey = "x-api-key"
+)
+
+var (
+	ErrNoCredentials      = errors.New("credentials are required")
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrTenantForbidden    = errors.New("credentials do not grant access to the tenant")
+)
+
+// Identity is who a request is made by.
+type Identity struct {
+	Subject string
+	Roles   []string
+	// Tenant limits the identity to one shop, empty for identities that serve every shop
+	Tenant tenants.Tenant
+}
+
+// Authenticator checks one kind of credentials. It returns ErrNoCredentials when the
+// request carries none of its kind, so that the next authenticator can be tried.
+type Authenticator interface {
+	Authenticate(md metadata.MD) (*Identity, error)
+}
+
+// Authenticators tries every authenticator in order. Credentials that are present but
+// invalid fail the request instead of falling through to the next authenticator.
+type Authenticators []Authenticator
+
+func (a Authenticators) Authenticate(md metadata.MD) (*Identity, error) {
+	for _, authenticator := range a {
+		identity, err := authenticator.Authenticate(md)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+		return identity, err
+	}
+	return nil, ErrNoCredentials
+}
+
+// CheckTenant rejects an identity bound to a shop other than the one a request is for.
+func (i *Identity) CheckTenant(tenant tenants.Tenant) error {
+	if i.Tenant != "" && i.Tenant != tenant {
+		return ErrTenantForbidden
+	}
+	return nil
+}
+
+type contextKey struct{}
+
+func NewContext(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, contextKey{}, identity)
+}
+
+// FromContext returns the identity of an authenticated request, false for health checks.
+func FromContext(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(contextKey{}).(*Identity)
+	return identity, ok && identity != nil
+}
+
+// Load builds the authenticators from an API key file and a JWT key set file. A missing file
+// disables its kind of credentials, without both files only health checks are served.
+func Load(apiKeysPath string, keySetPath string, issuer string, audience string) (Authenticators, error) {
+	var authenticators Authenticators
+
+	apiKeys, err := LoadAPIKeys(apiKeysPath)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		log.Warnf("auth: %s not found, api keys are disabled", apiKeysPath)
+	case err != nil:
+		return nil, fmt.Errorf("auth: api keys: %w", err)
+	default:
+		authenticators = append(authenticators, apiKeys)
+	}
+
+	keySet, err := LoadKeySet(keySetPath)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		log.Warnf("auth: %s not found, tokens are disabled", keySetPath)
+	case err != nil:
+		return nil, fmt.Errorf("auth: jwt key set: %w", err)
+	default:
+		authenticators = append(authenticators, &JWTVerifier{Keys: keySet, Issuer: issuer, Audience: audience})
+	}
+
+	return authenticators, nil
+}
+
+// bearerToken returns the token of an "authorization: Bearer <token>" value.
+func bearerToken(md metadata.MD) (string, bool) {
+	values := md.Get(AuthorizationMetadataKey)
+	if len(values) == 0 {
+		return "", false
+	}
+	scheme, token, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
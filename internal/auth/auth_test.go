@@ -0,0 +1,368 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"homework-1/internal/tenants"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	testSecret = []byte("0123456789abcdef0123456789abcdef")
+	testNow    = time.Date(2022, 10, 5, 12, 0, 0, 0, time.UTC)
+)
+
+func newVerifier(t *testing.T) *JWTVerifier {
+	keys, err := NewKeySet([]Key{{
+		Type:      "oct",
+		Id:        "test",
+		Algorithm: "HS256",
+		Secret:    base64.RawURLEncoding.EncodeToString(testSecret),
+	}})
+	require.NoError(t, err)
+	return &JWTVerifier{Keys: keys, Issuer: "homework-1", Audience: "api", Now: func() time.Time { return testNow }}
+}
+
+func validClaims() map[string]interface{} {
+	return map[string]interface{}{
+		"sub":    "alice",
+		"iss":    "homework-1",
+		"aud":    []string{"api"},
+		"exp":    testNow.Add(time.Hour).Unix(),
+		"roles":  []string{"admin"},
+		"tenant": "shop",
+	}
+}
+
+func sign(t *testing.T, header map[string]interface{}, claims map[string]interface{}, secret []byte) string {
+	encode := func(v interface{}) string {
+		data, err := json.Marshal(v)
+		require.NoError(t, err)
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	signingInput := encode(header) + "." + encode(claims)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signingInput))
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func bearer(token string) metadata.MD {
+	return metadata.Pairs(AuthorizationMetadataKey, "Bearer "+token)
+}
+
+func TestJWTVerifier(t *testing.T) {
+	header := map[string]interface{}{"alg": "HS256", "kid": "test"}
+
+	t.Run("valid token", func(t *testing.T) {
+		// arrange
+		verifier := newVerifier(t)
+
+		// act
+		identity, err := verifier.Authenticate(bearer(sign(t, header, validClaims(), testSecret)))
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, &Identity{Subject: "alice", Roles: []string{"admin"}, Tenant: "shop"}, identity)
+	})
+
+	t.Run("single audience", func(t *testing.T) {
+		// arrange
+		verifier := newVerifier(t)
+		claims := validClaims()
+		claims["aud"] = "api"
+
+		// act
+		_, err := verifier.Authenticate(bearer(sign(t, header, claims, testSecret)))
+
+		// assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("no token", func(t *testing.T) {
+		// arrange
+		verifier := newVerifier(t)
+
+		// act
+		_, err := verifier.Authenticate(metadata.Pairs(AuthorizationMetadataKey, "Basic YWxpY2U6"))
+
+		// assert
+		assert.ErrorIs(t, err, ErrNoCredentials)
+	})
+
+	t.Run("invalid tokens", func(t *testing.T) {
+		expired := validClaims()
+		expired["exp"] = testNow.Add(-2 * time.Minute).Unix()
+		notYetValid := validClaims()
+		notYetValid["nbf"] = testNow.Add(2 * time.Minute).Unix()
+		withoutExp := validClaims()
+		delete(withoutExp, "exp")
+		otherIssuer := validClaims()
+		otherIssuer["iss"] = "someone"
+		otherAudience := validClaims()
+		otherAudience["aud"] = "billing"
+
+		for name, token := range map[string]string{
+			"malformed":     "not-a-token",
+			"wrong key":     sign(t, header, validClaims(), []byte(strings.Repeat("x", 32))),
+			"unknown kid":   sign(t, map[string]interface{}{"alg": "HS256", "kid": "other"}, validClaims(), testSecret),
+			"alg mismatch":  sign(t, map[string]interface{}{"alg": "none", "kid": "test"}, validClaims(), testSecret),
+			"expired":       sign(t, header, expired, testSecret),
+			"not yet valid": sign(t, header, notYetValid, testSecret),
+			"without exp":   sign(t, header, withoutExp, testSecret),
+			"other issuer":  sign(t, header, otherIssuer, testSecret),
+			"other aud":     sign(t, header, otherAudience, testSecret),
+		} {
+			// arrange
+			verifier := newVerifier(t)
+
+			// act
+			_, err := verifier.Authenticate(bearer(token))
+
+			// assert
+			assert.ErrorIs(t, err, ErrInvalidCredentials, name)
+		}
+	})
+
+	t.Run("expiry within clock skew", func(t *testing.T) {
+		// arrange
+		verifier := newVerifier(t)
+		claims := validClaims()
+		claims["exp"] = testNow.Add(-30 * time.Second).Unix()
+
+		// act
+		_, err := verifier.Authenticate(bearer(sign(t, header, claims, testSecret)))
+
+		// assert
+		assert.NoError(t, err)
+	})
+}
+
+func TestNewKeySet(t *testing.T) {
+	// act
+	_, err := NewKeySet([]Key{{Type: "oct", Id: "short", Algorithm: "HS256", Secret: base64.RawURLEncoding.EncodeToString([]byte("short"))}})
+
+	// assert
+	assert.Error(t, err)
+}
+
+func TestAPIKeys(t *testing.T) {
+	hash := sha256.Sum256([]byte("secret-key"))
+	keys, err := NewAPIKeys([]APIKey{{SHA256: hex.EncodeToString(hash[:]), Subject: "ci", Roles: []string{"reader"}}})
+	require.NoError(t, err)
+
+	t.Run("known key", func(t *testing.T) {
+		// act
+		identity, err := keys.Authenticate(metadata.Pairs(APIKeyMetadataKey, "secret-key"))
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, &Identity{Subject: "ci", Roles: []string{"reader"}}, identity)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		// act
+		_, err := keys.Authenticate(metadata.Pairs(APIKeyMetadataKey, "guess"))
+
+		// assert
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+
+	t.Run("invalid key does not fall through", func(t *testing.T) {
+		// arrange
+		md := metadata.Pairs(APIKeyMetadataKey, "guess", AuthorizationMetadataKey, "Bearer "+sign(t, map[string]interface{}{"alg": "HS256", "kid": "test"}, validClaims(), testSecret))
+
+		// act
+		_, err := Authenticators{keys, newVerifier(t)}.Authenticate(md)
+
+		// assert
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+
+	t.Run("no credentials", func(t *testing.T) {
+		// act
+		_, err := Authenticators{keys, newVerifier(t)}.Authenticate(metadata.MD{})
+
+		// assert
+		assert.ErrorIs(t, err, ErrNoCredentials)
+	})
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	hash := sha256.Sum256([]byte("shop-key"))
+	keys, err := NewAPIKeys([]APIKey{{SHA256: hex.EncodeToString(hash[:]), Subject: "shop-admin", Tenant: "shop"}})
+	require.NoError(t, err)
+	interceptor := UnaryServerInterceptor(keys)
+
+	call := func(method string, md metadata.MD) (*Identity, error) {
+		var identity *Identity
+		_, err := interceptor(metadata.NewIncomingContext(context.Background(), md), nil, &grpc.UnaryServerInfo{FullMethod: method},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				identity, _ = FromContext(ctx)
+				return nil, nil
+			})
+		return identity, err
+	}
+
+	t.Run("authenticated", func(t *testing.T) {
+		// act
+		identity, err := call("/api.v1.ApiService/ProductList", metadata.Pairs(APIKeyMetadataKey, "shop-key", tenants.MetadataKey, "shop"))
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, "shop-admin", identity.Subject)
+	})
+
+	t.Run("health check without credentials", func(t *testing.T) {
+		// act
+		identity, err := call("/grpc.health.v1.Health/Check", metadata.MD{})
+
+		// assert
+		require.NoError(t, err)
+		assert.Nil(t, identity)
+	})
+
+	t.Run("no credentials", func(t *testing.T) {
+		// act
+		_, err := call("/api.v1.ApiService/ProductList", metadata.Pairs(tenants.MetadataKey, "shop"))
+
+		// assert
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("invalid credentials", func(t *testing.T) {
+		// act
+		_, err := call("/api.v1.ApiService/ProductList", metadata.Pairs(APIKeyMetadataKey, "guess", tenants.MetadataKey, "shop"))
+
+		// assert
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("other tenant", func(t *testing.T) {
+		// act
+		_, err := call("/api.v1.ApiService/ProductList", metadata.Pairs(APIKeyMetadataKey, "shop-key", tenants.MetadataKey, "acme"))
+
+		// assert
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
+
+func TestForwarded(t *testing.T) {
+	forwarded := Forwarded{Key: testSecret, Now: func() time.Time { return testNow }}
+	alice := &Identity{Subject: "alice", Roles: []string{"editor", "viewer"}, Tenant: "shop"}
+	forward := func(key []byte, identity *Identity, expires time.Time) metadata.MD {
+		md, _ := metadata.FromOutgoingContext(AppendToOutgoingContext(context.Background(), key, identity, expires))
+		return md
+	}
+
+	t.Run("forwarded identity", func(t *testing.T) {
+		// act
+		identity, err := forwarded.Authenticate(forward(testSecret, alice, testNow.Add(time.Minute)))
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, alice, identity)
+	})
+
+	t.Run("nothing forwarded", func(t *testing.T) {
+		// act
+		_, err := forwarded.Authenticate(metadata.MD{})
+
+		// assert
+		assert.ErrorIs(t, err, ErrNoCredentials)
+	})
+
+	t.Run("invalid forwarded identities", func(t *testing.T) {
+		unsigned := metadata.Pairs(SubjectMetadataKey, "alice", RolesMetadataKey, "admin")
+		otherKey := forward([]byte("fedcba9876543210fedcba9876543210"), alice, testNow.Add(time.Minute))
+		expired := forward(testSecret, alice, testNow.Add(-2*time.Minute))
+		addedRole := forward(testSecret, alice, testNow.Add(time.Minute))
+		addedRole.Append(RolesMetadataKey, "admin")
+		otherTenant := forward(testSecret, alice, testNow.Add(time.Minute))
+		otherTenant.Set(TenantMetadataKey, "acme")
+		otherSubject := forward(testSecret, alice, testNow.Add(time.Minute))
+		otherSubject.Append(SubjectMetadataKey, "bob")
+
+		for name, md := range map[string]metadata.MD{
+			"unsigned":      unsigned,
+			"other key":     otherKey,
+			"expired":       expired,
+			"added role":    addedRole,
+			"other tenant":  otherTenant,
+			"other subject": otherSubject,
+		} {
+			t.Run(name, func(t *testing.T) {
+				// act
+				_, err := forwarded.Authenticate(md)
+
+				// assert
+				assert.ErrorIs(t, err, ErrInvalidCredentials)
+			})
+		}
+	})
+
+	t.Run("no forwarding key", func(t *testing.T) {
+		// act
+		_, err := Forwarded{}.Authenticate(forward(nil, alice, testNow.Add(time.Minute)))
+
+		// assert
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	call := func(ctx context.Context) metadata.MD {
+		var md metadata.MD
+		err := UnaryClientInterceptor(testSecret)(ctx, "/storage.v1.StorageService/ProductList", nil, nil, nil,
+			func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				md, _ = metadata.FromOutgoingContext(ctx)
+				return nil
+			})
+		require.NoError(t, err)
+		return md
+	}
+
+	t.Run("identity of every shop is bound to the shop of the request", func(t *testing.T) {
+		// arrange
+		ctx := tenants.NewContext(NewContext(context.Background(), &Identity{Subject: "alice", Roles: []string{"admin"}}), "shop")
+
+		// act
+		md := call(ctx)
+
+		// assert
+		identity, err := Forwarded{Key: testSecret}.Authenticate(md)
+		require.NoError(t, err)
+		assert.Equal(t, &Identity{Subject: "alice", Roles: []string{"admin"}, Tenant: "shop"}, identity)
+		assert.Equal(t, ErrTenantForbidden, identity.CheckTenant("acme"))
+	})
+
+	t.Run("identity of one shop keeps its shop", func(t *testing.T) {
+		// arrange
+		ctx := tenants.NewContext(NewContext(context.Background(), &Identity{Subject: "alice", Tenant: "shop"}), "acme")
+
+		// act
+		md := call(ctx)
+
+		// assert
+		assert.Equal(t, []string{"shop"}, md.Get(TenantMetadataKey))
+	})
+
+	t.Run("nothing to forward", func(t *testing.T) {
+		// act
+		md := call(context.Background())
+
+		// assert
+		assert.Empty(t, md.Get(SubjectMetadataKey))
+	})
+}
@@ -0,0 +1,182 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"homework-1/internal/tenants"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// SubjectMetadataKey, RolesMetadataKey and TenantMetadataKey carry the caller the api services
+	// authenticated on to the storage services. ExpiresMetadataKey and SignatureMetadataKey
+	// carry the HMAC the api services sign them with, the storage services trust nothing else.
+	SubjectMetadataKey   = "x-auth-subject"
+	RolesMetadataKey     = "x-auth-roles"
+	TenantMetadataKey    = "x-auth-tenant"
+	ExpiresMetadataKey   = "x-auth-expires"
+	SignatureMetadataKey = "x-auth-signature"
+)
+
+// forwardedTTL is how long a forwarded identity is accepted, long enough for one call.
+const forwardedTTL = time.Minute
+
+// Forwarded authenticates storage requests by the identity an api service forwarded and signed with Key.
+type Forwarded struct {
+	Key []byte
+	// Now replaces the clock in tests
+	Now func() time.Time
+}
+
+func (f Forwarded) Authenticate(md metadata.MD) (*Identity, error) {
+	if subjects := md.Get(SubjectMetadataKey); len(subjects) == 0 || subjects[0] == "" {
+		return nil, ErrNoCredentials
+	}
+	subject, ok := single(md, SubjectMetadataKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: several forwarded subjects", ErrInvalidCredentials)
+	}
+	if len(f.Key) == 0 {
+		return nil, fmt.Errorf("%w: no forwarding key", ErrInvalidCredentials)
+	}
+
+	signature, ok := single(md, SignatureMetadataKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: forwarded identity is not signed", ErrInvalidCredentials)
+	}
+	mac, err := hex.DecodeString(signature)
+	if err != nil {
+		return nil, fmt.Errorf("%w: signature: %v", ErrInvalidCredentials, err)
+	}
+	expiresAt, _ := single(md, ExpiresMetadataKey)
+	tenant, _ := single(md, TenantMetadataKey)
+	roles := md.Get(RolesMetadataKey)
+	if !hmac.Equal(mac, forwardedSignature(f.Key, subject, roles, tenant, expiresAt)) {
+		return nil, fmt.Errorf("%w: bad signature", ErrInvalidCredentials)
+	}
+
+	expires, err := strconv.ParseInt(expiresAt, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: expires: %v", ErrInvalidCredentials, err)
+	}
+	now := time.Now()
+	if f.Now != nil {
+		now = f.Now()
+	}
+	if now.Add(-clockSkew).After(time.Unix(expires, 0)) {
+		return nil, fmt.Errorf("%w: forwarded identity expired", ErrInvalidCredentials)
+	}
+
+	identity := &Identity{Subject: subject, Roles: roles}
+	if tenant != "" {
+		if identity.Tenant, err = tenants.Parse(tenant); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+		}
+	}
+	return identity, nil
+}
+
+// AppendToOutgoingContext forwards identity with the calls made in ctx, signed with key until expires.
+func AppendToOutgoingContext(ctx context.Context, key []byte, identity *Identity, expires time.Time) context.Context {
+	expiresAt := strconv.FormatInt(expires.Unix(), 10)
+	signature := forwardedSignature(key, identity.Subject, identity.Roles, string(identity.Tenant), expiresAt)
+
+	kv := []string{
+		SubjectMetadataKey, identity.Subject,
+		TenantMetadataKey, string(identity.Tenant),
+		ExpiresMetadataKey, expiresAt,
+		SignatureMetadataKey, hex.EncodeToString(signature),
+	}
+	for _, role := range identity.Roles {
+		kv = append(kv, RolesMetadataKey, role)
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
+// UnaryClientInterceptor forwards the identity of the request being served to the called service.
+func UnaryClientInterceptor(key []byte) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingContext(ctx, key), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor is UnaryClientInterceptor for streaming methods.
+func StreamClientInterceptor(key []byte) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingContext(ctx, key), desc, cc, method, opts...)
+	}
+}
+
+// outgoingContext binds an identity that serves every shop to the shop of the request,
+// so the signature can't be replayed for another tenant.
+func outgoingContext(ctx context.Context, key []byte) context.Context {
+	identity, ok := FromContext(ctx)
+	if !ok {
+		return ctx
+	}
+	forwarded := *identity
+	if forwarded.Tenant == "" {
+		forwarded.Tenant, _ = tenants.FromContext(ctx)
+	}
+	return AppendToOutgoingContext(ctx, key, &forwarded, time.Now().Add(forwardedTTL))
+}
+
+func forwardedSignature(key []byte, subject string, roles []string, tenant string, expiresAt string) []byte {
+	if roles == nil {
+		roles = []string{}
+	}
+	// a JSON array keeps the values apart, whatever characters they hold
+	payload, _ := json.Marshal([]interface{}{subject, roles, tenant, expiresAt})
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// single returns the only value of key, a repeated value is not trusted to be the signed one.
+func single(md metadata.MD, key string) (string, bool) {
+	values := md.Get(key)
+	if len(values) != 1 || values[0] == "" {
+		return "", false
+	}
+	return values[0], true
+}
+
+// LoadForwardingKey reads the base64url key the api services sign forwarded identities with.
+func LoadForwardingKey(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(strings.TrimSpace(string(data)), "="))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(key) < sha256.Size {
+		return nil, fmt.Errorf("%s: key must be at least %d bytes", path, sha256.Size)
+	}
+	return key, nil
+}
+
+// GenerateForwardingKey writes a random key to path unless there is one already.
+func GenerateForwardingKey(path string) error {
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	key := make([]byte, sha256.Size)
+	if _, err := rand.Read(key); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(base64.RawURLEncoding.EncodeToString(key)+"\n"), 0o600)
+}
@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"homework-1/internal/health"
+	"homework-1/internal/tenants"
+)
+
+// UnaryServerInterceptor rejects requests that authenticator does not accept and puts the
+// identity into the handler context. Health checks are served without credentials.
+func UnaryServerInterceptor(authenticator Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if health.IsHealthCheck(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		ctx, err := authenticate(ctx, authenticator, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streaming methods.
+func StreamServerInterceptor(authenticator Authenticator) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if health.IsHealthCheck(info.FullMethod) {
+			return handler(srv, stream)
+		}
+		ctx, err := authenticate(stream.Context(), authenticator, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func authenticate(ctx context.Context, authenticator Authenticator, method string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	identity, err := authenticator.Authenticate(md)
+	if err != nil {
+		log.WithError(err).Infof("%s: authentication failed", method)
+		if errors.Is(err, ErrNoCredentials) {
+			return nil, status.Error(codes.Unauthenticated, ErrNoCredentials.Error())
+		}
+		// the cause stays in the log, the caller only learns that the credentials were rejected
+		return nil, status.Error(codes.Unauthenticated, ErrInvalidCredentials.Error())
+	}
+
+	// a missing or invalid tenant is reported by the tenant interceptor
+	if tenant, err := tenants.FromIncomingContext(ctx); err == nil {
+		if err = identity.CheckTenant(tenant); err != nil {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+	}
+	return NewContext(ctx, identity), nil
+}
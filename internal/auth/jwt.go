@@ -0,0 +1,221 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"google.golang.org/grpc/metadata"
+	"hash"
+	"homework-1/internal/tenants"
+	"os"
+	"strings"
+	"time"
+)
+
+// clockSkew is how far the clocks of the token issuer and the services may drift apart.
+const clockSkew = time.Minute
+
+var algorithms = map[string]func() hash.Hash{
+	"HS256": sha256.New,
+	"HS384": sha512.New384,
+	"HS512": sha512.New,
+}
+
+// Key is an HMAC key of a JSON Web Key Set: {"kty": "oct", "kid": "...", "alg": "HS256", "k": "<base64url secret>"}.
+type Key struct {
+	Type      string `json:"kty"`
+	Id        string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Secret    string `json:"k"`
+}
+
+// KeySet holds the HMAC keys tokens are signed with, a token names its key by the kid header.
+type KeySet struct {
+	keys map[string]*verificationKey
+}
+
+type verificationKey struct {
+	algorithm string
+	secret    []byte
+}
+
+func NewKeySet(keys []Key) (*KeySet, error) {
+	set := &KeySet{keys: make(map[string]*verificationKey, len(keys))}
+	for _, key := range keys {
+		if key.Type != "oct" {
+			return nil, fmt.Errorf("key %s: only symmetric keys, kty oct, are supported", key.Id)
+		}
+		if key.Id == "" {
+			return nil, fmt.Errorf("key without kid")
+		}
+		if _, ok := algorithms[key.Algorithm]; !ok {
+			return nil, fmt.Errorf("key %s: unsupported algorithm %s", key.Id, key.Algorithm)
+		}
+		secret, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(key.Secret, "="))
+		if err != nil {
+			return nil, fmt.Errorf("key %s: secret: %w", key.Id, err)
+		}
+		if len(secret) < sha256.Size {
+			return nil, fmt.Errorf("key %s: secret must be at least %d bytes", key.Id, sha256.Size)
+		}
+		set.keys[key.Id] = &verificationKey{algorithm: key.Algorithm, secret: secret}
+	}
+	return set, nil
+}
+
+// LoadKeySet reads a JSON Web Key Set, {"keys": [...]}.
+func LoadKeySet(path string) (*KeySet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var set struct {
+		Keys []Key `json:"keys"`
+	}
+	if err = json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return NewKeySet(set.Keys)
+}
+
+// Claims are the token claims the services use. Roles and tenant are private claims.
+type Claims struct {
+	Subject   string         `json:"sub"`
+	Issuer    string         `json:"iss"`
+	Audience  audience       `json:"aud"`
+	ExpiresAt int64          `json:"exp"`
+	NotBefore int64          `json:"nbf"`
+	Roles     []string       `json:"roles"`
+	Tenant    tenants.Tenant `json:"tenant"`
+}
+
+// audience is either a single string or an array of strings.
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		return json.Unmarshal(data, (*[]string)(a))
+	}
+	var single string
+	if err := json.Unmarshal(data, &single); err != nil {
+		return err
+	}
+	*a = audience{single}
+	return nil
+}
+
+func (a audience) contains(value string) bool {
+	for _, v := range a {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// JWTVerifier authenticates requests by a bearer token in the authorization metadata.
+// Tokens must be signed by a key of Keys and carry sub and exp claims.
+type JWTVerifier struct {
+	Keys *KeySet
+	// Issuer and Audience are checked when set
+	Issuer   string
+	Audience string
+	// Now replaces the clock in tests
+	Now func() time.Time
+}
+
+func (v *JWTVerifier) Authenticate(md metadata.MD) (*Identity, error) {
+	token, ok := bearerToken(md)
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+	claims, err := v.Verify(token)
+	if err != nil {
+		return nil, err
+	}
+	return &Identity{Subject: claims.Subject, Roles: claims.Roles, Tenant: claims.Tenant}, nil
+}
+
+// Verify checks the signature and the registered claims of a compact JWS token.
+func (v *JWTVerifier) Verify(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidCredentials)
+	}
+
+	var header struct {
+		Algorithm string `json:"alg"`
+		KeyId     string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: header: %v", ErrInvalidCredentials, err)
+	}
+	key, ok := v.Keys.keys[header.KeyId]
+	// the key decides the algorithm, a token can't downgrade it or switch to "none"
+	if !ok || key.algorithm != header.Algorithm {
+		return nil, fmt.Errorf("%w: unknown signing key", ErrInvalidCredentials)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: signature: %v", ErrInvalidCredentials, err)
+	}
+	mac := hmac.New(algorithms[key.algorithm], key.secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, fmt.Errorf("%w: bad signature", ErrInvalidCredentials)
+	}
+
+	var claims Claims
+	if err = decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: claims: %v", ErrInvalidCredentials, err)
+	}
+	if err = v.checkClaims(&claims); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+	}
+	return &claims, nil
+}
+
+func (v *JWTVerifier) checkClaims(claims *Claims) error {
+	now := time.Now()
+	if v.Now != nil {
+		now = v.Now()
+	}
+
+	if claims.Subject == "" {
+		return fmt.Errorf("sub is required")
+	}
+	if claims.ExpiresAt == 0 {
+		return fmt.Errorf("exp is required")
+	}
+	if now.Add(-clockSkew).After(time.Unix(claims.ExpiresAt, 0)) {
+		return fmt.Errorf("token expired")
+	}
+	if claims.NotBefore != 0 && now.Add(clockSkew).Before(time.Unix(claims.NotBefore, 0)) {
+		return fmt.Errorf("token not valid yet")
+	}
+	if v.Issuer != "" && claims.Issuer != v.Issuer {
+		return fmt.Errorf("unexpected issuer %s", claims.Issuer)
+	}
+	if v.Audience != "" && !claims.Audience.contains(v.Audience) {
+		return fmt.Errorf("token is not meant for %s", v.Audience)
+	}
+	if claims.Tenant != "" {
+		if err := tenants.Validate(claims.Tenant); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
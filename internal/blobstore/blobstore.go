@@ -0,0 +1,23 @@
+//go:generate mockgen -source ./blobstore.go -destination=./mock/blobstore.go -package=mock_blobstore
+
+package blobstore
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"io"
+)
+
+var BlobNotExists = errors.New("blob does not exist")
+
+// BlobStore keeps binary content such as product images by key.
+// Keys are slash separated paths like "products/1/3f2a".
+type BlobStore interface {
+	// Put stores the content under the key replacing the previous one and returns its size,
+	// a failed Put leaves no partial content behind.
+	Put(ctx context.Context, key string, content io.Reader) (int64, error)
+	// Get opens the content for reading, the caller closes it.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the content, deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,116 @@
+package local
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"homework-1/internal/blobstore"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var ErrInvalidKey = errors.New("invalid blob key")
+
+// BlobStore keeps blobs as files under the root directory.
+type BlobStore struct {
+	root string
+}
+
+func New(root string) (*BlobStore, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("BlobStore.New: %w", err)
+	}
+	return &BlobStore{root: root}, nil
+}
+
+func (s *BlobStore) Put(ctx context.Context, key string, content io.Reader) (int64, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return 0, err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return 0, fmt.Errorf("BlobStore.Put: mkdir: %w", err)
+	}
+
+	// the content is written next to the blob and renamed, so readers never see a partial blob
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return 0, fmt.Errorf("BlobStore.Put: create: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // no-op after rename
+
+	size, err := io.Copy(tmp, &contextReader{ctx: ctx, r: content})
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, fmt.Errorf("BlobStore.Put: write: %w", err)
+	}
+
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return 0, fmt.Errorf("BlobStore.Put: rename: %w", err)
+	}
+	return size, nil
+}
+
+func (s *BlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, errors.Wrap(blobstore.BlobNotExists, key)
+		}
+		return nil, fmt.Errorf("BlobStore.Get: open: %w", err)
+	}
+	return file, nil
+}
+
+func (s *BlobStore) Delete(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("BlobStore.Delete: remove: %w", err)
+	}
+	return nil
+}
+
+// path maps the key to a file under the root and rejects keys that would escape it.
+func (s *BlobStore) path(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return "", errors.Wrap(ErrInvalidKey, key)
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." || strings.HasPrefix(part, ".") {
+			return "", errors.Wrap(ErrInvalidKey, key)
+		}
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
+
+// contextReader stops reading once the context is done, so a cancelled upload is not stored.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r *contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
@@ -0,0 +1,107 @@
+package local
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"homework-1/internal/blobstore"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPutGet(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// arrange
+		store, err := New(t.TempDir())
+		require.NoError(t, err)
+
+		// act
+		size, err := store.Put(context.Background(), "products/1/image", strings.NewReader("content"))
+		require.NoError(t, err)
+		content, err := store.Get(context.Background(), "products/1/image")
+		require.NoError(t, err)
+		defer content.Close()
+		data, err := io.ReadAll(content)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), size)
+		assert.Equal(t, "content", string(data))
+	})
+
+	t.Run("blob does not exist", func(t *testing.T) {
+		// arrange
+		store, err := New(t.TempDir())
+		require.NoError(t, err)
+
+		// act
+		_, err = store.Get(context.Background(), "products/1/image")
+
+		// assert
+		assert.ErrorIs(t, err, blobstore.BlobNotExists)
+	})
+
+	t.Run("cancelled put leaves nothing behind", func(t *testing.T) {
+		// arrange
+		root := t.TempDir()
+		store, err := New(root)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// act
+		_, err = store.Put(ctx, "products/1/image", strings.NewReader("content"))
+
+		// assert
+		assert.ErrorIs(t, err, context.Canceled)
+		entries, err := os.ReadDir(filepath.Join(root, "products", "1"))
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("key escaping the root", func(t *testing.T) {
+		// arrange
+		store, err := New(t.TempDir())
+		require.NoError(t, err)
+
+		// act
+		_, err = store.Put(context.Background(), "../image", strings.NewReader("content"))
+
+		// assert
+		assert.EqualError(t, err, "../image: invalid blob key")
+	})
+}
+
+func TestDelete(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// arrange
+		store, err := New(t.TempDir())
+		require.NoError(t, err)
+		_, err = store.Put(context.Background(), "products/1/image", strings.NewReader("content"))
+		require.NoError(t, err)
+
+		// act
+		err = store.Delete(context.Background(), "products/1/image")
+
+		// assert
+		require.NoError(t, err)
+		_, err = store.Get(context.Background(), "products/1/image")
+		assert.ErrorIs(t, err, blobstore.BlobNotExists)
+	})
+
+	t.Run("missing blob", func(t *testing.T) {
+		// arrange
+		store, err := New(t.TempDir())
+		require.NoError(t, err)
+
+		// act
+		err = store.Delete(context.Background(), "products/1/image")
+
+		// assert
+		assert.NoError(t, err)
+	})
+}
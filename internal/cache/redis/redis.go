@@ -14,8 +14,8 @@ type Cache struct {
 	metrics *cacheMetrics.Metrics
 }
 
-func New(opts *redis.Options, metrics *cacheMetrics.Metrics) *Cache {
-	client := redis.NewClient(opts)
+// New caches with client, the cache does not close it.
+func New(client *redis.Client, metrics *cacheMetrics.Metrics) *Cache {
 	return &Cache{
 		client:  *client,
 		metrics: metrics,
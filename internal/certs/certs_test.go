@@ -0,0 +1,141 @@
+package certs
+
+import (
+	"context"
+	"crypto/tls"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"homework-1/internal/health"
+	"net"
+	"os"
+	"testing"
+	"time"
+)
+
+func serve(t *testing.T, transport *Transport) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer(transport.ServerOption())
+	health.Register(server)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
+
+func check(address string, option grpc.DialOption) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, address, option)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	return err
+}
+
+func TestTransport(t *testing.T) {
+	files, err := GenerateDevCA(t.TempDir(), []string{"localhost", "127.0.0.1"})
+	require.NoError(t, err)
+	transport, err := NewTransport(true, files, time.Minute)
+	require.NoError(t, err)
+	address := serve(t, transport)
+
+	t.Run("mutual TLS", func(t *testing.T) {
+		assert.NoError(t, check(address, transport.DialOption(address)))
+	})
+
+	t.Run("client without certificate", func(t *testing.T) {
+		// arrange
+		config := &tls.Config{ServerName: "127.0.0.1", RootCAs: transport.reloader.Pool()}
+
+		// act
+		err := check(address, grpc.WithTransportCredentials(credentials.NewTLS(config)))
+
+		// assert
+		assert.Error(t, err)
+	})
+
+	t.Run("server of another CA", func(t *testing.T) {
+		// arrange
+		otherFiles, err := GenerateDevCA(t.TempDir(), []string{"127.0.0.1"})
+		require.NoError(t, err)
+		other, err := NewTransport(true, otherFiles, time.Minute)
+		require.NoError(t, err)
+
+		// act
+		err = check(address, other.DialOption(address))
+
+		// assert
+		assert.Error(t, err)
+	})
+
+	t.Run("plaintext", func(t *testing.T) {
+		// arrange
+		plaintext, err := NewTransport(false, Files{}, time.Minute)
+		require.NoError(t, err)
+		plaintextAddress := serve(t, plaintext)
+
+		// act
+		err = check(plaintextAddress, plaintext.DialOption(plaintextAddress))
+
+		// assert
+		assert.NoError(t, err)
+	})
+}
+
+func TestReloader(t *testing.T) {
+	// arrange
+	dir := t.TempDir()
+	files, err := GenerateDevCA(dir, []string{"localhost"})
+	require.NoError(t, err)
+	reloader, err := NewReloader(files, time.Minute)
+	require.NoError(t, err)
+	now := time.Now()
+	reloader.now = func() time.Time { return now }
+	before := reloader.Certificate()
+
+	_, err = GenerateDevCA(dir, []string{"localhost"})
+	require.NoError(t, err)
+	later := time.Now().Add(time.Hour)
+	for _, path := range []string{files.Cert, files.Key} {
+		require.NoError(t, os.Chtimes(path, later, later))
+	}
+
+	t.Run("within the interval", func(t *testing.T) {
+		assert.Same(t, before, reloader.Certificate())
+	})
+
+	t.Run("after the interval", func(t *testing.T) {
+		// arrange
+		now = now.Add(2 * time.Minute)
+
+		// act
+		after := reloader.Certificate()
+
+		// assert
+		assert.NotEqual(t, before.Certificate, after.Certificate)
+	})
+
+	t.Run("broken file keeps the certificate", func(t *testing.T) {
+		// arrange
+		loaded := reloader.Certificate()
+		require.NoError(t, os.WriteFile(files.Cert, []byte("garbage"), 0644))
+		now = now.Add(2 * time.Minute)
+
+		// act
+		after := reloader.Certificate()
+
+		// assert
+		assert.Same(t, loaded, after)
+	})
+}
+
+func TestServerName(t *testing.T) {
+	assert.Equal(t, "localhost", serverName(":8080"))
+	assert.Equal(t, "storage", serverName("storage:8080"))
+	assert.Equal(t, "127.0.0.1", serverName("127.0.0.1:8080"))
+}
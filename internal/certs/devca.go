@@ -0,0 +1,164 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	devCAValidity      = 10 * 365 * 24 * time.Hour
+	devServiceValidity = 365 * 24 * time.Hour
+)
+
+// DevFiles are the files GenerateDevCA writes to dir.
+func DevFiles(dir string) Files {
+	return Files{
+		CA:   filepath.Join(dir, "ca.crt"),
+		Cert: filepath.Join(dir, "service.crt"),
+		Key:  filepath.Join(dir, "service.key"),
+	}
+}
+
+// GenerateDevCA writes a CA for local development to dir and issues a certificate for hosts,
+// which every service uses both as a server and as a client. An existing CA is kept, so running
+// it again only renews the service certificate and the running services reload it.
+// Not meant for production, the CA key lies next to the certificates.
+func GenerateDevCA(dir string, hosts []string) (Files, error) {
+	files := DevFiles(dir)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return files, err
+	}
+
+	caKeyPath := filepath.Join(dir, "ca.key")
+	caCert, caKey, err := loadDevCA(files.CA, caKeyPath)
+	if errors.Is(err, os.ErrNotExist) {
+		caCert, caKey, err = createDevCA(files.CA, caKeyPath)
+	}
+	if err != nil {
+		return files, err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return files, err
+	}
+	template, err := newTemplate("homework-1 service", devServiceValidity)
+	if err != nil {
+		return files, err
+	}
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+	if err != nil {
+		return files, err
+	}
+
+	// the key goes first, a reload between the writes then fails and keeps the old pair
+	if err = writeKey(files.Key, key); err != nil {
+		return files, err
+	}
+	return files, writePEM(files.Cert, "CERTIFICATE", der, 0644)
+}
+
+func createDevCA(certPath string, keyPath string) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	template, err := newTemplate("homework-1 dev CA", devCAValidity)
+	if err != nil {
+		return nil, nil, err
+	}
+	template.IsCA = true
+	template.BasicConstraintsValid = true
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = writeKey(keyPath, key); err != nil {
+		return nil, nil, err
+	}
+	return cert, key, writePEM(certPath, "CERTIFICATE", der, 0644)
+}
+
+func loadDevCA(certPath string, keyPath string) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	certDER, err := readPEM(certPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	cert, err := x509.ParseCertificate(certDER)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", certPath, err)
+	}
+	keyDER, err := readPEM(keyPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := x509.ParseECPrivateKey(keyDER)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", keyPath, err)
+	}
+	return cert, key, nil
+}
+
+func newTemplate(commonName string, validity time.Duration) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(validity),
+	}, nil
+}
+
+func readPEM(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: %w", path, errNoCertificates)
+	}
+	return block.Bytes, nil
+}
+
+func writeKey(path string, key *ecdsa.PrivateKey) error {
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return err
+	}
+	return writePEM(path, "EC PRIVATE KEY", der, 0600)
+}
+
+func writePEM(path string, blockType string, der []byte, perm os.FileMode) error {
+	return os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), perm)
+}
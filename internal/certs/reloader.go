@@ -0,0 +1,187 @@
+// Package certs secures the gRPC hops between the services with mutual TLS. Certificates
+// are read from files and reloaded when the files change, so they rotate without a restart.
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"os"
+	"sync"
+	"time"
+)
+
+// Files are the PEM files of a service: the CA that signs every service certificate and the
+// certificate and key the service presents, both as a server and as a client.
+type Files struct {
+	CA   string
+	Cert string
+	Key  string
+}
+
+// Reloader keeps the certificate and the CA pool loaded from Files. The files are checked for
+// changes at most once per interval, during handshakes, and a broken change keeps the old ones.
+type Reloader struct {
+	files    Files
+	interval time.Duration
+	now      func() time.Time
+
+	mu       sync.RWMutex
+	cert     *tls.Certificate
+	pool     *x509.CertPool
+	modTimes [3]time.Time
+	checked  time.Time
+}
+
+func NewReloader(files Files, interval time.Duration) (*Reloader, error) {
+	r := &Reloader{files: files, interval: interval, now: time.Now}
+	modTimes, err := r.statFiles()
+	if err != nil {
+		return nil, err
+	}
+	if err = r.load(modTimes); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Certificate returns the current certificate of the service.
+func (r *Reloader) Certificate() *tls.Certificate {
+	r.reloadIfChanged()
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert
+}
+
+// Pool returns the current CA pool peers are verified against.
+func (r *Reloader) Pool() *x509.CertPool {
+	r.reloadIfChanged()
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.pool
+}
+
+func (r *Reloader) reloadIfChanged() {
+	now := r.now()
+	r.mu.RLock()
+	due := now.Sub(r.checked) >= r.interval
+	current := r.modTimes
+	r.mu.RUnlock()
+	if !due {
+		return
+	}
+
+	modTimes, err := r.statFiles()
+	if err == nil && changed(current, modTimes) {
+		err = r.load(modTimes)
+		if err == nil {
+			log.Infof("certs: reloaded %s", r.files.Cert)
+		}
+	}
+	if err != nil {
+		log.WithError(err).Error("certs: reload failed, keeping the loaded certificates")
+	}
+
+	r.mu.Lock()
+	r.checked = now
+	r.mu.Unlock()
+}
+
+func (r *Reloader) statFiles() ([3]time.Time, error) {
+	var modTimes [3]time.Time
+	for i, path := range []string{r.files.CA, r.files.Cert, r.files.Key} {
+		info, err := os.Stat(path)
+		if err != nil {
+			return modTimes, err
+		}
+		modTimes[i] = info.ModTime()
+	}
+	return modTimes, nil
+}
+
+func changed(before [3]time.Time, after [3]time.Time) bool {
+	for i := range before {
+		if !before[i].Equal(after[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Reloader) load(modTimes [3]time.Time) error {
+	cert, err := tls.LoadX509KeyPair(r.files.Cert, r.files.Key)
+	if err != nil {
+		return fmt.Errorf("certs: %w", err)
+	}
+	caPEM, err := os.ReadFile(r.files.CA)
+	if err != nil {
+		return fmt.Errorf("certs: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return fmt.Errorf("certs: %s: %w", r.files.CA, errNoCertificates)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.pool = pool
+	r.modTimes = modTimes
+	return nil
+}
+
+var errNoCertificates = errors.New("no PEM certificates")
+
+// ServerConfig requires clients to present a certificate signed by the CA.
+func (r *Reloader) ServerConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// a config per handshake picks up reloaded certificates and CAs
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				NextProtos:   []string{"h2"},
+				Certificates: []tls.Certificate{*r.Certificate()},
+				ClientCAs:    r.Pool(),
+				ClientAuth:   tls.RequireAndVerifyClientCert,
+			}, nil
+		},
+	}
+}
+
+// ClientConfig presents the service certificate and verifies that the server holds a
+// certificate for serverName signed by the CA.
+func (r *Reloader) ClientConfig(serverName string) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: serverName,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return r.Certificate(), nil
+		},
+		// the standard verification would pin the CA pool of the time the config was made,
+		// VerifyConnection does the same checks against the current pool instead
+		InsecureSkipVerify: true,
+		VerifyConnection: func(state tls.ConnectionState) error {
+			return r.verifyServer(state, serverName)
+		},
+	}
+}
+
+func (r *Reloader) verifyServer(state tls.ConnectionState, serverName string) error {
+	if len(state.PeerCertificates) == 0 {
+		return errors.New("certs: server presented no certificate")
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+		DNSName:       serverName,
+		Roots:         r.Pool(),
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	return err
+}
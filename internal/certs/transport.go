@@ -0,0 +1,60 @@
+package certs
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"net"
+	"time"
+)
+
+// defaultServerName is verified for addresses without a host, e.g. ":8080".
+const defaultServerName = "localhost"
+
+// Transport picks the transport credentials of gRPC servers and clients: mutual TLS with the
+// certificates of the reloader, or plaintext when TLS is disabled.
+type Transport struct {
+	reloader *Reloader
+}
+
+// NewTransport loads the certificates of files when enabled.
+func NewTransport(enabled bool, files Files, reloadInterval time.Duration) (*Transport, error) {
+	if !enabled {
+		return &Transport{}, nil
+	}
+	reloader, err := NewReloader(files, reloadInterval)
+	if err != nil {
+		return nil, err
+	}
+	return &Transport{reloader: reloader}, nil
+}
+
+func (t *Transport) Enabled() bool {
+	return t.reloader != nil
+}
+
+func (t *Transport) ServerOption() grpc.ServerOption {
+	if !t.Enabled() {
+		return grpc.Creds(insecure.NewCredentials())
+	}
+	return grpc.Creds(credentials.NewTLS(t.reloader.ServerConfig()))
+}
+
+// DialOption secures the connection to address, the server must hold a certificate for its host.
+func (t *Transport) DialOption(address string) grpc.DialOption {
+	if !t.Enabled() {
+		return grpc.WithTransportCredentials(insecure.NewCredentials())
+	}
+	return grpc.WithTransportCredentials(credentials.NewTLS(t.reloader.ClientConfig(serverName(address))))
+}
+
+func serverName(address string) string {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		host = address
+	}
+	if host == "" {
+		return defaultServerName
+	}
+	return host
+}
@@ -6,13 +6,43 @@ import (
 	"github.com/pkg/errors"
 	"homework-1/internal/repository"
 	"log"
+	"sort"
+	"strings"
 )
 
 type CmdHandler func(repository.Product, string) string
 
+// MessageHandler handles a command that needs the whole message, e.g. to know who sent it.
+type MessageHandler func(repository.Product, *tgbotapi.Message) tgbotapi.MessageConfig
+
+// CallbackHandler handles an inline keyboard button press. The data is the callback data
+// without the "<prefix>:" part the handler was registered with.
+type CallbackHandler func(*tgbotapi.CallbackQuery, string) string
+
+// Access decides who may use the bot. Authorize returns nil to run the command for the user,
+// otherwise an error with the refusal to answer. An empty command asks whether the user may
+// use the bot at all, e.g. for messages without a registered command.
+type Access interface {
+	Authorize(user *tgbotapi.User, command string) error
+}
+
+// errNoAccess refuses every update of a commander without Access, a bot must not be open to
+// whoever finds it because the access control was not wired.
+var errNoAccess = errors.New("Sorry, this bot is not open to anyone yet.")
+
+// botAPI is the part of tgbotapi.BotAPI the commander talks to.
+type botAPI interface {
+	GetUpdatesChan(config tgbotapi.UpdateConfig) tgbotapi.UpdatesChannel
+	Send(c tgbotapi.Chattable) (tgbotapi.Message, error)
+	Request(c tgbotapi.Chattable) (*tgbotapi.APIResponse, error)
+}
+
 type Commander struct {
-	bot               *tgbotapi.BotAPI
+	bot               botAPI
 	router            map[string]CmdHandler
+	messageRouter     map[string]MessageHandler
+	callbackRouter    map[string]CallbackHandler
+	access            Access
 	ProductRepository repository.Product
 }
 
@@ -26,11 +56,18 @@ func Init(tgApiKey string, repository repository.Product) (*Commander, error) {
 
 	log.Printf("Authorized on account: %s", bot.Self.UserName)
 
+	return New(bot, repository), nil
+}
+
+// New is Init for a bot that is already connected.
+func New(bot *tgbotapi.BotAPI, repository repository.Product) *Commander {
 	return &Commander{
 		bot:               bot,
 		router:            make(map[string]CmdHandler),
+		messageRouter:     make(map[string]MessageHandler),
+		callbackRouter:    make(map[string]CallbackHandler),
 		ProductRepository: repository,
-	}, nil
+	}
 }
 
 func (c *Commander) Run() error {
@@ -40,14 +77,25 @@ func (c *Commander) Run() error {
 	updates := c.bot.GetUpdatesChan(u)
 
 	for update := range updates {
+		if update.CallbackQuery != nil {
+			if err := c.handleCallback(update.CallbackQuery); err != nil {
+				return err
+			}
+			continue
+		}
+
 		if update.Message == nil {
 			continue
 		}
 
 		msg := tgbotapi.NewMessage(update.Message.Chat.ID, update.Message.Text)
-		if update.Message.Command() != "" {
+		if err := c.authorize(update.Message.From, c.registeredCommand(update.Message.Command())); err != nil {
+			msg.Text = err.Error()
+		} else if update.Message.Command() != "" {
 			if cmd, ok := c.router[update.Message.Command()]; ok {
 				msg.Text = cmd(c.ProductRepository, update.Message.CommandArguments())
+			} else if handler, ok := c.messageRouter[update.Message.Command()]; ok {
+				msg = handler(c.ProductRepository, update.Message)
 			} else {
 				msg.Text = fmt.Sprintf("Invalid command: %v", update.Message.Command())
 			}
@@ -63,6 +111,97 @@ func (c *Commander) Run() error {
 	return nil
 }
 
+// Notify sends a message to the chat outside of any update, e.g. for alerts.
+func (c *Commander) Notify(chatId int64, text string) error {
+	if _, err := c.bot.Send(tgbotapi.NewMessage(chatId, text)); err != nil {
+		return errors.Wrap(err, "failed to send message")
+	}
+	return nil
+}
+
+// SetAccess checks every update against access before it is handled, without one every
+// update is refused.
+func (c *Commander) SetAccess(access Access) {
+	c.access = access
+}
+
+func (c *Commander) authorize(user *tgbotapi.User, command string) error {
+	if c.access == nil {
+		return errNoAccess
+	}
+	return c.access.Authorize(user, command)
+}
+
+// registeredCommand is the command if it has a handler, unknown commands are answered to
+// everyone who may use the bot.
+func (c *Commander) registeredCommand(command string) string {
+	if _, ok := c.router[command]; ok {
+		return command
+	}
+	if _, ok := c.messageRouter[command]; ok {
+		return command
+	}
+	return ""
+}
+
+// Commands lists the registered commands in alphabetical order.
+func (c *Commander) Commands() []string {
+	commands := make([]string, 0, len(c.router)+len(c.messageRouter))
+	for command := range c.router {
+		commands = append(commands, command)
+	}
+	for command := range c.messageRouter {
+		commands = append(commands, command)
+	}
+	sort.Strings(commands)
+	return commands
+}
+
+// Actions lists the prefixes of the registered callback handlers in alphabetical order.
+func (c *Commander) Actions() []string {
+	actions := make([]string, 0, len(c.callbackRouter))
+	for prefix := range c.callbackRouter {
+		actions = append(actions, prefix)
+	}
+	sort.Strings(actions)
+	return actions
+}
+
 func (c *Commander) RegisterHandler(cmd string, handler CmdHandler) {
 	c.router[cmd] = handler
 }
+
+func (c *Commander) RegisterMessageHandler(cmd string, handler MessageHandler) {
+	c.messageRouter[cmd] = handler
+}
+
+func (c *Commander) RegisterCallbackHandler(prefix string, handler CallbackHandler) {
+	c.callbackRouter[prefix] = handler
+}
+
+func (c *Commander) handleCallback(query *tgbotapi.CallbackQuery) error {
+	prefix, data, _ := strings.Cut(query.Data, ":")
+
+	text := fmt.Sprintf("Invalid action: %v", prefix)
+	handler, ok := c.callbackRouter[prefix]
+	if !ok {
+		prefix = ""
+	}
+	if err := c.authorize(query.From, prefix); err != nil {
+		text = err.Error()
+	} else if ok {
+		text = handler(query, data)
+	}
+
+	if _, err := c.bot.Request(tgbotapi.NewCallback(query.ID, text)); err != nil {
+		return errors.Wrap(err, "failed to answer callback")
+	}
+
+	if query.Message == nil {
+		return nil
+	}
+	if _, err := c.bot.Send(tgbotapi.NewMessage(query.Message.Chat.ID, text)); err != nil {
+		return errors.Wrap(err, "failed to send message")
+	}
+	return nil
+}
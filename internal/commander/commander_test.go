@@ -0,0 +1,245 @@
+package commander
+
+import (
+	"errors"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"homework-1/internal/repository"
+	"testing"
+)
+
+const (
+	editorId int64 = iota + 1
+	strangerId
+)
+
+// fakeBot replays updates and keeps what the commander sends.
+type fakeBot struct {
+	updates  []tgbotapi.Update
+	sent     []tgbotapi.MessageConfig
+	answered []tgbotapi.CallbackConfig
+}
+
+func (b *fakeBot) GetUpdatesChan(tgbotapi.UpdateConfig) tgbotapi.UpdatesChannel {
+	ch := make(chan tgbotapi.Update, len(b.updates))
+	for _, update := range b.updates {
+		ch <- update
+	}
+	close(ch)
+	return ch
+}
+
+func (b *fakeBot) Send(c tgbotapi.Chattable) (tgbotapi.Message, error) {
+	b.sent = append(b.sent, c.(tgbotapi.MessageConfig))
+	return tgbotapi.Message{}, nil
+}
+
+func (b *fakeBot) Request(c tgbotapi.Chattable) (*tgbotapi.APIResponse, error) {
+	b.answered = append(b.answered, c.(tgbotapi.CallbackConfig))
+	return &tgbotapi.APIResponse{Ok: true}, nil
+}
+
+// fakeAccess lets the editor run add and approve, an empty command asks for the bot itself.
+type fakeAccess struct {
+	commands []string
+}
+
+func (a *fakeAccess) Authorize(user *tgbotapi.User, command string) error {
+	a.commands = append(a.commands, command)
+	if user == nil || user.ID != editorId {
+		return errors.New("denied")
+	}
+	if command != "" && command != "add" && command != "approve" {
+		return errors.New("denied /" + command)
+	}
+	return nil
+}
+
+func newTestCommander(bot *fakeBot, access Access) *Commander {
+	c := New(nil, nil)
+	c.bot = bot
+	c.SetAccess(access)
+	c.RegisterHandler("add", func(_ repository.Product, args string) string {
+		return "added " + args
+	})
+	c.RegisterMessageHandler("delete", func(_ repository.Product, message *tgbotapi.Message) tgbotapi.MessageConfig {
+		return tgbotapi.NewMessage(message.Chat.ID, "deleted")
+	})
+	c.RegisterCallbackHandler("approve", func(_ *tgbotapi.CallbackQuery, data string) string {
+		return "approved " + data
+	})
+	c.RegisterCallbackHandler("reject", func(_ *tgbotapi.CallbackQuery, data string) string {
+		return "rejected " + data
+	})
+	return c
+}
+
+func commandMessage(userId int64, text string, command string) *tgbotapi.Message {
+	message := &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: 10}, Text: text}
+	if userId != 0 {
+		message.From = &tgbotapi.User{ID: userId}
+	}
+	if command != "" {
+		message.Entities = []tgbotapi.MessageEntity{{Type: "bot_command", Offset: 0, Length: len(command) + 1}}
+	}
+	return message
+}
+
+func TestRun(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		message *tgbotapi.Message
+		// access is nil for a bot without access control
+		access       Access
+		want         string
+		wantCommands []string
+	}{
+		{
+			name:         "allowed command",
+			message:      commandMessage(editorId, "/add pillow", "add"),
+			access:       &fakeAccess{},
+			want:         "added pillow",
+			wantCommands: []string{"add"},
+		},
+		{
+			name:         "denied command",
+			message:      commandMessage(editorId, "/delete 1", "delete"),
+			access:       &fakeAccess{},
+			want:         "denied /delete",
+			wantCommands: []string{"delete"},
+		},
+		{
+			name:         "stranger",
+			message:      commandMessage(strangerId, "/add pillow", "add"),
+			access:       &fakeAccess{},
+			want:         "denied",
+			wantCommands: []string{"add"},
+		},
+		{
+			name:         "unknown command is checked as the bot itself",
+			message:      commandMessage(editorId, "/purge", "purge"),
+			access:       &fakeAccess{},
+			want:         "Invalid command: purge",
+			wantCommands: []string{""},
+		},
+		{
+			name:         "unknown command of a stranger",
+			message:      commandMessage(strangerId, "/purge", "purge"),
+			access:       &fakeAccess{},
+			want:         "denied",
+			wantCommands: []string{""},
+		},
+		{
+			name:         "text is echoed to users",
+			message:      commandMessage(editorId, "hello", ""),
+			access:       &fakeAccess{},
+			want:         "hello",
+			wantCommands: []string{""},
+		},
+		{
+			name:         "message without a sender",
+			message:      commandMessage(0, "/add pillow", "add"),
+			access:       &fakeAccess{},
+			want:         "denied",
+			wantCommands: []string{"add"},
+		},
+		{
+			name:    "no access control",
+			message: commandMessage(editorId, "/add pillow", "add"),
+			want:    "Sorry, this bot is not open to anyone yet.",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// arrange
+			bot := &fakeBot{updates: []tgbotapi.Update{{Message: tc.message}}}
+			c := newTestCommander(bot, tc.access)
+
+			// act
+			err := c.Run()
+
+			// assert
+			require.NoError(t, err)
+			require.Len(t, bot.sent, 1)
+			assert.Equal(t, int64(10), bot.sent[0].ChatID)
+			assert.Equal(t, tc.want, bot.sent[0].Text)
+			if access, ok := tc.access.(*fakeAccess); ok {
+				assert.Equal(t, tc.wantCommands, access.commands)
+			}
+		})
+	}
+}
+
+func TestHandleCallback(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		from *tgbotapi.User
+		data string
+		// inline is true for buttons of inline messages, which have no chat to answer in
+		inline      bool
+		want        string
+		wantCommand string
+	}{
+		{name: "allowed action", from: &tgbotapi.User{ID: editorId}, data: "approve:7", want: "approved 7", wantCommand: "approve"},
+		{name: "denied action", from: &tgbotapi.User{ID: editorId}, data: "reject:7", want: "denied /reject", wantCommand: "reject"},
+		{name: "stranger", from: &tgbotapi.User{ID: strangerId}, data: "approve:7", want: "denied", wantCommand: "approve"},
+		{name: "no sender", data: "approve:7", want: "denied", wantCommand: "approve"},
+		{name: "unknown action", from: &tgbotapi.User{ID: editorId}, data: "purge:7", want: "Invalid action: purge"},
+		{name: "unknown action of a stranger", from: &tgbotapi.User{ID: strangerId}, data: "purge:7", want: "denied"},
+		{name: "inline message", from: &tgbotapi.User{ID: editorId}, data: "approve:7", inline: true, want: "approved 7", wantCommand: "approve"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// arrange
+			bot := &fakeBot{}
+			access := &fakeAccess{}
+			c := newTestCommander(bot, access)
+			query := &tgbotapi.CallbackQuery{ID: "q1", From: tc.from, Data: tc.data}
+			if !tc.inline {
+				query.Message = &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: 10}}
+			}
+
+			// act
+			err := c.handleCallback(query)
+
+			// assert
+			require.NoError(t, err)
+			assert.Equal(t, []string{tc.wantCommand}, access.commands)
+			require.Len(t, bot.answered, 1)
+			assert.Equal(t, "q1", bot.answered[0].CallbackQueryID)
+			assert.Equal(t, tc.want, bot.answered[0].Text)
+			if tc.inline {
+				assert.Empty(t, bot.sent)
+			} else {
+				require.Len(t, bot.sent, 1)
+				assert.Equal(t, tc.want, bot.sent[0].Text)
+			}
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	t.Run("without access everyone is denied", func(t *testing.T) {
+		// arrange
+		c := New(nil, nil)
+
+		// act
+		err := c.authorize(&tgbotapi.User{ID: editorId}, "add")
+
+		// assert
+		assert.ErrorIs(t, err, errNoAccess)
+	})
+
+	t.Run("access decides", func(t *testing.T) {
+		// arrange
+		c := New(nil, nil)
+		c.SetAccess(&fakeAccess{})
+
+		// act
+		allowed := c.authorize(&tgbotapi.User{ID: editorId}, "add")
+		denied := c.authorize(&tgbotapi.User{ID: editorId}, "delete")
+
+		// assert
+		assert.NoError(t, allowed)
+		assert.EqualError(t, denied, "denied /delete")
+	})
+}
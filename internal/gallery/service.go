@@ -0,0 +1,117 @@
+package gallery
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"homework-1/internal/blobstore"
+	"homework-1/internal/models/images"
+	"homework-1/internal/models/relations"
+	"homework-1/internal/repository"
+	"io"
+	"time"
+)
+
+// cleanupTimeout bounds removing blobs, which must not depend on the request context
+// that may already be cancelled.
+const cleanupTimeout = time.Second * 5
+
+// Service keeps product images: the content goes to the blob store and the metadata to the repository.
+// It also deletes products, since a product can't be deleted without its images.
+type Service struct {
+	ProductRepository  repository.Product
+	ImageRepository    repository.Image
+	BlobStore          blobstore.BlobStore
+	RelationRepository repository.Relation
+	// RelationPolicy decides whether deleting a product deletes its relations or is refused
+	RelationPolicy relations.DeletePolicy
+}
+
+// Upload stores the content as a new image of the product. The content is checked against
+// images.MaxSize while it is stored, the blob is removed when the image is rejected.
+func (s *Service) Upload(ctx context.Context, image images.Image, content io.Reader) (*images.Image, error) {
+	hash := sha256.New()
+	limited := io.TeeReader(io.LimitReader(content, images.MaxSize+1), hash)
+
+	size, err := s.BlobStore.Put(ctx, image.Key, limited)
+	if err != nil {
+		return nil, fmt.Errorf("Service.Upload: put: %w", err)
+	}
+
+	if err = images.ValidateSize(uint64(size)); err != nil {
+		s.removeBlobs(image.Key)
+		return nil, err
+	}
+
+	image.Size = uint64(size)
+	image.Checksum = hex.EncodeToString(hash.Sum(nil))
+
+	saved, err := s.ImageRepository.AddImage(ctx, image)
+	if err != nil {
+		s.removeBlobs(image.Key)
+		return nil, err
+	}
+	return saved, nil
+}
+
+// Open returns the product image and its content, the caller closes the content.
+func (s *Service) Open(ctx context.Context, productId uint64, id uint64) (*images.Image, io.ReadCloser, error) {
+	image, err := s.ImageRepository.GetImageById(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if image.ProductId != productId {
+		return nil, nil, errors.Wrapf(repository.ImageNotExists, "%d", id)
+	}
+
+	content, err := s.BlobStore.Get(ctx, image.Key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Service.Open: get: %w", err)
+	}
+	return image, content, nil
+}
+
+// DeleteProduct purges the product with its images. The blobs are removed after the product is
+// deleted, a blob that fails to be removed is logged and left behind rather than failing the purge.
+// With the restrict relation policy a product that takes part in relations is not deleted.
+func (s *Service) DeleteProduct(ctx context.Context, id uint64) error {
+	if s.RelationPolicy == relations.DeleteRestrict {
+		count, err := s.RelationRepository.CountProductRelations(ctx, id)
+		if err != nil {
+			return fmt.Errorf("Service.DeleteProduct: count relations: %w", err)
+		}
+		if count > 0 {
+			return errors.Wrapf(repository.ProductHasRelations, "%d", id)
+		}
+	}
+
+	productImages, err := s.ImageRepository.GetProductImages(ctx, id)
+	if err != nil {
+		return fmt.Errorf("Service.DeleteProduct: get images: %w", err)
+	}
+
+	if err = s.ProductRepository.DeleteProduct(ctx, id); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(productImages))
+	for _, image := range productImages {
+		keys = append(keys, image.Key)
+	}
+	s.removeBlobs(keys...)
+	return nil
+}
+
+func (s *Service) removeBlobs(keys ...string) {
+	ctx, cancel := context.WithTimeout(context.Background(), cleanupTimeout)
+	defer cancel()
+
+	for _, key := range keys {
+		if err := s.BlobStore.Delete(ctx, key); err != nil {
+			log.WithError(err).Errorf("ImageService: remove blob %s", key)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package gallery
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"homework-1/internal/blobstore"
+	localBlobStore "homework-1/internal/blobstore/local"
+	"homework-1/internal/models/images"
+	"homework-1/internal/models/products"
+	"homework-1/internal/models/relations"
+	"homework-1/internal/repository"
+	localRepository "homework-1/internal/repository/local"
+	"homework-1/internal/tenants"
+	"io"
+	"strings"
+	"testing"
+)
+
+type serviceFixture struct {
+	service   *Service
+	repo      *localRepository.Repository
+	blobStore blobstore.BlobStore
+	productId uint64
+	ctx       context.Context
+}
+
+func SetUp(t *testing.T) *serviceFixture {
+	repo := localRepository.NewRepository(localRepository.NewWarehouse())
+	blobStore, err := localBlobStore.New(t.TempDir())
+	require.NoError(t, err)
+
+	f := serviceFixture{repo: repo, blobStore: blobStore, ctx: tenants.NewContext(context.Background(), "shop")}
+	f.service = &Service{
+		ProductRepository:  repo,
+		ImageRepository:    repo,
+		BlobStore:          blobStore,
+		RelationRepository: repo,
+		RelationPolicy:     relations.DeleteCascade,
+	}
+
+	f.productId = f.createProduct(t)
+	return &f
+}
+
+func (f *serviceFixture) createProduct(t *testing.T) uint64 {
+	product, err := f.repo.CreateProduct(f.ctx, products.Product{
+		Name:     "product",
+		Price:    uint64(1),
+		Quantity: uint64(1),
+		Status:   products.StatusActive,
+	})
+	require.NoError(t, err)
+	return product.GetId()
+}
+
+func (f *serviceFixture) upload(t *testing.T, content string) *images.Image {
+	image, err := images.NewImage(f.productId, "image/png")
+	require.NoError(t, err)
+
+	saved, err := f.service.Upload(f.ctx, *image, strings.NewReader(content))
+	require.NoError(t, err)
+	return saved
+}
+
+func TestUpload(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		// act
+		image := f.upload(t, "content")
+
+		// assert
+		assert.Equal(t, uint64(7), image.Size)
+		assert.Equal(t, "ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73", image.Checksum)
+
+		_, content, err := f.service.Open(f.ctx, f.productId, image.Id)
+		require.NoError(t, err)
+		defer content.Close()
+		data, err := io.ReadAll(content)
+		require.NoError(t, err)
+		assert.Equal(t, "content", string(data))
+	})
+
+	t.Run("too large image is removed", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		image, err := images.NewImage(f.productId, "image/png")
+		require.NoError(t, err)
+
+		// act
+		_, err = f.service.Upload(f.ctx, *image, bytes.NewReader(make([]byte, images.MaxSize+1)))
+
+		// assert
+		assert.ErrorIs(t, err, images.ErrImageTooLarge)
+		_, err = f.blobStore.Get(f.ctx, image.Key)
+		assert.ErrorIs(t, err, blobstore.BlobNotExists)
+	})
+
+	t.Run("product does not exist", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		image, err := images.NewImage(f.productId+1, "image/png")
+		require.NoError(t, err)
+
+		// act
+		_, err = f.service.Upload(f.ctx, *image, strings.NewReader("content"))
+
+		// assert
+		assert.ErrorIs(t, err, repository.ProductNotExists)
+		_, err = f.blobStore.Get(f.ctx, image.Key)
+		assert.ErrorIs(t, err, blobstore.BlobNotExists)
+	})
+}
+
+func TestOpen(t *testing.T) {
+	t.Run("image of another product", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		image := f.upload(t, "content")
+
+		// act
+		_, _, err := f.service.Open(f.ctx, f.productId+1, image.Id)
+
+		// assert
+		assert.ErrorIs(t, err, repository.ImageNotExists)
+	})
+}
+
+func TestDeleteProduct(t *testing.T) {
+	t.Run("purges images with blobs", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		image := f.upload(t, "content")
+
+		// act
+		err := f.service.DeleteProduct(f.ctx, f.productId)
+
+		// assert
+		require.NoError(t, err)
+		_, err = f.repo.GetImageById(f.ctx, image.Id)
+		assert.ErrorIs(t, err, repository.ImageNotExists)
+		_, err = f.blobStore.Get(f.ctx, image.Key)
+		assert.ErrorIs(t, err, blobstore.BlobNotExists)
+	})
+
+	t.Run("product does not exist", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		// act
+		err := f.service.DeleteProduct(f.ctx, f.productId+1)
+
+		// assert
+		assert.ErrorIs(t, err, repository.ProductNotExists)
+	})
+
+	t.Run("cascade policy deletes relations", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		related := f.createProduct(t)
+		_, err := f.repo.CreateRelation(f.ctx, relations.Relation{
+			ProductId: related,
+			RelatedId: f.productId,
+			Type:      relations.TypeReplacement,
+		})
+		require.NoError(t, err)
+
+		// act
+		err = f.service.DeleteProduct(f.ctx, f.productId)
+
+		// assert
+		require.NoError(t, err)
+		count, err := f.repo.CountProductRelations(f.ctx, related)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), count)
+	})
+
+	t.Run("restrict policy refuses products with relations", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		f.service.RelationPolicy = relations.DeleteRestrict
+		related := f.createProduct(t)
+		_, err := f.repo.CreateRelation(f.ctx, relations.Relation{
+			ProductId: related,
+			RelatedId: f.productId,
+			Type:      relations.TypeReplacement,
+		})
+		require.NoError(t, err)
+
+		// act
+		err = f.service.DeleteProduct(f.ctx, f.productId)
+
+		// assert
+		assert.ErrorIs(t, err, repository.ProductHasRelations)
+		_, err = f.repo.GetProductById(f.ctx, f.productId)
+		assert.NoError(t, err)
+	})
+}
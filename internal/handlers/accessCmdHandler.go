@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"fmt"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"homework-1/internal/commander"
+	"homework-1/internal/models/grants"
+	"homework-1/internal/policy"
+	"homework-1/internal/repository"
+	"strconv"
+	"strings"
+)
+
+// access lets the users listed in the config or granted a role from the bot run the commands
+// their roles allow, everyone else gets a polite refusal.
+type access struct {
+	userRoles *policy.UserRoles
+	grants    repository.Grant
+}
+
+func newAccess(userRoles *policy.UserRoles, grants repository.Grant) *access {
+	return &access{userRoles: userRoles, grants: grants}
+}
+
+func (a *access) Authorize(user *tgbotapi.User, command string) error {
+	if user == nil {
+		return errors.New("Sorry, this bot only answers Telegram users.")
+	}
+
+	roles, err := a.roles(user.ID)
+	if err != nil {
+		log.Errorf("failed to get roles of telegram user %d: %v", user.ID, err)
+		return errors.New("Sorry, access can't be checked right now, please try again later.")
+	}
+	if len(roles) == 0 {
+		return errors.Errorf("Sorry, this bot is only open to invited users. Ask an admin to grant access to your user id %d.", user.ID)
+	}
+	if command == "" {
+		return nil
+	}
+
+	permission, ok := commandPermissions[command]
+	if !ok || !policy.Allowed(roles, permission) {
+		return errors.Errorf("Sorry, /%s needs the %s permission, which your roles %v don't include.", command, permissionName(permission), roles)
+	}
+	return nil
+}
+
+// roles are the roles from the config together with the granted one.
+func (a *access) roles(userId int64) ([]string, error) {
+	var roles []string
+	if a.userRoles != nil {
+		roles = a.userRoles.Roles(userId)
+	}
+	if a.grants == nil {
+		return roles, nil
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	grant, err := a.grants.GetGrant(ctx, userId)
+	if errors.Is(err, repository.GrantNotExists) {
+		return roles, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return append(roles, string(grant.Role)), nil
+}
+
+func permissionName(permission policy.Permission) string {
+	if permission == "" {
+		return "unknown"
+	}
+	return string(permission)
+}
+
+func newGrantCmdHandler(deps Deps) commander.MessageHandler {
+	return func(_ repository.Product, message *tgbotapi.Message) tgbotapi.MessageConfig {
+		return tgbotapi.NewMessage(message.Chat.ID, grantCmdHandler(deps.GrantRepository, message.CommandArguments(), actor(message.From)))
+	}
+}
+
+func grantCmdHandler(repository repository.Grant, cmdArgs string, grantedBy string) string {
+	ctx, cancel := newContext()
+	defer cancel()
+
+	args := strings.Split(cmdArgs, " ")
+	if len(args) != 2 {
+		return errors.Wrapf(BadArguments, "Invalid arguments count: %d", len(args)).Error()
+	}
+
+	userId, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return errors.Wrapf(BadArguments, "Can't parse user id: %s", args[0]).Error()
+	}
+
+	grant, err := grants.NewGrant(userId, args[1], grantedBy)
+	if err != nil {
+		return err.Error()
+	}
+
+	grant, err = repository.SetGrant(ctx, *grant)
+	if err != nil {
+		return err.Error()
+	}
+	return fmt.Sprintf("User %d granted the %s role", grant.UserId, grant.Role)
+}
+
+func newRevokeCmdHandler(deps Deps) commander.MessageHandler {
+	return func(_ repository.Product, message *tgbotapi.Message) tgbotapi.MessageConfig {
+		var userId int64
+		if message.From != nil {
+			userId = message.From.ID
+		}
+		return tgbotapi.NewMessage(message.Chat.ID, revokeCmdHandler(deps.GrantRepository, message.CommandArguments(), userId))
+	}
+}
+
+func revokeCmdHandler(repository repository.Grant, cmdArgs string, revokedBy int64) string {
+	ctx, cancel := newContext()
+	defer cancel()
+
+	userId, err := strconv.ParseInt(strings.TrimSpace(cmdArgs), 10, 64)
+	if err != nil {
+		return errors.Wrapf(BadArguments, "Can't parse user id: %s", cmdArgs).Error()
+	}
+	if userId == revokedBy {
+		return "You can't revoke your own access, ask another admin"
+	}
+
+	if err = repository.DeleteGrant(ctx, userId); err != nil {
+		return err.Error()
+	}
+	return fmt.Sprintf("Access of user %d revoked", userId)
+}
+
+func newUsersCmdHandler(deps Deps) commander.MessageHandler {
+	return func(_ repository.Product, message *tgbotapi.Message) tgbotapi.MessageConfig {
+		return tgbotapi.NewMessage(message.Chat.ID, usersCmdHandler(deps.GrantRepository))
+	}
+}
+
+func usersCmdHandler(repository repository.Grant) string {
+	ctx, cancel := newContext()
+	defer cancel()
+
+	list, err := repository.GetGrants(ctx)
+	if err != nil {
+		return err.Error()
+	}
+
+	if len(list) == 0 {
+		return "nothing found"
+	}
+
+	res := make([]string, 0, len(list))
+	for _, grant := range list {
+		res = append(res, grant.String())
+	}
+
+	return strings.Join(res, "\n")
+}
@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"errors"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"homework-1/internal/models/grants"
+	"homework-1/internal/policy"
+	"homework-1/internal/repository"
+	mock_repository "homework-1/internal/repository/mock"
+	"testing"
+	"time"
+)
+
+const strangerId int64 = 42
+
+func TestAuthorize(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		user    *tgbotapi.User
+		command string
+		// grant is the granted role of the user, empty for none
+		grant    policy.Role
+		grantErr error
+		wantErr  string
+	}{
+		{name: "configured user", user: &tgbotapi.User{ID: editorId}, command: addCmd},
+		{name: "configured user may use the bot", user: &tgbotapi.User{ID: viewerId}},
+		{name: "granted user", user: &tgbotapi.User{ID: strangerId}, command: deleteCmd, grant: policy.Admin},
+		{name: "granted role adds to the configured one", user: &tgbotapi.User{ID: viewerId}, command: updateCmd, grant: policy.Editor},
+		{
+			name:    "not a user",
+			command: listCmd,
+			wantErr: "Sorry, this bot only answers Telegram users.",
+		},
+		{
+			name:    "stranger",
+			user:    &tgbotapi.User{ID: strangerId},
+			command: listCmd,
+			wantErr: "Sorry, this bot is only open to invited users. Ask an admin to grant access to your user id 42.",
+		},
+		{
+			name:    "stranger may not use the bot",
+			user:    &tgbotapi.User{ID: strangerId},
+			wantErr: "Sorry, this bot is only open to invited users. Ask an admin to grant access to your user id 42.",
+		},
+		{
+			name:    "role without the permission",
+			user:    &tgbotapi.User{ID: viewerId},
+			command: deleteCmd,
+			wantErr: "Sorry, /delete needs the delete permission, which your roles [viewer] don't include.",
+		},
+		{
+			name:    "granted role without the permission",
+			user:    &tgbotapi.User{ID: strangerId},
+			command: grantCmd,
+			grant:   policy.Editor,
+			wantErr: "Sorry, /grant needs the manage permission, which your roles [editor] don't include.",
+		},
+		{
+			name:    "unknown command",
+			user:    &tgbotapi.User{ID: adminId},
+			command: "purge",
+			wantErr: "Sorry, /purge needs the unknown permission, which your roles [admin] don't include.",
+		},
+		{
+			name:     "grants are unavailable",
+			user:     &tgbotapi.User{ID: adminId},
+			command:  listCmd,
+			grantErr: errors.New("connection refused"),
+			wantErr:  "Sorry, access can't be checked right now, please try again later.",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// arrange
+			grantRepo := mock_repository.NewMockGrant(gomock.NewController(t))
+			if tc.user != nil {
+				switch {
+				case tc.grantErr != nil:
+					grantRepo.EXPECT().GetGrant(gomock.Any(), tc.user.ID).Return(nil, tc.grantErr)
+				case tc.grant != "":
+					grantRepo.EXPECT().GetGrant(gomock.Any(), tc.user.ID).Return(&grants.Grant{UserId: tc.user.ID, Role: tc.grant}, nil)
+				default:
+					grantRepo.EXPECT().GetGrant(gomock.Any(), tc.user.ID).Return(nil, repository.GrantNotExists)
+				}
+			}
+
+			// act
+			err := newAccess(testUserRoles, grantRepo).Authorize(tc.user, tc.command)
+
+			// assert
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestGrantCmdHandler(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		cmdArgs string
+		// grant is the grant that is stored, nil when none is
+		grant *grants.Grant
+		want  string
+	}{
+		{
+			name:    "grants a role",
+			cmdArgs: "42 editor",
+			grant:   &grants.Grant{UserId: strangerId, Role: policy.Editor, GrantedBy: "admin"},
+			want:    "User 42 granted the editor role",
+		},
+		{name: "no role", cmdArgs: "42", want: "Invalid arguments count: 1: bad arguments"},
+		{name: "bad user id", cmdArgs: "alice editor", want: "Can't parse user id: alice: bad arguments"},
+		{name: "invalid user id", cmdArgs: "-1 editor", want: "-1: invalid Telegram user id, expected a positive number"},
+		{name: "unknown role", cmdArgs: "42 root", want: "unknown role: root"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// arrange
+			grantRepo := mock_repository.NewMockGrant(gomock.NewController(t))
+			if tc.grant != nil {
+				grantRepo.EXPECT().SetGrant(gomock.Any(), *tc.grant).Return(tc.grant, nil)
+			}
+
+			// act
+			res := grantCmdHandler(grantRepo, tc.cmdArgs, "admin")
+
+			// assert
+			assert.Equal(t, tc.want, res)
+		})
+	}
+}
+
+func TestRevokeCmdHandler(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		cmdArgs string
+		// revoked is the user whose grant is deleted, zero when none is
+		revoked   int64
+		revokeErr error
+		want      string
+	}{
+		{name: "revokes a grant", cmdArgs: "42", revoked: strangerId, want: "Access of user 42 revoked"},
+		{
+			name:      "user without a grant",
+			cmdArgs:   "42",
+			revoked:   strangerId,
+			revokeErr: repository.GrantNotExists,
+			want:      "grant does not exist",
+		},
+		{name: "bad user id", cmdArgs: "alice", want: "Can't parse user id: alice: bad arguments"},
+		{name: "own access", cmdArgs: "3", want: "You can't revoke your own access, ask another admin"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// arrange
+			grantRepo := mock_repository.NewMockGrant(gomock.NewController(t))
+			if tc.revoked != 0 {
+				grantRepo.EXPECT().DeleteGrant(gomock.Any(), tc.revoked).Return(tc.revokeErr)
+			}
+
+			// act
+			res := revokeCmdHandler(grantRepo, tc.cmdArgs, adminId)
+
+			// assert
+			assert.Equal(t, tc.want, res)
+		})
+	}
+}
+
+func TestUsersCmdHandler(t *testing.T) {
+	grantedAt := time.Date(2022, 10, 7, 12, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		name   string
+		grants []*grants.Grant
+		err    error
+		want   string
+	}{
+		{
+			name: "lists grants",
+			grants: []*grants.Grant{
+				{UserId: strangerId, Role: policy.Editor, GrantedBy: "admin", GrantedAt: grantedAt},
+				{UserId: 43, Role: policy.Viewer, GrantedBy: "admin", GrantedAt: grantedAt},
+			},
+			want: "42: editor, granted by admin at 2022-10-07T12:00:00Z\n43: viewer, granted by admin at 2022-10-07T12:00:00Z",
+		},
+		{name: "no grants", want: "nothing found"},
+		{name: "grants are unavailable", err: errors.New("connection refused"), want: "connection refused"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// arrange
+			grantRepo := mock_repository.NewMockGrant(gomock.NewController(t))
+			grantRepo.EXPECT().GetGrants(gomock.Any()).Return(tc.grants, tc.err)
+
+			// act
+			res := usersCmdHandler(grantRepo)
+
+			// assert
+			assert.Equal(t, tc.want, res)
+		})
+	}
+}
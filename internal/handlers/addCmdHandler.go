@@ -1,10 +1,10 @@
 package handlers
 
 import (
-	"context"
 	"fmt"
 	"github.com/pkg/errors"
 	"homework-1/internal/models/products"
+	"homework-1/internal/models/units"
 	"homework-1/internal/repository"
 	"strconv"
 	"strings"
@@ -12,26 +12,37 @@ import (
 
 func addCmdHandler(repository repository.Product, cmdArgs string) string {
 	params := strings.Split(cmdArgs, " ")
-	if len(params) != 3 {
+	if len(params) != 3 && len(params) != 4 {
 		return errors.Wrapf(BadArguments, "Invalid arguments count: %d", len(params)).Error()
 	}
 
+	unit := units.Default
+	if len(params) == 4 {
+		var err error
+		if unit, err = units.Parse(params[3]); err != nil {
+			return err.Error()
+		}
+	}
+
 	price, err := strconv.ParseUint(params[1], 10, 64)
 	if err != nil {
 		return errors.Wrapf(BadArguments, "Can't parse price: %s", params[1]).Error()
 	}
 
-	quantity, err := strconv.ParseUint(params[2], 10, 64)
+	quantity, err := units.ParseQuantity(params[2], unit)
 	if err != nil {
-		return errors.Wrapf(BadArguments, "Can't parse quantity: %s", params[2]).Error()
+		return errors.Wrapf(BadArguments, "Can't parse quantity: %s", err).Error()
 	}
 
 	product, err := products.BuildProduct(params[0], price, quantity)
 	if err != nil {
 		return err.Error()
 	}
+	if err = product.SetUnit(unit); err != nil {
+		return err.Error()
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), maxTimeout)
+	ctx, cancel := newContext()
 	defer cancel()
 
 	product, err = repository.CreateProduct(ctx, *product)
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"fmt"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/pkg/errors"
+	"homework-1/internal/commander"
+	"homework-1/internal/repository"
+	"strconv"
+)
+
+func newChangesCmdHandler(deps Deps) commander.MessageHandler {
+	return func(_ repository.Product, message *tgbotapi.Message) tgbotapi.MessageConfig {
+		msg := tgbotapi.NewMessage(message.Chat.ID, "")
+
+		ctx, cancel := newContext()
+		defer cancel()
+
+		page, size, err := extractPageAndSize(message.CommandArguments())
+		if err != nil {
+			msg.Text = err.Error()
+			return msg
+		}
+
+		pending, err := deps.ChangeRepository.GetPendingPriceChanges(ctx, page, size)
+		if err != nil {
+			msg.Text = err.Error()
+			return msg
+		}
+
+		if len(pending) == 0 {
+			msg.Text = "nothing found"
+			return msg
+		}
+
+		rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(pending))
+		for _, change := range pending {
+			msg.Text += change.String() + "\n"
+			rows = append(rows, changeKeyboard(change.Id).InlineKeyboard...)
+		}
+		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+		return msg
+	}
+}
+
+func newApproveCallbackHandler(deps Deps) commander.CallbackHandler {
+	return func(query *tgbotapi.CallbackQuery, data string) string {
+		ctx, cancel := newContext()
+		defer cancel()
+
+		id, err := strconv.ParseUint(data, 10, 64)
+		if err != nil {
+			return errors.Wrapf(BadArguments, "Can't parse id: %s", data).Error()
+		}
+
+		change, product, err := deps.ChangeRepository.ApprovePriceChange(ctx, id, actor(query.From))
+		if err != nil {
+			return err.Error()
+		}
+		return fmt.Sprintf("Change #%d approved, product updated: %s", change.Id, product.String())
+	}
+}
+
+func newRejectCallbackHandler(deps Deps) commander.CallbackHandler {
+	return func(query *tgbotapi.CallbackQuery, data string) string {
+		ctx, cancel := newContext()
+		defer cancel()
+
+		id, err := strconv.ParseUint(data, 10, 64)
+		if err != nil {
+			return errors.Wrapf(BadArguments, "Can't parse id: %s", data).Error()
+		}
+
+		change, err := deps.ChangeRepository.RejectPriceChange(ctx, id, actor(query.From))
+		if err != nil {
+			return err.Error()
+		}
+		return fmt.Sprintf("Change #%d rejected", change.Id)
+	}
+}
+
+func changeKeyboard(id uint64) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("Approve #%d", id), fmt.Sprintf("%s:%d", approveAction, id)),
+			tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("Reject #%d", id), fmt.Sprintf("%s:%d", rejectAction, id)),
+		),
+	)
+}
+
+// actor identifies the telegram user in change requests
+func actor(user *tgbotapi.User) string {
+	if user == nil {
+		return ""
+	}
+	return fmt.Sprintf("telegram:%d", user.ID)
+}
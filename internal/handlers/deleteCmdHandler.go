@@ -1,15 +1,23 @@
 package handlers
 
 import (
-	"context"
 	"fmt"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/pkg/errors"
+	"homework-1/internal/commander"
+	"homework-1/internal/gallery"
 	"homework-1/internal/repository"
 	"strconv"
 	"strings"
 )
 
-func deleteCmdHandler(repository repository.Product, cmdArgs string) string {
+func newDeleteCmdHandler(deps Deps) commander.MessageHandler {
+	return func(_ repository.Product, message *tgbotapi.Message) tgbotapi.MessageConfig {
+		return tgbotapi.NewMessage(message.Chat.ID, deleteCmdHandler(deps.ImageService, message.CommandArguments()))
+	}
+}
+
+func deleteCmdHandler(service *gallery.Service, cmdArgs string) string {
 	args := strings.Split(cmdArgs, " ")
 	if len(args) != 1 {
 		return errors.Wrapf(BadArguments, "Invalid arguments count: %d. Require 1", len(args)).Error()
@@ -20,10 +28,10 @@ func deleteCmdHandler(repository repository.Product, cmdArgs string) string {
 		return errors.Wrapf(BadArguments, "Can't parse id: %s", args[0]).Error()
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), maxTimeout)
+	ctx, cancel := newContext()
 	defer cancel()
 
-	if err = repository.DeleteProduct(ctx, id); err != nil {
+	if err = service.DeleteProduct(ctx, id); err != nil {
 		return err.Error()
 	}
 
@@ -1,37 +1,143 @@
 package handlers
 
 import (
+	"context"
 	"github.com/pkg/errors"
+	"homework-1/config"
+	"homework-1/internal/approvals"
 	"homework-1/internal/commander"
+	"homework-1/internal/gallery"
+	"homework-1/internal/policy"
 	"homework-1/internal/repository"
+	"homework-1/internal/tenants"
 	"time"
 )
 
 const (
-	helpCmd   = "help"
-	addCmd    = "add"
-	updateCmd = "update"
-	deleteCmd = "delete"
-	listCmd   = "list"
+	helpCmd    = "help"
+	addCmd     = "add"
+	updateCmd  = "update"
+	deleteCmd  = "delete"
+	listCmd    = "list"
+	changesCmd = "changes"
+	scanCmd    = "scan"
+
+	lowStockCmd    = "lowstock"
+	thresholdCmd   = "threshold"
+	subscribeCmd   = "subscribe"
+	unsubscribeCmd = "unsubscribe"
+
+	stocktakeCmd = "stocktake"
+	countCmd     = "count"
+	variancesCmd = "variances"
+	commitCmd    = "commit"
+
+	reportCmd = "report"
+
+	grantCmd  = "grant"
+	revokeCmd = "revoke"
+	usersCmd  = "users"
+
+	approveAction = "approve"
+	rejectAction  = "reject"
 
 	maxTimeout = time.Millisecond * 30
 )
 
 var BadArguments = errors.New("bad arguments")
 
+// newContext bounds a command by maxTimeout and runs it for the shop of the bot.
+func newContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(tenants.NewContext(context.Background(), tenants.Tenant(config.BotTenant)), maxTimeout)
+}
+
 func helpCmdHandler(_ repository.Product, _ string) string {
 	return `/help - list of commands
-/list [page] [size]  - list of products
-/add <name> <price> <quantity> - add new product
-/update <id> <name> <price> <quantity> - update product by id
+/list [page] [size]  - list of products in the language of your Telegram app
+/add <name> <price> <quantity> [unit] - add new product, unit is one of piece, kg, g, m, cm, l, ml
+/update <id> <name> <price> <quantity> - update product by id, quantity is in the product unit
 /delete <id> - delete product
+/scan <barcode> - find product by EAN-8, UPC-A, EAN-13 or GTIN-14 barcode
+/changes [page] [size] - list of price changes waiting for approval
+/lowstock [page] [size] - list of products below their reorder threshold
+/threshold <id> <threshold> - set reorder threshold, 0 removes it
+/subscribe - receive low stock alerts in this chat
+/unsubscribe - stop receiving low stock alerts
+/stocktake - open a stocktake session
+/count <stocktake id> <product id> <quantity> - submit a counted quantity
+/variances <stocktake id> - compare counted and system quantities
+/commit <stocktake id> - apply counted quantities
+/report [limit] - stock value, value by status and top products by value
+/grant <user id> <role> - give a Telegram user the viewer, editor or admin role
+/revoke <user id> - take the granted role of a Telegram user away
+/users - list of users with granted roles
 `
 }
 
-func AddHandlers(c *commander.Commander) {
+type Deps struct {
+	ChangeRepository repository.PriceChange
+	// ApprovalService updates products and holds large price changes back for approval
+	ApprovalService     *approvals.Service
+	StockRepository     repository.Stock
+	StocktakeRepository repository.Stocktake
+	ReportRepository    repository.Report
+	// TranslationRepository localizes /list and /scan to the language of the user's Telegram client
+	TranslationRepository repository.Translation
+	// ImageService deletes products together with their images
+	ImageService *gallery.Service
+	// UserRoles are the roles of Telegram users from the config, they can't be revoked from the bot
+	UserRoles *policy.UserRoles
+	// GrantRepository keeps the roles admins grant with /grant
+	GrantRepository repository.Grant
+}
+
+// commandPermissions is what a Telegram user needs for each command and button, the bot
+// counterpart of policy.Methods. A command missing here is denied.
+var commandPermissions = map[string]policy.Permission{
+	helpCmd:        policy.Read,
+	listCmd:        policy.Read,
+	addCmd:         policy.Write,
+	updateCmd:      policy.Write,
+	deleteCmd:      policy.Delete,
+	scanCmd:        policy.Read,
+	changesCmd:     policy.Read,
+	approveAction:  policy.Approve,
+	rejectAction:   policy.Approve,
+	lowStockCmd:    policy.Read,
+	thresholdCmd:   policy.Write,
+	subscribeCmd:   policy.Read,
+	unsubscribeCmd: policy.Read,
+	stocktakeCmd:   policy.Write,
+	countCmd:       policy.Write,
+	variancesCmd:   policy.Read,
+	commitCmd:      policy.Write,
+	reportCmd:      policy.Read,
+	grantCmd:       policy.Manage,
+	revokeCmd:      policy.Manage,
+	usersCmd:       policy.Manage,
+}
+
+func AddHandlers(c *commander.Commander, deps Deps) {
+	c.SetAccess(newAccess(deps.UserRoles, deps.GrantRepository))
 	c.RegisterHandler(helpCmd, helpCmdHandler)
-	c.RegisterHandler(listCmd, listCmdHandler)
+	c.RegisterMessageHandler(listCmd, newListCmdHandler(deps))
 	c.RegisterHandler(addCmd, addCmdHandler)
-	c.RegisterHandler(deleteCmd, deleteCmdHandler)
-	c.RegisterHandler(updateCmd, updateCmdHandler)
+	c.RegisterMessageHandler(deleteCmd, newDeleteCmdHandler(deps))
+	c.RegisterMessageHandler(scanCmd, newScanCmdHandler(deps))
+	c.RegisterMessageHandler(updateCmd, newUpdateCmdHandler(deps))
+	c.RegisterMessageHandler(changesCmd, newChangesCmdHandler(deps))
+	c.RegisterCallbackHandler(approveAction, newApproveCallbackHandler(deps))
+	c.RegisterCallbackHandler(rejectAction, newRejectCallbackHandler(deps))
+	c.RegisterMessageHandler(lowStockCmd, newLowStockCmdHandler(deps))
+	c.RegisterMessageHandler(thresholdCmd, newThresholdCmdHandler(deps))
+	c.RegisterMessageHandler(subscribeCmd, newSubscribeCmdHandler(deps))
+	c.RegisterMessageHandler(unsubscribeCmd, newUnsubscribeCmdHandler(deps))
+	c.RegisterMessageHandler(stocktakeCmd, newStocktakeCmdHandler(deps))
+	c.RegisterMessageHandler(countCmd, newCountCmdHandler(deps))
+	c.RegisterMessageHandler(variancesCmd, newVariancesCmdHandler(deps))
+	c.RegisterMessageHandler(commitCmd, newCommitCmdHandler(deps))
+	c.RegisterMessageHandler(reportCmd, newReportCmdHandler(deps))
+	c.RegisterMessageHandler(grantCmd, newGrantCmdHandler(deps))
+	c.RegisterMessageHandler(revokeCmd, newRevokeCmdHandler(deps))
+	c.RegisterMessageHandler(usersCmd, newUsersCmdHandler(deps))
 }
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+	"homework-1/internal/commander"
+	"homework-1/internal/policy"
+	"testing"
+)
+
+const (
+	viewerId int64 = iota + 1
+	editorId
+	adminId
+)
+
+var testUserRoles = policy.NewUserRoles(map[int64]policy.Role{
+	viewerId: policy.Viewer,
+	editorId: policy.Editor,
+	adminId:  policy.Admin,
+}, "")
+
+func TestCommandPermissionsCoverHandlers(t *testing.T) {
+	// arrange
+	c := commander.New(nil, nil)
+
+	// act
+	AddHandlers(c, Deps{})
+
+	// assert
+	assert.Contains(t, c.Actions(), approveAction)
+	for _, command := range append(c.Commands(), c.Actions()...) {
+		assert.Contains(t, commandPermissions, command)
+	}
+}
+
+func TestCommandPermissions(t *testing.T) {
+	access := newAccess(testUserRoles, nil)
+
+	for _, tc := range []struct {
+		name    string
+		userId  int64
+		command string
+		allowed bool
+	}{
+		{name: "viewer lists products", userId: viewerId, command: listCmd, allowed: true},
+		{name: "viewer reads the report", userId: viewerId, command: reportCmd, allowed: true},
+		{name: "viewer can't add products", userId: viewerId, command: addCmd},
+		{name: "viewer can't count stock", userId: viewerId, command: countCmd},
+		{name: "viewer can't approve price changes", userId: viewerId, command: approveAction},
+		{name: "editor adds products", userId: editorId, command: addCmd, allowed: true},
+		{name: "editor updates products", userId: editorId, command: updateCmd, allowed: true},
+		{name: "editor commits stocktakes", userId: editorId, command: commitCmd, allowed: true},
+		{name: "editor can't delete products", userId: editorId, command: deleteCmd},
+		{name: "editor can't approve price changes", userId: editorId, command: approveAction},
+		{name: "editor can't reject price changes", userId: editorId, command: rejectAction},
+		{name: "editor can't grant roles", userId: editorId, command: grantCmd},
+		{name: "admin deletes products", userId: adminId, command: deleteCmd, allowed: true},
+		{name: "admin approves price changes", userId: adminId, command: approveAction, allowed: true},
+		{name: "admin rejects price changes", userId: adminId, command: rejectAction, allowed: true},
+		{name: "admin revokes roles", userId: adminId, command: revokeCmd, allowed: true},
+		{name: "unknown command is denied to admins too", userId: adminId, command: "purge"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// act
+			err := access.Authorize(&tgbotapi.User{ID: tc.userId}, tc.command)
+
+			// assert
+			if tc.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestEveryCommandFollowsRolePermissions(t *testing.T) {
+	access := newAccess(testUserRoles, nil)
+
+	for command, permission := range commandPermissions {
+		for _, userId := range []int64{viewerId, editorId, adminId} {
+			// act
+			err := access.Authorize(&tgbotapi.User{ID: userId}, command)
+
+			// assert
+			allowed := policy.Allowed(testUserRoles.Roles(userId), permission)
+			assert.Equal(t, allowed, err == nil, "/%s for %v", command, testUserRoles.Roles(userId))
+		}
+	}
+}
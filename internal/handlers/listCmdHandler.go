@@ -2,14 +2,25 @@ package handlers
 
 import (
 	"context"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/pkg/errors"
+	"homework-1/internal/commander"
+	"homework-1/internal/locales"
+	"homework-1/internal/models/products"
 	"homework-1/internal/repository"
 	"strconv"
 	"strings"
 )
 
-func listCmdHandler(repository repository.Product, args string) string {
-	ctx, cancel := context.WithTimeout(context.Background(), maxTimeout)
+func newListCmdHandler(deps Deps) commander.MessageHandler {
+	return func(productRepository repository.Product, message *tgbotapi.Message) tgbotapi.MessageConfig {
+		text := listCmdHandler(productRepository, deps.TranslationRepository, message.CommandArguments(), userLocale(message.From))
+		return tgbotapi.NewMessage(message.Chat.ID, text)
+	}
+}
+
+func listCmdHandler(repository repository.Product, translationRepository repository.Translation, args string, locale locales.Locale) string {
+	ctx, cancel := newContext()
 	defer cancel()
 
 	page, size, err := extractPageAndSize(args)
@@ -26,9 +37,14 @@ func listCmdHandler(repository repository.Product, args string) string {
 		return "nothing found"
 	}
 
+	translations, err := localize(ctx, translationRepository, products, locale)
+	if err != nil {
+		return err.Error()
+	}
+
 	res := make([]string, len(products))
 	for _, p := range products {
-		res = append(res, p.String())
+		res = append(res, localizedString(p, translations[p.GetId()]))
 	}
 
 	return strings.Join(res, "\n")
@@ -56,3 +72,35 @@ func extractPageAndSize(args string) (uint64, uint64, error) {
 
 	return page, size, nil
 }
+
+// userLocale picks the locale from the Telegram client language, falling back to the default one.
+func userLocale(user *tgbotapi.User) locales.Locale {
+	if user == nil {
+		return locales.Default
+	}
+	return locales.Negotiate(user.LanguageCode)
+}
+
+func localize(ctx context.Context, repository repository.Translation, list []*products.Product, locale locales.Locale) (map[uint64]*products.Translation, error) {
+	if len(list) == 0 {
+		return nil, nil
+	}
+	ids := make([]uint64, 0, len(list))
+	for _, product := range list {
+		ids = append(ids, product.GetId())
+	}
+
+	translations, err := repository.GetTranslations(ctx, ids, locale)
+	if err != nil {
+		return nil, err
+	}
+	return products.PickTranslations(translations, locale), nil
+}
+
+func localizedString(product *products.Product, translation *products.Translation) string {
+	localized, description := product.Localize(translation)
+	if description == "" {
+		return localized.String()
+	}
+	return localized.String() + " - " + description
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/pkg/errors"
+	"homework-1/internal/commander"
+	"homework-1/internal/repository"
+	"strconv"
+	"strings"
+)
+
+func newReportCmdHandler(deps Deps) commander.MessageHandler {
+	return func(_ repository.Product, message *tgbotapi.Message) tgbotapi.MessageConfig {
+		return tgbotapi.NewMessage(message.Chat.ID, reportCmdHandler(deps.ReportRepository, message.CommandArguments()))
+	}
+}
+
+func reportCmdHandler(repository repository.Report, args string) string {
+	ctx, cancel := newContext()
+	defer cancel()
+
+	var limit uint64
+	if len(args) > 0 {
+		var err error
+		if limit, err = strconv.ParseUint(args, 10, 64); err != nil {
+			return errors.Wrapf(BadArguments, "Can't parse limit: %s", args).Error()
+		}
+	}
+
+	valuation, err := repository.GetStockValuation(ctx)
+	if err != nil {
+		return err.Error()
+	}
+
+	byStatus, err := repository.GetValueByStatus(ctx)
+	if err != nil {
+		return err.Error()
+	}
+
+	byCategory, err := repository.GetValueByCategory(ctx)
+	if err != nil {
+		return err.Error()
+	}
+
+	top, err := repository.GetTopProductsByValue(ctx, limit)
+	if err != nil {
+		return err.Error()
+	}
+
+	res := make([]string, 0, len(byStatus)+len(byCategory)+len(top)+7)
+	res = append(res, valuation.String(), "", "by status:")
+	for _, value := range byStatus {
+		res = append(res, value.String())
+	}
+	res = append(res, "", "by category:")
+	for _, value := range byCategory {
+		res = append(res, value.String())
+	}
+	res = append(res, "", "top products by value:")
+	for _, value := range top {
+		res = append(res, value.String())
+	}
+
+	return strings.Join(res, "\n")
+}
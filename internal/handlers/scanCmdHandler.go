@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"fmt"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/pkg/errors"
+	"homework-1/internal/commander"
+	"homework-1/internal/locales"
+	"homework-1/internal/models/products"
+	"homework-1/internal/repository"
+	"strings"
+)
+
+func newScanCmdHandler(deps Deps) commander.MessageHandler {
+	return func(productRepository repository.Product, message *tgbotapi.Message) tgbotapi.MessageConfig {
+		text := scanCmdHandler(productRepository, deps.TranslationRepository, message.CommandArguments(), userLocale(message.From))
+		return tgbotapi.NewMessage(message.Chat.ID, text)
+	}
+}
+
+func scanCmdHandler(repository repository.Product, translationRepository repository.Translation, cmdArgs string, locale locales.Locale) string {
+	args := strings.Split(cmdArgs, " ")
+	if len(args) != 1 {
+		return errors.Wrapf(BadArguments, "Invalid arguments count: %d. Require 1", len(args)).Error()
+	}
+
+	barcode, err := products.NormalizeBarcode(args[0])
+	if err != nil {
+		return err.Error()
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	product, err := repository.GetProductByBarcode(ctx, barcode)
+	if err != nil {
+		return err.Error()
+	}
+
+	translations, err := localize(ctx, translationRepository, []*products.Product{product}, locale)
+	if err != nil {
+		return err.Error()
+	}
+
+	return fmt.Sprintf("Scanned: %s", localizedString(product, translations[product.GetId()]))
+}
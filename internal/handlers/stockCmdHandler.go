@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"fmt"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/pkg/errors"
+	"homework-1/internal/commander"
+	"homework-1/internal/repository"
+	"strconv"
+	"strings"
+)
+
+func newLowStockCmdHandler(deps Deps) commander.MessageHandler {
+	return func(_ repository.Product, message *tgbotapi.Message) tgbotapi.MessageConfig {
+		return tgbotapi.NewMessage(message.Chat.ID, lowStockCmdHandler(deps.StockRepository, message.CommandArguments()))
+	}
+}
+
+func lowStockCmdHandler(repository repository.Stock, args string) string {
+	ctx, cancel := newContext()
+	defer cancel()
+
+	page, size, err := extractPageAndSize(args)
+	if err != nil {
+		return err.Error()
+	}
+
+	lowStock, err := repository.GetLowStockProducts(ctx, page, size)
+	if err != nil {
+		return err.Error()
+	}
+
+	if len(lowStock) == 0 {
+		return "nothing found"
+	}
+
+	res := make([]string, 0, len(lowStock))
+	for _, item := range lowStock {
+		res = append(res, item.String())
+	}
+
+	return strings.Join(res, "\n")
+}
+
+func newThresholdCmdHandler(deps Deps) commander.MessageHandler {
+	return func(_ repository.Product, message *tgbotapi.Message) tgbotapi.MessageConfig {
+		return tgbotapi.NewMessage(message.Chat.ID, thresholdCmdHandler(deps.StockRepository, message.CommandArguments()))
+	}
+}
+
+func thresholdCmdHandler(repository repository.Stock, cmdArgs string) string {
+	ctx, cancel := newContext()
+	defer cancel()
+
+	args := strings.Split(cmdArgs, " ")
+	if len(args) != 2 {
+		return errors.Wrapf(BadArguments, "Invalid arguments count: %d", len(args)).Error()
+	}
+
+	productId, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return errors.Wrapf(BadArguments, "Can't parse id: %s", args[0]).Error()
+	}
+
+	threshold, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		return errors.Wrapf(BadArguments, "Can't parse threshold: %s", args[1]).Error()
+	}
+
+	if err = repository.SetReorderThreshold(ctx, productId, threshold); err != nil {
+		return err.Error()
+	}
+	return fmt.Sprintf("Reorder threshold for product %d set to %d", productId, threshold)
+}
+
+func newSubscribeCmdHandler(deps Deps) commander.MessageHandler {
+	return func(_ repository.Product, message *tgbotapi.Message) tgbotapi.MessageConfig {
+		msg := tgbotapi.NewMessage(message.Chat.ID, "Subscribed to low stock alerts")
+
+		ctx, cancel := newContext()
+		defer cancel()
+
+		if err := deps.StockRepository.AddAlertSubscription(ctx, message.Chat.ID); err != nil {
+			msg.Text = err.Error()
+		}
+		return msg
+	}
+}
+
+func newUnsubscribeCmdHandler(deps Deps) commander.MessageHandler {
+	return func(_ repository.Product, message *tgbotapi.Message) tgbotapi.MessageConfig {
+		msg := tgbotapi.NewMessage(message.Chat.ID, "Unsubscribed from low stock alerts")
+
+		ctx, cancel := newContext()
+		defer cancel()
+
+		if err := deps.StockRepository.RemoveAlertSubscription(ctx, message.Chat.ID); err != nil {
+			msg.Text = err.Error()
+		}
+		return msg
+	}
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"fmt"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/pkg/errors"
+	"homework-1/internal/commander"
+	"homework-1/internal/models/stocktakes"
+	"homework-1/internal/repository"
+	"strconv"
+	"strings"
+)
+
+func newStocktakeCmdHandler(deps Deps) commander.MessageHandler {
+	return func(_ repository.Product, message *tgbotapi.Message) tgbotapi.MessageConfig {
+		ctx, cancel := newContext()
+		defer cancel()
+
+		msg := tgbotapi.NewMessage(message.Chat.ID, "")
+
+		session, err := stocktakes.NewSession(actor(message.From))
+		if err != nil {
+			msg.Text = err.Error()
+			return msg
+		}
+
+		if session, err = deps.StocktakeRepository.OpenStocktake(ctx, *session); err != nil {
+			msg.Text = err.Error()
+			return msg
+		}
+
+		msg.Text = fmt.Sprintf("Stocktake #%d opened, submit counts with /count %d <product id> <quantity>", session.Id, session.Id)
+		return msg
+	}
+}
+
+func newCountCmdHandler(deps Deps) commander.MessageHandler {
+	return func(_ repository.Product, message *tgbotapi.Message) tgbotapi.MessageConfig {
+		return tgbotapi.NewMessage(message.Chat.ID, countCmdHandler(deps.StocktakeRepository, message.CommandArguments(), actor(message.From)))
+	}
+}
+
+func countCmdHandler(repository repository.Stocktake, cmdArgs string, countedBy string) string {
+	ctx, cancel := newContext()
+	defer cancel()
+
+	args := strings.Split(cmdArgs, " ")
+	if len(args) != 3 {
+		return errors.Wrapf(BadArguments, "Invalid arguments count: %d", len(args)).Error()
+	}
+
+	sessionId, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return errors.Wrapf(BadArguments, "Can't parse stocktake id: %s", args[0]).Error()
+	}
+
+	productId, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		return errors.Wrapf(BadArguments, "Can't parse product id: %s", args[1]).Error()
+	}
+
+	counted, err := strconv.ParseUint(args[2], 10, 64)
+	if err != nil {
+		return errors.Wrapf(BadArguments, "Can't parse quantity: %s", args[2]).Error()
+	}
+
+	count, err := stocktakes.NewCount(sessionId, productId, counted, countedBy)
+	if err != nil {
+		return err.Error()
+	}
+
+	if _, err = repository.SubmitCount(ctx, *count); err != nil {
+		return err.Error()
+	}
+	return fmt.Sprintf("Counted %d of product %d in stocktake #%d", counted, productId, sessionId)
+}
+
+func newVariancesCmdHandler(deps Deps) commander.MessageHandler {
+	return func(_ repository.Product, message *tgbotapi.Message) tgbotapi.MessageConfig {
+		return tgbotapi.NewMessage(message.Chat.ID, variancesCmdHandler(deps.StocktakeRepository, message.CommandArguments()))
+	}
+}
+
+func variancesCmdHandler(repository repository.Stocktake, args string) string {
+	ctx, cancel := newContext()
+	defer cancel()
+
+	sessionId, err := strconv.ParseUint(args, 10, 64)
+	if err != nil {
+		return errors.Wrapf(BadArguments, "Can't parse stocktake id: %s", args).Error()
+	}
+
+	variances, err := repository.GetStocktakeVariances(ctx, sessionId)
+	if err != nil {
+		return err.Error()
+	}
+
+	if len(variances) == 0 {
+		return "nothing counted"
+	}
+
+	res := make([]string, 0, len(variances))
+	for _, variance := range variances {
+		res = append(res, variance.String())
+	}
+
+	return strings.Join(res, "\n")
+}
+
+func newCommitCmdHandler(deps Deps) commander.MessageHandler {
+	return func(_ repository.Product, message *tgbotapi.Message) tgbotapi.MessageConfig {
+		return tgbotapi.NewMessage(message.Chat.ID, commitCmdHandler(deps.StocktakeRepository, message.CommandArguments(), actor(message.From)))
+	}
+}
+
+func commitCmdHandler(repository repository.Stocktake, args string, committedBy string) string {
+	ctx, cancel := newContext()
+	defer cancel()
+
+	sessionId, err := strconv.ParseUint(args, 10, 64)
+	if err != nil {
+		return errors.Wrapf(BadArguments, "Can't parse stocktake id: %s", args).Error()
+	}
+
+	session, adjustments, err := repository.CommitStocktake(ctx, sessionId, committedBy)
+	if err != nil {
+		return err.Error()
+	}
+
+	res := make([]string, 0, len(adjustments)+1)
+	res = append(res, fmt.Sprintf("Stocktake #%d committed, %d products adjusted", session.Id, len(adjustments)))
+	for _, adjustment := range adjustments {
+		res = append(res, adjustment.String())
+	}
+
+	return strings.Join(res, "\n")
+}
@@ -3,37 +3,53 @@ package handlers
 import (
 	"context"
 	"fmt"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/pkg/errors"
+	"homework-1/internal/approvals"
+	"homework-1/internal/commander"
 	"homework-1/internal/models/products"
+	"homework-1/internal/models/units"
 	"homework-1/internal/repository"
 	"strconv"
 	"strings"
 )
 
-func updateCmdHandler(repository repository.Product, cmdArgs string) string {
-	ctx, cancel := context.WithTimeout(context.Background(), maxTimeout)
+func newUpdateCmdHandler(deps Deps) commander.MessageHandler {
+	return func(repository repository.Product, message *tgbotapi.Message) tgbotapi.MessageConfig {
+		msg := tgbotapi.NewMessage(message.Chat.ID, "")
+		msg.Text, msg.ReplyMarkup = updateCmdHandler(repository, deps, message)
+		return msg
+	}
+}
+
+func updateCmdHandler(repository repository.Product, deps Deps, message *tgbotapi.Message) (string, interface{}) {
+	ctx, cancel := newContext()
 	defer cancel()
 
-	args := strings.Split(cmdArgs, " ")
+	args := strings.Split(message.CommandArguments(), " ")
 	if len(args) != 4 {
-		return errors.Wrapf(BadArguments, "Invalid arguments count: %d", len(args)).Error()
+		return errors.Wrapf(BadArguments, "Invalid arguments count: %d", len(args)).Error(), nil
 	}
 
 	product, err := getProductByStringId(ctx, repository, args[0])
 	if err != nil {
-		return err.Error()
+		return err.Error(), nil
 	}
 
-	product, err = updateProduct(product, args[1:])
+	updated, err := updateProduct(product.Copy(), args[1:])
 	if err != nil {
-		return err.Error()
+		return err.Error(), nil
 	}
 
-	product, err = repository.UpdateProduct(ctx, *product)
+	product, change, err := deps.ApprovalService.UpdateProduct(ctx, product, *updated, approvals.Requested(actor(message.From)))
 	if err != nil {
-		return err.Error()
+		return err.Error(), nil
+	}
+
+	if change != nil {
+		return fmt.Sprintf("Product updated: %s\nPrice change waits for approval by another user: %s", product.String(), change.String()), changeKeyboard(change.Id)
 	}
-	return fmt.Sprintf("Product updated: %s", product.String())
+	return fmt.Sprintf("Product updated: %s", product.String()), nil
 }
 
 func getProductByStringId(ctx context.Context, repository repository.Product, id string) (*products.Product, error) {
@@ -63,9 +79,9 @@ func updateProduct(product *products.Product, params []string) (*products.Produc
 		return nil, err
 	}
 
-	quantity, err := strconv.ParseUint(params[2], 10, 64)
+	quantity, err := units.ParseQuantity(params[2], product.GetUnit())
 	if err != nil {
-		return nil, errors.Wrapf(BadArguments, "Can't parse quantity: %s", params[2])
+		return nil, errors.Wrapf(BadArguments, "Can't parse quantity: %s", err)
 	}
 	if err = product.SetQuantity(quantity); err != nil {
 		return nil, err
@@ -0,0 +1,22 @@
+// Package health serves the standard gRPC health checks, which are the only calls
+// allowed without credentials or a tenant.
+package health
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"strings"
+)
+
+var servicePrefix = "/" + healthpb.Health_ServiceDesc.ServiceName + "/"
+
+// Register adds the health service to server, it reports SERVING for the whole server.
+func Register(server *grpc.Server) {
+	healthpb.RegisterHealthServer(server, health.NewServer())
+}
+
+// IsHealthCheck tells whether fullMethod, e.g. "/grpc.health.v1.Health/Check", is a health check.
+func IsHealthCheck(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, servicePrefix)
+}
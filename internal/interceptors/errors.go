@@ -0,0 +1,114 @@
+package interceptors
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"homework-1/internal/models/changes"
+	"homework-1/internal/models/images"
+	"homework-1/internal/models/lots"
+	"homework-1/internal/models/products"
+	"homework-1/internal/models/purchases"
+	"homework-1/internal/models/stocktakes"
+	"homework-1/internal/money"
+	"homework-1/internal/repository"
+)
+
+// codeOf answers the errors of repositories and models, the first match wins.
+var codeOf = []struct {
+	err  error
+	code codes.Code
+}{
+	{repository.ProductNotExists, codes.NotFound},
+	{repository.PriceChangeNotExists, codes.NotFound},
+	{repository.SupplierNotExists, codes.NotFound},
+	{repository.PurchaseOrderNotExists, codes.NotFound},
+	{repository.StocktakeNotExists, codes.NotFound},
+	{repository.ImageNotExists, codes.NotFound},
+	{repository.RelationNotExists, codes.NotFound},
+	{repository.GrantNotExists, codes.NotFound},
+
+	{repository.ProductAlreadyExists, codes.AlreadyExists},
+	{repository.BarcodeAlreadyExists, codes.AlreadyExists},
+	{repository.LotAlreadyExists, codes.AlreadyExists},
+	{repository.RelationAlreadyExists, codes.AlreadyExists},
+
+	{repository.ProductHasRelations, codes.FailedPrecondition},
+	{repository.ExchangeRateNotExists, codes.FailedPrecondition},
+	{money.ErrOverflow, codes.FailedPrecondition},
+	{products.ErrInvalidStatusTransition, codes.FailedPrecondition},
+	{products.ErrProductNotActive, codes.FailedPrecondition},
+	{products.ErrNotEnoughQuantity, codes.FailedPrecondition},
+	{lots.ErrExpiredStock, codes.FailedPrecondition},
+	{changes.ErrNotPending, codes.FailedPrecondition},
+	{purchases.ErrOrderClosed, codes.FailedPrecondition},
+	{purchases.ErrOverReceipt, codes.FailedPrecondition},
+	{stocktakes.ErrSessionClosed, codes.FailedPrecondition},
+
+	{changes.ErrSelfApproval, codes.PermissionDenied},
+
+	{products.ErrUnknownStatus, codes.InvalidArgument},
+	{changes.ErrEmptyApprover, codes.InvalidArgument},
+	{images.ErrEmptyImage, codes.InvalidArgument},
+	{images.ErrImageTooLarge, codes.InvalidArgument},
+	{purchases.ErrUnknownLine, codes.InvalidArgument},
+}
+
+// invalidArgument is a mistake in a request without its own entry in codeOf.
+type invalidArgument struct {
+	err error
+}
+
+func (e invalidArgument) Error() string {
+	return e.err.Error()
+}
+
+func (e invalidArgument) Unwrap() error {
+	return e.err
+}
+
+// Invalid marks a validation error, it is answered with InvalidArgument and its message.
+func Invalid(err error) error {
+	return invalidArgument{err: err}
+}
+
+// Status turns the error of a handler into the status its caller gets. Status errors keep
+// their code, known errors get theirs from codeOf and anything else is answered with a bare
+// Internal, the details of our failures stay in the logs.
+func Status(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var invalid invalidArgument
+	if errors.As(err, &invalid) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	for _, known := range codeOf {
+		if errors.Is(err, known.err) {
+			return status.Error(known.code, err.Error())
+		}
+	}
+
+	return status.Error(codes.Internal, "internal error")
+}
+
+// toStatus is Status that logs the errors it hides.
+func toStatus(method string, err error) error {
+	mapped := Status(err)
+	if mapped != err && status.Code(mapped) == codes.Internal {
+		log.WithError(err).WithField("method", method).Error("internal error")
+	}
+	return mapped
+}
+
+// fromDownstream keeps the mistakes of callers that a downstream service reports as they are,
+// its failures become plain errors so toStatus logs them and hides them from our callers.
+func fromDownstream(method string, err error) error {
+	if s, ok := status.FromError(err); ok && err != nil && isServerFault(s.Code()) {
+		return fmt.Errorf("%s: %s: %s", method, s.Code(), s.Message())
+	}
+	return err
+}
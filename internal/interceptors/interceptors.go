@@ -0,0 +1,93 @@
+// Package interceptors does what every gRPC handler used to repeat: it counts the calls of
+// each method, logs one line per call with credentials and personal data redacted and turns
+// the errors of handlers into status codes, so handlers only return what went wrong.
+package interceptors
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"homework-1/internal/health"
+	"homework-1/internal/metrics"
+	"time"
+)
+
+type Deps struct {
+	Metrics  *metrics.Metrics
+	Redactor *Redactor
+}
+
+// UnaryServerInterceptor goes right after tracing, so the calls rejected by the auth, rate
+// limit and policy interceptors are counted and logged as well. Health checks are skipped.
+func UnaryServerInterceptor(deps Deps) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if health.IsHealthCheck(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		deps.Metrics.IncomingRequestCounter.Inc()
+		deps.Metrics.InFlight.Inc()
+		defer deps.Metrics.InFlight.Dec()
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		err = toStatus(info.FullMethod, err)
+
+		record(deps.Metrics, info.FullMethod, status.Code(err), start)
+		logCall(ctx, deps.Redactor, info.FullMethod, start, req, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streaming methods, a stream is one call.
+func StreamServerInterceptor(deps Deps) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if health.IsHealthCheck(info.FullMethod) {
+			return handler(srv, stream)
+		}
+
+		deps.Metrics.IncomingRequestCounter.Inc()
+		deps.Metrics.InFlight.Inc()
+		defer deps.Metrics.InFlight.Dec()
+		start := time.Now()
+		err := handler(srv, &loggedStream{ServerStream: stream, redactor: deps.Redactor, method: info.FullMethod})
+		err = toStatus(info.FullMethod, err)
+
+		record(deps.Metrics, info.FullMethod, status.Code(err), start)
+		logCall(stream.Context(), deps.Redactor, info.FullMethod, start, nil, err)
+		return err
+	}
+}
+
+// UnaryClientInterceptor counts the calls a proxy makes to the storage service. Failures of
+// the storage service reach the handlers as plain errors, see fromDownstream.
+func UnaryClientInterceptor(m *metrics.Metrics) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		m.OutgoingRequestCounter.Inc()
+		return fromDownstream(method, invoker(ctx, method, req, reply, cc, opts...))
+	}
+}
+
+// StreamClientInterceptor is UnaryClientInterceptor for streaming methods.
+func StreamClientInterceptor(m *metrics.Metrics) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		m.OutgoingRequestCounter.Inc()
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, fromDownstream(method, err)
+		}
+		return &downstream{ClientStream: stream, method: method}, nil
+	}
+}
+
+type downstream struct {
+	grpc.ClientStream
+	method string
+}
+
+func (s *downstream) SendMsg(m interface{}) error {
+	return fromDownstream(s.method, s.ClientStream.SendMsg(m))
+}
+
+func (s *downstream) RecvMsg(m interface{}) error {
+	return fromDownstream(s.method, s.ClientStream.RecvMsg(m))
+}
@@ -0,0 +1,174 @@
+package interceptors
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"homework-1/internal/metrics"
+	"homework-1/internal/repository"
+	pbApi "homework-1/pkg/api/v1"
+	"net"
+	"testing"
+)
+
+func TestRedactor(t *testing.T) {
+	redactor := NewRedactor([]string{"Authorization", "x-api-key"}, []string{"contact", "chunk"})
+
+	t.Run("metadata", func(t *testing.T) {
+		// arrange
+		md := metadata.Pairs("authorization", "Bearer secret", "x-api-key", "dev-key", "x-tenant-id", "shop")
+
+		// act
+		redacted := redactor.Metadata(md)
+
+		// assert
+		assert.Equal(t, metadata.Pairs("authorization", Redacted, "x-api-key", Redacted, "x-tenant-id", "shop"), redacted)
+		assert.Equal(t, []string{"Bearer secret"}, md.Get("authorization"))
+	})
+
+	t.Run("nested string fields", func(t *testing.T) {
+		// arrange
+		response := &pbApi.SupplierListResponse{Suppliers: []*pbApi.SupplierListResponse_Supplier{
+			{Id: 1, Name: "Acme", Contact: "acme@example.com"},
+		}}
+
+		// act
+		redacted := redactor.Message(response).(*pbApi.SupplierListResponse)
+
+		// assert
+		assert.Equal(t, "Acme", redacted.GetSuppliers()[0].GetName())
+		assert.Equal(t, Redacted, redacted.GetSuppliers()[0].GetContact())
+		assert.Equal(t, "acme@example.com", response.GetSuppliers()[0].GetContact())
+	})
+
+	t.Run("bytes fields are cleared", func(t *testing.T) {
+		// arrange
+		request := &pbApi.UploadProductImageRequest{Data: &pbApi.UploadProductImageRequest_Chunk{Chunk: []byte{1, 2, 3}}}
+
+		// act
+		redacted := redactor.Message(request).(*pbApi.UploadProductImageRequest)
+
+		// assert
+		assert.Empty(t, redacted.GetChunk())
+		assert.Equal(t, []byte{1, 2, 3}, request.GetChunk())
+	})
+
+	t.Run("not a message", func(t *testing.T) {
+		// act
+		redacted := redactor.Message("plain")
+
+		// assert
+		assert.Equal(t, "plain", redacted)
+	})
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	hook := test.NewGlobal()
+	level := log.GetLevel()
+	log.SetLevel(log.DebugLevel)
+	t.Cleanup(func() {
+		log.SetLevel(level)
+		log.StandardLogger().ReplaceHooks(make(log.LevelHooks))
+	})
+
+	call := func(deps Deps, ctx context.Context, req interface{}, err error) error {
+		_, callErr := UnaryServerInterceptor(deps)(ctx, req, &grpc.UnaryServerInfo{FullMethod: "/api.v1.ApiService/SupplierCreate"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, err
+		})
+		return callErr
+	}
+	newDeps := func() Deps {
+		return Deps{Metrics: metrics.NewMetrics(), Redactor: NewRedactor([]string{"x-api-key"}, []string{"contact"})}
+	}
+
+	t.Run("logs the call redacted", func(t *testing.T) {
+		// arrange
+		hook.Reset()
+		deps := newDeps()
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-api-key", "dev-key"))
+		ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 51234}})
+		request := &pbApi.SupplierCreateRequest{Name: "Acme", Contact: "acme@example.com"}
+
+		// act
+		err := call(deps, ctx, request, nil)
+
+		// assert
+		require.NoError(t, err)
+		require.Len(t, hook.AllEntries(), 1)
+		entry := hook.LastEntry()
+		assert.Equal(t, log.InfoLevel, entry.Level)
+		assert.Equal(t, "/api.v1.ApiService/SupplierCreate", entry.Data["method"])
+		assert.Equal(t, "10.0.0.1:51234", entry.Data["peer"])
+		assert.Equal(t, "OK", entry.Data["code"])
+		assert.Equal(t, metadata.Pairs("x-api-key", Redacted), entry.Data["metadata"])
+		assert.Equal(t, Redacted, entry.Data["request"].(*pbApi.SupplierCreateRequest).GetContact())
+		assert.Equal(t, "1", deps.Metrics.SuccessfulRequestCounter.String())
+		assert.Equal(t, uint64(1), deps.Metrics.Requests.With("api.v1.ApiService", "SupplierCreate", "OK").Value())
+		assert.Equal(t, uint64(1), deps.Metrics.Latency.With("api.v1.ApiService", "SupplierCreate").Snapshot().Count)
+		assert.Zero(t, deps.Metrics.InFlight.Value())
+	})
+
+	t.Run("maps known errors", func(t *testing.T) {
+		// arrange
+		deps := newDeps()
+
+		// act
+		notFound := call(deps, context.Background(), nil, errors.Wrap(repository.ProductNotExists, "1"))
+		alreadyExists := call(deps, context.Background(), nil, repository.ProductAlreadyExists)
+		invalid := call(deps, context.Background(), nil, Invalid(errors.New("name length must be greater than 0")))
+		passed := call(deps, context.Background(), nil, status.Error(codes.PermissionDenied, "denied"))
+
+		// assert
+		assert.Equal(t, codes.NotFound, status.Code(notFound))
+		assert.Equal(t, "1: product does not exist", status.Convert(notFound).Message())
+		assert.Equal(t, codes.AlreadyExists, status.Code(alreadyExists))
+		assert.Equal(t, codes.InvalidArgument, status.Code(invalid))
+		assert.Equal(t, "name length must be greater than 0", status.Convert(invalid).Message())
+		assert.Equal(t, codes.PermissionDenied, status.Code(passed))
+		assert.Equal(t, "4", deps.Metrics.UnsuccessfulRequestCounter.String())
+	})
+
+	t.Run("hides unknown errors", func(t *testing.T) {
+		// arrange
+		hook.Reset()
+		deps := newDeps()
+
+		// act
+		err := call(deps, context.Background(), nil, errors.New("connection refused"))
+
+		// assert
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.Equal(t, "internal error", status.Convert(err).Message())
+		assert.Equal(t, "1", deps.Metrics.FailedRequestCounter.String())
+		assert.Equal(t, log.ErrorLevel, hook.LastEntry().Level)
+	})
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	// arrange
+	m := metrics.NewMetrics()
+	interceptor := UnaryClientInterceptor(m)
+	call := func(err error) error {
+		return interceptor(context.Background(), "/storage.v1.StorageService/ProductGet", nil, nil, nil, func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			return err
+		})
+	}
+
+	// act
+	notFound := call(status.Error(codes.NotFound, "product does not exist"))
+	unavailable := call(status.Error(codes.Unavailable, "connection refused"))
+
+	// assert
+	assert.Equal(t, codes.NotFound, status.Code(notFound))
+	assert.Equal(t, codes.Unknown, status.Code(unavailable))
+	assert.Equal(t, codes.Internal, status.Code(toStatus("/api.v1.ApiService/ProductGet", unavailable)))
+	assert.Equal(t, "2", m.OutgoingRequestCounter.String())
+}
@@ -0,0 +1,61 @@
+package interceptors
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+// logCall logs the method, peer, duration and status of a call at Info, server faults at Error.
+// The redacted metadata and request are added at Debug, the messages of a stream are logged
+// one by one by loggedStream.
+func logCall(ctx context.Context, redactor *Redactor, method string, start time.Time, req interface{}, err error) {
+	code := status.Code(err)
+	fields := log.Fields{
+		"method":   method,
+		"duration": time.Since(start).String(),
+		"code":     code.String(),
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		fields["peer"] = p.Addr.String()
+	}
+	if err != nil {
+		fields["error"] = status.Convert(err).Message()
+	}
+	if log.IsLevelEnabled(log.DebugLevel) {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			fields["metadata"] = redactor.Metadata(md)
+		}
+		if req != nil {
+			fields["request"] = redactor.Message(req)
+		}
+	}
+
+	entry := log.WithFields(fields)
+	if isServerFault(code) {
+		entry.Error("grpc call")
+		return
+	}
+	entry.Info("grpc call")
+}
+
+type loggedStream struct {
+	grpc.ServerStream
+	redactor *Redactor
+	method   string
+}
+
+func (s *loggedStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil && log.IsLevelEnabled(log.DebugLevel) {
+		log.WithFields(log.Fields{
+			"method":  s.method,
+			"request": s.redactor.Message(m),
+		}).Debug("grpc stream message")
+	}
+	return err
+}
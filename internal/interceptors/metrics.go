@@ -0,0 +1,42 @@
+package interceptors
+
+import (
+	"google.golang.org/grpc/codes"
+	"homework-1/internal/metrics"
+	"strings"
+	"time"
+)
+
+// record counts a finished call as successful, as a mistake of the caller or as a failure of
+// ours, and observes how long it took.
+func record(m *metrics.Metrics, fullMethod string, code codes.Code, start time.Time) {
+	service, method := splitMethod(fullMethod)
+	m.Requests.With(service, method, code.String()).Inc()
+	m.Latency.With(service, method).Observe(time.Since(start).Seconds())
+	switch {
+	case code == codes.OK:
+		m.SuccessfulRequestCounter.Inc()
+	case isServerFault(code):
+		m.FailedRequestCounter.Inc()
+	default:
+		m.UnsuccessfulRequestCounter.Inc()
+	}
+}
+
+// isServerFault tells the codes of failures on our side from the mistakes of callers.
+func isServerFault(code codes.Code) bool {
+	switch code {
+	case codes.Unknown, codes.Internal, codes.Unavailable, codes.DataLoss, codes.DeadlineExceeded, codes.Unimplemented:
+		return true
+	}
+	return false
+}
+
+// splitMethod splits /api.v1.ApiService/ProductGet into api.v1.ApiService and ProductGet.
+func splitMethod(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "unknown", fullMethod
+}
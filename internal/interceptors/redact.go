@@ -0,0 +1,96 @@
+package interceptors
+
+import (
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"strings"
+)
+
+// Redacted replaces the values that must not reach the logs.
+const Redacted = "[REDACTED]"
+
+// Redactor hides the values of metadata keys and message fields on its lists.
+type Redactor struct {
+	metadataKeys map[string]struct{}
+	fields       map[protoreflect.Name]struct{}
+}
+
+// NewRedactor redacts the metadata keys, matched case-insensitively, and the message fields,
+// matched by their proto name at any depth of a message.
+func NewRedactor(metadataKeys []string, fields []string) *Redactor {
+	r := &Redactor{
+		metadataKeys: make(map[string]struct{}, len(metadataKeys)),
+		fields:       make(map[protoreflect.Name]struct{}, len(fields)),
+	}
+	for _, key := range metadataKeys {
+		r.metadataKeys[strings.ToLower(key)] = struct{}{}
+	}
+	for _, field := range fields {
+		r.fields[protoreflect.Name(field)] = struct{}{}
+	}
+	return r
+}
+
+// Metadata returns a copy of md with the values of redacted keys replaced.
+func (r *Redactor) Metadata(md metadata.MD) metadata.MD {
+	redacted := make(metadata.MD, len(md))
+	for key, values := range md {
+		if _, ok := r.metadataKeys[strings.ToLower(key)]; ok {
+			redacted[key] = []string{Redacted}
+			continue
+		}
+		redacted[key] = append([]string(nil), values...)
+	}
+	return redacted
+}
+
+// Message returns a copy of a proto message with the redacted fields replaced, strings by
+// Redacted and the other kinds cleared. Values that are not proto messages are returned as is.
+func (r *Redactor) Message(value interface{}) interface{} {
+	msg, ok := value.(proto.Message)
+	if !ok || msg == nil {
+		return value
+	}
+	redacted := proto.Clone(msg)
+	r.redact(redacted.ProtoReflect())
+	return redacted
+}
+
+func (r *Redactor) redact(msg protoreflect.Message) {
+	var fields []protoreflect.FieldDescriptor
+	msg.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fields = append(fields, fd)
+		return true
+	})
+
+	for _, fd := range fields {
+		if _, ok := r.fields[fd.Name()]; ok {
+			if fd.Kind() == protoreflect.StringKind && fd.Cardinality() != protoreflect.Repeated {
+				msg.Set(fd, protoreflect.ValueOfString(Redacted))
+			} else {
+				msg.Clear(fd)
+			}
+			continue
+		}
+
+		switch {
+		case fd.IsMap():
+			if fd.MapValue().Kind() == protoreflect.MessageKind {
+				msg.Get(fd).Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+					r.redact(v.Message())
+					return true
+				})
+			}
+		case fd.IsList():
+			if fd.Kind() == protoreflect.MessageKind {
+				list := msg.Get(fd).List()
+				for i := 0; i < list.Len(); i++ {
+					r.redact(list.Get(i).Message())
+				}
+			}
+		case fd.Kind() == protoreflect.MessageKind:
+			r.redact(msg.Get(fd).Message())
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package locales
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Locale is a two-letter ISO 639-1 language code.
+type Locale string
+
+const (
+	English Locale = "en"
+	Russian Locale = "ru"
+
+	// Default is used when the client asks for no supported locale, product names are written in it.
+	Default = English
+)
+
+var ErrUnsupportedLocale = errors.New("unsupported locale")
+
+var supported = map[Locale]struct{}{
+	English: {},
+	Russian: {},
+}
+
+func (l Locale) String() string {
+	return string(l)
+}
+
+// Parse accepts a language tag such as "ru" or "ru-RU" and returns its supported locale.
+func Parse(tag string) (Locale, error) {
+	language, _, _ := strings.Cut(strings.TrimSpace(tag), "-")
+	locale := Locale(strings.ToLower(language))
+	if err := ValidateLocale(locale); err != nil {
+		return "", fmt.Errorf("%s: %w", tag, ErrUnsupportedLocale)
+	}
+	return locale, nil
+}
+
+func ValidateLocale(locale Locale) error {
+	if _, ok := supported[locale]; !ok {
+		return fmt.Errorf("%s: %w", locale, ErrUnsupportedLocale)
+	}
+	return nil
+}
+
+// Negotiate picks the supported locale with the highest weight from an Accept-Language value
+// like "ru-RU,ru;q=0.9,en;q=0.8", a single language code like Telegram sends works as well.
+func Negotiate(acceptLanguage string) Locale {
+	type candidate struct {
+		locale Locale
+		weight float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		locale, err := Parse(tag)
+		if err != nil {
+			continue
+		}
+
+		weight := 1.0
+		if params = strings.TrimSpace(params); strings.HasPrefix(params, "q=") {
+			if weight, err = strconv.ParseFloat(strings.TrimPrefix(params, "q="), 64); err != nil {
+				continue
+			}
+		}
+		if weight > 0 {
+			candidates = append(candidates, candidate{locale: locale, weight: weight})
+		}
+	}
+	if len(candidates) == 0 {
+		return Default
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].weight > candidates[j].weight
+	})
+	return candidates[0].locale
+}
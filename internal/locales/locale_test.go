@@ -0,0 +1,55 @@
+package locales
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	t.Run("highest weight wins", func(t *testing.T) {
+		// act
+		locale := Negotiate("de-DE,de;q=0.9,ru;q=0.5,en;q=0.8")
+
+		// assert
+		assert.Equal(t, English, locale)
+	})
+
+	t.Run("region is ignored", func(t *testing.T) {
+		// act
+		locale := Negotiate("ru-RU")
+
+		// assert
+		assert.Equal(t, Russian, locale)
+	})
+
+	t.Run("fallback to default", func(t *testing.T) {
+		// act
+		locale := Negotiate("de, fr;q=0.5")
+
+		// assert
+		assert.Equal(t, Default, locale)
+	})
+}
+
+func TestFromIncomingContext(t *testing.T) {
+	t.Run("header forwarded by gateway", func(t *testing.T) {
+		// arrange
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(gatewayMetadataKey, "ru,en;q=0.5"))
+
+		// act
+		locale := FromIncomingContext(ctx)
+
+		// assert
+		assert.Equal(t, Russian, locale)
+	})
+
+	t.Run("no metadata", func(t *testing.T) {
+		// act
+		locale := FromIncomingContext(context.Background())
+
+		// assert
+		assert.Equal(t, Default, locale)
+	})
+}
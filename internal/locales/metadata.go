@@ -0,0 +1,27 @@
+package locales
+
+import (
+	"context"
+	"google.golang.org/grpc/metadata"
+)
+
+// MetadataKey carries the requested locale between services in the Accept-Language format.
+const MetadataKey = "accept-language"
+
+// gatewayMetadataKey is the Accept-Language header forwarded by grpc-gateway.
+const gatewayMetadataKey = "grpcgateway-accept-language"
+
+// FromIncomingContext negotiates the locale of an incoming gRPC request.
+func FromIncomingContext(ctx context.Context) Locale {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, key := range []string{MetadataKey, gatewayMetadataKey} {
+		if values := md.Get(key); len(values) > 0 {
+			return Negotiate(values[0])
+		}
+	}
+	return Default
+}
+
+func AppendToOutgoingContext(ctx context.Context, locale Locale) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, MetadataKey, locale.String())
+}
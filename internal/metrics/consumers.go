@@ -0,0 +1,305 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"github.com/Shopify/sarama"
+	log "github.com/sirupsen/logrus"
+	"homework-1/internal/metrics/counters"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// ConsumersPath is the status page of the kafka consumer groups.
+const ConsumersPath = "/debug/consumers"
+
+// Consumers follows the kafka consumer groups: how far behind the topics they are,
+// how long a message takes to handle and how many messages come in per second.
+type Consumers struct {
+	// Messages counts handled messages by group, topic and result
+	Messages *counters.CounterVec
+	// Latency is the handling time of messages in seconds by group and topic
+	Latency *counters.HistogramVec
+	// Rate is the messages per second of the last minute by group and topic
+	Rate *counters.MeterVec
+
+	mu         sync.RWMutex
+	partitions map[partitionKey]*partitionState
+	now        func() time.Time
+}
+
+type partitionKey struct {
+	group     string
+	topic     string
+	partition int32
+}
+
+type partitionState struct {
+	// offset is the next offset the group commits, the one after the last handled and marked
+	// message, -1 until it is known
+	offset int64
+	// highWaterMark is the offset of the next message produced, refreshed from the brokers
+	highWaterMark int64
+	messages      uint64
+	failures      uint64
+	lastMessage   time.Time
+}
+
+// lag is how many messages the group has still to consume, -1 when the offset is unknown.
+func (p *partitionState) lag() int64 {
+	if p.offset < 0 {
+		return -1
+	}
+	if lag := p.highWaterMark - p.offset; lag > 0 {
+		return lag
+	}
+	return 0
+}
+
+const (
+	ResultOK     = "ok"
+	ResultFailed = "failed"
+)
+
+func NewConsumers() *Consumers {
+	return &Consumers{
+		Messages:   counters.NewCounterVec("group", "topic", "result"),
+		Latency:    counters.NewHistogramVec(counters.LatencyBuckets, "group", "topic"),
+		Rate:       counters.NewMeterVec("group", "topic"),
+		partitions: make(map[partitionKey]*partitionState),
+		now:        time.Now,
+	}
+}
+
+// Claimed registers a partition given to the group. The initial offset is the next offset
+// the group consumes, sarama reports the negative OffsetNewest and OffsetOldest when the
+// group has not committed an offset yet.
+func (c *Consumers) Claimed(group, topic string, partition int32, initialOffset, highWaterMark int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	state := c.partition(partitionKey{group: group, topic: topic, partition: partition})
+	if initialOffset >= 0 {
+		state.offset = initialOffset
+	}
+	state.raiseHighWaterMark(highWaterMark)
+}
+
+// Processed records a message handled and marked by the group, err is what the handling failed with.
+// Messages are marked after they are handled, the lag counts the ones being handled too.
+func (c *Consumers) Processed(group, topic string, partition int32, offset, highWaterMark int64, elapsed time.Duration, err error) {
+	result := ResultOK
+	if err != nil {
+		result = ResultFailed
+	}
+	c.Messages.With(group, topic, result).Inc()
+	c.Latency.With(group, topic).Observe(elapsed.Seconds())
+	c.Rate.With(group, topic).Mark()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	state := c.partition(partitionKey{group: group, topic: topic, partition: partition})
+	state.offset = offset + 1
+	state.raiseHighWaterMark(highWaterMark)
+	state.messages++
+	if err != nil {
+		state.failures++
+	}
+	state.lastMessage = c.now()
+}
+
+// Released forgets a partition the group gave up, e.g. to another instance in a rebalance,
+// so the instance no longer reports a lag it does not follow.
+func (c *Consumers) Released(group, topic string, partition int32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.partitions, partitionKey{group: group, topic: topic, partition: partition})
+}
+
+// Offsets tells the offsets of a partition, sarama.Client does.
+type Offsets interface {
+	GetOffset(topic string, partition int32, time int64) (int64, error)
+}
+
+// RefreshHighWaterMarks asks the brokers for the high water marks of the partitions the groups
+// consumed, so the lag of a group that gets no messages still follows the topic.
+func (c *Consumers) RefreshHighWaterMarks(offsets Offsets) error {
+	type topicPartition struct {
+		topic     string
+		partition int32
+	}
+
+	c.mu.RLock()
+	keys := make(map[topicPartition][]partitionKey)
+	for key := range c.partitions {
+		tp := topicPartition{topic: key.topic, partition: key.partition}
+		keys[tp] = append(keys[tp], key)
+	}
+	c.mu.RUnlock()
+
+	var firstErr error
+	for tp, partitionKeys := range keys {
+		highWaterMark, err := offsets.GetOffset(tp.topic, tp.partition, sarama.OffsetNewest)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("high water mark of %s/%d: %w", tp.topic, tp.partition, err)
+			}
+			continue
+		}
+
+		c.mu.Lock()
+		for _, key := range partitionKeys {
+			// the partition may have been released while the brokers were asked
+			if state, ok := c.partitions[key]; ok {
+				state.highWaterMark = highWaterMark
+			}
+		}
+		c.mu.Unlock()
+	}
+	return firstErr
+}
+
+// WatchHighWaterMarks refreshes the high water marks every interval until ctx is done.
+func (c *Consumers) WatchHighWaterMarks(ctx context.Context, offsets Offsets, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.RefreshHighWaterMarks(offsets); err != nil {
+				log.WithError(err).Warn("failed to refresh consumer lag")
+			}
+		}
+	}
+}
+
+// raiseHighWaterMark keeps the newest high water mark, the one a claim saw may be older
+// than the one refreshed from the brokers.
+func (p *partitionState) raiseHighWaterMark(highWaterMark int64) {
+	if highWaterMark > p.highWaterMark {
+		p.highWaterMark = highWaterMark
+	}
+}
+
+func (c *Consumers) partition(key partitionKey) *partitionState {
+	state, ok := c.partitions[key]
+	if !ok {
+		state = &partitionState{offset: -1}
+		c.partitions[key] = state
+	}
+	return state
+}
+
+// ConsumerStatus is the state of one partition of a group.
+type ConsumerStatus struct {
+	Group         string
+	Topic         string
+	Partition     int32
+	Offset        int64
+	HighWaterMark int64
+	Lag           int64
+	Messages      uint64
+	Failures      uint64
+	Rate          float64
+	LastMessage   time.Time
+}
+
+// Status lists the partitions ordered by group, topic and partition.
+func (c *Consumers) Status() []ConsumerStatus {
+	c.mu.RLock()
+	status := make([]ConsumerStatus, 0, len(c.partitions))
+	for key, state := range c.partitions {
+		status = append(status, ConsumerStatus{
+			Group:         key.group,
+			Topic:         key.topic,
+			Partition:     key.partition,
+			Offset:        state.offset,
+			HighWaterMark: state.highWaterMark,
+			Lag:           state.lag(),
+			Messages:      state.messages,
+			Failures:      state.failures,
+			LastMessage:   state.lastMessage,
+		})
+	}
+	c.mu.RUnlock()
+
+	sort.Slice(status, func(i, j int) bool {
+		if status[i].Group != status[j].Group {
+			return status[i].Group < status[j].Group
+		}
+		if status[i].Topic != status[j].Topic {
+			return status[i].Topic < status[j].Topic
+		}
+		return status[i].Partition < status[j].Partition
+	})
+	for i := range status {
+		status[i].Rate = c.Rate.With(status[i].Group, status[i].Topic).Rate()
+	}
+	return status
+}
+
+// ServeHTTP renders the status page as a plain text table.
+func (c *Consumers) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "GROUP\tTOPIC\tPARTITION\tOFFSET\tHIGH WATER MARK\tLAG\tMESSAGES\tFAILED\tMSG/S\tLAST MESSAGE")
+	for _, s := range c.Status() {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%d\t%s\t%d\t%d\t%.2f\t%s\n",
+			s.Group, s.Topic, s.Partition, unknown(s.Offset), s.HighWaterMark, unknown(s.Lag),
+			s.Messages, s.Failures, s.Rate, lastMessage(s.LastMessage, c.now()))
+	}
+	_ = tw.Flush()
+}
+
+func unknown(value int64) string {
+	if value < 0 {
+		return "-"
+	}
+	return strconv.FormatInt(value, 10)
+}
+
+func lastMessage(at time.Time, now time.Time) string {
+	if at.IsZero() {
+		return "never"
+	}
+	return now.Sub(at).Truncate(time.Second).String() + " ago"
+}
+
+func (c *Consumers) write(e *exposition) {
+	status := c.Status()
+	names := []string{"group", "topic", "partition"}
+
+	e.header("kafka_consumer_lag", "Messages a consumer group has still to consume by group, topic and partition.", "gauge")
+	for _, s := range status {
+		if s.Lag >= 0 {
+			e.sample("kafka_consumer_lag", names, partitionLabels(s), strconv.FormatInt(s.Lag, 10))
+		}
+	}
+	e.header("kafka_consumer_offset", "Next offset a consumer group commits by group, topic and partition.", "gauge")
+	for _, s := range status {
+		if s.Offset >= 0 {
+			e.sample("kafka_consumer_offset", names, partitionLabels(s), strconv.FormatInt(s.Offset, 10))
+		}
+	}
+	e.header("kafka_consumer_high_water_mark", "Offset of the next message produced by group, topic and partition.", "gauge")
+	for _, s := range status {
+		e.sample("kafka_consumer_high_water_mark", names, partitionLabels(s), strconv.FormatInt(s.HighWaterMark, 10))
+	}
+	e.counterVec("kafka_consumer_messages_total", "Messages handled by group, topic and result.", c.Messages)
+	e.histogramVec("kafka_consumer_processing_seconds", "Handling time of messages in seconds.", c.Latency)
+	e.meterVec("kafka_consumer_messages_per_second", "Messages per second of the last minute by group and topic.", c.Rate)
+}
+
+func partitionLabels(s ConsumerStatus) []string {
+	return []string{s.Group, s.Topic, strconv.FormatInt(int64(s.Partition), 10)}
+}
@@ -0,0 +1,145 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestConsumers(t *testing.T) {
+	t.Run("lag is the high water mark minus the next offset", func(t *testing.T) {
+		// arrange
+		c := NewConsumers()
+
+		// act
+		c.Claimed("productCreateConsuming", "productCreate", 0, 5, 12)
+		c.Processed("productCreateConsuming", "productCreate", 0, 5, 12, 20*time.Millisecond, nil)
+		c.Processed("productCreateConsuming", "productCreate", 0, 6, 14, 20*time.Millisecond, errors.New("boom"))
+
+		// assert
+		status := c.Status()
+		assert.Len(t, status, 1)
+		assert.Equal(t, int64(7), status[0].Offset)
+		assert.Equal(t, int64(14), status[0].HighWaterMark)
+		assert.Equal(t, int64(7), status[0].Lag)
+		assert.Equal(t, uint64(2), status[0].Messages)
+		assert.Equal(t, uint64(1), status[0].Failures)
+	})
+
+	t.Run("lag is unknown before the group committed an offset", func(t *testing.T) {
+		// arrange
+		c := NewConsumers()
+
+		// act
+		c.Claimed("productDeleteConsuming", "productDelete", 1, -2, 3)
+
+		// assert
+		status := c.Status()
+		assert.Equal(t, int64(-1), status[0].Offset)
+		assert.Equal(t, int64(-1), status[0].Lag)
+	})
+
+	t.Run("refreshed high water marks raise the lag of an idle group", func(t *testing.T) {
+		// arrange
+		c := NewConsumers()
+		c.Claimed("productCreateConsuming", "productCreate", 0, 5, 5)
+		c.Claimed("productUpdateConsuming", "productUpdate", 0, 2, 4)
+		offsets := fakeOffsets{"productCreate/0": 9}
+
+		// act
+		err := c.RefreshHighWaterMarks(offsets)
+
+		// assert
+		assert.EqualError(t, err, "high water mark of productUpdate/0: no leader")
+		status := c.Status()
+		assert.Equal(t, int64(9), status[0].HighWaterMark)
+		assert.Equal(t, int64(4), status[0].Lag)
+		assert.Equal(t, int64(4), status[1].HighWaterMark, "a failed refresh keeps the last high water mark")
+	})
+
+	t.Run("an older high water mark of a claim does not lower the refreshed one", func(t *testing.T) {
+		// arrange
+		c := NewConsumers()
+		c.Claimed("productCreateConsuming", "productCreate", 0, 5, 5)
+		assert.NoError(t, c.RefreshHighWaterMarks(fakeOffsets{"productCreate/0": 9}))
+
+		// act
+		c.Processed("productCreateConsuming", "productCreate", 0, 5, 6, 20*time.Millisecond, nil)
+
+		// assert
+		status := c.Status()
+		assert.Equal(t, int64(9), status[0].HighWaterMark)
+		assert.Equal(t, int64(3), status[0].Lag)
+	})
+
+	t.Run("released partitions are forgotten", func(t *testing.T) {
+		// arrange
+		c := NewConsumers()
+		c.Claimed("productCreateConsuming", "productCreate", 0, 5, 12)
+		c.Claimed("productCreateConsuming", "productCreate", 1, 3, 4)
+
+		// act
+		c.Released("productCreateConsuming", "productCreate", 0)
+
+		// assert
+		status := c.Status()
+		assert.Len(t, status, 1)
+		assert.Equal(t, int32(1), status[0].Partition)
+		assert.NoError(t, c.RefreshHighWaterMarks(fakeOffsets{"productCreate/1": 4}))
+		assert.Len(t, c.Status(), 1, "a refresh does not bring a released partition back")
+	})
+
+	t.Run("exposes the consumer families with the metrics", func(t *testing.T) {
+		// arrange
+		m := NewMetrics()
+		m.Consumers = NewConsumers()
+		m.Consumers.Claimed("productDeleteConsuming", "productDelete", 1, -2, 3)
+		m.Consumers.Processed("productCreateConsuming", "productCreate", 0, 9, 12, 20*time.Millisecond, nil)
+		w := httptest.NewRecorder()
+
+		// act
+		m.ServeHTTP(w, httptest.NewRequest(http.MethodGet, Path, nil))
+
+		// assert
+		body := w.Body.String()
+		assert.Contains(t, body, "# TYPE kafka_consumer_lag gauge\n"+
+			`kafka_consumer_lag{group="productCreateConsuming",topic="productCreate",partition="0"} 2`+"\n#")
+		assert.Contains(t, body, `kafka_consumer_offset{group="productCreateConsuming",topic="productCreate",partition="0"} 10`)
+		assert.Contains(t, body, `kafka_consumer_high_water_mark{group="productDeleteConsuming",topic="productDelete",partition="1"} 3`)
+		assert.Contains(t, body, `kafka_consumer_messages_total{group="productCreateConsuming",topic="productCreate",result="ok"} 1`)
+		assert.Contains(t, body, `kafka_consumer_processing_seconds_bucket{group="productCreateConsuming",topic="productCreate",le="0.025"} 1`)
+		assert.Contains(t, body, "# TYPE kafka_consumer_messages_per_second gauge\n")
+	})
+
+	t.Run("renders the status page", func(t *testing.T) {
+		// arrange
+		c := NewConsumers()
+		c.Claimed("productDeleteConsuming", "productDelete", 1, -2, 3)
+		c.Processed("productCreateConsuming", "productCreate", 0, 9, 12, 20*time.Millisecond, nil)
+		w := httptest.NewRecorder()
+
+		// act
+		c.ServeHTTP(w, httptest.NewRequest(http.MethodGet, ConsumersPath, nil))
+
+		// assert
+		lines := w.Body.String()
+		assert.Contains(t, lines, "GROUP                   TOPIC          PARTITION  OFFSET  HIGH WATER MARK  LAG")
+		assert.Regexp(t, `productCreateConsuming\s+productCreate\s+0\s+10\s+12\s+2\s+1\s+0\s+0\.02\s+0s ago`, lines)
+		assert.Regexp(t, `productDeleteConsuming\s+productDelete\s+1\s+-\s+3\s+-\s+0\s+0\s+0\.00\s+never`, lines)
+	})
+}
+
+// fakeOffsets are the high water marks by "<topic>/<partition>", other partitions have no leader.
+type fakeOffsets map[string]int64
+
+func (o fakeOffsets) GetOffset(topic string, partition int32, _ int64) (int64, error) {
+	offset, ok := o[fmt.Sprintf("%s/%d", topic, partition)]
+	if !ok {
+		return 0, errors.New("no leader")
+	}
+	return offset, nil
+}
@@ -2,5 +2,6 @@ package counters
 
 type Counter interface {
 	Inc()
+	Value() uint64
 	String() string
 }
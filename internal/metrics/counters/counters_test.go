@@ -0,0 +1,84 @@
+package counters
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestHistogram(t *testing.T) {
+	// arrange
+	histogram := NewHistogram([]float64{1, 0.1, 0.5})
+
+	// act
+	histogram.Observe(0.05)
+	histogram.Observe(0.1)
+	histogram.Observe(0.7)
+	histogram.Observe(3)
+	snapshot := histogram.Snapshot()
+
+	// assert
+	assert.Equal(t, []float64{0.1, 0.5, 1}, snapshot.Buckets)
+	assert.Equal(t, []uint64{2, 2, 3}, snapshot.Counts)
+	assert.Equal(t, uint64(4), snapshot.Count)
+	assert.InDelta(t, 3.85, snapshot.Sum, 1e-9)
+}
+
+func TestGauge(t *testing.T) {
+	// arrange
+	gauge := NewGauge()
+
+	// act
+	gauge.Inc()
+	gauge.Inc()
+	gauge.Dec()
+	gauge.Add(0.5)
+
+	// assert
+	assert.Equal(t, 1.5, gauge.Value())
+	assert.Equal(t, "1.5", gauge.String())
+}
+
+func TestCounterVec(t *testing.T) {
+	// arrange
+	vec := NewCounterVec("method", "code")
+
+	// act
+	vec.With("ProductGet", "OK").Inc()
+	vec.With("ProductGet", "OK").Inc()
+	vec.With("ProductGet", "NotFound").Inc()
+
+	// assert
+	var series []Labels
+	vec.Each(func(labels Labels, counter Counter) {
+		series = append(series, labels)
+	})
+	assert.Equal(t, []Labels{{"ProductGet", "NotFound"}, {"ProductGet", "OK"}}, series)
+	assert.Equal(t, uint64(2), vec.With("ProductGet", "OK").Value())
+	assert.Equal(t, `{"ProductGet,NotFound":1,"ProductGet,OK":2}`, vec.String())
+	assert.Panics(t, func() { vec.With("ProductGet") })
+}
+
+func TestMeter(t *testing.T) {
+	// arrange
+	now := time.Unix(1000, 0)
+	meter := NewMeter()
+	meter.now = func() time.Time { return now }
+
+	// act
+	for i := 0; i < 60; i++ {
+		meter.Mark()
+	}
+	now = now.Add(30 * time.Second)
+	for i := 0; i < 60; i++ {
+		meter.Mark()
+	}
+	rate := meter.Rate()
+	now = now.Add(45 * time.Second)
+
+	// assert
+	assert.Equal(t, 2.0, rate)
+	assert.Equal(t, 1.0, meter.Rate())
+	now = now.Add(time.Minute)
+	assert.Equal(t, 0.0, meter.Rate())
+}
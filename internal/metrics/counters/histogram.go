@@ -0,0 +1,70 @@
+package counters
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"sync/atomic"
+)
+
+// LatencyBuckets are the upper bounds in seconds for the latencies of calls, from 5 ms to 10 s.
+var LatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Histogram counts observations in buckets by their upper bounds.
+type Histogram struct {
+	buckets []float64
+	// counts holds the observations of each bucket alone, the last one is above every bound
+	counts  []uint64
+	count   uint64
+	sumBits uint64
+}
+
+// HistogramSnapshot is a histogram at one moment, Counts are cumulative as in Prometheus.
+type HistogramSnapshot struct {
+	Buckets []float64 `json:"buckets"`
+	Counts  []uint64  `json:"counts"`
+	Count   uint64    `json:"count"`
+	Sum     float64   `json:"sum"`
+}
+
+func NewHistogram(buckets []float64) *Histogram {
+	sorted := append([]float64(nil), buckets...)
+	sort.Float64s(sorted)
+	return &Histogram{
+		buckets: sorted,
+		counts:  make([]uint64, len(sorted)+1),
+	}
+}
+
+func (h *Histogram) Observe(value float64) {
+	i := sort.SearchFloat64s(h.buckets, value)
+	atomic.AddUint64(&h.counts[i], 1)
+	atomic.AddUint64(&h.count, 1)
+	for {
+		old := atomic.LoadUint64(&h.sumBits)
+		updated := math.Float64bits(math.Float64frombits(old) + value)
+		if atomic.CompareAndSwapUint64(&h.sumBits, old, updated) {
+			return
+		}
+	}
+}
+
+func (h *Histogram) Snapshot() HistogramSnapshot {
+	snapshot := HistogramSnapshot{
+		Buckets: h.buckets,
+		Counts:  make([]uint64, len(h.buckets)),
+		Count:   atomic.LoadUint64(&h.count),
+		Sum:     math.Float64frombits(atomic.LoadUint64(&h.sumBits)),
+	}
+	var cumulative uint64
+	for i := range h.buckets {
+		cumulative += atomic.LoadUint64(&h.counts[i])
+		snapshot.Counts[i] = cumulative
+	}
+	return snapshot
+}
+
+func (h *Histogram) String() string {
+	data, _ := json.Marshal(h.Snapshot())
+	return string(data)
+}
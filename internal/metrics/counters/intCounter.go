@@ -13,8 +13,12 @@ func (c *IntCounter) Inc() {
 	atomic.AddUint64(&c.cnt, 1)
 }
 
+func (c *IntCounter) Value() uint64 {
+	return atomic.LoadUint64(&c.cnt)
+}
+
 func (c *IntCounter) String() string {
-	return strconv.FormatUint(c.cnt, 10)
+	return strconv.FormatUint(c.Value(), 10)
 }
 
 func NewIntCounter() Counter {
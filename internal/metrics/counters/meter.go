@@ -0,0 +1,58 @@
+package counters
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Meter is the rate of events per second over the last minute.
+type Meter struct {
+	mu     sync.Mutex
+	counts []uint64
+	// seconds holds the unix second each slot of counts belongs to
+	seconds []int64
+	now     func() time.Time
+}
+
+const meterWindow = 60
+
+func NewMeter() *Meter {
+	return &Meter{
+		counts:  make([]uint64, meterWindow),
+		seconds: make([]int64, meterWindow),
+		now:     time.Now,
+	}
+}
+
+func (m *Meter) Mark() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	second := m.now().Unix()
+	i := second % meterWindow
+	if m.seconds[i] != second {
+		m.seconds[i] = second
+		m.counts[i] = 0
+	}
+	m.counts[i]++
+}
+
+// Rate is the events per second of the last minute, the current second included.
+func (m *Meter) Rate() float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now().Unix()
+	var total uint64
+	for i, second := range m.seconds {
+		if now-second < meterWindow {
+			total += m.counts[i]
+		}
+	}
+	return float64(total) / meterWindow
+}
+
+func (m *Meter) String() string {
+	return strconv.FormatFloat(m.Rate(), 'g', -1, 64)
+}
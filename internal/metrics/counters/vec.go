@@ -0,0 +1,163 @@
+package counters
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Labels are the values of the labels of one series, in the order of the names of its vec.
+type Labels []string
+
+// vec keeps one metric per set of label values, creating them on first use.
+type vec struct {
+	names   []string
+	mu      sync.RWMutex
+	metrics map[string]interface{}
+	labels  map[string]Labels
+}
+
+func newVec(names []string) vec {
+	return vec{names: names, metrics: make(map[string]interface{}), labels: make(map[string]Labels)}
+}
+
+func (v *vec) with(values []string, create func() interface{}) interface{} {
+	if len(values) != len(v.names) {
+		panic("counters: got " + strings.Join(values, ",") + " for labels " + strings.Join(v.names, ","))
+	}
+	key := strings.Join(values, "\xff")
+
+	v.mu.RLock()
+	metric, ok := v.metrics[key]
+	v.mu.RUnlock()
+	if ok {
+		return metric
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if metric, ok = v.metrics[key]; !ok {
+		metric = create()
+		v.metrics[key] = metric
+		v.labels[key] = append(Labels(nil), values...)
+	}
+	return metric
+}
+
+// each calls fn for every series ordered by their label values.
+func (v *vec) each(fn func(labels Labels, metric interface{})) {
+	v.mu.RLock()
+	keys := make([]string, 0, len(v.metrics))
+	for key := range v.metrics {
+		keys = append(keys, key)
+	}
+	v.mu.RUnlock()
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		v.mu.RLock()
+		labels, metric := v.labels[key], v.metrics[key]
+		v.mu.RUnlock()
+		fn(labels, metric)
+	}
+}
+
+func (v *vec) LabelNames() []string {
+	return v.names
+}
+
+// string publishes the series as a JSON object keyed by their label values joined with commas.
+func (v *vec) string(value func(metric interface{}) interface{}) string {
+	values := make(map[string]interface{})
+	v.each(func(labels Labels, metric interface{}) {
+		values[strings.Join(labels, ",")] = value(metric)
+	})
+	data, _ := json.Marshal(values)
+	return string(data)
+}
+
+// CounterVec is a counter per set of label values, like the calls by service, method and code.
+type CounterVec struct {
+	vec
+}
+
+func NewCounterVec(names ...string) *CounterVec {
+	return &CounterVec{vec: newVec(names)}
+}
+
+func (v *CounterVec) With(values ...string) Counter {
+	return v.with(values, func() interface{} { return &IntCounter{} }).(Counter)
+}
+
+func (v *CounterVec) Each(fn func(labels Labels, counter Counter)) {
+	v.each(func(labels Labels, metric interface{}) { fn(labels, metric.(Counter)) })
+}
+
+func (v *CounterVec) String() string {
+	return v.string(func(metric interface{}) interface{} { return metric.(Counter).Value() })
+}
+
+// HistogramVec is a histogram per set of label values, the series share their buckets.
+type HistogramVec struct {
+	vec
+	buckets []float64
+}
+
+func NewHistogramVec(buckets []float64, names ...string) *HistogramVec {
+	return &HistogramVec{vec: newVec(names), buckets: buckets}
+}
+
+func (v *HistogramVec) With(values ...string) *Histogram {
+	return v.with(values, func() interface{} { return NewHistogram(v.buckets) }).(*Histogram)
+}
+
+func (v *HistogramVec) Each(fn func(labels Labels, histogram *Histogram)) {
+	v.each(func(labels Labels, metric interface{}) { fn(labels, metric.(*Histogram)) })
+}
+
+func (v *HistogramVec) String() string {
+	return v.string(func(metric interface{}) interface{} { return metric.(*Histogram).Snapshot() })
+}
+
+// GaugeVec is a gauge per set of label values.
+type GaugeVec struct {
+	vec
+}
+
+func NewGaugeVec(names ...string) *GaugeVec {
+	return &GaugeVec{vec: newVec(names)}
+}
+
+func (v *GaugeVec) With(values ...string) *Gauge {
+	return v.with(values, func() interface{} { return NewGauge() }).(*Gauge)
+}
+
+func (v *GaugeVec) Each(fn func(labels Labels, gauge *Gauge)) {
+	v.each(func(labels Labels, metric interface{}) { fn(labels, metric.(*Gauge)) })
+}
+
+func (v *GaugeVec) String() string {
+	return v.string(func(metric interface{}) interface{} { return metric.(*Gauge).Value() })
+}
+
+// MeterVec is a meter per set of label values.
+type MeterVec struct {
+	vec
+}
+
+func NewMeterVec(names ...string) *MeterVec {
+	return &MeterVec{vec: newVec(names)}
+}
+
+func (v *MeterVec) With(values ...string) *Meter {
+	return v.with(values, func() interface{} { return NewMeter() }).(*Meter)
+}
+
+func (v *MeterVec) Each(fn func(labels Labels, meter *Meter)) {
+	v.each(func(labels Labels, metric interface{}) { fn(labels, metric.(*Meter)) })
+}
+
+func (v *MeterVec) String() string {
+	return v.string(func(metric interface{}) interface{} { return metric.(*Meter).Rate() })
+}
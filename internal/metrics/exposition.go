@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"fmt"
+	"homework-1/internal/metrics/counters"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ContentType is the version 0.0.4 text format of Prometheus.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// exposition writes metric families in the Prometheus text format. Write errors mean the
+// scraper went away, they are ignored like net/http ignores them.
+type exposition struct {
+	w io.Writer
+}
+
+func (e *exposition) header(name, help, kind string) {
+	fmt.Fprintf(e.w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func (e *exposition) sample(name string, names []string, values []string, value string) {
+	fmt.Fprintf(e.w, "%s%s %s\n", name, labels(names, values), value)
+}
+
+func (e *exposition) counter(name, help string, counter counters.Counter) {
+	e.header(name, help, "counter")
+	e.sample(name, nil, nil, strconv.FormatUint(counter.Value(), 10))
+}
+
+func (e *exposition) gauge(name, help string, gauge *counters.Gauge) {
+	e.header(name, help, "gauge")
+	e.sample(name, nil, nil, formatFloat(gauge.Value()))
+}
+
+func (e *exposition) counterVec(name, help string, vec *counters.CounterVec) {
+	e.header(name, help, "counter")
+	vec.Each(func(values counters.Labels, counter counters.Counter) {
+		e.sample(name, vec.LabelNames(), values, strconv.FormatUint(counter.Value(), 10))
+	})
+}
+
+func (e *exposition) gaugeVec(name, help string, vec *counters.GaugeVec) {
+	e.header(name, help, "gauge")
+	vec.Each(func(values counters.Labels, gauge *counters.Gauge) {
+		e.sample(name, vec.LabelNames(), values, formatFloat(gauge.Value()))
+	})
+}
+
+func (e *exposition) meterVec(name, help string, vec *counters.MeterVec) {
+	e.header(name, help, "gauge")
+	vec.Each(func(values counters.Labels, meter *counters.Meter) {
+		e.sample(name, vec.LabelNames(), values, formatFloat(meter.Rate()))
+	})
+}
+
+func (e *exposition) histogramVec(name, help string, vec *counters.HistogramVec) {
+	e.header(name, help, "histogram")
+	names := append(append([]string(nil), vec.LabelNames()...), "le")
+	vec.Each(func(values counters.Labels, histogram *counters.Histogram) {
+		snapshot := histogram.Snapshot()
+		for i, bound := range snapshot.Buckets {
+			e.sample(name+"_bucket", names, append(append([]string(nil), values...), formatFloat(bound)), strconv.FormatUint(snapshot.Counts[i], 10))
+		}
+		e.sample(name+"_bucket", names, append(append([]string(nil), values...), "+Inf"), strconv.FormatUint(snapshot.Count, 10))
+		e.sample(name+"_sum", vec.LabelNames(), values, formatFloat(snapshot.Sum))
+		e.sample(name+"_count", vec.LabelNames(), values, strconv.FormatUint(snapshot.Count, 10))
+	})
+}
+
+func labels(names []string, values []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	pairs := make([]string, 0, len(names))
+	for i, name := range names {
+		pairs = append(pairs, name+`="`+labelEscaper.Replace(values[i])+`"`)
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -3,8 +3,13 @@ package metrics
 import (
 	"expvar"
 	"homework-1/internal/metrics/counters"
+	"net/http"
 )
 
+// Path is where the stat servers expose the metrics in the Prometheus text format,
+// expvar keeps serving the unlabeled counters and RequestsByMethod on /debug/vars.
+const Path = "/metrics"
+
 type Metrics struct {
 	IncomingRequestCounter     counters.Counter
 	OutgoingRequestCounter     counters.Counter
@@ -13,6 +18,14 @@ type Metrics struct {
 	FailedRequestCounter       counters.Counter
 	CacheHitCounter            counters.Counter
 	CacheMissCounter           counters.Counter
+	// Requests counts finished calls by grpc_service, grpc_method and grpc_code
+	Requests *counters.CounterVec
+	// Latency is the handling time of calls in seconds by grpc_service and grpc_method
+	Latency *counters.HistogramVec
+	// InFlight is the number of calls being handled
+	InFlight *counters.Gauge
+	// Consumers follows the kafka consumer groups of the service, nil when it has none
+	Consumers *Consumers
 }
 
 func NewMetrics() *Metrics {
@@ -24,9 +37,13 @@ func NewMetrics() *Metrics {
 		FailedRequestCounter:       counters.NewIntCounter(),
 		CacheHitCounter:            counters.NewIntCounter(),
 		CacheMissCounter:           counters.NewIntCounter(),
+		Requests:                   counters.NewCounterVec("grpc_service", "grpc_method", "grpc_code"),
+		Latency:                    counters.NewHistogramVec(counters.LatencyBuckets, "grpc_service", "grpc_method"),
+		InFlight:                   counters.NewGauge(),
 	}
 }
 
+// Publish serves the metrics on the default mux, the stat servers listen with it.
 func (m *Metrics) Publish() {
 	expvar.Publish("IncomingRequestCounter", m.IncomingRequestCounter)
 	expvar.Publish("OutgoingRequestCounter", m.OutgoingRequestCounter)
@@ -35,4 +52,38 @@ func (m *Metrics) Publish() {
 	expvar.Publish("FailedRequestCounter", m.FailedRequestCounter)
 	expvar.Publish("CacheHitCounter", m.CacheHitCounter)
 	expvar.Publish("CacheMissCounter", m.CacheMissCounter)
+	expvar.Publish("RequestsByMethod", expvar.Func(func() interface{} { return m.RequestsByMethod() }))
+	http.Handle(Path, m)
+	if m.Consumers != nil {
+		http.Handle(ConsumersPath, m.Consumers)
+	}
+}
+
+// RequestsByMethod counts finished calls by "<full method> <code>", the expvar view of Requests
+// that dashboards built on /debug/vars read.
+func (m *Metrics) RequestsByMethod() map[string]uint64 {
+	requests := make(map[string]uint64)
+	m.Requests.Each(func(labels counters.Labels, counter counters.Counter) {
+		requests["/"+labels[0]+"/"+labels[1]+" "+labels[2]] = counter.Value()
+	})
+	return requests
+}
+
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", ContentType)
+
+	e := &exposition{w: w}
+	e.counter("incoming_requests_total", "Calls received.", m.IncomingRequestCounter)
+	e.counter("outgoing_requests_total", "Calls made to other services.", m.OutgoingRequestCounter)
+	e.counter("successful_requests_total", "Calls answered with OK.", m.SuccessfulRequestCounter)
+	e.counter("unsuccessful_requests_total", "Calls refused because of a mistake of the caller.", m.UnsuccessfulRequestCounter)
+	e.counter("failed_requests_total", "Calls failed on our side.", m.FailedRequestCounter)
+	e.counter("cache_hits_total", "Cache lookups that found a value.", m.CacheHitCounter)
+	e.counter("cache_misses_total", "Cache lookups that found nothing.", m.CacheMissCounter)
+	e.counterVec("grpc_server_handled_total", "Calls finished by service, method and status code.", m.Requests)
+	e.histogramVec("grpc_server_handling_seconds", "Handling time of calls in seconds.", m.Latency)
+	e.gauge("grpc_server_in_flight_calls", "Calls being handled.", m.InFlight)
+	if m.Consumers != nil {
+		m.Consumers.write(e)
+	}
 }
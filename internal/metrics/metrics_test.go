@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestServeHTTP(t *testing.T) {
+	// arrange
+	m := NewMetrics()
+	m.IncomingRequestCounter.Inc()
+	m.Requests.With("api.v1.ApiService", "ProductGet", "NotFound").Inc()
+	m.Latency.With("api.v1.ApiService", "ProductGet").Observe(0.02)
+	w := httptest.NewRecorder()
+
+	// act
+	m.ServeHTTP(w, httptest.NewRequest(http.MethodGet, Path, nil))
+
+	// assert
+	body := w.Body.String()
+	assert.Equal(t, ContentType, w.Header().Get("Content-Type"))
+	assert.Contains(t, body, "# TYPE incoming_requests_total counter\nincoming_requests_total 1\n")
+	assert.Contains(t, body, `grpc_server_handled_total{grpc_service="api.v1.ApiService",grpc_method="ProductGet",grpc_code="NotFound"} 1`)
+	assert.Contains(t, body, "# TYPE grpc_server_handling_seconds histogram\n")
+	assert.Contains(t, body, `grpc_server_handling_seconds_bucket{grpc_service="api.v1.ApiService",grpc_method="ProductGet",le="0.01"} 0`)
+	assert.Contains(t, body, `grpc_server_handling_seconds_bucket{grpc_service="api.v1.ApiService",grpc_method="ProductGet",le="0.025"} 1`)
+	assert.Contains(t, body, `grpc_server_handling_seconds_bucket{grpc_service="api.v1.ApiService",grpc_method="ProductGet",le="+Inf"} 1`)
+	assert.Contains(t, body, `grpc_server_handling_seconds_count{grpc_service="api.v1.ApiService",grpc_method="ProductGet"} 1`)
+	assert.Contains(t, body, "grpc_server_in_flight_calls 0\n")
+}
+
+func TestRequestsByMethod(t *testing.T) {
+	// arrange
+	m := NewMetrics()
+	m.Requests.With("api.v1.ApiService", "ProductGet", "NotFound").Inc()
+	m.Requests.With("api.v1.ApiService", "ProductGet", "OK").Inc()
+	m.Requests.With("api.v1.ApiService", "ProductGet", "OK").Inc()
+
+	// act
+	result := m.RequestsByMethod()
+
+	// assert
+	assert.Equal(t, map[string]uint64{
+		"/api.v1.ApiService/ProductGet NotFound": 1,
+		"/api.v1.ApiService/ProductGet OK":       2,
+	}, result)
+}
+
+func TestLabelsEscaping(t *testing.T) {
+	// act
+	result := labels([]string{"reason"}, []string{"a \"quoted\"\\path\n"})
+
+	// assert
+	assert.Equal(t, `{reason="a \"quoted\"\\path\n"}`, result)
+}
@@ -0,0 +1,52 @@
+package attributes
+
+import "strconv"
+
+// Attributes are custom product attributes, values are string, float64 or bool
+// the same way they come out of a JSON object.
+type Attributes map[string]interface{}
+
+// Matches reports whether every attribute of the filter has the same value in a.
+func (a Attributes) Matches(filter Attributes) bool {
+	for key, want := range filter {
+		if got, ok := a[key]; !ok || got != want {
+			return false
+		}
+	}
+	return true
+}
+
+// Strings formats the values for transports that carry attributes as strings.
+func (a Attributes) Strings() map[string]string {
+	if len(a) == 0 {
+		return nil
+	}
+	res := make(map[string]string, len(a))
+	for key, value := range a {
+		res[key] = FormatValue(value)
+	}
+	return res
+}
+
+func (a Attributes) Copy() Attributes {
+	if a == nil {
+		return nil
+	}
+	res := make(Attributes, len(a))
+	for key, value := range a {
+		res[key] = value
+	}
+	return res
+}
+
+func FormatValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return ""
+}
@@ -0,0 +1,163 @@
+package attributes
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+type Type string
+
+const (
+	String Type = "string"
+	Number Type = "number"
+	Bool   Type = "bool"
+)
+
+// Definition describes a single attribute of a category.
+type Definition struct {
+	Type     Type
+	Required bool
+}
+
+// Schema maps attribute keys of a category to their definitions.
+type Schema map[string]Definition
+
+// parse converts the string representation of an attribute value into its typed value.
+func (d Definition) parse(key, raw string) (interface{}, error) {
+	switch d.Type {
+	case String:
+		return raw, nil
+	case Number:
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s=%q: %w: expected %s", key, raw, ErrInvalidType, d.Type)
+		}
+		return v, nil
+	case Bool:
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s=%q: %w: expected %s", key, raw, ErrInvalidType, d.Type)
+		}
+		return v, nil
+	}
+	return nil, fmt.Errorf("%s: %w", d.Type, ErrUnknownType)
+}
+
+// Registry holds the attribute schemas of product categories.
+type Registry struct {
+	mu      sync.RWMutex
+	schemas map[string]Schema
+}
+
+func NewRegistry() *Registry {
+	return &Registry{schemas: make(map[string]Schema)}
+}
+
+// DefaultRegistry returns a registry with the schemas of the built-in categories.
+func DefaultRegistry() *Registry {
+	r := NewRegistry()
+	_ = r.Register("electronics", Schema{
+		"voltage":  {Type: Number, Required: true},
+		"wattage":  {Type: Number},
+		"wireless": {Type: Bool},
+	})
+	_ = r.Register("clothing", Schema{
+		"fabric": {Type: String, Required: true},
+		"size":   {Type: String},
+	})
+	return r
+}
+
+// Register adds or replaces the schema of the category.
+func (r *Registry) Register(category string, schema Schema) error {
+	if err := ValidateSchema(category, schema); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.schemas[category] = schema
+	return nil
+}
+
+func (r *Registry) Schema(category string) (Schema, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	schema, ok := r.schemas[category]
+	return schema, ok
+}
+
+// Parse types the attributes of a product in the category and checks that required keys are present.
+// Products without a category can't have attributes.
+func (r *Registry) Parse(category string, raw map[string]string) (Attributes, error) {
+	if category == "" {
+		if len(raw) > 0 {
+			return nil, ErrCategoryRequired
+		}
+		return nil, nil
+	}
+
+	schema, err := r.schema(category)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range sortedKeys(schema) {
+		if _, ok := raw[key]; !ok && schema[key].Required {
+			return nil, fmt.Errorf("%s: %w", key, ErrMissingAttribute)
+		}
+	}
+	return schema.parseAll(category, raw)
+}
+
+// Validate checks typed attributes, for example attributes stored before the category changed.
+func (r *Registry) Validate(category string, attrs Attributes) error {
+	raw := make(map[string]string, len(attrs))
+	for key, value := range attrs {
+		raw[key] = FormatValue(value)
+	}
+	_, err := r.Parse(category, raw)
+	return err
+}
+
+// ParseFilter types the attributes of a product list filter, required keys may be omitted.
+func (r *Registry) ParseFilter(category string, raw map[string]string) (Attributes, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	if category == "" {
+		return nil, ErrCategoryRequired
+	}
+
+	schema, err := r.schema(category)
+	if err != nil {
+		return nil, err
+	}
+	return schema.parseAll(category, raw)
+}
+
+func (r *Registry) schema(category string) (Schema, error) {
+	schema, ok := r.Schema(category)
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", category, ErrUnknownCategory)
+	}
+	return schema, nil
+}
+
+func (s Schema) parseAll(category string, raw map[string]string) (Attributes, error) {
+	attrs := make(Attributes, len(raw))
+	for _, key := range sortedKeys(raw) {
+		definition, ok := s[key]
+		if !ok {
+			return nil, fmt.Errorf("%s.%s: %w", category, key, ErrUnknownAttribute)
+		}
+		value, err := definition.parse(key, raw[key])
+		if err != nil {
+			return nil, err
+		}
+		attrs[key] = value
+	}
+	return attrs, nil
+}
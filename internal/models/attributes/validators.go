@@ -0,0 +1,40 @@
+package attributes
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var (
+	ErrUnknownCategory  = errors.New("unknown category")
+	ErrCategoryRequired = errors.New("attributes require a category")
+	ErrUnknownAttribute = errors.New("unknown attribute")
+	ErrMissingAttribute = errors.New("required attribute is missing")
+	ErrInvalidType      = errors.New("invalid attribute type")
+	ErrUnknownType      = errors.New("unknown attribute type")
+)
+
+func ValidateSchema(category string, schema Schema) error {
+	if len(category) == 0 {
+		return errors.New("category must not be empty")
+	}
+	for _, key := range sortedKeys(schema) {
+		switch schema[key].Type {
+		case String, Number, Bool:
+		default:
+			return fmt.Errorf("%s.%s: %s: %w", category, key, schema[key].Type, ErrUnknownType)
+		}
+	}
+	return nil
+}
+
+// sortedKeys makes validation errors deterministic when several keys are wrong.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,108 @@
+package changes
+
+import (
+	"fmt"
+	"homework-1/internal/models/products"
+	"math/bits"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusApproved Status = "approved"
+	StatusRejected Status = "rejected"
+)
+
+// PriceChange is a product update held back until a second user approves it.
+type PriceChange struct {
+	Id          uint64     `db:"id" json:"id"`
+	ProductId   uint64     `db:"product_id" json:"product_id"`
+	Name        string     `db:"name" json:"name"`
+	OldPrice    uint64     `db:"old_price" json:"old_price"`
+	Price       uint64     `db:"price" json:"price"`
+	Quantity    uint64     `db:"quantity" json:"quantity"`
+	Status      Status     `db:"status" json:"status"`
+	RequestedBy string     `db:"requested_by" json:"requested_by"`
+	ResolvedBy  string     `db:"resolved_by" json:"resolved_by"`
+	CreatedAt   time.Time  `db:"created_at" json:"created_at"`
+	ResolvedAt  *time.Time `db:"resolved_at" json:"resolved_at"`
+}
+
+// RequiresApproval reports whether moving from oldPrice to newPrice changes the price
+// by more than thresholdPercent percent. A zero threshold disables approvals.
+func RequiresApproval(oldPrice, newPrice, thresholdPercent uint64) bool {
+	if thresholdPercent == 0 || oldPrice == newPrice {
+		return false
+	}
+
+	diff := newPrice - oldPrice
+	if oldPrice > newPrice {
+		diff = oldPrice - newPrice
+	}
+	// diff*100 > oldPrice*thresholdPercent in 128 bits, large prices overflow 64
+	changeHi, changeLo := bits.Mul64(diff, 100)
+	limitHi, limitLo := bits.Mul64(oldPrice, thresholdPercent)
+	return changeHi > limitHi || changeHi == limitHi && changeLo > limitLo
+}
+
+func NewPriceChange(product *products.Product, name string, price, quantity uint64, requestedBy string) *PriceChange {
+	return &PriceChange{
+		ProductId:   product.GetId(),
+		Name:        name,
+		OldPrice:    product.GetPrice(),
+		Price:       price,
+		Quantity:    quantity,
+		Status:      StatusPending,
+		RequestedBy: requestedBy,
+		CreatedAt:   time.Now(),
+	}
+}
+
+func (c *PriceChange) IsPending() bool {
+	return c.Status == StatusPending
+}
+
+func (c *PriceChange) Approve(approver string) error {
+	return c.resolve(StatusApproved, approver)
+}
+
+func (c *PriceChange) Reject(approver string) error {
+	return c.resolve(StatusRejected, approver)
+}
+
+// Apply sets the approved price on the product. Only the price waits for approval, the name
+// and quantity of the update were applied when it was requested and may have changed since.
+func (c *PriceChange) Apply(product *products.Product) {
+	product.Price = c.Price
+}
+
+func (c *PriceChange) String() string {
+	return fmt.Sprintf("#%d product:%d name:%s price:%d -> %d quantity:%d status:%s by:%s",
+		c.Id, c.ProductId, c.Name, c.OldPrice, c.Price, c.Quantity, c.Status, c.RequestedBy)
+}
+
+func (c *PriceChange) Copy() *PriceChange {
+	change := *c
+	if c.ResolvedAt != nil {
+		resolvedAt := *c.ResolvedAt
+		change.ResolvedAt = &resolvedAt
+	}
+	return &change
+}
+
+func (c *PriceChange) resolve(status Status, approver string) error {
+	if err := ValidateApprover(c.RequestedBy, approver); err != nil {
+		return err
+	}
+	if !c.IsPending() {
+		return fmt.Errorf("%d: %w", c.Id, ErrNotPending)
+	}
+
+	now := time.Now()
+	c.Status = status
+	c.ResolvedBy = approver
+	c.ResolvedAt = &now
+	return nil
+}
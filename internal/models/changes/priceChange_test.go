@@ -0,0 +1,37 @@
+package changes
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestRequiresApproval(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		oldPrice  uint64
+		newPrice  uint64
+		threshold uint64
+		want      bool
+	}{
+		{name: "raise above the threshold", oldPrice: 100, newPrice: 121, threshold: 20, want: true},
+		{name: "raise at the threshold", oldPrice: 100, newPrice: 120, threshold: 20},
+		{name: "cut above the threshold", oldPrice: 100, newPrice: 79, threshold: 20, want: true},
+		{name: "cut at the threshold", oldPrice: 100, newPrice: 80, threshold: 20},
+		{name: "same price", oldPrice: 100, newPrice: 100, threshold: 20},
+		{name: "zero threshold disables approvals", oldPrice: 100, newPrice: 1000},
+		{name: "price of a free product", oldPrice: 0, newPrice: 1, threshold: 20, want: true},
+		{name: "large price raised above the threshold", oldPrice: math.MaxUint64 / 4, newPrice: math.MaxUint64 / 2, threshold: 20, want: true},
+		{name: "large price raised within the threshold", oldPrice: math.MaxUint64 / 2, newPrice: math.MaxUint64/2 + math.MaxUint64/20, threshold: 20},
+		{name: "large price cut to zero", oldPrice: math.MaxUint64, newPrice: 0, threshold: 99, want: true},
+		{name: "threshold above 100 percent", oldPrice: math.MaxUint64 / 2, newPrice: math.MaxUint64, threshold: 150},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// act
+			res := RequiresApproval(tc.oldPrice, tc.newPrice, tc.threshold)
+
+			// assert
+			assert.Equal(t, tc.want, res)
+		})
+	}
+}
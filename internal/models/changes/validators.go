@@ -0,0 +1,19 @@
+package changes
+
+import "errors"
+
+var (
+	ErrNotPending    = errors.New("change is not pending")
+	ErrSelfApproval  = errors.New("change must be resolved by another user")
+	ErrEmptyApprover = errors.New("approver must not be empty")
+)
+
+func ValidateApprover(requestedBy, approver string) error {
+	if len(approver) == 0 {
+		return ErrEmptyApprover
+	}
+	if approver == requestedBy {
+		return ErrSelfApproval
+	}
+	return nil
+}
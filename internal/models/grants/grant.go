@@ -0,0 +1,40 @@
+package grants
+
+import (
+	"fmt"
+	"homework-1/internal/policy"
+	"time"
+)
+
+// Grant gives a Telegram user a role in the bot, admins grant and revoke them with bot commands.
+type Grant struct {
+	UserId    int64       `db:"user_id" json:"user_id"`
+	Role      policy.Role `db:"role" json:"role"`
+	GrantedBy string      `db:"granted_by" json:"granted_by"`
+	GrantedAt time.Time   `db:"granted_at" json:"granted_at"`
+}
+
+func NewGrant(userId int64, role string, grantedBy string) (*Grant, error) {
+	if err := ValidateUserId(userId); err != nil {
+		return nil, err
+	}
+	parsed, err := policy.ParseRole(role)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Grant{
+		UserId:    userId,
+		Role:      parsed,
+		GrantedBy: grantedBy,
+	}, nil
+}
+
+func (g *Grant) String() string {
+	return fmt.Sprintf("%d: %s, granted by %s at %s", g.UserId, g.Role, g.GrantedBy, g.GrantedAt.Format(time.RFC3339))
+}
+
+func (g *Grant) Copy() *Grant {
+	grant := *g
+	return &grant
+}
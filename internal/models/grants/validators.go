@@ -0,0 +1,15 @@
+package grants
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidUserId = errors.New("invalid Telegram user id, expected a positive number")
+
+func ValidateUserId(userId int64) error {
+	if userId <= 0 {
+		return fmt.Errorf("%d: %w", userId, ErrInvalidUserId)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package images
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// MaxSize is the largest image accepted for upload, 5 MiB.
+const MaxSize = 5 << 20
+
+// Image is the metadata of a product image, the content lives in the blob store under Key.
+type Image struct {
+	Id          uint64 `db:"id" json:"id"`
+	ProductId   uint64 `db:"product_id" json:"product_id"`
+	Key         string `db:"key" json:"key"`
+	ContentType string `db:"content_type" json:"content_type"`
+	Size        uint64 `db:"size" json:"size"`
+	// Checksum is the hex encoded SHA-256 of the content
+	Checksum  string    `db:"checksum" json:"checksum"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+func NewImage(productId uint64, contentType string) (*Image, error) {
+	if err := ValidateContentType(contentType); err != nil {
+		return nil, err
+	}
+
+	key, err := NewKey(productId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Image{
+		ProductId:   productId,
+		Key:         key,
+		ContentType: contentType,
+	}, nil
+}
+
+// NewKey returns a random blob key grouped by product, so keys of different uploads never collide.
+func NewKey(productId uint64) (string, error) {
+	suffix := make([]byte, 16)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", fmt.Errorf("images.NewKey: %w", err)
+	}
+	return fmt.Sprintf("products/%d/%s", productId, hex.EncodeToString(suffix)), nil
+}
+
+func (i *Image) String() string {
+	return fmt.Sprintf("image:%d product:%d type:%s size:%d", i.Id, i.ProductId, i.ContentType, i.Size)
+}
+
+func (i *Image) Copy() *Image {
+	image := *i
+	return &image
+}
@@ -0,0 +1,36 @@
+package images
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrUnsupportedContentType = errors.New("unsupported image content type, expected image/jpeg, image/png, image/gif or image/webp")
+	ErrEmptyImage             = errors.New("image must not be empty")
+	ErrImageTooLarge          = fmt.Errorf("image must not be larger than %d bytes", MaxSize)
+)
+
+var contentTypes = map[string]struct{}{
+	"image/jpeg": {},
+	"image/png":  {},
+	"image/gif":  {},
+	"image/webp": {},
+}
+
+func ValidateContentType(contentType string) error {
+	if _, ok := contentTypes[contentType]; !ok {
+		return fmt.Errorf("%q: %w", contentType, ErrUnsupportedContentType)
+	}
+	return nil
+}
+
+func ValidateSize(size uint64) error {
+	if size == 0 {
+		return ErrEmptyImage
+	}
+	if size > MaxSize {
+		return ErrImageTooLarge
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package lots
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// DateLayout is the expiry date format used by the api and the bot.
+const DateLayout = "2006-01-02"
+
+// Lot is a batch of a product received together and sharing one expiry date.
+// The product quantity is the sum of its lots plus stock that is not tracked by lot.
+type Lot struct {
+	Id        uint64    `db:"id" json:"id"`
+	ProductId uint64    `db:"product_id" json:"product_id"`
+	Number    string    `db:"number" json:"number"`
+	Quantity  uint64    `db:"quantity" json:"quantity"`
+	ExpiresAt time.Time `db:"expires_at" json:"expires_at"`
+}
+
+// Picked is the quantity a reservation took out of a lot, releasing the reservation puts it back.
+type Picked struct {
+	LotId    uint64 `json:"lot_id"`
+	Quantity uint64 `json:"quantity"`
+}
+
+func NewLot(productId uint64, number string, quantity uint64, expiresAt time.Time) (*Lot, error) {
+	if err := ValidateNumber(number); err != nil {
+		return nil, err
+	}
+	if err := ValidateQuantity(quantity); err != nil {
+		return nil, err
+	}
+	if err := ValidateExpiresAt(expiresAt); err != nil {
+		return nil, err
+	}
+
+	return &Lot{
+		ProductId: productId,
+		Number:    number,
+		Quantity:  quantity,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (l *Lot) String() string {
+	return fmt.Sprintf("lot:%s product:%d quantity:%d expires:%s", l.Number, l.ProductId, l.Quantity, l.ExpiresAt.Format(DateLayout))
+}
+
+// IsExpired reports whether the lot is past its expiry date at t, a lot is sold through its expiry date.
+func (l *Lot) IsExpired(t time.Time) bool {
+	return !t.Before(l.ExpiresAt.AddDate(0, 0, 1))
+}
+
+func (l *Lot) Copy() *Lot {
+	lot := *l
+	return &lot
+}
+
+// SortFEFO orders lots first-expired-first-out, lots expiring together keep the receiving order.
+func SortFEFO(lots []*Lot) {
+	sort.SliceStable(lots, func(i, j int) bool {
+		if !lots[i].ExpiresAt.Equal(lots[j].ExpiresAt) {
+			return lots[i].ExpiresAt.Before(lots[j].ExpiresAt)
+		}
+		return lots[i].Id < lots[j].Id
+	})
+}
+
+// Pick takes quantity out of the lots first-expired-first-out and returns the lots it changed.
+// Quantity beyond the lots total is left to the stock that is not tracked by lot.
+func Pick(lots []*Lot, quantity uint64) []*Lot {
+	SortFEFO(lots)
+
+	var picked []*Lot
+	for _, lot := range lots {
+		if quantity == 0 {
+			break
+		}
+		if lot.Quantity == 0 {
+			continue
+		}
+
+		taken := lot.Quantity
+		if taken > quantity {
+			taken = quantity
+		}
+		lot.Quantity -= taken
+		quantity -= taken
+		picked = append(picked, lot)
+	}
+	return picked
+}
+
+// Reserve takes quantity out of the lots that have not expired at now first-expired-first-out
+// and returns what it took of each lot. Quantity beyond the lots is taken from the untracked
+// stock, when that does not cover it either only expired stock is left and ErrExpiredStock is
+// returned. The lots are changed even then, callers drop them on error.
+func Reserve(lots []*Lot, quantity uint64, untracked uint64, now time.Time) ([]Picked, error) {
+	SortFEFO(lots)
+
+	var picked []Picked
+	for _, lot := range lots {
+		if quantity == 0 {
+			break
+		}
+		if lot.Quantity == 0 || lot.IsExpired(now) {
+			continue
+		}
+
+		taken := lot.Quantity
+		if taken > quantity {
+			taken = quantity
+		}
+		lot.Quantity -= taken
+		quantity -= taken
+		picked = append(picked, Picked{LotId: lot.Id, Quantity: taken})
+	}
+
+	if quantity > untracked {
+		return nil, ErrExpiredStock
+	}
+	return picked, nil
+}
+
+// Untracked is the part of the product quantity that is not kept in any of its lots.
+func Untracked(productQuantity uint64, lots []*Lot) uint64 {
+	var tracked uint64
+	for _, lot := range lots {
+		tracked += lot.Quantity
+	}
+	if tracked > productQuantity {
+		return 0
+	}
+	return productQuantity - tracked
+}
@@ -0,0 +1,40 @@
+package lots
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrExpiredStock is returned by reservations that could only be served from expired lots.
+var ErrExpiredStock = errors.New("not enough stock that has not expired")
+
+func ValidateNumber(number string) error {
+	if len(number) == 0 {
+		return errors.New("lot number length must be greater than 0")
+	}
+	return nil
+}
+
+func ValidateQuantity(quantity uint64) error {
+	if quantity == 0 {
+		return errors.New("lot quantity must be greater than 0")
+	}
+	return nil
+}
+
+// ParseExpiresAt parses an expiry date in DateLayout.
+func ParseExpiresAt(date string) (time.Time, error) {
+	expiresAt, err := time.Parse(DateLayout, date)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("lot expiry date must be in %s format", DateLayout)
+	}
+	return expiresAt, nil
+}
+
+func ValidateExpiresAt(expiresAt time.Time) error {
+	if expiresAt.IsZero() {
+		return errors.New("lot expiry date must be set")
+	}
+	return nil
+}
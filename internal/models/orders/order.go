@@ -0,0 +1,63 @@
+package orders
+
+import (
+	"fmt"
+	"homework-1/internal/models/lots"
+	"strings"
+)
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusPlaced    Status = "placed"
+	StatusCancelled Status = "cancelled"
+)
+
+// Order is a customer order that takes stock for each line when it is placed.
+type Order struct {
+	Id     string  `json:"id"`
+	Lines  []*Line `json:"lines"`
+	Status Status  `json:"status"`
+	Reason string  `json:"reason"`
+}
+
+type Line struct {
+	ProductId uint64 `json:"product_id"`
+	Quantity  uint64 `json:"quantity"`
+	// Lots is what the reservation of the line took of each lot, it is put back on compensation
+	Lots []lots.Picked `json:"lots,omitempty"`
+}
+
+func NewOrder(id string, lines []*Line) (*Order, error) {
+	if err := ValidateId(id); err != nil {
+		return nil, err
+	}
+	if err := ValidateLines(lines); err != nil {
+		return nil, err
+	}
+
+	return &Order{
+		Id:     id,
+		Lines:  lines,
+		Status: StatusPending,
+	}, nil
+}
+
+func (o *Order) Place() {
+	o.Status = StatusPlaced
+	o.Reason = ""
+}
+
+func (o *Order) Cancel(reason string) {
+	o.Status = StatusCancelled
+	o.Reason = reason
+}
+
+func (o *Order) String() string {
+	lines := make([]string, 0, len(o.Lines))
+	for _, line := range o.Lines {
+		lines = append(lines, fmt.Sprintf("product:%d quantity:%d", line.ProductId, line.Quantity))
+	}
+	return fmt.Sprintf("#%s status:%s lines:[%s]", o.Id, o.Status, strings.Join(lines, ", "))
+}
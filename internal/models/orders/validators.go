@@ -0,0 +1,36 @@
+package orders
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrEmptyOrder    = errors.New("order must have at least one line")
+	ErrDuplicateLine = errors.New("product is ordered more than once")
+)
+
+func ValidateId(id string) error {
+	if len(id) == 0 {
+		return errors.New("order id length must be greater than 0")
+	}
+	return nil
+}
+
+func ValidateLines(lines []*Line) error {
+	if len(lines) == 0 {
+		return ErrEmptyOrder
+	}
+
+	seen := make(map[uint64]struct{}, len(lines))
+	for _, line := range lines {
+		if line.Quantity == 0 {
+			return fmt.Errorf("product %d: quantity must be greater than 0", line.ProductId)
+		}
+		if _, ok := seen[line.ProductId]; ok {
+			return fmt.Errorf("product %d: %w", line.ProductId, ErrDuplicateLine)
+		}
+		seen[line.ProductId] = struct{}{}
+	}
+	return nil
+}
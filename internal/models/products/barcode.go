@@ -0,0 +1,44 @@
+package products
+
+import "strings"
+
+// gtinLength is the length of the GTIN-14 form barcodes are stored in.
+const gtinLength = 14
+
+// NormalizeBarcode validates an EAN-8, UPC-A, EAN-13 or GTIN-14 barcode and returns it as GTIN-14,
+// so the same item is found whether it was scanned as UPC-A or as EAN-13.
+func NormalizeBarcode(code string) (string, error) {
+	if err := ValidateBarcode(code); err != nil {
+		return "", err
+	}
+	return strings.Repeat("0", gtinLength-len(code)) + code, nil
+}
+
+// ParseBarcode is NormalizeBarcode that accepts an empty code as no barcode.
+func ParseBarcode(code string) (string, error) {
+	if code == "" {
+		return "", nil
+	}
+	return NormalizeBarcode(code)
+}
+
+// barcodeCheckDigit computes the GS1 mod 10 check digit of the digits without the check digit.
+func barcodeCheckDigit(digits string) byte {
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if (len(digits)-1-i)%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	return byte((10-sum%10)%10) + '0'
+}
+
+func isBarcodeLength(length int) bool {
+	switch length {
+	case 8, 12, 13, gtinLength:
+		return true
+	}
+	return false
+}
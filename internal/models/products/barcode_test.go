@@ -0,0 +1,103 @@
+package products
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNormalizeBarcode(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		code    string
+		want    string
+		wantErr error
+	}{
+		{name: "EAN-8", code: "96385074", want: "00000096385074"},
+		{name: "UPC-A", code: "036000291452", want: "00036000291452"},
+		{name: "EAN-13", code: "4006381333931", want: "04006381333931"},
+		{name: "GTIN-14", code: "00012345600012", want: "00012345600012"},
+		{name: "GTIN-14 of a case", code: "10012345600019", want: "10012345600019"},
+		{name: "wrong check digit of EAN-8", code: "96385075", wantErr: ErrBarcodeChecksum},
+		{name: "wrong check digit of UPC-A", code: "036000291453", wantErr: ErrBarcodeChecksum},
+		{name: "wrong check digit of EAN-13", code: "4006381333932", wantErr: ErrBarcodeChecksum},
+		{name: "wrong check digit of GTIN-14", code: "00012345600013", wantErr: ErrBarcodeChecksum},
+		{name: "swapped digits", code: "4006381339331", wantErr: ErrBarcodeChecksum},
+		{name: "letters", code: "40063813339A1", wantErr: ErrInvalidBarcode},
+		{name: "spaces", code: "400638 333931", wantErr: ErrInvalidBarcode},
+		{name: "sign", code: "+4006381333931", wantErr: ErrInvalidBarcode},
+		{name: "too short", code: "1234567", wantErr: ErrInvalidBarcode},
+		{name: "between EAN-8 and UPC-A", code: "1234567890", wantErr: ErrInvalidBarcode},
+		{name: "too long", code: "004006381333931", wantErr: ErrInvalidBarcode},
+		{name: "empty", code: "", wantErr: ErrInvalidBarcode},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// act
+			barcode, err := NormalizeBarcode(tc.code)
+
+			// assert
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, barcode)
+		})
+	}
+
+	t.Run("UPC-A is the EAN-13 with a leading zero", func(t *testing.T) {
+		// act
+		upc, err := NormalizeBarcode("036000291452")
+		require.NoError(t, err)
+		ean, err := NormalizeBarcode("0036000291452")
+		require.NoError(t, err)
+
+		// assert
+		assert.Equal(t, ean, upc)
+	})
+}
+
+func TestParseBarcode(t *testing.T) {
+	t.Run("no barcode", func(t *testing.T) {
+		// act
+		barcode, err := ParseBarcode("")
+
+		// assert
+		require.NoError(t, err)
+		assert.Empty(t, barcode)
+	})
+
+	t.Run("barcode is normalized", func(t *testing.T) {
+		// act
+		barcode, err := ParseBarcode("96385074")
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, "00000096385074", barcode)
+	})
+}
+
+func TestBarcodeCheckDigit(t *testing.T) {
+	for _, tc := range []struct {
+		digits string
+		want   byte
+	}{
+		{digits: "9638507", want: '4'},
+		{digits: "03600029145", want: '2'},
+		{digits: "400638133393", want: '1'},
+		{digits: "0001234560001", want: '2'},
+		{digits: "000000000000", want: '0'},
+		// the digit next to the check digit weighs 3 whatever the length, the one before it 1
+		{digits: "0000000000001", want: '7'},
+		{digits: "000000000001", want: '7'},
+		{digits: "000000000010", want: '9'},
+	} {
+		t.Run(tc.digits, func(t *testing.T) {
+			// act
+			digit := barcodeCheckDigit(tc.digits)
+
+			// assert
+			assert.Equal(t, string(tc.want), string(digit))
+		})
+	}
+}
@@ -1,12 +1,41 @@
 package products
 
-import "fmt"
+import (
+	"fmt"
+	"homework-1/internal/models/attributes"
+	"homework-1/internal/models/units"
+)
 
 type Product struct {
-	Id       uint64 `db:"id" json:"id"`
-	Name     string `db:"name" json:"name"`
-	Price    uint64 `db:"price" json:"price"`
-	Quantity uint64 `db:"quantity" json:"quantity"`
+	Id    uint64 `db:"id" json:"id"`
+	Name  string `db:"name" json:"name"`
+	Price uint64 `db:"price" json:"price"`
+	// Quantity is counted in the smallest step of the unit, see units.Unit.
+	Quantity uint64     `db:"quantity" json:"quantity"`
+	Status   Status     `db:"status" json:"status"`
+	Unit     units.Unit `db:"unit" json:"unit"`
+	// Barcode is stored as GTIN-14, empty when the product has no barcode.
+	Barcode string `db:"barcode" json:"barcode,omitempty"`
+	// Category selects the attribute schema, see attributes.Registry.
+	Category   string                `db:"category" json:"category,omitempty"`
+	Attributes attributes.Attributes `db:"attributes" json:"attributes,omitempty"`
+}
+
+// Filter narrows product lists down, empty fields match every product.
+type Filter struct {
+	Status     Status
+	Category   string
+	Attributes attributes.Attributes
+}
+
+func (f Filter) Match(p *Product) bool {
+	if f.Status != "" && p.Status != f.Status {
+		return false
+	}
+	if f.Category != "" && p.Category != f.Category {
+		return false
+	}
+	return p.Attributes.Matches(f.Attributes)
 }
 
 func (p *Product) GetId() uint64 {
@@ -49,21 +78,136 @@ func (p *Product) SetQuantity(quantity uint64) error {
 	return nil
 }
 
+// GetUnit returns the product unit, products stored before units were introduced are counted in pieces.
+func (p *Product) GetUnit() units.Unit {
+	if p.Unit == "" {
+		return units.Default
+	}
+	return p.Unit
+}
+
+func (p *Product) SetUnit(unit units.Unit) error {
+	if err := units.ValidateUnit(unit); err != nil {
+		return err
+	}
+	p.Unit = unit
+	return nil
+}
+
+// QuantityOf converts a decimal amount in a compatible unit into the product quantity.
+func (p *Product) QuantityOf(amount string, unit units.Unit) (uint64, error) {
+	quantity, err := units.ParseQuantity(amount, unit)
+	if err != nil {
+		return 0, err
+	}
+	return units.Convert(quantity, unit, p.GetUnit())
+}
+
+// Amount is the quantity as a decimal in the product unit.
+func (p *Product) Amount() string {
+	return units.FormatQuantity(p.Quantity, p.GetUnit())
+}
+
+func (p *Product) GetBarcode() string {
+	return p.Barcode
+}
+
+// SetBarcode normalizes and sets the barcode, an empty code removes it.
+func (p *Product) SetBarcode(code string) error {
+	barcode, err := ParseBarcode(code)
+	if err != nil {
+		return err
+	}
+	p.Barcode = barcode
+	return nil
+}
+
+func (p *Product) GetCategory() string {
+	return p.Category
+}
+
+// GetAttributes never returns nil, so stored attributes are always a JSON object.
+func (p *Product) GetAttributes() attributes.Attributes {
+	if p.Attributes == nil {
+		return attributes.Attributes{}
+	}
+	return p.Attributes
+}
+
+func (p *Product) GetStatus() Status {
+	return p.Status
+}
+
+func (p *Product) IsActive() bool {
+	return p.Status == StatusActive
+}
+
+// TransitionTo moves the product to the given status if the state machine allows it
+// and returns the transition that has to be recorded.
+func (p *Product) TransitionTo(to Status, reason, actor string) (*StatusTransition, error) {
+	if err := ValidateStatusTransition(p.Status, to); err != nil {
+		return nil, err
+	}
+
+	transition := StatusTransition{
+		ProductId: p.Id,
+		From:      p.Status,
+		To:        to,
+		Reason:    reason,
+		Actor:     actor,
+	}
+	p.Status = to
+	return &transition, nil
+}
+
+// Reserve takes quantity out of stock. Only active products can be reserved.
+func (p *Product) Reserve(quantity uint64) error {
+	if !p.IsActive() {
+		return fmt.Errorf("%d: %w", p.Id, ErrProductNotActive)
+	}
+	if err := ValidateQuantity(quantity); err != nil {
+		return err
+	}
+	if p.Quantity < quantity {
+		return fmt.Errorf("%d: %w", p.Id, ErrNotEnoughQuantity)
+	}
+	p.Quantity -= quantity
+	return nil
+}
+
+// Release puts previously reserved quantity back into stock.
+func (p *Product) Release(quantity uint64) error {
+	if err := ValidateQuantity(quantity); err != nil {
+		return err
+	}
+	p.Quantity += quantity
+	return nil
+}
+
 func (p *Product) String() string {
-	return fmt.Sprintf("[%d] name:%s price:%d quantity:%d", p.Id, p.Name, p.Price, p.Quantity)
+	s := fmt.Sprintf("[%d] name:%s price:%d quantity:%s %s status:%s", p.Id, p.Name, p.Price, p.Amount(), p.GetUnit(), p.Status)
+	if p.Barcode != "" {
+		s += " barcode:" + p.Barcode
+	}
+	return s
 }
 
 func (p *Product) Copy() *Product {
 	return &Product{
-		Id:       p.Id,
-		Name:     p.Name,
-		Price:    p.Price,
-		Quantity: p.Quantity,
+		Id:         p.Id,
+		Name:       p.Name,
+		Price:      p.Price,
+		Quantity:   p.Quantity,
+		Status:     p.Status,
+		Unit:       p.Unit,
+		Barcode:    p.Barcode,
+		Category:   p.Category,
+		Attributes: p.Attributes.Copy(),
 	}
 }
 
 func BuildProduct(name string, price uint64, quantity uint64) (*Product, error) {
-	p := Product{}
+	p := Product{Status: StatusActive, Unit: units.Default}
 	if err := p.SetName(name); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,47 @@
+package products
+
+import "fmt"
+
+type Status string
+
+const (
+	StatusDraft        Status = "draft"
+	StatusActive       Status = "active"
+	StatusDiscontinued Status = "discontinued"
+	StatusArchived     Status = "archived"
+)
+
+// statusTransitions lists the statuses each status is allowed to move to.
+// Archived is terminal: an archived product can never be brought back.
+var statusTransitions = map[Status][]Status{
+	StatusDraft:        {StatusActive, StatusArchived},
+	StatusActive:       {StatusDiscontinued, StatusArchived},
+	StatusDiscontinued: {StatusActive, StatusArchived},
+	StatusArchived:     {},
+}
+
+func (s Status) String() string {
+	return string(s)
+}
+
+func (s Status) CanTransitionTo(to Status) bool {
+	for _, allowed := range statusTransitions[s] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// StatusTransition describes a requested status change together with the audit data stored with it.
+type StatusTransition struct {
+	ProductId uint64 `db:"product_id" json:"product_id"`
+	From      Status `db:"from_status" json:"from_status"`
+	To        Status `db:"to_status" json:"to_status"`
+	Reason    string `db:"reason" json:"reason"`
+	Actor     string `db:"actor" json:"actor"`
+}
+
+func (t *StatusTransition) String() string {
+	return fmt.Sprintf("[%d] %s -> %s by %s: %s", t.ProductId, t.From, t.To, t.Actor, t.Reason)
+}
@@ -0,0 +1,80 @@
+package products
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCanTransitionTo(t *testing.T) {
+	for _, tc := range []struct {
+		from    Status
+		to      Status
+		allowed bool
+	}{
+		{from: StatusDraft, to: StatusDraft},
+		{from: StatusDraft, to: StatusActive, allowed: true},
+		{from: StatusDraft, to: StatusDiscontinued},
+		{from: StatusDraft, to: StatusArchived, allowed: true},
+
+		{from: StatusActive, to: StatusDraft},
+		{from: StatusActive, to: StatusActive},
+		{from: StatusActive, to: StatusDiscontinued, allowed: true},
+		{from: StatusActive, to: StatusArchived, allowed: true},
+
+		{from: StatusDiscontinued, to: StatusDraft},
+		{from: StatusDiscontinued, to: StatusActive, allowed: true},
+		{from: StatusDiscontinued, to: StatusDiscontinued},
+		{from: StatusDiscontinued, to: StatusArchived, allowed: true},
+
+		{from: StatusArchived, to: StatusDraft},
+		{from: StatusArchived, to: StatusActive},
+		{from: StatusArchived, to: StatusDiscontinued},
+		{from: StatusArchived, to: StatusArchived},
+
+		{from: StatusActive, to: "sold"},
+		{from: "sold", to: StatusActive},
+	} {
+		t.Run(tc.from.String()+" -> "+tc.to.String(), func(t *testing.T) {
+			// act
+			allowed := tc.from.CanTransitionTo(tc.to)
+
+			// assert
+			assert.Equal(t, tc.allowed, allowed)
+		})
+	}
+}
+
+func TestStatusTransitionsCoverEveryStatus(t *testing.T) {
+	for _, status := range []Status{StatusDraft, StatusActive, StatusDiscontinued, StatusArchived} {
+		// assert
+		assert.Contains(t, statusTransitions, status)
+		assert.NoError(t, ValidateStatus(status))
+	}
+	assert.Len(t, statusTransitions, 4)
+}
+
+func TestValidateStatusTransition(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		from    Status
+		to      Status
+		wantErr string
+	}{
+		{name: "allowed", from: StatusDraft, to: StatusActive},
+		{name: "forbidden", from: StatusArchived, to: StatusDraft, wantErr: "archived -> draft: invalid status transition"},
+		{name: "same status", from: StatusActive, to: StatusActive, wantErr: "active -> active: invalid status transition"},
+		{name: "unknown status", from: StatusActive, to: "sold", wantErr: "sold: unknown status"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// act
+			err := ValidateStatusTransition(tc.from, tc.to)
+
+			// assert
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package products
+
+import "homework-1/internal/locales"
+
+// Translation is the product name and description in a locale.
+type Translation struct {
+	ProductId   uint64         `db:"product_id" json:"product_id"`
+	Locale      locales.Locale `db:"locale" json:"locale"`
+	Name        string         `db:"name" json:"name"`
+	Description string         `db:"description" json:"description"`
+}
+
+func (t *Translation) Copy() *Translation {
+	translation := *t
+	return &translation
+}
+
+// PickTranslations returns the translation of every product in the locale, falling back
+// to the default locale. Products without both keep their own name and have no description.
+func PickTranslations(translations []*Translation, locale locales.Locale) map[uint64]*Translation {
+	picked := make(map[uint64]*Translation, len(translations))
+	for _, t := range translations {
+		if current, ok := picked[t.ProductId]; ok && current.Locale == locale {
+			continue
+		}
+		if t.Locale == locale || t.Locale == locales.Default {
+			picked[t.ProductId] = t
+		}
+	}
+	return picked
+}
+
+// Localize returns a copy of the product with the translated name,
+// the description is empty without a translation.
+func (p *Product) Localize(t *Translation) (*Product, string) {
+	localized := p.Copy()
+	if t == nil {
+		return localized, ""
+	}
+	if t.Name != "" {
+		localized.Name = t.Name
+	}
+	return localized, t.Description
+}
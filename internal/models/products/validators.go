@@ -2,6 +2,18 @@ package products
 
 import (
 	"errors"
+	"fmt"
+	"homework-1/internal/locales"
+	"homework-1/internal/models/units"
+)
+
+var (
+	ErrUnknownStatus           = errors.New("unknown status")
+	ErrInvalidStatusTransition = errors.New("invalid status transition")
+	ErrProductNotActive        = errors.New("product is not active")
+	ErrNotEnoughQuantity       = errors.New("not enough quantity")
+	ErrInvalidBarcode          = errors.New("invalid barcode")
+	ErrBarcodeChecksum         = errors.New("barcode check digit mismatch")
 )
 
 func ValidateName(name string) error {
@@ -25,6 +37,45 @@ func ValidateQuantity(quantity uint64) error {
 	return nil
 }
 
+func ValidateBarcode(code string) error {
+	if !isBarcodeLength(len(code)) {
+		return fmt.Errorf("%q: %w: expected 8, 12, 13 or 14 digits", code, ErrInvalidBarcode)
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return fmt.Errorf("%q: %w: only digits are allowed", code, ErrInvalidBarcode)
+		}
+	}
+	if barcodeCheckDigit(code[:len(code)-1]) != code[len(code)-1] {
+		return fmt.Errorf("%q: %w", code, ErrBarcodeChecksum)
+	}
+	return nil
+}
+
+func ValidateStatus(status Status) error {
+	if _, ok := statusTransitions[status]; !ok {
+		return fmt.Errorf("%s: %w", status, ErrUnknownStatus)
+	}
+	return nil
+}
+
+func ValidateStatusTransition(from, to Status) error {
+	if err := ValidateStatus(to); err != nil {
+		return err
+	}
+	if !from.CanTransitionTo(to) {
+		return fmt.Errorf("%s -> %s: %w", from, to, ErrInvalidStatusTransition)
+	}
+	return nil
+}
+
+func ValidateTransitionReason(reason string) error {
+	if len(reason) == 0 {
+		return errors.New("transition reason must not be empty")
+	}
+	return nil
+}
+
 func ValidateProductFields(name string, price, quantity uint64) []error {
 	validationErrors := make([]error, 0, 3)
 
@@ -42,3 +93,46 @@ func ValidateProductFields(name string, price, quantity uint64) []error {
 
 	return validationErrors
 }
+
+// ValidateProductAmountFields validates product fields whose quantity is given as a decimal amount.
+func ValidateProductAmountFields(name string, price uint64, amount string) []error {
+	validationErrors := make([]error, 0, 3)
+
+	if err := ValidateName(name); err != nil {
+		validationErrors = append(validationErrors, err)
+	}
+
+	if err := ValidatePrice(price); err != nil {
+		validationErrors = append(validationErrors, err)
+	}
+
+	if err := units.ValidateAmount(amount); err != nil {
+		validationErrors = append(validationErrors, err)
+	}
+
+	return validationErrors
+}
+
+func ValidateTranslation(t Translation) error {
+	if err := locales.ValidateLocale(t.Locale); err != nil {
+		return err
+	}
+	if len(t.Name) == 0 && len(t.Description) == 0 {
+		return errors.New("translation must have a name or a description")
+	}
+	return nil
+}
+
+func ValidateStatusTransitionFields(to Status, reason string) []error {
+	validationErrors := make([]error, 0, 2)
+
+	if err := ValidateStatus(to); err != nil {
+		validationErrors = append(validationErrors, err)
+	}
+
+	if err := ValidateTransitionReason(reason); err != nil {
+		validationErrors = append(validationErrors, err)
+	}
+
+	return validationErrors
+}
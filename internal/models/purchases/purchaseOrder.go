@@ -0,0 +1,135 @@
+package purchases
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusOpen              Status = "open"
+	StatusPartiallyReceived Status = "partially_received"
+	StatusReceived          Status = "received"
+)
+
+// PurchaseOrder is stock ordered from a supplier. It stays open until every line is received.
+type PurchaseOrder struct {
+	Id         uint64     `db:"id" json:"id"`
+	SupplierId uint64     `db:"supplier_id" json:"supplier_id"`
+	Status     Status     `db:"status" json:"status"`
+	CreatedAt  time.Time  `db:"created_at" json:"created_at"`
+	ReceivedAt *time.Time `db:"received_at" json:"received_at"`
+	Lines      []*Line    `db:"-" json:"lines"`
+}
+
+type Line struct {
+	Id        uint64 `db:"id" json:"id"`
+	OrderId   uint64 `db:"order_id" json:"order_id"`
+	ProductId uint64 `db:"product_id" json:"product_id"`
+	Quantity  uint64 `db:"quantity" json:"quantity"`
+	Received  uint64 `db:"received" json:"received"`
+}
+
+func NewPurchaseOrder(supplierId uint64, lines []*Line) (*PurchaseOrder, error) {
+	if err := ValidateLines(lines); err != nil {
+		return nil, err
+	}
+
+	return &PurchaseOrder{
+		SupplierId: supplierId,
+		Status:     StatusOpen,
+		CreatedAt:  time.Now(),
+		Lines:      lines,
+	}, nil
+}
+
+func (l *Line) Remaining() uint64 {
+	return l.Quantity - l.Received
+}
+
+// IsOpen reports whether the order still waits for stock.
+func (o *PurchaseOrder) IsOpen() bool {
+	return o.Status == StatusOpen || o.Status == StatusPartiallyReceived
+}
+
+// Receive books received quantities by product id onto the order lines and returns them
+// clamped to what was actually booked. An empty receipt receives everything that is left.
+func (o *PurchaseOrder) Receive(receipts map[uint64]uint64) (map[uint64]uint64, error) {
+	if !o.IsOpen() {
+		return nil, fmt.Errorf("%d: %w", o.Id, ErrOrderClosed)
+	}
+
+	if len(receipts) == 0 {
+		receipts = make(map[uint64]uint64, len(o.Lines))
+		for _, line := range o.Lines {
+			receipts[line.ProductId] = line.Remaining()
+		}
+	}
+
+	lines := make(map[uint64]*Line, len(o.Lines))
+	for _, line := range o.Lines {
+		lines[line.ProductId] = line
+	}
+	for productId, quantity := range receipts {
+		line, ok := lines[productId]
+		if !ok {
+			return nil, fmt.Errorf("product %d: %w", productId, ErrUnknownLine)
+		}
+		if quantity > line.Remaining() {
+			return nil, fmt.Errorf("product %d: %w", productId, ErrOverReceipt)
+		}
+	}
+
+	received := make(map[uint64]uint64, len(receipts))
+	for productId, quantity := range receipts {
+		if quantity == 0 {
+			continue
+		}
+		lines[productId].Received += quantity
+		received[productId] = quantity
+	}
+
+	o.updateStatus()
+	return received, nil
+}
+
+func (o *PurchaseOrder) String() string {
+	lines := make([]string, 0, len(o.Lines))
+	for _, line := range o.Lines {
+		lines = append(lines, fmt.Sprintf("product:%d %d/%d", line.ProductId, line.Received, line.Quantity))
+	}
+	return fmt.Sprintf("#%d supplier:%d status:%s lines:[%s]", o.Id, o.SupplierId, o.Status, strings.Join(lines, ", "))
+}
+
+func (o *PurchaseOrder) Copy() *PurchaseOrder {
+	order := *o
+	if o.ReceivedAt != nil {
+		receivedAt := *o.ReceivedAt
+		order.ReceivedAt = &receivedAt
+	}
+	order.Lines = make([]*Line, 0, len(o.Lines))
+	for _, line := range o.Lines {
+		copied := *line
+		order.Lines = append(order.Lines, &copied)
+	}
+	return &order
+}
+
+func (o *PurchaseOrder) updateStatus() {
+	var ordered, received uint64
+	for _, line := range o.Lines {
+		ordered += line.Quantity
+		received += line.Received
+	}
+
+	switch {
+	case received == ordered:
+		now := time.Now()
+		o.Status = StatusReceived
+		o.ReceivedAt = &now
+	case received > 0:
+		o.Status = StatusPartiallyReceived
+	}
+}
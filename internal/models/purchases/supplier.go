@@ -0,0 +1,18 @@
+package purchases
+
+import "fmt"
+
+type Supplier struct {
+	Id      uint64 `db:"id" json:"id"`
+	Name    string `db:"name" json:"name"`
+	Contact string `db:"contact" json:"contact"`
+}
+
+func (s *Supplier) String() string {
+	return fmt.Sprintf("id:%d name:%s contact:%s", s.Id, s.Name, s.Contact)
+}
+
+func (s *Supplier) Copy() *Supplier {
+	supplier := *s
+	return &supplier
+}
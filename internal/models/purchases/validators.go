@@ -0,0 +1,39 @@
+package purchases
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrOrderClosed   = errors.New("purchase order is not open")
+	ErrUnknownLine   = errors.New("product is not in the purchase order")
+	ErrOverReceipt   = errors.New("received quantity exceeds ordered quantity")
+	ErrEmptyOrder    = errors.New("purchase order must have at least one line")
+	ErrDuplicateLine = errors.New("product is ordered more than once")
+)
+
+func ValidateSupplierName(name string) error {
+	if len(name) == 0 {
+		return errors.New("supplier name length must be greater than 0")
+	}
+	return nil
+}
+
+func ValidateLines(lines []*Line) error {
+	if len(lines) == 0 {
+		return ErrEmptyOrder
+	}
+
+	seen := make(map[uint64]struct{}, len(lines))
+	for _, line := range lines {
+		if line.Quantity == 0 {
+			return fmt.Errorf("product %d: quantity must be greater than 0", line.ProductId)
+		}
+		if _, ok := seen[line.ProductId]; ok {
+			return fmt.Errorf("product %d: %w", line.ProductId, ErrDuplicateLine)
+		}
+		seen[line.ProductId] = struct{}{}
+	}
+	return nil
+}
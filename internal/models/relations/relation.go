@@ -0,0 +1,76 @@
+package relations
+
+import (
+	"fmt"
+	"homework-1/internal/models/products"
+)
+
+type Type string
+
+const (
+	// TypeRelated suggests a product to look at together with the product.
+	TypeRelated Type = "related"
+	// TypeAccessory is sold for use with the product.
+	TypeAccessory Type = "accessory"
+	// TypeReplacement can be offered instead of the product, e.g. when it is out of stock.
+	TypeReplacement Type = "replacement"
+)
+
+var types = map[Type]struct{}{
+	TypeRelated:     {},
+	TypeAccessory:   {},
+	TypeReplacement: {},
+}
+
+func (t Type) String() string {
+	return string(t)
+}
+
+// Relation points from a product to a related one, relations are directed:
+// a cable being an accessory of a phone does not make the phone an accessory of the cable.
+// A pair of products has at most one relation in each direction.
+type Relation struct {
+	ProductId uint64 `db:"product_id" json:"product_id"`
+	RelatedId uint64 `db:"related_id" json:"related_id"`
+	Type      Type   `db:"type" json:"type"`
+}
+
+func NewRelation(productId uint64, relatedId uint64, relationType Type) (*Relation, error) {
+	if err := ValidateType(relationType); err != nil {
+		return nil, err
+	}
+	if err := ValidateProducts(productId, relatedId); err != nil {
+		return nil, err
+	}
+
+	return &Relation{
+		ProductId: productId,
+		RelatedId: relatedId,
+		Type:      relationType,
+	}, nil
+}
+
+func (r *Relation) String() string {
+	return fmt.Sprintf("%d -%s-> %d", r.ProductId, r.Type, r.RelatedId)
+}
+
+func (r *Relation) Copy() *Relation {
+	relation := *r
+	return &relation
+}
+
+// RelatedProduct is a product reached through a relation of the given type.
+type RelatedProduct struct {
+	products.Product
+	Type Type `db:"relation_type" json:"relation_type"`
+}
+
+// DeletePolicy decides what deleting a product that takes part in relations does.
+type DeletePolicy string
+
+const (
+	// DeleteCascade deletes the product relations together with the product.
+	DeleteCascade DeletePolicy = "cascade"
+	// DeleteRestrict refuses to delete a product until its relations are deleted.
+	DeleteRestrict DeletePolicy = "restrict"
+)
@@ -0,0 +1,34 @@
+package relations
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrUnknownType         = errors.New("unknown relation type, expected related, accessory or replacement")
+	ErrSelfRelation        = errors.New("product can't be related to itself")
+	ErrUnknownDeletePolicy = errors.New("unknown relation delete policy, expected cascade or restrict")
+)
+
+func ValidateType(relationType Type) error {
+	if _, ok := types[relationType]; !ok {
+		return fmt.Errorf("%s: %w", relationType, ErrUnknownType)
+	}
+	return nil
+}
+
+func ValidateProducts(productId uint64, relatedId uint64) error {
+	if productId == relatedId {
+		return ErrSelfRelation
+	}
+	return nil
+}
+
+func ParseDeletePolicy(policy string) (DeletePolicy, error) {
+	switch DeletePolicy(policy) {
+	case DeleteCascade, DeleteRestrict:
+		return DeletePolicy(policy), nil
+	}
+	return "", fmt.Errorf("%s: %w", policy, ErrUnknownDeletePolicy)
+}
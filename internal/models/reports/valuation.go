@@ -0,0 +1,104 @@
+package reports
+
+import (
+	"fmt"
+	"homework-1/internal/models/products"
+	"homework-1/internal/models/units"
+)
+
+// DefaultTopLimit is the number of products in the top by value report when no limit is given.
+const DefaultTopLimit = 10
+
+// Valuation is the value of the whole stock, see ValueOf.
+type Valuation struct {
+	TotalValue     uint64 `db:"total_value" json:"total_value"`
+	TotalQuantity  uint64 `db:"total_quantity" json:"total_quantity"`
+	ProductCount   uint64 `db:"product_count" json:"product_count"`
+	ZeroStockCount uint64 `db:"zero_stock_count" json:"zero_stock_count"`
+}
+
+// StatusValue is the stock value of the products in one status.
+type StatusValue struct {
+	Status       products.Status `db:"status" json:"status"`
+	ProductCount uint64          `db:"product_count" json:"product_count"`
+	Quantity     uint64          `db:"quantity" json:"quantity"`
+	Value        uint64          `db:"value" json:"value"`
+}
+
+// CategoryValue is the stock value of the products in one category, products without a category have an empty one.
+type CategoryValue struct {
+	Category     string `db:"category" json:"category"`
+	ProductCount uint64 `db:"product_count" json:"product_count"`
+	Quantity     uint64 `db:"quantity" json:"quantity"`
+	Value        uint64 `db:"value" json:"value"`
+}
+
+type ProductValue struct {
+	ProductId uint64     `db:"product_id" json:"product_id"`
+	Name      string     `db:"name" json:"name"`
+	Price     uint64     `db:"price" json:"price"`
+	Quantity  uint64     `db:"quantity" json:"quantity"`
+	Unit      units.Unit `db:"unit" json:"unit"`
+	Value     uint64     `db:"value" json:"value"`
+}
+
+func NewProductValue(product *products.Product) *ProductValue {
+	return &ProductValue{
+		ProductId: product.Id,
+		Name:      product.Name,
+		Price:     product.Price,
+		Quantity:  product.Quantity,
+		Unit:      product.GetUnit(),
+		Value:     ValueOf(product),
+	}
+}
+
+// ValueOf is the stock value of the product. The price is given per whole unit while the
+// quantity is counted in unit steps, so 1.5 kg at 200 is worth 300 and not 300000.
+func ValueOf(product *products.Product) uint64 {
+	return product.Price * product.Quantity / product.GetUnit().Scale()
+}
+
+// Add accounts the product in the valuation.
+func (v *Valuation) Add(product *products.Product) {
+	v.TotalValue += ValueOf(product)
+	v.TotalQuantity += product.Quantity
+	v.ProductCount++
+	if product.Quantity == 0 {
+		v.ZeroStockCount++
+	}
+}
+
+// Add accounts the product in the status value.
+func (s *StatusValue) Add(product *products.Product) {
+	s.ProductCount++
+	s.Quantity += product.Quantity
+	s.Value += ValueOf(product)
+}
+
+// Add accounts the product in the category value.
+func (c *CategoryValue) Add(product *products.Product) {
+	c.ProductCount++
+	c.Quantity += product.Quantity
+	c.Value += ValueOf(product)
+}
+
+func (v *Valuation) String() string {
+	return fmt.Sprintf("total value:%d quantity:%d products:%d out of stock:%d", v.TotalValue, v.TotalQuantity, v.ProductCount, v.ZeroStockCount)
+}
+
+func (s *StatusValue) String() string {
+	return fmt.Sprintf("%s: value:%d quantity:%d products:%d", s.Status, s.Value, s.Quantity, s.ProductCount)
+}
+
+func (c *CategoryValue) String() string {
+	category := c.Category
+	if category == "" {
+		category = "no category"
+	}
+	return fmt.Sprintf("%s: value:%d quantity:%d products:%d", category, c.Value, c.Quantity, c.ProductCount)
+}
+
+func (p *ProductValue) String() string {
+	return fmt.Sprintf("[%d] %s value:%d (%s %s x %d)", p.ProductId, p.Name, p.Value, units.FormatQuantity(p.Quantity, p.Unit), p.Unit, p.Price)
+}
@@ -0,0 +1,20 @@
+package stock
+
+import "fmt"
+
+// LowStock is an active product whose quantity dropped below its reorder threshold.
+type LowStock struct {
+	ProductId uint64 `db:"product_id" json:"product_id"`
+	Name      string `db:"name" json:"name"`
+	Quantity  uint64 `db:"quantity" json:"quantity"`
+	Threshold uint64 `db:"threshold" json:"threshold"`
+}
+
+func (l *LowStock) String() string {
+	return fmt.Sprintf("id:%d name:%s quantity:%d threshold:%d", l.ProductId, l.Name, l.Quantity, l.Threshold)
+}
+
+// IsLow reports whether the quantity is below the threshold. A zero threshold never alerts.
+func IsLow(quantity, threshold uint64) bool {
+	return quantity < threshold
+}
@@ -0,0 +1,144 @@
+package stocktakes
+
+import (
+	"fmt"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusOpen      Status = "open"
+	StatusCommitted Status = "committed"
+)
+
+// Session is a physical stock count. Counts are collected while it is open and
+// committing it sets every counted product quantity to the counted value.
+type Session struct {
+	Id          uint64     `db:"id" json:"id"`
+	Status      Status     `db:"status" json:"status"`
+	OpenedBy    string     `db:"opened_by" json:"opened_by"`
+	CommittedBy string     `db:"committed_by" json:"committed_by"`
+	CreatedAt   time.Time  `db:"created_at" json:"created_at"`
+	CommittedAt *time.Time `db:"committed_at" json:"committed_at"`
+}
+
+type Count struct {
+	SessionId uint64    `db:"session_id" json:"session_id"`
+	ProductId uint64    `db:"product_id" json:"product_id"`
+	Counted   uint64    `db:"counted" json:"counted"`
+	CountedBy string    `db:"counted_by" json:"counted_by"`
+	CountedAt time.Time `db:"counted_at" json:"counted_at"`
+}
+
+// Variance compares a counted quantity with the quantity the system holds.
+type Variance struct {
+	ProductId uint64 `db:"product_id" json:"product_id"`
+	Name      string `db:"name" json:"name"`
+	Expected  uint64 `db:"expected" json:"expected"`
+	Counted   uint64 `db:"counted" json:"counted"`
+}
+
+// Adjustment is the audit record of a quantity changed by a committed session.
+type Adjustment struct {
+	Id        uint64    `db:"id" json:"id"`
+	SessionId uint64    `db:"session_id" json:"session_id"`
+	ProductId uint64    `db:"product_id" json:"product_id"`
+	Before    uint64    `db:"before" json:"before"`
+	After     uint64    `db:"after" json:"after"`
+	Actor     string    `db:"actor" json:"actor"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+func NewSession(openedBy string) (*Session, error) {
+	if err := ValidateActor(openedBy); err != nil {
+		return nil, err
+	}
+
+	return &Session{
+		Status:    StatusOpen,
+		OpenedBy:  openedBy,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func NewCount(sessionId, productId, counted uint64, countedBy string) (*Count, error) {
+	if err := ValidateActor(countedBy); err != nil {
+		return nil, err
+	}
+
+	return &Count{
+		SessionId: sessionId,
+		ProductId: productId,
+		Counted:   counted,
+		CountedBy: countedBy,
+		CountedAt: time.Now(),
+	}, nil
+}
+
+func (s *Session) IsOpen() bool {
+	return s.Status == StatusOpen
+}
+
+func (s *Session) Commit(actor string) error {
+	if err := ValidateActor(actor); err != nil {
+		return err
+	}
+	if !s.IsOpen() {
+		return fmt.Errorf("%d: %w", s.Id, ErrSessionClosed)
+	}
+
+	now := time.Now()
+	s.Status = StatusCommitted
+	s.CommittedBy = actor
+	s.CommittedAt = &now
+	return nil
+}
+
+// Adjust returns the adjustment that moves the expected quantity to the counted one,
+// nil when there is no variance.
+func (s *Session) Adjust(variance *Variance, actor string) *Adjustment {
+	if variance.Difference() == 0 {
+		return nil
+	}
+
+	return &Adjustment{
+		SessionId: s.Id,
+		ProductId: variance.ProductId,
+		Before:    variance.Expected,
+		After:     variance.Counted,
+		Actor:     actor,
+		CreatedAt: time.Now(),
+	}
+}
+
+func (s *Session) String() string {
+	return fmt.Sprintf("stocktake #%d status:%s opened by:%s", s.Id, s.Status, s.OpenedBy)
+}
+
+func (s *Session) Copy() *Session {
+	session := *s
+	if s.CommittedAt != nil {
+		committedAt := *s.CommittedAt
+		session.CommittedAt = &committedAt
+	}
+	return &session
+}
+
+func (c *Count) Copy() *Count {
+	count := *c
+	return &count
+}
+
+// Difference is positive when more was counted than the system holds.
+func (v *Variance) Difference() int64 {
+	return int64(v.Counted) - int64(v.Expected)
+}
+
+func (v *Variance) String() string {
+	return fmt.Sprintf("[%d] %s expected:%d counted:%d variance:%+d", v.ProductId, v.Name, v.Expected, v.Counted, v.Difference())
+}
+
+func (a *Adjustment) String() string {
+	return fmt.Sprintf("product:%d %d -> %d", a.ProductId, a.Before, a.After)
+}
@@ -0,0 +1,12 @@
+package stocktakes
+
+import "errors"
+
+var ErrSessionClosed = errors.New("stocktake session is not open")
+
+func ValidateActor(actor string) error {
+	if len(actor) == 0 {
+		return errors.New("stocktake actor must not be empty")
+	}
+	return nil
+}
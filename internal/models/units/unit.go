@@ -0,0 +1,169 @@
+package units
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Unit is a unit of measure. Quantities are stored as integers counted in the smallest
+// step of the unit, so a product sold in kg with precision 3 stores 1.5 kg as 1500.
+type Unit string
+
+const (
+	Piece      Unit = "piece"
+	Kilogram   Unit = "kg"
+	Gram       Unit = "g"
+	Metre      Unit = "m"
+	Centimetre Unit = "cm"
+	Litre      Unit = "l"
+	Millilitre Unit = "ml"
+
+	// Default is the unit of products created by clients that don't send one.
+	Default = Piece
+)
+
+type Dimension string
+
+const (
+	Count  Dimension = "count"
+	Mass   Dimension = "mass"
+	Length Dimension = "length"
+	Volume Dimension = "volume"
+)
+
+type definition struct {
+	dimension Dimension
+	// precision is the number of decimal places of a quantity
+	precision uint8
+	// base is the amount of the dimension base unit (piece, g, mm, ml) in one whole unit
+	base uint64
+}
+
+var definitions = map[Unit]definition{
+	Piece:      {dimension: Count, precision: 0, base: 1},
+	Kilogram:   {dimension: Mass, precision: 3, base: 1000},
+	Gram:       {dimension: Mass, precision: 0, base: 1},
+	Metre:      {dimension: Length, precision: 3, base: 1000},
+	Centimetre: {dimension: Length, precision: 1, base: 10},
+	Litre:      {dimension: Volume, precision: 3, base: 1000},
+	Millilitre: {dimension: Volume, precision: 0, base: 1},
+}
+
+// Parse returns the unit by name, an empty name is the default unit.
+func Parse(name string) (Unit, error) {
+	if name == "" {
+		return Default, nil
+	}
+	unit := Unit(name)
+	if err := ValidateUnit(unit); err != nil {
+		return "", err
+	}
+	return unit, nil
+}
+
+// All returns every known unit ordered by name.
+func All() []Unit {
+	all := make([]Unit, 0, len(definitions))
+	for unit := range definitions {
+		all = append(all, unit)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i] < all[j]
+	})
+	return all
+}
+
+func (u Unit) Dimension() Dimension {
+	return definitions[u].dimension
+}
+
+func (u Unit) Precision() uint8 {
+	return definitions[u].precision
+}
+
+// Scale is the number of steps in one whole unit, prices are given per whole unit.
+func (u Unit) Scale() uint64 {
+	return pow10(u.Precision())
+}
+
+// step is the amount of the dimension base unit in the smallest step of the unit.
+func (u Unit) step() uint64 {
+	return definitions[u].base / pow10(u.Precision())
+}
+
+func (u Unit) String() string {
+	return string(u)
+}
+
+// ParseQuantity parses a decimal quantity like "1.25" into steps of the unit.
+func ParseQuantity(amount string, unit Unit) (uint64, error) {
+	if err := ValidateUnit(unit); err != nil {
+		return 0, err
+	}
+
+	whole, fraction, err := splitDecimal(amount)
+	if err != nil {
+		return 0, err
+	}
+	if len(fraction) > int(unit.Precision()) {
+		return 0, fmt.Errorf("%q: %w: %s allows %d decimal places", amount, ErrPrecisionExceeded, unit, unit.Precision())
+	}
+
+	digits := whole + fraction + strings.Repeat("0", int(unit.Precision())-len(fraction))
+	quantity, err := strconv.ParseUint(digits, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q: %w", amount, ErrInvalidQuantity)
+	}
+	return quantity, nil
+}
+
+// FormatQuantity formats steps of the unit as a decimal quantity.
+func FormatQuantity(quantity uint64, unit Unit) string {
+	precision := unit.Precision()
+	if precision == 0 {
+		return strconv.FormatUint(quantity, 10)
+	}
+
+	scale := unit.Scale()
+	fraction := strings.TrimRight(fmt.Sprintf("%0*d", precision, quantity%scale), "0")
+	if fraction == "" {
+		return strconv.FormatUint(quantity/scale, 10)
+	}
+	return fmt.Sprintf("%d.%s", quantity/scale, fraction)
+}
+
+// Convert converts steps of one unit into steps of a compatible unit. Conversions that
+// would lose a fraction of the target step are rejected.
+func Convert(quantity uint64, from, to Unit) (uint64, error) {
+	if err := ValidateCompatible(from, to); err != nil {
+		return 0, err
+	}
+
+	if quantity > math.MaxUint64/from.step() {
+		return 0, fmt.Errorf("%s %s in %s: %w", FormatQuantity(quantity, from), from, to, ErrQuantityOverflow)
+	}
+	base := quantity * from.step()
+	if base%to.step() != 0 {
+		return 0, fmt.Errorf("%s %s in %s: %w", FormatQuantity(quantity, from), from, to, ErrPrecisionExceeded)
+	}
+	return base / to.step(), nil
+}
+
+func splitDecimal(amount string) (string, string, error) {
+	whole, fraction, hasFraction := strings.Cut(amount, ".")
+	if whole == "" || (hasFraction && fraction == "") || strings.Trim(whole+fraction, "0123456789") != "" {
+		return "", "", fmt.Errorf("%q: %w", amount, ErrInvalidQuantity)
+	}
+	return whole, fraction, nil
+}
+
+func pow10(n uint8) uint64 {
+	result := uint64(1)
+	for i := uint8(0); i < n; i++ {
+		result *= 10
+	}
+	return result
+}
@@ -0,0 +1,190 @@
+package units
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		unit    string
+		want    Unit
+		wantErr error
+	}{
+		{name: "known unit", unit: "kg", want: Kilogram},
+		{name: "no unit is integer pieces", unit: "", want: Piece},
+		{name: "unknown unit", unit: "lb", wantErr: ErrUnknownUnit},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// act
+			unit, err := Parse(tc.unit)
+
+			// assert
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, unit)
+		})
+	}
+}
+
+func TestParseQuantity(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		amount  string
+		unit    Unit
+		want    uint64
+		wantErr error
+	}{
+		{name: "whole pieces", amount: "12", unit: Piece, want: 12},
+		{name: "decimal kilograms", amount: "1.25", unit: Kilogram, want: 1250},
+		{name: "whole kilograms", amount: "2", unit: Kilogram, want: 2000},
+		{name: "all decimal places of the unit", amount: "0.001", unit: Kilogram, want: 1},
+		{name: "zero kilograms", amount: "0", unit: Kilogram, want: 0},
+		{name: "centimetres have one decimal place", amount: "2.5", unit: Centimetre, want: 25},
+		{name: "too many decimal places for kilograms", amount: "1.0001", unit: Kilogram, wantErr: ErrPrecisionExceeded},
+		{name: "pieces are integer", amount: "1.5", unit: Piece, wantErr: ErrPrecisionExceeded},
+		{name: "grams are integer", amount: "0.5", unit: Gram, wantErr: ErrPrecisionExceeded},
+		{name: "too many decimal places for centimetres", amount: "2.55", unit: Centimetre, wantErr: ErrPrecisionExceeded},
+		{name: "negative", amount: "-1", unit: Piece, wantErr: ErrInvalidQuantity},
+		{name: "no whole part", amount: ".5", unit: Kilogram, wantErr: ErrInvalidQuantity},
+		{name: "no fraction after the point", amount: "1.", unit: Kilogram, wantErr: ErrInvalidQuantity},
+		{name: "not a number", amount: "one", unit: Piece, wantErr: ErrInvalidQuantity},
+		{name: "does not fit the steps", amount: "18446744073709551.616", unit: Kilogram, wantErr: ErrInvalidQuantity},
+		{name: "unknown unit", amount: "1", unit: "lb", wantErr: ErrUnknownUnit},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// act
+			quantity, err := ParseQuantity(tc.amount, tc.unit)
+
+			// assert
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, quantity)
+		})
+	}
+}
+
+func TestFormatQuantity(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		quantity uint64
+		unit     Unit
+		want     string
+	}{
+		{name: "pieces", quantity: 12, unit: Piece, want: "12"},
+		{name: "decimal kilograms", quantity: 1250, unit: Kilogram, want: "1.25"},
+		{name: "whole kilograms", quantity: 2000, unit: Kilogram, want: "2"},
+		{name: "smallest step", quantity: 1, unit: Kilogram, want: "0.001"},
+		{name: "zero kilograms", quantity: 0, unit: Kilogram, want: "0"},
+		{name: "centimetres", quantity: 25, unit: Centimetre, want: "2.5"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// act
+			amount := FormatQuantity(tc.quantity, tc.unit)
+
+			// assert
+			assert.Equal(t, tc.want, amount)
+		})
+	}
+
+	t.Run("round trips through ParseQuantity", func(t *testing.T) {
+		for _, unit := range All() {
+			for _, quantity := range []uint64{0, 1, 10, 999, 1000, 1001, 123456, math.MaxUint64} {
+				// act
+				parsed, err := ParseQuantity(FormatQuantity(quantity, unit), unit)
+
+				// assert
+				require.NoError(t, err, "%d %s", quantity, unit)
+				assert.Equal(t, quantity, parsed, "%d %s", quantity, unit)
+			}
+		}
+	})
+}
+
+// tonne is a unit for tests whose step is coarser than the steps of the other mass units,
+// the units of the package all count their dimension base unit.
+const tonne Unit = "t"
+
+func withTonne(t *testing.T) {
+	definitions[tonne] = definition{dimension: Mass, precision: 0, base: 1000000}
+	t.Cleanup(func() {
+		delete(definitions, tonne)
+	})
+}
+
+func TestConvert(t *testing.T) {
+	withTonne(t)
+
+	for _, tc := range []struct {
+		name     string
+		quantity uint64
+		from     Unit
+		to       Unit
+		want     uint64
+		wantErr  error
+	}{
+		{name: "kilograms to grams", quantity: 1250, from: Kilogram, to: Gram, want: 1250},
+		{name: "grams to kilograms", quantity: 1500, from: Gram, to: Kilogram, want: 1500},
+		{name: "litres to millilitres", quantity: 2000, from: Litre, to: Millilitre, want: 2000},
+		{name: "metres to centimetres", quantity: 1500, from: Metre, to: Centimetre, want: 1500},
+		{name: "centimetres to metres", quantity: 25, from: Centimetre, to: Metre, want: 25},
+		{name: "same unit", quantity: 7, from: Piece, to: Piece, want: 7},
+		{name: "tonnes to kilograms", quantity: 2, from: tonne, to: Kilogram, want: 2000000},
+		{name: "kilograms to whole tonnes", quantity: 3000000, from: Kilogram, to: tonne, want: 3},
+		{name: "fraction of the target step", quantity: 1500, from: Gram, to: tonne, wantErr: ErrPrecisionExceeded},
+		{name: "kilograms to litres", quantity: 1000, from: Kilogram, to: Litre, wantErr: ErrIncompatibleUnits},
+		{name: "pieces to grams", quantity: 1, from: Piece, to: Gram, wantErr: ErrIncompatibleUnits},
+		{name: "overflow", quantity: math.MaxUint64/1000000 + 1, from: tonne, to: Kilogram, wantErr: ErrQuantityOverflow},
+		{name: "largest quantity that fits", quantity: math.MaxUint64 / 1000000, from: tonne, to: Kilogram, want: math.MaxUint64 / 1000000 * 1000000},
+		{name: "unknown unit", quantity: 1, from: "lb", to: Kilogram, wantErr: ErrUnknownUnit},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// act
+			quantity, err := Convert(tc.quantity, tc.from, tc.to)
+
+			// assert
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, quantity)
+		})
+	}
+}
+
+func TestValidateAmount(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		amount  string
+		wantErr string
+	}{
+		{name: "decimal amount", amount: "0.5"},
+		{name: "whole amount", amount: "3"},
+		{name: "zero", amount: "0", wantErr: "quantity must be greater than 0"},
+		{name: "zero with decimal places", amount: "0.000", wantErr: "quantity must be greater than 0"},
+		{name: "not a number", amount: "1,5", wantErr: strconv.Quote("1,5") + ": invalid decimal quantity"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// act
+			err := ValidateAmount(tc.amount)
+
+			// assert
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package units
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrUnknownUnit       = errors.New("unknown unit")
+	ErrIncompatibleUnits = errors.New("incompatible units")
+	ErrInvalidQuantity   = errors.New("invalid decimal quantity")
+	ErrPrecisionExceeded = errors.New("quantity precision exceeded")
+	ErrQuantityOverflow  = errors.New("quantity is too large")
+)
+
+func ValidateUnit(unit Unit) error {
+	if _, ok := definitions[unit]; !ok {
+		return fmt.Errorf("%s: %w", unit, ErrUnknownUnit)
+	}
+	return nil
+}
+
+func ValidateCompatible(from, to Unit) error {
+	if err := ValidateUnit(from); err != nil {
+		return err
+	}
+	if err := ValidateUnit(to); err != nil {
+		return err
+	}
+	if from.Dimension() != to.Dimension() {
+		return fmt.Errorf("%s -> %s: %w", from, to, ErrIncompatibleUnits)
+	}
+	return nil
+}
+
+// ValidateAmount checks that the decimal quantity is well-formed and greater than zero,
+// the precision depends on the unit and is checked by ParseQuantity.
+func ValidateAmount(amount string) error {
+	whole, fraction, err := splitDecimal(amount)
+	if err != nil {
+		return err
+	}
+	if strings.Trim(whole+fraction, "0") == "" {
+		return errors.New("quantity must be greater than 0")
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Currency is a three-letter ISO 4217 currency code.
+type Currency string
+
+// Base is the currency product prices are stored in, exchange rates convert from it.
+const Base Currency = "RUB"
+
+var ErrInvalidCurrency = errors.New("invalid currency, expected three-letter ISO 4217 code")
+
+func (c Currency) String() string {
+	return string(c)
+}
+
+// ParseCurrency accepts a currency code in any case, e.g. "usd", and returns it upper-cased.
+func ParseCurrency(code string) (Currency, error) {
+	currency := Currency(strings.ToUpper(strings.TrimSpace(code)))
+	if err := ValidateCurrency(currency); err != nil {
+		return "", err
+	}
+	return currency, nil
+}
+
+func ValidateCurrency(currency Currency) error {
+	if len(currency) != 3 || strings.Trim(string(currency), "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return fmt.Errorf("%s: %w", currency, ErrInvalidCurrency)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package money
+
+import (
+	"context"
+	"google.golang.org/grpc/metadata"
+)
+
+// MetadataKey carries the currency a client wants prices in, the gateway fills it
+// from the currency query parameter.
+const MetadataKey = "x-currency"
+
+// FromIncomingContext returns the currency requested by an incoming gRPC request,
+// the base currency when none is requested.
+func FromIncomingContext(ctx context.Context) (Currency, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(MetadataKey); len(values) > 0 && values[0] != "" {
+		return ParseCurrency(values[0])
+	}
+	return Base, nil
+}
+
+func AppendToOutgoingContext(ctx context.Context, currency Currency) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, MetadataKey, currency.String())
+}
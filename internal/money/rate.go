@@ -0,0 +1,114 @@
+// Package money converts product prices from the base currency into the currency a client asks for.
+//
+// Prices are whole numbers of the base currency and stay whole numbers after conversion.
+// An exchange rate is the amount of the target currency in one unit of the base currency,
+// kept as a fixed-point integer with RatePrecision decimal places so conversion needs no floats.
+//
+// Rounding rules:
+//   - the exact product of the price and the rate is rounded to the nearest whole unit,
+//     halves are rounded up (commercial rounding): 2.5 -> 3, 2.49 -> 2;
+//   - a positive price may round down to 0 when the target currency unit is worth more
+//     than twice the price, clients that can't show that should ask for the base currency;
+//   - conversion is applied to every price separately, sums of converted prices can differ
+//     from the converted sum by the rounding of each term.
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RatePrecision is the number of decimal places of an exchange rate.
+const RatePrecision = 6
+
+// rateScale is the fixed-point scale of a rate, 10^RatePrecision.
+const rateScale = 1_000_000
+
+var (
+	ErrInvalidRate   = errors.New("invalid exchange rate, expected a positive decimal number")
+	ErrRatePrecision = fmt.Errorf("exchange rate allows %d decimal places", RatePrecision)
+	ErrBaseRate      = errors.New("exchange rate of the base currency is always 1")
+	ErrOverflow      = errors.New("converted price overflows")
+)
+
+// Rate converts prices from the base currency into the currency.
+type Rate struct {
+	Currency Currency `db:"currency" json:"currency"`
+	// Value is the amount of the currency in one unit of the base currency scaled by 10^RatePrecision.
+	Value     uint64    `db:"rate" json:"rate"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// BaseRate converts prices into the base currency, which leaves them as they are.
+var BaseRate = Rate{Currency: Base, Value: rateScale}
+
+// NewRate parses the decimal rate, e.g. "0.013725" for RUB -> USD.
+func NewRate(currency Currency, rate string) (*Rate, error) {
+	if err := ValidateCurrency(currency); err != nil {
+		return nil, err
+	}
+	if currency == Base {
+		return nil, ErrBaseRate
+	}
+
+	value, err := ParseRate(rate)
+	if err != nil {
+		return nil, err
+	}
+	return &Rate{Currency: currency, Value: value}, nil
+}
+
+// ParseRate parses a positive decimal rate into its fixed-point value.
+func ParseRate(rate string) (uint64, error) {
+	whole, fraction, hasFraction := strings.Cut(rate, ".")
+	if whole == "" || (hasFraction && fraction == "") || strings.Trim(whole+fraction, "0123456789") != "" {
+		return 0, fmt.Errorf("%q: %w", rate, ErrInvalidRate)
+	}
+	if len(fraction) > RatePrecision {
+		return 0, fmt.Errorf("%q: %w", rate, ErrRatePrecision)
+	}
+
+	value, err := strconv.ParseUint(whole+fraction+strings.Repeat("0", RatePrecision-len(fraction)), 10, 64)
+	if err != nil || value == 0 {
+		return 0, fmt.Errorf("%q: %w", rate, ErrInvalidRate)
+	}
+	return value, nil
+}
+
+// FormatRate formats the fixed-point rate as a decimal number without trailing zeros.
+func FormatRate(value uint64) string {
+	fraction := strings.TrimRight(fmt.Sprintf("%0*d", RatePrecision, value%rateScale), "0")
+	if fraction == "" {
+		return strconv.FormatUint(value/rateScale, 10)
+	}
+	return fmt.Sprintf("%d.%s", value/rateScale, fraction)
+}
+
+func (r *Rate) String() string {
+	return fmt.Sprintf("1 %s = %s %s", Base, FormatRate(r.Value), r.Currency)
+}
+
+func (r *Rate) Copy() *Rate {
+	rate := *r
+	return &rate
+}
+
+// Convert converts the price in the base currency into the rate currency, see the package
+// documentation for the rounding rules.
+func (r *Rate) Convert(price uint64) (uint64, error) {
+	hi, lo := bits.Mul64(price, r.Value)
+	// adding half of the scale before the division rounds halves up
+	var carry uint64
+	lo, carry = bits.Add64(lo, rateScale/2, 0)
+	hi += carry
+	if hi >= rateScale {
+		return 0, fmt.Errorf("%d %s in %s: %w", price, Base, r.Currency, ErrOverflow)
+	}
+
+	converted, _ := bits.Div64(hi, lo, rateScale)
+	return converted, nil
+}
@@ -0,0 +1,127 @@
+package money
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"math"
+	"testing"
+)
+
+func TestParseRate(t *testing.T) {
+	t.Run("decimal rate", func(t *testing.T) {
+		// act
+		value, err := ParseRate("0.013725")
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, uint64(13725), value)
+		assert.Equal(t, "0.013725", FormatRate(value))
+	})
+
+	t.Run("whole rate", func(t *testing.T) {
+		// act
+		value, err := ParseRate("60")
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, "60", FormatRate(value))
+	})
+
+	t.Run("too precise", func(t *testing.T) {
+		// act
+		_, err := ParseRate("0.0000001")
+
+		// assert
+		assert.ErrorIs(t, err, ErrRatePrecision)
+	})
+
+	t.Run("zero", func(t *testing.T) {
+		// act
+		_, err := ParseRate("0.0")
+
+		// assert
+		assert.ErrorIs(t, err, ErrInvalidRate)
+	})
+}
+
+func TestConvert(t *testing.T) {
+	t.Run("halves are rounded up", func(t *testing.T) {
+		// arrange
+		rate := Rate{Currency: "USD", Value: 25_000}
+
+		// act
+		converted, err := rate.Convert(100)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, uint64(3), converted)
+	})
+
+	t.Run("below half is rounded down", func(t *testing.T) {
+		// arrange
+		rate := Rate{Currency: "USD", Value: 24_999}
+
+		// act
+		converted, err := rate.Convert(100)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), converted)
+	})
+
+	t.Run("base rate keeps the price", func(t *testing.T) {
+		// act
+		converted, err := BaseRate.Convert(math.MaxUint64)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, uint64(math.MaxUint64), converted)
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		// arrange
+		rate := Rate{Currency: "KZT", Value: 6_000_000}
+
+		// act
+		_, err := rate.Convert(math.MaxUint64)
+
+		// assert
+		assert.ErrorIs(t, err, ErrOverflow)
+	})
+}
+
+func TestFromIncomingContext(t *testing.T) {
+	t.Run("currency is upper-cased", func(t *testing.T) {
+		// arrange
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, "usd"))
+
+		// act
+		currency, err := FromIncomingContext(ctx)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, Currency("USD"), currency)
+	})
+
+	t.Run("no metadata", func(t *testing.T) {
+		// act
+		currency, err := FromIncomingContext(context.Background())
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, Base, currency)
+	})
+
+	t.Run("invalid currency", func(t *testing.T) {
+		// arrange
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, "dollar"))
+
+		// act
+		_, err := FromIncomingContext(ctx)
+
+		// assert
+		assert.ErrorIs(t, err, ErrInvalidCurrency)
+	})
+}
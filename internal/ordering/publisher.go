@@ -0,0 +1,60 @@
+package ordering
+
+import (
+	"context"
+	"github.com/Shopify/sarama"
+	"homework-1/internal/tenants"
+	"sync"
+)
+
+// Publisher delivers an encoded event to a topic, the event belongs to the tenant of ctx.
+type Publisher interface {
+	Publish(ctx context.Context, topic string, key string, value []byte) error
+}
+
+// KafkaPublisher publishes events with a sarama sync producer.
+type KafkaPublisher struct {
+	Producer sarama.SyncProducer
+}
+
+func (p *KafkaPublisher) Publish(ctx context.Context, topic string, key string, value []byte) error {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, _, err = p.Producer.SendMessage(&sarama.ProducerMessage{
+		Topic:   topic,
+		Key:     sarama.StringEncoder(key),
+		Value:   sarama.ByteEncoder(value),
+		Headers: []sarama.RecordHeader{tenants.MessageHeader(tenant)},
+	})
+	return err
+}
+
+// MemoryBus keeps published events in memory, it replaces kafka in tests.
+type MemoryBus struct {
+	mu     sync.Mutex
+	topics map[string][][]byte
+}
+
+func NewMemoryBus() *MemoryBus {
+	return &MemoryBus{topics: make(map[string][][]byte)}
+}
+
+func (b *MemoryBus) Publish(_ context.Context, topic string, _ string, value []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.topics[topic] = append(b.topics[topic], value)
+	return nil
+}
+
+// Messages returns the events published to the topic in publish order.
+func (b *MemoryBus) Messages(topic string) [][]byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	messages := make([][]byte, len(b.topics[topic]))
+	copy(messages, b.topics[topic])
+	return messages
+}
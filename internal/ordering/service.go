@@ -0,0 +1,93 @@
+package ordering
+
+import (
+	"context"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+	"homework-1/internal/models/orders"
+	"homework-1/internal/repository"
+	"homework-1/internal/tenants"
+	pb "homework-1/pkg/api/storage/v1"
+	"time"
+)
+
+// compensateTimeout bounds releasing reserved stock, which must not depend on the
+// request context that may already be cancelled, only its tenant is kept.
+const compensateTimeout = time.Second * 5
+
+// Service places orders as a saga: stock is reserved line by line and when any step fails
+// the lines reserved so far are released and the order is cancelled.
+type Service struct {
+	Repository     repository.Product
+	Publisher      Publisher
+	PlacedTopic    string
+	CancelledTopic string
+}
+
+// PlaceOrder reserves stock for every line and publishes OrderPlaced. On failure the
+// reservations are compensated, OrderCancelled is published and the cause is returned.
+func (s *Service) PlaceOrder(ctx context.Context, order *orders.Order) error {
+	reserved := make([]*orders.Line, 0, len(order.Lines))
+	for _, line := range order.Lines {
+		_, picked, err := s.Repository.ReserveProduct(ctx, line.ProductId, line.Quantity)
+		if err != nil {
+			s.compensate(ctx, order, reserved, err)
+			return err
+		}
+		line.Lots = picked
+		reserved = append(reserved, line)
+	}
+
+	order.Place()
+	if err := s.publish(ctx, s.PlacedTopic, order.Id, &pb.OrderPlaced{
+		OrderId: order.Id,
+		Lines:   linesToPb(order.Lines),
+	}); err != nil {
+		err = fmt.Errorf("Service.PlaceOrder: publish order placed: %w", err)
+		s.compensate(ctx, order, reserved, err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) compensate(ctx context.Context, order *orders.Order, reserved []*orders.Line, cause error) {
+	ctx, cancel := context.WithTimeout(tenants.Detach(ctx), compensateTimeout)
+	defer cancel()
+
+	for i := len(reserved) - 1; i >= 0; i-- {
+		line := reserved[i]
+		if _, err := s.Repository.ReleaseProduct(ctx, line.ProductId, line.Quantity, line.Lots); err != nil {
+			log.WithError(err).Errorf("OrderService: order %s: release product %d", order.Id, line.ProductId)
+		}
+	}
+
+	order.Cancel(cause.Error())
+	if err := s.publish(ctx, s.CancelledTopic, order.Id, &pb.OrderCancelled{
+		OrderId: order.Id,
+		Reason:  order.Reason,
+		Lines:   linesToPb(order.Lines),
+	}); err != nil {
+		log.WithError(err).Errorf("OrderService: order %s: publish order cancelled", order.Id)
+	}
+}
+
+func (s *Service) publish(ctx context.Context, topic string, key string, event proto.Message) error {
+	data, err := proto.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return s.Publisher.Publish(ctx, topic, key, data)
+}
+
+func linesToPb(lines []*orders.Line) []*pb.OrderLine {
+	result := make([]*pb.OrderLine, 0, len(lines))
+	for _, line := range lines {
+		result = append(result, &pb.OrderLine{
+			ProductId: line.ProductId,
+			Quantity:  line.Quantity,
+		})
+	}
+	return result
+}
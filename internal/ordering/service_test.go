@@ -0,0 +1,166 @@
+package ordering
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"homework-1/internal/models/orders"
+	"homework-1/internal/models/products"
+	"homework-1/internal/repository"
+	localRepository "homework-1/internal/repository/local"
+	"homework-1/internal/tenants"
+	pb "homework-1/pkg/api/storage/v1"
+	"testing"
+)
+
+const (
+	placedTopic    = "orderPlaced"
+	cancelledTopic = "orderCancelled"
+)
+
+type serviceFixture struct {
+	service     *Service
+	productRepo repository.Product
+	bus         *MemoryBus
+	ctx         context.Context
+}
+
+func SetUp(t *testing.T) *serviceFixture {
+	f := serviceFixture{
+		productRepo: localRepository.NewRepository(localRepository.NewWarehouse()),
+		bus:         NewMemoryBus(),
+		ctx:         tenants.NewContext(context.Background(), "shop"),
+	}
+	f.service = &Service{
+		Repository:     f.productRepo,
+		Publisher:      f.bus,
+		PlacedTopic:    placedTopic,
+		CancelledTopic: cancelledTopic,
+	}
+
+	for _, quantity := range []uint64{5, 1} {
+		_, err := f.productRepo.CreateProduct(f.ctx, products.Product{
+			Name:     "product",
+			Price:    uint64(1),
+			Quantity: quantity,
+			Status:   products.StatusActive,
+		})
+		require.NoError(t, err)
+	}
+	return &f
+}
+
+func (f *serviceFixture) quantity(t *testing.T, id uint64) uint64 {
+	product, err := f.productRepo.GetProductById(f.ctx, id)
+	require.NoError(t, err)
+	return product.Quantity
+}
+
+type failingPublisher struct {
+	*MemoryBus
+	failTopic string
+}
+
+func (p *failingPublisher) Publish(ctx context.Context, topic string, key string, value []byte) error {
+	if topic == p.failTopic {
+		return errors.New("broker is not available")
+	}
+	return p.MemoryBus.Publish(ctx, topic, key, value)
+}
+
+func TestPlaceOrder(t *testing.T) {
+	t.Run("success placing order", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		order, err := orders.NewOrder("order1", []*orders.Line{
+			{ProductId: uint64(1), Quantity: uint64(3)},
+			{ProductId: uint64(2), Quantity: uint64(1)},
+		})
+		require.NoError(t, err)
+
+		// act
+		err = f.service.PlaceOrder(f.ctx, order)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, order.Status, orders.StatusPlaced)
+		assert.Equal(t, f.quantity(t, 1), uint64(2))
+		assert.Equal(t, f.quantity(t, 2), uint64(0))
+
+		messages := f.bus.Messages(placedTopic)
+		require.Len(t, messages, 1)
+		placed := pb.OrderPlaced{}
+		require.NoError(t, proto.Unmarshal(messages[0], &placed))
+		assert.Equal(t, placed.GetOrderId(), "order1")
+		assert.Len(t, placed.GetLines(), 2)
+		assert.Empty(t, f.bus.Messages(cancelledTopic))
+	})
+
+	t.Run("not enough quantity releases reserved lines", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		order, err := orders.NewOrder("order1", []*orders.Line{
+			{ProductId: uint64(1), Quantity: uint64(3)},
+			{ProductId: uint64(2), Quantity: uint64(2)},
+		})
+		require.NoError(t, err)
+
+		// act
+		err = f.service.PlaceOrder(f.ctx, order)
+
+		// assert
+		assert.ErrorIs(t, err, products.ErrNotEnoughQuantity)
+		assert.Equal(t, order.Status, orders.StatusCancelled)
+		assert.Equal(t, f.quantity(t, 1), uint64(5))
+		assert.Equal(t, f.quantity(t, 2), uint64(1))
+
+		messages := f.bus.Messages(cancelledTopic)
+		require.Len(t, messages, 1)
+		cancelled := pb.OrderCancelled{}
+		require.NoError(t, proto.Unmarshal(messages[0], &cancelled))
+		assert.Equal(t, cancelled.GetOrderId(), "order1")
+		assert.Equal(t, cancelled.GetReason(), "2: not enough quantity")
+		assert.Empty(t, f.bus.Messages(placedTopic))
+	})
+
+	t.Run("unknown product releases reserved lines", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		order, err := orders.NewOrder("order1", []*orders.Line{
+			{ProductId: uint64(1), Quantity: uint64(3)},
+			{ProductId: uint64(3), Quantity: uint64(1)},
+		})
+		require.NoError(t, err)
+
+		// act
+		err = f.service.PlaceOrder(f.ctx, order)
+
+		// assert
+		assert.ErrorIs(t, err, repository.ProductNotExists)
+		assert.Equal(t, f.quantity(t, 1), uint64(5))
+		assert.Len(t, f.bus.Messages(cancelledTopic), 1)
+	})
+
+	t.Run("failed publish releases all lines", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		f.service.Publisher = &failingPublisher{MemoryBus: f.bus, failTopic: placedTopic}
+		order, err := orders.NewOrder("order1", []*orders.Line{
+			{ProductId: uint64(1), Quantity: uint64(3)},
+			{ProductId: uint64(2), Quantity: uint64(1)},
+		})
+		require.NoError(t, err)
+
+		// act
+		err = f.service.PlaceOrder(f.ctx, order)
+
+		// assert
+		assert.Error(t, err)
+		assert.Equal(t, order.Status, orders.StatusCancelled)
+		assert.Equal(t, f.quantity(t, 1), uint64(5))
+		assert.Equal(t, f.quantity(t, 2), uint64(1))
+		assert.Len(t, f.bus.Messages(cancelledTopic), 1)
+	})
+}
@@ -0,0 +1,53 @@
+package policy
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"homework-1/internal/auth"
+	"homework-1/internal/health"
+)
+
+// UnaryServerInterceptor checks the roles of the identity the auth interceptor put into the
+// context against Methods. It has to run after the auth interceptor.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streaming methods.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := authorize(stream.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
+
+func authorize(ctx context.Context, method string) error {
+	if health.IsHealthCheck(method) {
+		return nil
+	}
+
+	identity, ok := auth.FromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, auth.ErrNoCredentials.Error())
+	}
+	permission, ok := Methods[method]
+	if !ok {
+		log.Warnf("%s: method has no permission in the policy, denied", method)
+		return status.Error(codes.PermissionDenied, ErrPermissionDenied.Error())
+	}
+	if err := Check(identity.Roles, permission); err != nil {
+		log.Infof("%s: %s: %v", method, identity.Subject, err)
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return nil
+}
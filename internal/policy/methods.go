@@ -0,0 +1,101 @@
+package policy
+
+// Methods is the permission every gRPC method requires. A method missing here is denied,
+// so a new rpc has to be added before anyone can call it.
+var Methods = map[string]Permission{
+	// api.v1
+	"/api.v1.ApiService/ProductList":          Read,
+	"/api.v1.ApiService/ProductGet":           Read,
+	"/api.v1.ApiService/ProductCreate":        Write,
+	"/api.v1.ApiService/ProductUpdate":        Write,
+	"/api.v1.ApiService/ProductDelete":        Delete,
+	"/api.v1.ApiService/ProductTransition":    Write,
+	"/api.v1.ApiService/ApproveChange":        Approve,
+	"/api.v1.ApiService/RejectChange":         Approve,
+	"/api.v1.ApiService/ListLowStock":         Read,
+	"/api.v1.ApiService/SetReorderThreshold":  Write,
+	"/api.v1.ApiService/SupplierCreate":       Write,
+	"/api.v1.ApiService/SupplierList":         Read,
+	"/api.v1.ApiService/PurchaseOrderCreate":  Write,
+	"/api.v1.ApiService/PurchaseOrderGet":     Read,
+	"/api.v1.ApiService/PurchaseOrderList":    Read,
+	"/api.v1.ApiService/PurchaseOrderReceive": Write,
+	"/api.v1.ApiService/PlaceOrder":           Write,
+	"/api.v1.ApiService/LotAdd":               Write,
+	"/api.v1.ApiService/LotList":              Read,
+	"/api.v1.ApiService/ListExpiringLots":     Read,
+	"/api.v1.ApiService/StocktakeOpen":        Write,
+	"/api.v1.ApiService/StocktakeCount":       Write,
+	"/api.v1.ApiService/StocktakeGet":         Read,
+	"/api.v1.ApiService/StocktakeCommit":      Write,
+	"/api.v1.ApiService/StockValuation":       Read,
+	"/api.v1.ApiService/TopProductsByValue":   Read,
+	"/api.v1.ApiService/ProductGetByBarcode":  Read,
+	"/api.v1.ApiService/ProductTranslate":     Write,
+	"/api.v1.ApiService/UploadProductImage":   Write,
+	"/api.v1.ApiService/DownloadProductImage": Read,
+	"/api.v1.ApiService/ListProductImages":    Read,
+	"/api.v1.ApiService/RelationCreate":       Write,
+	"/api.v1.ApiService/RelationList":         Read,
+	"/api.v1.ApiService/RelationUpdate":       Write,
+	"/api.v1.ApiService/RelationDelete":       Delete,
+	"/api.v1.ApiService/GetRelatedProducts":   Read,
+	"/api.v1.ApiService/ExchangeRateSet":      Write,
+	"/api.v1.ApiService/ExchangeRateList":     Read,
+
+	// api.v2
+	"/api.v2.ApiService/ProductList":      Read,
+	"/api.v2.ApiService/AsyncProductList": Read,
+	"/api.v2.ApiService/ProductGet":       Read,
+	"/api.v2.ApiService/ProductCreate":    Write,
+	"/api.v2.ApiService/ProductUpdate":    Write,
+	"/api.v2.ApiService/ProductDelete":    Delete,
+
+	// api.storage.v1
+	"/api.storage.v1.StorageService/ProductList":          Read,
+	"/api.storage.v1.StorageService/ProductGet":           Read,
+	"/api.storage.v1.StorageService/ProductCreate":        Write,
+	"/api.storage.v1.StorageService/ProductUpdate":        Write,
+	"/api.storage.v1.StorageService/ProductDelete":        Delete,
+	"/api.storage.v1.StorageService/ProductTransition":    Write,
+	"/api.storage.v1.StorageService/ApproveChange":        Approve,
+	"/api.storage.v1.StorageService/RejectChange":         Approve,
+	"/api.storage.v1.StorageService/ListLowStock":         Read,
+	"/api.storage.v1.StorageService/SetReorderThreshold":  Write,
+	"/api.storage.v1.StorageService/SupplierCreate":       Write,
+	"/api.storage.v1.StorageService/SupplierList":         Read,
+	"/api.storage.v1.StorageService/PurchaseOrderCreate":  Write,
+	"/api.storage.v1.StorageService/PurchaseOrderGet":     Read,
+	"/api.storage.v1.StorageService/PurchaseOrderList":    Read,
+	"/api.storage.v1.StorageService/PurchaseOrderReceive": Write,
+	"/api.storage.v1.StorageService/PlaceOrder":           Write,
+	"/api.storage.v1.StorageService/LotAdd":               Write,
+	"/api.storage.v1.StorageService/LotList":              Read,
+	"/api.storage.v1.StorageService/ListExpiringLots":     Read,
+	"/api.storage.v1.StorageService/StocktakeOpen":        Write,
+	"/api.storage.v1.StorageService/StocktakeCount":       Write,
+	"/api.storage.v1.StorageService/StocktakeGet":         Read,
+	"/api.storage.v1.StorageService/StocktakeCommit":      Write,
+	"/api.storage.v1.StorageService/StockValuation":       Read,
+	"/api.storage.v1.StorageService/TopProductsByValue":   Read,
+	"/api.storage.v1.StorageService/ProductGetByBarcode":  Read,
+	"/api.storage.v1.StorageService/ProductTranslate":     Write,
+	"/api.storage.v1.StorageService/UploadProductImage":   Write,
+	"/api.storage.v1.StorageService/DownloadProductImage": Read,
+	"/api.storage.v1.StorageService/ListProductImages":    Read,
+	"/api.storage.v1.StorageService/RelationCreate":       Write,
+	"/api.storage.v1.StorageService/RelationList":         Read,
+	"/api.storage.v1.StorageService/RelationUpdate":       Write,
+	"/api.storage.v1.StorageService/RelationDelete":       Delete,
+	"/api.storage.v1.StorageService/GetRelatedProducts":   Read,
+	"/api.storage.v1.StorageService/ExchangeRateSet":      Write,
+	"/api.storage.v1.StorageService/ExchangeRateList":     Read,
+
+	// api.storage.v2
+	"/api.storage.v2.StorageService/ProductList":      Read,
+	"/api.storage.v2.StorageService/AsyncProductList": Read,
+	"/api.storage.v2.StorageService/ProductGet":       Read,
+	"/api.storage.v2.StorageService/ProductCreate":    Write,
+	"/api.storage.v2.StorageService/ProductUpdate":    Write,
+	"/api.storage.v2.StorageService/ProductDelete":    Delete,
+}
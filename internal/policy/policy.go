@@ -0,0 +1,142 @@
+// Package policy decides which roles may call which methods. Callers are identified by
+// the auth package, the roles come with their API key, token or Telegram user id.
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// Role is a set of permissions, every role includes the permissions of the role before it.
+type Role string
+
+const (
+	// Viewer reads products, stock and reports
+	Viewer Role = "viewer"
+	// Editor creates and updates products, stock and orders
+	Editor Role = "editor"
+	// Admin approves price changes, deletes products and relations and grants bot access
+	Admin Role = "admin"
+)
+
+// Permission is what a method or a bot command requires.
+type Permission string
+
+const (
+	Read    Permission = "read"
+	Write   Permission = "write"
+	Approve Permission = "approve"
+	Delete  Permission = "delete"
+	// Manage grants and revokes the roles of bot users
+	Manage Permission = "manage"
+)
+
+var rolePermissions = map[Role][]Permission{
+	Viewer: {Read},
+	Editor: {Read, Write},
+	Admin:  {Read, Write, Approve, Delete, Manage},
+}
+
+var (
+	ErrUnknownRole      = errors.New("unknown role")
+	ErrPermissionDenied = errors.New("permission denied")
+)
+
+func ParseRole(value string) (Role, error) {
+	role := Role(value)
+	if _, ok := rolePermissions[role]; !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownRole, value)
+	}
+	return role, nil
+}
+
+// Allowed reports whether any of roles grants permission. Unknown roles grant nothing.
+func Allowed(roles []string, permission Permission) bool {
+	for _, role := range roles {
+		for _, granted := range rolePermissions[Role(role)] {
+			if granted == permission {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Check returns ErrPermissionDenied unless roles grant permission.
+func Check(roles []string, permission Permission) error {
+	if !Allowed(roles, permission) {
+		return fmt.Errorf("%w: %s requires %s", ErrPermissionDenied, roleList(roles), permission)
+	}
+	return nil
+}
+
+func roleList(roles []string) string {
+	if len(roles) == 0 {
+		return "caller without roles"
+	}
+	return fmt.Sprintf("roles %v", roles)
+}
+
+// UserRoles assigns roles to Telegram user ids.
+type UserRoles struct {
+	users map[int64]Role
+	// Default is the role of users not listed, empty denies them every command
+	Default Role
+}
+
+func NewUserRoles(users map[int64]Role, defaultRole Role) *UserRoles {
+	return &UserRoles{users: users, Default: defaultRole}
+}
+
+// LoadUserRoles reads a JSON object of Telegram user ids and roles, {"123456": "admin"}.
+// A missing file assigns every user the default role.
+func LoadUserRoles(path string, defaultRole string) (*UserRoles, error) {
+	var fallback Role
+	if defaultRole != "" {
+		role, err := ParseRole(defaultRole)
+		if err != nil {
+			return nil, fmt.Errorf("default role: %w", err)
+		}
+		fallback = role
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return NewUserRoles(nil, fallback), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries map[string]string
+	if err = json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	users := make(map[int64]Role, len(entries))
+	for id, value := range entries {
+		userId, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: user id %s: %w", path, id, err)
+		}
+		role, err := ParseRole(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: user %d: %w", path, userId, err)
+		}
+		users[userId] = role
+	}
+	return NewUserRoles(users, fallback), nil
+}
+
+// Roles returns the roles of a Telegram user.
+func (u *UserRoles) Roles(userId int64) []string {
+	if role, ok := u.users[userId]; ok {
+		return []string{string(role)}
+	}
+	if u.Default != "" {
+		return []string{string(u.Default)}
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package policy
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"homework-1/internal/auth"
+	pbStorageV1 "homework-1/pkg/api/storage/v1"
+	pbStorageV2 "homework-1/pkg/api/storage/v2"
+	pbApiV1 "homework-1/pkg/api/v1"
+	pbApiV2 "homework-1/pkg/api/v2"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAllowed(t *testing.T) {
+	for _, tc := range []struct {
+		role    Role
+		allowed []Permission
+		denied  []Permission
+	}{
+		{role: Viewer, allowed: []Permission{Read}, denied: []Permission{Write, Approve, Delete, Manage}},
+		{role: Editor, allowed: []Permission{Read, Write}, denied: []Permission{Approve, Delete, Manage}},
+		{role: Admin, allowed: []Permission{Read, Write, Approve, Delete, Manage}},
+	} {
+		for _, permission := range tc.allowed {
+			assert.True(t, Allowed([]string{string(tc.role)}, permission), "%s %s", tc.role, permission)
+		}
+		for _, permission := range tc.denied {
+			assert.False(t, Allowed([]string{string(tc.role)}, permission), "%s %s", tc.role, permission)
+		}
+	}
+
+	assert.False(t, Allowed([]string{"root"}, Read))
+	assert.False(t, Allowed(nil, Read))
+}
+
+func TestMethodsCoverServices(t *testing.T) {
+	for _, service := range []grpc.ServiceDesc{
+		pbApiV1.ApiService_ServiceDesc,
+		pbApiV2.ApiService_ServiceDesc,
+		pbStorageV1.StorageService_ServiceDesc,
+		pbStorageV2.StorageService_ServiceDesc,
+	} {
+		for _, method := range service.Methods {
+			assert.Contains(t, Methods, "/"+service.ServiceName+"/"+method.MethodName)
+		}
+		for _, stream := range service.Streams {
+			assert.Contains(t, Methods, "/"+service.ServiceName+"/"+stream.StreamName)
+		}
+	}
+}
+
+func TestLoadUserRoles(t *testing.T) {
+	t.Run("listed and other users", func(t *testing.T) {
+		// arrange
+		path := filepath.Join(t.TempDir(), "bot_users.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"42": "admin"}`), 0600))
+
+		// act
+		users, err := LoadUserRoles(path, "viewer")
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{"admin"}, users.Roles(42))
+		assert.Equal(t, []string{"viewer"}, users.Roles(7))
+	})
+
+	t.Run("missing file without default role", func(t *testing.T) {
+		// act
+		users, err := LoadUserRoles(filepath.Join(t.TempDir(), "missing.json"), "")
+
+		// assert
+		require.NoError(t, err)
+		assert.Empty(t, users.Roles(42))
+	})
+
+	t.Run("unknown role", func(t *testing.T) {
+		// arrange
+		path := filepath.Join(t.TempDir(), "bot_users.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"42": "owner"}`), 0600))
+
+		// act
+		_, err := LoadUserRoles(path, "")
+
+		// assert
+		assert.ErrorIs(t, err, ErrUnknownRole)
+	})
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := UnaryServerInterceptor()
+	call := func(ctx context.Context, method string) error {
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		})
+		return err
+	}
+	viewer := auth.NewContext(context.Background(), &auth.Identity{Subject: "alice", Roles: []string{string(Viewer)}})
+
+	t.Run("permitted", func(t *testing.T) {
+		assert.NoError(t, call(viewer, "/api.v1.ApiService/ProductList"))
+	})
+
+	t.Run("not permitted", func(t *testing.T) {
+		assert.Equal(t, codes.PermissionDenied, status.Code(call(viewer, "/api.v1.ApiService/ProductDelete")))
+	})
+
+	t.Run("method missing from the policy", func(t *testing.T) {
+		assert.Equal(t, codes.PermissionDenied, status.Code(call(viewer, "/api.v1.ApiService/Unknown")))
+	})
+
+	t.Run("without identity", func(t *testing.T) {
+		assert.Equal(t, codes.Unauthenticated, status.Code(call(context.Background(), "/api.v1.ApiService/ProductList")))
+	})
+
+	t.Run("health check", func(t *testing.T) {
+		assert.NoError(t, call(context.Background(), "/grpc.health.v1.Health/Check"))
+	})
+}
@@ -0,0 +1,31 @@
+package ratelimit
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// Middleware rejects requests over the limit with 429 Too Many Requests and a Retry-After header.
+// The gateway does not verify credentials, so it counts requests by peer address and leaves
+// the limits of authenticated clients to the api services behind it.
+func Middleware(limiter *Limiter, next http.Handler, exempt ...string) http.Handler {
+	exemptPaths := make(map[string]struct{}, len(exempt))
+	for _, path := range exempt {
+		exemptPaths[path] = struct{}{}
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := exemptPaths[r.URL.Path]; ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		allowed, retryAfter := limiter.Allow(r.Context(), Client{Address: host(r.RemoteAddr)})
+		if !allowed {
+			w.Header().Set("Retry-After", strconv.FormatInt(retryAfterSeconds(retryAfter), 10))
+			http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"homework-1/internal/auth"
+	"homework-1/internal/health"
+	"net"
+	"strconv"
+)
+
+// RetryAfterMetadataKey is the response header with the seconds a rejected client should wait.
+const RetryAfterMetadataKey = "retry-after"
+
+// UnaryServerInterceptor rejects requests over the limit of their client with ResourceExhausted.
+// It has to run after the auth interceptor to count authenticated requests by their subject.
+func UnaryServerInterceptor(limiter *Limiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if health.IsHealthCheck(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		if err := limit(ctx, limiter, func(md metadata.MD) error { return grpc.SetHeader(ctx, md) }); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streaming methods, a stream takes one token.
+func StreamServerInterceptor(limiter *Limiter) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if health.IsHealthCheck(info.FullMethod) {
+			return handler(srv, stream)
+		}
+		if err := limit(stream.Context(), limiter, stream.SetHeader); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
+
+func limit(ctx context.Context, limiter *Limiter, setHeader func(metadata.MD) error) error {
+	allowed, retryAfter := limiter.Allow(ctx, clientOf(ctx))
+	if allowed {
+		return nil
+	}
+	seconds := retryAfterSeconds(retryAfter)
+	_ = setHeader(metadata.Pairs(RetryAfterMetadataKey, strconv.FormatInt(seconds, 10)))
+	return status.Errorf(codes.ResourceExhausted, "rate limit exceeded, retry after %d s", seconds)
+}
+
+func clientOf(ctx context.Context) Client {
+	var client Client
+	if identity, ok := auth.FromContext(ctx); ok {
+		client.Subject = identity.Subject
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		client.Address = host(p.Addr.String())
+	}
+	return client
+}
+
+// host drops the port, the connections of one client come from many ports.
+func host(address string) string {
+	if h, _, err := net.SplitHostPort(address); err == nil {
+		return h
+	}
+	return address
+}
@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"context"
+	"homework-1/internal/ratelimit"
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepEvery is how many takes pass between removals of full buckets, a full bucket is the
+// same as a missing one so dropping it keeps the map from growing with every peer seen.
+const sweepEvery = 1024
+
+// Store keeps the buckets in process, each instance of a service limits on its own.
+type Store struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	takes   int
+	now     func() time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	limit   ratelimit.Limit
+}
+
+func New() *Store {
+	return &Store{buckets: make(map[string]*bucket), now: time.Now}
+}
+
+func (s *Store) Take(_ context.Context, key string, limit ratelimit.Limit) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.takes++
+	if s.takes%sweepEvery == 0 {
+		s.sweep(now)
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), updated: now}
+		s.buckets[key] = b
+	}
+	b.refill(now, limit)
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0, nil
+	}
+	missing := 1 - b.tokens
+	return false, time.Duration(math.Ceil(missing / limit.Rate * float64(time.Second))), nil
+}
+
+func (b *bucket) refill(now time.Time, limit ratelimit.Limit) {
+	elapsed := now.Sub(b.updated).Seconds()
+	if elapsed > 0 {
+		b.tokens = math.Min(float64(limit.Burst), b.tokens+elapsed*limit.Rate)
+		b.updated = now
+	}
+	b.limit = limit
+}
+
+func (s *Store) sweep(now time.Time) {
+	for key, b := range s.buckets {
+		b.refill(now, b.limit)
+		if b.tokens >= float64(b.limit.Burst) {
+			delete(s.buckets, key)
+		}
+	}
+}
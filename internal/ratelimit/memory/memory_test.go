@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"homework-1/internal/ratelimit"
+	"testing"
+	"time"
+)
+
+func TestStore(t *testing.T) {
+	limit := ratelimit.Limit{Rate: 2, Burst: 3}
+
+	newStore := func() (*Store, *time.Time) {
+		store := New()
+		now := time.Date(2022, 10, 5, 12, 0, 0, 0, time.UTC)
+		store.now = func() time.Time { return now }
+		return store, &now
+	}
+
+	t.Run("burst then rejected", func(t *testing.T) {
+		// arrange
+		store, _ := newStore()
+		for i := 0; i < limit.Burst; i++ {
+			allowed, _, err := store.Take(context.Background(), "alice", limit)
+			require.NoError(t, err)
+			require.True(t, allowed)
+		}
+
+		// act
+		allowed, retryAfter, err := store.Take(context.Background(), "alice", limit)
+
+		// assert
+		require.NoError(t, err)
+		assert.False(t, allowed)
+		assert.Equal(t, 500*time.Millisecond, retryAfter)
+	})
+
+	t.Run("refilled over time", func(t *testing.T) {
+		// arrange
+		store, now := newStore()
+		for i := 0; i < limit.Burst; i++ {
+			_, _, _ = store.Take(context.Background(), "alice", limit)
+		}
+		*now = now.Add(500 * time.Millisecond)
+
+		// act
+		allowed, _, err := store.Take(context.Background(), "alice", limit)
+
+		// assert
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	})
+
+	t.Run("clients have their own buckets", func(t *testing.T) {
+		// arrange
+		store, _ := newStore()
+		for i := 0; i < limit.Burst; i++ {
+			_, _, _ = store.Take(context.Background(), "alice", limit)
+		}
+
+		// act
+		allowed, _, err := store.Take(context.Background(), "bob", limit)
+
+		// assert
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	})
+
+	t.Run("full buckets are swept", func(t *testing.T) {
+		// arrange
+		store, now := newStore()
+		_, _, _ = store.Take(context.Background(), "alice", limit)
+		*now = now.Add(time.Minute)
+
+		// act
+		for i := 1; i < sweepEvery; i++ {
+			_, _, _ = store.Take(context.Background(), "bob", limit)
+			*now = now.Add(time.Second)
+		}
+
+		// assert
+		assert.NotContains(t, store.buckets, "alice")
+	})
+}
@@ -0,0 +1,115 @@
+// Package ratelimit keeps a single client from starving the others. Every client has a token
+// bucket, a request takes a token and is rejected when the bucket is empty.
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"os"
+	"time"
+)
+
+// Limit is a token bucket: Burst tokens at most, refilled at Rate tokens per second.
+type Limit struct {
+	Rate  float64 `json:"rate"`
+	Burst int     `json:"burst"`
+}
+
+func (l Limit) validate() error {
+	if l.Rate <= 0 || l.Burst < 1 {
+		return fmt.Errorf("rate must be positive and burst at least 1, got %v and %d", l.Rate, l.Burst)
+	}
+	return nil
+}
+
+// Store keeps the buckets. Take takes a token from the bucket of key and, when it is empty,
+// returns false with the time until the next token.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (bool, time.Duration, error)
+}
+
+// Limiter applies the limit of a client to its bucket in Store.
+type Limiter struct {
+	Store Store
+	// Default is the limit of clients without a quota of their own
+	Default Limit
+	// Quotas are the limits of single clients by subject
+	Quotas map[string]Limit
+}
+
+// NewLimiter limits clients to def in store, or to their quota in quotasFile, see LoadQuotas.
+func NewLimiter(store Store, def Limit, quotasFile string) (*Limiter, error) {
+	if err := def.validate(); err != nil {
+		return nil, fmt.Errorf("default limit: %w", err)
+	}
+	quotas, err := LoadQuotas(quotasFile)
+	if err != nil {
+		return nil, err
+	}
+	return &Limiter{Store: store, Default: def, Quotas: quotas}, nil
+}
+
+// Allow takes a token of client, keyed by the identity subject when one is known. A failing
+// store lets the request through, an outage of the limiter must not stop the services.
+func (l *Limiter) Allow(ctx context.Context, client Client) (bool, time.Duration) {
+	limit := l.Default
+	if quota, ok := l.Quotas[client.Subject]; ok && client.Subject != "" {
+		limit = quota
+	}
+
+	allowed, retryAfter, err := l.Store.Take(ctx, client.key(), limit)
+	if err != nil {
+		log.WithError(err).Errorf("ratelimit: %s: store failed, request let through", client.key())
+		return true, 0
+	}
+	return allowed, retryAfter
+}
+
+// Client is who a request is counted for: the authenticated subject, or the peer address of
+// requests without an identity.
+type Client struct {
+	Subject string
+	Address string
+}
+
+func (c Client) key() string {
+	if c.Subject != "" {
+		return "ratelimit:subject:" + c.Subject
+	}
+	return "ratelimit:peer:" + c.Address
+}
+
+// LoadQuotas reads the limits of single clients, {"<subject>": {"rate": 50, "burst": 100}}.
+// A missing file leaves every client at the default limit.
+func LoadQuotas(path string) (map[string]Limit, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var quotas map[string]Limit
+	if err = json.Unmarshal(data, &quotas); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for subject, limit := range quotas {
+		if err = limit.validate(); err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", path, subject, err)
+		}
+	}
+	return quotas, nil
+}
+
+// retryAfterSeconds rounds up, a client that waits the returned seconds finds a token.
+func retryAfterSeconds(retryAfter time.Duration) int64 {
+	seconds := int64((retryAfter + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		return 1
+	}
+	return seconds
+}
@@ -0,0 +1,209 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"homework-1/internal/auth"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// fakeStore allows the keys with tokens left and records the limits it was asked for.
+type fakeStore struct {
+	tokens map[string]int
+	limits map[string]Limit
+	err    error
+}
+
+func newFakeStore(tokens map[string]int) *fakeStore {
+	return &fakeStore{tokens: tokens, limits: map[string]Limit{}}
+}
+
+func (s *fakeStore) Take(_ context.Context, key string, limit Limit) (bool, time.Duration, error) {
+	s.limits[key] = limit
+	if s.err != nil {
+		return false, 0, s.err
+	}
+	if s.tokens[key] > 0 {
+		s.tokens[key]--
+		return true, 0, nil
+	}
+	return false, 1500 * time.Millisecond, nil
+}
+
+func TestLimiter(t *testing.T) {
+	defaultLimit := Limit{Rate: 1, Burst: 1}
+	quota := Limit{Rate: 10, Burst: 20}
+
+	t.Run("quota of a subject", func(t *testing.T) {
+		// arrange
+		store := newFakeStore(map[string]int{"ratelimit:subject:ci": 1})
+		limiter := &Limiter{Store: store, Default: defaultLimit, Quotas: map[string]Limit{"ci": quota}}
+
+		// act
+		allowed, _ := limiter.Allow(context.Background(), Client{Subject: "ci", Address: "10.0.0.1"})
+
+		// assert
+		assert.True(t, allowed)
+		assert.Equal(t, quota, store.limits["ratelimit:subject:ci"])
+	})
+
+	t.Run("peer without identity", func(t *testing.T) {
+		// arrange
+		store := newFakeStore(map[string]int{})
+		limiter := &Limiter{Store: store, Default: defaultLimit, Quotas: map[string]Limit{"ci": quota}}
+
+		// act
+		allowed, retryAfter := limiter.Allow(context.Background(), Client{Address: "10.0.0.1"})
+
+		// assert
+		assert.False(t, allowed)
+		assert.Equal(t, 1500*time.Millisecond, retryAfter)
+		assert.Equal(t, defaultLimit, store.limits["ratelimit:peer:10.0.0.1"])
+	})
+
+	t.Run("failing store lets requests through", func(t *testing.T) {
+		// arrange
+		store := newFakeStore(map[string]int{})
+		store.err = errors.New("connection refused")
+		limiter := &Limiter{Store: store, Default: defaultLimit}
+
+		// act
+		allowed, _ := limiter.Allow(context.Background(), Client{Address: "10.0.0.1"})
+
+		// assert
+		assert.True(t, allowed)
+	})
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	// arrange
+	store := newFakeStore(map[string]int{"ratelimit:subject:alice": 1})
+	interceptor := UnaryServerInterceptor(&Limiter{Store: store, Default: Limit{Rate: 1, Burst: 1}})
+	ctx := auth.NewContext(context.Background(), &auth.Identity{Subject: "alice"})
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 51234}})
+	call := func(method string) error {
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		})
+		return err
+	}
+
+	// act
+	first := call("/api.v1.ApiService/ProductList")
+	second := call("/api.v1.ApiService/ProductList")
+	healthCheck := call("/grpc.health.v1.Health/Check")
+
+	// assert
+	assert.NoError(t, first)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(second))
+	assert.Contains(t, status.Convert(second).Message(), "retry after 2 s")
+	assert.NoError(t, healthCheck)
+}
+
+func TestMiddleware(t *testing.T) {
+	// arrange
+	store := newFakeStore(map[string]int{"ratelimit:peer:10.0.0.1": 1})
+	handler := Middleware(&Limiter{Store: store, Default: Limit{Rate: 1, Burst: 1}}, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), "/healthz")
+	serve := func(path string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		r.RemoteAddr = "10.0.0.1:51234"
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	// act
+	first := serve("/api/v1/users")
+	second := serve("/api/v1/users")
+	healthCheck := serve("/healthz")
+
+	// assert
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, http.StatusTooManyRequests, second.Code)
+	assert.Equal(t, "2", second.Header().Get("Retry-After"))
+	assert.Equal(t, http.StatusOK, healthCheck.Code)
+}
+
+func TestNewLimiter(t *testing.T) {
+	t.Run("default and quotas", func(t *testing.T) {
+		// arrange
+		path := filepath.Join(t.TempDir(), "rate_limits.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"ci": {"rate": 50, "burst": 100}}`), 0600))
+
+		// act
+		limiter, err := NewLimiter(nil, Limit{Rate: 20, Burst: 40}, path)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, Limit{Rate: 20, Burst: 40}, limiter.Default)
+		assert.Equal(t, map[string]Limit{"ci": {Rate: 50, Burst: 100}}, limiter.Quotas)
+	})
+
+	for _, tc := range []struct {
+		name  string
+		limit Limit
+	}{
+		{name: "zero rate", limit: Limit{Rate: 0, Burst: 40}},
+		{name: "negative rate", limit: Limit{Rate: -1, Burst: 40}},
+		{name: "zero burst", limit: Limit{Rate: 20, Burst: 0}},
+		{name: "negative burst", limit: Limit{Rate: 20, Burst: -1}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// act
+			_, err := NewLimiter(nil, tc.limit, filepath.Join(t.TempDir(), "missing.json"))
+
+			// assert
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLoadQuotas(t *testing.T) {
+	t.Run("quotas", func(t *testing.T) {
+		// arrange
+		path := filepath.Join(t.TempDir(), "rate_limits.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"ci": {"rate": 50, "burst": 100}}`), 0600))
+
+		// act
+		quotas, err := LoadQuotas(path)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, map[string]Limit{"ci": {Rate: 50, Burst: 100}}, quotas)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		// arrange
+		path := filepath.Join(t.TempDir(), "rate_limits.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"ci": {"rate": 0, "burst": 100}}`), 0600))
+
+		// act
+		_, err := LoadQuotas(path)
+
+		// assert
+		assert.Error(t, err)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		// act
+		quotas, err := LoadQuotas(filepath.Join(t.TempDir(), "missing.json"))
+
+		// assert
+		require.NoError(t, err)
+		assert.Empty(t, quotas)
+	})
+}
@@ -0,0 +1,65 @@
+package redis
+
+import (
+	"context"
+	"github.com/go-redis/redis/v9"
+	"github.com/pkg/errors"
+	"homework-1/internal/ratelimit"
+	"time"
+)
+
+// takeScript refills and takes from a bucket in one step, so the instances of a service
+// share the buckets without racing each other. A bucket expires once it would be full again.
+var takeScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local bucket = redis.call("HMGET", KEYS[1], "tokens", "updated")
+local tokens = tonumber(bucket[1])
+local updated = tonumber(bucket[2])
+if tokens == nil or updated == nil then
+	tokens = burst
+	updated = now
+end
+if now > updated then
+	tokens = math.min(burst, tokens + (now - updated) / 1000 * rate)
+	updated = now
+end
+
+local allowed = 0
+local retry_after = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry_after = math.ceil((1 - tokens) / rate * 1000)
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "updated", tostring(updated))
+redis.call("PEXPIRE", KEYS[1], math.ceil(burst / rate * 1000))
+return {allowed, retry_after}
+`)
+
+// Store keeps the buckets in redis, the instances of a service share the limit of a client.
+type Store struct {
+	client *redis.Client
+	now    func() time.Time
+}
+
+// New keeps the buckets with client, the store does not close it.
+func New(client *redis.Client) *Store {
+	return &Store{client: client, now: time.Now}
+}
+
+func (s *Store) Take(ctx context.Context, key string, limit ratelimit.Limit) (bool, time.Duration, error) {
+	now := s.now().UnixMilli()
+	result, err := takeScript.Run(ctx, s.client, []string{key}, limit.Rate, limit.Burst, now).Int64Slice()
+	if err != nil {
+		return false, 0, errors.Wrap(err, "failed to take a token from redis")
+	}
+	if len(result) != 2 {
+		return false, 0, errors.Errorf("unexpected redis reply %v", result)
+	}
+	return result[0] == 1, time.Duration(result[1]) * time.Millisecond, nil
+}
@@ -0,0 +1,114 @@
+package redis
+
+import (
+	"context"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"homework-1/internal/ratelimit"
+	"testing"
+	"time"
+)
+
+func TestStore(t *testing.T) {
+	limit := ratelimit.Limit{Rate: 2, Burst: 3}
+
+	newStore := func(t *testing.T) (*Store, *miniredis.Miniredis, *time.Time) {
+		server := miniredis.RunT(t)
+		client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+		t.Cleanup(func() { _ = client.Close() })
+
+		store := New(client)
+		now := time.Date(2022, 10, 5, 12, 0, 0, 0, time.UTC)
+		store.now = func() time.Time { return now }
+		return store, server, &now
+	}
+
+	t.Run("burst then rejected", func(t *testing.T) {
+		// arrange
+		store, _, _ := newStore(t)
+		for i := 0; i < limit.Burst; i++ {
+			allowed, _, err := store.Take(context.Background(), "alice", limit)
+			require.NoError(t, err)
+			require.True(t, allowed)
+		}
+
+		// act
+		allowed, retryAfter, err := store.Take(context.Background(), "alice", limit)
+
+		// assert
+		require.NoError(t, err)
+		assert.False(t, allowed)
+		assert.Equal(t, 500*time.Millisecond, retryAfter)
+	})
+
+	t.Run("refilled over time", func(t *testing.T) {
+		// arrange
+		store, _, now := newStore(t)
+		for i := 0; i < limit.Burst; i++ {
+			_, _, _ = store.Take(context.Background(), "alice", limit)
+		}
+		*now = now.Add(500 * time.Millisecond)
+
+		// act
+		allowed, _, err := store.Take(context.Background(), "alice", limit)
+
+		// assert
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	})
+
+	t.Run("clients have their own buckets", func(t *testing.T) {
+		// arrange
+		store, _, _ := newStore(t)
+		for i := 0; i < limit.Burst; i++ {
+			_, _, _ = store.Take(context.Background(), "alice", limit)
+		}
+
+		// act
+		allowed, _, err := store.Take(context.Background(), "bob", limit)
+
+		// assert
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	})
+
+	t.Run("bucket expires once full again", func(t *testing.T) {
+		// arrange
+		store, server, _ := newStore(t)
+
+		// act
+		_, _, err := store.Take(context.Background(), "alice", limit)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, 1500*time.Millisecond, server.TTL("alice"))
+		server.FastForward(1500 * time.Millisecond)
+		assert.False(t, server.Exists("alice"))
+	})
+
+	t.Run("key of another type", func(t *testing.T) {
+		// arrange
+		store, server, _ := newStore(t)
+		require.NoError(t, server.Set("alice", "not a bucket"))
+
+		// act
+		_, _, err := store.Take(context.Background(), "alice", limit)
+
+		// assert
+		assert.Error(t, err)
+	})
+
+	t.Run("redis down", func(t *testing.T) {
+		// arrange
+		store, server, _ := newStore(t)
+		server.Close()
+
+		// act
+		_, _, err := store.Take(context.Background(), "alice", limit)
+
+		// assert
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,37 @@
+// Package setup builds the rate limiter of the api services and the gateway from the config.
+package setup
+
+import (
+	"fmt"
+	"github.com/go-redis/redis/v9"
+	"homework-1/config"
+	"homework-1/internal/ratelimit"
+	"homework-1/internal/ratelimit/memory"
+	redisLimits "homework-1/internal/ratelimit/redis"
+)
+
+// FromConfig limits clients to config.RateLimitRate and config.RateLimitBurst, or to their
+// quota in config.RateLimitQuotasFile. The buckets are kept with client when
+// config.RateLimitStore is "redis".
+func FromConfig(client *redis.Client) (*ratelimit.Limiter, error) {
+	store, err := newStore(config.RateLimitStore, client)
+	if err != nil {
+		return nil, err
+	}
+	def := ratelimit.Limit{Rate: config.RateLimitRate, Burst: config.RateLimitBurst}
+	return ratelimit.NewLimiter(store, def, config.RateLimitQuotasFile)
+}
+
+func newStore(name string, client *redis.Client) (ratelimit.Store, error) {
+	switch name {
+	case "memory":
+		return memory.New(), nil
+	case "redis":
+		if client == nil {
+			return nil, fmt.Errorf("rate limit store %q needs a redis client", name)
+		}
+		return redisLimits.New(client), nil
+	default:
+		return nil, fmt.Errorf("unknown rate limit store %q, want \"memory\" or \"redis\"", name)
+	}
+}
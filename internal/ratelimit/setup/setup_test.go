@@ -0,0 +1,49 @@
+package setup
+
+import (
+	"github.com/go-redis/redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"homework-1/internal/ratelimit/memory"
+	redisLimits "homework-1/internal/ratelimit/redis"
+	"testing"
+)
+
+func TestNewStore(t *testing.T) {
+	client := redis.NewClient(&redis.Options{})
+	t.Cleanup(func() { _ = client.Close() })
+
+	t.Run("memory", func(t *testing.T) {
+		// act
+		store, err := newStore("memory", nil)
+
+		// assert
+		require.NoError(t, err)
+		assert.IsType(t, &memory.Store{}, store)
+	})
+
+	t.Run("redis", func(t *testing.T) {
+		// act
+		store, err := newStore("redis", client)
+
+		// assert
+		require.NoError(t, err)
+		assert.IsType(t, &redisLimits.Store{}, store)
+	})
+
+	t.Run("redis without a client", func(t *testing.T) {
+		// act
+		_, err := newStore("redis", nil)
+
+		// assert
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown store", func(t *testing.T) {
+		// act
+		_, err := newStore("redsi", client)
+
+		// assert
+		assert.Error(t, err)
+	})
+}
@@ -3,6 +3,18 @@ package repository
 import "github.com/pkg/errors"
 
 var (
-	ProductAlreadyExists = errors.New("product already exists")
-	ProductNotExists     = errors.New("product does not exist")
+	ProductAlreadyExists   = errors.New("product already exists")
+	ProductNotExists       = errors.New("product does not exist")
+	BarcodeAlreadyExists   = errors.New("barcode already belongs to another product")
+	PriceChangeNotExists   = errors.New("price change does not exist")
+	SupplierNotExists      = errors.New("supplier does not exist")
+	PurchaseOrderNotExists = errors.New("purchase order does not exist")
+	LotAlreadyExists       = errors.New("lot already exists")
+	StocktakeNotExists     = errors.New("stocktake session does not exist")
+	ImageNotExists         = errors.New("image does not exist")
+	RelationAlreadyExists  = errors.New("relation already exists")
+	RelationNotExists      = errors.New("relation does not exist")
+	ProductHasRelations    = errors.New("product has relations, delete them first")
+	ExchangeRateNotExists  = errors.New("exchange rate does not exist")
+	GrantNotExists         = errors.New("grant does not exist")
 )
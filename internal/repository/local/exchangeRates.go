@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"homework-1/internal/money"
+	"homework-1/internal/repository"
+	"sort"
+	"time"
+)
+
+func (r *Repository) SetExchangeRate(ctx context.Context, rate money.Rate) (*money.Rate, error) {
+	shop, err := r.warehouse.writeShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.Unlock()
+
+	rate.UpdatedAt = time.Now().UTC()
+	shop.exchangeRates[rate.Currency] = rate.Copy()
+	return rate.Copy(), nil
+}
+
+func (r *Repository) GetExchangeRate(ctx context.Context, currency money.Currency) (*money.Rate, error) {
+	shop, err := r.warehouse.readShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.RUnlock()
+
+	rate, ok := shop.exchangeRates[currency]
+	if !ok {
+		return nil, errors.Wrap(repository.ExchangeRateNotExists, currency.String())
+	}
+	return rate.Copy(), nil
+}
+
+func (r *Repository) GetExchangeRates(ctx context.Context) ([]*money.Rate, error) {
+	shop, err := r.warehouse.readShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.RUnlock()
+
+	rates := make([]*money.Rate, 0, len(shop.exchangeRates))
+	for _, rate := range shop.exchangeRates {
+		rates = append(rates, rate.Copy())
+	}
+	sort.Slice(rates, func(i, j int) bool {
+		return rates[i].Currency < rates[j].Currency
+	})
+	return rates, nil
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"homework-1/internal/money"
+	"testing"
+)
+
+func TestSetExchangeRate(t *testing.T) {
+	t.Run("replaces existing rate", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.shop().exchangeRates["USD"] = &money.Rate{Currency: "USD", Value: uint64(13000)}
+
+		// act
+		res, err := f.rateRepo.SetExchangeRate(f.ctx, money.Rate{Currency: "USD", Value: uint64(13725)})
+
+		// assert
+		require.NoError(t, err)
+		assert.False(t, res.UpdatedAt.IsZero())
+		assert.Equal(t, f.shop().exchangeRates["USD"].Value, uint64(13725))
+	})
+}
+
+func TestGetExchangeRate(t *testing.T) {
+	t.Run("rate does not exist", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		// act
+		_, err := f.rateRepo.GetExchangeRate(f.ctx, "USD")
+
+		// assert
+		assert.EqualError(t, err, "USD: exchange rate does not exist")
+	})
+
+	t.Run("rate of another tenant", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.warehouse.shopOf("north-store").exchangeRates["USD"] = &money.Rate{Currency: "USD", Value: uint64(13725)}
+
+		// act
+		_, err := f.rateRepo.GetExchangeRate(f.ctx, "USD")
+		rates, listErr := f.rateRepo.GetExchangeRates(f.ctx)
+
+		// assert
+		assert.EqualError(t, err, "USD: exchange rate does not exist")
+		require.NoError(t, listErr)
+		assert.Empty(t, rates)
+	})
+}
+
+func TestGetExchangeRates(t *testing.T) {
+	t.Run("ordered by currency", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.shop().exchangeRates["USD"] = &money.Rate{Currency: "USD", Value: uint64(13725)}
+		f.shop().exchangeRates["EUR"] = &money.Rate{Currency: "EUR", Value: uint64(14010)}
+
+		// act
+		res, err := f.rateRepo.GetExchangeRates(f.ctx)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, []*money.Rate{
+			{Currency: "EUR", Value: uint64(14010)},
+			{Currency: "USD", Value: uint64(13725)},
+		})
+	})
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"homework-1/internal/models/grants"
+	"homework-1/internal/repository"
+	"sort"
+	"strconv"
+	"time"
+)
+
+func (r *Repository) SetGrant(ctx context.Context, grant grants.Grant) (*grants.Grant, error) {
+	if err := r.warehouse.LockWithContext(ctx); err != nil {
+		return nil, err
+	}
+	defer r.warehouse.Unlock()
+
+	grant.GrantedAt = time.Now().UTC()
+	r.warehouse.grants[grant.UserId] = grant.Copy()
+	return grant.Copy(), nil
+}
+
+func (r *Repository) GetGrant(ctx context.Context, userId int64) (*grants.Grant, error) {
+	if err := r.warehouse.RLockWithContext(ctx); err != nil {
+		return nil, err
+	}
+	defer r.warehouse.RUnlock()
+
+	grant, ok := r.warehouse.grants[userId]
+	if !ok {
+		return nil, errors.Wrap(repository.GrantNotExists, strconv.FormatInt(userId, 10))
+	}
+	return grant.Copy(), nil
+}
+
+func (r *Repository) DeleteGrant(ctx context.Context, userId int64) error {
+	if err := r.warehouse.LockWithContext(ctx); err != nil {
+		return err
+	}
+	defer r.warehouse.Unlock()
+
+	if _, ok := r.warehouse.grants[userId]; !ok {
+		return errors.Wrap(repository.GrantNotExists, strconv.FormatInt(userId, 10))
+	}
+	delete(r.warehouse.grants, userId)
+	return nil
+}
+
+func (r *Repository) GetGrants(ctx context.Context) ([]*grants.Grant, error) {
+	if err := r.warehouse.RLockWithContext(ctx); err != nil {
+		return nil, err
+	}
+	defer r.warehouse.RUnlock()
+
+	result := make([]*grants.Grant, 0, len(r.warehouse.grants))
+	for _, grant := range r.warehouse.grants {
+		result = append(result, grant.Copy())
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].UserId < result[j].UserId
+	})
+	return result, nil
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"homework-1/internal/models/grants"
+	"homework-1/internal/policy"
+	"testing"
+)
+
+func TestSetGrant(t *testing.T) {
+	t.Run("replaces existing grant", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.warehouse.grants[42] = &grants.Grant{UserId: 42, Role: policy.Viewer, GrantedBy: "telegram:1"}
+
+		// act
+		res, err := f.grantRepo.SetGrant(f.ctx, grants.Grant{UserId: 42, Role: policy.Editor, GrantedBy: "telegram:2"})
+
+		// assert
+		require.NoError(t, err)
+		assert.False(t, res.GrantedAt.IsZero())
+		assert.Equal(t, policy.Editor, f.warehouse.grants[42].Role)
+		assert.Equal(t, "telegram:2", f.warehouse.grants[42].GrantedBy)
+	})
+}
+
+func TestGetGrant(t *testing.T) {
+	t.Run("grant does not exist", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		// act
+		_, err := f.grantRepo.GetGrant(f.ctx, 42)
+
+		// assert
+		assert.EqualError(t, err, "42: grant does not exist")
+	})
+}
+
+func TestDeleteGrant(t *testing.T) {
+	t.Run("success deleting grant", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.warehouse.grants[42] = &grants.Grant{UserId: 42, Role: policy.Viewer}
+
+		// act
+		err := f.grantRepo.DeleteGrant(f.ctx, 42)
+
+		// assert
+		require.NoError(t, err)
+		assert.NotContains(t, f.warehouse.grants, int64(42))
+	})
+
+	t.Run("grant does not exist", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		// act
+		err := f.grantRepo.DeleteGrant(f.ctx, 42)
+
+		// assert
+		assert.EqualError(t, err, "42: grant does not exist")
+	})
+}
+
+func TestGetGrants(t *testing.T) {
+	t.Run("ordered by user id", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.warehouse.grants[42] = &grants.Grant{UserId: 42, Role: policy.Viewer}
+		f.warehouse.grants[7] = &grants.Grant{UserId: 7, Role: policy.Admin}
+
+		// act
+		res, err := f.grantRepo.GetGrants(f.ctx)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, []*grants.Grant{
+			{UserId: 7, Role: policy.Admin},
+			{UserId: 42, Role: policy.Viewer},
+		})
+	})
+}
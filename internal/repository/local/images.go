@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"homework-1/internal/models/images"
+	"homework-1/internal/repository"
+	"sort"
+	"strconv"
+	"time"
+)
+
+var ErrImageIdAlreadySet = errors.New("Image id already set")
+
+func (r *Repository) AddImage(ctx context.Context, image images.Image) (*images.Image, error) {
+	if image.Id > 0 {
+		return nil, errors.Wrap(ErrImageIdAlreadySet, "Can't add new image")
+	}
+
+	shop, err := r.warehouse.writeShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.Unlock()
+
+	if _, ok := shop.storage[image.ProductId]; !ok {
+		return nil, errors.Wrap(repository.ProductNotExists, strconv.FormatUint(image.ProductId, 10))
+	}
+
+	image.Id = r.warehouse.GetNextImageId()
+	image.CreatedAt = time.Now().UTC()
+	shop.images[image.Id] = &image
+	return image.Copy(), nil
+}
+
+func (r *Repository) GetImageById(ctx context.Context, id uint64) (*images.Image, error) {
+	shop, err := r.warehouse.readShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.RUnlock()
+
+	image, ok := shop.images[id]
+	if !ok {
+		return nil, errors.Wrap(repository.ImageNotExists, strconv.FormatUint(id, 10))
+	}
+	return image.Copy(), nil
+}
+
+func (r *Repository) GetProductImages(ctx context.Context, productId uint64) ([]*images.Image, error) {
+	shop, err := r.warehouse.readShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.RUnlock()
+
+	productImages := make([]*images.Image, 0)
+	for _, image := range shop.images {
+		if image.ProductId == productId {
+			productImages = append(productImages, image.Copy())
+		}
+	}
+	sort.Slice(productImages, func(i, j int) bool {
+		return productImages[i].Id < productImages[j].Id
+	})
+	return productImages, nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"homework-1/internal/models/images"
+	"homework-1/internal/models/products"
+	"testing"
+)
+
+func TestAddImage(t *testing.T) {
+	t.Run("success adding image", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.addProduct(&products.Product{Id: uint64(1), Name: "pillow"})
+
+		// act
+		res, err := f.imageRepo.AddImage(f.ctx, images.Image{
+			ProductId:   uint64(1),
+			Key:         "products/1/a",
+			ContentType: "image/png",
+			Size:        uint64(3),
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res.Id, uint64(1))
+		assert.Equal(t, f.shop().images[uint64(1)].Key, "products/1/a")
+	})
+
+	t.Run("product does not exist", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		// act
+		_, err := f.imageRepo.AddImage(f.ctx, images.Image{ProductId: uint64(1)})
+
+		// assert
+		assert.EqualError(t, err, "1: product does not exist")
+	})
+}
+
+func TestGetProductImages(t *testing.T) {
+	t.Run("images in upload order", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.shop().images[uint64(2)] = &images.Image{Id: uint64(2), ProductId: uint64(1), Key: "products/1/b"}
+		f.shop().images[uint64(1)] = &images.Image{Id: uint64(1), ProductId: uint64(1), Key: "products/1/a"}
+		f.shop().images[uint64(3)] = &images.Image{Id: uint64(3), ProductId: uint64(2), Key: "products/2/c"}
+
+		// act
+		res, err := f.imageRepo.GetProductImages(f.ctx, uint64(1))
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, []*images.Image{
+			{Id: uint64(1), ProductId: uint64(1), Key: "products/1/a"},
+			{Id: uint64(2), ProductId: uint64(1), Key: "products/1/b"},
+		})
+	})
+
+	t.Run("deleting product deletes its images", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.addProduct(&products.Product{Id: uint64(1), Name: "pillow"})
+		f.shop().images[uint64(1)] = &images.Image{Id: uint64(1), ProductId: uint64(1), Key: "products/1/a"}
+
+		// act
+		err := f.productRepo.DeleteProduct(f.ctx, uint64(1))
+
+		// assert
+		require.NoError(t, err)
+		assert.Empty(t, f.shop().images)
+	})
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"homework-1/internal/math"
+	"homework-1/internal/models/lots"
+	"homework-1/internal/repository"
+	"strconv"
+	"time"
+)
+
+var ErrLotIdAlreadySet = errors.New("Lot id already set")
+
+func (r *Repository) AddLot(ctx context.Context, lot lots.Lot) (*lots.Lot, error) {
+	if lot.Id > 0 {
+		return nil, errors.Wrap(ErrLotIdAlreadySet, "Can't create new lot")
+	}
+
+	shop, err := r.warehouse.writeShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.Unlock()
+
+	product, ok := shop.storage[lot.ProductId]
+	if !ok {
+		return nil, errors.Wrap(repository.ProductNotExists, strconv.FormatUint(lot.ProductId, 10))
+	}
+	for _, stored := range shop.lots {
+		if stored.ProductId == lot.ProductId && stored.Number == lot.Number {
+			return nil, errors.Wrap(repository.LotAlreadyExists, lot.Number)
+		}
+	}
+
+	updated := product.Copy()
+	updated.Quantity += lot.Quantity
+
+	lot.Id = r.warehouse.GetNextLotId()
+	shop.lots[lot.Id] = &lot
+	shop.storage[updated.GetId()] = updated
+	return lot.Copy(), nil
+}
+
+func (r *Repository) GetProductLots(ctx context.Context, productId uint64) ([]*lots.Lot, error) {
+	shop, err := r.warehouse.readShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.RUnlock()
+
+	return shop.productLots(productId), nil
+}
+
+func (r *Repository) GetExpiringLots(ctx context.Context, before time.Time, page uint64, size uint64) ([]*lots.Lot, error) {
+	limit, offset := r.getPaginationLimitAndOffset(page, size)
+
+	shop, err := r.warehouse.readShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.RUnlock()
+
+	expiring := make([]*lots.Lot, 0)
+	for _, lot := range shop.lots {
+		if lot.Quantity > 0 && lot.ExpiresAt.Before(before) {
+			expiring = append(expiring, lot.Copy())
+		}
+	}
+	lots.SortFEFO(expiring)
+
+	expiringLen := uint64(len(expiring))
+	start := math.MinUint64(expiringLen, offset)
+	end := math.MinUint64(expiringLen, offset+limit)
+	return expiring[start:end], nil
+}
+
+// productLots returns copies of the product lots that are not used up, the caller holds the lock.
+func (s *shop) productLots(productId uint64) []*lots.Lot {
+	productLots := make([]*lots.Lot, 0)
+	for _, lot := range s.lots {
+		if lot.ProductId == productId && lot.Quantity > 0 {
+			productLots = append(productLots, lot.Copy())
+		}
+	}
+	lots.SortFEFO(productLots)
+	return productLots
+}
+
+// pickLots takes quantity out of the product lots first-expired-first-out, the caller holds the lock.
+func (s *shop) pickLots(productId uint64, quantity uint64) {
+	for _, lot := range lots.Pick(s.productLots(productId), quantity) {
+		s.lots[lot.Id] = lot
+	}
+}
+
+// reserveLots takes quantity out of the product lots that have not expired, the caller holds the lock.
+func (s *shop) reserveLots(productId uint64, productQuantity uint64, quantity uint64) ([]lots.Picked, error) {
+	productLots := s.productLots(productId)
+	picked, err := lots.Reserve(productLots, quantity, lots.Untracked(productQuantity, productLots), time.Now())
+	if err != nil {
+		return nil, errors.Wrap(err, strconv.FormatUint(productId, 10))
+	}
+
+	for _, lot := range productLots {
+		s.lots[lot.Id] = lot
+	}
+	return picked, nil
+}
+
+// restoreLots puts picked quantities back into the product lots, the caller holds the lock.
+// Lots removed since the reservation are skipped, their quantity stays untracked.
+func (s *shop) restoreLots(productId uint64, picked []lots.Picked) {
+	for _, pick := range picked {
+		lot, ok := s.lots[pick.LotId]
+		if !ok || lot.ProductId != productId {
+			continue
+		}
+		restored := lot.Copy()
+		restored.Quantity += pick.Quantity
+		s.lots[restored.Id] = restored
+	}
+}
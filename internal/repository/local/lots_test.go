@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"homework-1/internal/models/lots"
+	"homework-1/internal/models/products"
+	"testing"
+	"time"
+)
+
+func TestAddLot(t *testing.T) {
+	t.Run("success adding lot", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.addProduct(&products.Product{Id: uint64(1), Name: "product1", Price: uint64(1), Quantity: uint64(2)})
+
+		// act
+		res, err := f.lotRepo.AddLot(f.ctx, lots.Lot{
+			ProductId: uint64(1),
+			Number:    "A",
+			Quantity:  uint64(5),
+			ExpiresAt: time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC),
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res.Id, uint64(1))
+		assert.Equal(t, f.shop().storage[uint64(1)].Quantity, uint64(7))
+	})
+
+	t.Run("lot already exists", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.addProduct(&products.Product{Id: uint64(1), Name: "product1", Price: uint64(1), Quantity: uint64(5)})
+		f.shop().lots[uint64(1)] = &lots.Lot{Id: uint64(1), ProductId: uint64(1), Number: "A", Quantity: uint64(5)}
+
+		// act
+		_, err := f.lotRepo.AddLot(f.ctx, lots.Lot{ProductId: uint64(1), Number: "A", Quantity: uint64(1)})
+
+		// assert
+		assert.EqualError(t, err, "A: lot already exists")
+		assert.Equal(t, f.shop().storage[uint64(1)].Quantity, uint64(5))
+	})
+
+	t.Run("product does not exists", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		// act
+		_, err := f.lotRepo.AddLot(f.ctx, lots.Lot{ProductId: uint64(1), Number: "A", Quantity: uint64(1)})
+
+		// assert
+		assert.EqualError(t, err, "1: product does not exist")
+	})
+}
+
+func TestLotsArePickedFirstExpiredFirstOut(t *testing.T) {
+	setUpLots := func(t *testing.T) *productRepoFixture {
+		f := SetUp(t)
+		f.addProduct(&products.Product{
+			Id:       uint64(1),
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(10),
+			Status:   products.StatusActive,
+		})
+		f.shop().lots[uint64(1)] = &lots.Lot{Id: uint64(1), ProductId: uint64(1), Number: "late", Quantity: uint64(4),
+			ExpiresAt: time.Date(2122, 11, 1, 0, 0, 0, 0, time.UTC)}
+		f.shop().lots[uint64(2)] = &lots.Lot{Id: uint64(2), ProductId: uint64(1), Number: "early", Quantity: uint64(4),
+			ExpiresAt: time.Date(2122, 10, 1, 0, 0, 0, 0, time.UTC)}
+		return f
+	}
+	addExpiredLot := func(f *productRepoFixture) {
+		f.shop().lots[uint64(3)] = &lots.Lot{Id: uint64(3), ProductId: uint64(1), Number: "expired", Quantity: uint64(2),
+			ExpiresAt: time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC)}
+	}
+
+	t.Run("reserving consumes the earliest lot first", func(t *testing.T) {
+		// arrange
+		f := setUpLots(t)
+
+		// act
+		_, _, err := f.productRepo.ReserveProduct(f.ctx, 1, 5)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, f.shop().lots[uint64(2)].Quantity, uint64(0))
+		assert.Equal(t, f.shop().lots[uint64(1)].Quantity, uint64(3))
+		assert.Equal(t, f.shop().storage[uint64(1)].Quantity, uint64(5))
+	})
+
+	t.Run("reserving skips expired lots", func(t *testing.T) {
+		// arrange
+		f := setUpLots(t)
+		addExpiredLot(f)
+
+		// act
+		_, picked, err := f.productRepo.ReserveProduct(f.ctx, 1, 8)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, picked, []lots.Picked{{LotId: uint64(2), Quantity: uint64(4)}, {LotId: uint64(1), Quantity: uint64(4)}})
+		assert.Equal(t, f.shop().lots[uint64(3)].Quantity, uint64(2))
+		assert.Equal(t, f.shop().storage[uint64(1)].Quantity, uint64(2))
+	})
+
+	t.Run("reserving fails when only expired stock is left", func(t *testing.T) {
+		// arrange
+		f := setUpLots(t)
+		addExpiredLot(f)
+
+		// act
+		_, _, err := f.productRepo.ReserveProduct(f.ctx, 1, 9)
+
+		// assert
+		assert.ErrorIs(t, err, lots.ErrExpiredStock)
+		assert.Equal(t, f.shop().lots[uint64(2)].Quantity, uint64(4))
+		assert.Equal(t, f.shop().lots[uint64(1)].Quantity, uint64(4))
+		assert.Equal(t, f.shop().storage[uint64(1)].Quantity, uint64(10))
+	})
+
+	t.Run("releasing puts the picked quantity back into the lots", func(t *testing.T) {
+		// arrange
+		f := setUpLots(t)
+		_, picked, err := f.productRepo.ReserveProduct(f.ctx, 1, 5)
+		require.NoError(t, err)
+
+		// act
+		_, err = f.productRepo.ReleaseProduct(f.ctx, 1, 5, picked)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, f.shop().lots[uint64(2)].Quantity, uint64(4))
+		assert.Equal(t, f.shop().lots[uint64(1)].Quantity, uint64(4))
+		assert.Equal(t, f.shop().storage[uint64(1)].Quantity, uint64(10))
+	})
+
+	t.Run("lowering quantity by update consumes lots", func(t *testing.T) {
+		// arrange
+		f := setUpLots(t)
+
+		// act
+		_, err := f.productRepo.UpdateProduct(f.ctx, products.Product{
+			Id:       uint64(1),
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(7),
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, f.shop().lots[uint64(2)].Quantity, uint64(1))
+		assert.Equal(t, f.shop().lots[uint64(1)].Quantity, uint64(4))
+	})
+
+	t.Run("used up lots are not listed", func(t *testing.T) {
+		// arrange
+		f := setUpLots(t)
+		f.shop().lots[uint64(2)].Quantity = 0
+
+		// act
+		res, err := f.lotRepo.GetProductLots(f.ctx, 1)
+
+		// assert
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.Equal(t, res[0].Number, "late")
+	})
+}
+
+func TestGetExpiringLots(t *testing.T) {
+	t.Run("success getting expiring lots", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.shop().lots[uint64(1)] = &lots.Lot{Id: uint64(1), ProductId: uint64(1), Number: "A", Quantity: uint64(1),
+			ExpiresAt: time.Date(2022, 10, 5, 0, 0, 0, 0, time.UTC)}
+		f.shop().lots[uint64(2)] = &lots.Lot{Id: uint64(2), ProductId: uint64(2), Number: "B", Quantity: uint64(1),
+			ExpiresAt: time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)}
+		f.shop().lots[uint64(3)] = &lots.Lot{Id: uint64(3), ProductId: uint64(2), Number: "C", Quantity: uint64(1),
+			ExpiresAt: time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC)}
+		f.shop().lots[uint64(4)] = &lots.Lot{Id: uint64(4), ProductId: uint64(2), Number: "D", Quantity: uint64(0),
+			ExpiresAt: time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC)}
+
+		// act
+		res, err := f.lotRepo.GetExpiringLots(f.ctx, time.Date(2022, 10, 15, 0, 0, 0, 0, time.UTC), 0, 0)
+
+		// assert
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+		assert.Equal(t, res[0].Number, "B")
+		assert.Equal(t, res[1].Number, "A")
+	})
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"homework-1/internal/math"
+	"homework-1/internal/models/changes"
+	"homework-1/internal/models/products"
+	"homework-1/internal/repository"
+	"sort"
+	"strconv"
+)
+
+var ErrPriceChangeIdAlreadySet = errors.New("Price change id already set")
+
+func (r *Repository) CreatePriceChange(ctx context.Context, change changes.PriceChange) (*changes.PriceChange, error) {
+	if change.Id > 0 {
+		return nil, errors.Wrap(ErrPriceChangeIdAlreadySet, "Can't create new price change")
+	}
+
+	shop, err := r.warehouse.writeShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.Unlock()
+
+	if _, ok := shop.storage[change.ProductId]; !ok {
+		return nil, errors.Wrap(repository.ProductNotExists, strconv.FormatUint(change.ProductId, 10))
+	}
+
+	change.Id = r.warehouse.GetNextPriceChangeId()
+	shop.priceChanges[change.Id] = &change
+	return change.Copy(), nil
+}
+
+func (r *Repository) GetPriceChangeById(ctx context.Context, id uint64) (*changes.PriceChange, error) {
+	shop, err := r.warehouse.readShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.RUnlock()
+
+	if change, ok := shop.priceChanges[id]; ok {
+		return change.Copy(), nil
+	}
+	return nil, errors.Wrap(repository.PriceChangeNotExists, strconv.FormatUint(id, 10))
+}
+
+func (r *Repository) GetPendingPriceChanges(ctx context.Context, page uint64, size uint64) ([]*changes.PriceChange, error) {
+	limit, offset := r.getPaginationLimitAndOffset(page, size)
+
+	shop, err := r.warehouse.readShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.RUnlock()
+
+	pending := make([]*changes.PriceChange, 0, len(shop.priceChanges))
+	for _, change := range shop.priceChanges {
+		if change.IsPending() {
+			pending = append(pending, change.Copy())
+		}
+	}
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].Id < pending[j].Id
+	})
+
+	pendingLen := uint64(len(pending))
+	start := math.MinUint64(pendingLen, offset)
+	end := math.MinUint64(pendingLen, offset+limit)
+	return pending[start:end], nil
+}
+
+func (r *Repository) ApprovePriceChange(ctx context.Context, id uint64, approver string) (*changes.PriceChange, *products.Product, error) {
+	shop, err := r.warehouse.writeShop(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer r.warehouse.Unlock()
+
+	stored, ok := shop.priceChanges[id]
+	if !ok {
+		return nil, nil, errors.Wrap(repository.PriceChangeNotExists, strconv.FormatUint(id, 10))
+	}
+
+	product, ok := shop.storage[stored.ProductId]
+	if !ok {
+		return nil, nil, errors.Wrap(repository.ProductNotExists, strconv.FormatUint(stored.ProductId, 10))
+	}
+
+	change := stored.Copy()
+	if err := change.Approve(approver); err != nil {
+		return nil, nil, err
+	}
+
+	updated := product.Copy()
+	change.Apply(updated)
+
+	shop.priceChanges[id] = change
+	shop.storage[updated.GetId()] = updated
+	return change.Copy(), updated.Copy(), nil
+}
+
+func (r *Repository) RejectPriceChange(ctx context.Context, id uint64, approver string) (*changes.PriceChange, error) {
+	shop, err := r.warehouse.writeShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.Unlock()
+
+	stored, ok := shop.priceChanges[id]
+	if !ok {
+		return nil, errors.Wrap(repository.PriceChangeNotExists, strconv.FormatUint(id, 10))
+	}
+
+	change := stored.Copy()
+	if err := change.Reject(approver); err != nil {
+		return nil, err
+	}
+
+	shop.priceChanges[id] = change
+	return change.Copy(), nil
+}
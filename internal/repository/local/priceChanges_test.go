@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"homework-1/internal/models/changes"
+	"homework-1/internal/models/products"
+	"testing"
+)
+
+func TestCreatePriceChange(t *testing.T) {
+	t.Run("success creating price change", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.addProduct(&products.Product{
+			Id:       uint64(1),
+			Name:     "product1",
+			Price:    uint64(100),
+			Quantity: uint64(1),
+		})
+
+		// act
+		res, err := f.priceChangeRepo.CreatePriceChange(f.ctx, changes.PriceChange{
+			ProductId:   uint64(1),
+			Name:        "product1",
+			OldPrice:    uint64(100),
+			Price:       uint64(1),
+			Quantity:    uint64(1),
+			Status:      changes.StatusPending,
+			RequestedBy: "alice",
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res.Id, uint64(1))
+		assert.Equal(t, f.shop().priceChanges[uint64(1)].Status, changes.StatusPending)
+		assert.Equal(t, f.shop().storage[uint64(1)].Price, uint64(100))
+	})
+
+	t.Run("product does not exists", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		// act
+		_, err := f.priceChangeRepo.CreatePriceChange(f.ctx, changes.PriceChange{ProductId: uint64(1)})
+
+		// assert
+		assert.EqualError(t, err, "1: product does not exist")
+		assert.Empty(t, f.shop().priceChanges)
+	})
+}
+
+func TestGetPendingPriceChanges(t *testing.T) {
+	t.Run("success getting pending price changes", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.shop().priceChanges[uint64(1)] = &changes.PriceChange{Id: uint64(1), Status: changes.StatusPending}
+		f.shop().priceChanges[uint64(2)] = &changes.PriceChange{Id: uint64(2), Status: changes.StatusApproved}
+		f.shop().priceChanges[uint64(3)] = &changes.PriceChange{Id: uint64(3), Status: changes.StatusPending}
+
+		// act
+		res, err := f.priceChangeRepo.GetPendingPriceChanges(f.ctx, 0, 0)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, []*changes.PriceChange{
+			{Id: uint64(1), Status: changes.StatusPending},
+			{Id: uint64(3), Status: changes.StatusPending},
+		})
+	})
+}
+
+func TestApprovePriceChange(t *testing.T) {
+	t.Run("success approving price change", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.addProduct(&products.Product{
+			Id:       uint64(1),
+			Name:     "product1",
+			Price:    uint64(100),
+			Quantity: uint64(1),
+			Status:   products.StatusActive,
+		})
+		f.shop().priceChanges[uint64(1)] = &changes.PriceChange{
+			Id:          uint64(1),
+			ProductId:   uint64(1),
+			Name:        "product2",
+			OldPrice:    uint64(100),
+			Price:       uint64(1),
+			Quantity:    uint64(2),
+			Status:      changes.StatusPending,
+			RequestedBy: "alice",
+		}
+
+		// act
+		change, product, err := f.priceChangeRepo.ApprovePriceChange(f.ctx, 1, "bob")
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, change.Status, changes.StatusApproved)
+		assert.Equal(t, change.ResolvedBy, "bob")
+		assert.NotNil(t, change.ResolvedAt)
+		assert.Equal(t, product, &products.Product{
+			Id:       uint64(1),
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(1),
+			Status:   products.StatusActive,
+		})
+		assert.Equal(t, f.shop().storage[uint64(1)].Price, uint64(1))
+		assert.Equal(t, f.shop().priceChanges[uint64(1)].Status, changes.StatusApproved)
+	})
+
+	t.Run("requester can't approve own change", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.addProduct(&products.Product{Id: uint64(1), Name: "product1", Price: uint64(100)})
+		f.shop().priceChanges[uint64(1)] = &changes.PriceChange{
+			Id:          uint64(1),
+			ProductId:   uint64(1),
+			Price:       uint64(1),
+			Status:      changes.StatusPending,
+			RequestedBy: "alice",
+		}
+
+		// act
+		_, _, err := f.priceChangeRepo.ApprovePriceChange(f.ctx, 1, "alice")
+
+		// assert
+		assert.ErrorIs(t, err, changes.ErrSelfApproval)
+		assert.Equal(t, f.shop().storage[uint64(1)].Price, uint64(100))
+		assert.Equal(t, f.shop().priceChanges[uint64(1)].Status, changes.StatusPending)
+	})
+
+	t.Run("change already resolved", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.addProduct(&products.Product{Id: uint64(1), Name: "product1", Price: uint64(100)})
+		f.shop().priceChanges[uint64(1)] = &changes.PriceChange{
+			Id:          uint64(1),
+			ProductId:   uint64(1),
+			Price:       uint64(1),
+			Status:      changes.StatusRejected,
+			RequestedBy: "alice",
+		}
+
+		// act
+		_, _, err := f.priceChangeRepo.ApprovePriceChange(f.ctx, 1, "bob")
+
+		// assert
+		assert.EqualError(t, err, "1: change is not pending")
+		assert.Equal(t, f.shop().storage[uint64(1)].Price, uint64(100))
+	})
+
+	t.Run("price change does not exists", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		// act
+		_, _, err := f.priceChangeRepo.ApprovePriceChange(f.ctx, 1, "bob")
+
+		// assert
+		assert.EqualError(t, err, "1: price change does not exist")
+	})
+}
+
+func TestRejectPriceChange(t *testing.T) {
+	t.Run("success rejecting price change", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.addProduct(&products.Product{Id: uint64(1), Name: "product1", Price: uint64(100)})
+		f.shop().priceChanges[uint64(1)] = &changes.PriceChange{
+			Id:          uint64(1),
+			ProductId:   uint64(1),
+			Price:       uint64(1),
+			Status:      changes.StatusPending,
+			RequestedBy: "alice",
+		}
+
+		// act
+		change, err := f.priceChangeRepo.RejectPriceChange(f.ctx, 1, "bob")
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, change.Status, changes.StatusRejected)
+		assert.Equal(t, f.shop().storage[uint64(1)].Price, uint64(100))
+		assert.Equal(t, f.shop().priceChanges[uint64(1)].ResolvedBy, "bob")
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/pkg/errors"
 	"homework-1/internal/math"
+	"homework-1/internal/models/lots"
 	"homework-1/internal/models/products"
 	"homework-1/internal/repository"
 	"sort"
@@ -15,82 +16,240 @@ var ErrProductIdAlreadySet = errors.New("Product id already set")
 var defaultProductsPageSize = uint64(20)
 
 func (r *Repository) GetProductById(ctx context.Context, id uint64) (*products.Product, error) {
-	if err := r.warehouse.RLockWithContext(ctx); err != nil {
+	shop, err := r.warehouse.readShop(ctx)
+	if err != nil {
 		return nil, err
 	}
 	defer r.warehouse.RUnlock()
 
-	if product, ok := r.warehouse.storage[id]; ok {
+	if product, ok := shop.storage[id]; ok {
 		return product.Copy(), nil
 	}
 	return nil, errors.Wrap(repository.ProductNotExists, strconv.FormatUint(id, 10))
 }
 
+func (r *Repository) GetProductByBarcode(ctx context.Context, barcode string) (*products.Product, error) {
+	shop, err := r.warehouse.readShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.RUnlock()
+
+	if product := shop.findProductByBarcode(barcode); product != nil {
+		return product.Copy(), nil
+	}
+	return nil, errors.Wrap(repository.ProductNotExists, barcode)
+}
+
 func (r *Repository) CreateProduct(ctx context.Context, product products.Product) (*products.Product, error) {
 	if product.Id > 0 {
 		return nil, errors.Wrap(ErrProductIdAlreadySet, "Can't create new products")
 	}
 
 	product.Id = r.warehouse.GetNextId()
+	if product.Status == "" {
+		product.Status = products.StatusActive
+	}
+	product.Unit = product.GetUnit()
 
-	if err := r.warehouse.LockWithContext(ctx); err != nil {
+	shop, err := r.warehouse.writeShop(ctx)
+	if err != nil {
 		return nil, err
 	}
 	defer r.warehouse.Unlock()
 
-	if _, ok := r.warehouse.storage[product.GetId()]; ok {
+	if _, ok := shop.storage[product.GetId()]; ok {
 		return nil, errors.Wrap(repository.ProductAlreadyExists, strconv.FormatUint(product.GetId(), 10))
 	}
-	r.warehouse.storage[product.GetId()] = &product
+	if err = shop.checkBarcodeFree(product.GetId(), product.GetBarcode()); err != nil {
+		return nil, err
+	}
+	shop.storage[product.GetId()] = &product
 	return product.Copy(), nil
 }
 
 func (r *Repository) DeleteProduct(ctx context.Context, id uint64) error {
-	if err := r.warehouse.LockWithContext(ctx); err != nil {
+	shop, err := r.warehouse.writeShop(ctx)
+	if err != nil {
 		return err
 	}
 	defer r.warehouse.Unlock()
 
-	if _, ok := r.warehouse.storage[id]; !ok {
+	if _, ok := shop.storage[id]; !ok {
 		return errors.Wrap(repository.ProductNotExists, strconv.FormatUint(id, 10))
 	}
-	delete(r.warehouse.storage, id)
+	delete(shop.storage, id)
+	delete(shop.translations, id)
+	for lotId, lot := range shop.lots {
+		if lot.ProductId == id {
+			delete(shop.lots, lotId)
+		}
+	}
+	for imageId, image := range shop.images {
+		if image.ProductId == id {
+			delete(shop.images, imageId)
+		}
+	}
+	delete(shop.relations, id)
+	for _, related := range shop.relations {
+		delete(related, id)
+	}
 	return nil
 }
 
 func (r *Repository) UpdateProduct(ctx context.Context, product products.Product) (*products.Product, error) {
-	if err := r.warehouse.LockWithContext(ctx); err != nil {
+	shop, err := r.warehouse.writeShop(ctx)
+	if err != nil {
 		return nil, err
 	}
-	defer r.warehouse.mu.Unlock()
+	defer r.warehouse.Unlock()
 
-	if _, ok := r.warehouse.storage[product.GetId()]; !ok {
+	stored, ok := shop.storage[product.GetId()]
+	if !ok {
 		return nil, errors.Wrap(repository.ProductNotExists, strconv.FormatUint(product.GetId(), 10))
 	}
-	r.warehouse.storage[product.GetId()] = &product
+	if err = shop.checkBarcodeFree(product.GetId(), product.GetBarcode()); err != nil {
+		return nil, err
+	}
+	product.Status = stored.Status // status is changed only by TransitionProductStatus
+	if stored.Quantity > product.Quantity {
+		shop.pickLots(product.GetId(), stored.Quantity-product.Quantity)
+	}
+	shop.storage[product.GetId()] = &product
 	return product.Copy(), nil
 }
 
 func (r *Repository) GetAllProducts(ctx context.Context, page uint64, size uint64) ([]*products.Product, error) {
+	return r.getFilteredProducts(ctx, page, size, func(_ *products.Product) bool {
+		return true
+	})
+}
+
+func (r *Repository) GetProductsByStatus(ctx context.Context, status products.Status, page uint64, size uint64) ([]*products.Product, error) {
+	return r.getFilteredProducts(ctx, page, size, func(p *products.Product) bool {
+		return p.GetStatus() == status
+	})
+}
+
+func (r *Repository) FindProducts(ctx context.Context, filter products.Filter, page uint64, size uint64) ([]*products.Product, error) {
+	return r.getFilteredProducts(ctx, page, size, filter.Match)
+}
+
+func (r *Repository) TransitionProductStatus(ctx context.Context, id uint64, to products.Status, reason string, actor string) (*products.Product, error) {
+	shop, err := r.warehouse.writeShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.Unlock()
+
+	product, ok := shop.storage[id]
+	if !ok {
+		return nil, errors.Wrap(repository.ProductNotExists, strconv.FormatUint(id, 10))
+	}
+
+	updated := product.Copy()
+	transition, err := updated.TransitionTo(to, reason, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	shop.storage[id] = updated
+	shop.transitions[id] = append(shop.transitions[id], transition)
+	return updated.Copy(), nil
+}
+
+func (r *Repository) ReserveProduct(ctx context.Context, id uint64, quantity uint64) (*products.Product, []lots.Picked, error) {
+	shop, err := r.warehouse.writeShop(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer r.warehouse.Unlock()
+
+	product, ok := shop.storage[id]
+	if !ok {
+		return nil, nil, errors.Wrap(repository.ProductNotExists, strconv.FormatUint(id, 10))
+	}
+
+	updated := product.Copy()
+	if err = updated.Reserve(quantity); err != nil {
+		return nil, nil, err
+	}
+
+	picked, err := shop.reserveLots(id, product.Quantity, quantity)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	shop.storage[id] = updated
+	return updated.Copy(), picked, nil
+}
+
+func (r *Repository) ReleaseProduct(ctx context.Context, id uint64, quantity uint64, picked []lots.Picked) (*products.Product, error) {
+	shop, err := r.warehouse.writeShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.Unlock()
+
+	product, ok := shop.storage[id]
+	if !ok {
+		return nil, errors.Wrap(repository.ProductNotExists, strconv.FormatUint(id, 10))
+	}
+
+	updated := product.Copy()
+	if err = updated.Release(quantity); err != nil {
+		return nil, err
+	}
+
+	shop.restoreLots(id, picked)
+	shop.storage[id] = updated
+	return updated.Copy(), nil
+}
+
+// findProductByBarcode expects the warehouse to be locked by the caller,
+// barcodes are unique within a tenant only.
+func (s *shop) findProductByBarcode(barcode string) *products.Product {
+	if barcode == "" {
+		return nil
+	}
+	for _, product := range s.storage {
+		if product.GetBarcode() == barcode {
+			return product
+		}
+	}
+	return nil
+}
+
+// checkBarcodeFree expects the warehouse to be locked by the caller.
+func (s *shop) checkBarcodeFree(id uint64, barcode string) error {
+	if owner := s.findProductByBarcode(barcode); owner != nil && owner.GetId() != id {
+		return errors.Wrap(repository.BarcodeAlreadyExists, barcode)
+	}
+	return nil
+}
+
+func (r *Repository) getFilteredProducts(ctx context.Context, page uint64, size uint64, match func(*products.Product) bool) ([]*products.Product, error) {
 	limit, offset := r.getPaginationLimitAndOffset(page, size)
 
-	if err := r.warehouse.RLockWithContext(ctx); err != nil {
+	shop, err := r.warehouse.readShop(ctx)
+	if err != nil {
 		return nil, err
 	}
 	defer r.warehouse.RUnlock()
 
-	warehouseLen := uint64(len(r.warehouse.storage))
-
-	allProducts := make([]*products.Product, 0, warehouseLen)
-	for _, v := range r.warehouse.storage {
-		allProducts = append(allProducts, v.Copy())
+	allProducts := make([]*products.Product, 0, len(shop.storage))
+	for _, v := range shop.storage {
+		if match(v) {
+			allProducts = append(allProducts, v.Copy())
+		}
 	}
 	sort.SliceStable(allProducts, func(i, j int) bool {
 		return allProducts[i].Id < allProducts[j].Id
 	})
 
-	start := math.MinUint64(warehouseLen, offset)
-	end := math.MinUint64(warehouseLen, offset+limit)
+	productsLen := uint64(len(allProducts))
+	start := math.MinUint64(productsLen, offset)
+	end := math.MinUint64(productsLen, offset+limit)
 	return allProducts[start:end], nil
 }
 
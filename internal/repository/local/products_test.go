@@ -4,8 +4,17 @@ import (
 	"context"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"homework-1/internal/models/attributes"
+	"homework-1/internal/models/changes"
+	"homework-1/internal/models/lots"
 	"homework-1/internal/models/products"
+	"homework-1/internal/models/purchases"
+	"homework-1/internal/models/relations"
+	"homework-1/internal/models/reports"
+	"homework-1/internal/models/units"
+	"homework-1/internal/tenants"
 	"testing"
+	"time"
 )
 
 func TestGetProductByID(t *testing.T) {
@@ -13,15 +22,15 @@ func TestGetProductByID(t *testing.T) {
 		// arrange
 		f := SetUp(t)
 
-		f.warehouse.storage[uint64(1)] = &products.Product{
+		f.addProduct(&products.Product{
 			Id:       uint64(1),
 			Name:     "product1",
 			Price:    uint64(1),
 			Quantity: uint64(1),
-		}
+		})
 
 		// act
-		res, err := f.productRepo.GetProductById(context.Background(), 1)
+		res, err := f.productRepo.GetProductById(f.ctx, 1)
 
 		// assert
 		require.NoError(t, err)
@@ -38,13 +47,43 @@ func TestGetProductByID(t *testing.T) {
 		f := SetUp(t)
 
 		// act
-		_, err := f.productRepo.GetProductById(context.Background(), 1)
+		_, err := f.productRepo.GetProductById(f.ctx, 1)
 
 		// assert
 		assert.EqualError(t, err, "1: product does not exist")
 	})
 }
 
+func TestGetProductByBarcode(t *testing.T) {
+	t.Run("success getting product by barcode", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.addProduct(&products.Product{Id: uint64(1), Name: "product1"})
+		f.addProduct(&products.Product{Id: uint64(2), Name: "product2", Barcode: "04006381333931"})
+
+		// act
+		res, err := f.productRepo.GetProductByBarcode(f.ctx, "04006381333931")
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &products.Product{Id: uint64(2), Name: "product2", Barcode: "04006381333931"})
+	})
+
+	t.Run("product by barcode not found", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.addProduct(&products.Product{Id: uint64(1), Name: "product1"})
+
+		// act
+		_, err := f.productRepo.GetProductByBarcode(f.ctx, "04006381333931")
+
+		// assert
+		assert.EqualError(t, err, "04006381333931: product does not exist")
+	})
+}
+
 func TestCreateProduct(t *testing.T) {
 	t.Run("success creating product", func(t *testing.T) {
 		// arrange
@@ -55,10 +94,12 @@ func TestCreateProduct(t *testing.T) {
 			Name:     "product1",
 			Price:    uint64(1),
 			Quantity: uint64(1),
+			Status:   products.StatusActive,
+			Unit:     units.Piece,
 		}
 
 		// act
-		res, err := f.productRepo.CreateProduct(context.Background(), products.Product{
+		res, err := f.productRepo.CreateProduct(f.ctx, products.Product{
 			Name:     "product1",
 			Price:    uint64(1),
 			Quantity: uint64(1),
@@ -67,22 +108,22 @@ func TestCreateProduct(t *testing.T) {
 		// assert
 		require.NoError(t, err)
 		assert.Equal(t, res, &expectedProduct)
-		assert.Equal(t, f.warehouse.storage[uint64(1)], &expectedProduct)
+		assert.Equal(t, f.shop().storage[uint64(1)], &expectedProduct)
 	})
 
 	t.Run("try to create existed product", func(t *testing.T) {
 		// arrange
 		f := SetUp(t)
 
-		f.warehouse.storage[uint64(1)] = &products.Product{
+		f.addProduct(&products.Product{
 			Id:       uint64(1),
 			Name:     "product1",
 			Price:    uint64(1),
 			Quantity: uint64(1),
-		}
+		})
 
 		// act
-		_, err := f.productRepo.CreateProduct(context.Background(), products.Product{
+		_, err := f.productRepo.CreateProduct(f.ctx, products.Product{
 			Name:     "product1",
 			Price:    uint64(1),
 			Quantity: uint64(1),
@@ -97,7 +138,7 @@ func TestCreateProduct(t *testing.T) {
 		f := SetUp(t)
 
 		// act
-		_, err := f.productRepo.CreateProduct(context.Background(), products.Product{
+		_, err := f.productRepo.CreateProduct(f.ctx, products.Product{
 			Id:       uint64(1),
 			Name:     "product1",
 			Price:    uint64(1),
@@ -114,19 +155,19 @@ func TestDeleteProduct(t *testing.T) {
 		// arrange
 		f := SetUp(t)
 
-		f.warehouse.storage[uint64(1)] = &products.Product{
+		f.addProduct(&products.Product{
 			Id:       uint64(1),
 			Name:     "product1",
 			Price:    uint64(1),
 			Quantity: uint64(1),
-		}
+		})
 
 		// act
-		err := f.productRepo.DeleteProduct(context.Background(), 1)
+		err := f.productRepo.DeleteProduct(f.ctx, 1)
 
 		// assert
 		require.NoError(t, err)
-		_, ok := f.warehouse.storage[uint64(1)]
+		_, ok := f.shop().storage[uint64(1)]
 		assert.False(t, ok)
 	})
 
@@ -135,7 +176,7 @@ func TestDeleteProduct(t *testing.T) {
 		f := SetUp(t)
 
 		// act
-		err := f.productRepo.DeleteProduct(context.Background(), 1)
+		err := f.productRepo.DeleteProduct(f.ctx, 1)
 
 		// assert
 		assert.EqualError(t, err, "1: product does not exist")
@@ -154,20 +195,20 @@ func TestUpdateProduct(t *testing.T) {
 			Quantity: uint64(2),
 		}
 
-		f.warehouse.storage[uint64(1)] = &products.Product{
+		f.addProduct(&products.Product{
 			Id:       uint64(1),
 			Name:     "product1",
 			Price:    uint64(1),
 			Quantity: uint64(1),
-		}
+		})
 
 		// act
-		res, err := f.productRepo.UpdateProduct(context.Background(), updatedProduct)
+		res, err := f.productRepo.UpdateProduct(f.ctx, updatedProduct)
 
 		// assert
 		require.NoError(t, err)
 		assert.Equal(t, res, &updatedProduct)
-		assert.Equal(t, f.warehouse.storage[uint64(1)], &updatedProduct)
+		assert.Equal(t, f.shop().storage[uint64(1)], &updatedProduct)
 	})
 
 	t.Run("updating not existed product", func(t *testing.T) {
@@ -175,7 +216,7 @@ func TestUpdateProduct(t *testing.T) {
 		f := SetUp(t)
 
 		// act
-		_, err := f.productRepo.UpdateProduct(context.Background(), products.Product{
+		_, err := f.productRepo.UpdateProduct(f.ctx, products.Product{
 			Id:       uint64(1),
 			Name:     "product1",
 			Price:    uint64(1),
@@ -187,27 +228,66 @@ func TestUpdateProduct(t *testing.T) {
 	})
 }
 
+func TestUpdateProductBarcode(t *testing.T) {
+	t.Run("barcode taken by another product", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.addProduct(&products.Product{Id: uint64(1), Name: "product1", Barcode: "04006381333931"})
+		f.addProduct(&products.Product{Id: uint64(2), Name: "product2"})
+
+		// act
+		_, err := f.productRepo.UpdateProduct(f.ctx, products.Product{
+			Id:      uint64(2),
+			Name:    "product2",
+			Barcode: "04006381333931",
+		})
+
+		// assert
+		assert.EqualError(t, err, "04006381333931: barcode already belongs to another product")
+		assert.Empty(t, f.shop().storage[uint64(2)].Barcode)
+	})
+
+	t.Run("product keeps its own barcode", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.addProduct(&products.Product{Id: uint64(1), Name: "product1", Barcode: "04006381333931"})
+
+		// act
+		res, err := f.productRepo.UpdateProduct(f.ctx, products.Product{
+			Id:      uint64(1),
+			Name:    "renamed",
+			Barcode: "04006381333931",
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res.Name, "renamed")
+	})
+}
+
 func TestGetAllProducts(t *testing.T) {
 	t.Run("success getting all products", func(t *testing.T) {
 		// arrange
 		f := SetUp(t)
 
-		f.warehouse.storage[uint64(1)] = &products.Product{
+		f.addProduct(&products.Product{
 			Id:       uint64(1),
 			Name:     "product1",
 			Price:    uint64(1),
 			Quantity: uint64(1),
-		}
+		})
 
-		f.warehouse.storage[uint64(2)] = &products.Product{
+		f.addProduct(&products.Product{
 			Id:       uint64(2),
 			Name:     "product2",
 			Price:    uint64(2),
 			Quantity: uint64(2),
-		}
+		})
 
 		// act
-		res, err := f.productRepo.GetAllProducts(context.Background(), uint64(1), uint64(2))
+		res, err := f.productRepo.GetAllProducts(f.ctx, uint64(1), uint64(2))
 
 		// assert
 		require.NoError(t, err)
@@ -256,3 +336,440 @@ func TestGetPaginationLimitAndOffset(t *testing.T) {
 		assert.Equal(t, offset, uint64(0))
 	})
 }
+
+func TestGetProductsByStatus(t *testing.T) {
+	t.Run("success getting products by status", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.addProduct(&products.Product{
+			Id:       uint64(1),
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(1),
+			Status:   products.StatusActive,
+		})
+
+		f.addProduct(&products.Product{
+			Id:       uint64(2),
+			Name:     "product2",
+			Price:    uint64(2),
+			Quantity: uint64(2),
+			Status:   products.StatusArchived,
+		})
+
+		// act
+		res, err := f.productRepo.GetProductsByStatus(f.ctx, products.StatusArchived, uint64(1), uint64(2))
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, []*products.Product{
+			{
+				Id:       uint64(2),
+				Name:     "product2",
+				Price:    uint64(2),
+				Quantity: uint64(2),
+				Status:   products.StatusArchived,
+			},
+		})
+	})
+}
+
+func TestFindProducts(t *testing.T) {
+	t.Run("success finding products by category and attributes", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.addProduct(&products.Product{
+			Id:         uint64(1),
+			Name:       "kettle",
+			Category:   "electronics",
+			Attributes: attributes.Attributes{"voltage": float64(220)},
+		})
+		f.addProduct(&products.Product{
+			Id:         uint64(2),
+			Name:       "charger",
+			Category:   "electronics",
+			Attributes: attributes.Attributes{"voltage": float64(5)},
+		})
+		f.addProduct(&products.Product{
+			Id:         uint64(3),
+			Name:       "shirt",
+			Category:   "clothing",
+			Attributes: attributes.Attributes{"fabric": "cotton"},
+		})
+
+		// act
+		res, err := f.productRepo.FindProducts(f.ctx, products.Filter{
+			Category:   "electronics",
+			Attributes: attributes.Attributes{"voltage": float64(220)},
+		}, uint64(1), uint64(10))
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, []*products.Product{
+			{
+				Id:         uint64(1),
+				Name:       "kettle",
+				Category:   "electronics",
+				Attributes: attributes.Attributes{"voltage": float64(220)},
+			},
+		})
+	})
+
+	t.Run("attribute values are compared by type", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.addProduct(&products.Product{
+			Id:         uint64(1),
+			Name:       "kettle",
+			Category:   "electronics",
+			Attributes: attributes.Attributes{"voltage": float64(220)},
+		})
+
+		// act
+		res, err := f.productRepo.FindProducts(f.ctx, products.Filter{
+			Attributes: attributes.Attributes{"voltage": "220"},
+		}, uint64(1), uint64(10))
+
+		// assert
+		require.NoError(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func TestTransitionProductStatus(t *testing.T) {
+	t.Run("success transition", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.addProduct(&products.Product{
+			Id:       uint64(1),
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(1),
+			Status:   products.StatusActive,
+		})
+
+		// act
+		res, err := f.productRepo.TransitionProductStatus(f.ctx, 1, products.StatusDiscontinued, "out of production", "admin")
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res.Status, products.StatusDiscontinued)
+		assert.Equal(t, f.shop().storage[uint64(1)].Status, products.StatusDiscontinued)
+		assert.Equal(t, f.shop().transitions[uint64(1)], []*products.StatusTransition{
+			{
+				ProductId: uint64(1),
+				From:      products.StatusActive,
+				To:        products.StatusDiscontinued,
+				Reason:    "out of production",
+				Actor:     "admin",
+			},
+		})
+	})
+
+	t.Run("archived product can't go back to draft", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.addProduct(&products.Product{
+			Id:       uint64(1),
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(1),
+			Status:   products.StatusArchived,
+		})
+
+		// act
+		_, err := f.productRepo.TransitionProductStatus(f.ctx, 1, products.StatusDraft, "reason", "admin")
+
+		// assert
+		assert.EqualError(t, err, "archived -> draft: invalid status transition")
+		assert.Equal(t, f.shop().storage[uint64(1)].Status, products.StatusArchived)
+		assert.Empty(t, f.shop().transitions[uint64(1)])
+	})
+
+	t.Run("product does not exists", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		// act
+		_, err := f.productRepo.TransitionProductStatus(f.ctx, 1, products.StatusActive, "reason", "admin")
+
+		// assert
+		assert.EqualError(t, err, "1: product does not exist")
+	})
+}
+
+func TestReserveProduct(t *testing.T) {
+	t.Run("success reserving product", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.addProduct(&products.Product{
+			Id:       uint64(1),
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(5),
+			Status:   products.StatusActive,
+		})
+
+		// act
+		res, _, err := f.productRepo.ReserveProduct(f.ctx, 1, 3)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res.Quantity, uint64(2))
+		assert.Equal(t, f.shop().storage[uint64(1)].Quantity, uint64(2))
+	})
+
+	t.Run("reserving not active product", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.addProduct(&products.Product{
+			Id:       uint64(1),
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(5),
+			Status:   products.StatusDiscontinued,
+		})
+
+		// act
+		_, _, err := f.productRepo.ReserveProduct(f.ctx, 1, 3)
+
+		// assert
+		assert.EqualError(t, err, "1: product is not active")
+		assert.Equal(t, f.shop().storage[uint64(1)].Quantity, uint64(5))
+	})
+
+	t.Run("reserving more than in stock", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.addProduct(&products.Product{
+			Id:       uint64(1),
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(2),
+			Status:   products.StatusActive,
+		})
+
+		// act
+		_, _, err := f.productRepo.ReserveProduct(f.ctx, 1, 3)
+
+		// assert
+		assert.EqualError(t, err, "1: not enough quantity")
+	})
+}
+
+func TestReleaseProduct(t *testing.T) {
+	t.Run("success releasing product", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.addProduct(&products.Product{
+			Id:       uint64(1),
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(2),
+			Status:   products.StatusActive,
+		})
+
+		// act
+		res, err := f.productRepo.ReleaseProduct(f.ctx, 1, 3, nil)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res.Quantity, uint64(5))
+		assert.Equal(t, f.shop().storage[uint64(1)].Quantity, uint64(5))
+	})
+
+	t.Run("product does not exists", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		// act
+		_, err := f.productRepo.ReleaseProduct(f.ctx, 1, 3, nil)
+
+		// assert
+		assert.EqualError(t, err, "1: product does not exist")
+	})
+}
+
+func TestProductsOfAnotherTenant(t *testing.T) {
+	anotherCtx := tenants.NewContext(context.Background(), "north-store")
+
+	t.Run("product of another tenant does not exist", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		f.addProduct(&products.Product{Id: uint64(1), Name: "product1", Quantity: uint64(2)})
+
+		// act
+		_, getErr := f.productRepo.GetProductById(anotherCtx, 1)
+		_, _, reserveErr := f.productRepo.ReserveProduct(anotherCtx, 1, 1)
+		deleteErr := f.productRepo.DeleteProduct(anotherCtx, 1)
+
+		// assert
+		assert.EqualError(t, getErr, "1: product does not exist")
+		assert.EqualError(t, reserveErr, "1: product does not exist")
+		assert.EqualError(t, deleteErr, "1: product does not exist")
+		assert.Equal(t, f.shop().storage[uint64(1)].Quantity, uint64(2))
+	})
+
+	t.Run("lists only the products of the tenant", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		f.addProduct(&products.Product{Id: uint64(10), Name: "product1"})
+		created, err := f.productRepo.CreateProduct(anotherCtx, products.Product{Name: "product2"})
+		require.NoError(t, err)
+
+		// act
+		own, err := f.productRepo.GetAllProducts(f.ctx, 0, 0)
+		require.NoError(t, err)
+		another, err := f.productRepo.GetAllProducts(anotherCtx, 0, 0)
+		require.NoError(t, err)
+
+		// assert
+		require.Len(t, own, 1)
+		assert.Equal(t, uint64(10), own[0].Id)
+		require.Len(t, another, 1)
+		assert.Equal(t, created.Id, another[0].Id)
+	})
+
+	t.Run("barcodes are unique within a tenant", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		f.addProduct(&products.Product{Id: uint64(10), Name: "product1", Barcode: "04006381333931"})
+
+		// act
+		_, err := f.productRepo.CreateProduct(anotherCtx, products.Product{Name: "product2", Barcode: "04006381333931"})
+
+		// assert
+		require.NoError(t, err)
+		_, err = f.productRepo.CreateProduct(f.ctx, products.Product{Name: "product3", Barcode: "04006381333931"})
+		assert.EqualError(t, err, "04006381333931: barcode already belongs to another product")
+	})
+
+	t.Run("context without tenant", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		// act
+		_, err := f.productRepo.GetAllProducts(context.Background(), 0, 0)
+
+		// assert
+		assert.ErrorIs(t, err, tenants.ErrNoTenant)
+	})
+}
+
+func TestDataOfAnotherTenant(t *testing.T) {
+	anotherCtx := tenants.NewContext(context.Background(), "north-store")
+	setUpShops := func(t *testing.T) *productRepoFixture {
+		f := SetUp(t)
+		f.addProduct(&products.Product{Id: uint64(1), Name: "product1", Price: uint64(10), Quantity: uint64(2), Status: products.StatusActive})
+		f.addProduct(&products.Product{Id: uint64(2), Name: "product2", Price: uint64(5), Quantity: uint64(1), Status: products.StatusActive})
+		f.shop().lots[uint64(1)] = &lots.Lot{Id: uint64(1), ProductId: uint64(1), Number: "A", Quantity: uint64(2)}
+		f.shop().priceChanges[uint64(1)] = &changes.PriceChange{Id: uint64(1), ProductId: uint64(1), Price: uint64(20), Status: changes.StatusPending}
+		f.shop().relations[uint64(1)] = map[uint64]*relations.Relation{
+			uint64(2): {ProductId: uint64(1), RelatedId: uint64(2), Type: relations.TypeAccessory},
+		}
+		f.shop().reorderThresholds[uint64(1)] = uint64(5)
+		f.shop().suppliers[uint64(1)] = &purchases.Supplier{Id: uint64(1), Name: "supplier1"}
+		f.shop().purchaseOrders[uint64(1)] = &purchases.PurchaseOrder{Id: uint64(1), SupplierId: uint64(1), Status: purchases.StatusOpen,
+			Lines: []*purchases.Line{{Id: uint64(1), OrderId: uint64(1), ProductId: uint64(1), Quantity: uint64(3)}}}
+		return f
+	}
+
+	t.Run("lots of another tenant are not seen", func(t *testing.T) {
+		// arrange
+		f := setUpShops(t)
+
+		// act
+		productLots, err := f.lotRepo.GetProductLots(anotherCtx, 1)
+		require.NoError(t, err)
+		expiring, err := f.lotRepo.GetExpiringLots(anotherCtx, time.Now().AddDate(1, 0, 0), 0, 0)
+		require.NoError(t, err)
+		_, addErr := f.lotRepo.AddLot(anotherCtx, lots.Lot{ProductId: uint64(1), Number: "B", Quantity: uint64(1)})
+
+		// assert
+		assert.Empty(t, productLots)
+		assert.Empty(t, expiring)
+		assert.EqualError(t, addErr, "1: product does not exist")
+		assert.Equal(t, f.shop().storage[uint64(1)].Quantity, uint64(2))
+	})
+
+	t.Run("price changes of another tenant are not seen", func(t *testing.T) {
+		// arrange
+		f := setUpShops(t)
+
+		// act
+		pending, err := f.priceChangeRepo.GetPendingPriceChanges(anotherCtx, 0, 0)
+		require.NoError(t, err)
+		_, getErr := f.priceChangeRepo.GetPriceChangeById(anotherCtx, 1)
+		_, _, approveErr := f.priceChangeRepo.ApprovePriceChange(anotherCtx, 1, "manager")
+		_, createErr := f.priceChangeRepo.CreatePriceChange(anotherCtx, changes.PriceChange{ProductId: uint64(1), Price: uint64(30)})
+
+		// assert
+		assert.Empty(t, pending)
+		assert.EqualError(t, getErr, "1: price change does not exist")
+		assert.EqualError(t, approveErr, "1: price change does not exist")
+		assert.EqualError(t, createErr, "1: product does not exist")
+		assert.Equal(t, f.shop().storage[uint64(1)].Price, uint64(10))
+	})
+
+	t.Run("relations to a product of another tenant are rejected", func(t *testing.T) {
+		// arrange
+		f := setUpShops(t)
+		f.warehouse.shopOf("north-store").storage[uint64(3)] = &products.Product{Id: uint64(3), Name: "product3"}
+
+		// act
+		_, createErr := f.relationRepo.CreateRelation(f.ctx, relations.Relation{ProductId: uint64(1), RelatedId: uint64(3), Type: relations.TypeRelated})
+		related, err := f.relationRepo.GetRelatedProducts(anotherCtx, 1, "")
+		require.NoError(t, err)
+
+		// assert
+		assert.EqualError(t, createErr, "3: product does not exist")
+		assert.Empty(t, related)
+	})
+
+	t.Run("suppliers and purchase orders of another tenant are not seen", func(t *testing.T) {
+		// arrange
+		f := setUpShops(t)
+
+		// act
+		suppliers, err := f.purchaseRepo.GetAllSuppliers(anotherCtx, 0, 0)
+		require.NoError(t, err)
+		orders, err := f.purchaseRepo.GetOpenPurchaseOrders(anotherCtx, 0, 0)
+		require.NoError(t, err)
+		_, supplierErr := f.purchaseRepo.GetSupplierById(anotherCtx, 1)
+		_, receiveErr := f.purchaseRepo.ReceivePurchaseOrder(anotherCtx, 1, map[uint64]uint64{1: 3})
+
+		// assert
+		assert.Empty(t, suppliers)
+		assert.Empty(t, orders)
+		assert.EqualError(t, supplierErr, "1: supplier does not exist")
+		assert.EqualError(t, receiveErr, "1: purchase order does not exist")
+		assert.Equal(t, f.shop().storage[uint64(1)].Quantity, uint64(2))
+	})
+
+	t.Run("reports and low stock cover only the tenant", func(t *testing.T) {
+		// arrange
+		f := setUpShops(t)
+
+		// act
+		valuation, err := f.reportRepo.GetStockValuation(anotherCtx)
+		require.NoError(t, err)
+		lowStock, err := f.stockRepo.GetLowStockProducts(anotherCtx, 0, 0)
+		require.NoError(t, err)
+		ownLowStock, err := f.stockRepo.GetLowStockProducts(f.ctx, 0, 0)
+		require.NoError(t, err)
+
+		// assert
+		assert.Equal(t, valuation, &reports.Valuation{})
+		assert.Empty(t, lowStock)
+		assert.Len(t, ownLowStock, 1)
+	})
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"homework-1/internal/math"
+	"homework-1/internal/models/purchases"
+	"homework-1/internal/repository"
+	"sort"
+	"strconv"
+)
+
+var (
+	ErrSupplierIdAlreadySet      = errors.New("Supplier id already set")
+	ErrPurchaseOrderIdAlreadySet = errors.New("Purchase order id already set")
+)
+
+func (r *Repository) CreateSupplier(ctx context.Context, supplier purchases.Supplier) (*purchases.Supplier, error) {
+	if supplier.Id > 0 {
+		return nil, errors.Wrap(ErrSupplierIdAlreadySet, "Can't create new supplier")
+	}
+
+	shop, err := r.warehouse.writeShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.Unlock()
+
+	supplier.Id = r.warehouse.GetNextSupplierId()
+	shop.suppliers[supplier.Id] = &supplier
+	return supplier.Copy(), nil
+}
+
+func (r *Repository) GetSupplierById(ctx context.Context, id uint64) (*purchases.Supplier, error) {
+	shop, err := r.warehouse.readShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.RUnlock()
+
+	if supplier, ok := shop.suppliers[id]; ok {
+		return supplier.Copy(), nil
+	}
+	return nil, errors.Wrap(repository.SupplierNotExists, strconv.FormatUint(id, 10))
+}
+
+func (r *Repository) GetAllSuppliers(ctx context.Context, page uint64, size uint64) ([]*purchases.Supplier, error) {
+	limit, offset := r.getPaginationLimitAndOffset(page, size)
+
+	shop, err := r.warehouse.readShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.RUnlock()
+
+	suppliers := make([]*purchases.Supplier, 0, len(shop.suppliers))
+	for _, supplier := range shop.suppliers {
+		suppliers = append(suppliers, supplier.Copy())
+	}
+	sort.SliceStable(suppliers, func(i, j int) bool {
+		return suppliers[i].Id < suppliers[j].Id
+	})
+
+	suppliersLen := uint64(len(suppliers))
+	start := math.MinUint64(suppliersLen, offset)
+	end := math.MinUint64(suppliersLen, offset+limit)
+	return suppliers[start:end], nil
+}
+
+func (r *Repository) CreatePurchaseOrder(ctx context.Context, order purchases.PurchaseOrder) (*purchases.PurchaseOrder, error) {
+	if order.Id > 0 {
+		return nil, errors.Wrap(ErrPurchaseOrderIdAlreadySet, "Can't create new purchase order")
+	}
+
+	shop, err := r.warehouse.writeShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.Unlock()
+
+	if _, ok := shop.suppliers[order.SupplierId]; !ok {
+		return nil, errors.Wrap(repository.SupplierNotExists, strconv.FormatUint(order.SupplierId, 10))
+	}
+	for _, line := range order.Lines {
+		if _, ok := shop.storage[line.ProductId]; !ok {
+			return nil, errors.Wrap(repository.ProductNotExists, strconv.FormatUint(line.ProductId, 10))
+		}
+	}
+
+	stored := order.Copy()
+	stored.Id = r.warehouse.GetNextPurchaseOrderId()
+	for _, line := range stored.Lines {
+		line.Id = r.warehouse.GetNextLineId()
+		line.OrderId = stored.Id
+	}
+	shop.purchaseOrders[stored.Id] = stored
+	return stored.Copy(), nil
+}
+
+func (r *Repository) GetPurchaseOrderById(ctx context.Context, id uint64) (*purchases.PurchaseOrder, error) {
+	shop, err := r.warehouse.readShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.RUnlock()
+
+	if order, ok := shop.purchaseOrders[id]; ok {
+		return order.Copy(), nil
+	}
+	return nil, errors.Wrap(repository.PurchaseOrderNotExists, strconv.FormatUint(id, 10))
+}
+
+func (r *Repository) GetOpenPurchaseOrders(ctx context.Context, page uint64, size uint64) ([]*purchases.PurchaseOrder, error) {
+	limit, offset := r.getPaginationLimitAndOffset(page, size)
+
+	shop, err := r.warehouse.readShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.RUnlock()
+
+	orders := make([]*purchases.PurchaseOrder, 0, len(shop.purchaseOrders))
+	for _, order := range shop.purchaseOrders {
+		if order.IsOpen() {
+			orders = append(orders, order.Copy())
+		}
+	}
+	sort.SliceStable(orders, func(i, j int) bool {
+		return orders[i].Id < orders[j].Id
+	})
+
+	ordersLen := uint64(len(orders))
+	start := math.MinUint64(ordersLen, offset)
+	end := math.MinUint64(ordersLen, offset+limit)
+	return orders[start:end], nil
+}
+
+func (r *Repository) ReceivePurchaseOrder(ctx context.Context, id uint64, receipts map[uint64]uint64) (*purchases.PurchaseOrder, error) {
+	shop, err := r.warehouse.writeShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.Unlock()
+
+	stored, ok := shop.purchaseOrders[id]
+	if !ok {
+		return nil, errors.Wrap(repository.PurchaseOrderNotExists, strconv.FormatUint(id, 10))
+	}
+
+	order := stored.Copy()
+	received, err := order.Receive(receipts)
+	if err != nil {
+		return nil, err
+	}
+
+	for productId := range received {
+		if _, ok = shop.storage[productId]; !ok {
+			return nil, errors.Wrap(repository.ProductNotExists, strconv.FormatUint(productId, 10))
+		}
+	}
+	for productId, quantity := range received {
+		updated := shop.storage[productId].Copy()
+		updated.Quantity += quantity
+		shop.storage[productId] = updated
+	}
+
+	shop.purchaseOrders[id] = order
+	return order.Copy(), nil
+}
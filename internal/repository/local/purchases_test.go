@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"homework-1/internal/models/products"
+	"homework-1/internal/models/purchases"
+	"testing"
+)
+
+func TestCreatePurchaseOrder(t *testing.T) {
+	t.Run("success creating purchase order", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.shop().suppliers[uint64(1)] = &purchases.Supplier{Id: uint64(1), Name: "supplier1"}
+		f.addProduct(&products.Product{Id: uint64(1), Name: "product1", Price: uint64(1)})
+
+		// act
+		res, err := f.purchaseRepo.CreatePurchaseOrder(f.ctx, purchases.PurchaseOrder{
+			SupplierId: uint64(1),
+			Status:     purchases.StatusOpen,
+			Lines:      []*purchases.Line{{ProductId: uint64(1), Quantity: uint64(5)}},
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res.Id, uint64(1))
+		assert.Equal(t, res.Lines, []*purchases.Line{{Id: uint64(1), OrderId: uint64(1), ProductId: uint64(1), Quantity: uint64(5)}})
+		assert.Equal(t, f.shop().purchaseOrders[uint64(1)].Status, purchases.StatusOpen)
+	})
+
+	t.Run("supplier does not exists", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		// act
+		_, err := f.purchaseRepo.CreatePurchaseOrder(f.ctx, purchases.PurchaseOrder{SupplierId: uint64(1)})
+
+		// assert
+		assert.EqualError(t, err, "1: supplier does not exist")
+		assert.Empty(t, f.shop().purchaseOrders)
+	})
+
+	t.Run("product does not exists", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.shop().suppliers[uint64(1)] = &purchases.Supplier{Id: uint64(1), Name: "supplier1"}
+
+		// act
+		_, err := f.purchaseRepo.CreatePurchaseOrder(f.ctx, purchases.PurchaseOrder{
+			SupplierId: uint64(1),
+			Lines:      []*purchases.Line{{ProductId: uint64(2), Quantity: uint64(5)}},
+		})
+
+		// assert
+		assert.EqualError(t, err, "2: product does not exist")
+		assert.Empty(t, f.shop().purchaseOrders)
+	})
+}
+
+func TestReceivePurchaseOrder(t *testing.T) {
+	setUpOrder := func(t *testing.T) *productRepoFixture {
+		f := SetUp(t)
+		f.addProduct(&products.Product{Id: uint64(1), Name: "product1", Price: uint64(1), Quantity: uint64(1)})
+		f.addProduct(&products.Product{Id: uint64(2), Name: "product2", Price: uint64(1)})
+		f.shop().purchaseOrders[uint64(1)] = &purchases.PurchaseOrder{
+			Id:         uint64(1),
+			SupplierId: uint64(1),
+			Status:     purchases.StatusOpen,
+			Lines: []*purchases.Line{
+				{Id: uint64(1), OrderId: uint64(1), ProductId: uint64(1), Quantity: uint64(5)},
+				{Id: uint64(2), OrderId: uint64(1), ProductId: uint64(2), Quantity: uint64(3)},
+			},
+		}
+		return f
+	}
+
+	t.Run("partial then full receipt", func(t *testing.T) {
+		// arrange
+		f := setUpOrder(t)
+
+		// act
+		partial, err := f.purchaseRepo.ReceivePurchaseOrder(f.ctx, uint64(1), map[uint64]uint64{1: 2})
+		require.NoError(t, err)
+		full, err := f.purchaseRepo.ReceivePurchaseOrder(f.ctx, uint64(1), nil)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, partial.Status, purchases.StatusPartiallyReceived)
+		assert.Equal(t, full.Status, purchases.StatusReceived)
+		assert.NotNil(t, full.ReceivedAt)
+		assert.Equal(t, f.shop().storage[uint64(1)].Quantity, uint64(6))
+		assert.Equal(t, f.shop().storage[uint64(2)].Quantity, uint64(3))
+	})
+
+	t.Run("over receipt", func(t *testing.T) {
+		// arrange
+		f := setUpOrder(t)
+
+		// act
+		_, err := f.purchaseRepo.ReceivePurchaseOrder(f.ctx, uint64(1), map[uint64]uint64{1: 2, 2: 4})
+
+		// assert
+		assert.ErrorIs(t, err, purchases.ErrOverReceipt)
+		assert.Equal(t, f.shop().storage[uint64(1)].Quantity, uint64(1))
+		assert.Equal(t, f.shop().purchaseOrders[uint64(1)].Status, purchases.StatusOpen)
+	})
+
+	t.Run("order already received", func(t *testing.T) {
+		// arrange
+		f := setUpOrder(t)
+		f.shop().purchaseOrders[uint64(1)].Status = purchases.StatusReceived
+
+		// act
+		_, err := f.purchaseRepo.ReceivePurchaseOrder(f.ctx, uint64(1), nil)
+
+		// assert
+		assert.ErrorIs(t, err, purchases.ErrOrderClosed)
+		assert.Equal(t, f.shop().storage[uint64(1)].Quantity, uint64(1))
+	})
+
+	t.Run("order does not exists", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		// act
+		_, err := f.purchaseRepo.ReceivePurchaseOrder(f.ctx, uint64(1), nil)
+
+		// assert
+		assert.EqualError(t, err, "1: purchase order does not exist")
+	})
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"homework-1/internal/models/relations"
+	"homework-1/internal/repository"
+	"sort"
+	"strconv"
+)
+
+func (r *Repository) CreateRelation(ctx context.Context, relation relations.Relation) (*relations.Relation, error) {
+	shop, err := r.warehouse.writeShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.Unlock()
+
+	for _, id := range []uint64{relation.ProductId, relation.RelatedId} {
+		if _, ok := shop.storage[id]; !ok {
+			return nil, errors.Wrap(repository.ProductNotExists, strconv.FormatUint(id, 10))
+		}
+	}
+	if _, ok := shop.relations[relation.ProductId][relation.RelatedId]; ok {
+		return nil, errors.Wrap(repository.RelationAlreadyExists, relationKey(relation.ProductId, relation.RelatedId))
+	}
+
+	if _, ok := shop.relations[relation.ProductId]; !ok {
+		shop.relations[relation.ProductId] = make(map[uint64]*relations.Relation)
+	}
+	shop.relations[relation.ProductId][relation.RelatedId] = relation.Copy()
+	return relation.Copy(), nil
+}
+
+func (r *Repository) UpdateRelation(ctx context.Context, relation relations.Relation) (*relations.Relation, error) {
+	shop, err := r.warehouse.writeShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.Unlock()
+
+	if _, ok := shop.relations[relation.ProductId][relation.RelatedId]; !ok {
+		return nil, errors.Wrap(repository.RelationNotExists, relationKey(relation.ProductId, relation.RelatedId))
+	}
+	shop.relations[relation.ProductId][relation.RelatedId] = relation.Copy()
+	return relation.Copy(), nil
+}
+
+func (r *Repository) DeleteRelation(ctx context.Context, productId uint64, relatedId uint64) error {
+	shop, err := r.warehouse.writeShop(ctx)
+	if err != nil {
+		return err
+	}
+	defer r.warehouse.Unlock()
+
+	if _, ok := shop.relations[productId][relatedId]; !ok {
+		return errors.Wrap(repository.RelationNotExists, relationKey(productId, relatedId))
+	}
+	delete(shop.relations[productId], relatedId)
+	return nil
+}
+
+func (r *Repository) GetProductRelations(ctx context.Context, productId uint64) ([]*relations.Relation, error) {
+	shop, err := r.warehouse.readShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.RUnlock()
+
+	productRelations := make([]*relations.Relation, 0, len(shop.relations[productId]))
+	for _, relation := range shop.relations[productId] {
+		productRelations = append(productRelations, relation.Copy())
+	}
+	sort.Slice(productRelations, func(i, j int) bool {
+		return productRelations[i].RelatedId < productRelations[j].RelatedId
+	})
+	return productRelations, nil
+}
+
+func (r *Repository) GetRelatedProducts(ctx context.Context, productId uint64, relationType relations.Type) ([]*relations.RelatedProduct, error) {
+	shop, err := r.warehouse.readShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.RUnlock()
+
+	related := make([]*relations.RelatedProduct, 0)
+	for relatedId, relation := range shop.relations[productId] {
+		if relationType != "" && relation.Type != relationType {
+			continue
+		}
+		if product, ok := shop.storage[relatedId]; ok {
+			related = append(related, &relations.RelatedProduct{Product: *product.Copy(), Type: relation.Type})
+		}
+	}
+	sort.Slice(related, func(i, j int) bool {
+		return related[i].GetId() < related[j].GetId()
+	})
+	return related, nil
+}
+
+func (r *Repository) CountProductRelations(ctx context.Context, productId uint64) (uint64, error) {
+	shop, err := r.warehouse.readShop(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer r.warehouse.RUnlock()
+
+	count := uint64(len(shop.relations[productId]))
+	for id, related := range shop.relations {
+		if _, ok := related[productId]; ok && id != productId {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func relationKey(productId uint64, relatedId uint64) string {
+	return fmt.Sprintf("%d -> %d", productId, relatedId)
+}
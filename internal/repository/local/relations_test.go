@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"homework-1/internal/models/products"
+	"homework-1/internal/models/relations"
+	"testing"
+)
+
+func TestCreateRelation(t *testing.T) {
+	t.Run("success creating relation", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.addProduct(&products.Product{Id: uint64(1), Name: "phone"})
+		f.addProduct(&products.Product{Id: uint64(2), Name: "cable"})
+
+		// act
+		_, err := f.relationRepo.CreateRelation(f.ctx, relations.Relation{
+			ProductId: uint64(1),
+			RelatedId: uint64(2),
+			Type:      relations.TypeAccessory,
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, f.shop().relations[uint64(1)][uint64(2)].Type, relations.TypeAccessory)
+	})
+
+	t.Run("related product does not exist", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.addProduct(&products.Product{Id: uint64(1), Name: "phone"})
+
+		// act
+		_, err := f.relationRepo.CreateRelation(f.ctx, relations.Relation{
+			ProductId: uint64(1),
+			RelatedId: uint64(2),
+			Type:      relations.TypeAccessory,
+		})
+
+		// assert
+		assert.EqualError(t, err, "2: product does not exist")
+	})
+
+	t.Run("relation already exists", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.addProduct(&products.Product{Id: uint64(1), Name: "phone"})
+		f.addProduct(&products.Product{Id: uint64(2), Name: "cable"})
+		f.shop().relations[uint64(1)] = map[uint64]*relations.Relation{
+			uint64(2): {ProductId: uint64(1), RelatedId: uint64(2), Type: relations.TypeRelated},
+		}
+
+		// act
+		_, err := f.relationRepo.CreateRelation(f.ctx, relations.Relation{
+			ProductId: uint64(1),
+			RelatedId: uint64(2),
+			Type:      relations.TypeAccessory,
+		})
+
+		// assert
+		assert.EqualError(t, err, "1 -> 2: relation already exists")
+	})
+}
+
+func TestGetRelatedProducts(t *testing.T) {
+	t.Run("filters by type", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.addProduct(&products.Product{Id: uint64(2), Name: "cable"})
+		f.addProduct(&products.Product{Id: uint64(3), Name: "phone 2"})
+		f.shop().relations[uint64(1)] = map[uint64]*relations.Relation{
+			uint64(2): {ProductId: uint64(1), RelatedId: uint64(2), Type: relations.TypeAccessory},
+			uint64(3): {ProductId: uint64(1), RelatedId: uint64(3), Type: relations.TypeReplacement},
+		}
+
+		// act
+		res, err := f.relationRepo.GetRelatedProducts(f.ctx, uint64(1), relations.TypeAccessory)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, []*relations.RelatedProduct{{
+			Product: products.Product{Id: uint64(2), Name: "cable"},
+			Type:    relations.TypeAccessory,
+		}})
+	})
+}
+
+func TestCountProductRelations(t *testing.T) {
+	t.Run("counts both directions", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.shop().relations[uint64(1)] = map[uint64]*relations.Relation{
+			uint64(2): {ProductId: uint64(1), RelatedId: uint64(2), Type: relations.TypeAccessory},
+		}
+		f.shop().relations[uint64(3)] = map[uint64]*relations.Relation{
+			uint64(1): {ProductId: uint64(3), RelatedId: uint64(1), Type: relations.TypeReplacement},
+		}
+
+		// act
+		res, err := f.relationRepo.CountProductRelations(f.ctx, uint64(1))
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, uint64(2))
+	})
+
+	t.Run("deleting product deletes its relations", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.addProduct(&products.Product{Id: uint64(1), Name: "phone"})
+		f.shop().relations[uint64(1)] = map[uint64]*relations.Relation{
+			uint64(2): {ProductId: uint64(1), RelatedId: uint64(2), Type: relations.TypeAccessory},
+		}
+		f.shop().relations[uint64(3)] = map[uint64]*relations.Relation{
+			uint64(1): {ProductId: uint64(3), RelatedId: uint64(1), Type: relations.TypeReplacement},
+		}
+
+		// act
+		err := f.productRepo.DeleteProduct(f.ctx, uint64(1))
+
+		// assert
+		require.NoError(t, err)
+		res, err := f.relationRepo.CountProductRelations(f.ctx, uint64(1))
+		require.NoError(t, err)
+		assert.Equal(t, res, uint64(0))
+	})
+}
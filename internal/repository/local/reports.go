@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"homework-1/internal/models/products"
+	"homework-1/internal/models/reports"
+	"sort"
+)
+
+func (r *Repository) GetStockValuation(ctx context.Context) (*reports.Valuation, error) {
+	shop, err := r.warehouse.readShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.RUnlock()
+
+	var valuation reports.Valuation
+	for _, product := range shop.storage {
+		valuation.Add(product)
+	}
+	return &valuation, nil
+}
+
+func (r *Repository) GetValueByStatus(ctx context.Context) ([]*reports.StatusValue, error) {
+	shop, err := r.warehouse.readShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.RUnlock()
+
+	byStatus := make(map[products.Status]*reports.StatusValue)
+	for _, product := range shop.storage {
+		value, ok := byStatus[product.GetStatus()]
+		if !ok {
+			value = &reports.StatusValue{Status: product.GetStatus()}
+			byStatus[product.GetStatus()] = value
+		}
+		value.Add(product)
+	}
+
+	values := make([]*reports.StatusValue, 0, len(byStatus))
+	for _, value := range byStatus {
+		values = append(values, value)
+	}
+	sort.SliceStable(values, func(i, j int) bool {
+		return values[i].Status < values[j].Status
+	})
+	return values, nil
+}
+
+func (r *Repository) GetValueByCategory(ctx context.Context) ([]*reports.CategoryValue, error) {
+	shop, err := r.warehouse.readShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.RUnlock()
+
+	byCategory := make(map[string]*reports.CategoryValue)
+	for _, product := range shop.storage {
+		value, ok := byCategory[product.GetCategory()]
+		if !ok {
+			value = &reports.CategoryValue{Category: product.GetCategory()}
+			byCategory[product.GetCategory()] = value
+		}
+		value.Add(product)
+	}
+
+	values := make([]*reports.CategoryValue, 0, len(byCategory))
+	for _, value := range byCategory {
+		values = append(values, value)
+	}
+	sort.SliceStable(values, func(i, j int) bool {
+		return values[i].Category < values[j].Category
+	})
+	return values, nil
+}
+
+func (r *Repository) GetTopProductsByValue(ctx context.Context, limit uint64) ([]*reports.ProductValue, error) {
+	if limit == 0 {
+		limit = reports.DefaultTopLimit
+	}
+
+	shop, err := r.warehouse.readShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.RUnlock()
+
+	values := make([]*reports.ProductValue, 0, len(shop.storage))
+	for _, product := range shop.storage {
+		values = append(values, reports.NewProductValue(product))
+	}
+	sort.SliceStable(values, func(i, j int) bool {
+		if values[i].Value != values[j].Value {
+			return values[i].Value > values[j].Value
+		}
+		return values[i].ProductId < values[j].ProductId
+	})
+
+	if uint64(len(values)) > limit {
+		values = values[:limit]
+	}
+	return values, nil
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"homework-1/internal/models/products"
+	"homework-1/internal/models/reports"
+	"homework-1/internal/models/units"
+	"testing"
+)
+
+func setUpReportProducts(t *testing.T) *productRepoFixture {
+	f := SetUp(t)
+	f.addProduct(&products.Product{Id: uint64(1), Name: "product1", Price: uint64(10), Quantity: uint64(2), Status: products.StatusActive, Category: "food"})
+	f.addProduct(&products.Product{Id: uint64(2), Name: "product2", Price: uint64(3), Quantity: uint64(10), Status: products.StatusActive, Category: "food"})
+	f.addProduct(&products.Product{Id: uint64(3), Name: "product3", Price: uint64(5), Status: products.StatusDraft})
+	return f
+}
+
+func TestGetStockValuation(t *testing.T) {
+	t.Run("success getting valuation", func(t *testing.T) {
+		// arrange
+		f := setUpReportProducts(t)
+
+		// act
+		res, err := f.reportRepo.GetStockValuation(f.ctx)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &reports.Valuation{TotalValue: uint64(50), TotalQuantity: uint64(12), ProductCount: uint64(3), ZeroStockCount: uint64(1)})
+	})
+
+	t.Run("price is per whole unit", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		f.addProduct(&products.Product{Id: uint64(1), Name: "flour", Price: uint64(200), Quantity: uint64(1500), Unit: units.Kilogram, Status: products.StatusActive})
+
+		// act
+		res, err := f.reportRepo.GetStockValuation(f.ctx)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &reports.Valuation{TotalValue: uint64(300), TotalQuantity: uint64(1500), ProductCount: uint64(1)})
+	})
+
+	t.Run("empty warehouse", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		// act
+		res, err := f.reportRepo.GetStockValuation(f.ctx)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &reports.Valuation{})
+	})
+}
+
+func TestGetValueByStatus(t *testing.T) {
+	t.Run("success getting value by status", func(t *testing.T) {
+		// arrange
+		f := setUpReportProducts(t)
+
+		// act
+		res, err := f.reportRepo.GetValueByStatus(f.ctx)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, []*reports.StatusValue{
+			{Status: products.StatusActive, ProductCount: uint64(2), Quantity: uint64(12), Value: uint64(50)},
+			{Status: products.StatusDraft, ProductCount: uint64(1)},
+		})
+	})
+}
+
+func TestGetValueByCategory(t *testing.T) {
+	t.Run("success getting value by category", func(t *testing.T) {
+		// arrange
+		f := setUpReportProducts(t)
+
+		// act
+		res, err := f.reportRepo.GetValueByCategory(f.ctx)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, []*reports.CategoryValue{
+			{ProductCount: uint64(1)},
+			{Category: "food", ProductCount: uint64(2), Quantity: uint64(12), Value: uint64(50)},
+		})
+	})
+}
+
+func TestGetTopProductsByValue(t *testing.T) {
+	t.Run("success getting top products", func(t *testing.T) {
+		// arrange
+		f := setUpReportProducts(t)
+
+		// act
+		res, err := f.reportRepo.GetTopProductsByValue(f.ctx, uint64(2))
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, []*reports.ProductValue{
+			{ProductId: uint64(2), Name: "product2", Price: uint64(3), Quantity: uint64(10), Unit: units.Piece, Value: uint64(30)},
+			{ProductId: uint64(1), Name: "product1", Price: uint64(10), Quantity: uint64(2), Unit: units.Piece, Value: uint64(20)},
+		})
+	})
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"homework-1/internal/math"
+	"homework-1/internal/models/products"
+	"homework-1/internal/models/stock"
+	"homework-1/internal/repository"
+	"sort"
+	"strconv"
+)
+
+func (r *Repository) SetReorderThreshold(ctx context.Context, productId uint64, threshold uint64) error {
+	shop, err := r.warehouse.writeShop(ctx)
+	if err != nil {
+		return err
+	}
+	defer r.warehouse.Unlock()
+
+	if _, ok := shop.storage[productId]; !ok {
+		return errors.Wrap(repository.ProductNotExists, strconv.FormatUint(productId, 10))
+	}
+
+	if threshold == 0 {
+		delete(shop.reorderThresholds, productId)
+		return nil
+	}
+	shop.reorderThresholds[productId] = threshold
+	return nil
+}
+
+func (r *Repository) GetLowStockProducts(ctx context.Context, page uint64, size uint64) ([]*stock.LowStock, error) {
+	limit, offset := r.getPaginationLimitAndOffset(page, size)
+
+	shop, err := r.warehouse.readShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.RUnlock()
+
+	lowStock := make([]*stock.LowStock, 0)
+	for productId, threshold := range shop.reorderThresholds {
+		product, ok := shop.storage[productId]
+		if !ok || product.GetStatus() != products.StatusActive || !stock.IsLow(product.GetQuantity(), threshold) {
+			continue
+		}
+		lowStock = append(lowStock, &stock.LowStock{
+			ProductId: product.GetId(),
+			Name:      product.GetName(),
+			Quantity:  product.GetQuantity(),
+			Threshold: threshold,
+		})
+	}
+	sort.SliceStable(lowStock, func(i, j int) bool {
+		return lowStock[i].ProductId < lowStock[j].ProductId
+	})
+
+	lowStockLen := uint64(len(lowStock))
+	start := math.MinUint64(lowStockLen, offset)
+	end := math.MinUint64(lowStockLen, offset+limit)
+	return lowStock[start:end], nil
+}
+
+func (r *Repository) AddAlertSubscription(ctx context.Context, chatId int64) error {
+	if err := r.warehouse.LockWithContext(ctx); err != nil {
+		return err
+	}
+	defer r.warehouse.Unlock()
+
+	r.warehouse.alertSubscriptions[chatId] = struct{}{}
+	return nil
+}
+
+func (r *Repository) RemoveAlertSubscription(ctx context.Context, chatId int64) error {
+	if err := r.warehouse.LockWithContext(ctx); err != nil {
+		return err
+	}
+	defer r.warehouse.Unlock()
+
+	delete(r.warehouse.alertSubscriptions, chatId)
+	return nil
+}
+
+func (r *Repository) GetAlertSubscriptions(ctx context.Context) ([]int64, error) {
+	if err := r.warehouse.RLockWithContext(ctx); err != nil {
+		return nil, err
+	}
+	defer r.warehouse.RUnlock()
+
+	chatIds := make([]int64, 0, len(r.warehouse.alertSubscriptions))
+	for chatId := range r.warehouse.alertSubscriptions {
+		chatIds = append(chatIds, chatId)
+	}
+	sort.Slice(chatIds, func(i, j int) bool {
+		return chatIds[i] < chatIds[j]
+	})
+	return chatIds, nil
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"homework-1/internal/models/products"
+	"homework-1/internal/models/stock"
+	"testing"
+)
+
+func TestSetReorderThreshold(t *testing.T) {
+	t.Run("success setting threshold", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.addProduct(&products.Product{Id: uint64(1), Name: "product1", Quantity: uint64(1)})
+
+		// act
+		err := f.stockRepo.SetReorderThreshold(f.ctx, 1, 5)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, f.shop().reorderThresholds[uint64(1)], uint64(5))
+	})
+
+	t.Run("zero threshold removes it", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.addProduct(&products.Product{Id: uint64(1), Name: "product1", Quantity: uint64(1)})
+		f.shop().reorderThresholds[uint64(1)] = uint64(5)
+
+		// act
+		err := f.stockRepo.SetReorderThreshold(f.ctx, 1, 0)
+
+		// assert
+		require.NoError(t, err)
+		assert.Empty(t, f.shop().reorderThresholds)
+	})
+
+	t.Run("product does not exists", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		// act
+		err := f.stockRepo.SetReorderThreshold(f.ctx, 1, 5)
+
+		// assert
+		assert.EqualError(t, err, "1: product does not exist")
+	})
+}
+
+func TestGetLowStockProducts(t *testing.T) {
+	t.Run("success getting low stock products", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.addProduct(&products.Product{Id: uint64(1), Name: "product1", Quantity: uint64(1), Status: products.StatusActive})
+		f.addProduct(&products.Product{Id: uint64(2), Name: "product2", Quantity: uint64(10), Status: products.StatusActive})
+		f.addProduct(&products.Product{Id: uint64(3), Name: "product3", Quantity: uint64(1), Status: products.StatusDiscontinued})
+		f.addProduct(&products.Product{Id: uint64(4), Name: "product4", Quantity: uint64(0), Status: products.StatusActive})
+		f.shop().reorderThresholds[uint64(1)] = uint64(5)
+		f.shop().reorderThresholds[uint64(2)] = uint64(5)
+		f.shop().reorderThresholds[uint64(3)] = uint64(5)
+
+		// act
+		res, err := f.stockRepo.GetLowStockProducts(f.ctx, 0, 0)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, []*stock.LowStock{
+			{ProductId: uint64(1), Name: "product1", Quantity: uint64(1), Threshold: uint64(5)},
+		})
+	})
+}
+
+func TestAlertSubscriptions(t *testing.T) {
+	t.Run("success subscribing and unsubscribing", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		// act
+		require.NoError(t, f.stockRepo.AddAlertSubscription(f.ctx, 2))
+		require.NoError(t, f.stockRepo.AddAlertSubscription(f.ctx, 1))
+		require.NoError(t, f.stockRepo.AddAlertSubscription(f.ctx, 2))
+		require.NoError(t, f.stockRepo.AddAlertSubscription(f.ctx, 3))
+		require.NoError(t, f.stockRepo.RemoveAlertSubscription(f.ctx, 3))
+		res, err := f.stockRepo.GetAlertSubscriptions(f.ctx)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, []int64{1, 2})
+	})
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"homework-1/internal/models/stocktakes"
+	"homework-1/internal/repository"
+	"sort"
+	"strconv"
+)
+
+var ErrStocktakeIdAlreadySet = errors.New("Stocktake id already set")
+
+func (r *Repository) OpenStocktake(ctx context.Context, session stocktakes.Session) (*stocktakes.Session, error) {
+	if session.Id > 0 {
+		return nil, errors.Wrap(ErrStocktakeIdAlreadySet, "Can't open new stocktake")
+	}
+
+	shop, err := r.warehouse.writeShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.Unlock()
+
+	session.Id = r.warehouse.GetNextStocktakeId()
+	shop.stocktakes[session.Id] = &session
+	shop.stocktakeCounts[session.Id] = make(map[uint64]*stocktakes.Count)
+	return session.Copy(), nil
+}
+
+func (r *Repository) GetStocktakeById(ctx context.Context, id uint64) (*stocktakes.Session, error) {
+	shop, err := r.warehouse.readShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.RUnlock()
+
+	if session, ok := shop.stocktakes[id]; ok {
+		return session.Copy(), nil
+	}
+	return nil, errors.Wrap(repository.StocktakeNotExists, strconv.FormatUint(id, 10))
+}
+
+func (r *Repository) SubmitCount(ctx context.Context, count stocktakes.Count) (*stocktakes.Count, error) {
+	shop, err := r.warehouse.writeShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.Unlock()
+
+	session, ok := shop.stocktakes[count.SessionId]
+	if !ok {
+		return nil, errors.Wrap(repository.StocktakeNotExists, strconv.FormatUint(count.SessionId, 10))
+	}
+	if !session.IsOpen() {
+		return nil, errors.Wrap(stocktakes.ErrSessionClosed, strconv.FormatUint(count.SessionId, 10))
+	}
+	if _, ok = shop.storage[count.ProductId]; !ok {
+		return nil, errors.Wrap(repository.ProductNotExists, strconv.FormatUint(count.ProductId, 10))
+	}
+
+	shop.stocktakeCounts[count.SessionId][count.ProductId] = &count
+	return count.Copy(), nil
+}
+
+func (r *Repository) GetStocktakeVariances(ctx context.Context, id uint64) ([]*stocktakes.Variance, error) {
+	shop, err := r.warehouse.readShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.RUnlock()
+
+	if _, ok := shop.stocktakes[id]; !ok {
+		return nil, errors.Wrap(repository.StocktakeNotExists, strconv.FormatUint(id, 10))
+	}
+	return shop.stocktakeVariances(id), nil
+}
+
+func (r *Repository) CommitStocktake(ctx context.Context, id uint64, actor string) (*stocktakes.Session, []*stocktakes.Adjustment, error) {
+	shop, err := r.warehouse.writeShop(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer r.warehouse.Unlock()
+
+	stored, ok := shop.stocktakes[id]
+	if !ok {
+		return nil, nil, errors.Wrap(repository.StocktakeNotExists, strconv.FormatUint(id, 10))
+	}
+
+	session := stored.Copy()
+	if err := session.Commit(actor); err != nil {
+		return nil, nil, err
+	}
+
+	adjustments := make([]*stocktakes.Adjustment, 0)
+	for _, variance := range shop.stocktakeVariances(id) {
+		adjustment := session.Adjust(variance, actor)
+		if adjustment == nil {
+			continue
+		}
+
+		updated := shop.storage[variance.ProductId].Copy()
+		if updated.Quantity > variance.Counted {
+			shop.pickLots(updated.GetId(), updated.Quantity-variance.Counted)
+		}
+		updated.Quantity = variance.Counted
+		shop.storage[updated.GetId()] = updated
+
+		adjustment.Id = r.warehouse.GetNextAdjustmentId()
+		shop.adjustments = append(shop.adjustments, adjustment)
+		adjustments = append(adjustments, adjustment)
+	}
+
+	shop.stocktakes[id] = session
+	return session.Copy(), adjustments, nil
+}
+
+// stocktakeVariances compares the session counts with the products that still exist, the caller holds the lock.
+func (s *shop) stocktakeVariances(id uint64) []*stocktakes.Variance {
+	variances := make([]*stocktakes.Variance, 0, len(s.stocktakeCounts[id]))
+	for productId, count := range s.stocktakeCounts[id] {
+		product, ok := s.storage[productId]
+		if !ok {
+			continue
+		}
+		variances = append(variances, &stocktakes.Variance{
+			ProductId: productId,
+			Name:      product.GetName(),
+			Expected:  product.GetQuantity(),
+			Counted:   count.Counted,
+		})
+	}
+	sort.SliceStable(variances, func(i, j int) bool {
+		return variances[i].ProductId < variances[j].ProductId
+	})
+	return variances
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"homework-1/internal/models/lots"
+	"homework-1/internal/models/products"
+	"homework-1/internal/models/stocktakes"
+	"testing"
+	"time"
+)
+
+func TestSubmitCount(t *testing.T) {
+	t.Run("recount replaces previous count", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.addProduct(&products.Product{Id: uint64(1), Name: "product1", Price: uint64(1), Quantity: uint64(5)})
+		session, err := f.stocktakeRepo.OpenStocktake(f.ctx, stocktakes.Session{Status: stocktakes.StatusOpen, OpenedBy: "user1"})
+		require.NoError(t, err)
+
+		// act
+		_, err = f.stocktakeRepo.SubmitCount(f.ctx, stocktakes.Count{SessionId: session.Id, ProductId: uint64(1), Counted: uint64(3)})
+		require.NoError(t, err)
+		_, err = f.stocktakeRepo.SubmitCount(f.ctx, stocktakes.Count{SessionId: session.Id, ProductId: uint64(1), Counted: uint64(4)})
+		require.NoError(t, err)
+		res, err := f.stocktakeRepo.GetStocktakeVariances(f.ctx, session.Id)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, []*stocktakes.Variance{{ProductId: uint64(1), Name: "product1", Expected: uint64(5), Counted: uint64(4)}})
+	})
+
+	t.Run("session is committed", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.addProduct(&products.Product{Id: uint64(1), Name: "product1", Price: uint64(1)})
+		f.shop().stocktakes[uint64(1)] = &stocktakes.Session{Id: uint64(1), Status: stocktakes.StatusCommitted}
+
+		// act
+		_, err := f.stocktakeRepo.SubmitCount(f.ctx, stocktakes.Count{SessionId: uint64(1), ProductId: uint64(1), Counted: uint64(3)})
+
+		// assert
+		assert.ErrorIs(t, err, stocktakes.ErrSessionClosed)
+	})
+
+	t.Run("product does not exists", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.shop().stocktakes[uint64(1)] = &stocktakes.Session{Id: uint64(1), Status: stocktakes.StatusOpen}
+		f.shop().stocktakeCounts[uint64(1)] = make(map[uint64]*stocktakes.Count)
+
+		// act
+		_, err := f.stocktakeRepo.SubmitCount(f.ctx, stocktakes.Count{SessionId: uint64(1), ProductId: uint64(2), Counted: uint64(3)})
+
+		// assert
+		assert.EqualError(t, err, "2: product does not exist")
+	})
+
+	t.Run("session does not exists", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		// act
+		_, err := f.stocktakeRepo.SubmitCount(f.ctx, stocktakes.Count{SessionId: uint64(1), ProductId: uint64(1)})
+
+		// assert
+		assert.EqualError(t, err, "1: stocktake session does not exist")
+	})
+}
+
+func TestCommitStocktake(t *testing.T) {
+	t.Run("success committing stocktake", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		expiresAt := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+		f.addProduct(&products.Product{Id: uint64(1), Name: "product1", Price: uint64(1), Quantity: uint64(5)})
+		f.addProduct(&products.Product{Id: uint64(2), Name: "product2", Price: uint64(1), Quantity: uint64(2)})
+		f.addProduct(&products.Product{Id: uint64(3), Name: "product3", Price: uint64(1), Quantity: uint64(7)})
+		f.shop().lots[uint64(1)] = &lots.Lot{Id: uint64(1), ProductId: uint64(1), Number: "A", Quantity: uint64(5), ExpiresAt: expiresAt}
+		f.shop().stocktakes[uint64(1)] = &stocktakes.Session{Id: uint64(1), Status: stocktakes.StatusOpen, OpenedBy: "user1"}
+		f.shop().stocktakeCounts[uint64(1)] = map[uint64]*stocktakes.Count{
+			1: {SessionId: uint64(1), ProductId: uint64(1), Counted: uint64(3)},
+			2: {SessionId: uint64(1), ProductId: uint64(2), Counted: uint64(4)},
+			3: {SessionId: uint64(1), ProductId: uint64(3), Counted: uint64(7)},
+		}
+
+		// act
+		session, adjustments, err := f.stocktakeRepo.CommitStocktake(f.ctx, uint64(1), "user2")
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, session.Status, stocktakes.StatusCommitted)
+		assert.Equal(t, session.CommittedBy, "user2")
+		require.Len(t, adjustments, 2)
+		assert.Equal(t, adjustments[0].ProductId, uint64(1))
+		assert.Equal(t, adjustments[0].Before, uint64(5))
+		assert.Equal(t, adjustments[0].After, uint64(3))
+		assert.Equal(t, adjustments[1].ProductId, uint64(2))
+		assert.Equal(t, adjustments[1].After, uint64(4))
+		assert.Equal(t, f.shop().storage[uint64(1)].Quantity, uint64(3))
+		assert.Equal(t, f.shop().storage[uint64(2)].Quantity, uint64(4))
+		assert.Equal(t, f.shop().lots[uint64(1)].Quantity, uint64(3))
+		assert.Len(t, f.shop().adjustments, 2)
+	})
+
+	t.Run("session already committed", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.addProduct(&products.Product{Id: uint64(1), Name: "product1", Price: uint64(1), Quantity: uint64(5)})
+		f.shop().stocktakes[uint64(1)] = &stocktakes.Session{Id: uint64(1), Status: stocktakes.StatusCommitted}
+		f.shop().stocktakeCounts[uint64(1)] = map[uint64]*stocktakes.Count{
+			1: {SessionId: uint64(1), ProductId: uint64(1), Counted: uint64(3)},
+		}
+
+		// act
+		_, _, err := f.stocktakeRepo.CommitStocktake(f.ctx, uint64(1), "user2")
+
+		// assert
+		assert.ErrorIs(t, err, stocktakes.ErrSessionClosed)
+		assert.Equal(t, f.shop().storage[uint64(1)].Quantity, uint64(5))
+	})
+}
@@ -1,20 +1,60 @@
 package repository
 
 import (
+	"context"
+	"homework-1/internal/models/products"
 	"homework-1/internal/repository"
+	"homework-1/internal/tenants"
 	"testing"
 )
 
+const testTenant = tenants.Tenant("shop")
+
 type productRepoFixture struct {
-	productRepo repository.Product
-	warehouse   *Warehouse
+	productRepo     repository.Product
+	priceChangeRepo repository.PriceChange
+	stockRepo       repository.Stock
+	purchaseRepo    repository.Purchase
+	lotRepo         repository.Lot
+	stocktakeRepo   repository.Stocktake
+	reportRepo      repository.Report
+	translationRepo repository.Translation
+	imageRepo       repository.Image
+	relationRepo    repository.Relation
+	rateRepo        repository.ExchangeRate
+	grantRepo       repository.Grant
+	// ctx runs the repositories for testTenant
+	ctx       context.Context
+	warehouse *Warehouse
 }
 
 func SetUp(_ *testing.T) *productRepoFixture {
 	var fixture productRepoFixture
+	fixture.ctx = tenants.NewContext(context.Background(), testTenant)
 
 	fixture.warehouse = NewWarehouse()
 	fixture.productRepo = NewRepository(fixture.warehouse)
+	fixture.priceChangeRepo = NewRepository(fixture.warehouse)
+	fixture.stockRepo = NewRepository(fixture.warehouse)
+	fixture.purchaseRepo = NewRepository(fixture.warehouse)
+	fixture.lotRepo = NewRepository(fixture.warehouse)
+	fixture.stocktakeRepo = NewRepository(fixture.warehouse)
+	fixture.reportRepo = NewRepository(fixture.warehouse)
+	fixture.translationRepo = NewRepository(fixture.warehouse)
+	fixture.imageRepo = NewRepository(fixture.warehouse)
+	fixture.relationRepo = NewRepository(fixture.warehouse)
+	fixture.rateRepo = NewRepository(fixture.warehouse)
+	fixture.grantRepo = NewRepository(fixture.warehouse)
 
 	return &fixture
 }
+
+// shop returns the data of testTenant bypassing the repository.
+func (f *productRepoFixture) shop() *shop {
+	return f.warehouse.shopOf(testTenant)
+}
+
+// addProduct stores a product of testTenant bypassing the repository.
+func (f *productRepoFixture) addProduct(product *products.Product) {
+	f.shop().storage[product.GetId()] = product
+}
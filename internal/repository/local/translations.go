@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"homework-1/internal/locales"
+	"homework-1/internal/models/products"
+	"homework-1/internal/repository"
+	"strconv"
+)
+
+func (r *Repository) SetTranslation(ctx context.Context, translation products.Translation) (*products.Translation, error) {
+	shop, err := r.warehouse.writeShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.Unlock()
+
+	if _, ok := shop.storage[translation.ProductId]; !ok {
+		return nil, errors.Wrap(repository.ProductNotExists, strconv.FormatUint(translation.ProductId, 10))
+	}
+
+	if _, ok := shop.translations[translation.ProductId]; !ok {
+		shop.translations[translation.ProductId] = make(map[locales.Locale]*products.Translation)
+	}
+	shop.translations[translation.ProductId][translation.Locale] = translation.Copy()
+	return translation.Copy(), nil
+}
+
+func (r *Repository) GetTranslations(ctx context.Context, productIds []uint64, locale locales.Locale) ([]*products.Translation, error) {
+	shop, err := r.warehouse.readShop(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.warehouse.RUnlock()
+
+	var translations []*products.Translation
+	for _, id := range productIds {
+		for _, l := range []locales.Locale{locale, locales.Default} {
+			if t, ok := shop.translations[id][l]; ok {
+				translations = append(translations, t.Copy())
+			}
+			if locale == locales.Default {
+				break
+			}
+		}
+	}
+	return translations, nil
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"homework-1/internal/locales"
+	"homework-1/internal/models/products"
+	"testing"
+)
+
+func TestSetTranslation(t *testing.T) {
+	t.Run("success setting translation", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		f.addProduct(&products.Product{Id: uint64(1), Name: "pillow"})
+
+		// act
+		_, err := f.translationRepo.SetTranslation(f.ctx, products.Translation{
+			ProductId: uint64(1),
+			Locale:    locales.Russian,
+			Name:      "подушка",
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, f.shop().translations[uint64(1)][locales.Russian].Name, "подушка")
+	})
+
+	t.Run("product does not exist", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		// act
+		_, err := f.translationRepo.SetTranslation(f.ctx, products.Translation{
+			ProductId: uint64(1),
+			Locale:    locales.Russian,
+			Name:      "подушка",
+		})
+
+		// assert
+		assert.EqualError(t, err, "1: product does not exist")
+	})
+}
+
+func TestGetTranslations(t *testing.T) {
+	t.Run("translations in locale and default locale", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+
+		ru := &products.Translation{ProductId: uint64(1), Locale: locales.Russian, Name: "подушка"}
+		en := &products.Translation{ProductId: uint64(1), Locale: locales.English, Name: "pillow"}
+		f.shop().translations[uint64(1)] = map[locales.Locale]*products.Translation{
+			locales.Russian: ru,
+			locales.English: en,
+		}
+
+		// act
+		res, err := f.translationRepo.GetTranslations(f.ctx, []uint64{1, 2}, locales.Russian)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, []*products.Translation{ru, en})
+		assert.Equal(t, products.PickTranslations(res, locales.Russian)[uint64(1)], ru)
+	})
+}
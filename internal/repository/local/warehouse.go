@@ -2,7 +2,17 @@ package repository
 
 import (
 	"context"
+	"homework-1/internal/locales"
+	"homework-1/internal/models/changes"
+	"homework-1/internal/models/grants"
+	"homework-1/internal/models/images"
+	"homework-1/internal/models/lots"
 	"homework-1/internal/models/products"
+	"homework-1/internal/models/purchases"
+	"homework-1/internal/models/relations"
+	"homework-1/internal/models/stocktakes"
+	"homework-1/internal/money"
+	"homework-1/internal/tenants"
 	"sync"
 	"sync/atomic"
 )
@@ -11,24 +21,169 @@ const accessPoolSize = 10
 
 type Warehouse struct {
 	mu         sync.RWMutex
-	storage    map[uint64]*products.Product
 	accessPool chan struct{}
 
-	lastProductId uint64
+	// shops keep the data of every tenant apart, a tenant never sees another tenant's shop
+	shops map[tenants.Tenant]*shop
+
+	// alert subscriptions and grants are shared by the whole deployment
+	alertSubscriptions map[int64]struct{}
+
+	grants map[int64]*grants.Grant
+
+	lastProductId       uint64
+	lastPriceChangeId   uint64
+	lastSupplierId      uint64
+	lastPurchaseOrderId uint64
+	lastLineId          uint64
+	lastLotId           uint64
+	lastStocktakeId     uint64
+	lastAdjustmentId    uint64
+	lastImageId         uint64
+}
+
+// shop is the data of one tenant. Ids are unique across the warehouse, an id of another
+// tenant is simply not found in the shop.
+type shop struct {
+	storage      map[uint64]*products.Product
+	transitions  map[uint64][]*products.StatusTransition
+	priceChanges map[uint64]*changes.PriceChange
+
+	reorderThresholds map[uint64]uint64
+
+	suppliers      map[uint64]*purchases.Supplier
+	purchaseOrders map[uint64]*purchases.PurchaseOrder
+
+	lots map[uint64]*lots.Lot
+
+	stocktakes      map[uint64]*stocktakes.Session
+	stocktakeCounts map[uint64]map[uint64]*stocktakes.Count
+	adjustments     []*stocktakes.Adjustment
+
+	translations map[uint64]map[locales.Locale]*products.Translation
+
+	images map[uint64]*images.Image
+
+	// relations are keyed by product id and then by related product id
+	relations map[uint64]map[uint64]*relations.Relation
+
+	exchangeRates map[money.Currency]*money.Rate
 }
 
 func NewWarehouse() *Warehouse {
 	return &Warehouse{
-		storage:       make(map[uint64]*products.Product),
 		accessPool:    make(chan struct{}, accessPoolSize),
 		lastProductId: 0,
+
+		shops: make(map[tenants.Tenant]*shop),
+
+		alertSubscriptions: make(map[int64]struct{}),
+
+		grants: make(map[int64]*grants.Grant),
+	}
+}
+
+func newShop() *shop {
+	return &shop{
+		storage:      make(map[uint64]*products.Product),
+		transitions:  make(map[uint64][]*products.StatusTransition),
+		priceChanges: make(map[uint64]*changes.PriceChange),
+
+		reorderThresholds: make(map[uint64]uint64),
+
+		suppliers:      make(map[uint64]*purchases.Supplier),
+		purchaseOrders: make(map[uint64]*purchases.PurchaseOrder),
+
+		lots: make(map[uint64]*lots.Lot),
+
+		stocktakes:      make(map[uint64]*stocktakes.Session),
+		stocktakeCounts: make(map[uint64]map[uint64]*stocktakes.Count),
+
+		translations: make(map[uint64]map[locales.Locale]*products.Translation),
+
+		images: make(map[uint64]*images.Image),
+
+		relations: make(map[uint64]map[uint64]*relations.Relation),
+
+		exchangeRates: make(map[money.Currency]*money.Rate),
 	}
 }
 
+// shopOf returns the shop of the tenant and creates it on first use, the caller holds the write lock.
+func (w *Warehouse) shopOf(tenant tenants.Tenant) *shop {
+	s, ok := w.shops[tenant]
+	if !ok {
+		s = newShop()
+		w.shops[tenant] = s
+	}
+	return s
+}
+
+// readShop read locks the warehouse and returns the shop of the tenant of ctx, the caller
+// releases it with RUnlock. A tenant without data gets an empty shop that is not kept.
+func (w *Warehouse) readShop(ctx context.Context) (*shop, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = w.RLockWithContext(ctx); err != nil {
+		return nil, err
+	}
+	if s, ok := w.shops[tenant]; ok {
+		return s, nil
+	}
+	return newShop(), nil
+}
+
+// writeShop write locks the warehouse and returns the shop of the tenant of ctx, the caller
+// releases it with Unlock.
+func (w *Warehouse) writeShop(ctx context.Context) (*shop, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = w.LockWithContext(ctx); err != nil {
+		return nil, err
+	}
+	return w.shopOf(tenant), nil
+}
+
 func (w *Warehouse) GetNextId() uint64 {
 	return atomic.AddUint64(&w.lastProductId, 1)
 }
 
+func (w *Warehouse) GetNextPriceChangeId() uint64 {
+	return atomic.AddUint64(&w.lastPriceChangeId, 1)
+}
+
+func (w *Warehouse) GetNextSupplierId() uint64 {
+	return atomic.AddUint64(&w.lastSupplierId, 1)
+}
+
+func (w *Warehouse) GetNextPurchaseOrderId() uint64 {
+	return atomic.AddUint64(&w.lastPurchaseOrderId, 1)
+}
+
+func (w *Warehouse) GetNextLineId() uint64 {
+	return atomic.AddUint64(&w.lastLineId, 1)
+}
+
+func (w *Warehouse) GetNextLotId() uint64 {
+	return atomic.AddUint64(&w.lastLotId, 1)
+}
+
+func (w *Warehouse) GetNextStocktakeId() uint64 {
+	return atomic.AddUint64(&w.lastStocktakeId, 1)
+}
+
+func (w *Warehouse) GetNextAdjustmentId() uint64 {
+	return atomic.AddUint64(&w.lastAdjustmentId, 1)
+}
+
+func (w *Warehouse) GetNextImageId() uint64 {
+	return atomic.AddUint64(&w.lastImageId, 1)
+}
+
 func (w *Warehouse) Lock() {
 	w.accessPool <- struct{}{}
 	w.mu.Lock()
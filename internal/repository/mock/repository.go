@@ -6,8 +6,20 @@ package mock_repository
 
 import (
 	context "context"
+	locales "homework-1/internal/locales"
+	changes "homework-1/internal/models/changes"
+	grants "homework-1/internal/models/grants"
+	images "homework-1/internal/models/images"
+	lots "homework-1/internal/models/lots"
 	products "homework-1/internal/models/products"
+	purchases "homework-1/internal/models/purchases"
+	relations "homework-1/internal/models/relations"
+	reports "homework-1/internal/models/reports"
+	stock "homework-1/internal/models/stock"
+	stocktakes "homework-1/internal/models/stocktakes"
+	money "homework-1/internal/money"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -64,6 +76,21 @@ func (mr *MockProductMockRecorder) DeleteProduct(ctx, id interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockProduct)(nil).DeleteProduct), ctx, id)
 }
 
+// FindProducts mocks base method.
+func (m *MockProduct) FindProducts(ctx context.Context, filter products.Filter, page, size uint64) ([]*products.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindProducts", ctx, filter, page, size)
+	ret0, _ := ret[0].([]*products.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindProducts indicates an expected call of FindProducts.
+func (mr *MockProductMockRecorder) FindProducts(ctx, filter, page, size interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindProducts", reflect.TypeOf((*MockProduct)(nil).FindProducts), ctx, filter, page, size)
+}
+
 // GetAllProducts mocks base method.
 func (m *MockProduct) GetAllProducts(ctx context.Context, page, size uint64) ([]*products.Product, error) {
 	m.ctrl.T.Helper()
@@ -79,6 +106,21 @@ func (mr *MockProductMockRecorder) GetAllProducts(ctx, page, size interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllProducts", reflect.TypeOf((*MockProduct)(nil).GetAllProducts), ctx, page, size)
 }
 
+// GetProductByBarcode mocks base method.
+func (m *MockProduct) GetProductByBarcode(ctx context.Context, barcode string) (*products.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductByBarcode", ctx, barcode)
+	ret0, _ := ret[0].(*products.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductByBarcode indicates an expected call of GetProductByBarcode.
+func (mr *MockProductMockRecorder) GetProductByBarcode(ctx, barcode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductByBarcode", reflect.TypeOf((*MockProduct)(nil).GetProductByBarcode), ctx, barcode)
+}
+
 // GetProductById mocks base method.
 func (m *MockProduct) GetProductById(ctx context.Context, id uint64) (*products.Product, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +136,67 @@ func (mr *MockProductMockRecorder) GetProductById(ctx, id interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductById", reflect.TypeOf((*MockProduct)(nil).GetProductById), ctx, id)
 }
 
+// GetProductsByStatus mocks base method.
+func (m *MockProduct) GetProductsByStatus(ctx context.Context, status products.Status, page, size uint64) ([]*products.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductsByStatus", ctx, status, page, size)
+	ret0, _ := ret[0].([]*products.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductsByStatus indicates an expected call of GetProductsByStatus.
+func (mr *MockProductMockRecorder) GetProductsByStatus(ctx, status, page, size interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductsByStatus", reflect.TypeOf((*MockProduct)(nil).GetProductsByStatus), ctx, status, page, size)
+}
+
+// ReleaseProduct mocks base method.
+func (m *MockProduct) ReleaseProduct(ctx context.Context, id, quantity uint64, picked []lots.Picked) (*products.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseProduct", ctx, id, quantity, picked)
+	ret0, _ := ret[0].(*products.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseProduct indicates an expected call of ReleaseProduct.
+func (mr *MockProductMockRecorder) ReleaseProduct(ctx, id, quantity, picked interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseProduct", reflect.TypeOf((*MockProduct)(nil).ReleaseProduct), ctx, id, quantity, picked)
+}
+
+// ReserveProduct mocks base method.
+func (m *MockProduct) ReserveProduct(ctx context.Context, id, quantity uint64) (*products.Product, []lots.Picked, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveProduct", ctx, id, quantity)
+	ret0, _ := ret[0].(*products.Product)
+	ret1, _ := ret[1].([]lots.Picked)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ReserveProduct indicates an expected call of ReserveProduct.
+func (mr *MockProductMockRecorder) ReserveProduct(ctx, id, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveProduct", reflect.TypeOf((*MockProduct)(nil).ReserveProduct), ctx, id, quantity)
+}
+
+// TransitionProductStatus mocks base method.
+func (m *MockProduct) TransitionProductStatus(ctx context.Context, id uint64, to products.Status, reason, actor string) (*products.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransitionProductStatus", ctx, id, to, reason, actor)
+	ret0, _ := ret[0].(*products.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransitionProductStatus indicates an expected call of TransitionProductStatus.
+func (mr *MockProductMockRecorder) TransitionProductStatus(ctx, id, to, reason, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransitionProductStatus", reflect.TypeOf((*MockProduct)(nil).TransitionProductStatus), ctx, id, to, reason, actor)
+}
+
 // UpdateProduct mocks base method.
 func (m *MockProduct) UpdateProduct(ctx context.Context, product products.Product) (*products.Product, error) {
 	m.ctrl.T.Helper()
@@ -108,3 +211,958 @@ func (mr *MockProductMockRecorder) UpdateProduct(ctx, product interface{}) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProduct", reflect.TypeOf((*MockProduct)(nil).UpdateProduct), ctx, product)
 }
+
+// MockPriceChange is a mock of PriceChange interface.
+type MockPriceChange struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceChangeMockRecorder
+}
+
+// MockPriceChangeMockRecorder is the mock recorder for MockPriceChange.
+type MockPriceChangeMockRecorder struct {
+	mock *MockPriceChange
+}
+
+// NewMockPriceChange creates a new mock instance.
+func NewMockPriceChange(ctrl *gomock.Controller) *MockPriceChange {
+	mock := &MockPriceChange{ctrl: ctrl}
+	mock.recorder = &MockPriceChangeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceChange) EXPECT() *MockPriceChangeMockRecorder {
+	return m.recorder
+}
+
+// ApprovePriceChange mocks base method.
+func (m *MockPriceChange) ApprovePriceChange(ctx context.Context, id uint64, approver string) (*changes.PriceChange, *products.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApprovePriceChange", ctx, id, approver)
+	ret0, _ := ret[0].(*changes.PriceChange)
+	ret1, _ := ret[1].(*products.Product)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ApprovePriceChange indicates an expected call of ApprovePriceChange.
+func (mr *MockPriceChangeMockRecorder) ApprovePriceChange(ctx, id, approver interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApprovePriceChange", reflect.TypeOf((*MockPriceChange)(nil).ApprovePriceChange), ctx, id, approver)
+}
+
+// CreatePriceChange mocks base method.
+func (m *MockPriceChange) CreatePriceChange(ctx context.Context, change changes.PriceChange) (*changes.PriceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePriceChange", ctx, change)
+	ret0, _ := ret[0].(*changes.PriceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePriceChange indicates an expected call of CreatePriceChange.
+func (mr *MockPriceChangeMockRecorder) CreatePriceChange(ctx, change interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePriceChange", reflect.TypeOf((*MockPriceChange)(nil).CreatePriceChange), ctx, change)
+}
+
+// GetPendingPriceChanges mocks base method.
+func (m *MockPriceChange) GetPendingPriceChanges(ctx context.Context, page, size uint64) ([]*changes.PriceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingPriceChanges", ctx, page, size)
+	ret0, _ := ret[0].([]*changes.PriceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingPriceChanges indicates an expected call of GetPendingPriceChanges.
+func (mr *MockPriceChangeMockRecorder) GetPendingPriceChanges(ctx, page, size interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingPriceChanges", reflect.TypeOf((*MockPriceChange)(nil).GetPendingPriceChanges), ctx, page, size)
+}
+
+// GetPriceChangeById mocks base method.
+func (m *MockPriceChange) GetPriceChangeById(ctx context.Context, id uint64) (*changes.PriceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPriceChangeById", ctx, id)
+	ret0, _ := ret[0].(*changes.PriceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPriceChangeById indicates an expected call of GetPriceChangeById.
+func (mr *MockPriceChangeMockRecorder) GetPriceChangeById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPriceChangeById", reflect.TypeOf((*MockPriceChange)(nil).GetPriceChangeById), ctx, id)
+}
+
+// RejectPriceChange mocks base method.
+func (m *MockPriceChange) RejectPriceChange(ctx context.Context, id uint64, approver string) (*changes.PriceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectPriceChange", ctx, id, approver)
+	ret0, _ := ret[0].(*changes.PriceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectPriceChange indicates an expected call of RejectPriceChange.
+func (mr *MockPriceChangeMockRecorder) RejectPriceChange(ctx, id, approver interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectPriceChange", reflect.TypeOf((*MockPriceChange)(nil).RejectPriceChange), ctx, id, approver)
+}
+
+// MockStock is a mock of Stock interface.
+type MockStock struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockMockRecorder
+}
+
+// MockStockMockRecorder is the mock recorder for MockStock.
+type MockStockMockRecorder struct {
+	mock *MockStock
+}
+
+// NewMockStock creates a new mock instance.
+func NewMockStock(ctrl *gomock.Controller) *MockStock {
+	mock := &MockStock{ctrl: ctrl}
+	mock.recorder = &MockStockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStock) EXPECT() *MockStockMockRecorder {
+	return m.recorder
+}
+
+// AddAlertSubscription mocks base method.
+func (m *MockStock) AddAlertSubscription(ctx context.Context, chatId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAlertSubscription", ctx, chatId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddAlertSubscription indicates an expected call of AddAlertSubscription.
+func (mr *MockStockMockRecorder) AddAlertSubscription(ctx, chatId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAlertSubscription", reflect.TypeOf((*MockStock)(nil).AddAlertSubscription), ctx, chatId)
+}
+
+// GetAlertSubscriptions mocks base method.
+func (m *MockStock) GetAlertSubscriptions(ctx context.Context) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlertSubscriptions", ctx)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlertSubscriptions indicates an expected call of GetAlertSubscriptions.
+func (mr *MockStockMockRecorder) GetAlertSubscriptions(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlertSubscriptions", reflect.TypeOf((*MockStock)(nil).GetAlertSubscriptions), ctx)
+}
+
+// GetLowStockProducts mocks base method.
+func (m *MockStock) GetLowStockProducts(ctx context.Context, page, size uint64) ([]*stock.LowStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLowStockProducts", ctx, page, size)
+	ret0, _ := ret[0].([]*stock.LowStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLowStockProducts indicates an expected call of GetLowStockProducts.
+func (mr *MockStockMockRecorder) GetLowStockProducts(ctx, page, size interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLowStockProducts", reflect.TypeOf((*MockStock)(nil).GetLowStockProducts), ctx, page, size)
+}
+
+// RemoveAlertSubscription mocks base method.
+func (m *MockStock) RemoveAlertSubscription(ctx context.Context, chatId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAlertSubscription", ctx, chatId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAlertSubscription indicates an expected call of RemoveAlertSubscription.
+func (mr *MockStockMockRecorder) RemoveAlertSubscription(ctx, chatId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAlertSubscription", reflect.TypeOf((*MockStock)(nil).RemoveAlertSubscription), ctx, chatId)
+}
+
+// SetReorderThreshold mocks base method.
+func (m *MockStock) SetReorderThreshold(ctx context.Context, productId, threshold uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReorderThreshold", ctx, productId, threshold)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetReorderThreshold indicates an expected call of SetReorderThreshold.
+func (mr *MockStockMockRecorder) SetReorderThreshold(ctx, productId, threshold interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReorderThreshold", reflect.TypeOf((*MockStock)(nil).SetReorderThreshold), ctx, productId, threshold)
+}
+
+// MockPurchase is a mock of Purchase interface.
+type MockPurchase struct {
+	ctrl     *gomock.Controller
+	recorder *MockPurchaseMockRecorder
+}
+
+// MockPurchaseMockRecorder is the mock recorder for MockPurchase.
+type MockPurchaseMockRecorder struct {
+	mock *MockPurchase
+}
+
+// NewMockPurchase creates a new mock instance.
+func NewMockPurchase(ctrl *gomock.Controller) *MockPurchase {
+	mock := &MockPurchase{ctrl: ctrl}
+	mock.recorder = &MockPurchaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPurchase) EXPECT() *MockPurchaseMockRecorder {
+	return m.recorder
+}
+
+// CreatePurchaseOrder mocks base method.
+func (m *MockPurchase) CreatePurchaseOrder(ctx context.Context, order purchases.PurchaseOrder) (*purchases.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePurchaseOrder", ctx, order)
+	ret0, _ := ret[0].(*purchases.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePurchaseOrder indicates an expected call of CreatePurchaseOrder.
+func (mr *MockPurchaseMockRecorder) CreatePurchaseOrder(ctx, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePurchaseOrder", reflect.TypeOf((*MockPurchase)(nil).CreatePurchaseOrder), ctx, order)
+}
+
+// CreateSupplier mocks base method.
+func (m *MockPurchase) CreateSupplier(ctx context.Context, supplier purchases.Supplier) (*purchases.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSupplier", ctx, supplier)
+	ret0, _ := ret[0].(*purchases.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSupplier indicates an expected call of CreateSupplier.
+func (mr *MockPurchaseMockRecorder) CreateSupplier(ctx, supplier interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSupplier", reflect.TypeOf((*MockPurchase)(nil).CreateSupplier), ctx, supplier)
+}
+
+// GetAllSuppliers mocks base method.
+func (m *MockPurchase) GetAllSuppliers(ctx context.Context, page, size uint64) ([]*purchases.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllSuppliers", ctx, page, size)
+	ret0, _ := ret[0].([]*purchases.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllSuppliers indicates an expected call of GetAllSuppliers.
+func (mr *MockPurchaseMockRecorder) GetAllSuppliers(ctx, page, size interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllSuppliers", reflect.TypeOf((*MockPurchase)(nil).GetAllSuppliers), ctx, page, size)
+}
+
+// GetOpenPurchaseOrders mocks base method.
+func (m *MockPurchase) GetOpenPurchaseOrders(ctx context.Context, page, size uint64) ([]*purchases.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenPurchaseOrders", ctx, page, size)
+	ret0, _ := ret[0].([]*purchases.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenPurchaseOrders indicates an expected call of GetOpenPurchaseOrders.
+func (mr *MockPurchaseMockRecorder) GetOpenPurchaseOrders(ctx, page, size interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenPurchaseOrders", reflect.TypeOf((*MockPurchase)(nil).GetOpenPurchaseOrders), ctx, page, size)
+}
+
+// GetPurchaseOrderById mocks base method.
+func (m *MockPurchase) GetPurchaseOrderById(ctx context.Context, id uint64) (*purchases.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPurchaseOrderById", ctx, id)
+	ret0, _ := ret[0].(*purchases.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPurchaseOrderById indicates an expected call of GetPurchaseOrderById.
+func (mr *MockPurchaseMockRecorder) GetPurchaseOrderById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurchaseOrderById", reflect.TypeOf((*MockPurchase)(nil).GetPurchaseOrderById), ctx, id)
+}
+
+// GetSupplierById mocks base method.
+func (m *MockPurchase) GetSupplierById(ctx context.Context, id uint64) (*purchases.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSupplierById", ctx, id)
+	ret0, _ := ret[0].(*purchases.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSupplierById indicates an expected call of GetSupplierById.
+func (mr *MockPurchaseMockRecorder) GetSupplierById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSupplierById", reflect.TypeOf((*MockPurchase)(nil).GetSupplierById), ctx, id)
+}
+
+// ReceivePurchaseOrder mocks base method.
+func (m *MockPurchase) ReceivePurchaseOrder(ctx context.Context, id uint64, receipts map[uint64]uint64) (*purchases.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReceivePurchaseOrder", ctx, id, receipts)
+	ret0, _ := ret[0].(*purchases.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReceivePurchaseOrder indicates an expected call of ReceivePurchaseOrder.
+func (mr *MockPurchaseMockRecorder) ReceivePurchaseOrder(ctx, id, receipts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceivePurchaseOrder", reflect.TypeOf((*MockPurchase)(nil).ReceivePurchaseOrder), ctx, id, receipts)
+}
+
+// MockLot is a mock of Lot interface.
+type MockLot struct {
+	ctrl     *gomock.Controller
+	recorder *MockLotMockRecorder
+}
+
+// MockLotMockRecorder is the mock recorder for MockLot.
+type MockLotMockRecorder struct {
+	mock *MockLot
+}
+
+// NewMockLot creates a new mock instance.
+func NewMockLot(ctrl *gomock.Controller) *MockLot {
+	mock := &MockLot{ctrl: ctrl}
+	mock.recorder = &MockLotMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLot) EXPECT() *MockLotMockRecorder {
+	return m.recorder
+}
+
+// AddLot mocks base method.
+func (m *MockLot) AddLot(ctx context.Context, lot lots.Lot) (*lots.Lot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddLot", ctx, lot)
+	ret0, _ := ret[0].(*lots.Lot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddLot indicates an expected call of AddLot.
+func (mr *MockLotMockRecorder) AddLot(ctx, lot interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLot", reflect.TypeOf((*MockLot)(nil).AddLot), ctx, lot)
+}
+
+// GetExpiringLots mocks base method.
+func (m *MockLot) GetExpiringLots(ctx context.Context, before time.Time, page, size uint64) ([]*lots.Lot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiringLots", ctx, before, page, size)
+	ret0, _ := ret[0].([]*lots.Lot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiringLots indicates an expected call of GetExpiringLots.
+func (mr *MockLotMockRecorder) GetExpiringLots(ctx, before, page, size interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiringLots", reflect.TypeOf((*MockLot)(nil).GetExpiringLots), ctx, before, page, size)
+}
+
+// GetProductLots mocks base method.
+func (m *MockLot) GetProductLots(ctx context.Context, productId uint64) ([]*lots.Lot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductLots", ctx, productId)
+	ret0, _ := ret[0].([]*lots.Lot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductLots indicates an expected call of GetProductLots.
+func (mr *MockLotMockRecorder) GetProductLots(ctx, productId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductLots", reflect.TypeOf((*MockLot)(nil).GetProductLots), ctx, productId)
+}
+
+// MockStocktake is a mock of Stocktake interface.
+type MockStocktake struct {
+	ctrl     *gomock.Controller
+	recorder *MockStocktakeMockRecorder
+}
+
+// MockStocktakeMockRecorder is the mock recorder for MockStocktake.
+type MockStocktakeMockRecorder struct {
+	mock *MockStocktake
+}
+
+// NewMockStocktake creates a new mock instance.
+func NewMockStocktake(ctrl *gomock.Controller) *MockStocktake {
+	mock := &MockStocktake{ctrl: ctrl}
+	mock.recorder = &MockStocktakeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStocktake) EXPECT() *MockStocktakeMockRecorder {
+	return m.recorder
+}
+
+// CommitStocktake mocks base method.
+func (m *MockStocktake) CommitStocktake(ctx context.Context, id uint64, actor string) (*stocktakes.Session, []*stocktakes.Adjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitStocktake", ctx, id, actor)
+	ret0, _ := ret[0].(*stocktakes.Session)
+	ret1, _ := ret[1].([]*stocktakes.Adjustment)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CommitStocktake indicates an expected call of CommitStocktake.
+func (mr *MockStocktakeMockRecorder) CommitStocktake(ctx, id, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitStocktake", reflect.TypeOf((*MockStocktake)(nil).CommitStocktake), ctx, id, actor)
+}
+
+// GetStocktakeById mocks base method.
+func (m *MockStocktake) GetStocktakeById(ctx context.Context, id uint64) (*stocktakes.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStocktakeById", ctx, id)
+	ret0, _ := ret[0].(*stocktakes.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStocktakeById indicates an expected call of GetStocktakeById.
+func (mr *MockStocktakeMockRecorder) GetStocktakeById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStocktakeById", reflect.TypeOf((*MockStocktake)(nil).GetStocktakeById), ctx, id)
+}
+
+// GetStocktakeVariances mocks base method.
+func (m *MockStocktake) GetStocktakeVariances(ctx context.Context, id uint64) ([]*stocktakes.Variance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStocktakeVariances", ctx, id)
+	ret0, _ := ret[0].([]*stocktakes.Variance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStocktakeVariances indicates an expected call of GetStocktakeVariances.
+func (mr *MockStocktakeMockRecorder) GetStocktakeVariances(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStocktakeVariances", reflect.TypeOf((*MockStocktake)(nil).GetStocktakeVariances), ctx, id)
+}
+
+// OpenStocktake mocks base method.
+func (m *MockStocktake) OpenStocktake(ctx context.Context, session stocktakes.Session) (*stocktakes.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenStocktake", ctx, session)
+	ret0, _ := ret[0].(*stocktakes.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenStocktake indicates an expected call of OpenStocktake.
+func (mr *MockStocktakeMockRecorder) OpenStocktake(ctx, session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenStocktake", reflect.TypeOf((*MockStocktake)(nil).OpenStocktake), ctx, session)
+}
+
+// SubmitCount mocks base method.
+func (m *MockStocktake) SubmitCount(ctx context.Context, count stocktakes.Count) (*stocktakes.Count, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitCount", ctx, count)
+	ret0, _ := ret[0].(*stocktakes.Count)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitCount indicates an expected call of SubmitCount.
+func (mr *MockStocktakeMockRecorder) SubmitCount(ctx, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitCount", reflect.TypeOf((*MockStocktake)(nil).SubmitCount), ctx, count)
+}
+
+// MockTranslation is a mock of Translation interface.
+type MockTranslation struct {
+	ctrl     *gomock.Controller
+	recorder *MockTranslationMockRecorder
+}
+
+// MockTranslationMockRecorder is the mock recorder for MockTranslation.
+type MockTranslationMockRecorder struct {
+	mock *MockTranslation
+}
+
+// NewMockTranslation creates a new mock instance.
+func NewMockTranslation(ctrl *gomock.Controller) *MockTranslation {
+	mock := &MockTranslation{ctrl: ctrl}
+	mock.recorder = &MockTranslationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTranslation) EXPECT() *MockTranslationMockRecorder {
+	return m.recorder
+}
+
+// GetTranslations mocks base method.
+func (m *MockTranslation) GetTranslations(ctx context.Context, productIds []uint64, locale locales.Locale) ([]*products.Translation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTranslations", ctx, productIds, locale)
+	ret0, _ := ret[0].([]*products.Translation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTranslations indicates an expected call of GetTranslations.
+func (mr *MockTranslationMockRecorder) GetTranslations(ctx, productIds, locale interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTranslations", reflect.TypeOf((*MockTranslation)(nil).GetTranslations), ctx, productIds, locale)
+}
+
+// SetTranslation mocks base method.
+func (m *MockTranslation) SetTranslation(ctx context.Context, translation products.Translation) (*products.Translation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTranslation", ctx, translation)
+	ret0, _ := ret[0].(*products.Translation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetTranslation indicates an expected call of SetTranslation.
+func (mr *MockTranslationMockRecorder) SetTranslation(ctx, translation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTranslation", reflect.TypeOf((*MockTranslation)(nil).SetTranslation), ctx, translation)
+}
+
+// MockImage is a mock of Image interface.
+type MockImage struct {
+	ctrl     *gomock.Controller
+	recorder *MockImageMockRecorder
+}
+
+// MockImageMockRecorder is the mock recorder for MockImage.
+type MockImageMockRecorder struct {
+	mock *MockImage
+}
+
+// NewMockImage creates a new mock instance.
+func NewMockImage(ctrl *gomock.Controller) *MockImage {
+	mock := &MockImage{ctrl: ctrl}
+	mock.recorder = &MockImageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImage) EXPECT() *MockImageMockRecorder {
+	return m.recorder
+}
+
+// AddImage mocks base method.
+func (m *MockImage) AddImage(ctx context.Context, image images.Image) (*images.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddImage", ctx, image)
+	ret0, _ := ret[0].(*images.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddImage indicates an expected call of AddImage.
+func (mr *MockImageMockRecorder) AddImage(ctx, image interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddImage", reflect.TypeOf((*MockImage)(nil).AddImage), ctx, image)
+}
+
+// GetImageById mocks base method.
+func (m *MockImage) GetImageById(ctx context.Context, id uint64) (*images.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImageById", ctx, id)
+	ret0, _ := ret[0].(*images.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImageById indicates an expected call of GetImageById.
+func (mr *MockImageMockRecorder) GetImageById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImageById", reflect.TypeOf((*MockImage)(nil).GetImageById), ctx, id)
+}
+
+// GetProductImages mocks base method.
+func (m *MockImage) GetProductImages(ctx context.Context, productId uint64) ([]*images.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductImages", ctx, productId)
+	ret0, _ := ret[0].([]*images.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductImages indicates an expected call of GetProductImages.
+func (mr *MockImageMockRecorder) GetProductImages(ctx, productId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductImages", reflect.TypeOf((*MockImage)(nil).GetProductImages), ctx, productId)
+}
+
+// MockRelation is a mock of Relation interface.
+type MockRelation struct {
+	ctrl     *gomock.Controller
+	recorder *MockRelationMockRecorder
+}
+
+// MockRelationMockRecorder is the mock recorder for MockRelation.
+type MockRelationMockRecorder struct {
+	mock *MockRelation
+}
+
+// NewMockRelation creates a new mock instance.
+func NewMockRelation(ctrl *gomock.Controller) *MockRelation {
+	mock := &MockRelation{ctrl: ctrl}
+	mock.recorder = &MockRelationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRelation) EXPECT() *MockRelationMockRecorder {
+	return m.recorder
+}
+
+// CountProductRelations mocks base method.
+func (m *MockRelation) CountProductRelations(ctx context.Context, productId uint64) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountProductRelations", ctx, productId)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountProductRelations indicates an expected call of CountProductRelations.
+func (mr *MockRelationMockRecorder) CountProductRelations(ctx, productId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountProductRelations", reflect.TypeOf((*MockRelation)(nil).CountProductRelations), ctx, productId)
+}
+
+// CreateRelation mocks base method.
+func (m *MockRelation) CreateRelation(ctx context.Context, relation relations.Relation) (*relations.Relation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRelation", ctx, relation)
+	ret0, _ := ret[0].(*relations.Relation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRelation indicates an expected call of CreateRelation.
+func (mr *MockRelationMockRecorder) CreateRelation(ctx, relation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRelation", reflect.TypeOf((*MockRelation)(nil).CreateRelation), ctx, relation)
+}
+
+// DeleteRelation mocks base method.
+func (m *MockRelation) DeleteRelation(ctx context.Context, productId, relatedId uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRelation", ctx, productId, relatedId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRelation indicates an expected call of DeleteRelation.
+func (mr *MockRelationMockRecorder) DeleteRelation(ctx, productId, relatedId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRelation", reflect.TypeOf((*MockRelation)(nil).DeleteRelation), ctx, productId, relatedId)
+}
+
+// GetProductRelations mocks base method.
+func (m *MockRelation) GetProductRelations(ctx context.Context, productId uint64) ([]*relations.Relation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductRelations", ctx, productId)
+	ret0, _ := ret[0].([]*relations.Relation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductRelations indicates an expected call of GetProductRelations.
+func (mr *MockRelationMockRecorder) GetProductRelations(ctx, productId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductRelations", reflect.TypeOf((*MockRelation)(nil).GetProductRelations), ctx, productId)
+}
+
+// GetRelatedProducts mocks base method.
+func (m *MockRelation) GetRelatedProducts(ctx context.Context, productId uint64, relationType relations.Type) ([]*relations.RelatedProduct, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRelatedProducts", ctx, productId, relationType)
+	ret0, _ := ret[0].([]*relations.RelatedProduct)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRelatedProducts indicates an expected call of GetRelatedProducts.
+func (mr *MockRelationMockRecorder) GetRelatedProducts(ctx, productId, relationType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRelatedProducts", reflect.TypeOf((*MockRelation)(nil).GetRelatedProducts), ctx, productId, relationType)
+}
+
+// UpdateRelation mocks base method.
+func (m *MockRelation) UpdateRelation(ctx context.Context, relation relations.Relation) (*relations.Relation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRelation", ctx, relation)
+	ret0, _ := ret[0].(*relations.Relation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRelation indicates an expected call of UpdateRelation.
+func (mr *MockRelationMockRecorder) UpdateRelation(ctx, relation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRelation", reflect.TypeOf((*MockRelation)(nil).UpdateRelation), ctx, relation)
+}
+
+// MockExchangeRate is a mock of ExchangeRate interface.
+type MockExchangeRate struct {
+	ctrl     *gomock.Controller
+	recorder *MockExchangeRateMockRecorder
+}
+
+// MockExchangeRateMockRecorder is the mock recorder for MockExchangeRate.
+type MockExchangeRateMockRecorder struct {
+	mock *MockExchangeRate
+}
+
+// NewMockExchangeRate creates a new mock instance.
+func NewMockExchangeRate(ctrl *gomock.Controller) *MockExchangeRate {
+	mock := &MockExchangeRate{ctrl: ctrl}
+	mock.recorder = &MockExchangeRateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExchangeRate) EXPECT() *MockExchangeRateMockRecorder {
+	return m.recorder
+}
+
+// GetExchangeRate mocks base method.
+func (m *MockExchangeRate) GetExchangeRate(ctx context.Context, currency money.Currency) (*money.Rate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExchangeRate", ctx, currency)
+	ret0, _ := ret[0].(*money.Rate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExchangeRate indicates an expected call of GetExchangeRate.
+func (mr *MockExchangeRateMockRecorder) GetExchangeRate(ctx, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExchangeRate", reflect.TypeOf((*MockExchangeRate)(nil).GetExchangeRate), ctx, currency)
+}
+
+// GetExchangeRates mocks base method.
+func (m *MockExchangeRate) GetExchangeRates(ctx context.Context) ([]*money.Rate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExchangeRates", ctx)
+	ret0, _ := ret[0].([]*money.Rate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExchangeRates indicates an expected call of GetExchangeRates.
+func (mr *MockExchangeRateMockRecorder) GetExchangeRates(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExchangeRates", reflect.TypeOf((*MockExchangeRate)(nil).GetExchangeRates), ctx)
+}
+
+// SetExchangeRate mocks base method.
+func (m *MockExchangeRate) SetExchangeRate(ctx context.Context, rate money.Rate) (*money.Rate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetExchangeRate", ctx, rate)
+	ret0, _ := ret[0].(*money.Rate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetExchangeRate indicates an expected call of SetExchangeRate.
+func (mr *MockExchangeRateMockRecorder) SetExchangeRate(ctx, rate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExchangeRate", reflect.TypeOf((*MockExchangeRate)(nil).SetExchangeRate), ctx, rate)
+}
+
+// MockGrant is a mock of Grant interface.
+type MockGrant struct {
+	ctrl     *gomock.Controller
+	recorder *MockGrantMockRecorder
+}
+
+// MockGrantMockRecorder is the mock recorder for MockGrant.
+type MockGrantMockRecorder struct {
+	mock *MockGrant
+}
+
+// NewMockGrant creates a new mock instance.
+func NewMockGrant(ctrl *gomock.Controller) *MockGrant {
+	mock := &MockGrant{ctrl: ctrl}
+	mock.recorder = &MockGrantMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGrant) EXPECT() *MockGrantMockRecorder {
+	return m.recorder
+}
+
+// DeleteGrant mocks base method.
+func (m *MockGrant) DeleteGrant(ctx context.Context, userId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGrant", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGrant indicates an expected call of DeleteGrant.
+func (mr *MockGrantMockRecorder) DeleteGrant(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGrant", reflect.TypeOf((*MockGrant)(nil).DeleteGrant), ctx, userId)
+}
+
+// GetGrant mocks base method.
+func (m *MockGrant) GetGrant(ctx context.Context, userId int64) (*grants.Grant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGrant", ctx, userId)
+	ret0, _ := ret[0].(*grants.Grant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGrant indicates an expected call of GetGrant.
+func (mr *MockGrantMockRecorder) GetGrant(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGrant", reflect.TypeOf((*MockGrant)(nil).GetGrant), ctx, userId)
+}
+
+// GetGrants mocks base method.
+func (m *MockGrant) GetGrants(ctx context.Context) ([]*grants.Grant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGrants", ctx)
+	ret0, _ := ret[0].([]*grants.Grant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGrants indicates an expected call of GetGrants.
+func (mr *MockGrantMockRecorder) GetGrants(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGrants", reflect.TypeOf((*MockGrant)(nil).GetGrants), ctx)
+}
+
+// SetGrant mocks base method.
+func (m *MockGrant) SetGrant(ctx context.Context, grant grants.Grant) (*grants.Grant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetGrant", ctx, grant)
+	ret0, _ := ret[0].(*grants.Grant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetGrant indicates an expected call of SetGrant.
+func (mr *MockGrantMockRecorder) SetGrant(ctx, grant interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGrant", reflect.TypeOf((*MockGrant)(nil).SetGrant), ctx, grant)
+}
+
+// MockReport is a mock of Report interface.
+type MockReport struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportMockRecorder
+}
+
+// MockReportMockRecorder is the mock recorder for MockReport.
+type MockReportMockRecorder struct {
+	mock *MockReport
+}
+
+// NewMockReport creates a new mock instance.
+func NewMockReport(ctrl *gomock.Controller) *MockReport {
+	mock := &MockReport{ctrl: ctrl}
+	mock.recorder = &MockReportMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReport) EXPECT() *MockReportMockRecorder {
+	return m.recorder
+}
+
+// GetStockValuation mocks base method.
+func (m *MockReport) GetStockValuation(ctx context.Context) (*reports.Valuation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStockValuation", ctx)
+	ret0, _ := ret[0].(*reports.Valuation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStockValuation indicates an expected call of GetStockValuation.
+func (mr *MockReportMockRecorder) GetStockValuation(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStockValuation", reflect.TypeOf((*MockReport)(nil).GetStockValuation), ctx)
+}
+
+// GetTopProductsByValue mocks base method.
+func (m *MockReport) GetTopProductsByValue(ctx context.Context, limit uint64) ([]*reports.ProductValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTopProductsByValue", ctx, limit)
+	ret0, _ := ret[0].([]*reports.ProductValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTopProductsByValue indicates an expected call of GetTopProductsByValue.
+func (mr *MockReportMockRecorder) GetTopProductsByValue(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopProductsByValue", reflect.TypeOf((*MockReport)(nil).GetTopProductsByValue), ctx, limit)
+}
+
+// GetValueByCategory mocks base method.
+func (m *MockReport) GetValueByCategory(ctx context.Context) ([]*reports.CategoryValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValueByCategory", ctx)
+	ret0, _ := ret[0].([]*reports.CategoryValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValueByCategory indicates an expected call of GetValueByCategory.
+func (mr *MockReportMockRecorder) GetValueByCategory(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValueByCategory", reflect.TypeOf((*MockReport)(nil).GetValueByCategory), ctx)
+}
+
+// GetValueByStatus mocks base method.
+func (m *MockReport) GetValueByStatus(ctx context.Context) ([]*reports.StatusValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValueByStatus", ctx)
+	ret0, _ := ret[0].([]*reports.StatusValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValueByStatus indicates an expected call of GetValueByStatus.
+func (mr *MockReportMockRecorder) GetValueByStatus(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValueByStatus", reflect.TypeOf((*MockReport)(nil).GetValueByStatus), ctx)
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/pkg/errors"
+	"homework-1/internal/money"
+	"homework-1/internal/repository"
+	"homework-1/internal/tenants"
+)
+
+const exchangeRateColumns = "currency, rate, updated_at"
+
+func (r *Repository) SetExchangeRate(ctx context.Context, rate money.Rate) (*money.Rate, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := psql.Insert("exchange_rates").
+		Columns("currency, rate, tenant_id").
+		Values(rate.Currency, rate.Value, tenant).
+		Suffix("ON CONFLICT (tenant_id, currency) DO UPDATE SET rate = EXCLUDED.rate, updated_at = now() RETURNING updated_at").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.SetExchangeRate: to sql: %w", err)
+	}
+
+	if err = r.pool.QueryRow(ctx, query, args...).Scan(&rate.UpdatedAt); err != nil {
+		return nil, fmt.Errorf("Repository.SetExchangeRate: insert: %w", err)
+	}
+
+	return &rate, nil
+}
+
+func (r *Repository) GetExchangeRate(ctx context.Context, currency money.Currency) (*money.Rate, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := psql.Select(exchangeRateColumns).
+		From("exchange_rates").
+		Where(squirrel.Eq{"currency": currency, "tenant_id": tenant}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetExchangeRate: to sql: %w", err)
+	}
+
+	var rate money.Rate
+	if err = pgxscan.Get(ctx, r.pool, &rate, query, args...); err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, errors.Wrap(repository.ExchangeRateNotExists, currency.String())
+		}
+		return nil, fmt.Errorf("Repository.GetExchangeRate: select: %w", err)
+	}
+
+	return &rate, nil
+}
+
+func (r *Repository) GetExchangeRates(ctx context.Context) ([]*money.Rate, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := psql.Select(exchangeRateColumns).
+		From("exchange_rates").
+		Where(squirrel.Eq{"tenant_id": tenant}).
+		OrderBy("currency").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetExchangeRates: to sql: %w", err)
+	}
+
+	var rates []*money.Rate
+	if err = pgxscan.Select(ctx, r.pool, &rates, query, args...); err != nil {
+		return nil, fmt.Errorf("Repository.GetExchangeRates: select: %w", err)
+	}
+
+	return rates, nil
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"homework-1/internal/money"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestSetExchangeRate(t *testing.T) {
+	t.Run("success setting rate", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		updatedAt := time.Date(2022, 10, 3, 0, 0, 0, 0, time.UTC)
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`INSERT INTO exchange_rates (currency, rate, tenant_id) VALUES ($1,$2,$3) ON CONFLICT (tenant_id, currency) DO UPDATE SET rate = EXCLUDED.rate, updated_at = now() RETURNING updated_at`)).
+			WithArgs(money.Currency("USD"), uint64(13725), testTenant).
+			WillReturnRows(pgxmock.NewRows([]string{"updated_at"}).AddRow(updatedAt))
+
+		// act
+		res, err := f.rateRepo.SetExchangeRate(f.ctx, money.Rate{Currency: "USD", Value: uint64(13725)})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &money.Rate{Currency: "USD", Value: uint64(13725), UpdatedAt: updatedAt})
+	})
+}
+
+func TestGetExchangeRate(t *testing.T) {
+	t.Run("rate does not exist", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT currency, rate, updated_at FROM exchange_rates WHERE currency = $1 AND tenant_id = $2`)).
+			WithArgs(money.Currency("USD"), testTenant).
+			WillReturnRows(pgxmock.NewRows([]string{"currency", "rate", "updated_at"}))
+
+		// act
+		_, err := f.rateRepo.GetExchangeRate(f.ctx, "USD")
+
+		// assert
+		assert.EqualError(t, err, "USD: exchange rate does not exist")
+	})
+}
+
+func TestGetExchangeRates(t *testing.T) {
+	t.Run("success getting rates", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		updatedAt := time.Date(2022, 10, 3, 0, 0, 0, 0, time.UTC)
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT currency, rate, updated_at FROM exchange_rates WHERE tenant_id = $1 ORDER BY currency`)).
+			WithArgs(testTenant).
+			WillReturnRows(pgxmock.NewRows([]string{"currency", "rate", "updated_at"}).
+				AddRow(money.Currency("EUR"), uint64(14010), updatedAt).
+				AddRow(money.Currency("USD"), uint64(13725), updatedAt))
+
+		// act
+		res, err := f.rateRepo.GetExchangeRates(f.ctx)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, []*money.Rate{
+			{Currency: "EUR", Value: uint64(14010), UpdatedAt: updatedAt},
+			{Currency: "USD", Value: uint64(13725), UpdatedAt: updatedAt},
+		})
+	})
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/pkg/errors"
+	"homework-1/internal/models/grants"
+	"homework-1/internal/repository"
+	"strconv"
+)
+
+const grantColumns = "user_id, role, granted_by, granted_at"
+
+func (r *Repository) SetGrant(ctx context.Context, grant grants.Grant) (*grants.Grant, error) {
+	query, args, err := psql.Insert("bot_grants").
+		Columns("user_id, role, granted_by").
+		Values(grant.UserId, grant.Role, grant.GrantedBy).
+		Suffix("ON CONFLICT (user_id) DO UPDATE SET role = EXCLUDED.role, granted_by = EXCLUDED.granted_by, granted_at = now() RETURNING granted_at").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.SetGrant: to sql: %w", err)
+	}
+
+	if err = r.pool.QueryRow(ctx, query, args...).Scan(&grant.GrantedAt); err != nil {
+		return nil, fmt.Errorf("Repository.SetGrant: insert: %w", err)
+	}
+
+	return &grant, nil
+}
+
+func (r *Repository) GetGrant(ctx context.Context, userId int64) (*grants.Grant, error) {
+	query, args, err := psql.Select(grantColumns).
+		From("bot_grants").
+		Where(squirrel.Eq{"user_id": userId}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetGrant: to sql: %w", err)
+	}
+
+	var grant grants.Grant
+	if err = pgxscan.Get(ctx, r.pool, &grant, query, args...); err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, errors.Wrap(repository.GrantNotExists, strconv.FormatInt(userId, 10))
+		}
+		return nil, fmt.Errorf("Repository.GetGrant: select: %w", err)
+	}
+
+	return &grant, nil
+}
+
+func (r *Repository) DeleteGrant(ctx context.Context, userId int64) error {
+	query, args, err := psql.Delete("bot_grants").
+		Where(squirrel.Eq{"user_id": userId}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Repository.DeleteGrant: to sql: %w", err)
+	}
+
+	tag, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("Repository.DeleteGrant: delete: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.Wrap(repository.GrantNotExists, strconv.FormatInt(userId, 10))
+	}
+	return nil
+}
+
+func (r *Repository) GetGrants(ctx context.Context) ([]*grants.Grant, error) {
+	query, args, err := psql.Select(grantColumns).
+		From("bot_grants").
+		OrderBy("user_id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetGrants: to sql: %w", err)
+	}
+
+	var result []*grants.Grant
+	if err = pgxscan.Select(ctx, r.pool, &result, query, args...); err != nil {
+		return nil, fmt.Errorf("Repository.GetGrants: select: %w", err)
+	}
+
+	return result, nil
+}
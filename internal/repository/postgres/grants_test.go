@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"homework-1/internal/models/grants"
+	"homework-1/internal/policy"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestSetGrant(t *testing.T) {
+	t.Run("success setting grant", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		grantedAt := time.Date(2022, 10, 7, 0, 0, 0, 0, time.UTC)
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`INSERT INTO bot_grants (user_id, role, granted_by) VALUES ($1,$2,$3) ON CONFLICT (user_id) DO UPDATE SET role = EXCLUDED.role, granted_by = EXCLUDED.granted_by, granted_at = now() RETURNING granted_at`)).
+			WithArgs(int64(42), policy.Editor, "telegram:7").
+			WillReturnRows(pgxmock.NewRows([]string{"granted_at"}).AddRow(grantedAt))
+
+		// act
+		res, err := f.grantRepo.SetGrant(f.ctx, grants.Grant{UserId: 42, Role: policy.Editor, GrantedBy: "telegram:7"})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &grants.Grant{UserId: 42, Role: policy.Editor, GrantedBy: "telegram:7", GrantedAt: grantedAt})
+	})
+}
+
+func TestGetGrant(t *testing.T) {
+	t.Run("grant does not exist", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT user_id, role, granted_by, granted_at FROM bot_grants WHERE user_id = $1`)).
+			WithArgs(int64(42)).
+			WillReturnRows(pgxmock.NewRows([]string{"user_id", "role", "granted_by", "granted_at"}))
+
+		// act
+		_, err := f.grantRepo.GetGrant(f.ctx, 42)
+
+		// assert
+		assert.EqualError(t, err, "42: grant does not exist")
+	})
+}
+
+func TestDeleteGrant(t *testing.T) {
+	t.Run("grant does not exist", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectExec(regexp.QuoteMeta(`DELETE FROM bot_grants WHERE user_id = $1`)).
+			WithArgs(int64(42)).
+			WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+		// act
+		err := f.grantRepo.DeleteGrant(f.ctx, 42)
+
+		// assert
+		assert.EqualError(t, err, "42: grant does not exist")
+	})
+}
+
+func TestGetGrants(t *testing.T) {
+	t.Run("success getting grants", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		grantedAt := time.Date(2022, 10, 7, 0, 0, 0, 0, time.UTC)
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT user_id, role, granted_by, granted_at FROM bot_grants ORDER BY user_id`)).
+			WillReturnRows(pgxmock.NewRows([]string{"user_id", "role", "granted_by", "granted_at"}).
+				AddRow(int64(7), policy.Admin, "config", grantedAt).
+				AddRow(int64(42), policy.Viewer, "telegram:7", grantedAt))
+
+		// act
+		res, err := f.grantRepo.GetGrants(f.ctx)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, []*grants.Grant{
+			{UserId: 7, Role: policy.Admin, GrantedBy: "config", GrantedAt: grantedAt},
+			{UserId: 42, Role: policy.Viewer, GrantedBy: "telegram:7", GrantedAt: grantedAt},
+		})
+	})
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/pkg/errors"
+	"homework-1/internal/models/images"
+	"homework-1/internal/repository"
+	"homework-1/internal/tenants"
+	"strconv"
+)
+
+const imageColumns = "id, product_id, key, content_type, size, checksum, created_at"
+
+func (r *Repository) AddImage(ctx context.Context, image images.Image) (*images.Image, error) {
+	if err := r.checkProducts(ctx, r.pool, image.ProductId); err != nil {
+		return nil, err
+	}
+
+	query, args, err := psql.Insert("product_images").
+		Columns("product_id, key, content_type, size, checksum").
+		Values(image.ProductId, image.Key, image.ContentType, image.Size, image.Checksum).
+		Suffix("RETURNING id, created_at").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.AddImage: to sql: %w", err)
+	}
+
+	if err = r.pool.QueryRow(ctx, query, args...).Scan(&image.Id, &image.CreatedAt); err != nil {
+		if isForeignKeyViolation(err) {
+			return nil, errors.Wrap(repository.ProductNotExists, strconv.FormatUint(image.ProductId, 10))
+		}
+		return nil, fmt.Errorf("Repository.AddImage: insert: %w", err)
+	}
+
+	return &image, nil
+}
+
+func (r *Repository) GetImageById(ctx context.Context, id uint64) (*images.Image, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetImageById: %w", err)
+	}
+
+	query, args, err := psql.Select(imageColumns).
+		From("product_images").
+		Where(squirrel.Eq{"id": id}).
+		Where(ofTenantProducts("product_id", tenant)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetImageById: to sql: %w", err)
+	}
+
+	var image images.Image
+	if err = pgxscan.Get(ctx, r.pool, &image, query, args...); err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, errors.Wrap(repository.ImageNotExists, strconv.FormatUint(id, 10))
+		}
+		return nil, fmt.Errorf("Repository.GetImageById: select: %w", err)
+	}
+
+	return &image, nil
+}
+
+func (r *Repository) GetProductImages(ctx context.Context, productId uint64) ([]*images.Image, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetProductImages: %w", err)
+	}
+
+	query, args, err := psql.Select(imageColumns).
+		From("product_images").
+		Where(squirrel.Eq{"product_id": productId}).
+		Where(ofTenantProducts("product_id", tenant)).
+		OrderBy("id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetProductImages: to sql: %w", err)
+	}
+
+	var productImages []*images.Image
+	if err = pgxscan.Select(ctx, r.pool, &productImages, query, args...); err != nil {
+		return nil, fmt.Errorf("Repository.GetProductImages: select: %w", err)
+	}
+
+	return productImages, nil
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"github.com/jackc/pgconn"
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"homework-1/internal/models/images"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestAddImage(t *testing.T) {
+	t.Run("success adding image", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		createdAt := time.Date(2022, 9, 28, 0, 0, 0, 0, time.UTC)
+		f.expectProducts([]uint64{1}, 1)
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`INSERT INTO product_images (product_id, key, content_type, size, checksum) VALUES ($1,$2,$3,$4,$5) RETURNING id, created_at`)).
+			WithArgs(uint64(1), "products/1/a", "image/png", uint64(3), "abc").
+			WillReturnRows(pgxmock.NewRows([]string{"id", "created_at"}).AddRow(uint64(1), createdAt))
+
+		// act
+		res, err := f.imageRepo.AddImage(f.ctx, images.Image{
+			ProductId:   uint64(1),
+			Key:         "products/1/a",
+			ContentType: "image/png",
+			Size:        uint64(3),
+			Checksum:    "abc",
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res.Id, uint64(1))
+		assert.Equal(t, res.CreatedAt, createdAt)
+	})
+
+	t.Run("product does not exist", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.expectProducts([]uint64{1}, 1)
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`INSERT INTO product_images`)).
+			WithArgs(uint64(1), "products/1/a", "image/png", uint64(3), "abc").
+			WillReturnError(&pgconn.PgError{Code: foreignKeyViolation})
+
+		// act
+		_, err := f.imageRepo.AddImage(f.ctx, images.Image{
+			ProductId:   uint64(1),
+			Key:         "products/1/a",
+			ContentType: "image/png",
+			Size:        uint64(3),
+			Checksum:    "abc",
+		})
+
+		// assert
+		assert.EqualError(t, err, "1: product does not exist")
+	})
+
+	t.Run("product of another tenant", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.expectProducts([]uint64{1})
+
+		// act
+		_, err := f.imageRepo.AddImage(f.ctx, images.Image{ProductId: uint64(1), Key: "products/1/a", ContentType: "image/png"})
+
+		// assert
+		assert.EqualError(t, err, "1: product does not exist")
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestGetImageById(t *testing.T) {
+	t.Run("image does not exist", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, product_id, key, content_type, size, checksum, created_at FROM product_images WHERE id = $1 AND product_id IN (SELECT id FROM products WHERE tenant_id = $2)`)).
+			WithArgs(uint64(1), testTenant).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "product_id", "key", "content_type", "size", "checksum", "created_at"}))
+
+		// act
+		_, err := f.imageRepo.GetImageById(f.ctx, uint64(1))
+
+		// assert
+		assert.EqualError(t, err, "1: image does not exist")
+	})
+}
+
+func TestGetProductImages(t *testing.T) {
+	t.Run("success getting images", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		createdAt := time.Date(2022, 9, 28, 0, 0, 0, 0, time.UTC)
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, product_id, key, content_type, size, checksum, created_at FROM product_images WHERE product_id = $1 AND product_id IN (SELECT id FROM products WHERE tenant_id = $2) ORDER BY id`)).
+			WithArgs(uint64(1), testTenant).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "product_id", "key", "content_type", "size", "checksum", "created_at"}).
+				AddRow(uint64(1), uint64(1), "products/1/a", "image/png", uint64(3), "abc", createdAt))
+
+		// act
+		res, err := f.imageRepo.GetProductImages(f.ctx, uint64(1))
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, []*images.Image{{
+			Id:          uint64(1),
+			ProductId:   uint64(1),
+			Key:         "products/1/a",
+			ContentType: "image/png",
+			Size:        uint64(3),
+			Checksum:    "abc",
+			CreatedAt:   createdAt,
+		}})
+	})
+}
@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
+	"github.com/pkg/errors"
+	"homework-1/internal/models/lots"
+	"homework-1/internal/repository"
+	"homework-1/internal/tenants"
+	"strconv"
+	"time"
+)
+
+const lotColumns = "id, product_id, number, quantity, expires_at"
+
+func (r *Repository) AddLot(ctx context.Context, lot lots.Lot) (*lots.Lot, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.AddLot: begin: %w", err)
+	}
+	defer tx.Rollback(ctx) // no-op after commit
+
+	if _, err = r.getProductForUpdate(ctx, tx, lot.ProductId); err != nil {
+		return nil, err
+	}
+
+	query, args, err := psql.Insert("product_lots").
+		Columns("product_id, number, quantity, expires_at").
+		Values(lot.ProductId, lot.Number, lot.Quantity, lot.ExpiresAt).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.AddLot: to sql: %w", err)
+	}
+
+	if err = tx.QueryRow(ctx, query, args...).Scan(&lot.Id); err != nil {
+		switch {
+		case isForeignKeyViolation(err):
+			return nil, errors.Wrap(repository.ProductNotExists, strconv.FormatUint(lot.ProductId, 10))
+		case isUniqueViolation(err):
+			return nil, errors.Wrap(repository.LotAlreadyExists, lot.Number)
+		}
+		return nil, fmt.Errorf("Repository.AddLot: insert: %w", err)
+	}
+
+	query, args, err = psql.Update("products").
+		Set("quantity", squirrel.Expr("quantity + ?", lot.Quantity)).
+		Where(squirrel.Eq{"id": lot.ProductId}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.AddLot: to sql: %w", err)
+	}
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		return nil, fmt.Errorf("Repository.AddLot: update product: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("Repository.AddLot: commit: %w", err)
+	}
+	return &lot, nil
+}
+
+func (r *Repository) GetProductLots(ctx context.Context, productId uint64) ([]*lots.Lot, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetProductLots: %w", err)
+	}
+
+	query, args, err := psql.Select(lotColumns).
+		From("product_lots").
+		Where(squirrel.Eq{"product_id": productId}).
+		Where(ofTenantProducts("product_id", tenant)).
+		Where("quantity > 0").
+		OrderBy("expires_at", "id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetProductLots: to sql: %w", err)
+	}
+
+	var productLots []*lots.Lot
+	if err = pgxscan.Select(ctx, r.pool, &productLots, query, args...); err != nil {
+		return nil, fmt.Errorf("Repository.GetProductLots: select: %w", err)
+	}
+
+	return productLots, nil
+}
+
+func (r *Repository) GetExpiringLots(ctx context.Context, before time.Time, page uint64, size uint64) ([]*lots.Lot, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetExpiringLots: %w", err)
+	}
+	limit, offset := r.getPaginationLimitAndOffset(page, size)
+
+	query, args, err := psql.Select(lotColumns).
+		From("product_lots").
+		Where(ofTenantProducts("product_id", tenant)).
+		Where("quantity > 0").
+		Where(squirrel.Lt{"expires_at": before}).
+		OrderBy("expires_at", "id").
+		Limit(limit).
+		Offset(offset).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetExpiringLots: to sql: %w", err)
+	}
+
+	var expiring []*lots.Lot
+	if err = pgxscan.Select(ctx, r.pool, &expiring, query, args...); err != nil {
+		return nil, fmt.Errorf("Repository.GetExpiringLots: select: %w", err)
+	}
+
+	return expiring, nil
+}
+
+// pickLots takes quantity out of the product lots first-expired-first-out within the transaction.
+func (r *Repository) pickLots(ctx context.Context, tx pgx.Tx, productId uint64, quantity uint64) error {
+	productLots, err := r.getProductLotsForUpdate(ctx, tx, productId)
+	if err != nil {
+		return err
+	}
+
+	for _, lot := range lots.Pick(productLots, quantity) {
+		if err = r.updateLotQuantity(ctx, tx, lot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reserveLots takes quantity out of the product lots that have not expired within the transaction.
+func (r *Repository) reserveLots(ctx context.Context, tx pgx.Tx, productId uint64, productQuantity uint64, quantity uint64) ([]lots.Picked, error) {
+	productLots, err := r.getProductLotsForUpdate(ctx, tx, productId)
+	if err != nil {
+		return nil, err
+	}
+
+	picked, err := lots.Reserve(productLots, quantity, lots.Untracked(productQuantity, productLots), time.Now())
+	if err != nil {
+		return nil, errors.Wrap(err, strconv.FormatUint(productId, 10))
+	}
+
+	byId := make(map[uint64]*lots.Lot, len(productLots))
+	for _, lot := range productLots {
+		byId[lot.Id] = lot
+	}
+	for _, pick := range picked {
+		if err = r.updateLotQuantity(ctx, tx, byId[pick.LotId]); err != nil {
+			return nil, err
+		}
+	}
+	return picked, nil
+}
+
+// restoreLots puts picked quantities back into the product lots within the transaction.
+// Lots removed since the reservation are skipped, their quantity stays untracked.
+func (r *Repository) restoreLots(ctx context.Context, tx pgx.Tx, productId uint64, picked []lots.Picked) error {
+	for _, pick := range picked {
+		query, args, err := psql.Update("product_lots").
+			Set("quantity", squirrel.Expr("quantity + ?", pick.Quantity)).
+			Where(squirrel.Eq{"id": pick.LotId, "product_id": productId}).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("Repository.restoreLots: to sql: %w", err)
+		}
+		if _, err = tx.Exec(ctx, query, args...); err != nil {
+			return fmt.Errorf("Repository.restoreLots: update lot: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *Repository) getProductLotsForUpdate(ctx context.Context, tx pgx.Tx, productId uint64) ([]*lots.Lot, error) {
+	query, args, err := psql.Select(lotColumns).
+		From("product_lots").
+		Where(squirrel.Eq{"product_id": productId}).
+		Where("quantity > 0").
+		OrderBy("expires_at", "id").
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.getProductLotsForUpdate: to sql: %w", err)
+	}
+
+	var productLots []*lots.Lot
+	if err = pgxscan.Select(ctx, tx, &productLots, query, args...); err != nil {
+		return nil, fmt.Errorf("Repository.getProductLotsForUpdate: select: %w", err)
+	}
+	return productLots, nil
+}
+
+func (r *Repository) updateLotQuantity(ctx context.Context, tx pgx.Tx, lot *lots.Lot) error {
+	query, args, err := psql.Update("product_lots").
+		Set("quantity", lot.Quantity).
+		Where(squirrel.Eq{"id": lot.Id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Repository.updateLotQuantity: to sql: %w", err)
+	}
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("Repository.updateLotQuantity: update lot: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"github.com/jackc/pgconn"
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"homework-1/internal/models/lots"
+	"homework-1/internal/models/products"
+	"regexp"
+	"testing"
+	"time"
+)
+
+var lotRows = []string{"id", "product_id", "number", "quantity", "expires_at"}
+
+func TestAddLot(t *testing.T) {
+	expiresAt := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success adding lot", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectBegin()
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, price, quantity, status, unit, barcode, category, attributes FROM products WHERE id = $1 AND tenant_id = $2 FOR UPDATE`)).
+			WithArgs(uint64(1), testTenant).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "price", "quantity", "status"}).
+				AddRow(uint64(1), "product1", uint64(100), uint64(1), products.StatusActive))
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`INSERT INTO product_lots (product_id, number, quantity, expires_at) VALUES ($1,$2,$3,$4) RETURNING id`)).
+			WithArgs(uint64(1), "A", uint64(5), expiresAt).
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint64(1)))
+		f.mockPool.ExpectExec(regexp.QuoteMeta(`UPDATE products SET quantity = quantity + $1 WHERE id = $2`)).
+			WithArgs(uint64(5), uint64(1)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		f.mockPool.ExpectCommit()
+
+		// act
+		res, err := f.lotRepo.AddLot(f.ctx, lots.Lot{
+			ProductId: uint64(1),
+			Number:    "A",
+			Quantity:  uint64(5),
+			ExpiresAt: expiresAt,
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res.Id, uint64(1))
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("lot already exists", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectBegin()
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, price, quantity, status, unit, barcode, category, attributes FROM products WHERE id = $1 AND tenant_id = $2 FOR UPDATE`)).
+			WithArgs(uint64(1), testTenant).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "price", "quantity", "status"}).
+				AddRow(uint64(1), "product1", uint64(100), uint64(1), products.StatusActive))
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`INSERT INTO product_lots`)).
+			WithArgs(uint64(1), "A", uint64(5), expiresAt).
+			WillReturnError(&pgconn.PgError{Code: uniqueViolation})
+		f.mockPool.ExpectRollback()
+
+		// act
+		_, err := f.lotRepo.AddLot(f.ctx, lots.Lot{
+			ProductId: uint64(1),
+			Number:    "A",
+			Quantity:  uint64(5),
+			ExpiresAt: expiresAt,
+		})
+
+		// assert
+		assert.EqualError(t, err, "A: lot already exists")
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("product of another tenant", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectBegin()
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, price, quantity, status, unit, barcode, category, attributes FROM products WHERE id = $1 AND tenant_id = $2 FOR UPDATE`)).
+			WithArgs(uint64(1), testTenant).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "price", "quantity", "status"}))
+		f.mockPool.ExpectRollback()
+
+		// act
+		_, err := f.lotRepo.AddLot(f.ctx, lots.Lot{ProductId: uint64(1), Number: "A", Quantity: uint64(5), ExpiresAt: expiresAt})
+
+		// assert
+		assert.EqualError(t, err, "1: product does not exist")
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestGetExpiringLots(t *testing.T) {
+	t.Run("success getting expiring lots", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		before := time.Date(2022, 10, 15, 0, 0, 0, 0, time.UTC)
+		expiresAt := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, product_id, number, quantity, expires_at FROM product_lots WHERE product_id IN (SELECT id FROM products WHERE tenant_id = $1) AND quantity > 0 AND expires_at < $2 ORDER BY expires_at, id LIMIT 20 OFFSET 0`)).
+			WithArgs(testTenant, before).
+			WillReturnRows(pgxmock.NewRows(lotRows).
+				AddRow(uint64(1), uint64(1), "A", uint64(5), expiresAt))
+
+		// act
+		res, err := f.lotRepo.GetExpiringLots(f.ctx, before, 0, 0)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, []*lots.Lot{
+			{Id: uint64(1), ProductId: uint64(1), Number: "A", Quantity: uint64(5), ExpiresAt: expiresAt},
+		})
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
+	"github.com/pkg/errors"
+	"homework-1/internal/models/changes"
+	"homework-1/internal/models/products"
+	"homework-1/internal/repository"
+	"homework-1/internal/tenants"
+	"strconv"
+)
+
+const priceChangeColumns = "id, product_id, name, old_price, price, quantity, status, requested_by, resolved_by, created_at, resolved_at"
+
+func (r *Repository) CreatePriceChange(ctx context.Context, change changes.PriceChange) (*changes.PriceChange, error) {
+	if err := r.checkProducts(ctx, r.pool, change.ProductId); err != nil {
+		return nil, err
+	}
+
+	query, args, err := psql.Insert("price_change_requests").
+		Columns("product_id, name, old_price, price, quantity, status, requested_by, created_at").
+		Values(change.ProductId, change.Name, change.OldPrice, change.Price, change.Quantity, change.Status, change.RequestedBy, change.CreatedAt).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.CreatePriceChange: to sql: %w", err)
+	}
+
+	row := r.pool.QueryRow(ctx, query, args...)
+	if err = row.Scan(&change.Id); err != nil {
+		if isForeignKeyViolation(err) {
+			return nil, errors.Wrap(repository.ProductNotExists, strconv.FormatUint(change.ProductId, 10))
+		}
+		return nil, fmt.Errorf("Repository.CreatePriceChange: insert: %w", err)
+	}
+
+	return &change, nil
+}
+
+func (r *Repository) GetPriceChangeById(ctx context.Context, id uint64) (*changes.PriceChange, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetPriceChangeById: %w", err)
+	}
+
+	query, args, err := psql.Select(priceChangeColumns).
+		From("price_change_requests").
+		Where(squirrel.Eq{"id": id}).
+		Where(ofTenantProducts("product_id", tenant)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetPriceChangeById: to sql: %w", err)
+	}
+
+	var change changes.PriceChange
+	if err = pgxscan.Get(ctx, r.pool, &change, query, args...); err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, errors.Wrap(repository.PriceChangeNotExists, strconv.FormatUint(id, 10))
+		}
+		return nil, fmt.Errorf("Repository.GetPriceChangeById: select: %w", err)
+	}
+
+	return &change, nil
+}
+
+func (r *Repository) GetPendingPriceChanges(ctx context.Context, page uint64, size uint64) ([]*changes.PriceChange, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetPendingPriceChanges: %w", err)
+	}
+	limit, offset := r.getPaginationLimitAndOffset(page, size)
+
+	query, args, err := psql.Select(priceChangeColumns).
+		From("price_change_requests").
+		Where(squirrel.Eq{"status": changes.StatusPending}).
+		Where(ofTenantProducts("product_id", tenant)).
+		OrderBy("id").
+		Limit(limit).
+		Offset(offset).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetPendingPriceChanges: to sql: %w", err)
+	}
+
+	var pending []*changes.PriceChange
+	if err = pgxscan.Select(ctx, r.pool, &pending, query, args...); err != nil {
+		return nil, fmt.Errorf("Repository.GetPendingPriceChanges: select: %w", err)
+	}
+
+	return pending, nil
+}
+
+func (r *Repository) ApprovePriceChange(ctx context.Context, id uint64, approver string) (*changes.PriceChange, *products.Product, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Repository.ApprovePriceChange: begin: %w", err)
+	}
+	defer tx.Rollback(ctx) // no-op after commit
+
+	change, err := r.getPriceChangeForUpdate(ctx, tx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = change.Approve(approver); err != nil {
+		return nil, nil, err
+	}
+
+	product, err := r.getProductForUpdate(ctx, tx, change.ProductId)
+	if err != nil {
+		return nil, nil, err
+	}
+	change.Apply(product)
+
+	query, args, err := psql.Update("products").
+		Set("price", product.Price).
+		Where(squirrel.Eq{"id": product.Id}).
+		ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("Repository.ApprovePriceChange: to sql: %w", err)
+	}
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		return nil, nil, fmt.Errorf("Repository.ApprovePriceChange: update product: %w", err)
+	}
+
+	if err = r.updatePriceChangeResolution(ctx, tx, change); err != nil {
+		return nil, nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, nil, fmt.Errorf("Repository.ApprovePriceChange: commit: %w", err)
+	}
+	return change, product, nil
+}
+
+func (r *Repository) RejectPriceChange(ctx context.Context, id uint64, approver string) (*changes.PriceChange, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.RejectPriceChange: begin: %w", err)
+	}
+	defer tx.Rollback(ctx) // no-op after commit
+
+	change, err := r.getPriceChangeForUpdate(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = change.Reject(approver); err != nil {
+		return nil, err
+	}
+
+	if err = r.updatePriceChangeResolution(ctx, tx, change); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("Repository.RejectPriceChange: commit: %w", err)
+	}
+	return change, nil
+}
+
+func (r *Repository) getPriceChangeForUpdate(ctx context.Context, tx pgx.Tx, id uint64) (*changes.PriceChange, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.getPriceChangeForUpdate: %w", err)
+	}
+
+	query, args, err := psql.Select(priceChangeColumns).
+		From("price_change_requests").
+		Where(squirrel.Eq{"id": id}).
+		Where(ofTenantProducts("product_id", tenant)).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.getPriceChangeForUpdate: to sql: %w", err)
+	}
+
+	var change changes.PriceChange
+	if err = pgxscan.Get(ctx, tx, &change, query, args...); err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, errors.Wrap(repository.PriceChangeNotExists, strconv.FormatUint(id, 10))
+		}
+		return nil, fmt.Errorf("Repository.getPriceChangeForUpdate: select: %w", err)
+	}
+
+	return &change, nil
+}
+
+func (r *Repository) updatePriceChangeResolution(ctx context.Context, tx pgx.Tx, change *changes.PriceChange) error {
+	query, args, err := psql.Update("price_change_requests").
+		Set("status", change.Status).
+		Set("resolved_by", change.ResolvedBy).
+		Set("resolved_at", change.ResolvedAt).
+		Where(squirrel.Eq{"id": change.Id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Repository.updatePriceChangeResolution: to sql: %w", err)
+	}
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("Repository.updatePriceChangeResolution: update: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+package repository
+
+import (
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"homework-1/internal/models/changes"
+	"homework-1/internal/models/products"
+	"regexp"
+	"testing"
+	"time"
+)
+
+var priceChangeRows = []string{
+	"id", "product_id", "name", "old_price", "price", "quantity", "status", "requested_by", "resolved_by", "created_at", "resolved_at",
+}
+
+func TestCreatePriceChange(t *testing.T) {
+	createdAt := time.Date(2022, 9, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("success creating price change", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.expectProducts([]uint64{1}, 1)
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`INSERT INTO price_change_requests (product_id, name, old_price, price, quantity, status, requested_by, created_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING id`)).
+			WithArgs(uint64(1), "product1", uint64(100), uint64(1), uint64(1), changes.StatusPending, "alice", createdAt).
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint64(1)))
+
+		// act
+		res, err := f.priceChangeRepo.CreatePriceChange(f.ctx, changes.PriceChange{
+			ProductId:   uint64(1),
+			Name:        "product1",
+			OldPrice:    uint64(100),
+			Price:       uint64(1),
+			Quantity:    uint64(1),
+			Status:      changes.StatusPending,
+			RequestedBy: "alice",
+			CreatedAt:   createdAt,
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res.Id, uint64(1))
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("product does not exists", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.expectProducts([]uint64{1}, 1)
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`INSERT INTO price_change_requests`)).
+			WithArgs(uint64(1), "", uint64(0), uint64(0), uint64(0), changes.Status(""), "", createdAt).
+			WillReturnError(&pgconn.PgError{Code: foreignKeyViolation})
+
+		// act
+		_, err := f.priceChangeRepo.CreatePriceChange(f.ctx, changes.PriceChange{
+			ProductId: uint64(1),
+			CreatedAt: createdAt,
+		})
+
+		// assert
+		assert.EqualError(t, err, "1: product does not exist")
+	})
+
+	t.Run("product of another tenant", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.expectProducts([]uint64{1})
+
+		// act
+		_, err := f.priceChangeRepo.CreatePriceChange(f.ctx, changes.PriceChange{
+			ProductId: uint64(1),
+			CreatedAt: createdAt,
+		})
+
+		// assert
+		assert.EqualError(t, err, "1: product does not exist")
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestGetPriceChangeById(t *testing.T) {
+	t.Run("price change of another tenant", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, product_id, name, old_price, price, quantity, status, requested_by, resolved_by, created_at, resolved_at FROM price_change_requests WHERE id = $1 AND product_id IN (SELECT id FROM products WHERE tenant_id = $2)`)).
+			WithArgs(uint64(1), testTenant).
+			WillReturnRows(pgxmock.NewRows(priceChangeRows))
+
+		// act
+		_, err := f.priceChangeRepo.GetPriceChangeById(f.ctx, 1)
+
+		// assert
+		assert.EqualError(t, err, "1: price change does not exist")
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestGetPendingPriceChanges(t *testing.T) {
+	t.Run("success getting pending price changes", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		createdAt := time.Date(2022, 9, 10, 12, 0, 0, 0, time.UTC)
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, product_id, name, old_price, price, quantity, status, requested_by, resolved_by, created_at, resolved_at FROM price_change_requests WHERE status = $1 AND product_id IN (SELECT id FROM products WHERE tenant_id = $2) ORDER BY id LIMIT 20 OFFSET 0`)).
+			WithArgs(changes.StatusPending, testTenant).
+			WillReturnRows(pgxmock.NewRows(priceChangeRows).
+				AddRow(uint64(1), uint64(1), "product1", uint64(100), uint64(1), uint64(1), changes.StatusPending, "alice", "", createdAt, (*time.Time)(nil)))
+
+		// act
+		res, err := f.priceChangeRepo.GetPendingPriceChanges(f.ctx, 0, 0)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, []*changes.PriceChange{
+			{
+				Id:          uint64(1),
+				ProductId:   uint64(1),
+				Name:        "product1",
+				OldPrice:    uint64(100),
+				Price:       uint64(1),
+				Quantity:    uint64(1),
+				Status:      changes.StatusPending,
+				RequestedBy: "alice",
+				CreatedAt:   createdAt,
+			},
+		})
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestApprovePriceChange(t *testing.T) {
+	createdAt := time.Date(2022, 9, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("success approving price change", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectBegin()
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, product_id, name, old_price, price, quantity, status, requested_by, resolved_by, created_at, resolved_at FROM price_change_requests WHERE id = $1 AND product_id IN (SELECT id FROM products WHERE tenant_id = $2) FOR UPDATE`)).
+			WithArgs(uint64(1), testTenant).
+			WillReturnRows(pgxmock.NewRows(priceChangeRows).
+				AddRow(uint64(1), uint64(1), "product2", uint64(100), uint64(1), uint64(2), changes.StatusPending, "alice", "", createdAt, (*time.Time)(nil)))
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, price, quantity, status, unit, barcode, category, attributes FROM products WHERE id = $1 AND tenant_id = $2 FOR UPDATE`)).
+			WithArgs(uint64(1), testTenant).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "price", "quantity", "status"}).
+				AddRow(uint64(1), "product1", uint64(100), uint64(1), products.StatusActive))
+		f.mockPool.ExpectExec(regexp.QuoteMeta(`UPDATE products SET price = $1 WHERE id = $2`)).
+			WithArgs(uint64(1), uint64(1)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		f.mockPool.ExpectExec(regexp.QuoteMeta(`UPDATE price_change_requests SET status = $1, resolved_by = $2, resolved_at = $3 WHERE id = $4`)).
+			WithArgs(changes.StatusApproved, "bob", pgxmock.AnyArg(), uint64(1)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		f.mockPool.ExpectCommit()
+
+		// act
+		change, product, err := f.priceChangeRepo.ApprovePriceChange(f.ctx, 1, "bob")
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, change.Status, changes.StatusApproved)
+		assert.Equal(t, change.ResolvedBy, "bob")
+		assert.Equal(t, product, &products.Product{
+			Id:       uint64(1),
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(1),
+			Status:   products.StatusActive,
+		})
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("requester can't approve own change", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectBegin()
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, product_id, name, old_price, price, quantity, status, requested_by, resolved_by, created_at, resolved_at FROM price_change_requests WHERE id = $1 AND product_id IN (SELECT id FROM products WHERE tenant_id = $2) FOR UPDATE`)).
+			WithArgs(uint64(1), testTenant).
+			WillReturnRows(pgxmock.NewRows(priceChangeRows).
+				AddRow(uint64(1), uint64(1), "product2", uint64(100), uint64(1), uint64(2), changes.StatusPending, "alice", "", createdAt, (*time.Time)(nil)))
+		f.mockPool.ExpectRollback()
+
+		// act
+		_, _, err := f.priceChangeRepo.ApprovePriceChange(f.ctx, 1, "alice")
+
+		// assert
+		assert.ErrorIs(t, err, changes.ErrSelfApproval)
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("price change does not exists", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectBegin()
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, product_id, name, old_price, price, quantity, status, requested_by, resolved_by, created_at, resolved_at FROM price_change_requests WHERE id = $1 AND product_id IN (SELECT id FROM products WHERE tenant_id = $2) FOR UPDATE`)).
+			WithArgs(uint64(1), testTenant).
+			WillReturnError(pgx.ErrNoRows)
+		f.mockPool.ExpectRollback()
+
+		// act
+		_, _, err := f.priceChangeRepo.ApprovePriceChange(f.ctx, 1, "bob")
+
+		// assert
+		assert.EqualError(t, err, "1: price change does not exist")
+	})
+}
+
+func TestRejectPriceChange(t *testing.T) {
+	t.Run("success rejecting price change", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		createdAt := time.Date(2022, 9, 10, 12, 0, 0, 0, time.UTC)
+		f.mockPool.ExpectBegin()
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, product_id, name, old_price, price, quantity, status, requested_by, resolved_by, created_at, resolved_at FROM price_change_requests WHERE id = $1 AND product_id IN (SELECT id FROM products WHERE tenant_id = $2) FOR UPDATE`)).
+			WithArgs(uint64(1), testTenant).
+			WillReturnRows(pgxmock.NewRows(priceChangeRows).
+				AddRow(uint64(1), uint64(1), "product2", uint64(100), uint64(1), uint64(2), changes.StatusPending, "alice", "", createdAt, (*time.Time)(nil)))
+		f.mockPool.ExpectExec(regexp.QuoteMeta(`UPDATE price_change_requests SET status = $1, resolved_by = $2, resolved_at = $3 WHERE id = $4`)).
+			WithArgs(changes.StatusRejected, "bob", pgxmock.AnyArg(), uint64(1)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		f.mockPool.ExpectCommit()
+
+		// act
+		change, err := f.priceChangeRepo.RejectPriceChange(f.ctx, 1, "bob")
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, change.Status, changes.StatusRejected)
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+}
@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"github.com/Masterminds/squirrel"
 	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
 	"github.com/pkg/errors"
+	"homework-1/internal/models/lots"
 	"homework-1/internal/models/products"
 	"homework-1/internal/repository"
+	"homework-1/internal/tenants"
 	"strconv"
 )
 
@@ -15,10 +18,17 @@ var psql = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
 
 var defaultProductsPageSize = uint64(20)
 
+const productColumns = "id, name, price, quantity, status, unit, barcode, category, attributes"
+
 func (r *Repository) GetProductById(ctx context.Context, id uint64) (*products.Product, error) {
-	query, args, err := psql.Select("id, name, price, quantity").
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetProductById: %w", err)
+	}
+
+	query, args, err := psql.Select(productColumns).
 		From("products").
-		Where(squirrel.Eq{"id": id}).
+		Where(squirrel.Eq{"id": id, "tenant_id": tenant}).
 		ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("Repository.GetProductById: to sql: %w", err)
@@ -35,10 +45,46 @@ func (r *Repository) GetProductById(ctx context.Context, id uint64) (*products.P
 	return &product, nil
 }
 
+func (r *Repository) GetProductByBarcode(ctx context.Context, barcode string) (*products.Product, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetProductByBarcode: %w", err)
+	}
+
+	query, args, err := psql.Select(productColumns).
+		From("products").
+		Where(squirrel.Eq{"barcode": barcode, "tenant_id": tenant}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetProductByBarcode: to sql: %w", err)
+	}
+
+	var product products.Product
+	if err = pgxscan.Get(ctx, r.pool, &product, query, args...); err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, errors.Wrap(repository.ProductNotExists, barcode)
+		}
+		return nil, fmt.Errorf("Repository.GetProductByBarcode: select: %w", err)
+	}
+
+	return &product, nil
+}
+
 func (r *Repository) CreateProduct(ctx context.Context, product products.Product) (*products.Product, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.CreateProduct: %w", err)
+	}
+
+	if product.Status == "" {
+		product.Status = products.StatusActive
+	}
+	product.Unit = product.GetUnit()
+
 	query, args, err := psql.Insert("products").
-		Columns("name, price, quantity").
-		Values(product.Name, product.Price, product.Quantity).
+		Columns("name, price, quantity, status, unit, barcode, category, attributes, tenant_id").
+		Values(product.Name, product.Price, product.Quantity, product.Status, product.Unit, product.Barcode,
+			product.Category, product.GetAttributes(), tenant).
 		Suffix("RETURNING id").
 		ToSql()
 	if err != nil {
@@ -47,6 +93,9 @@ func (r *Repository) CreateProduct(ctx context.Context, product products.Product
 
 	row := r.pool.QueryRow(ctx, query, args...)
 	if err = row.Scan(&product.Id); err != nil {
+		if isUniqueViolation(err) {
+			return nil, errors.Wrap(repository.BarcodeAlreadyExists, product.Barcode)
+		}
 		return nil, fmt.Errorf("Repository.CreateProduct: insert: %w", err)
 	}
 
@@ -54,7 +103,12 @@ func (r *Repository) CreateProduct(ctx context.Context, product products.Product
 }
 
 func (r *Repository) DeleteProduct(ctx context.Context, id uint64) error {
-	query, args, err := psql.Delete("products").Where(squirrel.Eq{"id": id}).ToSql()
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("Repository.DeleteProduct: %w", err)
+	}
+
+	query, args, err := psql.Delete("products").Where(squirrel.Eq{"id": id, "tenant_id": tenant}).ToSql()
 	if err != nil {
 		return fmt.Errorf("Repository.DeleteProduct: to sql: %w", err)
 	}
@@ -66,28 +120,59 @@ func (r *Repository) DeleteProduct(ctx context.Context, id uint64) error {
 }
 
 func (r *Repository) UpdateProduct(ctx context.Context, product products.Product) (*products.Product, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.UpdateProduct: begin: %w", err)
+	}
+	defer tx.Rollback(ctx) // no-op after commit
+
+	stored, err := r.getProductForUpdate(ctx, tx, product.Id)
+	if err != nil {
+		return nil, err
+	}
+
 	query, args, err := psql.Update("products").
 		Set("name", product.Name).
 		Set("price", product.Price).
 		Set("quantity", product.Quantity).
+		Set("barcode", product.Barcode).
+		Set("category", product.Category).
+		Set("attributes", product.GetAttributes()).
 		Where(squirrel.Eq{"id": product.Id}).
 		ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("Repository.UpdateProduct: to sql: %w", err)
 	}
 
-	if _, err = r.pool.Exec(ctx, query, args...); err != nil {
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		if isUniqueViolation(err) {
+			return nil, errors.Wrap(repository.BarcodeAlreadyExists, product.Barcode)
+		}
 		return nil, fmt.Errorf("Repository.UpdateProduct: to update: %w", err)
 	}
 
+	if stored.Quantity > product.Quantity {
+		if err = r.pickLots(ctx, tx, product.Id, stored.Quantity-product.Quantity); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("Repository.UpdateProduct: commit: %w", err)
+	}
 	return &product, nil
 }
 
 func (r *Repository) GetAllProducts(ctx context.Context, page uint64, size uint64) ([]*products.Product, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetAllProducts: %w", err)
+	}
 	limit, offset := r.getPaginationLimitAndOffset(page, size)
 
-	query, args, err := psql.Select("id, name, price, quantity").
+	query, args, err := psql.Select(productColumns).
 		From("products").
+		Where(squirrel.Eq{"tenant_id": tenant}).
 		OrderBy("id").
 		Limit(limit).
 		Offset(offset).
@@ -104,6 +189,259 @@ func (r *Repository) GetAllProducts(ctx context.Context, page uint64, size uint6
 	return allProducts, nil
 }
 
+func (r *Repository) GetProductsByStatus(ctx context.Context, status products.Status, page uint64, size uint64) ([]*products.Product, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetProductsByStatus: %w", err)
+	}
+	limit, offset := r.getPaginationLimitAndOffset(page, size)
+
+	query, args, err := psql.Select(productColumns).
+		From("products").
+		Where(squirrel.Eq{"tenant_id": tenant}).
+		Where(squirrel.Eq{"status": status}).
+		OrderBy("id").
+		Limit(limit).
+		Offset(offset).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetProductsByStatus: to sql: %w", err)
+	}
+
+	var filteredProducts []*products.Product
+	if err = pgxscan.Select(ctx, r.pool, &filteredProducts, query, args...); err != nil {
+		return nil, fmt.Errorf("Repository.GetProductsByStatus: select: %w", err)
+	}
+
+	return filteredProducts, nil
+}
+
+func (r *Repository) FindProducts(ctx context.Context, filter products.Filter, page uint64, size uint64) ([]*products.Product, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.FindProducts: %w", err)
+	}
+	limit, offset := r.getPaginationLimitAndOffset(page, size)
+
+	builder := psql.Select(productColumns).From("products").Where(squirrel.Eq{"tenant_id": tenant})
+	if filter.Status != "" {
+		builder = builder.Where(squirrel.Eq{"status": filter.Status})
+	}
+	if filter.Category != "" {
+		builder = builder.Where(squirrel.Eq{"category": filter.Category})
+	}
+	if len(filter.Attributes) > 0 {
+		builder = builder.Where("attributes @> ?", filter.Attributes)
+	}
+
+	query, args, err := builder.
+		OrderBy("id").
+		Limit(limit).
+		Offset(offset).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.FindProducts: to sql: %w", err)
+	}
+
+	var foundProducts []*products.Product
+	if err = pgxscan.Select(ctx, r.pool, &foundProducts, query, args...); err != nil {
+		return nil, fmt.Errorf("Repository.FindProducts: select: %w", err)
+	}
+
+	return foundProducts, nil
+}
+
+func (r *Repository) TransitionProductStatus(ctx context.Context, id uint64, to products.Status, reason string, actor string) (*products.Product, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.TransitionProductStatus: begin: %w", err)
+	}
+	defer tx.Rollback(ctx) // no-op after commit
+
+	product, err := r.getProductForUpdate(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	transition, err := product.TransitionTo(to, reason, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := psql.Update("products").
+		Set("status", product.Status).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.TransitionProductStatus: to sql: %w", err)
+	}
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		return nil, fmt.Errorf("Repository.TransitionProductStatus: to update: %w", err)
+	}
+
+	query, args, err = psql.Insert("product_status_transitions").
+		Columns("product_id, from_status, to_status, reason, actor").
+		Values(transition.ProductId, transition.From, transition.To, transition.Reason, transition.Actor).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.TransitionProductStatus: to sql: %w", err)
+	}
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		return nil, fmt.Errorf("Repository.TransitionProductStatus: insert transition: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("Repository.TransitionProductStatus: commit: %w", err)
+	}
+	return product, nil
+}
+
+func (r *Repository) ReserveProduct(ctx context.Context, id uint64, quantity uint64) (*products.Product, []lots.Picked, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Repository.ReserveProduct: begin: %w", err)
+	}
+	defer tx.Rollback(ctx) // no-op after commit
+
+	product, err := r.getProductForUpdate(ctx, tx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stored := product.Quantity
+	if err = product.Reserve(quantity); err != nil {
+		return nil, nil, err
+	}
+
+	query, args, err := psql.Update("products").
+		Set("quantity", product.Quantity).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("Repository.ReserveProduct: to sql: %w", err)
+	}
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		return nil, nil, fmt.Errorf("Repository.ReserveProduct: to update: %w", err)
+	}
+
+	picked, err := r.reserveLots(ctx, tx, id, stored, quantity)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, nil, fmt.Errorf("Repository.ReserveProduct: commit: %w", err)
+	}
+	return product, picked, nil
+}
+
+func (r *Repository) ReleaseProduct(ctx context.Context, id uint64, quantity uint64, picked []lots.Picked) (*products.Product, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.ReleaseProduct: begin: %w", err)
+	}
+	defer tx.Rollback(ctx) // no-op after commit
+
+	product, err := r.getProductForUpdate(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = product.Release(quantity); err != nil {
+		return nil, err
+	}
+
+	query, args, err := psql.Update("products").
+		Set("quantity", product.Quantity).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.ReleaseProduct: to sql: %w", err)
+	}
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		return nil, fmt.Errorf("Repository.ReleaseProduct: to update: %w", err)
+	}
+
+	if err = r.restoreLots(ctx, tx, id, picked); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("Repository.ReleaseProduct: commit: %w", err)
+	}
+	return product, nil
+}
+
+// getProductForUpdate locks a product of the tenant of ctx, the updates that follow in tx
+// may address it by id alone.
+func (r *Repository) getProductForUpdate(ctx context.Context, tx pgx.Tx, id uint64) (*products.Product, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.getProductForUpdate: %w", err)
+	}
+
+	query, args, err := psql.Select(productColumns).
+		From("products").
+		Where(squirrel.Eq{"id": id, "tenant_id": tenant}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.getProductForUpdate: to sql: %w", err)
+	}
+
+	var product products.Product
+	if err = pgxscan.Get(ctx, tx, &product, query, args...); err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, errors.Wrap(repository.ProductNotExists, strconv.FormatUint(id, 10))
+		}
+		return nil, fmt.Errorf("Repository.getProductForUpdate: select: %w", err)
+	}
+
+	return &product, nil
+}
+
+// checkProducts makes sure the products belong to the tenant of ctx before rows referencing
+// them are written, the foreign keys alone would accept the products of another tenant.
+func (r *Repository) checkProducts(ctx context.Context, db pgxscan.Querier, ids ...uint64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("Repository.checkProducts: %w", err)
+	}
+
+	query, args, err := psql.Select("id").
+		From("products").
+		Where(squirrel.Eq{"id": ids, "tenant_id": tenant}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Repository.checkProducts: to sql: %w", err)
+	}
+
+	var found []uint64
+	if err = pgxscan.Select(ctx, db, &found, query, args...); err != nil {
+		return fmt.Errorf("Repository.checkProducts: select: %w", err)
+	}
+
+	exists := make(map[uint64]struct{}, len(found))
+	for _, id := range found {
+		exists[id] = struct{}{}
+	}
+	for _, id := range ids {
+		if _, ok := exists[id]; !ok {
+			return errors.Wrap(repository.ProductNotExists, strconv.FormatUint(id, 10))
+		}
+	}
+	return nil
+}
+
+// ofTenantProducts matches the rows whose product column points at a product of the tenant,
+// tables that hang off products have no tenant of their own.
+func ofTenantProducts(column string, tenant tenants.Tenant) squirrel.Sqlizer {
+	return squirrel.Expr(column+" IN (SELECT id FROM products WHERE tenant_id = ?)", tenant)
+}
+
 func (r *Repository) getPaginationLimitAndOffset(page uint64, size uint64) (uint64, uint64) {
 	if page <= 0 {
 		page = 1 // min page number
@@ -2,14 +2,19 @@ package repository
 
 import (
 	"context"
+	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	"github.com/pashagolub/pgxmock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"homework-1/internal/models/attributes"
+	"homework-1/internal/models/lots"
 	"homework-1/internal/models/products"
+	"homework-1/internal/models/units"
 	"regexp"
 	"testing"
+	"time"
 )
 
 func TestGetProductByID(t *testing.T) {
@@ -18,13 +23,13 @@ func TestGetProductByID(t *testing.T) {
 		f := SetUp(t)
 		defer f.TearDown()
 
-		mockResponse := pgxmock.NewRows([]string{"id", "name", "price", "quantity"}).AddRow(uint64(1), "product1", uint64(1), uint64(1))
-		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, price, quantity FROM products WHERE id = $1`)).
-			WithArgs(uint64(1)).
+		mockResponse := pgxmock.NewRows([]string{"id", "name", "price", "quantity", "status"}).AddRow(uint64(1), "product1", uint64(1), uint64(1), products.StatusActive)
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, price, quantity, status, unit, barcode, category, attributes FROM products WHERE id = $1 AND tenant_id = $2`)).
+			WithArgs(uint64(1), testTenant).
 			WillReturnRows(mockResponse)
 
 		// act
-		res, err := f.productRepo.GetProductById(context.Background(), 1)
+		res, err := f.productRepo.GetProductById(f.ctx, 1)
 
 		// assert
 		require.NoError(t, err)
@@ -33,6 +38,7 @@ func TestGetProductByID(t *testing.T) {
 			Name:     "product1",
 			Price:    uint64(1),
 			Quantity: uint64(1),
+			Status:   products.StatusActive,
 		})
 	})
 
@@ -41,12 +47,12 @@ func TestGetProductByID(t *testing.T) {
 		f := SetUp(t)
 		defer f.TearDown()
 
-		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, price, quantity FROM products WHERE id = $1`)).
-			WithArgs(uint64(1)).
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, price, quantity, status, unit, barcode, category, attributes FROM products WHERE id = $1 AND tenant_id = $2`)).
+			WithArgs(uint64(1), testTenant).
 			WillReturnError(pgx.ErrNoRows)
 
 		// act
-		_, err := f.productRepo.GetProductById(context.Background(), 1)
+		_, err := f.productRepo.GetProductById(f.ctx, 1)
 
 		// assert
 		assert.EqualError(t, err, "1: product does not exist")
@@ -57,16 +63,71 @@ func TestGetProductByID(t *testing.T) {
 		f := SetUp(t)
 		defer f.TearDown()
 
-		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, price, quantity FROM products WHERE id = $1`)).
-			WithArgs(uint64(1)).
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, price, quantity, status, unit, barcode, category, attributes FROM products WHERE id = $1 AND tenant_id = $2`)).
+			WithArgs(uint64(1), testTenant).
 			WillReturnError(errors.New("internal error"))
 
 		// act
-		_, err := f.productRepo.GetProductById(context.Background(), 1)
+		_, err := f.productRepo.GetProductById(f.ctx, 1)
 
 		// assert
 		assert.EqualError(t, err, "Repository.GetProductById: select: scany: query one result row: internal error")
 	})
+	t.Run("getting without tenant", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		// act
+		_, err := f.productRepo.GetProductById(context.Background(), 1)
+
+		// assert
+		assert.EqualError(t, err, "Repository.GetProductById: tenant id is required")
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestGetProductByBarcode(t *testing.T) {
+	t.Run("success getting product by barcode", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, price, quantity, status, unit, barcode, category, attributes FROM products WHERE barcode = $1 AND tenant_id = $2`)).
+			WithArgs("04006381333931", testTenant).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "price", "quantity", "status", "barcode"}).
+				AddRow(uint64(1), "product1", uint64(1), uint64(1), products.StatusActive, "04006381333931"))
+
+		// act
+		res, err := f.productRepo.GetProductByBarcode(f.ctx, "04006381333931")
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &products.Product{
+			Id:       uint64(1),
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(1),
+			Status:   products.StatusActive,
+			Barcode:  "04006381333931",
+		})
+	})
+
+	t.Run("product by barcode not found", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, price, quantity, status, unit, barcode, category, attributes FROM products WHERE barcode = $1 AND tenant_id = $2`)).
+			WithArgs("04006381333931", testTenant).
+			WillReturnError(pgx.ErrNoRows)
+
+		// act
+		_, err := f.productRepo.GetProductByBarcode(f.ctx, "04006381333931")
+
+		// assert
+		assert.EqualError(t, err, "04006381333931: product does not exist")
+	})
 }
 
 func TestCreateProduct(t *testing.T) {
@@ -75,12 +136,12 @@ func TestCreateProduct(t *testing.T) {
 		f := SetUp(t)
 		defer f.TearDown()
 
-		f.mockPool.ExpectQuery(regexp.QuoteMeta(`INSERT INTO products (name, price, quantity) VALUES ($1,$2,$3) RETURNING id`)).
-			WithArgs("product1", uint64(1), uint64(1)).
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`INSERT INTO products (name, price, quantity, status, unit, barcode, category, attributes, tenant_id) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING id`)).
+			WithArgs("product1", uint64(1), uint64(1), products.StatusActive, units.Piece, "", "", attributes.Attributes{}, testTenant).
 			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint64(1)))
 
 		// act
-		res, err := f.productRepo.CreateProduct(context.Background(), products.Product{
+		res, err := f.productRepo.CreateProduct(f.ctx, products.Product{
 			Name:     "product1",
 			Price:    uint64(1),
 			Quantity: uint64(1),
@@ -93,6 +154,8 @@ func TestCreateProduct(t *testing.T) {
 			Name:     "product1",
 			Price:    uint64(1),
 			Quantity: uint64(1),
+			Status:   products.StatusActive,
+			Unit:     units.Piece,
 		})
 	})
 
@@ -101,12 +164,12 @@ func TestCreateProduct(t *testing.T) {
 		f := SetUp(t)
 		defer f.TearDown()
 
-		f.mockPool.ExpectQuery(regexp.QuoteMeta(`INSERT INTO products (name, price, quantity) VALUES ($1,$2,$3) RETURNING id`)).
-			WithArgs("product1", uint64(1), uint64(1)).
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`INSERT INTO products (name, price, quantity, status, unit, barcode, category, attributes, tenant_id) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING id`)).
+			WithArgs("product1", uint64(1), uint64(1), products.StatusActive, units.Piece, "", "", attributes.Attributes{}, testTenant).
 			WillReturnError(errors.New("internal error"))
 
 		// act
-		_, err := f.productRepo.CreateProduct(context.Background(), products.Product{
+		_, err := f.productRepo.CreateProduct(f.ctx, products.Product{
 			Name:     "product1",
 			Price:    uint64(1),
 			Quantity: uint64(1),
@@ -115,6 +178,27 @@ func TestCreateProduct(t *testing.T) {
 		// assert
 		assert.EqualError(t, err, "Repository.CreateProduct: insert: internal error")
 	})
+
+	t.Run("creating with taken barcode", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`INSERT INTO products (name, price, quantity, status, unit, barcode, category, attributes, tenant_id) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING id`)).
+			WithArgs("product1", uint64(1), uint64(1), products.StatusActive, units.Piece, "04006381333931", "", attributes.Attributes{}, testTenant).
+			WillReturnError(&pgconn.PgError{Code: uniqueViolation})
+
+		// act
+		_, err := f.productRepo.CreateProduct(f.ctx, products.Product{
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(1),
+			Barcode:  "04006381333931",
+		})
+
+		// assert
+		assert.EqualError(t, err, "04006381333931: barcode already belongs to another product")
+	})
 }
 
 func TestDeleteProduct(t *testing.T) {
@@ -123,12 +207,12 @@ func TestDeleteProduct(t *testing.T) {
 		f := SetUp(t)
 		defer f.TearDown()
 
-		f.mockPool.ExpectExec(regexp.QuoteMeta(`DELETE FROM products WHERE id = $1`)).
-			WithArgs(uint64(1)).
+		f.mockPool.ExpectExec(regexp.QuoteMeta(`DELETE FROM products WHERE id = $1 AND tenant_id = $2`)).
+			WithArgs(uint64(1), testTenant).
 			WillReturnResult(pgxmock.NewResult("DELETE", 1))
 
 		// act
-		err := f.productRepo.DeleteProduct(context.Background(), 1)
+		err := f.productRepo.DeleteProduct(f.ctx, 1)
 
 		// assert
 		require.NoError(t, err)
@@ -139,12 +223,12 @@ func TestDeleteProduct(t *testing.T) {
 		f := SetUp(t)
 		defer f.TearDown()
 
-		f.mockPool.ExpectExec(regexp.QuoteMeta(`DELETE FROM products WHERE id = $1`)).
-			WithArgs(uint64(1)).
+		f.mockPool.ExpectExec(regexp.QuoteMeta(`DELETE FROM products WHERE id = $1 AND tenant_id = $2`)).
+			WithArgs(uint64(1), testTenant).
 			WillReturnError(errors.New("internal error"))
 
 		// act
-		err := f.productRepo.DeleteProduct(context.Background(), 1)
+		err := f.productRepo.DeleteProduct(f.ctx, 1)
 
 		// assert
 		assert.EqualError(t, err, "Repository.DeleteProduct: to delete: internal error")
@@ -157,12 +241,18 @@ func TestUpdateProduct(t *testing.T) {
 		f := SetUp(t)
 		defer f.TearDown()
 
-		f.mockPool.ExpectExec(regexp.QuoteMeta(`UPDATE products SET name = $1, price = $2, quantity = $3 WHERE id = $4`)).
-			WithArgs("product1", uint64(1), uint64(1), uint64(1)).
+		f.mockPool.ExpectBegin()
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, price, quantity, status, unit, barcode, category, attributes FROM products WHERE id = $1 AND tenant_id = $2 FOR UPDATE`)).
+			WithArgs(uint64(1), testTenant).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "price", "quantity", "status"}).
+				AddRow(uint64(1), "product1", uint64(1), uint64(1), products.StatusActive))
+		f.mockPool.ExpectExec(regexp.QuoteMeta(`UPDATE products SET name = $1, price = $2, quantity = $3, barcode = $4, category = $5, attributes = $6 WHERE id = $7`)).
+			WithArgs("product1", uint64(1), uint64(1), "", "", attributes.Attributes{}, uint64(1)).
 			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		f.mockPool.ExpectCommit()
 
 		// act
-		res, err := f.productRepo.UpdateProduct(context.Background(), products.Product{
+		res, err := f.productRepo.UpdateProduct(f.ctx, products.Product{
 			Id:       uint64(1),
 			Name:     "product1",
 			Price:    uint64(1),
@@ -184,12 +274,18 @@ func TestUpdateProduct(t *testing.T) {
 		f := SetUp(t)
 		defer f.TearDown()
 
-		f.mockPool.ExpectExec(regexp.QuoteMeta(`UPDATE products SET name = $1, price = $2, quantity = $3 WHERE id = $4`)).
-			WithArgs("product1", uint64(1), uint64(1), uint64(1)).
+		f.mockPool.ExpectBegin()
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, price, quantity, status, unit, barcode, category, attributes FROM products WHERE id = $1 AND tenant_id = $2 FOR UPDATE`)).
+			WithArgs(uint64(1), testTenant).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "price", "quantity", "status"}).
+				AddRow(uint64(1), "product1", uint64(1), uint64(1), products.StatusActive))
+		f.mockPool.ExpectExec(regexp.QuoteMeta(`UPDATE products SET name = $1, price = $2, quantity = $3, barcode = $4, category = $5, attributes = $6 WHERE id = $7`)).
+			WithArgs("product1", uint64(1), uint64(1), "", "", attributes.Attributes{}, uint64(1)).
 			WillReturnError(errors.New("internal error"))
+		f.mockPool.ExpectRollback()
 
 		// act
-		_, err := f.productRepo.UpdateProduct(context.Background(), products.Product{
+		_, err := f.productRepo.UpdateProduct(f.ctx, products.Product{
 			Id:       uint64(1),
 			Name:     "product1",
 			Price:    uint64(1),
@@ -207,13 +303,14 @@ func TestGetAllProducts(t *testing.T) {
 		f := SetUp(t)
 		defer f.TearDown()
 
-		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, price, quantity FROM products ORDER BY id LIMIT 2 OFFSET 0`)).
-			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "price", "quantity"}).
-				AddRow(uint64(1), "product1", uint64(1), uint64(1)).
-				AddRow(uint64(2), "product2", uint64(2), uint64(2)))
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, price, quantity, status, unit, barcode, category, attributes FROM products WHERE tenant_id = $1 ORDER BY id LIMIT 2 OFFSET 0`)).
+			WithArgs(testTenant).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "price", "quantity", "status"}).
+				AddRow(uint64(1), "product1", uint64(1), uint64(1), products.StatusActive).
+				AddRow(uint64(2), "product2", uint64(2), uint64(2), products.StatusActive))
 
 		// act
-		res, err := f.productRepo.GetAllProducts(context.Background(), uint64(1), uint64(2))
+		res, err := f.productRepo.GetAllProducts(f.ctx, uint64(1), uint64(2))
 
 		// assert
 		require.NoError(t, err)
@@ -223,12 +320,14 @@ func TestGetAllProducts(t *testing.T) {
 				Name:     "product1",
 				Price:    uint64(1),
 				Quantity: uint64(1),
+				Status:   products.StatusActive,
 			},
 			{
 				Id:       uint64(2),
 				Name:     "product2",
 				Price:    uint64(2),
 				Quantity: uint64(2),
+				Status:   products.StatusActive,
 			},
 		})
 	})
@@ -238,11 +337,12 @@ func TestGetAllProducts(t *testing.T) {
 		f := SetUp(t)
 		defer f.TearDown()
 
-		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, price, quantity FROM products ORDER BY id LIMIT 2 OFFSET 0`)).
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, price, quantity, status, unit, barcode, category, attributes FROM products WHERE tenant_id = $1 ORDER BY id LIMIT 2 OFFSET 0`)).
+			WithArgs(testTenant).
 			WillReturnError(errors.New("internal error"))
 
 		// act
-		_, err := f.productRepo.GetAllProducts(context.Background(), uint64(1), uint64(2))
+		_, err := f.productRepo.GetAllProducts(f.ctx, uint64(1), uint64(2))
 
 		// assert
 		assert.EqualError(t, err, "Repository.GetAllProducts: select: scany: query multiple result rows: internal error")
@@ -277,3 +377,238 @@ func TestGetPaginationLimitAndOffset(t *testing.T) {
 		assert.Equal(t, offset, uint64(0))
 	})
 }
+
+func TestGetProductsByStatus(t *testing.T) {
+	t.Run("success getting products by status", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, price, quantity, status, unit, barcode, category, attributes FROM products WHERE tenant_id = $1 AND status = $2 ORDER BY id LIMIT 2 OFFSET 0`)).
+			WithArgs(testTenant, products.StatusDraft).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "price", "quantity", "status"}).
+				AddRow(uint64(1), "product1", uint64(1), uint64(1), products.StatusDraft))
+
+		// act
+		res, err := f.productRepo.GetProductsByStatus(f.ctx, products.StatusDraft, uint64(1), uint64(2))
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, []*products.Product{
+			{
+				Id:       uint64(1),
+				Name:     "product1",
+				Price:    uint64(1),
+				Quantity: uint64(1),
+				Status:   products.StatusDraft,
+			},
+		})
+	})
+}
+
+func TestFindProducts(t *testing.T) {
+	t.Run("success finding products by category and attributes", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		filter := attributes.Attributes{"voltage": float64(220)}
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, price, quantity, status, unit, barcode, category, attributes FROM products WHERE tenant_id = $1 AND status = $2 AND category = $3 AND attributes @> $4 ORDER BY id LIMIT 2 OFFSET 0`)).
+			WithArgs(testTenant, products.StatusActive, "electronics", filter).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "category", "attributes"}).
+				AddRow(uint64(1), "kettle", "electronics", attributes.Attributes{"voltage": float64(220), "wireless": true}))
+
+		// act
+		res, err := f.productRepo.FindProducts(f.ctx, products.Filter{
+			Status:     products.StatusActive,
+			Category:   "electronics",
+			Attributes: filter,
+		}, uint64(1), uint64(2))
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, []*products.Product{
+			{
+				Id:         uint64(1),
+				Name:       "kettle",
+				Category:   "electronics",
+				Attributes: attributes.Attributes{"voltage": float64(220), "wireless": true},
+			},
+		})
+	})
+
+	t.Run("finding without filter", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, price, quantity, status, unit, barcode, category, attributes FROM products WHERE tenant_id = $1 ORDER BY id LIMIT 2 OFFSET 0`)).
+			WithArgs(testTenant).
+			WillReturnError(errors.New("internal error"))
+
+		// act
+		_, err := f.productRepo.FindProducts(f.ctx, products.Filter{}, uint64(1), uint64(2))
+
+		// assert
+		assert.EqualError(t, err, "Repository.FindProducts: select: scany: query multiple result rows: internal error")
+	})
+}
+
+func TestTransitionProductStatus(t *testing.T) {
+	t.Run("success transition", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectBegin()
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, price, quantity, status, unit, barcode, category, attributes FROM products WHERE id = $1 AND tenant_id = $2 FOR UPDATE`)).
+			WithArgs(uint64(1), testTenant).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "price", "quantity", "status"}).
+				AddRow(uint64(1), "product1", uint64(1), uint64(1), products.StatusDraft))
+		f.mockPool.ExpectExec(regexp.QuoteMeta(`UPDATE products SET status = $1 WHERE id = $2`)).
+			WithArgs(products.StatusActive, uint64(1)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		f.mockPool.ExpectExec(regexp.QuoteMeta(`INSERT INTO product_status_transitions (product_id, from_status, to_status, reason, actor) VALUES ($1,$2,$3,$4,$5)`)).
+			WithArgs(uint64(1), products.StatusDraft, products.StatusActive, "ready for sale", "admin").
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		f.mockPool.ExpectCommit()
+
+		// act
+		res, err := f.productRepo.TransitionProductStatus(f.ctx, 1, products.StatusActive, "ready for sale", "admin")
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &products.Product{
+			Id:       uint64(1),
+			Name:     "product1",
+			Price:    uint64(1),
+			Quantity: uint64(1),
+			Status:   products.StatusActive,
+		})
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("invalid transition", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectBegin()
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, price, quantity, status, unit, barcode, category, attributes FROM products WHERE id = $1 AND tenant_id = $2 FOR UPDATE`)).
+			WithArgs(uint64(1), testTenant).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "price", "quantity", "status"}).
+				AddRow(uint64(1), "product1", uint64(1), uint64(1), products.StatusArchived))
+		f.mockPool.ExpectRollback()
+
+		// act
+		_, err := f.productRepo.TransitionProductStatus(f.ctx, 1, products.StatusDraft, "reason", "admin")
+
+		// assert
+		assert.EqualError(t, err, "archived -> draft: invalid status transition")
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("product does not exists", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectBegin()
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, price, quantity, status, unit, barcode, category, attributes FROM products WHERE id = $1 AND tenant_id = $2 FOR UPDATE`)).
+			WithArgs(uint64(1), testTenant).
+			WillReturnError(pgx.ErrNoRows)
+		f.mockPool.ExpectRollback()
+
+		// act
+		_, err := f.productRepo.TransitionProductStatus(f.ctx, 1, products.StatusActive, "reason", "admin")
+
+		// assert
+		assert.EqualError(t, err, "1: product does not exist")
+	})
+}
+
+func TestReserveProduct(t *testing.T) {
+	t.Run("success reserving product", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectBegin()
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, price, quantity, status, unit, barcode, category, attributes FROM products WHERE id = $1 AND tenant_id = $2 FOR UPDATE`)).
+			WithArgs(uint64(1), testTenant).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "price", "quantity", "status"}).
+				AddRow(uint64(1), "product1", uint64(1), uint64(5), products.StatusActive))
+		f.mockPool.ExpectExec(regexp.QuoteMeta(`UPDATE products SET quantity = $1 WHERE id = $2`)).
+			WithArgs(uint64(2), uint64(1)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, product_id, number, quantity, expires_at FROM product_lots WHERE product_id = $1 AND quantity > 0 ORDER BY expires_at, id FOR UPDATE`)).
+			WithArgs(uint64(1)).
+			WillReturnRows(pgxmock.NewRows(lotRows).
+				AddRow(uint64(2), uint64(1), "B", uint64(2), time.Date(2122, 10, 1, 0, 0, 0, 0, time.UTC)).
+				AddRow(uint64(1), uint64(1), "A", uint64(2), time.Date(2122, 11, 1, 0, 0, 0, 0, time.UTC)))
+		f.mockPool.ExpectExec(regexp.QuoteMeta(`UPDATE product_lots SET quantity = $1 WHERE id = $2`)).
+			WithArgs(uint64(0), uint64(2)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		f.mockPool.ExpectExec(regexp.QuoteMeta(`UPDATE product_lots SET quantity = $1 WHERE id = $2`)).
+			WithArgs(uint64(1), uint64(1)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		f.mockPool.ExpectCommit()
+
+		// act
+		res, picked, err := f.productRepo.ReserveProduct(f.ctx, 1, 3)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res.Quantity, uint64(2))
+		assert.Equal(t, picked, []lots.Picked{{LotId: uint64(2), Quantity: uint64(2)}, {LotId: uint64(1), Quantity: uint64(1)}})
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("reserving not active product", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectBegin()
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, price, quantity, status, unit, barcode, category, attributes FROM products WHERE id = $1 AND tenant_id = $2 FOR UPDATE`)).
+			WithArgs(uint64(1), testTenant).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "price", "quantity", "status"}).
+				AddRow(uint64(1), "product1", uint64(1), uint64(5), products.StatusDraft))
+		f.mockPool.ExpectRollback()
+
+		// act
+		_, _, err := f.productRepo.ReserveProduct(f.ctx, 1, 3)
+
+		// assert
+		assert.EqualError(t, err, "1: product is not active")
+	})
+}
+
+func TestReleaseProduct(t *testing.T) {
+	t.Run("success releasing product", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectBegin()
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, price, quantity, status, unit, barcode, category, attributes FROM products WHERE id = $1 AND tenant_id = $2 FOR UPDATE`)).
+			WithArgs(uint64(1), testTenant).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "price", "quantity", "status"}).
+				AddRow(uint64(1), "product1", uint64(1), uint64(2), products.StatusActive))
+		f.mockPool.ExpectExec(regexp.QuoteMeta(`UPDATE products SET quantity = $1 WHERE id = $2`)).
+			WithArgs(uint64(5), uint64(1)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		f.mockPool.ExpectExec(regexp.QuoteMeta(`UPDATE product_lots SET quantity = quantity + $1 WHERE id = $2 AND product_id = $3`)).
+			WithArgs(uint64(3), uint64(2), uint64(1)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		f.mockPool.ExpectCommit()
+
+		// act
+		res, err := f.productRepo.ReleaseProduct(f.ctx, 1, 3, []lots.Picked{{LotId: uint64(2), Quantity: uint64(3)}})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res.Quantity, uint64(5))
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+}
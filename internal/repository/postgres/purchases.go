@@ -0,0 +1,344 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/pkg/errors"
+	"homework-1/internal/models/purchases"
+	"homework-1/internal/repository"
+	"homework-1/internal/tenants"
+	"strconv"
+)
+
+const (
+	supplierColumns      = "id, name, contact"
+	purchaseOrderColumns = "id, supplier_id, status, created_at, received_at"
+	lineColumns          = "id, order_id, product_id, quantity, received"
+)
+
+func (r *Repository) CreateSupplier(ctx context.Context, supplier purchases.Supplier) (*purchases.Supplier, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.CreateSupplier: %w", err)
+	}
+
+	query, args, err := psql.Insert("suppliers").
+		Columns("name, contact, tenant_id").
+		Values(supplier.Name, supplier.Contact, tenant).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.CreateSupplier: to sql: %w", err)
+	}
+
+	row := r.pool.QueryRow(ctx, query, args...)
+	if err = row.Scan(&supplier.Id); err != nil {
+		return nil, fmt.Errorf("Repository.CreateSupplier: insert: %w", err)
+	}
+
+	return &supplier, nil
+}
+
+func (r *Repository) GetSupplierById(ctx context.Context, id uint64) (*purchases.Supplier, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetSupplierById: %w", err)
+	}
+
+	query, args, err := psql.Select(supplierColumns).
+		From("suppliers").
+		Where(squirrel.Eq{"id": id, "tenant_id": tenant}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetSupplierById: to sql: %w", err)
+	}
+
+	var supplier purchases.Supplier
+	if err = pgxscan.Get(ctx, r.pool, &supplier, query, args...); err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, errors.Wrap(repository.SupplierNotExists, strconv.FormatUint(id, 10))
+		}
+		return nil, fmt.Errorf("Repository.GetSupplierById: select: %w", err)
+	}
+
+	return &supplier, nil
+}
+
+func (r *Repository) GetAllSuppliers(ctx context.Context, page uint64, size uint64) ([]*purchases.Supplier, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetAllSuppliers: %w", err)
+	}
+	limit, offset := r.getPaginationLimitAndOffset(page, size)
+
+	query, args, err := psql.Select(supplierColumns).
+		From("suppliers").
+		Where(squirrel.Eq{"tenant_id": tenant}).
+		OrderBy("id").
+		Limit(limit).
+		Offset(offset).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetAllSuppliers: to sql: %w", err)
+	}
+
+	var suppliers []*purchases.Supplier
+	if err = pgxscan.Select(ctx, r.pool, &suppliers, query, args...); err != nil {
+		return nil, fmt.Errorf("Repository.GetAllSuppliers: select: %w", err)
+	}
+
+	return suppliers, nil
+}
+
+func (r *Repository) CreatePurchaseOrder(ctx context.Context, order purchases.PurchaseOrder) (*purchases.PurchaseOrder, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.CreatePurchaseOrder: %w", err)
+	}
+	if _, err = r.GetSupplierById(ctx, order.SupplierId); err != nil {
+		return nil, err
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.CreatePurchaseOrder: begin: %w", err)
+	}
+	defer tx.Rollback(ctx) // no-op after commit
+
+	productIds := make([]uint64, 0, len(order.Lines))
+	for _, line := range order.Lines {
+		productIds = append(productIds, line.ProductId)
+	}
+	if err = r.checkProducts(ctx, tx, productIds...); err != nil {
+		return nil, err
+	}
+
+	query, args, err := psql.Insert("purchase_orders").
+		Columns("supplier_id, status, created_at, tenant_id").
+		Values(order.SupplierId, order.Status, order.CreatedAt, tenant).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.CreatePurchaseOrder: to sql: %w", err)
+	}
+
+	created := order.Copy()
+	if err = tx.QueryRow(ctx, query, args...).Scan(&created.Id); err != nil {
+		if isForeignKeyViolation(err) {
+			return nil, errors.Wrap(repository.SupplierNotExists, strconv.FormatUint(order.SupplierId, 10))
+		}
+		return nil, fmt.Errorf("Repository.CreatePurchaseOrder: insert order: %w", err)
+	}
+
+	for _, line := range created.Lines {
+		line.OrderId = created.Id
+
+		query, args, err = psql.Insert("purchase_order_lines").
+			Columns("order_id, product_id, quantity, received").
+			Values(line.OrderId, line.ProductId, line.Quantity, line.Received).
+			Suffix("RETURNING id").
+			ToSql()
+		if err != nil {
+			return nil, fmt.Errorf("Repository.CreatePurchaseOrder: to sql: %w", err)
+		}
+
+		if err = tx.QueryRow(ctx, query, args...).Scan(&line.Id); err != nil {
+			if isForeignKeyViolation(err) {
+				return nil, errors.Wrap(repository.ProductNotExists, strconv.FormatUint(line.ProductId, 10))
+			}
+			return nil, fmt.Errorf("Repository.CreatePurchaseOrder: insert line: %w", err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("Repository.CreatePurchaseOrder: commit: %w", err)
+	}
+	return created, nil
+}
+
+func (r *Repository) GetPurchaseOrderById(ctx context.Context, id uint64) (*purchases.PurchaseOrder, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetPurchaseOrderById: %w", err)
+	}
+
+	query, args, err := psql.Select(purchaseOrderColumns).
+		From("purchase_orders").
+		Where(squirrel.Eq{"id": id, "tenant_id": tenant}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetPurchaseOrderById: to sql: %w", err)
+	}
+
+	var order purchases.PurchaseOrder
+	if err = pgxscan.Get(ctx, r.pool, &order, query, args...); err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, errors.Wrap(repository.PurchaseOrderNotExists, strconv.FormatUint(id, 10))
+		}
+		return nil, fmt.Errorf("Repository.GetPurchaseOrderById: select: %w", err)
+	}
+
+	if order.Lines, err = r.getLines(ctx, r.pool, id); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+func (r *Repository) GetOpenPurchaseOrders(ctx context.Context, page uint64, size uint64) ([]*purchases.PurchaseOrder, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetOpenPurchaseOrders: %w", err)
+	}
+	limit, offset := r.getPaginationLimitAndOffset(page, size)
+
+	query, args, err := psql.Select(purchaseOrderColumns).
+		From("purchase_orders").
+		Where(squirrel.Eq{"status": []purchases.Status{purchases.StatusOpen, purchases.StatusPartiallyReceived}, "tenant_id": tenant}).
+		OrderBy("id").
+		Limit(limit).
+		Offset(offset).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetOpenPurchaseOrders: to sql: %w", err)
+	}
+
+	var orders []*purchases.PurchaseOrder
+	if err = pgxscan.Select(ctx, r.pool, &orders, query, args...); err != nil {
+		return nil, fmt.Errorf("Repository.GetOpenPurchaseOrders: select: %w", err)
+	}
+	if len(orders) == 0 {
+		return orders, nil
+	}
+
+	orderIds := make([]uint64, 0, len(orders))
+	ordersById := make(map[uint64]*purchases.PurchaseOrder, len(orders))
+	for _, order := range orders {
+		orderIds = append(orderIds, order.Id)
+		ordersById[order.Id] = order
+	}
+
+	query, args, err = psql.Select(lineColumns).
+		From("purchase_order_lines").
+		Where(squirrel.Eq{"order_id": orderIds}).
+		OrderBy("id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetOpenPurchaseOrders: to sql: %w", err)
+	}
+
+	var lines []*purchases.Line
+	if err = pgxscan.Select(ctx, r.pool, &lines, query, args...); err != nil {
+		return nil, fmt.Errorf("Repository.GetOpenPurchaseOrders: select lines: %w", err)
+	}
+	for _, line := range lines {
+		order := ordersById[line.OrderId]
+		order.Lines = append(order.Lines, line)
+	}
+
+	return orders, nil
+}
+
+func (r *Repository) ReceivePurchaseOrder(ctx context.Context, id uint64, receipts map[uint64]uint64) (*purchases.PurchaseOrder, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.ReceivePurchaseOrder: %w", err)
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.ReceivePurchaseOrder: begin: %w", err)
+	}
+	defer tx.Rollback(ctx) // no-op after commit
+
+	query, args, err := psql.Select(purchaseOrderColumns).
+		From("purchase_orders").
+		Where(squirrel.Eq{"id": id, "tenant_id": tenant}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.ReceivePurchaseOrder: to sql: %w", err)
+	}
+
+	var order purchases.PurchaseOrder
+	if err = pgxscan.Get(ctx, tx, &order, query, args...); err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, errors.Wrap(repository.PurchaseOrderNotExists, strconv.FormatUint(id, 10))
+		}
+		return nil, fmt.Errorf("Repository.ReceivePurchaseOrder: select: %w", err)
+	}
+
+	if order.Lines, err = r.getLines(ctx, tx, id); err != nil {
+		return nil, err
+	}
+
+	received, err := order.Receive(receipts)
+	if err != nil {
+		return nil, err
+	}
+
+	// lines are walked in id order so concurrent receipts lock products in the same order
+	for _, line := range order.Lines {
+		quantity, ok := received[line.ProductId]
+		if !ok {
+			continue
+		}
+
+		query, args, err = psql.Update("purchase_order_lines").
+			Set("received", line.Received).
+			Where(squirrel.Eq{"id": line.Id}).
+			ToSql()
+		if err != nil {
+			return nil, fmt.Errorf("Repository.ReceivePurchaseOrder: to sql: %w", err)
+		}
+		if _, err = tx.Exec(ctx, query, args...); err != nil {
+			return nil, fmt.Errorf("Repository.ReceivePurchaseOrder: update line: %w", err)
+		}
+
+		query, args, err = psql.Update("products").
+			Set("quantity", squirrel.Expr("quantity + ?", quantity)).
+			Where(squirrel.Eq{"id": line.ProductId}).
+			ToSql()
+		if err != nil {
+			return nil, fmt.Errorf("Repository.ReceivePurchaseOrder: to sql: %w", err)
+		}
+		if _, err = tx.Exec(ctx, query, args...); err != nil {
+			return nil, fmt.Errorf("Repository.ReceivePurchaseOrder: update product: %w", err)
+		}
+	}
+
+	query, args, err = psql.Update("purchase_orders").
+		Set("status", order.Status).
+		Set("received_at", order.ReceivedAt).
+		Where(squirrel.Eq{"id": order.Id}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.ReceivePurchaseOrder: to sql: %w", err)
+	}
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		return nil, fmt.Errorf("Repository.ReceivePurchaseOrder: update order: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("Repository.ReceivePurchaseOrder: commit: %w", err)
+	}
+	return &order, nil
+}
+
+func (r *Repository) getLines(ctx context.Context, db pgxscan.Querier, orderId uint64) ([]*purchases.Line, error) {
+	query, args, err := psql.Select(lineColumns).
+		From("purchase_order_lines").
+		Where(squirrel.Eq{"order_id": orderId}).
+		OrderBy("id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.getLines: to sql: %w", err)
+	}
+
+	var lines []*purchases.Line
+	if err = pgxscan.Select(ctx, db, &lines, query, args...); err != nil {
+		return nil, fmt.Errorf("Repository.getLines: select: %w", err)
+	}
+	return lines, nil
+}
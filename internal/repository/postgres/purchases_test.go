@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"github.com/jackc/pgx/v4"
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"homework-1/internal/models/purchases"
+	"regexp"
+	"testing"
+	"time"
+)
+
+var (
+	supplierRows      = []string{"id", "name", "contact"}
+	purchaseOrderRows = []string{"id", "supplier_id", "status", "created_at", "received_at"}
+	lineRows          = []string{"id", "order_id", "product_id", "quantity", "received"}
+)
+
+func TestCreatePurchaseOrder(t *testing.T) {
+	createdAt := time.Date(2022, 9, 14, 10, 0, 0, 0, time.UTC)
+
+	t.Run("success creating purchase order", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, contact FROM suppliers WHERE id = $1 AND tenant_id = $2`)).
+			WithArgs(uint64(1), testTenant).
+			WillReturnRows(pgxmock.NewRows(supplierRows).AddRow(uint64(1), "supplier1", "contact1"))
+		f.mockPool.ExpectBegin()
+		f.expectProducts([]uint64{2}, 2)
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`INSERT INTO purchase_orders (supplier_id, status, created_at, tenant_id) VALUES ($1,$2,$3,$4) RETURNING id`)).
+			WithArgs(uint64(1), purchases.StatusOpen, createdAt, testTenant).
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint64(1)))
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`INSERT INTO purchase_order_lines (order_id, product_id, quantity, received) VALUES ($1,$2,$3,$4) RETURNING id`)).
+			WithArgs(uint64(1), uint64(2), uint64(5), uint64(0)).
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint64(3)))
+		f.mockPool.ExpectCommit()
+
+		// act
+		res, err := f.purchaseRepo.CreatePurchaseOrder(f.ctx, purchases.PurchaseOrder{
+			SupplierId: uint64(1),
+			Status:     purchases.StatusOpen,
+			CreatedAt:  createdAt,
+			Lines:      []*purchases.Line{{ProductId: uint64(2), Quantity: uint64(5)}},
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res.Id, uint64(1))
+		assert.Equal(t, res.Lines, []*purchases.Line{{Id: uint64(3), OrderId: uint64(1), ProductId: uint64(2), Quantity: uint64(5)}})
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("supplier does not exists", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, contact FROM suppliers WHERE id = $1 AND tenant_id = $2`)).
+			WithArgs(uint64(1), testTenant).
+			WillReturnRows(pgxmock.NewRows(supplierRows))
+
+		// act
+		_, err := f.purchaseRepo.CreatePurchaseOrder(f.ctx, purchases.PurchaseOrder{
+			SupplierId: uint64(1),
+			Status:     purchases.StatusOpen,
+			CreatedAt:  createdAt,
+		})
+
+		// assert
+		assert.EqualError(t, err, "1: supplier does not exist")
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("product of another tenant", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, contact FROM suppliers WHERE id = $1 AND tenant_id = $2`)).
+			WithArgs(uint64(1), testTenant).
+			WillReturnRows(pgxmock.NewRows(supplierRows).AddRow(uint64(1), "supplier1", "contact1"))
+		f.mockPool.ExpectBegin()
+		f.expectProducts([]uint64{2})
+		f.mockPool.ExpectRollback()
+
+		// act
+		_, err := f.purchaseRepo.CreatePurchaseOrder(f.ctx, purchases.PurchaseOrder{
+			SupplierId: uint64(1),
+			Status:     purchases.StatusOpen,
+			CreatedAt:  createdAt,
+			Lines:      []*purchases.Line{{ProductId: uint64(2), Quantity: uint64(5)}},
+		})
+
+		// assert
+		assert.EqualError(t, err, "2: product does not exist")
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestReceivePurchaseOrder(t *testing.T) {
+	createdAt := time.Date(2022, 9, 14, 10, 0, 0, 0, time.UTC)
+
+	t.Run("success receiving purchase order", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectBegin()
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, supplier_id, status, created_at, received_at FROM purchase_orders WHERE id = $1 AND tenant_id = $2 FOR UPDATE`)).
+			WithArgs(uint64(1), testTenant).
+			WillReturnRows(pgxmock.NewRows(purchaseOrderRows).
+				AddRow(uint64(1), uint64(1), purchases.StatusOpen, createdAt, (*time.Time)(nil)))
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, order_id, product_id, quantity, received FROM purchase_order_lines WHERE order_id = $1 ORDER BY id`)).
+			WithArgs(uint64(1)).
+			WillReturnRows(pgxmock.NewRows(lineRows).
+				AddRow(uint64(1), uint64(1), uint64(2), uint64(5), uint64(0)).
+				AddRow(uint64(2), uint64(1), uint64(3), uint64(4), uint64(0)))
+		f.mockPool.ExpectExec(regexp.QuoteMeta(`UPDATE purchase_order_lines SET received = $1 WHERE id = $2`)).
+			WithArgs(uint64(2), uint64(1)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		f.mockPool.ExpectExec(regexp.QuoteMeta(`UPDATE products SET quantity = quantity + $1 WHERE id = $2`)).
+			WithArgs(uint64(2), uint64(2)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		f.mockPool.ExpectExec(regexp.QuoteMeta(`UPDATE purchase_orders SET status = $1, received_at = $2 WHERE id = $3`)).
+			WithArgs(purchases.StatusPartiallyReceived, (*time.Time)(nil), uint64(1)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		f.mockPool.ExpectCommit()
+
+		// act
+		res, err := f.purchaseRepo.ReceivePurchaseOrder(f.ctx, uint64(1), map[uint64]uint64{2: 2})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res.Status, purchases.StatusPartiallyReceived)
+		assert.Equal(t, res.Lines[0].Received, uint64(2))
+		assert.Equal(t, res.Lines[1].Received, uint64(0))
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("over receipt", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectBegin()
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, supplier_id, status, created_at, received_at FROM purchase_orders`)).
+			WithArgs(uint64(1), testTenant).
+			WillReturnRows(pgxmock.NewRows(purchaseOrderRows).
+				AddRow(uint64(1), uint64(1), purchases.StatusOpen, createdAt, (*time.Time)(nil)))
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, order_id, product_id, quantity, received FROM purchase_order_lines`)).
+			WithArgs(uint64(1)).
+			WillReturnRows(pgxmock.NewRows(lineRows).
+				AddRow(uint64(1), uint64(1), uint64(2), uint64(5), uint64(4)))
+		f.mockPool.ExpectRollback()
+
+		// act
+		_, err := f.purchaseRepo.ReceivePurchaseOrder(f.ctx, uint64(1), map[uint64]uint64{2: 2})
+
+		// assert
+		assert.ErrorIs(t, err, purchases.ErrOverReceipt)
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("purchase order does not exists", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectBegin()
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, supplier_id, status, created_at, received_at FROM purchase_orders`)).
+			WithArgs(uint64(1), testTenant).
+			WillReturnError(pgx.ErrNoRows)
+		f.mockPool.ExpectRollback()
+
+		// act
+		_, err := f.purchaseRepo.ReceivePurchaseOrder(f.ctx, uint64(1), nil)
+
+		// assert
+		assert.EqualError(t, err, "1: purchase order does not exist")
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+}
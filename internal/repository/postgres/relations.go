@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/pkg/errors"
+	"homework-1/internal/models/relations"
+	"homework-1/internal/repository"
+	"homework-1/internal/tenants"
+)
+
+const relationColumns = "product_id, related_id, type"
+
+func (r *Repository) CreateRelation(ctx context.Context, relation relations.Relation) (*relations.Relation, error) {
+	if err := r.checkProducts(ctx, r.pool, relation.ProductId, relation.RelatedId); err != nil {
+		return nil, err
+	}
+
+	query, args, err := psql.Insert("product_relations").
+		Columns(relationColumns).
+		Values(relation.ProductId, relation.RelatedId, relation.Type).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.CreateRelation: to sql: %w", err)
+	}
+
+	if _, err = r.pool.Exec(ctx, query, args...); err != nil {
+		switch {
+		case isForeignKeyViolation(err):
+			return nil, errors.Wrap(repository.ProductNotExists, relationKey(relation.ProductId, relation.RelatedId))
+		case isUniqueViolation(err):
+			return nil, errors.Wrap(repository.RelationAlreadyExists, relationKey(relation.ProductId, relation.RelatedId))
+		}
+		return nil, fmt.Errorf("Repository.CreateRelation: insert: %w", err)
+	}
+
+	return &relation, nil
+}
+
+func (r *Repository) UpdateRelation(ctx context.Context, relation relations.Relation) (*relations.Relation, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.UpdateRelation: %w", err)
+	}
+
+	query, args, err := psql.Update("product_relations").
+		Set("type", relation.Type).
+		Where(squirrel.Eq{"product_id": relation.ProductId, "related_id": relation.RelatedId}).
+		Where(ofTenantProducts("product_id", tenant)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.UpdateRelation: to sql: %w", err)
+	}
+
+	tag, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.UpdateRelation: update: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, errors.Wrap(repository.RelationNotExists, relationKey(relation.ProductId, relation.RelatedId))
+	}
+
+	return &relation, nil
+}
+
+func (r *Repository) DeleteRelation(ctx context.Context, productId uint64, relatedId uint64) error {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("Repository.DeleteRelation: %w", err)
+	}
+
+	query, args, err := psql.Delete("product_relations").
+		Where(squirrel.Eq{"product_id": productId, "related_id": relatedId}).
+		Where(ofTenantProducts("product_id", tenant)).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Repository.DeleteRelation: to sql: %w", err)
+	}
+
+	tag, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("Repository.DeleteRelation: delete: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.Wrap(repository.RelationNotExists, relationKey(productId, relatedId))
+	}
+	return nil
+}
+
+func (r *Repository) GetProductRelations(ctx context.Context, productId uint64) ([]*relations.Relation, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetProductRelations: %w", err)
+	}
+
+	query, args, err := psql.Select(relationColumns).
+		From("product_relations").
+		Where(squirrel.Eq{"product_id": productId}).
+		Where(ofTenantProducts("product_id", tenant)).
+		OrderBy("related_id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetProductRelations: to sql: %w", err)
+	}
+
+	var productRelations []*relations.Relation
+	if err = pgxscan.Select(ctx, r.pool, &productRelations, query, args...); err != nil {
+		return nil, fmt.Errorf("Repository.GetProductRelations: select: %w", err)
+	}
+
+	return productRelations, nil
+}
+
+func (r *Repository) GetRelatedProducts(ctx context.Context, productId uint64, relationType relations.Type) ([]*relations.RelatedProduct, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetRelatedProducts: %w", err)
+	}
+
+	builder := psql.Select(productColumns, "product_relations.type AS relation_type").
+		From("products").
+		Join("product_relations ON product_relations.related_id = products.id").
+		Where(squirrel.Eq{"product_relations.product_id": productId, "products.tenant_id": tenant}).
+		OrderBy("products.id")
+	if relationType != "" {
+		builder = builder.Where(squirrel.Eq{"product_relations.type": relationType})
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetRelatedProducts: to sql: %w", err)
+	}
+
+	var related []*relations.RelatedProduct
+	if err = pgxscan.Select(ctx, r.pool, &related, query, args...); err != nil {
+		return nil, fmt.Errorf("Repository.GetRelatedProducts: select: %w", err)
+	}
+
+	return related, nil
+}
+
+func (r *Repository) CountProductRelations(ctx context.Context, productId uint64) (uint64, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Repository.CountProductRelations: %w", err)
+	}
+
+	query, args, err := psql.Select("count(*)").
+		From("product_relations").
+		Where(squirrel.Or{
+			squirrel.Eq{"product_id": productId},
+			squirrel.Eq{"related_id": productId},
+		}).
+		Where(ofTenantProducts("product_id", tenant)).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("Repository.CountProductRelations: to sql: %w", err)
+	}
+
+	var count uint64
+	if err = r.pool.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("Repository.CountProductRelations: select: %w", err)
+	}
+
+	return count, nil
+}
+
+func relationKey(productId uint64, relatedId uint64) string {
+	return fmt.Sprintf("%d -> %d", productId, relatedId)
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"github.com/jackc/pgconn"
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"homework-1/internal/models/products"
+	"homework-1/internal/models/relations"
+	"regexp"
+	"testing"
+)
+
+func TestCreateRelation(t *testing.T) {
+	t.Run("success creating relation", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.expectProducts([]uint64{1, 2}, 1, 2)
+		f.mockPool.ExpectExec(regexp.QuoteMeta(`INSERT INTO product_relations (product_id, related_id, type) VALUES ($1,$2,$3)`)).
+			WithArgs(uint64(1), uint64(2), relations.TypeAccessory).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+		// act
+		res, err := f.relationRepo.CreateRelation(f.ctx, relations.Relation{
+			ProductId: uint64(1),
+			RelatedId: uint64(2),
+			Type:      relations.TypeAccessory,
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res.Type, relations.TypeAccessory)
+	})
+
+	t.Run("product does not exist", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.expectProducts([]uint64{1, 2}, 1, 2)
+		f.mockPool.ExpectExec(regexp.QuoteMeta(`INSERT INTO product_relations`)).
+			WithArgs(uint64(1), uint64(2), relations.TypeAccessory).
+			WillReturnError(&pgconn.PgError{Code: foreignKeyViolation})
+
+		// act
+		_, err := f.relationRepo.CreateRelation(f.ctx, relations.Relation{
+			ProductId: uint64(1),
+			RelatedId: uint64(2),
+			Type:      relations.TypeAccessory,
+		})
+
+		// assert
+		assert.EqualError(t, err, "1 -> 2: product does not exist")
+	})
+
+	t.Run("relation already exists", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.expectProducts([]uint64{1, 2}, 1, 2)
+		f.mockPool.ExpectExec(regexp.QuoteMeta(`INSERT INTO product_relations`)).
+			WithArgs(uint64(1), uint64(2), relations.TypeAccessory).
+			WillReturnError(&pgconn.PgError{Code: uniqueViolation})
+
+		// act
+		_, err := f.relationRepo.CreateRelation(f.ctx, relations.Relation{
+			ProductId: uint64(1),
+			RelatedId: uint64(2),
+			Type:      relations.TypeAccessory,
+		})
+
+		// assert
+		assert.EqualError(t, err, "1 -> 2: relation already exists")
+	})
+
+	t.Run("related product of another tenant", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.expectProducts([]uint64{1, 2}, 1)
+
+		// act
+		_, err := f.relationRepo.CreateRelation(f.ctx, relations.Relation{
+			ProductId: uint64(1),
+			RelatedId: uint64(2),
+			Type:      relations.TypeAccessory,
+		})
+
+		// assert
+		assert.EqualError(t, err, "2: product does not exist")
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestUpdateRelation(t *testing.T) {
+	t.Run("relation does not exist", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectExec(regexp.QuoteMeta(`UPDATE product_relations SET type = $1 WHERE product_id = $2 AND related_id = $3 AND product_id IN (SELECT id FROM products WHERE tenant_id = $4)`)).
+			WithArgs(relations.TypeReplacement, uint64(1), uint64(2), testTenant).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+		// act
+		_, err := f.relationRepo.UpdateRelation(f.ctx, relations.Relation{
+			ProductId: uint64(1),
+			RelatedId: uint64(2),
+			Type:      relations.TypeReplacement,
+		})
+
+		// assert
+		assert.EqualError(t, err, "1 -> 2: relation does not exist")
+	})
+}
+
+func TestGetRelatedProducts(t *testing.T) {
+	t.Run("success getting related products by type", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, price, quantity, status, unit, barcode, category, attributes, product_relations.type AS relation_type FROM products JOIN product_relations ON product_relations.related_id = products.id WHERE product_relations.product_id = $1 AND products.tenant_id = $2 AND product_relations.type = $3 ORDER BY products.id`)).
+			WithArgs(uint64(1), testTenant, relations.TypeAccessory).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "price", "quantity", "status", "relation_type"}).
+				AddRow(uint64(2), "cable", uint64(1), uint64(1), products.StatusActive, relations.TypeAccessory))
+
+		// act
+		res, err := f.relationRepo.GetRelatedProducts(f.ctx, uint64(1), relations.TypeAccessory)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, []*relations.RelatedProduct{{
+			Product: products.Product{Id: uint64(2), Name: "cable", Price: uint64(1), Quantity: uint64(1), Status: products.StatusActive},
+			Type:    relations.TypeAccessory,
+		}})
+	})
+}
+
+func TestCountProductRelations(t *testing.T) {
+	t.Run("counts both directions", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM product_relations WHERE (product_id = $1 OR related_id = $2) AND product_id IN (SELECT id FROM products WHERE tenant_id = $3)`)).
+			WithArgs(uint64(1), uint64(1), testTenant).
+			WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(uint64(3)))
+
+		// act
+		res, err := f.relationRepo.CountProductRelations(f.ctx, uint64(1))
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, uint64(3))
+	})
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/pgxscan"
+	"homework-1/internal/models/reports"
+	"homework-1/internal/models/units"
+	"homework-1/internal/tenants"
+	"strings"
+)
+
+// valueExpr is the stock value of a product, see reports.ValueOf.
+var valueExpr = "price * quantity / " + unitScaleExpr()
+
+// unitScaleExpr is the number of steps in one whole unit of the product.
+func unitScaleExpr() string {
+	var b strings.Builder
+	b.WriteString("CASE unit")
+	for _, unit := range units.All() {
+		if scale := unit.Scale(); scale != 1 {
+			fmt.Fprintf(&b, " WHEN '%s' THEN %d", unit, scale)
+		}
+	}
+	b.WriteString(" ELSE 1 END")
+	return b.String()
+}
+
+func (r *Repository) GetStockValuation(ctx context.Context) (*reports.Valuation, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetStockValuation: %w", err)
+	}
+
+	query, args, err := psql.Select(
+		"COALESCE(SUM("+valueExpr+"), 0)::bigint AS total_value",
+		"COALESCE(SUM(quantity), 0)::bigint AS total_quantity",
+		"COUNT(*) AS product_count",
+		"COUNT(*) FILTER (WHERE quantity = 0) AS zero_stock_count",
+	).
+		From("products").
+		Where(squirrel.Eq{"tenant_id": tenant}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetStockValuation: to sql: %w", err)
+	}
+
+	var valuation reports.Valuation
+	if err = pgxscan.Get(ctx, r.pool, &valuation, query, args...); err != nil {
+		return nil, fmt.Errorf("Repository.GetStockValuation: select: %w", err)
+	}
+
+	return &valuation, nil
+}
+
+func (r *Repository) GetValueByStatus(ctx context.Context) ([]*reports.StatusValue, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetValueByStatus: %w", err)
+	}
+
+	query, args, err := psql.Select(
+		"status",
+		"COUNT(*) AS product_count",
+		"SUM(quantity)::bigint AS quantity",
+		"SUM("+valueExpr+")::bigint AS value",
+	).
+		From("products").
+		Where(squirrel.Eq{"tenant_id": tenant}).
+		GroupBy("status").
+		OrderBy("status").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetValueByStatus: to sql: %w", err)
+	}
+
+	var values []*reports.StatusValue
+	if err = pgxscan.Select(ctx, r.pool, &values, query, args...); err != nil {
+		return nil, fmt.Errorf("Repository.GetValueByStatus: select: %w", err)
+	}
+
+	return values, nil
+}
+
+func (r *Repository) GetValueByCategory(ctx context.Context) ([]*reports.CategoryValue, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetValueByCategory: %w", err)
+	}
+
+	query, args, err := psql.Select(
+		"category",
+		"COUNT(*) AS product_count",
+		"SUM(quantity)::bigint AS quantity",
+		"SUM("+valueExpr+")::bigint AS value",
+	).
+		From("products").
+		Where(squirrel.Eq{"tenant_id": tenant}).
+		GroupBy("category").
+		OrderBy("category").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetValueByCategory: to sql: %w", err)
+	}
+
+	var values []*reports.CategoryValue
+	if err = pgxscan.Select(ctx, r.pool, &values, query, args...); err != nil {
+		return nil, fmt.Errorf("Repository.GetValueByCategory: select: %w", err)
+	}
+
+	return values, nil
+}
+
+func (r *Repository) GetTopProductsByValue(ctx context.Context, limit uint64) ([]*reports.ProductValue, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetTopProductsByValue: %w", err)
+	}
+	if limit == 0 {
+		limit = reports.DefaultTopLimit
+	}
+
+	if limit == 0 {
+		limit = reports.DefaultTopLimit
+	}
+
+	query, args, err := psql.Select("id AS product_id, name, price, quantity, unit, "+valueExpr+" AS value").
+		From("products").
+		Where(squirrel.Eq{"tenant_id": tenant}).
+		OrderBy("value DESC", "id").
+		Limit(limit).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetTopProductsByValue: to sql: %w", err)
+	}
+
+	var values []*reports.ProductValue
+	if err = pgxscan.Select(ctx, r.pool, &values, query, args...); err != nil {
+		return nil, fmt.Errorf("Repository.GetTopProductsByValue: select: %w", err)
+	}
+
+	return values, nil
+}
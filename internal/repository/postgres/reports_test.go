@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"homework-1/internal/models/products"
+	"homework-1/internal/models/reports"
+	"homework-1/internal/models/units"
+	"regexp"
+	"testing"
+)
+
+const valueSql = `price * quantity / CASE unit WHEN 'cm' THEN 10 WHEN 'kg' THEN 1000 WHEN 'l' THEN 1000 WHEN 'm' THEN 1000 ELSE 1 END`
+
+func TestGetStockValuation(t *testing.T) {
+	t.Run("success getting valuation", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(` + valueSql + `), 0)::bigint AS total_value, COALESCE(SUM(quantity), 0)::bigint AS total_quantity, COUNT(*) AS product_count, COUNT(*) FILTER (WHERE quantity = 0) AS zero_stock_count FROM products WHERE tenant_id = $1`)).
+			WithArgs(testTenant).
+			WillReturnRows(pgxmock.NewRows([]string{"total_value", "total_quantity", "product_count", "zero_stock_count"}).
+				AddRow(uint64(50), uint64(12), uint64(3), uint64(1)))
+
+		// act
+		res, err := f.reportRepo.GetStockValuation(f.ctx)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, &reports.Valuation{TotalValue: uint64(50), TotalQuantity: uint64(12), ProductCount: uint64(3), ZeroStockCount: uint64(1)})
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestGetValueByStatus(t *testing.T) {
+	t.Run("success getting value by status", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT status, COUNT(*) AS product_count, SUM(quantity)::bigint AS quantity, SUM(` + valueSql + `)::bigint AS value FROM products WHERE tenant_id = $1 GROUP BY status ORDER BY status`)).
+			WithArgs(testTenant).
+			WillReturnRows(pgxmock.NewRows([]string{"status", "product_count", "quantity", "value"}).
+				AddRow(products.StatusActive, uint64(2), uint64(12), uint64(50)))
+
+		// act
+		res, err := f.reportRepo.GetValueByStatus(f.ctx)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, []*reports.StatusValue{{Status: products.StatusActive, ProductCount: uint64(2), Quantity: uint64(12), Value: uint64(50)}})
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestGetValueByCategory(t *testing.T) {
+	t.Run("success getting value by category", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT category, COUNT(*) AS product_count, SUM(quantity)::bigint AS quantity, SUM(` + valueSql + `)::bigint AS value FROM products WHERE tenant_id = $1 GROUP BY category ORDER BY category`)).
+			WithArgs(testTenant).
+			WillReturnRows(pgxmock.NewRows([]string{"category", "product_count", "quantity", "value"}).
+				AddRow("", uint64(1), uint64(0), uint64(0)).
+				AddRow("food", uint64(2), uint64(12), uint64(50)))
+
+		// act
+		res, err := f.reportRepo.GetValueByCategory(f.ctx)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, []*reports.CategoryValue{
+			{ProductCount: uint64(1)},
+			{Category: "food", ProductCount: uint64(2), Quantity: uint64(12), Value: uint64(50)},
+		})
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestGetTopProductsByValue(t *testing.T) {
+	t.Run("default limit", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id AS product_id, name, price, quantity, unit, ` + valueSql + ` AS value FROM products WHERE tenant_id = $1 ORDER BY value DESC, id LIMIT 10`)).
+			WithArgs(testTenant).
+			WillReturnRows(pgxmock.NewRows([]string{"product_id", "name", "price", "quantity", "unit", "value"}).
+				AddRow(uint64(2), "product2", uint64(3), uint64(10), units.Piece, uint64(30)))
+
+		// act
+		res, err := f.reportRepo.GetTopProductsByValue(f.ctx, uint64(0))
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, []*reports.ProductValue{{ProductId: uint64(2), Name: "product2", Price: uint64(3), Quantity: uint64(10), Unit: units.Piece, Value: uint64(30)}})
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+}
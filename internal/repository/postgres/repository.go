@@ -4,6 +4,12 @@ import (
 	"context"
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
+	"github.com/pkg/errors"
+)
+
+const (
+	foreignKeyViolation = "23503"
+	uniqueViolation     = "23505"
 )
 
 type PgxPool interface {
@@ -28,3 +34,13 @@ func NewRepository(pool PgxPool) *Repository {
 		pool: pool,
 	}
 }
+
+func isForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
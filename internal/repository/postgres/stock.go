@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/pkg/errors"
+	"homework-1/internal/models/products"
+	"homework-1/internal/models/stock"
+	"homework-1/internal/repository"
+	"homework-1/internal/tenants"
+	"strconv"
+)
+
+func (r *Repository) SetReorderThreshold(ctx context.Context, productId uint64, threshold uint64) error {
+	if err := r.checkProducts(ctx, r.pool, productId); err != nil {
+		return err
+	}
+	if threshold == 0 {
+		return r.deleteReorderThreshold(ctx, productId)
+	}
+
+	query, args, err := psql.Insert("reorder_thresholds").
+		Columns("product_id, threshold").
+		Values(productId, threshold).
+		Suffix("ON CONFLICT (product_id) DO UPDATE SET threshold = EXCLUDED.threshold").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Repository.SetReorderThreshold: to sql: %w", err)
+	}
+
+	if _, err = r.pool.Exec(ctx, query, args...); err != nil {
+		if isForeignKeyViolation(err) {
+			return errors.Wrap(repository.ProductNotExists, strconv.FormatUint(productId, 10))
+		}
+		return fmt.Errorf("Repository.SetReorderThreshold: insert: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) GetLowStockProducts(ctx context.Context, page uint64, size uint64) ([]*stock.LowStock, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetLowStockProducts: %w", err)
+	}
+	limit, offset := r.getPaginationLimitAndOffset(page, size)
+
+	query, args, err := psql.Select("p.id AS product_id, p.name, p.quantity, t.threshold").
+		From("products p").
+		Join("reorder_thresholds t ON t.product_id = p.id").
+		Where(squirrel.Eq{"p.status": products.StatusActive, "p.tenant_id": tenant}).
+		Where("p.quantity < t.threshold").
+		OrderBy("p.id").
+		Limit(limit).
+		Offset(offset).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetLowStockProducts: to sql: %w", err)
+	}
+
+	var lowStock []*stock.LowStock
+	if err = pgxscan.Select(ctx, r.pool, &lowStock, query, args...); err != nil {
+		return nil, fmt.Errorf("Repository.GetLowStockProducts: select: %w", err)
+	}
+
+	return lowStock, nil
+}
+
+func (r *Repository) AddAlertSubscription(ctx context.Context, chatId int64) error {
+	query, args, err := psql.Insert("alert_subscriptions").
+		Columns("chat_id").
+		Values(chatId).
+		Suffix("ON CONFLICT (chat_id) DO NOTHING").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Repository.AddAlertSubscription: to sql: %w", err)
+	}
+
+	if _, err = r.pool.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("Repository.AddAlertSubscription: insert: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) RemoveAlertSubscription(ctx context.Context, chatId int64) error {
+	query, args, err := psql.Delete("alert_subscriptions").
+		Where(squirrel.Eq{"chat_id": chatId}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Repository.RemoveAlertSubscription: to sql: %w", err)
+	}
+
+	if _, err = r.pool.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("Repository.RemoveAlertSubscription: delete: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) GetAlertSubscriptions(ctx context.Context) ([]int64, error) {
+	query, args, err := psql.Select("chat_id").
+		From("alert_subscriptions").
+		OrderBy("chat_id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetAlertSubscriptions: to sql: %w", err)
+	}
+
+	var chatIds []int64
+	if err = pgxscan.Select(ctx, r.pool, &chatIds, query, args...); err != nil {
+		return nil, fmt.Errorf("Repository.GetAlertSubscriptions: select: %w", err)
+	}
+	return chatIds, nil
+}
+
+func (r *Repository) deleteReorderThreshold(ctx context.Context, productId uint64) error {
+	query, args, err := psql.Delete("reorder_thresholds").
+		Where(squirrel.Eq{"product_id": productId}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Repository.deleteReorderThreshold: to sql: %w", err)
+	}
+
+	if _, err = r.pool.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("Repository.deleteReorderThreshold: delete: %w", err)
+	}
+	return nil
+}
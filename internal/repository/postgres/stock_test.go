@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"homework-1/internal/models/products"
+	"homework-1/internal/models/stock"
+	"regexp"
+	"testing"
+)
+
+func TestSetReorderThreshold(t *testing.T) {
+	t.Run("success setting threshold", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.expectProducts([]uint64{1}, 1)
+		f.mockPool.ExpectExec(regexp.QuoteMeta(`INSERT INTO reorder_thresholds (product_id, threshold) VALUES ($1,$2) ON CONFLICT (product_id) DO UPDATE SET threshold = EXCLUDED.threshold`)).
+			WithArgs(uint64(1), uint64(5)).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+		// act
+		err := f.stockRepo.SetReorderThreshold(f.ctx, 1, 5)
+
+		// assert
+		require.NoError(t, err)
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("zero threshold removes it", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.expectProducts([]uint64{1}, 1)
+		f.mockPool.ExpectExec(regexp.QuoteMeta(`DELETE FROM reorder_thresholds WHERE product_id = $1`)).
+			WithArgs(uint64(1)).
+			WillReturnResult(pgxmock.NewResult("DELETE", 1))
+
+		// act
+		err := f.stockRepo.SetReorderThreshold(f.ctx, 1, 0)
+
+		// assert
+		require.NoError(t, err)
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("product does not exists", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.expectProducts([]uint64{1})
+
+		// act
+		err := f.stockRepo.SetReorderThreshold(f.ctx, 1, 5)
+
+		// assert
+		assert.EqualError(t, err, "1: product does not exist")
+	})
+}
+
+func TestGetLowStockProducts(t *testing.T) {
+	t.Run("success getting low stock products", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT p.id AS product_id, p.name, p.quantity, t.threshold FROM products p JOIN reorder_thresholds t ON t.product_id = p.id WHERE p.status = $1 AND p.tenant_id = $2 AND p.quantity < t.threshold ORDER BY p.id LIMIT 20 OFFSET 0`)).
+			WithArgs(products.StatusActive, testTenant).
+			WillReturnRows(pgxmock.NewRows([]string{"product_id", "name", "quantity", "threshold"}).
+				AddRow(uint64(1), "product1", uint64(1), uint64(5)))
+
+		// act
+		res, err := f.stockRepo.GetLowStockProducts(f.ctx, 0, 0)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, []*stock.LowStock{
+			{ProductId: uint64(1), Name: "product1", Quantity: uint64(1), Threshold: uint64(5)},
+		})
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestAlertSubscriptions(t *testing.T) {
+	t.Run("success adding subscription", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectExec(regexp.QuoteMeta(`INSERT INTO alert_subscriptions (chat_id) VALUES ($1) ON CONFLICT (chat_id) DO NOTHING`)).
+			WithArgs(int64(1)).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+		// act
+		err := f.stockRepo.AddAlertSubscription(f.ctx, 1)
+
+		// assert
+		require.NoError(t, err)
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("success getting subscriptions", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT chat_id FROM alert_subscriptions ORDER BY chat_id`)).
+			WillReturnRows(pgxmock.NewRows([]string{"chat_id"}).AddRow(int64(1)).AddRow(int64(2)))
+
+		// act
+		res, err := f.stockRepo.GetAlertSubscriptions(f.ctx)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, []int64{1, 2})
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+}
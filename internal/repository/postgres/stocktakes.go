@@ -0,0 +1,237 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/pkg/errors"
+	"homework-1/internal/models/stocktakes"
+	"homework-1/internal/repository"
+	"homework-1/internal/tenants"
+	"strconv"
+)
+
+const (
+	stocktakeColumns = "id, status, opened_by, committed_by, created_at, committed_at"
+	countColumns     = "session_id, product_id, counted, counted_by, counted_at"
+)
+
+func (r *Repository) OpenStocktake(ctx context.Context, session stocktakes.Session) (*stocktakes.Session, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.OpenStocktake: %w", err)
+	}
+
+	query, args, err := psql.Insert("stocktake_sessions").
+		Columns("status, opened_by, created_at, tenant_id").
+		Values(session.Status, session.OpenedBy, session.CreatedAt, tenant).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.OpenStocktake: to sql: %w", err)
+	}
+
+	row := r.pool.QueryRow(ctx, query, args...)
+	if err = row.Scan(&session.Id); err != nil {
+		return nil, fmt.Errorf("Repository.OpenStocktake: insert: %w", err)
+	}
+
+	return &session, nil
+}
+
+func (r *Repository) GetStocktakeById(ctx context.Context, id uint64) (*stocktakes.Session, error) {
+	return r.getStocktake(ctx, r.pool, id, "")
+}
+
+func (r *Repository) SubmitCount(ctx context.Context, count stocktakes.Count) (*stocktakes.Count, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.SubmitCount: begin: %w", err)
+	}
+	defer tx.Rollback(ctx) // no-op after commit
+
+	// the session row is shared locked so a concurrent commit waits for the count or rejects it
+	session, err := r.getStocktake(ctx, tx, count.SessionId, "FOR SHARE")
+	if err != nil {
+		return nil, err
+	}
+	if !session.IsOpen() {
+		return nil, errors.Wrap(stocktakes.ErrSessionClosed, strconv.FormatUint(count.SessionId, 10))
+	}
+	if err = r.checkProducts(ctx, tx, count.ProductId); err != nil {
+		return nil, err
+	}
+
+	query, args, err := psql.Insert("stocktake_counts").
+		Columns(countColumns).
+		Values(count.SessionId, count.ProductId, count.Counted, count.CountedBy, count.CountedAt).
+		Suffix("ON CONFLICT (session_id, product_id) DO UPDATE SET counted = EXCLUDED.counted, counted_by = EXCLUDED.counted_by, counted_at = EXCLUDED.counted_at").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.SubmitCount: to sql: %w", err)
+	}
+
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		if isForeignKeyViolation(err) {
+			return nil, errors.Wrap(repository.ProductNotExists, strconv.FormatUint(count.ProductId, 10))
+		}
+		return nil, fmt.Errorf("Repository.SubmitCount: insert: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("Repository.SubmitCount: commit: %w", err)
+	}
+	return &count, nil
+}
+
+func (r *Repository) GetStocktakeVariances(ctx context.Context, id uint64) ([]*stocktakes.Variance, error) {
+	if _, err := r.getStocktake(ctx, r.pool, id, ""); err != nil {
+		return nil, err
+	}
+
+	query, args, err := psql.Select("c.product_id, p.name, p.quantity AS expected, c.counted").
+		From("stocktake_counts c").
+		Join("products p ON p.id = c.product_id").
+		Where(squirrel.Eq{"c.session_id": id}).
+		OrderBy("c.product_id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetStocktakeVariances: to sql: %w", err)
+	}
+
+	var variances []*stocktakes.Variance
+	if err = pgxscan.Select(ctx, r.pool, &variances, query, args...); err != nil {
+		return nil, fmt.Errorf("Repository.GetStocktakeVariances: select: %w", err)
+	}
+
+	return variances, nil
+}
+
+func (r *Repository) CommitStocktake(ctx context.Context, id uint64, actor string) (*stocktakes.Session, []*stocktakes.Adjustment, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Repository.CommitStocktake: begin: %w", err)
+	}
+	defer tx.Rollback(ctx) // no-op after commit
+
+	session, err := r.getStocktake(ctx, tx, id, "FOR UPDATE")
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = session.Commit(actor); err != nil {
+		return nil, nil, err
+	}
+
+	query, args, err := psql.Select(countColumns).
+		From("stocktake_counts").
+		Where(squirrel.Eq{"session_id": id}).
+		OrderBy("product_id").
+		ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("Repository.CommitStocktake: to sql: %w", err)
+	}
+
+	var counts []*stocktakes.Count
+	if err = pgxscan.Select(ctx, tx, &counts, query, args...); err != nil {
+		return nil, nil, fmt.Errorf("Repository.CommitStocktake: select counts: %w", err)
+	}
+
+	// counts are walked in product id order so concurrent commits lock products in the same order
+	adjustments := make([]*stocktakes.Adjustment, 0)
+	for _, count := range counts {
+		product, err := r.getProductForUpdate(ctx, tx, count.ProductId)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		adjustment := session.Adjust(&stocktakes.Variance{
+			ProductId: product.Id,
+			Name:      product.Name,
+			Expected:  product.Quantity,
+			Counted:   count.Counted,
+		}, actor)
+		if adjustment == nil {
+			continue
+		}
+
+		query, args, err = psql.Update("products").
+			Set("quantity", adjustment.After).
+			Where(squirrel.Eq{"id": adjustment.ProductId}).
+			ToSql()
+		if err != nil {
+			return nil, nil, fmt.Errorf("Repository.CommitStocktake: to sql: %w", err)
+		}
+		if _, err = tx.Exec(ctx, query, args...); err != nil {
+			return nil, nil, fmt.Errorf("Repository.CommitStocktake: update product: %w", err)
+		}
+
+		if adjustment.Before > adjustment.After {
+			if err = r.pickLots(ctx, tx, adjustment.ProductId, adjustment.Before-adjustment.After); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		query, args, err = psql.Insert("stock_adjustments").
+			Columns("session_id, product_id, before, after, actor, created_at").
+			Values(adjustment.SessionId, adjustment.ProductId, adjustment.Before, adjustment.After, adjustment.Actor, adjustment.CreatedAt).
+			Suffix("RETURNING id").
+			ToSql()
+		if err != nil {
+			return nil, nil, fmt.Errorf("Repository.CommitStocktake: to sql: %w", err)
+		}
+		if err = tx.QueryRow(ctx, query, args...).Scan(&adjustment.Id); err != nil {
+			return nil, nil, fmt.Errorf("Repository.CommitStocktake: insert adjustment: %w", err)
+		}
+
+		adjustments = append(adjustments, adjustment)
+	}
+
+	query, args, err = psql.Update("stocktake_sessions").
+		Set("status", session.Status).
+		Set("committed_by", session.CommittedBy).
+		Set("committed_at", session.CommittedAt).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("Repository.CommitStocktake: to sql: %w", err)
+	}
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		return nil, nil, fmt.Errorf("Repository.CommitStocktake: update session: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, nil, fmt.Errorf("Repository.CommitStocktake: commit: %w", err)
+	}
+	return session, adjustments, nil
+}
+
+// getStocktake returns a session of the tenant of ctx, the counts and adjustments that follow
+// may address it by id alone.
+func (r *Repository) getStocktake(ctx context.Context, db pgxscan.Querier, id uint64, lock string) (*stocktakes.Session, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.getStocktake: %w", err)
+	}
+
+	builder := psql.Select(stocktakeColumns).
+		From("stocktake_sessions").
+		Where(squirrel.Eq{"id": id, "tenant_id": tenant})
+	if lock != "" {
+		builder = builder.Suffix(lock)
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.getStocktake: to sql: %w", err)
+	}
+
+	var session stocktakes.Session
+	if err = pgxscan.Get(ctx, db, &session, query, args...); err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, errors.Wrap(repository.StocktakeNotExists, strconv.FormatUint(id, 10))
+		}
+		return nil, fmt.Errorf("Repository.getStocktake: select: %w", err)
+	}
+	return &session, nil
+}
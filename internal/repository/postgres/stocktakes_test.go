@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"github.com/jackc/pgx/v4"
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"homework-1/internal/models/products"
+	"homework-1/internal/models/stocktakes"
+	"regexp"
+	"testing"
+	"time"
+)
+
+var (
+	stocktakeRows = []string{"id", "status", "opened_by", "committed_by", "created_at", "committed_at"}
+	countRows     = []string{"session_id", "product_id", "counted", "counted_by", "counted_at"}
+)
+
+func TestSubmitCount(t *testing.T) {
+	createdAt := time.Date(2022, 9, 18, 10, 0, 0, 0, time.UTC)
+	countedAt := time.Date(2022, 9, 18, 11, 0, 0, 0, time.UTC)
+
+	t.Run("success submitting count", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectBegin()
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, status, opened_by, committed_by, created_at, committed_at FROM stocktake_sessions WHERE id = $1 AND tenant_id = $2 FOR SHARE`)).
+			WithArgs(uint64(1), testTenant).
+			WillReturnRows(pgxmock.NewRows(stocktakeRows).
+				AddRow(uint64(1), stocktakes.StatusOpen, "user1", "", createdAt, (*time.Time)(nil)))
+		f.expectProducts([]uint64{2}, 2)
+		f.mockPool.ExpectExec(regexp.QuoteMeta(`INSERT INTO stocktake_counts (session_id, product_id, counted, counted_by, counted_at) VALUES ($1,$2,$3,$4,$5) ON CONFLICT (session_id, product_id) DO UPDATE`)).
+			WithArgs(uint64(1), uint64(2), uint64(3), "user1", countedAt).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		f.mockPool.ExpectCommit()
+
+		// act
+		res, err := f.stocktakeRepo.SubmitCount(f.ctx, stocktakes.Count{
+			SessionId: uint64(1),
+			ProductId: uint64(2),
+			Counted:   uint64(3),
+			CountedBy: "user1",
+			CountedAt: countedAt,
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res.Counted, uint64(3))
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("session is committed", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectBegin()
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, status, opened_by, committed_by, created_at, committed_at FROM stocktake_sessions`)).
+			WithArgs(uint64(1), testTenant).
+			WillReturnRows(pgxmock.NewRows(stocktakeRows).
+				AddRow(uint64(1), stocktakes.StatusCommitted, "user1", "user2", createdAt, &countedAt))
+		f.mockPool.ExpectRollback()
+
+		// act
+		_, err := f.stocktakeRepo.SubmitCount(f.ctx, stocktakes.Count{SessionId: uint64(1), ProductId: uint64(2)})
+
+		// assert
+		assert.ErrorIs(t, err, stocktakes.ErrSessionClosed)
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("product does not exists", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectBegin()
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, status, opened_by, committed_by, created_at, committed_at FROM stocktake_sessions`)).
+			WithArgs(uint64(1), testTenant).
+			WillReturnRows(pgxmock.NewRows(stocktakeRows).
+				AddRow(uint64(1), stocktakes.StatusOpen, "user1", "", createdAt, (*time.Time)(nil)))
+		f.expectProducts([]uint64{2})
+		f.mockPool.ExpectRollback()
+
+		// act
+		_, err := f.stocktakeRepo.SubmitCount(f.ctx, stocktakes.Count{
+			SessionId: uint64(1),
+			ProductId: uint64(2),
+			Counted:   uint64(3),
+			CountedBy: "user1",
+			CountedAt: countedAt,
+		})
+
+		// assert
+		assert.EqualError(t, err, "2: product does not exist")
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestGetStocktakeVariances(t *testing.T) {
+	t.Run("success getting variances", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, status, opened_by, committed_by, created_at, committed_at FROM stocktake_sessions WHERE id = $1 AND tenant_id = $2`)).
+			WithArgs(uint64(1), testTenant).
+			WillReturnRows(pgxmock.NewRows(stocktakeRows).
+				AddRow(uint64(1), stocktakes.StatusOpen, "user1", "", time.Now(), (*time.Time)(nil)))
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT c.product_id, p.name, p.quantity AS expected, c.counted FROM stocktake_counts c JOIN products p ON p.id = c.product_id WHERE c.session_id = $1 ORDER BY c.product_id`)).
+			WithArgs(uint64(1)).
+			WillReturnRows(pgxmock.NewRows([]string{"product_id", "name", "expected", "counted"}).
+				AddRow(uint64(2), "product2", uint64(5), uint64(3)))
+
+		// act
+		res, err := f.stocktakeRepo.GetStocktakeVariances(f.ctx, uint64(1))
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, []*stocktakes.Variance{{ProductId: uint64(2), Name: "product2", Expected: uint64(5), Counted: uint64(3)}})
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("session does not exists", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, status, opened_by, committed_by, created_at, committed_at FROM stocktake_sessions`)).
+			WithArgs(uint64(1), testTenant).
+			WillReturnError(pgx.ErrNoRows)
+
+		// act
+		_, err := f.stocktakeRepo.GetStocktakeVariances(f.ctx, uint64(1))
+
+		// assert
+		assert.EqualError(t, err, "1: stocktake session does not exist")
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+}
+
+func TestCommitStocktake(t *testing.T) {
+	createdAt := time.Date(2022, 9, 18, 10, 0, 0, 0, time.UTC)
+
+	t.Run("success committing stocktake", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectBegin()
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, status, opened_by, committed_by, created_at, committed_at FROM stocktake_sessions WHERE id = $1 AND tenant_id = $2 FOR UPDATE`)).
+			WithArgs(uint64(1), testTenant).
+			WillReturnRows(pgxmock.NewRows(stocktakeRows).
+				AddRow(uint64(1), stocktakes.StatusOpen, "user1", "", createdAt, (*time.Time)(nil)))
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT session_id, product_id, counted, counted_by, counted_at FROM stocktake_counts WHERE session_id = $1 ORDER BY product_id`)).
+			WithArgs(uint64(1)).
+			WillReturnRows(pgxmock.NewRows(countRows).
+				AddRow(uint64(1), uint64(1), uint64(5), "user1", createdAt).
+				AddRow(uint64(1), uint64(2), uint64(3), "user1", createdAt))
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, price, quantity, status, unit, barcode, category, attributes FROM products WHERE id = $1 AND tenant_id = $2 FOR UPDATE`)).
+			WithArgs(uint64(1), testTenant).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "price", "quantity", "status"}).
+				AddRow(uint64(1), "product1", uint64(1), uint64(5), products.StatusActive))
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, price, quantity, status, unit, barcode, category, attributes FROM products WHERE id = $1 AND tenant_id = $2 FOR UPDATE`)).
+			WithArgs(uint64(2), testTenant).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "price", "quantity", "status"}).
+				AddRow(uint64(2), "product2", uint64(1), uint64(4), products.StatusActive))
+		f.mockPool.ExpectExec(regexp.QuoteMeta(`UPDATE products SET quantity = $1 WHERE id = $2`)).
+			WithArgs(uint64(3), uint64(2)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, product_id, number, quantity, expires_at FROM product_lots WHERE product_id = $1 AND quantity > 0 ORDER BY expires_at, id FOR UPDATE`)).
+			WithArgs(uint64(2)).
+			WillReturnRows(pgxmock.NewRows(lotRows))
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`INSERT INTO stock_adjustments (session_id, product_id, before, after, actor, created_at) VALUES ($1,$2,$3,$4,$5,$6) RETURNING id`)).
+			WithArgs(uint64(1), uint64(2), uint64(4), uint64(3), "user2", pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint64(1)))
+		f.mockPool.ExpectExec(regexp.QuoteMeta(`UPDATE stocktake_sessions SET status = $1, committed_by = $2, committed_at = $3 WHERE id = $4`)).
+			WithArgs(stocktakes.StatusCommitted, "user2", pgxmock.AnyArg(), uint64(1)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		f.mockPool.ExpectCommit()
+
+		// act
+		session, adjustments, err := f.stocktakeRepo.CommitStocktake(f.ctx, uint64(1), "user2")
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, session.Status, stocktakes.StatusCommitted)
+		require.Len(t, adjustments, 1)
+		assert.Equal(t, adjustments[0].Id, uint64(1))
+		assert.Equal(t, adjustments[0].Before, uint64(4))
+		assert.Equal(t, adjustments[0].After, uint64(3))
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+
+	t.Run("session already committed", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectBegin()
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id, status, opened_by, committed_by, created_at, committed_at FROM stocktake_sessions`)).
+			WithArgs(uint64(1), testTenant).
+			WillReturnRows(pgxmock.NewRows(stocktakeRows).
+				AddRow(uint64(1), stocktakes.StatusCommitted, "user1", "user2", createdAt, &createdAt))
+		f.mockPool.ExpectRollback()
+
+		// act
+		_, _, err := f.stocktakeRepo.CommitStocktake(f.ctx, uint64(1), "user2")
+
+		// assert
+		assert.ErrorIs(t, err, stocktakes.ErrSessionClosed)
+		assert.NoError(t, f.mockPool.ExpectationsWereMet())
+	})
+}
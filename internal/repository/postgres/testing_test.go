@@ -1,20 +1,41 @@
 package repository
 
 import (
+	"context"
 	"github.com/golang/mock/gomock"
 	"github.com/pashagolub/pgxmock"
 	"homework-1/internal/repository"
+	"homework-1/internal/tenants"
+	"regexp"
+	"strconv"
+	"strings"
 	"testing"
 )
 
+const testTenant = tenants.Tenant("shop")
+
 type productRepoFixture struct {
-	productRepo repository.Product
-	mockPool    pgxmock.PgxPoolIface
-	ctrl        *gomock.Controller
+	productRepo     repository.Product
+	priceChangeRepo repository.PriceChange
+	stockRepo       repository.Stock
+	purchaseRepo    repository.Purchase
+	lotRepo         repository.Lot
+	stocktakeRepo   repository.Stocktake
+	reportRepo      repository.Report
+	translationRepo repository.Translation
+	imageRepo       repository.Image
+	relationRepo    repository.Relation
+	rateRepo        repository.ExchangeRate
+	grantRepo       repository.Grant
+	// ctx runs the repositories for testTenant
+	ctx      context.Context
+	mockPool pgxmock.PgxPoolIface
+	ctrl     *gomock.Controller
 }
 
 func SetUp(t *testing.T) *productRepoFixture {
 	var fixture productRepoFixture
+	fixture.ctx = tenants.NewContext(context.Background(), testTenant)
 	fixture.ctrl = gomock.NewController(t)
 
 	mock, err := pgxmock.NewPool()
@@ -24,6 +45,17 @@ func SetUp(t *testing.T) *productRepoFixture {
 
 	fixture.mockPool = mock
 	fixture.productRepo = NewRepository(mock)
+	fixture.priceChangeRepo = NewRepository(mock)
+	fixture.stockRepo = NewRepository(mock)
+	fixture.purchaseRepo = NewRepository(mock)
+	fixture.lotRepo = NewRepository(mock)
+	fixture.stocktakeRepo = NewRepository(mock)
+	fixture.reportRepo = NewRepository(mock)
+	fixture.translationRepo = NewRepository(mock)
+	fixture.imageRepo = NewRepository(mock)
+	fixture.relationRepo = NewRepository(mock)
+	fixture.rateRepo = NewRepository(mock)
+	fixture.grantRepo = NewRepository(mock)
 
 	return &fixture
 }
@@ -32,3 +64,22 @@ func (f *productRepoFixture) TearDown() {
 	f.ctrl.Finish()
 	f.mockPool.Close()
 }
+
+// expectProducts expects the check that the products belong to testTenant, found are the ones that do.
+func (f *productRepoFixture) expectProducts(ids []uint64, found ...uint64) {
+	placeholders := make([]string, 0, len(ids))
+	args := make([]interface{}, 0, len(ids)+1)
+	for i, id := range ids {
+		placeholders = append(placeholders, "$"+strconv.Itoa(i+1))
+		args = append(args, id)
+	}
+	args = append(args, testTenant)
+
+	rows := pgxmock.NewRows([]string{"id"})
+	for _, id := range found {
+		rows.AddRow(id)
+	}
+	f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM products WHERE id IN (` + strings.Join(placeholders, ",") + `) AND tenant_id = $` + strconv.Itoa(len(ids)+1))).
+		WithArgs(args...).
+		WillReturnRows(rows)
+}
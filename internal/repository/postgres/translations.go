@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/Masterminds/squirrel"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/pkg/errors"
+	"homework-1/internal/locales"
+	"homework-1/internal/models/products"
+	"homework-1/internal/repository"
+	"homework-1/internal/tenants"
+	"strconv"
+)
+
+const translationColumns = "product_id, locale, name, description"
+
+func (r *Repository) SetTranslation(ctx context.Context, translation products.Translation) (*products.Translation, error) {
+	if err := r.checkProducts(ctx, r.pool, translation.ProductId); err != nil {
+		return nil, err
+	}
+
+	query, args, err := psql.Insert("product_translations").
+		Columns(translationColumns).
+		Values(translation.ProductId, translation.Locale, translation.Name, translation.Description).
+		Suffix("ON CONFLICT (product_id, locale) DO UPDATE SET name = EXCLUDED.name, description = EXCLUDED.description").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.SetTranslation: to sql: %w", err)
+	}
+
+	if _, err = r.pool.Exec(ctx, query, args...); err != nil {
+		if isForeignKeyViolation(err) {
+			return nil, errors.Wrap(repository.ProductNotExists, strconv.FormatUint(translation.ProductId, 10))
+		}
+		return nil, fmt.Errorf("Repository.SetTranslation: insert: %w", err)
+	}
+
+	return &translation, nil
+}
+
+func (r *Repository) GetTranslations(ctx context.Context, productIds []uint64, locale locales.Locale) ([]*products.Translation, error) {
+	tenant, err := tenants.FromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetTranslations: %w", err)
+	}
+
+	query, args, err := psql.Select(translationColumns).
+		From("product_translations").
+		Where(squirrel.Eq{"product_id": productIds}).
+		Where(ofTenantProducts("product_id", tenant)).
+		Where(squirrel.Eq{"locale": []locales.Locale{locale, locales.Default}}).
+		OrderBy("product_id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Repository.GetTranslations: to sql: %w", err)
+	}
+
+	var translations []*products.Translation
+	if err = pgxscan.Select(ctx, r.pool, &translations, query, args...); err != nil {
+		return nil, fmt.Errorf("Repository.GetTranslations: select: %w", err)
+	}
+
+	return translations, nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"homework-1/internal/locales"
+	"homework-1/internal/models/products"
+	"regexp"
+	"testing"
+)
+
+func TestSetTranslation(t *testing.T) {
+	t.Run("success setting translation", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.expectProducts([]uint64{1}, 1)
+		f.mockPool.ExpectExec(regexp.QuoteMeta(`INSERT INTO product_translations (product_id, locale, name, description) VALUES ($1,$2,$3,$4) ON CONFLICT (product_id, locale) DO UPDATE SET name = EXCLUDED.name, description = EXCLUDED.description`)).
+			WithArgs(uint64(1), locales.Russian, "подушка", "мягкая").
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+		// act
+		res, err := f.translationRepo.SetTranslation(f.ctx, products.Translation{
+			ProductId:   uint64(1),
+			Locale:      locales.Russian,
+			Name:        "подушка",
+			Description: "мягкая",
+		})
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res.Name, "подушка")
+	})
+
+	t.Run("product does not exist", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.expectProducts([]uint64{1})
+
+		// act
+		_, err := f.translationRepo.SetTranslation(f.ctx, products.Translation{
+			ProductId: uint64(1),
+			Locale:    locales.Russian,
+			Name:      "подушка",
+		})
+
+		// assert
+		assert.EqualError(t, err, "1: product does not exist")
+	})
+}
+
+func TestGetTranslations(t *testing.T) {
+	t.Run("success getting translations with default locale", func(t *testing.T) {
+		// arrange
+		f := SetUp(t)
+		defer f.TearDown()
+
+		f.mockPool.ExpectQuery(regexp.QuoteMeta(`SELECT product_id, locale, name, description FROM product_translations WHERE product_id IN ($1,$2) AND product_id IN (SELECT id FROM products WHERE tenant_id = $3) AND locale IN ($4,$5) ORDER BY product_id`)).
+			WithArgs(uint64(1), uint64(2), testTenant, locales.Russian, locales.English).
+			WillReturnRows(pgxmock.NewRows([]string{"product_id", "locale", "name", "description"}).
+				AddRow(uint64(1), locales.Russian, "подушка", "").
+				AddRow(uint64(2), locales.English, "pillow", "soft"))
+
+		// act
+		res, err := f.translationRepo.GetTranslations(f.ctx, []uint64{1, 2}, locales.Russian)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, res, []*products.Translation{
+			{ProductId: uint64(1), Locale: locales.Russian, Name: "подушка"},
+			{ProductId: uint64(2), Locale: locales.English, Name: "pillow", Description: "soft"},
+		})
+	})
+}
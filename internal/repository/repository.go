@@ -4,13 +4,147 @@ package repository
 
 import (
 	"context"
+	"homework-1/internal/locales"
+	"homework-1/internal/models/changes"
+	"homework-1/internal/models/grants"
+	"homework-1/internal/models/images"
+	"homework-1/internal/models/lots"
 	"homework-1/internal/models/products"
+	"homework-1/internal/models/purchases"
+	"homework-1/internal/models/relations"
+	"homework-1/internal/models/reports"
+	"homework-1/internal/models/stock"
+	"homework-1/internal/models/stocktakes"
+	"homework-1/internal/money"
+	"time"
 )
 
 type Product interface {
 	GetProductById(ctx context.Context, id uint64) (*products.Product, error)
+	// GetProductByBarcode looks the product up by its GTIN-14 barcode, see products.NormalizeBarcode.
+	GetProductByBarcode(ctx context.Context, barcode string) (*products.Product, error)
 	GetAllProducts(ctx context.Context, page uint64, size uint64) ([]*products.Product, error)
 	CreateProduct(ctx context.Context, product products.Product) (*products.Product, error)
 	UpdateProduct(ctx context.Context, product products.Product) (*products.Product, error)
 	DeleteProduct(ctx context.Context, id uint64) error
+	GetProductsByStatus(ctx context.Context, status products.Status, page uint64, size uint64) ([]*products.Product, error)
+	// FindProducts lists products matching the filter, attribute values are compared by type.
+	FindProducts(ctx context.Context, filter products.Filter, page uint64, size uint64) ([]*products.Product, error)
+	TransitionProductStatus(ctx context.Context, id uint64, to products.Status, reason string, actor string) (*products.Product, error)
+	// ReserveProduct takes quantity out of stock, lots that have not expired are consumed
+	// first-expired-first-out. It returns what was taken of each lot for ReleaseProduct.
+	ReserveProduct(ctx context.Context, id uint64, quantity uint64) (*products.Product, []lots.Picked, error)
+	// ReleaseProduct returns quantity taken by ReserveProduct back to the product stock,
+	// the picked quantities go back into their lots.
+	ReleaseProduct(ctx context.Context, id uint64, quantity uint64, picked []lots.Picked) (*products.Product, error)
+}
+
+type PriceChange interface {
+	CreatePriceChange(ctx context.Context, change changes.PriceChange) (*changes.PriceChange, error)
+	GetPriceChangeById(ctx context.Context, id uint64) (*changes.PriceChange, error)
+	GetPendingPriceChanges(ctx context.Context, page uint64, size uint64) ([]*changes.PriceChange, error)
+	// ApprovePriceChange resolves the change and applies it to the product in one step.
+	ApprovePriceChange(ctx context.Context, id uint64, approver string) (*changes.PriceChange, *products.Product, error)
+	RejectPriceChange(ctx context.Context, id uint64, approver string) (*changes.PriceChange, error)
+}
+
+type Stock interface {
+	// SetReorderThreshold sets the quantity below which the product is low on stock, zero removes the threshold.
+	SetReorderThreshold(ctx context.Context, productId uint64, threshold uint64) error
+	GetLowStockProducts(ctx context.Context, page uint64, size uint64) ([]*stock.LowStock, error)
+	AddAlertSubscription(ctx context.Context, chatId int64) error
+	RemoveAlertSubscription(ctx context.Context, chatId int64) error
+	GetAlertSubscriptions(ctx context.Context) ([]int64, error)
+}
+
+type Purchase interface {
+	CreateSupplier(ctx context.Context, supplier purchases.Supplier) (*purchases.Supplier, error)
+	GetSupplierById(ctx context.Context, id uint64) (*purchases.Supplier, error)
+	GetAllSuppliers(ctx context.Context, page uint64, size uint64) ([]*purchases.Supplier, error)
+	CreatePurchaseOrder(ctx context.Context, order purchases.PurchaseOrder) (*purchases.PurchaseOrder, error)
+	GetPurchaseOrderById(ctx context.Context, id uint64) (*purchases.PurchaseOrder, error)
+	GetOpenPurchaseOrders(ctx context.Context, page uint64, size uint64) ([]*purchases.PurchaseOrder, error)
+	// ReceivePurchaseOrder books received quantities by product id and adds them to the products
+	// stock in one step. Empty receipts receive everything that is left on the order.
+	ReceivePurchaseOrder(ctx context.Context, id uint64, receipts map[uint64]uint64) (*purchases.PurchaseOrder, error)
+}
+
+type Lot interface {
+	// AddLot stores the lot and adds its quantity to the product stock in one step.
+	AddLot(ctx context.Context, lot lots.Lot) (*lots.Lot, error)
+	// GetProductLots returns the product lots that are not used up in first-expired-first-out order.
+	GetProductLots(ctx context.Context, productId uint64) ([]*lots.Lot, error)
+	GetExpiringLots(ctx context.Context, before time.Time, page uint64, size uint64) ([]*lots.Lot, error)
+}
+
+type Stocktake interface {
+	OpenStocktake(ctx context.Context, session stocktakes.Session) (*stocktakes.Session, error)
+	GetStocktakeById(ctx context.Context, id uint64) (*stocktakes.Session, error)
+	// SubmitCount stores the counted quantity of a product, counting a product again replaces the previous count.
+	SubmitCount(ctx context.Context, count stocktakes.Count) (*stocktakes.Count, error)
+	// GetStocktakeVariances compares the session counts with the current product quantities.
+	GetStocktakeVariances(ctx context.Context, id uint64) ([]*stocktakes.Variance, error)
+	// CommitStocktake sets every counted product to its counted quantity and records an adjustment
+	// for each changed product in one step.
+	CommitStocktake(ctx context.Context, id uint64, actor string) (*stocktakes.Session, []*stocktakes.Adjustment, error)
+}
+
+type Translation interface {
+	// SetTranslation adds or replaces the product translation in its locale.
+	SetTranslation(ctx context.Context, translation products.Translation) (*products.Translation, error)
+	// GetTranslations returns the translations of the products in the locale and in the default locale,
+	// see products.PickTranslations.
+	GetTranslations(ctx context.Context, productIds []uint64, locale locales.Locale) ([]*products.Translation, error)
+}
+
+// Image keeps product image metadata, the content lives in a blob store.
+// Deleting a product deletes its images metadata, the caller removes the blobs.
+type Image interface {
+	AddImage(ctx context.Context, image images.Image) (*images.Image, error)
+	GetImageById(ctx context.Context, id uint64) (*images.Image, error)
+	// GetProductImages returns the product images in upload order.
+	GetProductImages(ctx context.Context, productId uint64) ([]*images.Image, error)
+}
+
+// Relation keeps directed typed relations between products.
+// Deleting a product deletes the relations it takes part in.
+type Relation interface {
+	CreateRelation(ctx context.Context, relation relations.Relation) (*relations.Relation, error)
+	// UpdateRelation changes the type of an existing relation.
+	UpdateRelation(ctx context.Context, relation relations.Relation) (*relations.Relation, error)
+	DeleteRelation(ctx context.Context, productId uint64, relatedId uint64) error
+	// GetProductRelations returns the relations pointing from the product ordered by related product id.
+	GetProductRelations(ctx context.Context, productId uint64) ([]*relations.Relation, error)
+	// GetRelatedProducts returns the products the product points to, an empty type matches every type.
+	GetRelatedProducts(ctx context.Context, productId uint64, relationType relations.Type) ([]*relations.RelatedProduct, error)
+	// CountProductRelations counts the relations pointing both from and to the product.
+	CountProductRelations(ctx context.Context, productId uint64) (uint64, error)
+}
+
+// ExchangeRate keeps the rates that convert prices from the base currency, see money.Rate.
+type ExchangeRate interface {
+	// SetExchangeRate adds or replaces the rate of its currency.
+	SetExchangeRate(ctx context.Context, rate money.Rate) (*money.Rate, error)
+	GetExchangeRate(ctx context.Context, currency money.Currency) (*money.Rate, error)
+	// GetExchangeRates returns every rate ordered by currency.
+	GetExchangeRates(ctx context.Context) ([]*money.Rate, error)
+}
+
+// Grant keeps the roles admins grant to Telegram users from the bot.
+type Grant interface {
+	// SetGrant adds or replaces the grant of its user.
+	SetGrant(ctx context.Context, grant grants.Grant) (*grants.Grant, error)
+	GetGrant(ctx context.Context, userId int64) (*grants.Grant, error)
+	DeleteGrant(ctx context.Context, userId int64) error
+	// GetGrants returns every grant ordered by user id.
+	GetGrants(ctx context.Context) ([]*grants.Grant, error)
+}
+
+// Report computes aggregates over the whole stock, the value of a product is reports.ValueOf.
+type Report interface {
+	GetStockValuation(ctx context.Context) (*reports.Valuation, error)
+	GetValueByStatus(ctx context.Context) ([]*reports.StatusValue, error)
+	// GetValueByCategory returns the value per category ordered by category, uncategorized products come first.
+	GetValueByCategory(ctx context.Context) ([]*reports.CategoryValue, error)
+	GetTopProductsByValue(ctx context.Context, limit uint64) ([]*reports.ProductValue, error)
 }
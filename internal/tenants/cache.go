@@ -0,0 +1,41 @@
+package tenants
+
+import (
+	"context"
+	"homework-1/internal/cache"
+	"time"
+)
+
+// Cache namespaces every key by the tenant of the context, so shops sharing
+// one Redis never read or evict each other's entries.
+type Cache struct {
+	cache cache.KVCache
+}
+
+func NewCache(cache cache.KVCache) *Cache {
+	return &Cache{cache: cache}
+}
+
+func (c *Cache) Get(ctx context.Context, key string) (string, error) {
+	tenant, err := FromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	return c.cache.Get(ctx, CacheKey(tenant, key))
+}
+
+func (c *Cache) Set(ctx context.Context, key string, value string, expiration time.Duration) error {
+	tenant, err := FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return c.cache.Set(ctx, CacheKey(tenant, key), value, expiration)
+}
+
+func (c *Cache) Del(ctx context.Context, key string) error {
+	tenant, err := FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return c.cache.Del(ctx, CacheKey(tenant, key))
+}
@@ -0,0 +1,76 @@
+package tenants
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"homework-1/internal/health"
+)
+
+// UnaryServerInterceptor rejects requests without a valid tenant id and puts the tenant into the handler context.
+// Health checks are served for the whole deployment and need no tenant.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if health.IsHealthCheck(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		tenant, err := FromIncomingContext(ctx)
+		if err != nil {
+			return nil, tenantError(err)
+		}
+		return handler(NewContext(ctx, tenant), req)
+	}
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streaming methods.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if health.IsHealthCheck(info.FullMethod) {
+			return handler(srv, stream)
+		}
+		tenant, err := FromIncomingContext(stream.Context())
+		if err != nil {
+			return tenantError(err)
+		}
+		return handler(srv, &serverStream{ServerStream: stream, ctx: NewContext(stream.Context(), tenant)})
+	}
+}
+
+// UnaryClientInterceptor passes the tenant of the context on to the called service.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingContext(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor is UnaryClientInterceptor for streaming methods.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingContext(ctx), desc, cc, method, opts...)
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func outgoingContext(ctx context.Context) context.Context {
+	if tenant, err := FromContext(ctx); err == nil {
+		return AppendToOutgoingContext(ctx, tenant)
+	}
+	return ctx
+}
+
+func tenantError(err error) error {
+	if errors.Is(err, ErrTenantMismatch) {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return status.Error(codes.InvalidArgument, err.Error())
+}
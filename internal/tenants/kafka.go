@@ -0,0 +1,22 @@
+package tenants
+
+import (
+	"github.com/Shopify/sarama"
+)
+
+// HeaderKey is the Kafka message header that carries the tenant a message belongs to.
+const HeaderKey = "x-tenant-id"
+
+func MessageHeader(tenant Tenant) sarama.RecordHeader {
+	return sarama.RecordHeader{Key: []byte(HeaderKey), Value: []byte(tenant)}
+}
+
+// FromMessage returns the tenant of a consumed message, ErrNoTenant when the producer did not set one.
+func FromMessage(msg *sarama.ConsumerMessage) (Tenant, error) {
+	for _, header := range msg.Headers {
+		if header != nil && string(header.Key) == HeaderKey {
+			return Parse(string(header.Value))
+		}
+	}
+	return "", ErrNoTenant
+}
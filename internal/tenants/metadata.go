@@ -0,0 +1,41 @@
+package tenants
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/grpc/metadata"
+)
+
+// MetadataKey carries the tenant id between services, the gateway fills it from the X-Tenant-Id header.
+const MetadataKey = "x-tenant-id"
+
+var ErrTenantMismatch = errors.New("request carries several tenant ids")
+
+// FromIncomingContext returns the tenant of an incoming gRPC request. A request that names
+// several different tenants is rejected rather than served for one of them.
+func FromIncomingContext(ctx context.Context) (Tenant, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(MetadataKey)
+	if len(values) == 0 || values[0] == "" {
+		return "", ErrNoTenant
+	}
+
+	tenant, err := Parse(values[0])
+	if err != nil {
+		return "", err
+	}
+	for _, value := range values[1:] {
+		other, err := Parse(value)
+		if err != nil {
+			return "", err
+		}
+		if other != tenant {
+			return "", ErrTenantMismatch
+		}
+	}
+	return tenant, nil
+}
+
+func AppendToOutgoingContext(ctx context.Context, tenant Tenant) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, MetadataKey, tenant.String())
+}
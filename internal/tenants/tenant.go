@@ -0,0 +1,93 @@
+// Package tenants keeps the shops hosted on one deployment apart. Every request carries
+// a tenant id, the repositories only see the products of the tenant found in the context.
+package tenants
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Tenant identifies a shop, e.g. "acme" or "north-store".
+type Tenant string
+
+// Default owns the products created before tenants were introduced.
+const Default Tenant = "default"
+
+const maxLength = 32
+
+var (
+	ErrInvalidTenant = errors.New("invalid tenant id, expected up to 32 lowercase latin letters, digits and dashes")
+	ErrNoTenant      = errors.New("tenant id is required")
+)
+
+type contextKey struct{}
+
+func (t Tenant) String() string {
+	return string(t)
+}
+
+// Parse accepts a tenant id in any case and returns it lower-cased.
+func Parse(id string) (Tenant, error) {
+	tenant := Tenant(strings.ToLower(strings.TrimSpace(id)))
+	if err := Validate(tenant); err != nil {
+		return "", err
+	}
+	return tenant, nil
+}
+
+func Validate(tenant Tenant) error {
+	if tenant == "" || len(tenant) > maxLength ||
+		strings.Trim(string(tenant), "abcdefghijklmnopqrstuvwxyz0123456789-") != "" ||
+		strings.HasPrefix(string(tenant), "-") || strings.HasSuffix(string(tenant), "-") {
+		return fmt.Errorf("%s: %w", tenant, ErrInvalidTenant)
+	}
+	return nil
+}
+
+func NewContext(ctx context.Context, tenant Tenant) context.Context {
+	return context.WithValue(ctx, contextKey{}, tenant)
+}
+
+// FromContext returns the tenant a request runs for, ErrNoTenant when there is none.
+func FromContext(ctx context.Context) (Tenant, error) {
+	tenant, ok := ctx.Value(contextKey{}).(Tenant)
+	if !ok || tenant == "" {
+		return "", ErrNoTenant
+	}
+	return tenant, nil
+}
+
+// Detach returns a context that keeps the values of ctx, the tenant among them, but not its
+// deadline and cancellation. Handlers run storage calls with their own timeout, which must not
+// lose the tenant or the caller of the request.
+func Detach(ctx context.Context) context.Context {
+	return detached{parent: ctx}
+}
+
+type detached struct {
+	parent context.Context
+}
+
+func (detached) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detached) Done() <-chan struct{} {
+	return nil
+}
+
+func (detached) Err() error {
+	return nil
+}
+
+func (d detached) Value(key interface{}) interface{} {
+	return d.parent.Value(key)
+}
+
+// CacheKey namespaces a cache key so tenants never read each other's entries.
+func CacheKey(tenant Tenant, key string) string {
+	return "tenant:" + tenant.String() + ":" + key
+}
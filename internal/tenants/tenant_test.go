@@ -0,0 +1,208 @@
+package tenants
+
+import (
+	"context"
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("lower-cases the id", func(t *testing.T) {
+		// act
+		tenant, err := Parse(" North-Store ")
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, Tenant("north-store"), tenant)
+	})
+
+	t.Run("invalid ids", func(t *testing.T) {
+		for _, id := range []string{"", "north store", "-north", "shop_1", strings.Repeat("a", maxLength+1)} {
+			// act
+			_, err := Parse(id)
+
+			// assert
+			assert.ErrorIs(t, err, ErrInvalidTenant, id)
+		}
+	})
+}
+
+func TestFromIncomingContext(t *testing.T) {
+	t.Run("tenant from metadata", func(t *testing.T) {
+		// arrange
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, "acme"))
+
+		// act
+		tenant, err := FromIncomingContext(ctx)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, Tenant("acme"), tenant)
+	})
+
+	t.Run("no tenant", func(t *testing.T) {
+		// act
+		_, err := FromIncomingContext(context.Background())
+
+		// assert
+		assert.ErrorIs(t, err, ErrNoTenant)
+	})
+
+	t.Run("several tenants", func(t *testing.T) {
+		// arrange
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, "acme", MetadataKey, "north-store"))
+
+		// act
+		_, err := FromIncomingContext(ctx)
+
+		// assert
+		assert.ErrorIs(t, err, ErrTenantMismatch)
+	})
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := UnaryServerInterceptor()
+
+	t.Run("tenant is passed to the handler", func(t *testing.T) {
+		// arrange
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, "acme"))
+		var got Tenant
+		handler := func(ctx context.Context, _ interface{}) (interface{}, error) {
+			got, _ = FromContext(ctx)
+			return nil, nil
+		}
+
+		// act
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, Tenant("acme"), got)
+	})
+
+	t.Run("request without tenant is rejected", func(t *testing.T) {
+		// arrange
+		handler := func(context.Context, interface{}) (interface{}, error) {
+			t.Fatal("handler must not be called")
+			return nil, nil
+		}
+
+		// act
+		_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+
+		// assert
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("request for several tenants is denied", func(t *testing.T) {
+		// arrange
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, "acme", MetadataKey, "north-store"))
+		handler := func(context.Context, interface{}) (interface{}, error) {
+			t.Fatal("handler must not be called")
+			return nil, nil
+		}
+
+		// act
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+
+		// assert
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
+
+func TestDetach(t *testing.T) {
+	// arrange
+	type otherKey struct{}
+	ctx := context.WithValue(NewContext(context.Background(), "acme"), otherKey{}, "caller")
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	cancel()
+
+	// act
+	detached := Detach(ctx)
+
+	// assert
+	assert.NoError(t, detached.Err())
+	_, hasDeadline := detached.Deadline()
+	assert.False(t, hasDeadline)
+	tenant, err := FromContext(detached)
+	require.NoError(t, err)
+	assert.Equal(t, Tenant("acme"), tenant)
+	assert.Equal(t, "caller", detached.Value(otherKey{}))
+}
+
+func TestFromMessage(t *testing.T) {
+	t.Run("tenant from header", func(t *testing.T) {
+		// arrange
+		header := MessageHeader("acme")
+		msg := &sarama.ConsumerMessage{Headers: []*sarama.RecordHeader{&header}}
+
+		// act
+		tenant, err := FromMessage(msg)
+
+		// assert
+		require.NoError(t, err)
+		assert.Equal(t, Tenant("acme"), tenant)
+	})
+
+	t.Run("message without tenant", func(t *testing.T) {
+		// act
+		_, err := FromMessage(&sarama.ConsumerMessage{})
+
+		// assert
+		assert.ErrorIs(t, err, ErrNoTenant)
+	})
+}
+
+type mapCache map[string]string
+
+func (c mapCache) Get(_ context.Context, key string) (string, error) {
+	return c[key], nil
+}
+
+func (c mapCache) Set(_ context.Context, key string, value string, _ time.Duration) error {
+	c[key] = value
+	return nil
+}
+
+func (c mapCache) Del(_ context.Context, key string) error {
+	delete(c, key)
+	return nil
+}
+
+func TestCache(t *testing.T) {
+	t.Run("keys are namespaced by tenant", func(t *testing.T) {
+		// arrange
+		kv := mapCache{}
+		cache := NewCache(kv)
+		acme := NewContext(context.Background(), "acme")
+		north := NewContext(context.Background(), "north-store")
+		require.NoError(t, cache.Set(acme, "product:1", "pillow", time.Minute))
+
+		// act
+		fromAcme, err := cache.Get(acme, "product:1")
+		require.NoError(t, err)
+		fromNorth, err := cache.Get(north, "product:1")
+		require.NoError(t, err)
+
+		// assert
+		assert.Equal(t, "pillow", fromAcme)
+		assert.Empty(t, fromNorth)
+		assert.Equal(t, mapCache{"tenant:acme:product:1": "pillow"}, kv)
+	})
+
+	t.Run("context without tenant", func(t *testing.T) {
+		// act
+		_, err := NewCache(mapCache{}).Get(context.Background(), "product:1")
+
+		// assert
+		assert.ErrorIs(t, err, ErrNoTenant)
+	})
+}
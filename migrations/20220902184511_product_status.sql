@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE public.products
+    ADD COLUMN status varchar(32) not null default 'active'
+        CONSTRAINT known_product_status CHECK (status IN ('draft', 'active', 'discontinued', 'archived'));
+
+CREATE INDEX IF NOT EXISTS products_status_idx ON public.products (status);
+
+CREATE TABLE IF NOT EXISTS public.product_status_transitions (
+    id bigserial primary key,
+    product_id bigint not null REFERENCES public.products (id) ON DELETE CASCADE,
+    from_status varchar(32) not null,
+    to_status varchar(32) not null,
+    reason text not null,
+    actor varchar(255) not null,
+    created_at timestamptz not null default now()
+);
+
+CREATE INDEX IF NOT EXISTS product_status_transitions_product_id_idx ON public.product_status_transitions (product_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS public.product_status_transitions;
+DROP INDEX IF EXISTS public.products_status_idx;
+ALTER TABLE public.products DROP COLUMN IF EXISTS status;
+-- +goose StatementEnd
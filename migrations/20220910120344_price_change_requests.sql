@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS public.price_change_requests (
+    id bigserial primary key,
+    product_id bigint not null REFERENCES public.products (id) ON DELETE CASCADE,
+    name varchar(255) not null,
+    old_price bigint not null CONSTRAINT positive_change_old_price CHECK (old_price >= 0),
+    price bigint not null CONSTRAINT positive_change_price CHECK (price >= 0),
+    quantity bigint not null CONSTRAINT positive_change_quantity CHECK (quantity >= 0),
+    status varchar(32) not null default 'pending'
+        CONSTRAINT known_price_change_status CHECK (status IN ('pending', 'approved', 'rejected')),
+    requested_by varchar(255) not null,
+    resolved_by varchar(255) not null default '',
+    created_at timestamptz not null default now(),
+    resolved_at timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS price_change_requests_pending_idx ON public.price_change_requests (id) WHERE status = 'pending';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS public.price_change_requests;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS public.reorder_thresholds (
+    product_id bigint primary key REFERENCES public.products (id) ON DELETE CASCADE,
+    threshold bigint not null CONSTRAINT positive_threshold CHECK (threshold > 0)
+);
+
+CREATE TABLE IF NOT EXISTS public.alert_subscriptions (
+    chat_id bigint primary key,
+    created_at timestamptz not null default now()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS public.alert_subscriptions;
+DROP TABLE IF EXISTS public.reorder_thresholds;
+-- +goose StatementEnd
@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS public.suppliers (
+    id bigserial primary key,
+    name varchar(255) not null,
+    contact varchar(255) not null default ''
+);
+
+CREATE TABLE IF NOT EXISTS public.purchase_orders (
+    id bigserial primary key,
+    supplier_id bigint not null REFERENCES public.suppliers (id),
+    status varchar(32) not null default 'open'
+        CONSTRAINT known_purchase_order_status CHECK (status IN ('open', 'partially_received', 'received')),
+    created_at timestamptz not null default now(),
+    received_at timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS purchase_orders_open_idx ON public.purchase_orders (id) WHERE status <> 'received';
+
+CREATE TABLE IF NOT EXISTS public.purchase_order_lines (
+    id bigserial primary key,
+    order_id bigint not null REFERENCES public.purchase_orders (id) ON DELETE CASCADE,
+    product_id bigint not null REFERENCES public.products (id),
+    quantity bigint not null CONSTRAINT positive_line_quantity CHECK (quantity > 0),
+    received bigint not null default 0 CONSTRAINT received_within_quantity CHECK (received >= 0 AND received <= quantity),
+    UNIQUE (order_id, product_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS public.purchase_order_lines;
+DROP TABLE IF EXISTS public.purchase_orders;
+DROP TABLE IF EXISTS public.suppliers;
+-- +goose StatementEnd
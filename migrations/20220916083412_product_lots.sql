@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS public.product_lots (
+    id bigserial primary key,
+    product_id bigint not null REFERENCES public.products (id) ON DELETE CASCADE,
+    number text not null,
+    quantity bigint not null CONSTRAINT non_negative_quantity CHECK (quantity >= 0),
+    expires_at timestamptz not null,
+    UNIQUE (product_id, number)
+);
+
+CREATE INDEX IF NOT EXISTS product_lots_expires_at_idx ON public.product_lots (expires_at) WHERE quantity > 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS public.product_lots;
+-- +goose StatementEnd
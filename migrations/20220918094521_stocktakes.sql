@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS public.stocktake_sessions (
+    id bigserial primary key,
+    status varchar(32) not null default 'open'
+        CONSTRAINT known_stocktake_status CHECK (status IN ('open', 'committed')),
+    opened_by varchar(255) not null,
+    committed_by varchar(255) not null default '',
+    created_at timestamptz not null default now(),
+    committed_at timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS public.stocktake_counts (
+    session_id bigint not null REFERENCES public.stocktake_sessions (id) ON DELETE CASCADE,
+    product_id bigint not null REFERENCES public.products (id) ON DELETE CASCADE,
+    counted bigint not null CONSTRAINT non_negative_count CHECK (counted >= 0),
+    counted_by varchar(255) not null,
+    counted_at timestamptz not null default now(),
+    PRIMARY KEY (session_id, product_id)
+);
+
+CREATE TABLE IF NOT EXISTS public.stock_adjustments (
+    id bigserial primary key,
+    session_id bigint not null REFERENCES public.stocktake_sessions (id),
+    product_id bigint not null REFERENCES public.products (id) ON DELETE CASCADE,
+    before bigint not null,
+    after bigint not null,
+    actor varchar(255) not null,
+    created_at timestamptz not null default now()
+);
+
+CREATE INDEX IF NOT EXISTS stock_adjustments_product_idx ON public.stock_adjustments (product_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS public.stock_adjustments;
+DROP TABLE IF EXISTS public.stocktake_counts;
+DROP TABLE IF EXISTS public.stocktake_sessions;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE public.products
+    ADD COLUMN IF NOT EXISTS unit varchar(16) not null default 'piece'
+        CONSTRAINT known_product_unit CHECK (unit IN ('piece', 'kg', 'g', 'm', 'cm', 'l', 'ml'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE public.products DROP COLUMN IF EXISTS unit;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE public.products
+    ADD COLUMN IF NOT EXISTS barcode varchar(14) not null default '';
+
+CREATE UNIQUE INDEX IF NOT EXISTS products_barcode_key ON public.products (barcode) WHERE barcode <> '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS products_barcode_key;
+
+ALTER TABLE public.products DROP COLUMN IF EXISTS barcode;
+-- +goose StatementEnd
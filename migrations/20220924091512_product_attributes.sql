@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE public.products
+    ADD COLUMN IF NOT EXISTS category varchar(64) not null default '',
+    ADD COLUMN IF NOT EXISTS attributes jsonb not null default '{}'::jsonb
+        CONSTRAINT attributes_is_object CHECK (jsonb_typeof(attributes) = 'object');
+
+CREATE INDEX IF NOT EXISTS products_category_idx ON public.products (category) WHERE category <> '';
+CREATE INDEX IF NOT EXISTS products_attributes_idx ON public.products USING gin (attributes jsonb_path_ops);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS products_attributes_idx;
+DROP INDEX IF EXISTS products_category_idx;
+
+ALTER TABLE public.products
+    DROP COLUMN IF EXISTS attributes,
+    DROP COLUMN IF EXISTS category;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS public.product_translations (
+    product_id bigint not null REFERENCES public.products (id) ON DELETE CASCADE,
+    locale varchar(2) not null,
+    name text not null,
+    description text not null default '',
+    PRIMARY KEY (product_id, locale)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS public.product_translations;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS public.product_images (
+    id bigserial primary key,
+    product_id bigint not null REFERENCES public.products (id) ON DELETE CASCADE,
+    key text not null UNIQUE,
+    content_type text not null,
+    size bigint not null CONSTRAINT positive_size CHECK (size > 0),
+    checksum varchar(64) not null,
+    created_at timestamptz not null default now()
+);
+
+CREATE INDEX IF NOT EXISTS product_images_product_id_idx ON public.product_images (product_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS public.product_images;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS public.product_relations (
+    product_id bigint not null REFERENCES public.products (id) ON DELETE CASCADE,
+    related_id bigint not null REFERENCES public.products (id) ON DELETE CASCADE,
+    type text not null CONSTRAINT known_type CHECK (type IN ('related', 'accessory', 'replacement')),
+    PRIMARY KEY (product_id, related_id),
+    CONSTRAINT not_self_related CHECK (product_id <> related_id)
+);
+
+CREATE INDEX IF NOT EXISTS product_relations_related_id_idx ON public.product_relations (related_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS public.product_relations;
+-- +goose StatementEnd
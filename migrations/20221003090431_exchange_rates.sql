@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS public.exchange_rates (
+    currency varchar(3) PRIMARY KEY,
+    -- amount of the currency in one unit of the base currency scaled by 10^6, see money.Rate
+    rate bigint not null CHECK (rate > 0),
+    updated_at timestamptz not null default now()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS public.exchange_rates;
+-- +goose StatementEnd
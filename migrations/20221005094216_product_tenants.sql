@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE public.products
+    ADD COLUMN IF NOT EXISTS tenant_id varchar(32) not null default 'default';
+
+CREATE INDEX IF NOT EXISTS products_tenant_id_idx ON public.products (tenant_id, id);
+
+-- barcodes are unique within a shop, two shops may sell the same goods
+DROP INDEX IF EXISTS products_barcode_key;
+CREATE UNIQUE INDEX IF NOT EXISTS products_tenant_barcode_key ON public.products (tenant_id, barcode) WHERE barcode <> '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS products_tenant_barcode_key;
+CREATE UNIQUE INDEX IF NOT EXISTS products_barcode_key ON public.products (barcode) WHERE barcode <> '';
+
+DROP INDEX IF EXISTS products_tenant_id_idx;
+
+ALTER TABLE public.products DROP COLUMN IF EXISTS tenant_id;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS public.bot_grants (
+    -- Telegram user id
+    user_id bigint PRIMARY KEY CHECK (user_id > 0),
+    role varchar(16) not null,
+    granted_by varchar(64) not null,
+    granted_at timestamptz not null default now()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS public.bot_grants;
+-- +goose StatementEnd
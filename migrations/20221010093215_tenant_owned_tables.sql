@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+-- suppliers, purchase orders and stocktakes belong to a shop, the tables that hang off
+-- products are scoped by the tenant of their product
+ALTER TABLE public.suppliers
+    ADD COLUMN IF NOT EXISTS tenant_id varchar(32) not null default 'default';
+CREATE INDEX IF NOT EXISTS suppliers_tenant_id_idx ON public.suppliers (tenant_id, id);
+
+ALTER TABLE public.purchase_orders
+    ADD COLUMN IF NOT EXISTS tenant_id varchar(32) not null default 'default';
+CREATE INDEX IF NOT EXISTS purchase_orders_tenant_id_idx ON public.purchase_orders (tenant_id, id);
+
+ALTER TABLE public.stocktake_sessions
+    ADD COLUMN IF NOT EXISTS tenant_id varchar(32) not null default 'default';
+CREATE INDEX IF NOT EXISTS stocktake_sessions_tenant_id_idx ON public.stocktake_sessions (tenant_id, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS stocktake_sessions_tenant_id_idx;
+ALTER TABLE public.stocktake_sessions DROP COLUMN IF EXISTS tenant_id;
+
+DROP INDEX IF EXISTS purchase_orders_tenant_id_idx;
+ALTER TABLE public.purchase_orders DROP COLUMN IF EXISTS tenant_id;
+
+DROP INDEX IF EXISTS suppliers_tenant_id_idx;
+ALTER TABLE public.suppliers DROP COLUMN IF EXISTS tenant_id;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- every shop converts its prices with its own rates
+ALTER TABLE public.exchange_rates
+    ADD COLUMN IF NOT EXISTS tenant_id varchar(32) not null default 'default';
+ALTER TABLE public.exchange_rates DROP CONSTRAINT IF EXISTS exchange_rates_pkey;
+ALTER TABLE public.exchange_rates ADD PRIMARY KEY (tenant_id, currency);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM public.exchange_rates WHERE tenant_id <> 'default';
+ALTER TABLE public.exchange_rates DROP CONSTRAINT IF EXISTS exchange_rates_pkey;
+ALTER TABLE public.exchange_rates ADD PRIMARY KEY (currency);
+ALTER TABLE public.exchange_rates DROP COLUMN IF EXISTS tenant_id;
+-- +goose StatementEnd
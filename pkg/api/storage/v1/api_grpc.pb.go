@@ -27,6 +27,39 @@ type StorageServiceClient interface {
 	ProductCreate(ctx context.Context, in *ProductCreateRequest, opts ...grpc.CallOption) (*ProductCreateResponse, error)
 	ProductUpdate(ctx context.Context, in *ProductUpdateRequest, opts ...grpc.CallOption) (*ProductUpdateResponse, error)
 	ProductDelete(ctx context.Context, in *ProductDeleteRequest, opts ...grpc.CallOption) (*ProductDeleteResponse, error)
+	ProductTransition(ctx context.Context, in *ProductTransitionRequest, opts ...grpc.CallOption) (*ProductTransitionResponse, error)
+	ApproveChange(ctx context.Context, in *ApproveChangeRequest, opts ...grpc.CallOption) (*ApproveChangeResponse, error)
+	RejectChange(ctx context.Context, in *RejectChangeRequest, opts ...grpc.CallOption) (*RejectChangeResponse, error)
+	ListLowStock(ctx context.Context, in *ListLowStockRequest, opts ...grpc.CallOption) (StorageService_ListLowStockClient, error)
+	SetReorderThreshold(ctx context.Context, in *SetReorderThresholdRequest, opts ...grpc.CallOption) (*SetReorderThresholdResponse, error)
+	SupplierCreate(ctx context.Context, in *SupplierCreateRequest, opts ...grpc.CallOption) (*SupplierCreateResponse, error)
+	SupplierList(ctx context.Context, in *SupplierListRequest, opts ...grpc.CallOption) (StorageService_SupplierListClient, error)
+	PurchaseOrderCreate(ctx context.Context, in *PurchaseOrderCreateRequest, opts ...grpc.CallOption) (*PurchaseOrderCreateResponse, error)
+	PurchaseOrderGet(ctx context.Context, in *PurchaseOrderGetRequest, opts ...grpc.CallOption) (*PurchaseOrderGetResponse, error)
+	PurchaseOrderList(ctx context.Context, in *PurchaseOrderListRequest, opts ...grpc.CallOption) (StorageService_PurchaseOrderListClient, error)
+	PurchaseOrderReceive(ctx context.Context, in *PurchaseOrderReceiveRequest, opts ...grpc.CallOption) (*PurchaseOrderReceiveResponse, error)
+	PlaceOrder(ctx context.Context, in *PlaceOrderRequest, opts ...grpc.CallOption) (*PlaceOrderResponse, error)
+	LotAdd(ctx context.Context, in *LotAddRequest, opts ...grpc.CallOption) (*LotAddResponse, error)
+	LotList(ctx context.Context, in *LotListRequest, opts ...grpc.CallOption) (StorageService_LotListClient, error)
+	ListExpiringLots(ctx context.Context, in *ListExpiringLotsRequest, opts ...grpc.CallOption) (StorageService_ListExpiringLotsClient, error)
+	StocktakeOpen(ctx context.Context, in *StocktakeOpenRequest, opts ...grpc.CallOption) (*StocktakeOpenResponse, error)
+	StocktakeCount(ctx context.Context, in *StocktakeCountRequest, opts ...grpc.CallOption) (*StocktakeCountResponse, error)
+	StocktakeGet(ctx context.Context, in *StocktakeGetRequest, opts ...grpc.CallOption) (*StocktakeGetResponse, error)
+	StocktakeCommit(ctx context.Context, in *StocktakeCommitRequest, opts ...grpc.CallOption) (*StocktakeCommitResponse, error)
+	StockValuation(ctx context.Context, in *StockValuationRequest, opts ...grpc.CallOption) (*StockValuationResponse, error)
+	TopProductsByValue(ctx context.Context, in *TopProductsByValueRequest, opts ...grpc.CallOption) (StorageService_TopProductsByValueClient, error)
+	ProductGetByBarcode(ctx context.Context, in *ProductGetByBarcodeRequest, opts ...grpc.CallOption) (*ProductGetByBarcodeResponse, error)
+	ProductTranslate(ctx context.Context, in *ProductTranslateRequest, opts ...grpc.CallOption) (*ProductTranslateResponse, error)
+	UploadProductImage(ctx context.Context, opts ...grpc.CallOption) (StorageService_UploadProductImageClient, error)
+	DownloadProductImage(ctx context.Context, in *DownloadProductImageRequest, opts ...grpc.CallOption) (StorageService_DownloadProductImageClient, error)
+	ListProductImages(ctx context.Context, in *ListProductImagesRequest, opts ...grpc.CallOption) (StorageService_ListProductImagesClient, error)
+	RelationCreate(ctx context.Context, in *RelationCreateRequest, opts ...grpc.CallOption) (*RelationCreateResponse, error)
+	RelationList(ctx context.Context, in *RelationListRequest, opts ...grpc.CallOption) (StorageService_RelationListClient, error)
+	RelationUpdate(ctx context.Context, in *RelationUpdateRequest, opts ...grpc.CallOption) (*RelationUpdateResponse, error)
+	RelationDelete(ctx context.Context, in *RelationDeleteRequest, opts ...grpc.CallOption) (*RelationDeleteResponse, error)
+	GetRelatedProducts(ctx context.Context, in *GetRelatedProductsRequest, opts ...grpc.CallOption) (StorageService_GetRelatedProductsClient, error)
+	ExchangeRateSet(ctx context.Context, in *ExchangeRateSetRequest, opts ...grpc.CallOption) (*ExchangeRateSetResponse, error)
+	ExchangeRateList(ctx context.Context, in *ExchangeRateListRequest, opts ...grpc.CallOption) (StorageService_ExchangeRateListClient, error)
 }
 
 type storageServiceClient struct {
@@ -105,6 +138,581 @@ func (c *storageServiceClient) ProductDelete(ctx context.Context, in *ProductDel
 	return out, nil
 }
 
+func (c *storageServiceClient) ProductTransition(ctx context.Context, in *ProductTransitionRequest, opts ...grpc.CallOption) (*ProductTransitionResponse, error) {
+	out := new(ProductTransitionResponse)
+	err := c.cc.Invoke(ctx, "/api.storage.v1.StorageService/ProductTransition", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) ApproveChange(ctx context.Context, in *ApproveChangeRequest, opts ...grpc.CallOption) (*ApproveChangeResponse, error) {
+	out := new(ApproveChangeResponse)
+	err := c.cc.Invoke(ctx, "/api.storage.v1.StorageService/ApproveChange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) RejectChange(ctx context.Context, in *RejectChangeRequest, opts ...grpc.CallOption) (*RejectChangeResponse, error) {
+	out := new(RejectChangeResponse)
+	err := c.cc.Invoke(ctx, "/api.storage.v1.StorageService/RejectChange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) ListLowStock(ctx context.Context, in *ListLowStockRequest, opts ...grpc.CallOption) (StorageService_ListLowStockClient, error) {
+	stream, err := c.cc.NewStream(ctx, &StorageService_ServiceDesc.Streams[1], "/api.storage.v1.StorageService/ListLowStock", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storageServiceListLowStockClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StorageService_ListLowStockClient interface {
+	Recv() (*ListLowStockResponse, error)
+	grpc.ClientStream
+}
+
+type storageServiceListLowStockClient struct {
+	grpc.ClientStream
+}
+
+func (x *storageServiceListLowStockClient) Recv() (*ListLowStockResponse, error) {
+	m := new(ListLowStockResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *storageServiceClient) SetReorderThreshold(ctx context.Context, in *SetReorderThresholdRequest, opts ...grpc.CallOption) (*SetReorderThresholdResponse, error) {
+	out := new(SetReorderThresholdResponse)
+	err := c.cc.Invoke(ctx, "/api.storage.v1.StorageService/SetReorderThreshold", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) SupplierCreate(ctx context.Context, in *SupplierCreateRequest, opts ...grpc.CallOption) (*SupplierCreateResponse, error) {
+	out := new(SupplierCreateResponse)
+	err := c.cc.Invoke(ctx, "/api.storage.v1.StorageService/SupplierCreate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) SupplierList(ctx context.Context, in *SupplierListRequest, opts ...grpc.CallOption) (StorageService_SupplierListClient, error) {
+	stream, err := c.cc.NewStream(ctx, &StorageService_ServiceDesc.Streams[2], "/api.storage.v1.StorageService/SupplierList", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storageServiceSupplierListClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StorageService_SupplierListClient interface {
+	Recv() (*SupplierListResponse, error)
+	grpc.ClientStream
+}
+
+type storageServiceSupplierListClient struct {
+	grpc.ClientStream
+}
+
+func (x *storageServiceSupplierListClient) Recv() (*SupplierListResponse, error) {
+	m := new(SupplierListResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *storageServiceClient) PurchaseOrderCreate(ctx context.Context, in *PurchaseOrderCreateRequest, opts ...grpc.CallOption) (*PurchaseOrderCreateResponse, error) {
+	out := new(PurchaseOrderCreateResponse)
+	err := c.cc.Invoke(ctx, "/api.storage.v1.StorageService/PurchaseOrderCreate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) PurchaseOrderGet(ctx context.Context, in *PurchaseOrderGetRequest, opts ...grpc.CallOption) (*PurchaseOrderGetResponse, error) {
+	out := new(PurchaseOrderGetResponse)
+	err := c.cc.Invoke(ctx, "/api.storage.v1.StorageService/PurchaseOrderGet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) PurchaseOrderList(ctx context.Context, in *PurchaseOrderListRequest, opts ...grpc.CallOption) (StorageService_PurchaseOrderListClient, error) {
+	stream, err := c.cc.NewStream(ctx, &StorageService_ServiceDesc.Streams[3], "/api.storage.v1.StorageService/PurchaseOrderList", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storageServicePurchaseOrderListClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StorageService_PurchaseOrderListClient interface {
+	Recv() (*PurchaseOrderListResponse, error)
+	grpc.ClientStream
+}
+
+type storageServicePurchaseOrderListClient struct {
+	grpc.ClientStream
+}
+
+func (x *storageServicePurchaseOrderListClient) Recv() (*PurchaseOrderListResponse, error) {
+	m := new(PurchaseOrderListResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *storageServiceClient) PurchaseOrderReceive(ctx context.Context, in *PurchaseOrderReceiveRequest, opts ...grpc.CallOption) (*PurchaseOrderReceiveResponse, error) {
+	out := new(PurchaseOrderReceiveResponse)
+	err := c.cc.Invoke(ctx, "/api.storage.v1.StorageService/PurchaseOrderReceive", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) PlaceOrder(ctx context.Context, in *PlaceOrderRequest, opts ...grpc.CallOption) (*PlaceOrderResponse, error) {
+	out := new(PlaceOrderResponse)
+	err := c.cc.Invoke(ctx, "/api.storage.v1.StorageService/PlaceOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) LotAdd(ctx context.Context, in *LotAddRequest, opts ...grpc.CallOption) (*LotAddResponse, error) {
+	out := new(LotAddResponse)
+	err := c.cc.Invoke(ctx, "/api.storage.v1.StorageService/LotAdd", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) LotList(ctx context.Context, in *LotListRequest, opts ...grpc.CallOption) (StorageService_LotListClient, error) {
+	stream, err := c.cc.NewStream(ctx, &StorageService_ServiceDesc.Streams[4], "/api.storage.v1.StorageService/LotList", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storageServiceLotListClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StorageService_LotListClient interface {
+	Recv() (*LotListResponse, error)
+	grpc.ClientStream
+}
+
+type storageServiceLotListClient struct {
+	grpc.ClientStream
+}
+
+func (x *storageServiceLotListClient) Recv() (*LotListResponse, error) {
+	m := new(LotListResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *storageServiceClient) ListExpiringLots(ctx context.Context, in *ListExpiringLotsRequest, opts ...grpc.CallOption) (StorageService_ListExpiringLotsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &StorageService_ServiceDesc.Streams[5], "/api.storage.v1.StorageService/ListExpiringLots", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storageServiceListExpiringLotsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StorageService_ListExpiringLotsClient interface {
+	Recv() (*ListExpiringLotsResponse, error)
+	grpc.ClientStream
+}
+
+type storageServiceListExpiringLotsClient struct {
+	grpc.ClientStream
+}
+
+func (x *storageServiceListExpiringLotsClient) Recv() (*ListExpiringLotsResponse, error) {
+	m := new(ListExpiringLotsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *storageServiceClient) StocktakeOpen(ctx context.Context, in *StocktakeOpenRequest, opts ...grpc.CallOption) (*StocktakeOpenResponse, error) {
+	out := new(StocktakeOpenResponse)
+	err := c.cc.Invoke(ctx, "/api.storage.v1.StorageService/StocktakeOpen", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) StocktakeCount(ctx context.Context, in *StocktakeCountRequest, opts ...grpc.CallOption) (*StocktakeCountResponse, error) {
+	out := new(StocktakeCountResponse)
+	err := c.cc.Invoke(ctx, "/api.storage.v1.StorageService/StocktakeCount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) StocktakeGet(ctx context.Context, in *StocktakeGetRequest, opts ...grpc.CallOption) (*StocktakeGetResponse, error) {
+	out := new(StocktakeGetResponse)
+	err := c.cc.Invoke(ctx, "/api.storage.v1.StorageService/StocktakeGet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) StocktakeCommit(ctx context.Context, in *StocktakeCommitRequest, opts ...grpc.CallOption) (*StocktakeCommitResponse, error) {
+	out := new(StocktakeCommitResponse)
+	err := c.cc.Invoke(ctx, "/api.storage.v1.StorageService/StocktakeCommit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) StockValuation(ctx context.Context, in *StockValuationRequest, opts ...grpc.CallOption) (*StockValuationResponse, error) {
+	out := new(StockValuationResponse)
+	err := c.cc.Invoke(ctx, "/api.storage.v1.StorageService/StockValuation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) TopProductsByValue(ctx context.Context, in *TopProductsByValueRequest, opts ...grpc.CallOption) (StorageService_TopProductsByValueClient, error) {
+	stream, err := c.cc.NewStream(ctx, &StorageService_ServiceDesc.Streams[6], "/api.storage.v1.StorageService/TopProductsByValue", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storageServiceTopProductsByValueClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StorageService_TopProductsByValueClient interface {
+	Recv() (*TopProductsByValueResponse, error)
+	grpc.ClientStream
+}
+
+type storageServiceTopProductsByValueClient struct {
+	grpc.ClientStream
+}
+
+func (x *storageServiceTopProductsByValueClient) Recv() (*TopProductsByValueResponse, error) {
+	m := new(TopProductsByValueResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *storageServiceClient) ProductGetByBarcode(ctx context.Context, in *ProductGetByBarcodeRequest, opts ...grpc.CallOption) (*ProductGetByBarcodeResponse, error) {
+	out := new(ProductGetByBarcodeResponse)
+	err := c.cc.Invoke(ctx, "/api.storage.v1.StorageService/ProductGetByBarcode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) ProductTranslate(ctx context.Context, in *ProductTranslateRequest, opts ...grpc.CallOption) (*ProductTranslateResponse, error) {
+	out := new(ProductTranslateResponse)
+	err := c.cc.Invoke(ctx, "/api.storage.v1.StorageService/ProductTranslate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) UploadProductImage(ctx context.Context, opts ...grpc.CallOption) (StorageService_UploadProductImageClient, error) {
+	stream, err := c.cc.NewStream(ctx, &StorageService_ServiceDesc.Streams[7], "/api.storage.v1.StorageService/UploadProductImage", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storageServiceUploadProductImageClient{stream}
+	return x, nil
+}
+
+type StorageService_UploadProductImageClient interface {
+	Send(*UploadProductImageRequest) error
+	CloseAndRecv() (*UploadProductImageResponse, error)
+	grpc.ClientStream
+}
+
+type storageServiceUploadProductImageClient struct {
+	grpc.ClientStream
+}
+
+func (x *storageServiceUploadProductImageClient) Send(m *UploadProductImageRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *storageServiceUploadProductImageClient) CloseAndRecv() (*UploadProductImageResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadProductImageResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *storageServiceClient) DownloadProductImage(ctx context.Context, in *DownloadProductImageRequest, opts ...grpc.CallOption) (StorageService_DownloadProductImageClient, error) {
+	stream, err := c.cc.NewStream(ctx, &StorageService_ServiceDesc.Streams[8], "/api.storage.v1.StorageService/DownloadProductImage", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storageServiceDownloadProductImageClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StorageService_DownloadProductImageClient interface {
+	Recv() (*DownloadProductImageResponse, error)
+	grpc.ClientStream
+}
+
+type storageServiceDownloadProductImageClient struct {
+	grpc.ClientStream
+}
+
+func (x *storageServiceDownloadProductImageClient) Recv() (*DownloadProductImageResponse, error) {
+	m := new(DownloadProductImageResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *storageServiceClient) ListProductImages(ctx context.Context, in *ListProductImagesRequest, opts ...grpc.CallOption) (StorageService_ListProductImagesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &StorageService_ServiceDesc.Streams[9], "/api.storage.v1.StorageService/ListProductImages", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storageServiceListProductImagesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StorageService_ListProductImagesClient interface {
+	Recv() (*ListProductImagesResponse, error)
+	grpc.ClientStream
+}
+
+type storageServiceListProductImagesClient struct {
+	grpc.ClientStream
+}
+
+func (x *storageServiceListProductImagesClient) Recv() (*ListProductImagesResponse, error) {
+	m := new(ListProductImagesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *storageServiceClient) RelationCreate(ctx context.Context, in *RelationCreateRequest, opts ...grpc.CallOption) (*RelationCreateResponse, error) {
+	out := new(RelationCreateResponse)
+	err := c.cc.Invoke(ctx, "/api.storage.v1.StorageService/RelationCreate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) RelationList(ctx context.Context, in *RelationListRequest, opts ...grpc.CallOption) (StorageService_RelationListClient, error) {
+	stream, err := c.cc.NewStream(ctx, &StorageService_ServiceDesc.Streams[10], "/api.storage.v1.StorageService/RelationList", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storageServiceRelationListClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StorageService_RelationListClient interface {
+	Recv() (*RelationListResponse, error)
+	grpc.ClientStream
+}
+
+type storageServiceRelationListClient struct {
+	grpc.ClientStream
+}
+
+func (x *storageServiceRelationListClient) Recv() (*RelationListResponse, error) {
+	m := new(RelationListResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *storageServiceClient) RelationUpdate(ctx context.Context, in *RelationUpdateRequest, opts ...grpc.CallOption) (*RelationUpdateResponse, error) {
+	out := new(RelationUpdateResponse)
+	err := c.cc.Invoke(ctx, "/api.storage.v1.StorageService/RelationUpdate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) RelationDelete(ctx context.Context, in *RelationDeleteRequest, opts ...grpc.CallOption) (*RelationDeleteResponse, error) {
+	out := new(RelationDeleteResponse)
+	err := c.cc.Invoke(ctx, "/api.storage.v1.StorageService/RelationDelete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) GetRelatedProducts(ctx context.Context, in *GetRelatedProductsRequest, opts ...grpc.CallOption) (StorageService_GetRelatedProductsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &StorageService_ServiceDesc.Streams[11], "/api.storage.v1.StorageService/GetRelatedProducts", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storageServiceGetRelatedProductsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StorageService_GetRelatedProductsClient interface {
+	Recv() (*GetRelatedProductsResponse, error)
+	grpc.ClientStream
+}
+
+type storageServiceGetRelatedProductsClient struct {
+	grpc.ClientStream
+}
+
+func (x *storageServiceGetRelatedProductsClient) Recv() (*GetRelatedProductsResponse, error) {
+	m := new(GetRelatedProductsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *storageServiceClient) ExchangeRateSet(ctx context.Context, in *ExchangeRateSetRequest, opts ...grpc.CallOption) (*ExchangeRateSetResponse, error) {
+	out := new(ExchangeRateSetResponse)
+	err := c.cc.Invoke(ctx, "/api.storage.v1.StorageService/ExchangeRateSet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) ExchangeRateList(ctx context.Context, in *ExchangeRateListRequest, opts ...grpc.CallOption) (StorageService_ExchangeRateListClient, error) {
+	stream, err := c.cc.NewStream(ctx, &StorageService_ServiceDesc.Streams[12], "/api.storage.v1.StorageService/ExchangeRateList", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storageServiceExchangeRateListClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StorageService_ExchangeRateListClient interface {
+	Recv() (*ExchangeRateListResponse, error)
+	grpc.ClientStream
+}
+
+type storageServiceExchangeRateListClient struct {
+	grpc.ClientStream
+}
+
+func (x *storageServiceExchangeRateListClient) Recv() (*ExchangeRateListResponse, error) {
+	m := new(ExchangeRateListResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // StorageServiceServer is the server API for StorageService service.
 // All implementations must embed UnimplementedStorageServiceServer
 // for forward compatibility
@@ -114,134 +722,901 @@ type StorageServiceServer interface {
 	ProductCreate(context.Context, *ProductCreateRequest) (*ProductCreateResponse, error)
 	ProductUpdate(context.Context, *ProductUpdateRequest) (*ProductUpdateResponse, error)
 	ProductDelete(context.Context, *ProductDeleteRequest) (*ProductDeleteResponse, error)
+	ProductTransition(context.Context, *ProductTransitionRequest) (*ProductTransitionResponse, error)
+	ApproveChange(context.Context, *ApproveChangeRequest) (*ApproveChangeResponse, error)
+	RejectChange(context.Context, *RejectChangeRequest) (*RejectChangeResponse, error)
+	ListLowStock(*ListLowStockRequest, StorageService_ListLowStockServer) error
+	SetReorderThreshold(context.Context, *SetReorderThresholdRequest) (*SetReorderThresholdResponse, error)
+	SupplierCreate(context.Context, *SupplierCreateRequest) (*SupplierCreateResponse, error)
+	SupplierList(*SupplierListRequest, StorageService_SupplierListServer) error
+	PurchaseOrderCreate(context.Context, *PurchaseOrderCreateRequest) (*PurchaseOrderCreateResponse, error)
+	PurchaseOrderGet(context.Context, *PurchaseOrderGetRequest) (*PurchaseOrderGetResponse, error)
+	PurchaseOrderList(*PurchaseOrderListRequest, StorageService_PurchaseOrderListServer) error
+	PurchaseOrderReceive(context.Context, *PurchaseOrderReceiveRequest) (*PurchaseOrderReceiveResponse, error)
+	PlaceOrder(context.Context, *PlaceOrderRequest) (*PlaceOrderResponse, error)
+	LotAdd(context.Context, *LotAddRequest) (*LotAddResponse, error)
+	LotList(*LotListRequest, StorageService_LotListServer) error
+	ListExpiringLots(*ListExpiringLotsRequest, StorageService_ListExpiringLotsServer) error
+	StocktakeOpen(context.Context, *StocktakeOpenRequest) (*StocktakeOpenResponse, error)
+	StocktakeCount(context.Context, *StocktakeCountRequest) (*StocktakeCountResponse, error)
+	StocktakeGet(context.Context, *StocktakeGetRequest) (*StocktakeGetResponse, error)
+	StocktakeCommit(context.Context, *StocktakeCommitRequest) (*StocktakeCommitResponse, error)
+	StockValuation(context.Context, *StockValuationRequest) (*StockValuationResponse, error)
+	TopProductsByValue(*TopProductsByValueRequest, StorageService_TopProductsByValueServer) error
+	ProductGetByBarcode(context.Context, *ProductGetByBarcodeRequest) (*ProductGetByBarcodeResponse, error)
+	ProductTranslate(context.Context, *ProductTranslateRequest) (*ProductTranslateResponse, error)
+	UploadProductImage(StorageService_UploadProductImageServer) error
+	DownloadProductImage(*DownloadProductImageRequest, StorageService_DownloadProductImageServer) error
+	ListProductImages(*ListProductImagesRequest, StorageService_ListProductImagesServer) error
+	RelationCreate(context.Context, *RelationCreateRequest) (*RelationCreateResponse, error)
+	RelationList(*RelationListRequest, StorageService_RelationListServer) error
+	RelationUpdate(context.Context, *RelationUpdateRequest) (*RelationUpdateResponse, error)
+	RelationDelete(context.Context, *RelationDeleteRequest) (*RelationDeleteResponse, error)
+	GetRelatedProducts(*GetRelatedProductsRequest, StorageService_GetRelatedProductsServer) error
+	ExchangeRateSet(context.Context, *ExchangeRateSetRequest) (*ExchangeRateSetResponse, error)
+	ExchangeRateList(*ExchangeRateListRequest, StorageService_ExchangeRateListServer) error
+	mustEmbedUnimplementedStorageServiceServer()
+}
+
+// UnimplementedStorageServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedStorageServiceServer struct {
+}
+
+func (UnimplementedStorageServiceServer) ProductList(*ProductListRequest, StorageService_ProductListServer) error {
+	return status.Errorf(codes.Unimplemented, "method ProductList not implemented")
+}
+func (UnimplementedStorageServiceServer) ProductGet(context.Context, *ProductGetRequest) (*ProductGetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProductGet not implemented")
+}
+func (UnimplementedStorageServiceServer) ProductCreate(context.Context, *ProductCreateRequest) (*ProductCreateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProductCreate not implemented")
+}
+func (UnimplementedStorageServiceServer) ProductUpdate(context.Context, *ProductUpdateRequest) (*ProductUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProductUpdate not implemented")
+}
+func (UnimplementedStorageServiceServer) ProductDelete(context.Context, *ProductDeleteRequest) (*ProductDeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProductDelete not implemented")
+}
+func (UnimplementedStorageServiceServer) ProductTransition(context.Context, *ProductTransitionRequest) (*ProductTransitionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProductTransition not implemented")
+}
+func (UnimplementedStorageServiceServer) ApproveChange(context.Context, *ApproveChangeRequest) (*ApproveChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveChange not implemented")
+}
+func (UnimplementedStorageServiceServer) RejectChange(context.Context, *RejectChangeRequest) (*RejectChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectChange not implemented")
+}
+func (UnimplementedStorageServiceServer) ListLowStock(*ListLowStockRequest, StorageService_ListLowStockServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListLowStock not implemented")
+}
+func (UnimplementedStorageServiceServer) SetReorderThreshold(context.Context, *SetReorderThresholdRequest) (*SetReorderThresholdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetReorderThreshold not implemented")
+}
+func (UnimplementedStorageServiceServer) SupplierCreate(context.Context, *SupplierCreateRequest) (*SupplierCreateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SupplierCreate not implemented")
+}
+func (UnimplementedStorageServiceServer) SupplierList(*SupplierListRequest, StorageService_SupplierListServer) error {
+	return status.Errorf(codes.Unimplemented, "method SupplierList not implemented")
+}
+func (UnimplementedStorageServiceServer) PurchaseOrderCreate(context.Context, *PurchaseOrderCreateRequest) (*PurchaseOrderCreateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurchaseOrderCreate not implemented")
+}
+func (UnimplementedStorageServiceServer) PurchaseOrderGet(context.Context, *PurchaseOrderGetRequest) (*PurchaseOrderGetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurchaseOrderGet not implemented")
+}
+func (UnimplementedStorageServiceServer) PurchaseOrderList(*PurchaseOrderListRequest, StorageService_PurchaseOrderListServer) error {
+	return status.Errorf(codes.Unimplemented, "method PurchaseOrderList not implemented")
+}
+func (UnimplementedStorageServiceServer) PurchaseOrderReceive(context.Context, *PurchaseOrderReceiveRequest) (*PurchaseOrderReceiveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurchaseOrderReceive not implemented")
+}
+func (UnimplementedStorageServiceServer) PlaceOrder(context.Context, *PlaceOrderRequest) (*PlaceOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlaceOrder not implemented")
+}
+func (UnimplementedStorageServiceServer) LotAdd(context.Context, *LotAddRequest) (*LotAddResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LotAdd not implemented")
+}
+func (UnimplementedStorageServiceServer) LotList(*LotListRequest, StorageService_LotListServer) error {
+	return status.Errorf(codes.Unimplemented, "method LotList not implemented")
+}
+func (UnimplementedStorageServiceServer) ListExpiringLots(*ListExpiringLotsRequest, StorageService_ListExpiringLotsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListExpiringLots not implemented")
+}
+func (UnimplementedStorageServiceServer) StocktakeOpen(context.Context, *StocktakeOpenRequest) (*StocktakeOpenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StocktakeOpen not implemented")
+}
+func (UnimplementedStorageServiceServer) StocktakeCount(context.Context, *StocktakeCountRequest) (*StocktakeCountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StocktakeCount not implemented")
+}
+func (UnimplementedStorageServiceServer) StocktakeGet(context.Context, *StocktakeGetRequest) (*StocktakeGetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StocktakeGet not implemented")
+}
+func (UnimplementedStorageServiceServer) StocktakeCommit(context.Context, *StocktakeCommitRequest) (*StocktakeCommitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StocktakeCommit not implemented")
+}
+func (UnimplementedStorageServiceServer) StockValuation(context.Context, *StockValuationRequest) (*StockValuationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StockValuation not implemented")
+}
+func (UnimplementedStorageServiceServer) TopProductsByValue(*TopProductsByValueRequest, StorageService_TopProductsByValueServer) error {
+	return status.Errorf(codes.Unimplemented, "method TopProductsByValue not implemented")
+}
+func (UnimplementedStorageServiceServer) ProductGetByBarcode(context.Context, *ProductGetByBarcodeRequest) (*ProductGetByBarcodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProductGetByBarcode not implemented")
+}
+func (UnimplementedStorageServiceServer) ProductTranslate(context.Context, *ProductTranslateRequest) (*ProductTranslateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProductTranslate not implemented")
+}
+func (UnimplementedStorageServiceServer) UploadProductImage(StorageService_UploadProductImageServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadProductImage not implemented")
+}
+func (UnimplementedStorageServiceServer) DownloadProductImage(*DownloadProductImageRequest, StorageService_DownloadProductImageServer) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadProductImage not implemented")
+}
+func (UnimplementedStorageServiceServer) ListProductImages(*ListProductImagesRequest, StorageService_ListProductImagesServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListProductImages not implemented")
+}
+func (UnimplementedStorageServiceServer) RelationCreate(context.Context, *RelationCreateRequest) (*RelationCreateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RelationCreate not implemented")
+}
+func (UnimplementedStorageServiceServer) RelationList(*RelationListRequest, StorageService_RelationListServer) error {
+	return status.Errorf(codes.Unimplemented, "method RelationList not implemented")
+}
+func (UnimplementedStorageServiceServer) RelationUpdate(context.Context, *RelationUpdateRequest) (*RelationUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RelationUpdate not implemented")
+}
+func (UnimplementedStorageServiceServer) RelationDelete(context.Context, *RelationDeleteRequest) (*RelationDeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RelationDelete not implemented")
+}
+func (UnimplementedStorageServiceServer) GetRelatedProducts(*GetRelatedProductsRequest, StorageService_GetRelatedProductsServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetRelatedProducts not implemented")
+}
+func (UnimplementedStorageServiceServer) ExchangeRateSet(context.Context, *ExchangeRateSetRequest) (*ExchangeRateSetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExchangeRateSet not implemented")
+}
+func (UnimplementedStorageServiceServer) ExchangeRateList(*ExchangeRateListRequest, StorageService_ExchangeRateListServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExchangeRateList not implemented")
+}
+func (UnimplementedStorageServiceServer) mustEmbedUnimplementedStorageServiceServer() {}
+
+// UnsafeStorageServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StorageServiceServer will
+// result in compilation errors.
+type UnsafeStorageServiceServer interface {
 	mustEmbedUnimplementedStorageServiceServer()
 }
 
-// UnimplementedStorageServiceServer must be embedded to have forward compatible implementations.
-type UnimplementedStorageServiceServer struct {
+func RegisterStorageServiceServer(s grpc.ServiceRegistrar, srv StorageServiceServer) {
+	s.RegisterService(&StorageService_ServiceDesc, srv)
+}
+
+func _StorageService_ProductList_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ProductListRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StorageServiceServer).ProductList(m, &storageServiceProductListServer{stream})
+}
+
+type StorageService_ProductListServer interface {
+	Send(*ProductListResponse) error
+	grpc.ServerStream
+}
+
+type storageServiceProductListServer struct {
+	grpc.ServerStream
+}
+
+func (x *storageServiceProductListServer) Send(m *ProductListResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _StorageService_ProductGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProductGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).ProductGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.storage.v1.StorageService/ProductGet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).ProductGet(ctx, req.(*ProductGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_ProductCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProductCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).ProductCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.storage.v1.StorageService/ProductCreate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).ProductCreate(ctx, req.(*ProductCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_ProductUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProductUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).ProductUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.storage.v1.StorageService/ProductUpdate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).ProductUpdate(ctx, req.(*ProductUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_ProductDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProductDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).ProductDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.storage.v1.StorageService/ProductDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).ProductDelete(ctx, req.(*ProductDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_ProductTransition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProductTransitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).ProductTransition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.storage.v1.StorageService/ProductTransition",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).ProductTransition(ctx, req.(*ProductTransitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_ApproveChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).ApproveChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.storage.v1.StorageService/ApproveChange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).ApproveChange(ctx, req.(*ApproveChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_RejectChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).RejectChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.storage.v1.StorageService/RejectChange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).RejectChange(ctx, req.(*RejectChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_ListLowStock_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListLowStockRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StorageServiceServer).ListLowStock(m, &storageServiceListLowStockServer{stream})
+}
+
+type StorageService_ListLowStockServer interface {
+	Send(*ListLowStockResponse) error
+	grpc.ServerStream
+}
+
+type storageServiceListLowStockServer struct {
+	grpc.ServerStream
+}
+
+func (x *storageServiceListLowStockServer) Send(m *ListLowStockResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _StorageService_SetReorderThreshold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetReorderThresholdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).SetReorderThreshold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.storage.v1.StorageService/SetReorderThreshold",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).SetReorderThreshold(ctx, req.(*SetReorderThresholdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_SupplierCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SupplierCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).SupplierCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.storage.v1.StorageService/SupplierCreate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).SupplierCreate(ctx, req.(*SupplierCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_SupplierList_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SupplierListRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StorageServiceServer).SupplierList(m, &storageServiceSupplierListServer{stream})
+}
+
+type StorageService_SupplierListServer interface {
+	Send(*SupplierListResponse) error
+	grpc.ServerStream
+}
+
+type storageServiceSupplierListServer struct {
+	grpc.ServerStream
+}
+
+func (x *storageServiceSupplierListServer) Send(m *SupplierListResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _StorageService_PurchaseOrderCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurchaseOrderCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).PurchaseOrderCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.storage.v1.StorageService/PurchaseOrderCreate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).PurchaseOrderCreate(ctx, req.(*PurchaseOrderCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_PurchaseOrderGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurchaseOrderGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).PurchaseOrderGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.storage.v1.StorageService/PurchaseOrderGet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).PurchaseOrderGet(ctx, req.(*PurchaseOrderGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_PurchaseOrderList_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PurchaseOrderListRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StorageServiceServer).PurchaseOrderList(m, &storageServicePurchaseOrderListServer{stream})
+}
+
+type StorageService_PurchaseOrderListServer interface {
+	Send(*PurchaseOrderListResponse) error
+	grpc.ServerStream
+}
+
+type storageServicePurchaseOrderListServer struct {
+	grpc.ServerStream
+}
+
+func (x *storageServicePurchaseOrderListServer) Send(m *PurchaseOrderListResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _StorageService_PurchaseOrderReceive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurchaseOrderReceiveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).PurchaseOrderReceive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.storage.v1.StorageService/PurchaseOrderReceive",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).PurchaseOrderReceive(ctx, req.(*PurchaseOrderReceiveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_PlaceOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaceOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).PlaceOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.storage.v1.StorageService/PlaceOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).PlaceOrder(ctx, req.(*PlaceOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_LotAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LotAddRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).LotAdd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.storage.v1.StorageService/LotAdd",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).LotAdd(ctx, req.(*LotAddRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_LotList_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(LotListRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StorageServiceServer).LotList(m, &storageServiceLotListServer{stream})
+}
+
+type StorageService_LotListServer interface {
+	Send(*LotListResponse) error
+	grpc.ServerStream
+}
+
+type storageServiceLotListServer struct {
+	grpc.ServerStream
+}
+
+func (x *storageServiceLotListServer) Send(m *LotListResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _StorageService_ListExpiringLots_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListExpiringLotsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StorageServiceServer).ListExpiringLots(m, &storageServiceListExpiringLotsServer{stream})
+}
+
+type StorageService_ListExpiringLotsServer interface {
+	Send(*ListExpiringLotsResponse) error
+	grpc.ServerStream
+}
+
+type storageServiceListExpiringLotsServer struct {
+	grpc.ServerStream
+}
+
+func (x *storageServiceListExpiringLotsServer) Send(m *ListExpiringLotsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _StorageService_StocktakeOpen_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StocktakeOpenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).StocktakeOpen(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.storage.v1.StorageService/StocktakeOpen",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).StocktakeOpen(ctx, req.(*StocktakeOpenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_StocktakeCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StocktakeCountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).StocktakeCount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.storage.v1.StorageService/StocktakeCount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).StocktakeCount(ctx, req.(*StocktakeCountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_StocktakeGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StocktakeGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).StocktakeGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.storage.v1.StorageService/StocktakeGet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).StocktakeGet(ctx, req.(*StocktakeGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_StocktakeCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StocktakeCommitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).StocktakeCommit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.storage.v1.StorageService/StocktakeCommit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).StocktakeCommit(ctx, req.(*StocktakeCommitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (UnimplementedStorageServiceServer) ProductList(*ProductListRequest, StorageService_ProductListServer) error {
-	return status.Errorf(codes.Unimplemented, "method ProductList not implemented")
+func _StorageService_StockValuation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StockValuationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).StockValuation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.storage.v1.StorageService/StockValuation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).StockValuation(ctx, req.(*StockValuationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedStorageServiceServer) ProductGet(context.Context, *ProductGetRequest) (*ProductGetResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ProductGet not implemented")
+
+func _StorageService_TopProductsByValue_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TopProductsByValueRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StorageServiceServer).TopProductsByValue(m, &storageServiceTopProductsByValueServer{stream})
 }
-func (UnimplementedStorageServiceServer) ProductCreate(context.Context, *ProductCreateRequest) (*ProductCreateResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ProductCreate not implemented")
+
+type StorageService_TopProductsByValueServer interface {
+	Send(*TopProductsByValueResponse) error
+	grpc.ServerStream
 }
-func (UnimplementedStorageServiceServer) ProductUpdate(context.Context, *ProductUpdateRequest) (*ProductUpdateResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ProductUpdate not implemented")
+
+type storageServiceTopProductsByValueServer struct {
+	grpc.ServerStream
 }
-func (UnimplementedStorageServiceServer) ProductDelete(context.Context, *ProductDeleteRequest) (*ProductDeleteResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ProductDelete not implemented")
+
+func (x *storageServiceTopProductsByValueServer) Send(m *TopProductsByValueResponse) error {
+	return x.ServerStream.SendMsg(m)
 }
-func (UnimplementedStorageServiceServer) mustEmbedUnimplementedStorageServiceServer() {}
 
-// UnsafeStorageServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to StorageServiceServer will
-// result in compilation errors.
-type UnsafeStorageServiceServer interface {
-	mustEmbedUnimplementedStorageServiceServer()
+func _StorageService_ProductGetByBarcode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProductGetByBarcodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).ProductGetByBarcode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.storage.v1.StorageService/ProductGetByBarcode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).ProductGetByBarcode(ctx, req.(*ProductGetByBarcodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterStorageServiceServer(s grpc.ServiceRegistrar, srv StorageServiceServer) {
-	s.RegisterService(&StorageService_ServiceDesc, srv)
+func _StorageService_ProductTranslate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProductTranslateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).ProductTranslate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.storage.v1.StorageService/ProductTranslate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).ProductTranslate(ctx, req.(*ProductTranslateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _StorageService_ProductList_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(ProductListRequest)
+func _StorageService_UploadProductImage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(StorageServiceServer).UploadProductImage(&storageServiceUploadProductImageServer{stream})
+}
+
+type StorageService_UploadProductImageServer interface {
+	SendAndClose(*UploadProductImageResponse) error
+	Recv() (*UploadProductImageRequest, error)
+	grpc.ServerStream
+}
+
+type storageServiceUploadProductImageServer struct {
+	grpc.ServerStream
+}
+
+func (x *storageServiceUploadProductImageServer) SendAndClose(m *UploadProductImageResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *storageServiceUploadProductImageServer) Recv() (*UploadProductImageRequest, error) {
+	m := new(UploadProductImageRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _StorageService_DownloadProductImage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadProductImageRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(StorageServiceServer).ProductList(m, &storageServiceProductListServer{stream})
+	return srv.(StorageServiceServer).DownloadProductImage(m, &storageServiceDownloadProductImageServer{stream})
 }
 
-type StorageService_ProductListServer interface {
-	Send(*ProductListResponse) error
+type StorageService_DownloadProductImageServer interface {
+	Send(*DownloadProductImageResponse) error
 	grpc.ServerStream
 }
 
-type storageServiceProductListServer struct {
+type storageServiceDownloadProductImageServer struct {
 	grpc.ServerStream
 }
 
-func (x *storageServiceProductListServer) Send(m *ProductListResponse) error {
+func (x *storageServiceDownloadProductImageServer) Send(m *DownloadProductImageResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _StorageService_ProductGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ProductGetRequest)
+func _StorageService_ListProductImages_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListProductImagesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StorageServiceServer).ListProductImages(m, &storageServiceListProductImagesServer{stream})
+}
+
+type StorageService_ListProductImagesServer interface {
+	Send(*ListProductImagesResponse) error
+	grpc.ServerStream
+}
+
+type storageServiceListProductImagesServer struct {
+	grpc.ServerStream
+}
+
+func (x *storageServiceListProductImagesServer) Send(m *ListProductImagesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _StorageService_RelationCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RelationCreateRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(StorageServiceServer).ProductGet(ctx, in)
+		return srv.(StorageServiceServer).RelationCreate(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.storage.v1.StorageService/ProductGet",
+		FullMethod: "/api.storage.v1.StorageService/RelationCreate",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(StorageServiceServer).ProductGet(ctx, req.(*ProductGetRequest))
+		return srv.(StorageServiceServer).RelationCreate(ctx, req.(*RelationCreateRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _StorageService_ProductCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ProductCreateRequest)
+func _StorageService_RelationList_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RelationListRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StorageServiceServer).RelationList(m, &storageServiceRelationListServer{stream})
+}
+
+type StorageService_RelationListServer interface {
+	Send(*RelationListResponse) error
+	grpc.ServerStream
+}
+
+type storageServiceRelationListServer struct {
+	grpc.ServerStream
+}
+
+func (x *storageServiceRelationListServer) Send(m *RelationListResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _StorageService_RelationUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RelationUpdateRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(StorageServiceServer).ProductCreate(ctx, in)
+		return srv.(StorageServiceServer).RelationUpdate(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.storage.v1.StorageService/ProductCreate",
+		FullMethod: "/api.storage.v1.StorageService/RelationUpdate",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(StorageServiceServer).ProductCreate(ctx, req.(*ProductCreateRequest))
+		return srv.(StorageServiceServer).RelationUpdate(ctx, req.(*RelationUpdateRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _StorageService_ProductUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ProductUpdateRequest)
+func _StorageService_RelationDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RelationDeleteRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(StorageServiceServer).ProductUpdate(ctx, in)
+		return srv.(StorageServiceServer).RelationDelete(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.storage.v1.StorageService/ProductUpdate",
+		FullMethod: "/api.storage.v1.StorageService/RelationDelete",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(StorageServiceServer).ProductUpdate(ctx, req.(*ProductUpdateRequest))
+		return srv.(StorageServiceServer).RelationDelete(ctx, req.(*RelationDeleteRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _StorageService_ProductDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ProductDeleteRequest)
+func _StorageService_GetRelatedProducts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetRelatedProductsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StorageServiceServer).GetRelatedProducts(m, &storageServiceGetRelatedProductsServer{stream})
+}
+
+type StorageService_GetRelatedProductsServer interface {
+	Send(*GetRelatedProductsResponse) error
+	grpc.ServerStream
+}
+
+type storageServiceGetRelatedProductsServer struct {
+	grpc.ServerStream
+}
+
+func (x *storageServiceGetRelatedProductsServer) Send(m *GetRelatedProductsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _StorageService_ExchangeRateSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExchangeRateSetRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(StorageServiceServer).ProductDelete(ctx, in)
+		return srv.(StorageServiceServer).ExchangeRateSet(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.storage.v1.StorageService/ProductDelete",
+		FullMethod: "/api.storage.v1.StorageService/ExchangeRateSet",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(StorageServiceServer).ProductDelete(ctx, req.(*ProductDeleteRequest))
+		return srv.(StorageServiceServer).ExchangeRateSet(ctx, req.(*ExchangeRateSetRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StorageService_ExchangeRateList_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExchangeRateListRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StorageServiceServer).ExchangeRateList(m, &storageServiceExchangeRateListServer{stream})
+}
+
+type StorageService_ExchangeRateListServer interface {
+	Send(*ExchangeRateListResponse) error
+	grpc.ServerStream
+}
+
+type storageServiceExchangeRateListServer struct {
+	grpc.ServerStream
+}
+
+func (x *storageServiceExchangeRateListServer) Send(m *ExchangeRateListResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // StorageService_ServiceDesc is the grpc.ServiceDesc for StorageService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -265,6 +1640,90 @@ var StorageService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ProductDelete",
 			Handler:    _StorageService_ProductDelete_Handler,
 		},
+		{
+			MethodName: "ProductTransition",
+			Handler:    _StorageService_ProductTransition_Handler,
+		},
+		{
+			MethodName: "ApproveChange",
+			Handler:    _StorageService_ApproveChange_Handler,
+		},
+		{
+			MethodName: "RejectChange",
+			Handler:    _StorageService_RejectChange_Handler,
+		},
+		{
+			MethodName: "SetReorderThreshold",
+			Handler:    _StorageService_SetReorderThreshold_Handler,
+		},
+		{
+			MethodName: "SupplierCreate",
+			Handler:    _StorageService_SupplierCreate_Handler,
+		},
+		{
+			MethodName: "PurchaseOrderCreate",
+			Handler:    _StorageService_PurchaseOrderCreate_Handler,
+		},
+		{
+			MethodName: "PurchaseOrderGet",
+			Handler:    _StorageService_PurchaseOrderGet_Handler,
+		},
+		{
+			MethodName: "PurchaseOrderReceive",
+			Handler:    _StorageService_PurchaseOrderReceive_Handler,
+		},
+		{
+			MethodName: "PlaceOrder",
+			Handler:    _StorageService_PlaceOrder_Handler,
+		},
+		{
+			MethodName: "LotAdd",
+			Handler:    _StorageService_LotAdd_Handler,
+		},
+		{
+			MethodName: "StocktakeOpen",
+			Handler:    _StorageService_StocktakeOpen_Handler,
+		},
+		{
+			MethodName: "StocktakeCount",
+			Handler:    _StorageService_StocktakeCount_Handler,
+		},
+		{
+			MethodName: "StocktakeGet",
+			Handler:    _StorageService_StocktakeGet_Handler,
+		},
+		{
+			MethodName: "StocktakeCommit",
+			Handler:    _StorageService_StocktakeCommit_Handler,
+		},
+		{
+			MethodName: "StockValuation",
+			Handler:    _StorageService_StockValuation_Handler,
+		},
+		{
+			MethodName: "ProductGetByBarcode",
+			Handler:    _StorageService_ProductGetByBarcode_Handler,
+		},
+		{
+			MethodName: "ProductTranslate",
+			Handler:    _StorageService_ProductTranslate_Handler,
+		},
+		{
+			MethodName: "RelationCreate",
+			Handler:    _StorageService_RelationCreate_Handler,
+		},
+		{
+			MethodName: "RelationUpdate",
+			Handler:    _StorageService_RelationUpdate_Handler,
+		},
+		{
+			MethodName: "RelationDelete",
+			Handler:    _StorageService_RelationDelete_Handler,
+		},
+		{
+			MethodName: "ExchangeRateSet",
+			Handler:    _StorageService_ExchangeRateSet_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -272,6 +1731,66 @@ var StorageService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _StorageService_ProductList_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ListLowStock",
+			Handler:       _StorageService_ListLowStock_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SupplierList",
+			Handler:       _StorageService_SupplierList_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "PurchaseOrderList",
+			Handler:       _StorageService_PurchaseOrderList_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "LotList",
+			Handler:       _StorageService_LotList_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListExpiringLots",
+			Handler:       _StorageService_ListExpiringLots_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "TopProductsByValue",
+			Handler:       _StorageService_TopProductsByValue_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "UploadProductImage",
+			Handler:       _StorageService_UploadProductImage_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadProductImage",
+			Handler:       _StorageService_DownloadProductImage_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListProductImages",
+			Handler:       _StorageService_ListProductImages_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RelationList",
+			Handler:       _StorageService_RelationList_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetRelatedProducts",
+			Handler:       _StorageService_GetRelatedProducts_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExchangeRateList",
+			Handler:       _StorageService_ExchangeRateList_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "storage/v1/api.proto",
 }
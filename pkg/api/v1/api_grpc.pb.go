@@ -27,6 +27,42 @@ type ApiServiceClient interface {
 	ProductCreate(ctx context.Context, in *ProductCreateRequest, opts ...grpc.CallOption) (*ProductCreateResponse, error)
 	ProductUpdate(ctx context.Context, in *ProductUpdateRequest, opts ...grpc.CallOption) (*ProductUpdateResponse, error)
 	ProductDelete(ctx context.Context, in *ProductDeleteRequest, opts ...grpc.CallOption) (*ProductDeleteResponse, error)
+	ProductTransition(ctx context.Context, in *ProductTransitionRequest, opts ...grpc.CallOption) (*ProductTransitionResponse, error)
+	ApproveChange(ctx context.Context, in *ApproveChangeRequest, opts ...grpc.CallOption) (*ApproveChangeResponse, error)
+	RejectChange(ctx context.Context, in *RejectChangeRequest, opts ...grpc.CallOption) (*RejectChangeResponse, error)
+	ListLowStock(ctx context.Context, in *ListLowStockRequest, opts ...grpc.CallOption) (*ListLowStockResponse, error)
+	SetReorderThreshold(ctx context.Context, in *SetReorderThresholdRequest, opts ...grpc.CallOption) (*SetReorderThresholdResponse, error)
+	SupplierCreate(ctx context.Context, in *SupplierCreateRequest, opts ...grpc.CallOption) (*SupplierCreateResponse, error)
+	SupplierList(ctx context.Context, in *SupplierListRequest, opts ...grpc.CallOption) (*SupplierListResponse, error)
+	PurchaseOrderCreate(ctx context.Context, in *PurchaseOrderCreateRequest, opts ...grpc.CallOption) (*PurchaseOrderCreateResponse, error)
+	PurchaseOrderGet(ctx context.Context, in *PurchaseOrderGetRequest, opts ...grpc.CallOption) (*PurchaseOrderGetResponse, error)
+	PurchaseOrderList(ctx context.Context, in *PurchaseOrderListRequest, opts ...grpc.CallOption) (*PurchaseOrderListResponse, error)
+	PurchaseOrderReceive(ctx context.Context, in *PurchaseOrderReceiveRequest, opts ...grpc.CallOption) (*PurchaseOrderReceiveResponse, error)
+	PlaceOrder(ctx context.Context, in *PlaceOrderRequest, opts ...grpc.CallOption) (*PlaceOrderResponse, error)
+	LotAdd(ctx context.Context, in *LotAddRequest, opts ...grpc.CallOption) (*LotAddResponse, error)
+	LotList(ctx context.Context, in *LotListRequest, opts ...grpc.CallOption) (*LotListResponse, error)
+	ListExpiringLots(ctx context.Context, in *ListExpiringLotsRequest, opts ...grpc.CallOption) (*ListExpiringLotsResponse, error)
+	StocktakeOpen(ctx context.Context, in *StocktakeOpenRequest, opts ...grpc.CallOption) (*StocktakeOpenResponse, error)
+	StocktakeCount(ctx context.Context, in *StocktakeCountRequest, opts ...grpc.CallOption) (*StocktakeCountResponse, error)
+	StocktakeGet(ctx context.Context, in *StocktakeGetRequest, opts ...grpc.CallOption) (*StocktakeGetResponse, error)
+	StocktakeCommit(ctx context.Context, in *StocktakeCommitRequest, opts ...grpc.CallOption) (*StocktakeCommitResponse, error)
+	StockValuation(ctx context.Context, in *StockValuationRequest, opts ...grpc.CallOption) (*StockValuationResponse, error)
+	TopProductsByValue(ctx context.Context, in *TopProductsByValueRequest, opts ...grpc.CallOption) (*TopProductsByValueResponse, error)
+	ProductGetByBarcode(ctx context.Context, in *ProductGetByBarcodeRequest, opts ...grpc.CallOption) (*ProductGetByBarcodeResponse, error)
+	ProductTranslate(ctx context.Context, in *ProductTranslateRequest, opts ...grpc.CallOption) (*ProductTranslateResponse, error)
+	// UploadProductImage is not exposed by the gateway, upload with a gRPC client.
+	UploadProductImage(ctx context.Context, opts ...grpc.CallOption) (ApiService_UploadProductImageClient, error)
+	// DownloadProductImage is served by the gateway as raw content on
+	// GET /api/v1/users/{product_id}/images/{id}, see cmd/httpGateway.
+	DownloadProductImage(ctx context.Context, in *DownloadProductImageRequest, opts ...grpc.CallOption) (ApiService_DownloadProductImageClient, error)
+	ListProductImages(ctx context.Context, in *ListProductImagesRequest, opts ...grpc.CallOption) (*ListProductImagesResponse, error)
+	RelationCreate(ctx context.Context, in *RelationCreateRequest, opts ...grpc.CallOption) (*RelationCreateResponse, error)
+	RelationList(ctx context.Context, in *RelationListRequest, opts ...grpc.CallOption) (*RelationListResponse, error)
+	RelationUpdate(ctx context.Context, in *RelationUpdateRequest, opts ...grpc.CallOption) (*RelationUpdateResponse, error)
+	RelationDelete(ctx context.Context, in *RelationDeleteRequest, opts ...grpc.CallOption) (*RelationDeleteResponse, error)
+	GetRelatedProducts(ctx context.Context, in *GetRelatedProductsRequest, opts ...grpc.CallOption) (*GetRelatedProductsResponse, error)
+	ExchangeRateSet(ctx context.Context, in *ExchangeRateSetRequest, opts ...grpc.CallOption) (*ExchangeRateSetResponse, error)
+	ExchangeRateList(ctx context.Context, in *ExchangeRateListRequest, opts ...grpc.CallOption) (*ExchangeRateListResponse, error)
 }
 
 type apiServiceClient struct {
@@ -82,6 +118,351 @@ func (c *apiServiceClient) ProductDelete(ctx context.Context, in *ProductDeleteR
 	return out, nil
 }
 
+func (c *apiServiceClient) ProductTransition(ctx context.Context, in *ProductTransitionRequest, opts ...grpc.CallOption) (*ProductTransitionResponse, error) {
+	out := new(ProductTransitionResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.ApiService/ProductTransition", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) ApproveChange(ctx context.Context, in *ApproveChangeRequest, opts ...grpc.CallOption) (*ApproveChangeResponse, error) {
+	out := new(ApproveChangeResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.ApiService/ApproveChange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) RejectChange(ctx context.Context, in *RejectChangeRequest, opts ...grpc.CallOption) (*RejectChangeResponse, error) {
+	out := new(RejectChangeResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.ApiService/RejectChange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) ListLowStock(ctx context.Context, in *ListLowStockRequest, opts ...grpc.CallOption) (*ListLowStockResponse, error) {
+	out := new(ListLowStockResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.ApiService/ListLowStock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) SetReorderThreshold(ctx context.Context, in *SetReorderThresholdRequest, opts ...grpc.CallOption) (*SetReorderThresholdResponse, error) {
+	out := new(SetReorderThresholdResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.ApiService/SetReorderThreshold", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) SupplierCreate(ctx context.Context, in *SupplierCreateRequest, opts ...grpc.CallOption) (*SupplierCreateResponse, error) {
+	out := new(SupplierCreateResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.ApiService/SupplierCreate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) SupplierList(ctx context.Context, in *SupplierListRequest, opts ...grpc.CallOption) (*SupplierListResponse, error) {
+	out := new(SupplierListResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.ApiService/SupplierList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) PurchaseOrderCreate(ctx context.Context, in *PurchaseOrderCreateRequest, opts ...grpc.CallOption) (*PurchaseOrderCreateResponse, error) {
+	out := new(PurchaseOrderCreateResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.ApiService/PurchaseOrderCreate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) PurchaseOrderGet(ctx context.Context, in *PurchaseOrderGetRequest, opts ...grpc.CallOption) (*PurchaseOrderGetResponse, error) {
+	out := new(PurchaseOrderGetResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.ApiService/PurchaseOrderGet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) PurchaseOrderList(ctx context.Context, in *PurchaseOrderListRequest, opts ...grpc.CallOption) (*PurchaseOrderListResponse, error) {
+	out := new(PurchaseOrderListResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.ApiService/PurchaseOrderList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) PurchaseOrderReceive(ctx context.Context, in *PurchaseOrderReceiveRequest, opts ...grpc.CallOption) (*PurchaseOrderReceiveResponse, error) {
+	out := new(PurchaseOrderReceiveResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.ApiService/PurchaseOrderReceive", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) PlaceOrder(ctx context.Context, in *PlaceOrderRequest, opts ...grpc.CallOption) (*PlaceOrderResponse, error) {
+	out := new(PlaceOrderResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.ApiService/PlaceOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) LotAdd(ctx context.Context, in *LotAddRequest, opts ...grpc.CallOption) (*LotAddResponse, error) {
+	out := new(LotAddResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.ApiService/LotAdd", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) LotList(ctx context.Context, in *LotListRequest, opts ...grpc.CallOption) (*LotListResponse, error) {
+	out := new(LotListResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.ApiService/LotList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) ListExpiringLots(ctx context.Context, in *ListExpiringLotsRequest, opts ...grpc.CallOption) (*ListExpiringLotsResponse, error) {
+	out := new(ListExpiringLotsResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.ApiService/ListExpiringLots", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) StocktakeOpen(ctx context.Context, in *StocktakeOpenRequest, opts ...grpc.CallOption) (*StocktakeOpenResponse, error) {
+	out := new(StocktakeOpenResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.ApiService/StocktakeOpen", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) StocktakeCount(ctx context.Context, in *StocktakeCountRequest, opts ...grpc.CallOption) (*StocktakeCountResponse, error) {
+	out := new(StocktakeCountResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.ApiService/StocktakeCount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) StocktakeGet(ctx context.Context, in *StocktakeGetRequest, opts ...grpc.CallOption) (*StocktakeGetResponse, error) {
+	out := new(StocktakeGetResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.ApiService/StocktakeGet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) StocktakeCommit(ctx context.Context, in *StocktakeCommitRequest, opts ...grpc.CallOption) (*StocktakeCommitResponse, error) {
+	out := new(StocktakeCommitResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.ApiService/StocktakeCommit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) StockValuation(ctx context.Context, in *StockValuationRequest, opts ...grpc.CallOption) (*StockValuationResponse, error) {
+	out := new(StockValuationResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.ApiService/StockValuation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) TopProductsByValue(ctx context.Context, in *TopProductsByValueRequest, opts ...grpc.CallOption) (*TopProductsByValueResponse, error) {
+	out := new(TopProductsByValueResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.ApiService/TopProductsByValue", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) ProductGetByBarcode(ctx context.Context, in *ProductGetByBarcodeRequest, opts ...grpc.CallOption) (*ProductGetByBarcodeResponse, error) {
+	out := new(ProductGetByBarcodeResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.ApiService/ProductGetByBarcode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) ProductTranslate(ctx context.Context, in *ProductTranslateRequest, opts ...grpc.CallOption) (*ProductTranslateResponse, error) {
+	out := new(ProductTranslateResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.ApiService/ProductTranslate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) UploadProductImage(ctx context.Context, opts ...grpc.CallOption) (ApiService_UploadProductImageClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ApiService_ServiceDesc.Streams[0], "/api.v1.ApiService/UploadProductImage", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &apiServiceUploadProductImageClient{stream}
+	return x, nil
+}
+
+type ApiService_UploadProductImageClient interface {
+	Send(*UploadProductImageRequest) error
+	CloseAndRecv() (*UploadProductImageResponse, error)
+	grpc.ClientStream
+}
+
+type apiServiceUploadProductImageClient struct {
+	grpc.ClientStream
+}
+
+func (x *apiServiceUploadProductImageClient) Send(m *UploadProductImageRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *apiServiceUploadProductImageClient) CloseAndRecv() (*UploadProductImageResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadProductImageResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *apiServiceClient) DownloadProductImage(ctx context.Context, in *DownloadProductImageRequest, opts ...grpc.CallOption) (ApiService_DownloadProductImageClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ApiService_ServiceDesc.Streams[1], "/api.v1.ApiService/DownloadProductImage", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &apiServiceDownloadProductImageClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ApiService_DownloadProductImageClient interface {
+	Recv() (*DownloadProductImageResponse, error)
+	grpc.ClientStream
+}
+
+type apiServiceDownloadProductImageClient struct {
+	grpc.ClientStream
+}
+
+func (x *apiServiceDownloadProductImageClient) Recv() (*DownloadProductImageResponse, error) {
+	m := new(DownloadProductImageResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *apiServiceClient) ListProductImages(ctx context.Context, in *ListProductImagesRequest, opts ...grpc.CallOption) (*ListProductImagesResponse, error) {
+	out := new(ListProductImagesResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.ApiService/ListProductImages", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) RelationCreate(ctx context.Context, in *RelationCreateRequest, opts ...grpc.CallOption) (*RelationCreateResponse, error) {
+	out := new(RelationCreateResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.ApiService/RelationCreate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) RelationList(ctx context.Context, in *RelationListRequest, opts ...grpc.CallOption) (*RelationListResponse, error) {
+	out := new(RelationListResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.ApiService/RelationList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) RelationUpdate(ctx context.Context, in *RelationUpdateRequest, opts ...grpc.CallOption) (*RelationUpdateResponse, error) {
+	out := new(RelationUpdateResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.ApiService/RelationUpdate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) RelationDelete(ctx context.Context, in *RelationDeleteRequest, opts ...grpc.CallOption) (*RelationDeleteResponse, error) {
+	out := new(RelationDeleteResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.ApiService/RelationDelete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetRelatedProducts(ctx context.Context, in *GetRelatedProductsRequest, opts ...grpc.CallOption) (*GetRelatedProductsResponse, error) {
+	out := new(GetRelatedProductsResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.ApiService/GetRelatedProducts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) ExchangeRateSet(ctx context.Context, in *ExchangeRateSetRequest, opts ...grpc.CallOption) (*ExchangeRateSetResponse, error) {
+	out := new(ExchangeRateSetResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.ApiService/ExchangeRateSet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) ExchangeRateList(ctx context.Context, in *ExchangeRateListRequest, opts ...grpc.CallOption) (*ExchangeRateListResponse, error) {
+	out := new(ExchangeRateListResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.ApiService/ExchangeRateList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ApiServiceServer is the server API for ApiService service.
 // All implementations must embed UnimplementedApiServiceServer
 // for forward compatibility
@@ -91,6 +472,42 @@ type ApiServiceServer interface {
 	ProductCreate(context.Context, *ProductCreateRequest) (*ProductCreateResponse, error)
 	ProductUpdate(context.Context, *ProductUpdateRequest) (*ProductUpdateResponse, error)
 	ProductDelete(context.Context, *ProductDeleteRequest) (*ProductDeleteResponse, error)
+	ProductTransition(context.Context, *ProductTransitionRequest) (*ProductTransitionResponse, error)
+	ApproveChange(context.Context, *ApproveChangeRequest) (*ApproveChangeResponse, error)
+	RejectChange(context.Context, *RejectChangeRequest) (*RejectChangeResponse, error)
+	ListLowStock(context.Context, *ListLowStockRequest) (*ListLowStockResponse, error)
+	SetReorderThreshold(context.Context, *SetReorderThresholdRequest) (*SetReorderThresholdResponse, error)
+	SupplierCreate(context.Context, *SupplierCreateRequest) (*SupplierCreateResponse, error)
+	SupplierList(context.Context, *SupplierListRequest) (*SupplierListResponse, error)
+	PurchaseOrderCreate(context.Context, *PurchaseOrderCreateRequest) (*PurchaseOrderCreateResponse, error)
+	PurchaseOrderGet(context.Context, *PurchaseOrderGetRequest) (*PurchaseOrderGetResponse, error)
+	PurchaseOrderList(context.Context, *PurchaseOrderListRequest) (*PurchaseOrderListResponse, error)
+	PurchaseOrderReceive(context.Context, *PurchaseOrderReceiveRequest) (*PurchaseOrderReceiveResponse, error)
+	PlaceOrder(context.Context, *PlaceOrderRequest) (*PlaceOrderResponse, error)
+	LotAdd(context.Context, *LotAddRequest) (*LotAddResponse, error)
+	LotList(context.Context, *LotListRequest) (*LotListResponse, error)
+	ListExpiringLots(context.Context, *ListExpiringLotsRequest) (*ListExpiringLotsResponse, error)
+	StocktakeOpen(context.Context, *StocktakeOpenRequest) (*StocktakeOpenResponse, error)
+	StocktakeCount(context.Context, *StocktakeCountRequest) (*StocktakeCountResponse, error)
+	StocktakeGet(context.Context, *StocktakeGetRequest) (*StocktakeGetResponse, error)
+	StocktakeCommit(context.Context, *StocktakeCommitRequest) (*StocktakeCommitResponse, error)
+	StockValuation(context.Context, *StockValuationRequest) (*StockValuationResponse, error)
+	TopProductsByValue(context.Context, *TopProductsByValueRequest) (*TopProductsByValueResponse, error)
+	ProductGetByBarcode(context.Context, *ProductGetByBarcodeRequest) (*ProductGetByBarcodeResponse, error)
+	ProductTranslate(context.Context, *ProductTranslateRequest) (*ProductTranslateResponse, error)
+	// UploadProductImage is not exposed by the gateway, upload with a gRPC client.
+	UploadProductImage(ApiService_UploadProductImageServer) error
+	// DownloadProductImage is served by the gateway as raw content on
+	// GET /api/v1/users/{product_id}/images/{id}, see cmd/httpGateway.
+	DownloadProductImage(*DownloadProductImageRequest, ApiService_DownloadProductImageServer) error
+	ListProductImages(context.Context, *ListProductImagesRequest) (*ListProductImagesResponse, error)
+	RelationCreate(context.Context, *RelationCreateRequest) (*RelationCreateResponse, error)
+	RelationList(context.Context, *RelationListRequest) (*RelationListResponse, error)
+	RelationUpdate(context.Context, *RelationUpdateRequest) (*RelationUpdateResponse, error)
+	RelationDelete(context.Context, *RelationDeleteRequest) (*RelationDeleteResponse, error)
+	GetRelatedProducts(context.Context, *GetRelatedProductsRequest) (*GetRelatedProductsResponse, error)
+	ExchangeRateSet(context.Context, *ExchangeRateSetRequest) (*ExchangeRateSetResponse, error)
+	ExchangeRateList(context.Context, *ExchangeRateListRequest) (*ExchangeRateListResponse, error)
 	mustEmbedUnimplementedApiServiceServer()
 }
 
@@ -113,6 +530,105 @@ func (UnimplementedApiServiceServer) ProductUpdate(context.Context, *ProductUpda
 func (UnimplementedApiServiceServer) ProductDelete(context.Context, *ProductDeleteRequest) (*ProductDeleteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ProductDelete not implemented")
 }
+func (UnimplementedApiServiceServer) ProductTransition(context.Context, *ProductTransitionRequest) (*ProductTransitionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProductTransition not implemented")
+}
+func (UnimplementedApiServiceServer) ApproveChange(context.Context, *ApproveChangeRequest) (*ApproveChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveChange not implemented")
+}
+func (UnimplementedApiServiceServer) RejectChange(context.Context, *RejectChangeRequest) (*RejectChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectChange not implemented")
+}
+func (UnimplementedApiServiceServer) ListLowStock(context.Context, *ListLowStockRequest) (*ListLowStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLowStock not implemented")
+}
+func (UnimplementedApiServiceServer) SetReorderThreshold(context.Context, *SetReorderThresholdRequest) (*SetReorderThresholdResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetReorderThreshold not implemented")
+}
+func (UnimplementedApiServiceServer) SupplierCreate(context.Context, *SupplierCreateRequest) (*SupplierCreateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SupplierCreate not implemented")
+}
+func (UnimplementedApiServiceServer) SupplierList(context.Context, *SupplierListRequest) (*SupplierListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SupplierList not implemented")
+}
+func (UnimplementedApiServiceServer) PurchaseOrderCreate(context.Context, *PurchaseOrderCreateRequest) (*PurchaseOrderCreateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurchaseOrderCreate not implemented")
+}
+func (UnimplementedApiServiceServer) PurchaseOrderGet(context.Context, *PurchaseOrderGetRequest) (*PurchaseOrderGetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurchaseOrderGet not implemented")
+}
+func (UnimplementedApiServiceServer) PurchaseOrderList(context.Context, *PurchaseOrderListRequest) (*PurchaseOrderListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurchaseOrderList not implemented")
+}
+func (UnimplementedApiServiceServer) PurchaseOrderReceive(context.Context, *PurchaseOrderReceiveRequest) (*PurchaseOrderReceiveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurchaseOrderReceive not implemented")
+}
+func (UnimplementedApiServiceServer) PlaceOrder(context.Context, *PlaceOrderRequest) (*PlaceOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlaceOrder not implemented")
+}
+func (UnimplementedApiServiceServer) LotAdd(context.Context, *LotAddRequest) (*LotAddResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LotAdd not implemented")
+}
+func (UnimplementedApiServiceServer) LotList(context.Context, *LotListRequest) (*LotListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LotList not implemented")
+}
+func (UnimplementedApiServiceServer) ListExpiringLots(context.Context, *ListExpiringLotsRequest) (*ListExpiringLotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExpiringLots not implemented")
+}
+func (UnimplementedApiServiceServer) StocktakeOpen(context.Context, *StocktakeOpenRequest) (*StocktakeOpenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StocktakeOpen not implemented")
+}
+func (UnimplementedApiServiceServer) StocktakeCount(context.Context, *StocktakeCountRequest) (*StocktakeCountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StocktakeCount not implemented")
+}
+func (UnimplementedApiServiceServer) StocktakeGet(context.Context, *StocktakeGetRequest) (*StocktakeGetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StocktakeGet not implemented")
+}
+func (UnimplementedApiServiceServer) StocktakeCommit(context.Context, *StocktakeCommitRequest) (*StocktakeCommitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StocktakeCommit not implemented")
+}
+func (UnimplementedApiServiceServer) StockValuation(context.Context, *StockValuationRequest) (*StockValuationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StockValuation not implemented")
+}
+func (UnimplementedApiServiceServer) TopProductsByValue(context.Context, *TopProductsByValueRequest) (*TopProductsByValueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TopProductsByValue not implemented")
+}
+func (UnimplementedApiServiceServer) ProductGetByBarcode(context.Context, *ProductGetByBarcodeRequest) (*ProductGetByBarcodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProductGetByBarcode not implemented")
+}
+func (UnimplementedApiServiceServer) ProductTranslate(context.Context, *ProductTranslateRequest) (*ProductTranslateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProductTranslate not implemented")
+}
+func (UnimplementedApiServiceServer) UploadProductImage(ApiService_UploadProductImageServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadProductImage not implemented")
+}
+func (UnimplementedApiServiceServer) DownloadProductImage(*DownloadProductImageRequest, ApiService_DownloadProductImageServer) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadProductImage not implemented")
+}
+func (UnimplementedApiServiceServer) ListProductImages(context.Context, *ListProductImagesRequest) (*ListProductImagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProductImages not implemented")
+}
+func (UnimplementedApiServiceServer) RelationCreate(context.Context, *RelationCreateRequest) (*RelationCreateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RelationCreate not implemented")
+}
+func (UnimplementedApiServiceServer) RelationList(context.Context, *RelationListRequest) (*RelationListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RelationList not implemented")
+}
+func (UnimplementedApiServiceServer) RelationUpdate(context.Context, *RelationUpdateRequest) (*RelationUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RelationUpdate not implemented")
+}
+func (UnimplementedApiServiceServer) RelationDelete(context.Context, *RelationDeleteRequest) (*RelationDeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RelationDelete not implemented")
+}
+func (UnimplementedApiServiceServer) GetRelatedProducts(context.Context, *GetRelatedProductsRequest) (*GetRelatedProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRelatedProducts not implemented")
+}
+func (UnimplementedApiServiceServer) ExchangeRateSet(context.Context, *ExchangeRateSetRequest) (*ExchangeRateSetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExchangeRateSet not implemented")
+}
+func (UnimplementedApiServiceServer) ExchangeRateList(context.Context, *ExchangeRateListRequest) (*ExchangeRateListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExchangeRateList not implemented")
+}
 func (UnimplementedApiServiceServer) mustEmbedUnimplementedApiServiceServer() {}
 
 // UnsafeApiServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -216,9 +732,614 @@ func _ApiService_ProductDelete_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
-// ApiService_ServiceDesc is the grpc.ServiceDesc for ApiService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
+func _ApiService_ProductTransition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProductTransitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).ProductTransition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.ApiService/ProductTransition",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).ProductTransition(ctx, req.(*ProductTransitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_ApproveChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).ApproveChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.ApiService/ApproveChange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).ApproveChange(ctx, req.(*ApproveChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_RejectChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).RejectChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.ApiService/RejectChange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).RejectChange(ctx, req.(*RejectChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_ListLowStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLowStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).ListLowStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.ApiService/ListLowStock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).ListLowStock(ctx, req.(*ListLowStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_SetReorderThreshold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetReorderThresholdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).SetReorderThreshold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.ApiService/SetReorderThreshold",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).SetReorderThreshold(ctx, req.(*SetReorderThresholdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_SupplierCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SupplierCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).SupplierCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.ApiService/SupplierCreate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).SupplierCreate(ctx, req.(*SupplierCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_SupplierList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SupplierListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).SupplierList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.ApiService/SupplierList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).SupplierList(ctx, req.(*SupplierListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_PurchaseOrderCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurchaseOrderCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).PurchaseOrderCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.ApiService/PurchaseOrderCreate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).PurchaseOrderCreate(ctx, req.(*PurchaseOrderCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_PurchaseOrderGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurchaseOrderGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).PurchaseOrderGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.ApiService/PurchaseOrderGet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).PurchaseOrderGet(ctx, req.(*PurchaseOrderGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_PurchaseOrderList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurchaseOrderListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).PurchaseOrderList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.ApiService/PurchaseOrderList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).PurchaseOrderList(ctx, req.(*PurchaseOrderListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_PurchaseOrderReceive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurchaseOrderReceiveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).PurchaseOrderReceive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.ApiService/PurchaseOrderReceive",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).PurchaseOrderReceive(ctx, req.(*PurchaseOrderReceiveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_PlaceOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaceOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).PlaceOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.ApiService/PlaceOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).PlaceOrder(ctx, req.(*PlaceOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_LotAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LotAddRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).LotAdd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.ApiService/LotAdd",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).LotAdd(ctx, req.(*LotAddRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_LotList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LotListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).LotList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.ApiService/LotList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).LotList(ctx, req.(*LotListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_ListExpiringLots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExpiringLotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).ListExpiringLots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.ApiService/ListExpiringLots",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).ListExpiringLots(ctx, req.(*ListExpiringLotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_StocktakeOpen_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StocktakeOpenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).StocktakeOpen(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.ApiService/StocktakeOpen",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).StocktakeOpen(ctx, req.(*StocktakeOpenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_StocktakeCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StocktakeCountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).StocktakeCount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.ApiService/StocktakeCount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).StocktakeCount(ctx, req.(*StocktakeCountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_StocktakeGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StocktakeGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).StocktakeGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.ApiService/StocktakeGet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).StocktakeGet(ctx, req.(*StocktakeGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_StocktakeCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StocktakeCommitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).StocktakeCommit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.ApiService/StocktakeCommit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).StocktakeCommit(ctx, req.(*StocktakeCommitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_StockValuation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StockValuationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).StockValuation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.ApiService/StockValuation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).StockValuation(ctx, req.(*StockValuationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_TopProductsByValue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopProductsByValueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).TopProductsByValue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.ApiService/TopProductsByValue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).TopProductsByValue(ctx, req.(*TopProductsByValueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_ProductGetByBarcode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProductGetByBarcodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).ProductGetByBarcode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.ApiService/ProductGetByBarcode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).ProductGetByBarcode(ctx, req.(*ProductGetByBarcodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_ProductTranslate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProductTranslateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).ProductTranslate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.ApiService/ProductTranslate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).ProductTranslate(ctx, req.(*ProductTranslateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_UploadProductImage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ApiServiceServer).UploadProductImage(&apiServiceUploadProductImageServer{stream})
+}
+
+type ApiService_UploadProductImageServer interface {
+	SendAndClose(*UploadProductImageResponse) error
+	Recv() (*UploadProductImageRequest, error)
+	grpc.ServerStream
+}
+
+type apiServiceUploadProductImageServer struct {
+	grpc.ServerStream
+}
+
+func (x *apiServiceUploadProductImageServer) SendAndClose(m *UploadProductImageResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *apiServiceUploadProductImageServer) Recv() (*UploadProductImageRequest, error) {
+	m := new(UploadProductImageRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _ApiService_DownloadProductImage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadProductImageRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ApiServiceServer).DownloadProductImage(m, &apiServiceDownloadProductImageServer{stream})
+}
+
+type ApiService_DownloadProductImageServer interface {
+	Send(*DownloadProductImageResponse) error
+	grpc.ServerStream
+}
+
+type apiServiceDownloadProductImageServer struct {
+	grpc.ServerStream
+}
+
+func (x *apiServiceDownloadProductImageServer) Send(m *DownloadProductImageResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ApiService_ListProductImages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProductImagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).ListProductImages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.ApiService/ListProductImages",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).ListProductImages(ctx, req.(*ListProductImagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_RelationCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RelationCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).RelationCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.ApiService/RelationCreate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).RelationCreate(ctx, req.(*RelationCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_RelationList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RelationListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).RelationList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.ApiService/RelationList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).RelationList(ctx, req.(*RelationListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_RelationUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RelationUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).RelationUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.ApiService/RelationUpdate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).RelationUpdate(ctx, req.(*RelationUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_RelationDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RelationDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).RelationDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.ApiService/RelationDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).RelationDelete(ctx, req.(*RelationDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetRelatedProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRelatedProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetRelatedProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.ApiService/GetRelatedProducts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetRelatedProducts(ctx, req.(*GetRelatedProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_ExchangeRateSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExchangeRateSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).ExchangeRateSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.ApiService/ExchangeRateSet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).ExchangeRateSet(ctx, req.(*ExchangeRateSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_ExchangeRateList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExchangeRateListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).ExchangeRateList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.ApiService/ExchangeRateList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).ExchangeRateList(ctx, req.(*ExchangeRateListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ApiService_ServiceDesc is the grpc.ServiceDesc for ApiService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
 var ApiService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "api.v1.ApiService",
 	HandlerType: (*ApiServiceServer)(nil),
@@ -243,7 +1364,142 @@ var ApiService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ProductDelete",
 			Handler:    _ApiService_ProductDelete_Handler,
 		},
+		{
+			MethodName: "ProductTransition",
+			Handler:    _ApiService_ProductTransition_Handler,
+		},
+		{
+			MethodName: "ApproveChange",
+			Handler:    _ApiService_ApproveChange_Handler,
+		},
+		{
+			MethodName: "RejectChange",
+			Handler:    _ApiService_RejectChange_Handler,
+		},
+		{
+			MethodName: "ListLowStock",
+			Handler:    _ApiService_ListLowStock_Handler,
+		},
+		{
+			MethodName: "SetReorderThreshold",
+			Handler:    _ApiService_SetReorderThreshold_Handler,
+		},
+		{
+			MethodName: "SupplierCreate",
+			Handler:    _ApiService_SupplierCreate_Handler,
+		},
+		{
+			MethodName: "SupplierList",
+			Handler:    _ApiService_SupplierList_Handler,
+		},
+		{
+			MethodName: "PurchaseOrderCreate",
+			Handler:    _ApiService_PurchaseOrderCreate_Handler,
+		},
+		{
+			MethodName: "PurchaseOrderGet",
+			Handler:    _ApiService_PurchaseOrderGet_Handler,
+		},
+		{
+			MethodName: "PurchaseOrderList",
+			Handler:    _ApiService_PurchaseOrderList_Handler,
+		},
+		{
+			MethodName: "PurchaseOrderReceive",
+			Handler:    _ApiService_PurchaseOrderReceive_Handler,
+		},
+		{
+			MethodName: "PlaceOrder",
+			Handler:    _ApiService_PlaceOrder_Handler,
+		},
+		{
+			MethodName: "LotAdd",
+			Handler:    _ApiService_LotAdd_Handler,
+		},
+		{
+			MethodName: "LotList",
+			Handler:    _ApiService_LotList_Handler,
+		},
+		{
+			MethodName: "ListExpiringLots",
+			Handler:    _ApiService_ListExpiringLots_Handler,
+		},
+		{
+			MethodName: "StocktakeOpen",
+			Handler:    _ApiService_StocktakeOpen_Handler,
+		},
+		{
+			MethodName: "StocktakeCount",
+			Handler:    _ApiService_StocktakeCount_Handler,
+		},
+		{
+			MethodName: "StocktakeGet",
+			Handler:    _ApiService_StocktakeGet_Handler,
+		},
+		{
+			MethodName: "StocktakeCommit",
+			Handler:    _ApiService_StocktakeCommit_Handler,
+		},
+		{
+			MethodName: "StockValuation",
+			Handler:    _ApiService_StockValuation_Handler,
+		},
+		{
+			MethodName: "TopProductsByValue",
+			Handler:    _ApiService_TopProductsByValue_Handler,
+		},
+		{
+			MethodName: "ProductGetByBarcode",
+			Handler:    _ApiService_ProductGetByBarcode_Handler,
+		},
+		{
+			MethodName: "ProductTranslate",
+			Handler:    _ApiService_ProductTranslate_Handler,
+		},
+		{
+			MethodName: "ListProductImages",
+			Handler:    _ApiService_ListProductImages_Handler,
+		},
+		{
+			MethodName: "RelationCreate",
+			Handler:    _ApiService_RelationCreate_Handler,
+		},
+		{
+			MethodName: "RelationList",
+			Handler:    _ApiService_RelationList_Handler,
+		},
+		{
+			MethodName: "RelationUpdate",
+			Handler:    _ApiService_RelationUpdate_Handler,
+		},
+		{
+			MethodName: "RelationDelete",
+			Handler:    _ApiService_RelationDelete_Handler,
+		},
+		{
+			MethodName: "GetRelatedProducts",
+			Handler:    _ApiService_GetRelatedProducts_Handler,
+		},
+		{
+			MethodName: "ExchangeRateSet",
+			Handler:    _ApiService_ExchangeRateSet_Handler,
+		},
+		{
+			MethodName: "ExchangeRateList",
+			Handler:    _ApiService_ExchangeRateList_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadProductImage",
+			Handler:       _ApiService_UploadProductImage_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadProductImage",
+			Handler:       _ApiService_DownloadProductImage_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "v1/api.proto",
 }
@@ -3,7 +3,12 @@
 
 package config
 
-import "github.com/kelseyhightower/envconfig"
+import (
+	"github.com/kelseyhightower/envconfig"
+	"google.golang.org/grpc"
+	"homework-1/internal/certs"
+	"time"
+)
 
 const envPrefix = "QA"
 
@@ -15,6 +20,24 @@ type Config struct {
 	DBUser       string `split_words:"true" default:"postgres"`
 	DBPassword   string `split_words:"true" default:"postgres"`
 	DBName       string `split_words:"true" default:"postgres"`
+	Tenant       string `split_words:"true" default:"default"`
+	ApiKey       string `split_words:"true" default:"dev-key"`
+	// the services run with TLS unless config.TLSEnabled is off, paths are relative to the test packages
+	TLSEnabled  bool   `envconfig:"TLS_ENABLED" default:"true"`
+	TLSCAFile   string `envconfig:"TLS_CA_FILE" default:"../../certs/ca.crt"`
+	TLSCertFile string `envconfig:"TLS_CERT_FILE" default:"../../certs/service.crt"`
+	TLSKeyFile  string `envconfig:"TLS_KEY_FILE" default:"../../certs/service.key"`
+	// the storage tests sign the identity they call with like the api services do
+	ForwardingKeyFile string `envconfig:"FORWARDING_KEY_FILE" default:"../../config/forwarding.key"`
+}
+
+// DialOption connects to host with the TLS settings of the config.
+func (c *Config) DialOption(host string) (grpc.DialOption, error) {
+	transport, err := certs.NewTransport(c.TLSEnabled, certs.Files{CA: c.TLSCAFile, Cert: c.TLSCertFile, Key: c.TLSKeyFile}, time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	return transport.DialOption(host), nil
 }
 
 func FromEnv() (*Config, error) {
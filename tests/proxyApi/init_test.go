@@ -10,12 +10,16 @@ import (
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"homework-1/internal/auth"
+	"homework-1/internal/tenants"
 	"homework-1/tests/config"
 	"homework-1/tests/postgres"
 )
 
 var (
 	ProxyApiClient pbApi.ApiServiceClient
+	Ctx            context.Context
 	DB             *postgres.TDB
 )
 
@@ -25,11 +29,18 @@ func init() {
 		log.Fatal(err)
 	}
 
-	conn, err := grpc.Dial(cfg.ProxyApiHost, grpc.WithInsecure(), grpc.WithTimeout(3*time.Second))
+	dialOption, err := cfg.DialOption(cfg.ProxyApiHost)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	conn, err := grpc.Dial(cfg.ProxyApiHost, dialOption, grpc.WithTimeout(3*time.Second))
 	if err != nil {
 		log.Fatal(err)
 	}
 	ProxyApiClient = pbApi.NewApiServiceClient(conn)
 
+	Ctx = tenants.AppendToOutgoingContext(context.Background(), tenants.Tenant(cfg.Tenant))
+	Ctx = metadata.AppendToOutgoingContext(Ctx, auth.APIKeyMetadataKey, cfg.ApiKey)
 	DB = postgres.NewFromEnv(context.Background())
 }
@@ -4,7 +4,6 @@
 package proxyApi
 
 import (
-	"context"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	pbApi "homework-1/pkg/api/v1"
@@ -21,7 +20,7 @@ func TestTestProductListProxyApi(t *testing.T) {
 		pageSize := uint64(10)
 
 		//act
-		resp, err := ProxyApiClient.ProductList(context.Background(), &pbApi.ProductListRequest{Page: &pageNum, Size: &pageSize})
+		resp, err := ProxyApiClient.ProductList(Ctx, &pbApi.ProductListRequest{Page: &pageNum, Size: &pageSize})
 
 		//assert
 		require.NoError(t, err)
@@ -36,7 +35,7 @@ func TestTestProductListProxyApi(t *testing.T) {
 		pageNum := uint64(1)
 		pageSize := uint64(10)
 
-		newProduct, err := ProxyApiClient.ProductCreate(context.Background(), &pbApi.ProductCreateRequest{
+		newProduct, err := ProxyApiClient.ProductCreate(Ctx, &pbApi.ProductCreateRequest{
 			Name:     "product1",
 			Price:    uint64(1),
 			Quantity: uint64(1),
@@ -46,7 +45,7 @@ func TestTestProductListProxyApi(t *testing.T) {
 		}
 
 		//act
-		resp, err := ProxyApiClient.ProductList(context.Background(), &pbApi.ProductListRequest{Page: &pageNum, Size: &pageSize})
+		resp, err := ProxyApiClient.ProductList(Ctx, &pbApi.ProductListRequest{Page: &pageNum, Size: &pageSize})
 
 		//assert
 		require.NoError(t, err)
@@ -66,7 +65,7 @@ func TestProductGetProxyApi(t *testing.T) {
 		DB.SetUp(t)
 		defer DB.TearDown()
 
-		newProduct, err := ProxyApiClient.ProductCreate(context.Background(), &pbApi.ProductCreateRequest{
+		newProduct, err := ProxyApiClient.ProductCreate(Ctx, &pbApi.ProductCreateRequest{
 			Name:     "product1",
 			Price:    uint64(1),
 			Quantity: uint64(1),
@@ -76,7 +75,7 @@ func TestProductGetProxyApi(t *testing.T) {
 		}
 
 		//act
-		resp, err := ProxyApiClient.ProductGet(context.Background(), &pbApi.ProductGetRequest{Id: newProduct.Id})
+		resp, err := ProxyApiClient.ProductGet(Ctx, &pbApi.ProductGetRequest{Id: newProduct.Id})
 
 		//assert
 		require.NoError(t, err)
@@ -92,7 +91,7 @@ func TestProductGetProxyApi(t *testing.T) {
 		defer DB.TearDown()
 
 		//act
-		_, err := ProxyApiClient.ProductGet(context.Background(), &pbApi.ProductGetRequest{Id: 10})
+		_, err := ProxyApiClient.ProductGet(Ctx, &pbApi.ProductGetRequest{Id: 10})
 
 		//assert
 		assert.EqualError(t, err, "rpc error: code = NotFound desc = product not found")
@@ -106,7 +105,7 @@ func TestProductCreateProxyApi(t *testing.T) {
 		defer DB.TearDown()
 
 		//act
-		resp, err := ProxyApiClient.ProductCreate(context.Background(), &pbApi.ProductCreateRequest{
+		resp, err := ProxyApiClient.ProductCreate(Ctx, &pbApi.ProductCreateRequest{
 			Name:     "product1",
 			Price:    uint64(1),
 			Quantity: uint64(1),
@@ -125,7 +124,7 @@ func TestProductCreateProxyApi(t *testing.T) {
 		defer DB.TearDown()
 
 		//act
-		_, err := ProxyApiClient.ProductCreate(context.Background(), &pbApi.ProductCreateRequest{
+		_, err := ProxyApiClient.ProductCreate(Ctx, &pbApi.ProductCreateRequest{
 			Name:     "",
 			Price:    uint64(1),
 			Quantity: uint64(1),
@@ -141,7 +140,7 @@ func TestProductCreateProxyApi(t *testing.T) {
 		defer DB.TearDown()
 
 		//act
-		_, err := ProxyApiClient.ProductCreate(context.Background(), &pbApi.ProductCreateRequest{
+		_, err := ProxyApiClient.ProductCreate(Ctx, &pbApi.ProductCreateRequest{
 			Name:     "product1",
 			Price:    uint64(0),
 			Quantity: uint64(1),
@@ -157,7 +156,7 @@ func TestProductCreateProxyApi(t *testing.T) {
 		defer DB.TearDown()
 
 		//act
-		_, err := ProxyApiClient.ProductCreate(context.Background(), &pbApi.ProductCreateRequest{
+		_, err := ProxyApiClient.ProductCreate(Ctx, &pbApi.ProductCreateRequest{
 			Name:     "product1",
 			Price:    uint64(1),
 			Quantity: uint64(0),
@@ -173,7 +172,7 @@ func TestProductCreateProxyApi(t *testing.T) {
 		defer DB.TearDown()
 
 		//act
-		_, err := ProxyApiClient.ProductCreate(context.Background(), &pbApi.ProductCreateRequest{
+		_, err := ProxyApiClient.ProductCreate(Ctx, &pbApi.ProductCreateRequest{
 			Name:     "",
 			Price:    uint64(0),
 			Quantity: uint64(0),
@@ -191,7 +190,7 @@ func TestProductUpdateProxyApi(t *testing.T) {
 		DB.SetUp(t)
 		defer DB.TearDown()
 
-		newProduct, err := ProxyApiClient.ProductCreate(context.Background(), &pbApi.ProductCreateRequest{
+		newProduct, err := ProxyApiClient.ProductCreate(Ctx, &pbApi.ProductCreateRequest{
 			Name:     "product1",
 			Price:    uint64(1),
 			Quantity: uint64(1),
@@ -201,7 +200,7 @@ func TestProductUpdateProxyApi(t *testing.T) {
 		}
 
 		//act
-		resp, err := ProxyApiClient.ProductUpdate(context.Background(), &pbApi.ProductUpdateRequest{
+		resp, err := ProxyApiClient.ProductUpdate(Ctx, &pbApi.ProductUpdateRequest{
 			Id:       newProduct.Id,
 			Name:     "product2",
 			Price:    uint64(2),
@@ -222,7 +221,7 @@ func TestProductUpdateProxyApi(t *testing.T) {
 		defer DB.TearDown()
 
 		//act
-		_, err := ProxyApiClient.ProductUpdate(context.Background(), &pbApi.ProductUpdateRequest{
+		_, err := ProxyApiClient.ProductUpdate(Ctx, &pbApi.ProductUpdateRequest{
 			Id:       10,
 			Name:     "product2",
 			Price:    uint64(2),
@@ -238,7 +237,7 @@ func TestProductUpdateProxyApi(t *testing.T) {
 		DB.SetUp(t)
 		defer DB.TearDown()
 
-		newProduct, err := ProxyApiClient.ProductCreate(context.Background(), &pbApi.ProductCreateRequest{
+		newProduct, err := ProxyApiClient.ProductCreate(Ctx, &pbApi.ProductCreateRequest{
 			Name:     "product1",
 			Price:    uint64(1),
 			Quantity: uint64(1),
@@ -248,7 +247,7 @@ func TestProductUpdateProxyApi(t *testing.T) {
 		}
 
 		//act
-		_, err = ProxyApiClient.ProductUpdate(context.Background(), &pbApi.ProductUpdateRequest{
+		_, err = ProxyApiClient.ProductUpdate(Ctx, &pbApi.ProductUpdateRequest{
 			Id:       newProduct.Id,
 			Name:     "",
 			Price:    uint64(2),
@@ -264,7 +263,7 @@ func TestProductUpdateProxyApi(t *testing.T) {
 		DB.SetUp(t)
 		defer DB.TearDown()
 
-		newProduct, err := ProxyApiClient.ProductCreate(context.Background(), &pbApi.ProductCreateRequest{
+		newProduct, err := ProxyApiClient.ProductCreate(Ctx, &pbApi.ProductCreateRequest{
 			Name:     "product1",
 			Price:    uint64(1),
 			Quantity: uint64(1),
@@ -274,7 +273,7 @@ func TestProductUpdateProxyApi(t *testing.T) {
 		}
 
 		//act
-		_, err = ProxyApiClient.ProductUpdate(context.Background(), &pbApi.ProductUpdateRequest{
+		_, err = ProxyApiClient.ProductUpdate(Ctx, &pbApi.ProductUpdateRequest{
 			Id:       newProduct.Id,
 			Name:     "product2",
 			Price:    uint64(0),
@@ -290,7 +289,7 @@ func TestProductUpdateProxyApi(t *testing.T) {
 		DB.SetUp(t)
 		defer DB.TearDown()
 
-		newProduct, err := ProxyApiClient.ProductCreate(context.Background(), &pbApi.ProductCreateRequest{
+		newProduct, err := ProxyApiClient.ProductCreate(Ctx, &pbApi.ProductCreateRequest{
 			Name:     "product1",
 			Price:    uint64(1),
 			Quantity: uint64(1),
@@ -300,7 +299,7 @@ func TestProductUpdateProxyApi(t *testing.T) {
 		}
 
 		//act
-		_, err = ProxyApiClient.ProductUpdate(context.Background(), &pbApi.ProductUpdateRequest{
+		_, err = ProxyApiClient.ProductUpdate(Ctx, &pbApi.ProductUpdateRequest{
 			Id:       newProduct.Id,
 			Name:     "product2",
 			Price:    uint64(2),
@@ -316,7 +315,7 @@ func TestProductUpdateProxyApi(t *testing.T) {
 		DB.SetUp(t)
 		defer DB.TearDown()
 
-		newProduct, err := ProxyApiClient.ProductCreate(context.Background(), &pbApi.ProductCreateRequest{
+		newProduct, err := ProxyApiClient.ProductCreate(Ctx, &pbApi.ProductCreateRequest{
 			Name:     "product1",
 			Price:    uint64(1),
 			Quantity: uint64(1),
@@ -326,7 +325,7 @@ func TestProductUpdateProxyApi(t *testing.T) {
 		}
 
 		//act
-		_, err = ProxyApiClient.ProductUpdate(context.Background(), &pbApi.ProductUpdateRequest{
+		_, err = ProxyApiClient.ProductUpdate(Ctx, &pbApi.ProductUpdateRequest{
 			Id:       newProduct.Id,
 			Name:     "",
 			Price:    uint64(0),
@@ -345,7 +344,7 @@ func TestProductDeleteProxyApi(t *testing.T) {
 		DB.SetUp(t)
 		defer DB.TearDown()
 
-		newProduct, err := ProxyApiClient.ProductCreate(context.Background(), &pbApi.ProductCreateRequest{
+		newProduct, err := ProxyApiClient.ProductCreate(Ctx, &pbApi.ProductCreateRequest{
 			Name:     "product1",
 			Price:    uint64(1),
 			Quantity: uint64(1),
@@ -355,7 +354,7 @@ func TestProductDeleteProxyApi(t *testing.T) {
 		}
 
 		//act
-		_, err = ProxyApiClient.ProductDelete(context.Background(), &pbApi.ProductDeleteRequest{
+		_, err = ProxyApiClient.ProductDelete(Ctx, &pbApi.ProductDeleteRequest{
 			Id: newProduct.Id,
 		})
 
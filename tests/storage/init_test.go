@@ -10,12 +10,16 @@ import (
 	"time"
 
 	"google.golang.org/grpc"
+	"homework-1/internal/auth"
+	"homework-1/internal/policy"
+	"homework-1/internal/tenants"
 	"homework-1/tests/config"
 	"homework-1/tests/postgres"
 )
 
 var (
 	StorageClient pbStorage.StorageServiceClient
+	Ctx           context.Context
 	DB            *postgres.TDB
 )
 
@@ -25,11 +29,24 @@ func init() {
 		log.Fatal(err)
 	}
 
-	conn, err := grpc.Dial(cfg.StorageHost, grpc.WithInsecure(), grpc.WithTimeout(3*time.Second))
+	dialOption, err := cfg.DialOption(cfg.StorageHost)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	conn, err := grpc.Dial(cfg.StorageHost, dialOption, grpc.WithTimeout(3*time.Second))
 	if err != nil {
 		log.Fatal(err)
 	}
 	StorageClient = pbStorage.NewStorageServiceClient(conn)
 
+	Ctx = tenants.AppendToOutgoingContext(context.Background(), tenants.Tenant(cfg.Tenant))
+	// the storage service trusts the caller the api services forward, signed with the forwarding key
+	forwardingKey, err := auth.LoadForwardingKey(cfg.ForwardingKeyFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	identity := &auth.Identity{Subject: "integration-tests", Roles: []string{string(policy.Admin)}, Tenant: tenants.Tenant(cfg.Tenant)}
+	Ctx = auth.AppendToOutgoingContext(Ctx, forwardingKey, identity, time.Now().Add(time.Hour))
 	DB = postgres.NewFromEnv(context.Background())
 }
@@ -4,7 +4,6 @@
 package storage
 
 import (
-	"context"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"homework-1/internal/models/products"
@@ -23,7 +22,7 @@ func TestTestProductListStorage(t *testing.T) {
 		pageSize := uint64(10)
 
 		//act
-		stream, err := StorageClient.ProductList(context.Background(), &pbStorage.ProductListRequest{Page: &pageNum, Size: &pageSize})
+		stream, err := StorageClient.ProductList(Ctx, &pbStorage.ProductListRequest{Page: &pageNum, Size: &pageSize})
 
 		_, err = stream.Recv()
 
@@ -39,7 +38,7 @@ func TestTestProductListStorage(t *testing.T) {
 		pageNum := uint64(1)
 		pageSize := uint64(10)
 
-		newProduct, err := StorageClient.ProductCreate(context.Background(), &pbStorage.ProductCreateRequest{
+		newProduct, err := StorageClient.ProductCreate(Ctx, &pbStorage.ProductCreateRequest{
 			Name:     "product1",
 			Price:    uint64(1),
 			Quantity: uint64(1),
@@ -49,7 +48,7 @@ func TestTestProductListStorage(t *testing.T) {
 		}
 
 		//act
-		stream, err := StorageClient.ProductList(context.Background(), &pbStorage.ProductListRequest{Page: &pageNum, Size: &pageSize})
+		stream, err := StorageClient.ProductList(Ctx, &pbStorage.ProductListRequest{Page: &pageNum, Size: &pageSize})
 
 		var result []*products.Product
 		for {
@@ -86,7 +85,7 @@ func TestProductGetProxyApi(t *testing.T) {
 		DB.SetUp(t)
 		defer DB.TearDown()
 
-		newProduct, err := StorageClient.ProductCreate(context.Background(), &pbStorage.ProductCreateRequest{
+		newProduct, err := StorageClient.ProductCreate(Ctx, &pbStorage.ProductCreateRequest{
 			Name:     "product1",
 			Price:    uint64(1),
 			Quantity: uint64(1),
@@ -96,7 +95,7 @@ func TestProductGetProxyApi(t *testing.T) {
 		}
 
 		//act
-		resp, err := StorageClient.ProductGet(context.Background(), &pbStorage.ProductGetRequest{Id: newProduct.Id})
+		resp, err := StorageClient.ProductGet(Ctx, &pbStorage.ProductGetRequest{Id: newProduct.Id})
 
 		//assert
 		require.NoError(t, err)
@@ -112,7 +111,7 @@ func TestProductGetProxyApi(t *testing.T) {
 		defer DB.TearDown()
 
 		//act
-		_, err := StorageClient.ProductGet(context.Background(), &pbStorage.ProductGetRequest{Id: 10})
+		_, err := StorageClient.ProductGet(Ctx, &pbStorage.ProductGetRequest{Id: 10})
 
 		//assert
 		assert.EqualError(t, err, "rpc error: code = NotFound desc = 10: product does not exist")
@@ -126,7 +125,7 @@ func TestProductCreateProxyApi(t *testing.T) {
 		defer DB.TearDown()
 
 		//act
-		resp, err := StorageClient.ProductCreate(context.Background(), &pbStorage.ProductCreateRequest{
+		resp, err := StorageClient.ProductCreate(Ctx, &pbStorage.ProductCreateRequest{
 			Name:     "product1",
 			Price:    uint64(1),
 			Quantity: uint64(1),
@@ -146,7 +145,7 @@ func TestProductUpdateProxyApi(t *testing.T) {
 		DB.SetUp(t)
 		defer DB.TearDown()
 
-		newProduct, err := StorageClient.ProductCreate(context.Background(), &pbStorage.ProductCreateRequest{
+		newProduct, err := StorageClient.ProductCreate(Ctx, &pbStorage.ProductCreateRequest{
 			Name:     "product1",
 			Price:    uint64(1),
 			Quantity: uint64(1),
@@ -156,7 +155,7 @@ func TestProductUpdateProxyApi(t *testing.T) {
 		}
 
 		//act
-		resp, err := StorageClient.ProductUpdate(context.Background(), &pbStorage.ProductUpdateRequest{
+		resp, err := StorageClient.ProductUpdate(Ctx, &pbStorage.ProductUpdateRequest{
 			Id:       newProduct.Id,
 			Name:     "product2",
 			Price:    uint64(2),
@@ -177,7 +176,7 @@ func TestProductUpdateProxyApi(t *testing.T) {
 		defer DB.TearDown()
 
 		//act
-		_, err := StorageClient.ProductUpdate(context.Background(), &pbStorage.ProductUpdateRequest{
+		_, err := StorageClient.ProductUpdate(Ctx, &pbStorage.ProductUpdateRequest{
 			Id:       10,
 			Name:     "product2",
 			Price:    uint64(2),
@@ -195,7 +194,7 @@ func TestProductDeleteProxyApi(t *testing.T) {
 		DB.SetUp(t)
 		defer DB.TearDown()
 
-		newProduct, err := StorageClient.ProductCreate(context.Background(), &pbStorage.ProductCreateRequest{
+		newProduct, err := StorageClient.ProductCreate(Ctx, &pbStorage.ProductCreateRequest{
 			Name:     "product1",
 			Price:    uint64(1),
 			Quantity: uint64(1),
@@ -205,7 +204,7 @@ func TestProductDeleteProxyApi(t *testing.T) {
 		}
 
 		//act
-		_, err = StorageClient.ProductDelete(context.Background(), &pbStorage.ProductDeleteRequest{
+		_, err = StorageClient.ProductDelete(Ctx, &pbStorage.ProductDeleteRequest{
 			Id: newProduct.Id,
 		})
 